@@ -1,865 +0,0 @@
-// Code generated by swaggo/swag. DO NOT EDIT.
-
-package swagger
-
-import "github.com/swaggo/swag"
-
-const docTemplate = `{
-    "schemes": {{ marshal .Schemes }},
-    "swagger": "2.0",
-    "info": {
-        "description": "{{escape .Description}}",
-        "title": "{{.Title}}",
-        "termsOfService": "http://swagger.io/terms/",
-        "contact": {
-            "name": "API Support",
-            "url": "http://www.swagger.io/support",
-            "email": "support@swagger.io"
-        },
-        "license": {
-            "name": "MIT",
-            "url": "https://opensource.org/licenses/MIT"
-        },
-        "version": "{{.Version}}"
-    },
-    "host": "{{.Host}}",
-    "basePath": "{{.BasePath}}",
-    "paths": {
-        "/costs/calculate": {
-            "get": {
-                "description": "Calculate total cost of subscriptions for a given period with optional filtering",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "costs"
-                ],
-                "summary": "Calculate total subscription cost",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "User ID filter",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Service name filter",
-                        "name": "service_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (MM-YYYY format)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (MM-YYYY format)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.CostSummaryResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ValidationErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Get overall health status of the service and its dependencies",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.HealthResponse"
-                        }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.HealthResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/health/live": {
-            "get": {
-                "description": "Check if service is alive",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Liveness check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/health/ready": {
-            "get": {
-                "description": "Check if service is ready to accept traffic",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Readiness check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/subscriptions": {
-            "get": {
-                "description": "Get list of subscriptions with optional filtering",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "subscriptions"
-                ],
-                "summary": "List subscriptions",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "User ID filter",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Service name filter",
-                        "name": "service_name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date filter (MM-YYYY format)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date filter (MM-YYYY format)",
-                        "name": "end_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Limit number of results",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.SubscriptionsListResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Create a new subscription for a user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "subscriptions"
-                ],
-                "summary": "Create a new subscription",
-                "parameters": [
-                    {
-                        "description": "Subscription data",
-                        "name": "subscription",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_request.CreateSubscriptionRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.SubscriptionResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ValidationErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/subscriptions/{id}": {
-            "get": {
-                "description": "Get a single subscription by its ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "subscriptions"
-                ],
-                "summary": "Get subscription by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Subscription ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.SubscriptionResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "description": "Update an existing subscription",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "subscriptions"
-                ],
-                "summary": "Update subscription",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Subscription ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated subscription data",
-                        "name": "subscription",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_request.UpdateSubscriptionRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.SubscriptionResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ValidationErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete a subscription by ID",
-                "tags": [
-                    "subscriptions"
-                ],
-                "summary": "Delete subscription",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Subscription ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.MessageResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/users/{user_id}/subscriptions": {
-            "get": {
-                "description": "Get all subscriptions for a specific user",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "subscriptions"
-                ],
-                "summary": "Get user subscriptions",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Limit number of results",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.SubscriptionsListResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/users/{user_id}/subscriptions/stats": {
-            "get": {
-                "description": "Get total number of subscriptions for a user",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "subscriptions"
-                ],
-                "summary": "Get user subscription statistics",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.StatsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_request.CreateSubscriptionRequest": {
-            "type": "object",
-            "required": [
-                "price",
-                "service_name",
-                "start_date",
-                "user_id"
-            ],
-            "properties": {
-                "end_date": {
-                    "type": "string",
-                    "example": "12-2025"
-                },
-                "price": {
-                    "type": "integer",
-                    "maximum": 1000000,
-                    "minimum": 1,
-                    "example": 400
-                },
-                "service_name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "Yandex Plus"
-                },
-                "start_date": {
-                    "type": "string",
-                    "example": "07-2025"
-                },
-                "user_id": {
-                    "type": "string",
-                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_request.UpdateSubscriptionRequest": {
-            "type": "object",
-            "properties": {
-                "end_date": {
-                    "type": "string",
-                    "example": "12-2025"
-                },
-                "price": {
-                    "type": "integer",
-                    "maximum": 1000000,
-                    "minimum": 1,
-                    "example": 799
-                },
-                "service_name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "Netflix Premium"
-                },
-                "start_date": {
-                    "type": "string",
-                    "example": "08-2025"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.CostSummaryResponse": {
-            "type": "object",
-            "properties": {
-                "currency": {
-                    "type": "string",
-                    "example": "RUB"
-                },
-                "period": {
-                    "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.PeriodResponse"
-                },
-                "total_cost": {
-                    "type": "integer",
-                    "example": 2400
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorDetail": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "INVALID_INPUT"
-                },
-                "details": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Invalid input provided"
-                },
-                "request_id": {
-                    "type": "string",
-                    "example": "20250115103000-abc123"
-                },
-                "timestamp": {
-                    "type": "string",
-                    "example": "2025-01-15T10:30:00Z"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorDetail"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.HealthResponse": {
-            "type": "object",
-            "properties": {
-                "services": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "status": {
-                    "type": "string"
-                },
-                "timestamp": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.MessageResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.PaginationResponse": {
-            "type": "object",
-            "properties": {
-                "has_more": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "limit": {
-                    "type": "integer",
-                    "example": 20
-                },
-                "offset": {
-                    "type": "integer",
-                    "example": 0
-                },
-                "total": {
-                    "type": "integer",
-                    "example": 150
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.PeriodResponse": {
-            "type": "object",
-            "properties": {
-                "end_date": {
-                    "type": "string",
-                    "example": "06-2025"
-                },
-                "start_date": {
-                    "type": "string",
-                    "example": "01-2025"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.StatsResponse": {
-            "type": "object",
-            "properties": {
-                "total_subscriptions": {
-                    "type": "integer"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.SubscriptionResponse": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-01-15T10:30:00Z"
-                },
-                "end_date": {
-                    "type": "string",
-                    "example": "12-2025"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
-                },
-                "price": {
-                    "type": "integer",
-                    "example": 400
-                },
-                "service_name": {
-                    "type": "string",
-                    "example": "Yandex Plus"
-                },
-                "start_date": {
-                    "type": "string",
-                    "example": "07-2025"
-                },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2025-01-15T10:30:00Z"
-                },
-                "user_id": {
-                    "type": "string",
-                    "example": "60601fee-2bf1-4721-ae6f-7636e79a0cba"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.SubscriptionsListResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.SubscriptionResponse"
-                    }
-                },
-                "pagination": {
-                    "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.PaginationResponse"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ValidationError": {
-            "type": "object",
-            "properties": {
-                "field": {
-                    "type": "string",
-                    "example": "price"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "must be greater than 0"
-                },
-                "value": {
-                    "type": "string",
-                    "example": "-100"
-                }
-            }
-        },
-        "github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ValidationErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ErrorDetail"
-                },
-                "validation_errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_vagonaizer_effective-mobile_subscription-service_internal_transport_http_dto_response.ValidationError"
-                    }
-                }
-            }
-        }
-    },
-    "securityDefinitions": {
-        "BearerAuth": {
-            "description": "Type \"Bearer\" followed by a space and JWT token.",
-            "type": "apiKey",
-            "name": "Authorization",
-            "in": "header"
-        }
-    },
-    "tags": [
-        {
-            "description": "Subscription management operations",
-            "name": "subscriptions"
-        },
-        {
-            "description": "Health check operations",
-            "name": "health"
-        },
-        {
-            "description": "Cost calculation operations",
-            "name": "costs"
-        }
-    ]
-}`
-
-// SwaggerInfo holds exported Swagger Info so clients can modify it
-var SwaggerInfo = &swag.Spec{
-	Version:          "1.0",
-	Host:             "localhost:8080",
-	BasePath:         "/api/v1",
-	Schemes:          []string{"http", "https"},
-	Title:            "Subscription Service API",
-	Description:      "REST API for managing user subscriptions",
-	InfoInstanceName: "swagger",
-	SwaggerTemplate:  docTemplate,
-}
-
-func init() {
-	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
-}
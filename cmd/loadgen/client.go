@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	apiPrefix    = "/api/v1"
+	apiKeyHeader = "X-API-Key"
+)
+
+// client is a minimal JSON client. Unlike subctl it keeps connections warm
+// and never retries, so measured latency is that of a single round trip.
+type client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+func newClient(server, apiKey string, timeout time.Duration) *client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 1024
+	transport.MaxIdleConnsPerHost = 1024
+
+	return &client{
+		baseURL: strings.TrimRight(server, "/") + apiPrefix,
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+// do sends the request and decodes a successful body into out. The returned
+// status is zero when the request did not reach the server.
+func (c *client) do(ctx context.Context, method, path string, body, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		// Тело дочитываем, чтобы соединение вернулось в пул.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, fmt.Errorf("%s %s: server returned %s", method, path, resp.Status)
+	}
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
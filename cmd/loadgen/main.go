@@ -0,0 +1,116 @@
+// Command loadgen seeds users with subscriptions through the API and then
+// fires a fixed request rate at it, reporting latency percentiles per
+// scenario. It is meant for reproducible before/after benchmarks of
+// repository and caching changes.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultServer = "http://localhost:8080"
+	defaultMix    = "get=40,list=30,user=20,cost=10"
+)
+
+func main() {
+	var (
+		server      = flag.String("server", defaultServer, "service base URL (env LOADGEN_SERVER)")
+		apiKey      = flag.String("api-key", "", "API key sent as X-API-Key (env LOADGEN_API_KEY)")
+		users       = flag.Int("users", 50, "number of users to seed")
+		perUser     = flag.Int("subs-per-user", 10, "subscriptions seeded per user")
+		seedWorkers = flag.Int("seed-workers", 8, "concurrent requests while seeding")
+		skipSeed    = flag.Bool("skip-seed", false, "do not seed, load existing data through list requests only")
+		cleanup     = flag.Bool("cleanup", false, "delete seeded subscriptions when the run finishes")
+		rps         = flag.Int("rps", 100, "target requests per second")
+		duration    = flag.Duration("duration", 30*time.Second, "length of the load phase")
+		workers     = flag.Int("workers", 32, "maximum requests in flight")
+		timeout     = flag.Duration("timeout", 10*time.Second, "per-request timeout")
+		mix         = flag.String("mix", defaultMix, "weighted scenarios: get, list, user, cost, create")
+		seed        = flag.Int64("seed", 1, "random seed, the same seed produces the same data and request order")
+		jsonOutput  = flag.Bool("json", false, "print the report as JSON")
+	)
+	flag.Parse()
+
+	if v, ok := os.LookupEnv("LOADGEN_SERVER"); ok && !isFlagSet("server") {
+		*server = v
+	}
+	if v, ok := os.LookupEnv("LOADGEN_API_KEY"); ok && !isFlagSet("api-key") {
+		*apiKey = v
+	}
+
+	scenarios, err := parseMix(*mix)
+	if err != nil {
+		log.Fatalf("invalid -mix: %v", err)
+	}
+	if *rps <= 0 || *workers <= 0 || *duration <= 0 {
+		log.Fatalf("-rps, -workers and -duration must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	rng := rand.New(rand.NewSource(*seed))
+	c := newClient(*server, *apiKey, *timeout)
+
+	var fixture *fixture
+	if *skipSeed {
+		log.Printf("skipping seed, discovering existing subscriptions")
+		fixture, err = discover(ctx, c)
+	} else {
+		log.Printf("seeding %d users x %d subscriptions", *users, *perUser)
+		fixture, err = seedFixture(ctx, c, rng, seedOptions{
+			Users:   *users,
+			PerUser: *perUser,
+			Workers: *seedWorkers,
+		})
+	}
+	if err != nil {
+		log.Fatalf("failed to prepare data: %v", err)
+	}
+	log.Printf("fixture ready: %d users, %d subscriptions", len(fixture.UserIDs), len(fixture.SubscriptionIDs))
+
+	if *cleanup && !*skipSeed {
+		defer func() {
+			log.Printf("deleting seeded subscriptions")
+			if err := fixture.cleanup(context.Background(), c); err != nil {
+				log.Printf("cleanup failed: %v", err)
+			}
+		}()
+	}
+
+	log.Printf("running %s at %d rps, mix %s", *duration, *rps, *mix)
+	results := run(ctx, c, fixture, rng, runOptions{
+		RPS:       *rps,
+		Duration:  *duration,
+		Workers:   *workers,
+		Scenarios: scenarios,
+	})
+
+	rep := buildReport(results)
+	if *jsonOutput {
+		err = rep.writeJSON(os.Stdout)
+	} else {
+		err = rep.writeTable(os.Stdout)
+	}
+	if err != nil {
+		log.Fatalf("failed to print report: %v", err)
+	}
+}
+
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
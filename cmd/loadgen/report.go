@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// scenarioReport holds the latency distribution of one scenario. Failed
+// requests count towards latency too: a slow 500 is still a slow response.
+type scenarioReport struct {
+	Scenario string  `json:"scenario"`
+	Requests int     `json:"requests"`
+	Errors   int     `json:"errors"`
+	Dropped  int     `json:"dropped"`
+	P50Ms    float64 `json:"p50_ms"`
+	P90Ms    float64 `json:"p90_ms"`
+	P99Ms    float64 `json:"p99_ms"`
+	MaxMs    float64 `json:"max_ms"`
+}
+
+type report struct {
+	Scenarios []scenarioReport `json:"scenarios"`
+	Total     scenarioReport   `json:"total"`
+	Statuses  map[int]int      `json:"statuses"`
+}
+
+func buildReport(results []result) report {
+	byScenario := make(map[string][]result)
+	for _, r := range results {
+		byScenario[r.scenario] = append(byScenario[r.scenario], r)
+	}
+
+	rep := report{Statuses: make(map[int]int)}
+	for _, r := range results {
+		if !r.dropped {
+			rep.Statuses[r.status]++
+		}
+	}
+
+	for _, name := range sortedKeys(byScenario) {
+		rep.Scenarios = append(rep.Scenarios, summarize(name, byScenario[name]))
+	}
+	rep.Total = summarize("total", results)
+	return rep
+}
+
+func summarize(name string, results []result) scenarioReport {
+	s := scenarioReport{Scenario: name}
+
+	latencies := make([]time.Duration, 0, len(results))
+	for _, r := range results {
+		if r.dropped {
+			s.Dropped++
+			continue
+		}
+		s.Requests++
+		if r.err != nil {
+			s.Errors++
+		}
+		latencies = append(latencies, r.latency)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	s.P50Ms = millis(percentile(latencies, 50))
+	s.P90Ms = millis(percentile(latencies, 90))
+	s.P99Ms = millis(percentile(latencies, 99))
+	if len(latencies) > 0 {
+		s.MaxMs = millis(latencies[len(latencies)-1])
+	}
+	return s
+}
+
+// percentile uses the nearest-rank method on an ascending slice.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func sortedKeys(m map[string][]result) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r report) writeJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func (r report) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCENARIO\tREQUESTS\tERRORS\tDROPPED\tP50 MS\tP90 MS\tP99 MS\tMAX MS")
+	for _, s := range append(r.Scenarios, r.Total) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			s.Scenario, s.Requests, s.Errors, s.Dropped, s.P50Ms, s.P90Ms, s.P99Ms, s.MaxMs)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	codes := make([]int, 0, len(r.Statuses))
+	for code := range r.Statuses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		label := fmt.Sprint(code)
+		if code == 0 {
+			label = "transport error"
+		}
+		if _, err := fmt.Fprintf(w, "status %s: %d\n", label, r.Statuses[code]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// call is one prepared request. Parameters are chosen by the dispatcher so
+// that the request sequence depends only on the seed.
+type call struct {
+	scenario string
+	method   string
+	path     string
+	body     any
+}
+
+type scenarioFunc func(rng *rand.Rand, f *fixture) call
+
+var scenarioBuilders = map[string]scenarioFunc{
+	"get": func(rng *rand.Rand, f *fixture) call {
+		id := f.SubscriptionIDs[rng.Intn(len(f.SubscriptionIDs))]
+		return call{method: http.MethodGet, path: "/subscriptions/" + url.PathEscape(id)}
+	},
+	"list": func(rng *rand.Rand, f *fixture) call {
+		query := url.Values{}
+		query.Set("limit", "20")
+		query.Set("offset", strconv.Itoa(rng.Intn(5)*20))
+		if rng.Intn(2) == 0 {
+			query.Set("service_name", serviceNames[rng.Intn(len(serviceNames))])
+		}
+		return call{method: http.MethodGet, path: "/subscriptions/?" + query.Encode()}
+	},
+	"user": func(rng *rand.Rand, f *fixture) call {
+		userID := f.UserIDs[rng.Intn(len(f.UserIDs))]
+		return call{method: http.MethodGet, path: "/users/" + url.PathEscape(userID) + "/subscriptions?limit=20"}
+	},
+	"cost": func(rng *rand.Rand, f *fixture) call {
+		query := url.Values{}
+		query.Set("start_date", "01-2024")
+		query.Set("end_date", "12-2025")
+		if rng.Intn(2) == 0 {
+			query.Set("user_id", f.UserIDs[rng.Intn(len(f.UserIDs))])
+		}
+		return call{method: http.MethodGet, path: "/costs/calculate?" + query.Encode()}
+	},
+	"create": func(rng *rand.Rand, f *fixture) call {
+		userID := f.UserIDs[rng.Intn(len(f.UserIDs))]
+		return call{method: http.MethodPost, path: "/subscriptions/", body: randomSubscription(rng, userID)}
+	},
+}
+
+type weightedScenario struct {
+	name   string
+	weight int
+}
+
+// parseMix reads "get=40,list=30" into weighted scenarios.
+func parseMix(mix string) ([]weightedScenario, error) {
+	var scenarios []weightedScenario
+	for _, part := range strings.Split(mix, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, rawWeight, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q: expected name=weight", part)
+		}
+		if _, known := scenarioBuilders[name]; !known {
+			return nil, fmt.Errorf("unknown scenario %q, use one of: %s", name, strings.Join(scenarioNames(), ", "))
+		}
+		weight, err := strconv.Atoi(rawWeight)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("%q: weight must be a non-negative integer", part)
+		}
+		if weight > 0 {
+			scenarios = append(scenarios, weightedScenario{name: name, weight: weight})
+		}
+	}
+
+	if len(scenarios) == 0 {
+		return nil, fmt.Errorf("no scenarios with positive weight")
+	}
+	return scenarios, nil
+}
+
+func scenarioNames() []string {
+	names := make([]string, 0, len(scenarioBuilders))
+	for name := range scenarioBuilders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func pickScenario(rng *rand.Rand, scenarios []weightedScenario) string {
+	total := 0
+	for _, s := range scenarios {
+		total += s.weight
+	}
+
+	n := rng.Intn(total)
+	for _, s := range scenarios {
+		if n < s.weight {
+			return s.name
+		}
+		n -= s.weight
+	}
+	return scenarios[len(scenarios)-1].name
+}
+
+type runOptions struct {
+	RPS       int
+	Duration  time.Duration
+	Workers   int
+	Scenarios []weightedScenario
+}
+
+// result is the outcome of one scheduled request. Dropped requests never
+// left the generator because every worker was busy; they are reported
+// separately so an overloaded server is not hidden by a slower send rate.
+type result struct {
+	scenario string
+	latency  time.Duration
+	status   int
+	err      error
+	dropped  bool
+}
+
+// run dispatches requests at a fixed rate (open model): the schedule does
+// not wait for slow responses, so server stalls show up as latency and drops.
+func run(ctx context.Context, c *client, f *fixture, rng *rand.Rand, opts runOptions) []result {
+	ctx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second / time.Duration(opts.RPS))
+	defer ticker.Stop()
+
+	var (
+		mu      sync.Mutex
+		results = make([]result, 0, opts.RPS*int(opts.Duration/time.Second+1))
+		wg      sync.WaitGroup
+		slots   = make(chan struct{}, opts.Workers)
+	)
+	record := func(r result) {
+		mu.Lock()
+		results = append(results, r)
+		mu.Unlock()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return results
+		case <-ticker.C:
+		}
+
+		name := pickScenario(rng, opts.Scenarios)
+		next := scenarioBuilders[name](rng, f)
+		next.scenario = name
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			record(result{scenario: name, dropped: true})
+			continue
+		}
+
+		wg.Add(1)
+		go func(next call) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			// Запрос не привязан к ctx фазы, чтобы хвост не обрывался по её окончании.
+			started := time.Now()
+			status, err := c.do(context.Background(), next.method, next.path, next.body, nil)
+			record(result{
+				scenario: next.scenario,
+				latency:  time.Since(started),
+				status:   status,
+				err:      err,
+			})
+		}(next)
+	}
+}
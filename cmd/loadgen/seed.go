@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+var serviceNames = []string{
+	"Yandex Plus", "Netflix", "Spotify", "YouTube Premium", "Kinopoisk",
+	"iCloud", "Google One", "Dropbox", "GitHub Copilot", "JetBrains",
+}
+
+// fixture is the data set the load phase draws request parameters from.
+type fixture struct {
+	UserIDs         []string
+	SubscriptionIDs []string
+}
+
+type seedOptions struct {
+	Users   int
+	PerUser int
+	Workers int
+}
+
+// seedFixture creates the subscriptions through the public API, so the data
+// passes the same validation and events as production writes. Request
+// bodies are generated up front from rng, which keeps the data set
+// deterministic regardless of worker scheduling.
+func seedFixture(ctx context.Context, c *client, rng *rand.Rand, opts seedOptions) (*fixture, error) {
+	if opts.Users <= 0 || opts.PerUser <= 0 {
+		return nil, errors.New("-users and -subs-per-user must be positive")
+	}
+
+	f := &fixture{UserIDs: make([]string, 0, opts.Users)}
+	requests := make([]request.CreateSubscriptionRequest, 0, opts.Users*opts.PerUser)
+	for i := 0; i < opts.Users; i++ {
+		userID := uuid.Must(uuid.NewRandomFromReader(rng)).String()
+		f.UserIDs = append(f.UserIDs, userID)
+		for j := 0; j < opts.PerUser; j++ {
+			requests = append(requests, randomSubscription(rng, userID))
+		}
+	}
+
+	jobs := make(chan request.CreateSubscriptionRequest)
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < max(opts.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range jobs {
+				var created response.SubscriptionResponse
+				_, err := c.do(ctx, http.MethodPost, "/subscriptions/", req, &created)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				if err == nil {
+					f.SubscriptionIDs = append(f.SubscriptionIDs, created.ID)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+send:
+	for _, req := range requests {
+		select {
+		case jobs <- req:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return f, fmt.Errorf("seeding stopped after %d subscriptions: %w", len(f.SubscriptionIDs), firstErr)
+	}
+	return f, ctx.Err()
+}
+
+func randomSubscription(rng *rand.Rand, userID string) request.CreateSubscriptionRequest {
+	startMonth := 1 + rng.Intn(12)
+	startYear := 2023 + rng.Intn(3)
+
+	req := request.CreateSubscriptionRequest{
+		ServiceName: serviceNames[rng.Intn(len(serviceNames))],
+		Price:       100 + rng.Intn(20)*50,
+		UserID:      userID,
+		StartDate:   fmt.Sprintf("%02d-%d", startMonth, startYear),
+	}
+
+	// Примерно у половины подписок есть дата окончания.
+	if rng.Intn(2) == 0 {
+		months := 1 + rng.Intn(24)
+		endMonth := startMonth + months
+		req.EndDate = fmt.Sprintf("%02d-%d", (endMonth-1)%12+1, startYear+(endMonth-1)/12)
+	}
+	return req
+}
+
+// discover builds a fixture from subscriptions that already exist, for runs
+// against a pre-seeded database.
+func discover(ctx context.Context, c *client) (*fixture, error) {
+	query := url.Values{}
+	query.Set("limit", "100")
+
+	var list response.SubscriptionsListResponse
+	if _, err := c.do(ctx, http.MethodGet, "/subscriptions/?"+query.Encode(), nil, &list); err != nil {
+		return nil, err
+	}
+	if len(list.Data) == 0 {
+		return nil, errors.New("no subscriptions found, run without -skip-seed first")
+	}
+
+	f := &fixture{}
+	seen := make(map[string]bool)
+	for _, sub := range list.Data {
+		f.SubscriptionIDs = append(f.SubscriptionIDs, sub.ID)
+		if !seen[sub.UserID] {
+			seen[sub.UserID] = true
+			f.UserIDs = append(f.UserIDs, sub.UserID)
+		}
+	}
+	return f, nil
+}
+
+// cleanup removes every seeded user's subscriptions, including ones made by
+// the create scenario during the load phase.
+func (f *fixture) cleanup(ctx context.Context, c *client) error {
+	var errs []error
+	for _, userID := range f.UserIDs {
+		if _, err := c.do(ctx, http.MethodDelete, "/users/"+url.PathEscape(userID)+"/subscriptions", nil, nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
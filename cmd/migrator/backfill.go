@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+)
+
+const backfillStartKey = "00000000-0000-0000-0000-000000000000"
+
+// backfill describes a resumable batched data migration. Batch receives the
+// last processed key ($1) and the batch size ($2) and must return the last key
+// of the scanned batch (NULL when nothing is left), the number of rows scanned
+// and the number of rows changed.
+type backfill struct {
+	Name        string
+	Description string
+	Table       string
+	Batch       string
+}
+
+var backfills = map[string]backfill{}
+
+func registerBackfill(b backfill) {
+	backfills[b.Name] = b
+}
+
+// columnBackfill builds a keyset-paginated UPDATE for filling a derived column.
+// Only rows matching pending are touched, so reruns are cheap.
+func columnBackfill(name, description, table, set, pending string) backfill {
+	return backfill{
+		Name:        name,
+		Description: description,
+		Table:       table,
+		Batch: fmt.Sprintf(`
+			WITH batch AS (
+				SELECT id FROM %[1]s WHERE id > $1::uuid ORDER BY id LIMIT $2
+			), updated AS (
+				UPDATE %[1]s t SET %[2]s
+				FROM batch
+				WHERE t.id = batch.id AND (%[3]s)
+				RETURNING 1
+			)
+			SELECT (SELECT id::text FROM batch ORDER BY id DESC LIMIT 1),
+				(SELECT count(*) FROM batch), (SELECT count(*) FROM updated)`,
+			table, set, pending),
+	}
+}
+
+func init() {
+	registerBackfill(backfill{
+		Name:        "subscription_created_events",
+		Description: "record subscription.created events and audit rows for subscriptions that predate event recording",
+		Table:       "subscriptions",
+		Batch: `
+			WITH batch AS (
+				SELECT * FROM subscriptions WHERE id > $1::uuid ORDER BY id LIMIT $2
+			), pending AS (
+				SELECT gen_random_uuid() AS event_id, b.*
+				FROM batch b
+				WHERE NOT EXISTS (SELECT 1 FROM subscription_events e WHERE e.subscription_id = b.id)
+			), events AS (
+				INSERT INTO subscription_events (id, event_type, subscription_id, user_id, payload, occurred_at)
+				SELECT event_id, 'subscription.created', id, user_id,
+					jsonb_build_object(
+						'event_id', event_id,
+						'type', 'subscription.created',
+						'subscription_id', id,
+						'user_id', user_id,
+						'occurred_at', created_at,
+						'subscription', jsonb_strip_nulls(jsonb_build_object(
+							'service_name', service_name,
+							'price', price,
+							'start_date', start_date,
+							'end_date', end_date,
+							'updated_at', updated_at))),
+					created_at
+				FROM pending
+				RETURNING id, subscription_id, occurred_at
+			), audit AS (
+				INSERT INTO audit_log (event_id, entity_type, entity_id, action, recorded_at)
+				SELECT id, 'subscription', subscription_id, 'subscription.created', occurred_at
+				FROM events
+			)
+			SELECT (SELECT id::text FROM batch ORDER BY id DESC LIMIT 1),
+				(SELECT count(*) FROM batch), (SELECT count(*) FROM events)`,
+	})
+}
+
+type backfillOptions struct {
+	BatchSize int
+	Pause     time.Duration
+	Restart   bool
+}
+
+type backfillCheckpoint struct {
+	LastKey   string
+	Processed int64
+	Affected  int64
+	Completed bool
+}
+
+func printBackfills() {
+	names := make([]string, 0, len(backfills))
+	for name := range backfills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		log.Println("no backfills registered")
+		return
+	}
+
+	for _, name := range names {
+		fmt.Printf("%-32s %s\n", name, backfills[name].Description)
+	}
+}
+
+func runBackfill(db *sql.DB, name string, opts backfillOptions) error {
+	b, ok := backfills[name]
+	if !ok {
+		return fmt.Errorf("unknown backfill %q, run -action=backfill without -backfill to list available ones", name)
+	}
+	if opts.BatchSize <= 0 {
+		return fmt.Errorf("batch size must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	checkpoint, err := loadCheckpoint(ctx, db, name)
+	if err != nil {
+		return err
+	}
+	if opts.Restart || checkpoint == nil {
+		checkpoint = &backfillCheckpoint{LastKey: backfillStartKey}
+	}
+	if checkpoint.Completed {
+		log.Printf("backfill %s already completed (%d rows scanned, %d changed), use -restart to run it again",
+			name, checkpoint.Processed, checkpoint.Affected)
+		return nil
+	}
+
+	var total int64
+	if err := db.QueryRowContext(ctx, "SELECT count(*) FROM "+b.Table).Scan(&total); err != nil {
+		return fmt.Errorf("count rows: %w", err)
+	}
+
+	log.Printf("backfill %s: %d rows in %s, resuming after key %s", name, total, b.Table, checkpoint.LastKey)
+
+	started := time.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			log.Printf("backfill %s interrupted at key %s, rerun to resume", name, checkpoint.LastKey)
+			return nil
+		}
+
+		scanned, done, err := runBackfillBatch(ctx, db, b, checkpoint, opts.BatchSize)
+		if err != nil {
+			return fmt.Errorf("batch after key %s: %w", checkpoint.LastKey, err)
+		}
+		if done {
+			break
+		}
+
+		log.Printf("backfill %s: %d/%d rows scanned (%.1f%%), %d changed, batch of %d",
+			name, checkpoint.Processed, total, percent(checkpoint.Processed, total), checkpoint.Affected, scanned)
+
+		if opts.Pause > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(opts.Pause):
+			}
+		}
+	}
+
+	log.Printf("backfill %s completed in %s: %d rows scanned, %d changed",
+		name, time.Since(started).Round(time.Millisecond), checkpoint.Processed, checkpoint.Affected)
+	return nil
+}
+
+// runBackfillBatch applies one batch and advances the checkpoint in the same
+// transaction, so an interrupted run never skips or repeats a batch.
+func runBackfillBatch(ctx context.Context, db *sql.DB, b backfill, checkpoint *backfillCheckpoint, batchSize int) (int, bool, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	var (
+		lastKey  sql.NullString
+		scanned  int
+		affected int64
+	)
+	err = tx.QueryRowContext(ctx, b.Batch, checkpoint.LastKey, batchSize).Scan(&lastKey, &scanned, &affected)
+	if err != nil {
+		return 0, false, err
+	}
+
+	next := *checkpoint
+	next.Processed += int64(scanned)
+	next.Affected += affected
+	next.Completed = !lastKey.Valid
+	if lastKey.Valid {
+		next.LastKey = lastKey.String
+	}
+
+	if err := saveCheckpoint(ctx, tx, b.Name, &next); err != nil {
+		return 0, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, false, err
+	}
+
+	*checkpoint = next
+	return scanned, next.Completed, nil
+}
+
+func loadCheckpoint(ctx context.Context, db *sql.DB, name string) (*backfillCheckpoint, error) {
+	var (
+		checkpoint  backfillCheckpoint
+		completedAt sql.NullTime
+	)
+	err := db.QueryRowContext(ctx,
+		"SELECT last_key, processed, affected, completed_at FROM backfill_checkpoints WHERE name = $1", name,
+	).Scan(&checkpoint.LastKey, &checkpoint.Processed, &checkpoint.Affected, &completedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint: %w", err)
+	}
+
+	checkpoint.Completed = completedAt.Valid
+	return &checkpoint, nil
+}
+
+func saveCheckpoint(ctx context.Context, tx *sql.Tx, name string, checkpoint *backfillCheckpoint) error {
+	var completedAt sql.NullTime
+	if checkpoint.Completed {
+		completedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO backfill_checkpoints (name, last_key, processed, affected, completed_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (name) DO UPDATE
+		SET last_key = EXCLUDED.last_key, processed = EXCLUDED.processed, affected = EXCLUDED.affected,
+			completed_at = EXCLUDED.completed_at, updated_at = EXCLUDED.updated_at`,
+		name, checkpoint.LastKey, checkpoint.Processed, checkpoint.Affected, completedAt)
+	if err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func percent(part, total int64) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(part) / float64(total) * 100
+}
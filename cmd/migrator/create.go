@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// minVersionWidth — ширина номера миграции: файлы называются 001_..., 002_...
+const minVersionWidth = 3
+
+var (
+	migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+	migrationFilePattern = regexp.MustCompile(`^([0-9]+)_.*\.(up|down)\.sql$`)
+)
+
+// nextVersion — номер следующей миграции: на единицу больше последнего в dir,
+// с той же шириной, что у существующих файлов.
+func nextVersion(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("read migrations directory: %w", err)
+	}
+
+	last, width := uint64(0), minVersionWidth
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("parse version of %s: %w", entry.Name(), err)
+		}
+		last = max(last, version)
+		width = max(width, len(match[1]))
+	}
+
+	return fmt.Sprintf("%0*d", width, last+1), nil
+}
+
+func createMigration(dir, name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return fmt.Errorf("name must be specified for create action")
+	}
+	if !migrationNamePattern.MatchString(name) {
+		return fmt.Errorf("name %q must contain only lowercase letters, digits and underscores", name)
+	}
+
+	dir = strings.TrimPrefix(dir, "file://")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create migrations directory: %w", err)
+	}
+
+	version, err := nextVersion(dir)
+	if err != nil {
+		return err
+	}
+	base := fmt.Sprintf("%s_%s", version, name)
+
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%s.%s.sql", base, direction))
+
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", path, err)
+		}
+
+		_, err = fmt.Fprintf(file, "-- %s migration: %s\n", direction, name)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+
+		log.Printf("created %s", path)
+	}
+
+	return nil
+}
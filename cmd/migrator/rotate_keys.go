@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/fieldcrypt"
+)
+
+type rotateOptions struct {
+	BatchSize int
+	Pause     time.Duration
+}
+
+// runKeyRotation re-encrypts subscription notes and metadata with the active
+// key. Rows that are still plaintext or sealed with an older key are rewritten;
+// rows already on the active key are skipped, so an interrupted run is simply
+// started again.
+func runKeyRotation(db *sql.DB, cfg config.EncryptionConfig, opts rotateOptions) error {
+	if !cfg.Enabled {
+		return fmt.Errorf("encryption is disabled, set encryption.enabled to rotate keys")
+	}
+	if opts.BatchSize <= 0 {
+		return fmt.Errorf("batch size must be positive")
+	}
+
+	cipher, err := fieldcrypt.New(fieldcrypt.Config{
+		ActiveKey: cfg.ActiveKey,
+		Keys:      cfg.Keys,
+		IndexKey:  cfg.IndexKey,
+	})
+	if err != nil {
+		return fmt.Errorf("encryption: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var total int64
+	if err := db.QueryRowContext(ctx, "SELECT count(*) FROM subscriptions").Scan(&total); err != nil {
+		return fmt.Errorf("count rows: %w", err)
+	}
+
+	log.Printf("rotate-keys: %d subscriptions, active key %s", total, cipher.ActiveKey())
+
+	var (
+		lastKey   = uuid.Nil
+		processed int64
+		rotated   int64
+		started   = time.Now()
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			log.Printf("rotate-keys interrupted after key %s, rerun to finish", lastKey)
+			return nil
+		}
+
+		next, scanned, changed, err := rotateBatch(ctx, db, cipher, lastKey, opts.BatchSize)
+		if err != nil {
+			return fmt.Errorf("batch after key %s: %w", lastKey, err)
+		}
+		if scanned == 0 {
+			break
+		}
+
+		lastKey = next
+		processed += int64(scanned)
+		rotated += int64(changed)
+		log.Printf("rotate-keys: %d/%d rows scanned (%.1f%%), %d re-encrypted",
+			processed, total, percent(processed, total), rotated)
+
+		if opts.Pause > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(opts.Pause):
+			}
+		}
+	}
+
+	log.Printf("rotate-keys completed in %s: %d rows scanned, %d re-encrypted",
+		time.Since(started).Round(time.Millisecond), processed, rotated)
+	return nil
+}
+
+// rotateBatch locks one keyset page of subscriptions and rewrites the rows that
+// need the active key. updated_at is left alone: the data did not change.
+func rotateBatch(ctx context.Context, db *sql.DB, cipher *fieldcrypt.Cipher, after uuid.UUID, batchSize int) (uuid.UUID, int, int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return after, 0, 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, notes, metadata
+		FROM subscriptions
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+		FOR UPDATE`, after, batchSize)
+	if err != nil {
+		return after, 0, 0, err
+	}
+
+	type sealedRow struct {
+		id       uuid.UUID
+		notes    string
+		metadata map[string]string
+	}
+
+	var (
+		pending []sealedRow
+		scanned int
+		last    = after
+	)
+	for rows.Next() {
+		var (
+			row          sealedRow
+			metadataJSON []byte
+		)
+		if err := rows.Scan(&row.id, &row.notes, &metadataJSON); err != nil {
+			rows.Close()
+			return after, 0, 0, err
+		}
+		if err := json.Unmarshal(metadataJSON, &row.metadata); err != nil {
+			rows.Close()
+			return after, 0, 0, fmt.Errorf("subscription %s: decode metadata: %w", row.id, err)
+		}
+
+		scanned++
+		last = row.id
+		if needsRotation(cipher, row.notes, row.metadata) {
+			pending = append(pending, row)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return after, 0, 0, err
+	}
+	rows.Close()
+
+	for _, row := range pending {
+		notes, metadata, err := repository.OpenSubscriptionFields(cipher, row.id, row.notes, row.metadata)
+		if err != nil {
+			return after, 0, 0, fmt.Errorf("subscription %s: %w", row.id, err)
+		}
+		notes, metadata, digest, err := repository.SealSubscriptionFields(cipher, row.id, notes, metadata)
+		if err != nil {
+			return after, 0, 0, fmt.Errorf("subscription %s: %w", row.id, err)
+		}
+
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return after, 0, 0, err
+		}
+		digestJSON, err := json.Marshal(digest)
+		if err != nil {
+			return after, 0, 0, err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"UPDATE subscriptions SET notes = $2, metadata = $3::jsonb, metadata_digest = $4::jsonb WHERE id = $1",
+			row.id, notes, string(metadataJSON), string(digestJSON))
+		if err != nil {
+			return after, 0, 0, fmt.Errorf("subscription %s: %w", row.id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return after, 0, 0, err
+	}
+	return last, scanned, len(pending), nil
+}
+
+func needsRotation(cipher *fieldcrypt.Cipher, notes string, metadata map[string]string) bool {
+	if cipher.NeedsRotation(notes) {
+		return true
+	}
+	for _, value := range metadata {
+		if cipher.NeedsRotation(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+func listVersions(src source.Driver) ([]uint, error) {
+	version, err := src.First()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []uint{version}
+	for {
+		version, err = src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return versions, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+}
+
+func printStatus(m *migrate.Migrate, src source.Driver) error {
+	current, dirty, err := currentVersion(m)
+	if err != nil {
+		return err
+	}
+
+	versions, err := listVersions(src)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, version := range versions {
+		state := "applied"
+		switch {
+		case version > current:
+			state = "pending"
+			pending++
+		case version == current && dirty:
+			state = "dirty"
+		}
+
+		body, identifier, err := src.ReadUp(version)
+		if body != nil {
+			body.Close()
+		}
+		if err != nil {
+			identifier = "?"
+		}
+
+		fmt.Printf("%-8d %-8s %s\n", version, state, identifier)
+	}
+
+	log.Printf("current version: %d, dirty: %t, pending: %d", current, dirty, pending)
+	return nil
+}
+
+func printPlan(m *migrate.Migrate, src source.Driver, action string, steps int) error {
+	current, dirty, err := currentVersion(m)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("database is dirty at version %d, fix it with force first", current)
+	}
+
+	versions, err := listVersions(src)
+	if err != nil {
+		return err
+	}
+
+	var plan []uint
+	if action == "up" {
+		for _, version := range versions {
+			if version > current {
+				plan = append(plan, version)
+			}
+		}
+	} else {
+		for i := len(versions) - 1; i >= 0; i-- {
+			if versions[i] <= current {
+				plan = append(plan, versions[i])
+			}
+		}
+	}
+
+	if steps > 0 && steps < len(plan) {
+		plan = plan[:steps]
+	}
+
+	if len(plan) == 0 {
+		log.Println("no migrations to apply")
+		return nil
+	}
+
+	for _, version := range plan {
+		read := src.ReadUp
+		if action == "down" {
+			read = src.ReadDown
+		}
+
+		body, identifier, err := read(version)
+		if err != nil {
+			return fmt.Errorf("read migration %d: %w", version, err)
+		}
+
+		fmt.Printf("-- %d_%s (%s)\n", version, identifier, action)
+		_, err = io.Copy(os.Stdout, body)
+		body.Close()
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	log.Printf("dry run: %d migration(s) would be applied", len(plan))
+	return nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// catalogEntry is a real-world service with its typical monthly prices in
+// RUB. Popularity weights the pick, so the data has a long tail like
+// production: many Yandex Plus rows, few JetBrains ones.
+type catalogEntry struct {
+	name       string
+	category   models.SubscriptionCategory
+	prices     []int
+	popularity int
+}
+
+var catalog = []catalogEntry{
+	{"Yandex Plus", models.CategoryStreaming, []int{299, 399, 649}, 30},
+	{"Kinopoisk", models.CategoryStreaming, []int{269, 399}, 12},
+	{"Okko", models.CategoryStreaming, []int{199, 399, 599}, 8},
+	{"Ivi", models.CategoryStreaming, []int{399}, 7},
+	{"Netflix", models.CategoryStreaming, []int{599, 899, 1199}, 10},
+	{"YouTube Premium", models.CategoryStreaming, []int{299, 449}, 12},
+	{"Spotify", models.CategoryMusic, []int{169, 269, 339}, 10},
+	{"VK Music", models.CategoryMusic, []int{149, 249}, 9},
+	{"Apple Music", models.CategoryMusic, []int{169, 269}, 5},
+	{"iCloud+", models.CategoryCloud, []int{59, 149, 599}, 11},
+	{"Google One", models.CategoryCloud, []int{139, 279, 699}, 8},
+	{"Yandex 360", models.CategoryCloud, []int{199, 349}, 6},
+	{"Dropbox", models.CategoryCloud, []int{990}, 2},
+	{"GitHub Copilot", models.CategorySoftware, []int{950, 1900}, 3},
+	{"JetBrains All Products", models.CategorySoftware, []int{2490}, 2},
+	{"Microsoft 365", models.CategorySoftware, []int{399, 599}, 4},
+	{"Adobe Creative Cloud", models.CategorySoftware, []int{1699, 5499}, 2},
+	{"PlayStation Plus", models.CategoryGaming, []int{599, 899, 1199}, 4},
+	{"Xbox Game Pass", models.CategoryGaming, []int{699, 1199}, 3},
+	{"World Class", models.CategoryFitness, []int{4500, 9000}, 1},
+	{"Strava", models.CategoryFitness, []int{399}, 2},
+	{"Skyeng", models.CategoryEducation, []int{2990, 5990}, 2},
+	{"Duolingo Super", models.CategoryEducation, []int{349}, 3},
+	{"Kommersant", models.CategoryNews, []int{350}, 1},
+	{"Ozon Premium", models.CategoryShopping, []int{299}, 6},
+	{"SberPrime", models.CategoryShopping, []int{199, 399}, 7},
+}
+
+var (
+	fakeTags  = []string{"family", "work", "personal", "shared", "trial", "annual-review", "kids"}
+	fakeNotes = []string{
+		"Shared with family",
+		"Paid by the company card",
+		"Cancel before renewal",
+		"Student discount, check every year",
+		"Bundled with mobile plan",
+	}
+	fakeTeams = []string{"platform", "mobile", "data", "design", "support"}
+)
+
+// fakeSubscription is one generated row. It is converted either into a
+// domain model for direct inserts or into an API request.
+type fakeSubscription struct {
+	ID          uuid.UUID                   `json:"id"`
+	UserID      uuid.UUID                   `json:"user_id"`
+	ServiceName string                      `json:"service_name"`
+	Category    models.SubscriptionCategory `json:"category"`
+	Price       int                         `json:"price"`
+	StartDate   time.Time                   `json:"start_date"`
+	EndDate     *time.Time                  `json:"end_date,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Notes       string                      `json:"notes,omitempty"`
+	Metadata    map[string]string           `json:"metadata,omitempty"`
+}
+
+type generatorOptions struct {
+	Users        int
+	SubsPerUser  int
+	AsOf         time.Time
+	HistoryYears int
+}
+
+// generator draws everything from one rng, so the same seed and options
+// always produce the same users, IDs and dates.
+type generator struct {
+	rng         *rand.Rand
+	opts        generatorOptions
+	totalWeight int
+}
+
+func newGenerator(seed int64, opts generatorOptions) *generator {
+	g := &generator{rng: rand.New(rand.NewSource(seed)), opts: opts}
+	for _, entry := range catalog {
+		g.totalWeight += entry.popularity
+	}
+	return g
+}
+
+// generate returns subscriptions grouped by user. A user gets between one
+// and 2×SubsPerUser-1 subscriptions, averaging SubsPerUser.
+func (g *generator) generate() []fakeSubscription {
+	result := make([]fakeSubscription, 0, g.opts.Users*g.opts.SubsPerUser)
+	for i := 0; i < g.opts.Users; i++ {
+		userID := g.uuid()
+		count := 1 + g.rng.Intn(2*g.opts.SubsPerUser-1)
+		for j := 0; j < count; j++ {
+			result = append(result, g.subscription(userID))
+		}
+	}
+	return result
+}
+
+func (g *generator) subscription(userID uuid.UUID) fakeSubscription {
+	entry := g.pickService()
+
+	sub := fakeSubscription{
+		ID:          g.uuid(),
+		UserID:      userID,
+		ServiceName: entry.name,
+		Category:    entry.category,
+		Price:       g.price(entry),
+		StartDate:   g.startDate(),
+	}
+	sub.EndDate = g.endDate(sub.StartDate)
+
+	if g.rng.Float64() < 0.4 {
+		sub.Tags = g.tags()
+	}
+	if g.rng.Float64() < 0.15 {
+		sub.Notes = fakeNotes[g.rng.Intn(len(fakeNotes))]
+	}
+	if g.rng.Float64() < 0.1 {
+		sub.Metadata = map[string]string{"team": fakeTeams[g.rng.Intn(len(fakeTeams))]}
+	}
+	return sub
+}
+
+func (g *generator) pickService() catalogEntry {
+	n := g.rng.Intn(g.totalWeight)
+	for _, entry := range catalog {
+		if n < entry.popularity {
+			return entry
+		}
+		n -= entry.popularity
+	}
+	return catalog[len(catalog)-1]
+}
+
+// price picks a tier, cheaper tiers being more common, and now and then
+// applies a promo price so sums are not all round numbers.
+func (g *generator) price(entry catalogEntry) int {
+	tier := int(math.Min(float64(len(entry.prices)-1), g.rng.ExpFloat64()))
+	price := entry.prices[tier]
+	if g.rng.Float64() < 0.1 {
+		price = price * (70 + g.rng.Intn(25)) / 100
+	}
+	return max(price, 1)
+}
+
+// startDate skews towards recent months: most subscriptions in a real
+// account are young, a few date back years.
+func (g *generator) startDate() time.Time {
+	maxMonths := g.opts.HistoryYears * 12
+	monthsAgo := int(g.rng.ExpFloat64() * float64(maxMonths) / 4)
+	if monthsAgo >= maxMonths {
+		monthsAgo = g.rng.Intn(maxMonths)
+	}
+	return g.opts.AsOf.AddDate(0, -monthsAgo, 0)
+}
+
+// endDate leaves about 60% of subscriptions open-ended; the rest ran for a
+// month to two years, so some are already over and some still active.
+func (g *generator) endDate(start time.Time) *time.Time {
+	if g.rng.Float64() < 0.6 {
+		return nil
+	}
+	end := utils.EndOfMonth(start.AddDate(0, g.rng.Intn(24), 0))
+	return &end
+}
+
+func (g *generator) tags() []string {
+	count := 1 + g.rng.Intn(2)
+	picked := make([]string, 0, count)
+	for _, i := range g.rng.Perm(len(fakeTags))[:count] {
+		picked = append(picked, fakeTags[i])
+	}
+	return picked
+}
+
+func (g *generator) uuid() uuid.UUID {
+	return uuid.Must(uuid.NewRandomFromReader(g.rng))
+}
@@ -0,0 +1,116 @@
+// Command seeder fills a dev or demo environment with realistic fake
+// subscriptions, either straight into Postgres or through the API. The same
+// -seed always generates the same data.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+const defaultConfigPath = "configs/config.yaml"
+
+func main() {
+	var (
+		configPath   = flag.String("config", defaultConfigPath, "path to configuration file, used by -target=db (empty to configure from environment only)")
+		target       = flag.String("target", "db", "where to write: db (Postgres via the repository) or api (POST /api/v1/subscriptions/)")
+		server       = flag.String("server", "http://localhost:8080", "service base URL for -target=api")
+		apiKey       = flag.String("api-key", "", "API key sent as X-API-Key for -target=api")
+		users        = flag.Int("users", 100, "number of users to generate")
+		subsPerUser  = flag.Int("subs-per-user", 5, "average subscriptions per user")
+		historyYears = flag.Int("history-years", 3, "how far back start dates go")
+		asOf         = flag.String("as-of", "", "month the data is generated relative to, MM-YYYY (default current month)")
+		seed         = flag.Int64("seed", 1, "random seed, the same seed and flags produce the same data")
+		dryRun       = flag.Bool("dry-run", false, "print generated subscriptions as JSON lines instead of writing them")
+	)
+	flag.Parse()
+
+	if *users <= 0 || *subsPerUser <= 0 || *historyYears <= 0 {
+		log.Fatalf("-users, -subs-per-user and -history-years must be positive")
+	}
+
+	reference := utils.StartOfMonth(time.Now().UTC())
+	if *asOf != "" {
+		parsed, err := utils.ParseMonthYear(*asOf)
+		if err != nil {
+			log.Fatalf("invalid -as-of: %v", err)
+		}
+		reference = parsed
+	}
+
+	gen := newGenerator(*seed, generatorOptions{
+		Users:        *users,
+		SubsPerUser:  *subsPerUser,
+		AsOf:         reference,
+		HistoryYears: *historyYears,
+	})
+	subscriptions := gen.generate()
+
+	out, err := openSink(*target, *dryRun, *configPath, *server, *apiKey)
+	if err != nil {
+		log.Fatalf("failed to open %s target: %v", *target, err)
+	}
+	defer out.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !*dryRun {
+		log.Printf("seeding %d subscriptions for %d users into %s (seed %d)", len(subscriptions), *users, *target, *seed)
+	}
+
+	started := time.Now()
+	for i, sub := range subscriptions {
+		if err := out.Write(ctx, sub); err != nil {
+			log.Fatalf("failed after %d subscriptions: %v", i, err)
+		}
+		if !*dryRun && (i+1)%1000 == 0 {
+			log.Printf("seeded %d/%d", i+1, len(subscriptions))
+		}
+	}
+
+	if !*dryRun {
+		log.Printf("seeded %d subscriptions in %s", len(subscriptions), time.Since(started).Round(time.Millisecond))
+	}
+}
+
+func openSink(target string, dryRun bool, configPath, server, apiKey string) (sink, error) {
+	if dryRun {
+		return newPrintSink(os.Stdout), nil
+	}
+
+	switch target {
+	case "api":
+		return newAPISink(server, apiKey), nil
+	case "db":
+		if envConfigPath, ok := os.LookupEnv("CONFIG_PATH"); ok {
+			configPath = envConfigPath
+		}
+
+		cfg := config.NewConfig()
+		if err := cfg.Load(configPath); err != nil {
+			return nil, err
+		}
+		if err := cfg.Database.Validate(); err != nil {
+			return nil, err
+		}
+
+		repoLog, err := logger.NewLogger(logger.Config{Level: "warn", Encoding: "console"})
+		if err != nil {
+			return nil, err
+		}
+		return newDBSink(cfg, repoLog)
+	default:
+		return nil, fmt.Errorf("unknown target %q, use db or api", target)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	domainRepo "github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/fieldcrypt"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// sink writes generated subscriptions somewhere.
+type sink interface {
+	Write(ctx context.Context, sub fakeSubscription) error
+	Close() error
+}
+
+// dbSink inserts rows through the subscription repository, so encrypted
+// fields and the search index are filled exactly like the service does.
+// Events and price history are not written: the data is a fixture, not a
+// replay of user actions.
+type dbSink struct {
+	db   *postgres.DB
+	repo domainRepo.SubscriptionRepository
+}
+
+func newDBSink(cfg *config.Config, log *logger.Logger) (*dbSink, error) {
+	var cipher *fieldcrypt.Cipher
+	if cfg.Encryption.Enabled {
+		var err error
+		cipher, err = fieldcrypt.New(fieldcrypt.Config{
+			ActiveKey: cfg.Encryption.ActiveKey,
+			Keys:      cfg.Encryption.Keys,
+			IndexKey:  cfg.Encryption.IndexKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("encryption: %w", err)
+		}
+	}
+
+	db, err := postgres.New(cfg.Database, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbSink{
+		db:   db,
+		repo: repository.NewSubscriptionRepository(db, cipher, log),
+	}, nil
+}
+
+func (s *dbSink) Write(ctx context.Context, fake fakeSubscription) error {
+	sub := models.NewSubscription(fake.ServiceName, models.Rubles(fake.Price), fake.UserID, fake.StartDate)
+	sub.SetID(fake.ID)
+	sub.SetEndDate(fake.EndDate)
+	category := fake.Category
+	sub.SetCategory(&category)
+	if len(fake.Tags) > 0 {
+		sub.SetTags(fake.Tags)
+	}
+	sub.SetNotes(fake.Notes)
+	if fake.Metadata != nil {
+		sub.SetMetadata(fake.Metadata)
+	}
+
+	if err := sub.Validate(); err != nil {
+		return fmt.Errorf("generated invalid subscription: %w", err)
+	}
+	return s.repo.Create(ctx, sub)
+}
+
+func (s *dbSink) Close() error {
+	s.db.Close()
+	return nil
+}
+
+// apiSink creates subscriptions with POST /api/v1/subscriptions/. IDs are
+// assigned by the service, so only the data, not the IDs, is reproducible.
+type apiSink struct {
+	url    string
+	apiKey string
+	http   *http.Client
+}
+
+func newAPISink(server, apiKey string) *apiSink {
+	return &apiSink{
+		url:    strings.TrimRight(server, "/") + "/api/v1/subscriptions/",
+		apiKey: apiKey,
+		http:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *apiSink) Write(ctx context.Context, fake fakeSubscription) error {
+	body := request.CreateSubscriptionRequest{
+		ServiceName: fake.ServiceName,
+		Price:       fake.Price,
+		UserID:      fake.UserID.String(),
+		StartDate:   fake.StartDate.Format(utils.DateLayout),
+		Tags:        fake.Tags,
+		Category:    string(fake.Category),
+		Notes:       fake.Notes,
+		Metadata:    fake.Metadata,
+	}
+	if fake.EndDate != nil {
+		body.EndDate = fake.EndDate.Format(utils.DateLayout)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("X-API-Key", s.apiKey)
+	}
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("POST %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("POST %s: %s: %s", s.url, resp.Status, bytes.TrimSpace(detail))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (s *apiSink) Close() error {
+	return nil
+}
+
+// printSink writes generated rows as JSON lines, for -dry-run.
+type printSink struct {
+	encoder *json.Encoder
+}
+
+func newPrintSink(w io.Writer) *printSink {
+	return &printSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *printSink) Write(_ context.Context, fake fakeSubscription) error {
+	return s.encoder.Encode(fake)
+}
+
+func (s *printSink) Close() error {
+	return nil
+}
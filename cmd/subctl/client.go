@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+const (
+	apiPrefix    = "/api/v1"
+	apiKeyHeader = "X-API-Key"
+)
+
+// client is a thin JSON client for the /api/v1 endpoints.
+type client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+func newClient(server, apiKey string) *client {
+	return &client{
+		baseURL: strings.TrimRight(server, "/") + apiPrefix,
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *client) get(ctx context.Context, path string, query url.Values, out any) error {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return c.do(ctx, http.MethodGet, path, nil, out)
+}
+
+func (c *client) post(ctx context.Context, path string, body, out any) error {
+	return c.do(ctx, http.MethodPost, path, body, out)
+}
+
+func (c *client) delete(ctx context.Context, path string, out any) error {
+	return c.do(ctx, http.MethodDelete, path, nil, out)
+}
+
+func (c *client) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeAPIError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// decodeAPIError turns the service's error envelope into a readable error.
+func decodeAPIError(resp *http.Response) error {
+	var body response.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error.Code == "" {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	msg := fmt.Sprintf("%s (%d): %s", body.Error.Code, resp.StatusCode, body.Error.Message)
+	for field, detail := range body.Error.Details {
+		msg += fmt.Sprintf("\n  %s: %s", field, detail)
+	}
+	return fmt.Errorf("%s", msg)
+}
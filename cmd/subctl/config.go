@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// profile is one named target in the config file:
+//
+//	profile: prod
+//	profiles:
+//	  local:
+//	    server: http://localhost:8080
+//	  prod:
+//	    server: https://subscriptions.example.com
+//	    api_key: sk_...
+type profile struct {
+	Server string `mapstructure:"server"`
+	APIKey string `mapstructure:"api_key"`
+}
+
+type fileConfig struct {
+	Profile  string             `mapstructure:"profile"`
+	Profiles map[string]profile `mapstructure:"profiles"`
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "subctl", "config.yaml")
+}
+
+// resolveTarget picks the server and API key. Flags win over SUBCTL_*
+// environment variables, which win over the selected profile.
+func resolveTarget(opts *options) (profile, error) {
+	cfg, err := loadFileConfig(opts.configPath)
+	if err != nil {
+		return profile{}, err
+	}
+
+	name := firstNonEmpty(opts.profile, os.Getenv("SUBCTL_PROFILE"), cfg.Profile)
+
+	var target profile
+	if name != "" {
+		selected, ok := cfg.Profiles[name]
+		if !ok {
+			return profile{}, fmt.Errorf("profile %q not found in %s", name, opts.configPath)
+		}
+		target = selected
+	}
+
+	target.Server = firstNonEmpty(opts.server, os.Getenv("SUBCTL_SERVER"), target.Server, defaultServer)
+	target.APIKey = firstNonEmpty(opts.apiKey, os.Getenv("SUBCTL_API_KEY"), target.APIKey)
+
+	return target, nil
+}
+
+// loadFileConfig reads the config file; a missing file is not an error.
+func loadFileConfig(path string) (fileConfig, error) {
+	var cfg fileConfig
+	if path == "" {
+		return cfg, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+
+	if err := v.Unmarshal(&cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
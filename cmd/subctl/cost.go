@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+func newCostCommand(opts *options) *cobra.Command {
+	var (
+		from        string
+		to          string
+		userID      string
+		serviceName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Total cost of subscriptions over a period",
+		Example: `  subctl cost --from 01-2025 --to 12-2025 --user 60601fee-2bf1-4721-ae6f-7636e79a0cba
+  subctl cost --from 01-2025 --to 06-2025 --service "Yandex Plus" -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{}
+			query.Set("start_date", from)
+			query.Set("end_date", to)
+			if userID != "" {
+				query.Set("user_id", userID)
+			}
+			if serviceName != "" {
+				query.Set("service_name", serviceName)
+			}
+
+			var summary response.CostSummaryResponse
+			if err := opts.client.get(cmd.Context(), "/costs/calculate", query, &summary); err != nil {
+				return err
+			}
+
+			if opts.output == outputJSON {
+				return printJSON(cmd.OutOrStdout(), summary)
+			}
+			return printTable(cmd.OutOrStdout(),
+				[]string{"PERIOD", "TOTAL", "GROSS", "DISCOUNT", "CURRENCY", "BILLING", "PRICING"},
+				[][]string{{
+					summary.Period.StartDate + " – " + summary.Period.EndDate,
+					strconv.Itoa(summary.TotalCost),
+					strconv.Itoa(summary.GrossCost),
+					strconv.Itoa(summary.Discount),
+					summary.Currency,
+					summary.BillingMode,
+					summary.Pricing,
+				}})
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "first month of the period, e.g. 01-2025 (required)")
+	cmd.Flags().StringVar(&to, "to", "", "last month of the period, e.g. 12-2025 (required)")
+	cmd.Flags().StringVar(&userID, "user", "", "only subscriptions of this user ID")
+	cmd.Flags().StringVar(&serviceName, "service", "", "only this service")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// options holds the global flags shared by every command.
+type options struct {
+	configPath string
+	profile    string
+	server     string
+	apiKey     string
+	output     string
+
+	client *client
+}
+
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+
+	root := &cobra.Command{
+		Use:           "subctl",
+		Short:         "Command-line client for the subscription service API",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != outputTable && opts.output != outputJSON {
+				return fmt.Errorf("unsupported output %q, use %s or %s", opts.output, outputTable, outputJSON)
+			}
+
+			target, err := resolveTarget(opts)
+			if err != nil {
+				return err
+			}
+			opts.client = newClient(target.Server, target.APIKey)
+			return nil
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&opts.configPath, "config", defaultConfigPath(), "path to the subctl config file with profiles")
+	flags.StringVarP(&opts.profile, "profile", "p", "", "profile from the config file (env SUBCTL_PROFILE)")
+	flags.StringVar(&opts.server, "server", "", "service base URL, overrides the profile (env SUBCTL_SERVER)")
+	flags.StringVar(&opts.apiKey, "api-key", "", "API key sent as X-API-Key, overrides the profile (env SUBCTL_API_KEY)")
+	flags.StringVarP(&opts.output, "output", "o", outputTable, "output format: table or json")
+
+	root.AddCommand(
+		newSubscriptionsCommand(opts),
+		newCostCommand(opts),
+	)
+
+	return root
+}
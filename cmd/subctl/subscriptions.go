@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+func newSubscriptionsCommand(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "subscriptions",
+		Aliases: []string{"subs", "sub"},
+		Short:   "List, create and delete subscriptions",
+	}
+
+	cmd.AddCommand(
+		newSubscriptionsListCommand(opts),
+		newSubscriptionsCreateCommand(opts),
+		newSubscriptionsDeleteCommand(opts),
+	)
+	return cmd
+}
+
+func newSubscriptionsListCommand(opts *options) *cobra.Command {
+	var (
+		userID      string
+		serviceName string
+		category    string
+		limit       int
+		offset      int
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List subscriptions, optionally of one user",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{}
+			query.Set("limit", strconv.Itoa(limit))
+			query.Set("offset", strconv.Itoa(offset))
+
+			path := "/subscriptions/"
+			if userID != "" {
+				path = "/users/" + url.PathEscape(userID) + "/subscriptions"
+				if serviceName != "" || category != "" {
+					return fmt.Errorf("--service and --category cannot be combined with --user")
+				}
+			}
+			if serviceName != "" {
+				query.Set("service_name", serviceName)
+			}
+			if category != "" {
+				query.Set("category", category)
+			}
+
+			var list response.SubscriptionsListResponse
+			if err := opts.client.get(cmd.Context(), path, query, &list); err != nil {
+				return err
+			}
+
+			if opts.output == outputJSON {
+				return printJSON(cmd.OutOrStdout(), list)
+			}
+
+			rows := make([][]string, 0, len(list.Data))
+			for _, sub := range list.Data {
+				rows = append(rows, subscriptionRow(sub))
+			}
+			if err := printTable(cmd.OutOrStdout(), subscriptionHeader, rows); err != nil {
+				return err
+			}
+			if list.Pagination.HasMore {
+				fmt.Fprintf(cmd.ErrOrStderr(), "more results: --offset %d\n", list.Pagination.Offset+list.Pagination.Limit)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "only subscriptions of this user ID")
+	cmd.Flags().StringVar(&serviceName, "service", "", "filter by service name")
+	cmd.Flags().StringVar(&category, "category", "", "filter by category")
+	cmd.Flags().IntVar(&limit, "limit", 20, "page size")
+	cmd.Flags().IntVar(&offset, "offset", 0, "page offset")
+	return cmd
+}
+
+func newSubscriptionsCreateCommand(opts *options) *cobra.Command {
+	var (
+		req  request.CreateSubscriptionRequest
+		tags []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a subscription",
+		Example: `  subctl subscriptions create --user 60601fee-2bf1-4721-ae6f-7636e79a0cba \
+    --service "Yandex Plus" --price 400 --start 07-2025`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if req.PlanID == "" && (req.ServiceName == "" || req.Price == 0) {
+				return fmt.Errorf("either --plan or both --service and --price are required")
+			}
+			req.Tags = tags
+
+			var created response.SubscriptionResponse
+			if err := opts.client.post(cmd.Context(), "/subscriptions/", req, &created); err != nil {
+				return err
+			}
+
+			if opts.output == outputJSON {
+				return printJSON(cmd.OutOrStdout(), created)
+			}
+			return printTable(cmd.OutOrStdout(), subscriptionHeader, [][]string{subscriptionRow(created)})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&req.UserID, "user", "", "user ID (required)")
+	flags.StringVar(&req.ServiceName, "service", "", "service name")
+	flags.IntVar(&req.Price, "price", 0, "monthly price in rubles")
+	flags.StringVar(&req.PlanID, "plan", "", "plan ID instead of --service and --price")
+	flags.StringVar(&req.StartDate, "start", "", "start month, e.g. 07-2025 (required)")
+	flags.StringVar(&req.EndDate, "end", "", "end month, e.g. 12-2025")
+	flags.StringVar(&req.Category, "category", "", "category, e.g. streaming")
+	flags.StringVar(&req.PromoCode, "promo", "", "promo code")
+	flags.StringVar(&req.Notes, "notes", "", "free-form notes")
+	flags.StringSliceVar(&tags, "tag", nil, "tag, may be repeated or comma-separated")
+	_ = cmd.MarkFlagRequired("user")
+	_ = cmd.MarkFlagRequired("start")
+	return cmd
+}
+
+func newSubscriptionsDeleteCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <id>...",
+		Aliases: []string{"rm"},
+		Short:   "Delete subscriptions by ID",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, id := range args {
+				var resp response.MessageResponse
+				if err := opts.client.delete(cmd.Context(), "/subscriptions/"+url.PathEscape(id), &resp); err != nil {
+					return fmt.Errorf("delete %s: %w", id, err)
+				}
+
+				if opts.output == outputJSON {
+					if err := printJSON(cmd.OutOrStdout(), map[string]string{"id": id, "message": resp.Message}); err != nil {
+						return err
+					}
+					continue
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "deleted %s\n", id)
+			}
+			return nil
+		},
+	}
+}
+
+var subscriptionHeader = []string{"ID", "SERVICE", "PRICE", "USER", "START", "END", "CATEGORY", "TAGS"}
+
+func subscriptionRow(sub response.SubscriptionResponse) []string {
+	return []string{
+		sub.ID,
+		sub.ServiceName,
+		strconv.Itoa(sub.Price),
+		sub.UserID,
+		sub.StartDate,
+		deref(sub.EndDate),
+		deref(sub.Category),
+		strings.Join(sub.Tags, ","),
+	}
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/buildinfo"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
 )
 
@@ -23,21 +24,51 @@ func New(configPath string) (*App, error) {
 		return nil, err
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	loggerConfig := logger.Config{
 		Level:       cfg.Logger.Level,
 		Development: cfg.Logger.Development,
 		Encoding:    cfg.Logger.Encoding,
 	}
+	for _, output := range cfg.Logger.Outputs {
+		loggerConfig.Outputs = append(loggerConfig.Outputs, logger.OutputConfig{
+			Type:       output.Type,
+			Encoding:   output.Encoding,
+			Path:       output.Path,
+			MaxSizeMB:  output.MaxSizeMB,
+			MaxBackups: output.MaxBackups,
+			MaxAgeDays: output.MaxAgeDays,
+			Compress:   output.Compress,
+		})
+	}
 
 	log, err := logger.NewLogger(loggerConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	build := buildinfo.Get()
 	log.Info("application starting",
-		zap.String("version", "1.0.0"),
+		zap.String("version", build.Version),
+		zap.String("commit", build.Commit),
+		zap.String("build_date", build.BuildDate),
+		zap.String("go_version", build.GoVersion),
 		zap.String("environment", getEnvironment(cfg.Logger.Development)))
 
+	// Итоговая конфигурация без секретов: при разборе инцидента видно, какое
+	// значение действовало и пришло ли оно из файла, окружения или по умолчанию.
+	counts := cfg.SourceCounts()
+	log.Info("effective configuration",
+		zap.String("config_path", configPath),
+		zap.String("config_hash", cfg.Hash()),
+		zap.Int("from_file", counts[config.SourceFile]),
+		zap.Int("from_env", counts[config.SourceEnv]),
+		zap.Int("from_default", counts[config.SourceDefault]),
+		zap.Any("config", cfg.Audit()))
+
 	deps, err := NewDependencies(*cfg, log)
 	if err != nil {
 		log.Error("failed to initialize dependencies", zap.Error(err))
@@ -57,6 +88,28 @@ func (a *App) Run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if a.deps.Watchdog != nil {
+		a.deps.Watchdog.Start(ctx)
+	}
+
+	a.deps.Scheduler.Start(ctx)
+
+	if a.deps.LiveStats != nil {
+		a.deps.LiveStats.Start()
+	}
+
+	if a.deps.LiveHub != nil {
+		a.deps.LiveHub.Start()
+	}
+
+	if a.deps.BillingConsumer != nil {
+		a.deps.BillingConsumer.Start()
+	}
+
+	if a.deps.ConfigConsistencyService != nil {
+		go a.deps.ConfigConsistencyService.Run(ctx, a.deps.Config.Consistency.IntervalDuration())
+	}
+
 	errChan := make(chan error, 1)
 
 	go func() {
@@ -81,11 +134,11 @@ func (a *App) Run() error {
 func (a *App) shutdown(ctx context.Context) error {
 	a.logger.Info("gracefully shutting down application")
 
-	if err := a.deps.Server.Shutdown(); err != nil {
-		a.logger.Error("server shutdown error", zap.Error(err))
-		return err
-	}
+	// Проба готовности должна упасть раньше, чем начнут закрываться потоки.
+	a.deps.Readiness.MarkDraining()
 
+	// Порядок остановки задают хуки Dependencies: даже если сервер не
+	// дождался запросов, остальные ресурсы всё равно освобождаются.
 	if err := a.deps.Close(); err != nil {
 		a.logger.Error("dependencies cleanup error", zap.Error(err))
 		return err
@@ -2,33 +2,140 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/adminui"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/apidoc"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/handlers"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/server"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/rabbitmq"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/ws"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
 	infraRepo "github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/dedup"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/events"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/livestats"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/loglevel"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/maintenance"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/metrics"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/snapshot"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/storage"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/watchdog"
 	appService "github.com/vagonaizer/effective-mobile/subscription-service/internal/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/worker"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/breaker"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/buildinfo"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/featureflags"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/fieldcrypt"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/health"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/shutdown"
 )
 
 type Dependencies struct {
-	Config config.Config
-	Logger *logger.Logger
+	Config    config.Config
+	Logger    *logger.Logger
+	PublicIDs *publicid.Registry
 
-	Database *postgres.DB
+	Database   *postgres.DB
+	Storage    *storage.S3
+	DedupStore *dedup.RedisStore
 
-	SubscriptionRepo    repository.SubscriptionRepository
-	SubscriptionService service.SubscriptionService
+	SubscriptionRepo      repository.SubscriptionRepository
+	ConfigFingerprintRepo repository.ConfigFingerprintRepository
+	SubscriptionEventRepo repository.SubscriptionEventRepository
+	DeadLetterRepo        repository.DeadLetterRepository
+	CommentRepo           repository.SubscriptionCommentRepository
+	MemberRepo            repository.SubscriptionMemberRepository
+	ServiceNameRuleRepo   repository.ServiceNameRuleRepository
+	CanonicalNameRepo     repository.CanonicalServiceNameRepository
+	DiscountRepo          repository.DiscountRepository
+	PlanRepo              repository.PlanRepository
+	CatalogRepo           repository.ServiceCatalogRepository
+	CostAlertRepo         repository.CostAlertRepository
+	CalendarFeedRepo      repository.CalendarFeedRepository
+	SubscriptionLimitRepo repository.SubscriptionLimitRepository
+	APIKeyRepo            repository.APIKeyRepository
+	BillingCommandRepo    repository.BillingCommandRepository
+	PartitionRepo         repository.PartitionRepository
 
-	SubscriptionHandler *handlers.SubscriptionHandler
-	HealthHandler       *handlers.HealthHandler
+	EventBus     *events.Bus
+	FeatureFlags *featureflags.Flags
+
+	SubscriptionService      service.SubscriptionService
+	BulkService              service.SubscriptionBulkService
+	SubscriptionEvents       *appService.SubscriptionEventRecorder
+	CostCache                *appService.CostCache
+	ServiceNameRules         *appService.ServiceNameRules
+	CanonicalServiceNames    *appService.CanonicalServiceNames
+	SubscriptionLimits       *appService.SubscriptionLimits
+	DiscountService          service.DiscountService
+	PlanService              service.PlanService
+	CatalogService           service.ServiceCatalogService
+	CostAlertService         service.CostAlertService
+	CalendarFeedService      service.CalendarFeedService
+	SpendReportService       service.SpendReportService
+	AnalyticsService         service.AnalyticsService
+	ExpiryReminderService    service.ExpiryReminderService
+	ArchiveService           service.SubscriptionArchiveService
+	PartitionService         service.PartitionMaintenanceService
+	NormalizationService     service.ServiceNameNormalizationService
+	DeadLetterService        service.DeadLetterService
+	CommentService           service.SubscriptionCommentService
+	MemberService            service.SubscriptionMemberService
+	ConfigConsistencyService service.ConfigConsistencyService
+	AuthService              service.AuthService
+	BillingCommandService    service.BillingCommandService
+	ArtifactService          service.ArtifactService
+
+	SubscriptionHandler   *handlers.SubscriptionHandler
+	SubscriptionV2Handler *handlers.SubscriptionV2Handler
+	BulkHandler           *handlers.SubscriptionBulkHandler
+	PlanHandler           *handlers.PlanHandler
+	CatalogHandler        *handlers.ServiceCatalogHandler
+	CostAlertHandler      *handlers.CostAlertHandler
+	CalendarFeedHandler   *handlers.CalendarFeedHandler
+	MemberHandler         *handlers.SubscriptionMemberHandler
+	HealthHandler         *handlers.HealthHandler
+	AdminHandler          *handlers.AdminHandler
+	AccessHandler         *handlers.AccessHandler
+	LiveUpdatesHandler    *handlers.LiveUpdatesHandler
+	VersionHandler        *handlers.VersionHandler
+	MaintenanceHandler    *handlers.MaintenanceHandler
+	DBPoolHandler         *handlers.DBPoolHandler
+	LogLevelHandler       *handlers.LogLevelHandler
+	ServiceNamesHandler   *handlers.CanonicalServiceNameHandler
+	ExportHandler         *handlers.SubscriptionExportHandler
+	LimitHandler          *handlers.SubscriptionLimitHandler
+
+	Watchdog  *watchdog.Watchdog
+	Snapshots *snapshot.Store
+	Metrics   *metrics.Metrics
+	LiveStats *livestats.Stats
+	LiveHub   *ws.Hub
+	Scheduler *worker.Scheduler
+
+	BillingConsumer *rabbitmq.Consumer
+
+	HealthChecks *health.Registry
+	Breakers     *breaker.Group
+	Readiness    *server.Readiness
+	Maintenance  *maintenance.Switch
+	LogLevel     *loglevel.Switch
+
+	InFlight      *server.InFlight
+	ShutdownHooks *shutdown.Registry
 
 	Router *router.Router
 	Server *server.Server
@@ -36,26 +143,73 @@ type Dependencies struct {
 
 func NewDependencies(cfg config.Config, log *logger.Logger) (*Dependencies, error) {
 	deps := &Dependencies{
-		Config: cfg,
-		Logger: log,
+		Config:        cfg,
+		Logger:        log,
+		ShutdownHooks: shutdown.NewRegistry(),
+	}
+
+	deps.ShutdownHooks.Register(shutdown.PhaseLogger, "logger", func(context.Context) error {
+		return log.Close()
+	})
+
+	if err := deps.initPublicIDs(); err != nil {
+		return nil, err
 	}
 
 	if err := deps.initDatabase(); err != nil {
 		return nil, err
 	}
 
+	if err := deps.initMetrics(); err != nil {
+		return nil, err
+	}
+
+	if err := deps.initBreakers(); err != nil {
+		return nil, err
+	}
+
 	if err := deps.initRepositories(); err != nil {
 		return nil, err
 	}
 
+	if err := deps.initStorage(); err != nil {
+		return nil, err
+	}
+
+	deps.initDedup()
+
 	if err := deps.initServices(); err != nil {
 		return nil, err
 	}
 
+	if err := deps.initLiveStats(); err != nil {
+		return nil, err
+	}
+
+	if err := deps.initLiveHub(); err != nil {
+		return nil, err
+	}
+
+	if err := deps.initBillingConsumer(); err != nil {
+		return nil, err
+	}
+
+	if err := deps.initHealth(); err != nil {
+		return nil, err
+	}
+
 	if err := deps.initHandlers(); err != nil {
 		return nil, err
 	}
 
+	if err := deps.initScheduler(); err != nil {
+		return nil, err
+	}
+
+	if err := deps.initWatchdog(); err != nil {
+		return nil, err
+	}
+
 	if err := deps.initRouter(); err != nil {
 		return nil, err
 	}
@@ -67,15 +221,54 @@ func NewDependencies(cfg config.Config, log *logger.Logger) (*Dependencies, erro
 	return deps, nil
 }
 
+func (d *Dependencies) initPublicIDs() error {
+	tenants := make(map[string]publicid.Config, len(d.Config.PublicIDs.Tenants))
+	for tenant, tc := range d.Config.PublicIDs.Tenants {
+		tenants[tenant] = publicid.Config{
+			Mode:       tc.Mode,
+			Salt:       tc.Salt,
+			Prefix:     tc.Prefix,
+			AcceptUUID: tc.AcceptUUID,
+		}
+	}
+
+	registry, err := publicid.NewRegistry(publicid.Config{
+		Mode:       d.Config.PublicIDs.Mode,
+		Salt:       d.Config.PublicIDs.Salt,
+		Prefix:     d.Config.PublicIDs.Prefix,
+		AcceptUUID: d.Config.PublicIDs.AcceptUUID,
+	}, tenants)
+	if err != nil {
+		return err
+	}
+
+	d.PublicIDs = registry
+	d.Logger.Info("public id codec configured",
+		zap.String("mode", d.Config.PublicIDs.Mode),
+		zap.Bool("accept_uuid", d.Config.PublicIDs.AcceptUUID),
+		zap.Int("tenants", len(tenants)))
+	return nil
+}
+
 func (d *Dependencies) initDatabase() error {
 	d.Logger.Info("initializing database connection")
 
+	if d.Config.Database.AutoMigrate {
+		if err := postgres.Migrate(d.Config.Database, d.Logger); err != nil {
+			return err
+		}
+	}
+
 	db, err := postgres.New(d.Config.Database, d.Logger)
 	if err != nil {
 		return err
 	}
 
 	d.Database = db
+	d.ShutdownHooks.Register(shutdown.PhaseDatabase, "postgres", func(context.Context) error {
+		db.Close()
+		return nil
+	})
 	d.Logger.Info("database connection initialized successfully")
 	return nil
 }
@@ -83,34 +276,628 @@ func (d *Dependencies) initDatabase() error {
 func (d *Dependencies) initRepositories() error {
 	d.Logger.Info("initializing repositories")
 
-	d.SubscriptionRepo = infraRepo.NewSubscriptionRepository(d.Database, d.Logger)
+	var cipher *fieldcrypt.Cipher
+	if d.Config.Encryption.Enabled {
+		var err error
+		cipher, err = fieldcrypt.New(fieldcrypt.Config{
+			ActiveKey: d.Config.Encryption.ActiveKey,
+			Keys:      d.Config.Encryption.Keys,
+			IndexKey:  d.Config.Encryption.IndexKey,
+		})
+		if err != nil {
+			return fmt.Errorf("encryption: %w", err)
+		}
+		d.Logger.Info("field encryption enabled", zap.String("active_key", cipher.ActiveKey()))
+	}
+
+	d.SubscriptionRepo = infraRepo.NewSubscriptionRepository(d.Database, cipher, d.Logger)
+	if retry := d.Config.Database.Retry; retry.Enabled {
+		// nil *metrics.Metrics в интерфейсе не равен nil — передаём явно.
+		var observer infraRepo.RetryObserver
+		if d.Metrics != nil {
+			observer = d.Metrics
+		}
+		d.SubscriptionRepo = infraRepo.NewRetryingSubscriptionRepository(
+			d.SubscriptionRepo,
+			d.Database,
+			postgres.RetryPolicy{Attempts: retry.ReadAttempts, BaseDelay: retry.BaseDelay(), MaxDelay: retry.MaxDelay()},
+			postgres.RetryPolicy{Attempts: retry.WriteAttempts, BaseDelay: retry.BaseDelay(), MaxDelay: retry.MaxDelay()},
+			observer,
+			d.Logger,
+		)
+	}
+	d.ConfigFingerprintRepo = infraRepo.NewConfigFingerprintRepository(d.Database, d.Logger)
+	d.SubscriptionEventRepo = infraRepo.NewSubscriptionEventRepository(d.Database, d.Logger)
+	d.DeadLetterRepo = infraRepo.NewDeadLetterRepository(d.Database, d.Logger)
+	d.CommentRepo = infraRepo.NewSubscriptionCommentRepository(d.Database, d.Logger)
+	d.MemberRepo = infraRepo.NewSubscriptionMemberRepository(d.Database, d.Logger)
+	d.ServiceNameRuleRepo = infraRepo.NewServiceNameRuleRepository(d.Database, d.Logger)
+	d.CanonicalNameRepo = infraRepo.NewCanonicalServiceNameRepository(d.Database, d.Logger)
+	d.DiscountRepo = infraRepo.NewDiscountRepository(d.Database, d.Logger)
+	d.PlanRepo = infraRepo.NewPlanRepository(d.Database, d.Logger)
+	d.CatalogRepo = infraRepo.NewServiceCatalogRepository(d.Database, d.Logger)
+	d.CostAlertRepo = infraRepo.NewCostAlertRepository(d.Database, d.Logger)
+	d.CalendarFeedRepo = infraRepo.NewCalendarFeedRepository(d.Database, d.Logger)
+	d.SubscriptionLimitRepo = infraRepo.NewSubscriptionLimitRepository(d.Database, d.Logger)
+	d.APIKeyRepo = infraRepo.NewAPIKeyRepository(d.Database, d.Logger)
+	d.BillingCommandRepo = infraRepo.NewBillingCommandRepository(d.Database, d.Logger)
+	d.PartitionRepo = infraRepo.NewPartitionRepository(d.Database, d.Logger)
 
 	d.Logger.Info("repositories initialized successfully")
 	return nil
 }
 
+// initStorage подключает объектное хранилище выгрузок, если оно включено.
+// Доступность бакета не проверяется на старте: хранилище некритично, его
+// состояние видно в /health.
+func (d *Dependencies) initStorage() error {
+	sc := d.Config.Storage
+	if !sc.Enabled {
+		return nil
+	}
+
+	d.Logger.Info("initializing object storage", zap.String("endpoint", sc.Endpoint), zap.String("bucket", sc.Bucket))
+
+	s3, err := storage.NewS3(storage.Config{
+		Endpoint:  sc.Endpoint,
+		Region:    sc.Region,
+		Bucket:    sc.Bucket,
+		AccessKey: sc.AccessKey,
+		SecretKey: sc.SecretKey,
+		PathStyle: sc.PathStyle,
+		Prefix:    sc.Prefix,
+	}, d.Logger)
+	if err != nil {
+		return err
+	}
+	d.Storage = s3
+
+	d.Logger.Info("object storage initialized successfully")
+	return nil
+}
+
+// initDedup подключает Redis для защиты от двойной отправки, если она
+// включена. Как и хранилище, Redis не проверяется на старте: без него
+// Dedup пропускает запросы, а состояние видно в /health.
+func (d *Dependencies) initDedup() {
+	if !d.Config.Dedup.Enabled {
+		return
+	}
+
+	rc := d.Config.Redis
+	d.Logger.Info("initializing request deduplication", zap.String("redis", rc.Addr))
+
+	d.DedupStore = dedup.NewRedisStore(dedup.Config{
+		Addr:     rc.Addr,
+		Password: rc.Password,
+		DB:       rc.DB,
+		Prefix:   d.Config.Dedup.KeyPrefix,
+	})
+	d.ShutdownHooks.Register(shutdown.PhaseDatabase, "redis", func(context.Context) error {
+		return d.DedupStore.Close()
+	})
+}
+
 func (d *Dependencies) initServices() error {
 	d.Logger.Info("initializing services")
 
-	d.SubscriptionService = appService.NewSubscriptionService(d.SubscriptionRepo, d.Logger)
+	d.EventBus = events.NewBus(d.Config.Events.BusBuffer, d.Logger)
+	if d.Metrics != nil {
+		d.EventBus.Subscribe("metrics", d.Metrics.ObserveEvent)
+	}
+
+	// Без events.enabled события не пишутся в БД, но шина их получает.
+	var eventRepo repository.SubscriptionEventRepository
+	if d.Config.Events.Enabled {
+		eventRepo = d.SubscriptionEventRepo
+	}
+	d.SubscriptionEvents = appService.NewSubscriptionEventRecorder(
+		eventRepo,
+		d.DeadLetterRepo,
+		d.Database,
+		d.Config.Events.Delivery,
+		d.Config.Events.AsyncTimeoutDuration(),
+		d.EventBus,
+		d.Logger,
+	)
+	// Асинхронные записи событий идут через шину, поэтому закрываются раньше неё.
+	d.ShutdownHooks.Register(shutdown.PhaseOutbox, "subscription_events", func(context.Context) error {
+		d.SubscriptionEvents.Close()
+		return nil
+	})
+	d.ShutdownHooks.Register(shutdown.PhaseOutbox, "event_bus", func(context.Context) error {
+		d.EventBus.Close()
+		return nil
+	})
+
+	d.ServiceNameRules = appService.NewServiceNameRules(
+		d.ServiceNameRuleRepo,
+		d.Config.ServiceNames.CacheTTLDuration(),
+		d.Logger,
+	)
+	d.CanonicalServiceNames = appService.NewCanonicalServiceNames(
+		d.CanonicalNameRepo,
+		d.Config.ServiceNames.CacheTTLDuration(),
+		d.Logger,
+	)
+	d.SubscriptionLimits = appService.NewSubscriptionLimits(
+		d.SubscriptionLimitRepo,
+		d.SubscriptionRepo,
+		d.Config.Limits.MaxSubscriptionsPerUser,
+		d.Logger,
+	)
+
+	billing, err := models.ParseBillingMode(d.Config.Billing.Mode)
+	if err != nil {
+		return fmt.Errorf("billing.mode: %w", err)
+	}
+
+	// Удалённого провайдера флагов пока нет: порт featureflags.Provider
+	// подключается вторым аргументом, когда он появится.
+	d.FeatureFlags = featureflags.New(featureflags.NewStatic(d.Config.FeatureFlags.Rules()), nil, d.Logger)
+
+	var costObserver appService.CostCacheObserver
+	if d.Metrics != nil {
+		costObserver = d.Metrics
+	}
+	d.CostCache = appService.NewCostCache(
+		d.Config.Reports.CostCacheTTLDuration(),
+		d.Config.Reports.CostCacheMaxEntries,
+		costObserver,
+		d.Logger,
+	)
+	if d.CostCache != nil {
+		d.EventBus.Subscribe("cost-cache", d.CostCache.ObserveEvent, appService.CostCacheEventTypes...)
+	}
+
+	d.SubscriptionService = appService.NewSubscriptionService(d.SubscriptionRepo, d.DiscountRepo, d.PlanRepo, d.CatalogRepo, d.Database, d.SubscriptionEvents, d.ServiceNameRules, d.CanonicalServiceNames, d.CostCache, d.SubscriptionLimits, billing, d.FeatureFlags, d.Logger)
+
+	d.BulkService = appService.NewSubscriptionBulkService(d.SubscriptionService, d.Database, d.Logger)
+
+	d.DiscountService = appService.NewDiscountService(d.DiscountRepo, d.Logger)
+
+	d.DeadLetterService = appService.NewDeadLetterService(d.DeadLetterRepo, d.Logger)
+
+	d.PlanService = appService.NewPlanService(d.PlanRepo, d.Database, d.ServiceNameRules, d.Logger)
+
+	d.CatalogService = appService.NewServiceCatalogService(d.CatalogRepo, d.ServiceNameRules, d.CanonicalServiceNames, d.Logger)
+
+	d.CostAlertService = appService.NewCostAlertService(
+		d.CostAlertRepo,
+		d.SubscriptionService,
+		d.SubscriptionEventRepo,
+		d.Database,
+		d.EventBus,
+		d.Config.CostAlerts.BatchSize,
+		d.Logger,
+	)
+
+	d.CalendarFeedService = appService.NewCalendarFeedService(d.CalendarFeedRepo, d.SubscriptionRepo, d.Logger)
+
+	d.CommentService = appService.NewSubscriptionCommentService(d.CommentRepo, d.SubscriptionRepo, d.Logger)
+	d.MemberService = appService.NewSubscriptionMemberService(d.MemberRepo, d.SubscriptionRepo, d.CostCache, d.Logger)
+
+	d.SpendReportService = appService.NewSpendReportService(
+		d.SubscriptionRepo,
+		d.Config.Reports.Shards,
+		d.Config.Reports.Parallelism,
+		d.Config.Reports.TimeoutDuration(),
+		billing,
+		d.Logger,
+	)
+
+	d.AnalyticsService = appService.NewAnalyticsService(
+		d.SubscriptionRepo,
+		billing,
+		d.Config.Reports.AnalyticsCacheTTLDuration(),
+		d.Logger,
+	)
+
+	if d.Config.Reminders.Enabled {
+		d.ExpiryReminderService = appService.NewExpiryReminderService(
+			d.SubscriptionRepo,
+			d.SubscriptionEventRepo,
+			d.Database,
+			d.EventBus,
+			d.Config.Reminders.DaysBefore,
+			d.Config.Reminders.BatchSize,
+			d.Logger,
+		)
+	}
+
+	if d.Config.Archive.Enabled {
+		d.ArchiveService = appService.NewSubscriptionArchiveService(
+			d.SubscriptionRepo,
+			d.Config.Archive.AfterMonths,
+			d.Config.Archive.BatchSize,
+			d.CostCache,
+			d.Logger,
+		)
+	}
+
+	if d.Config.ServiceNames.Normalize.Enabled {
+		d.NormalizationService = appService.NewServiceNameNormalizationService(
+			d.CanonicalNameRepo,
+			d.SubscriptionService,
+			d.Config.ServiceNames.Normalize.BatchSize,
+			d.Logger,
+		)
+	}
+
+	if d.Config.Partitions.Enabled {
+		d.PartitionService = appService.NewPartitionMaintenanceService(
+			d.PartitionRepo,
+			d.Config.Partitions.YearsAhead,
+			d.Logger,
+		)
+	}
+
+	auth := d.Config.Auth
+	d.AuthService = appService.NewAuthService(
+		d.APIKeyRepo,
+		auth.AdminKey,
+		appService.JWTOptions{
+			Secret:    auth.JWT.Secret,
+			Issuer:    auth.JWT.Issuer,
+			Audience:  auth.JWT.Audience,
+			RoleClaim: auth.JWT.RoleClaim,
+		},
+		d.Logger,
+	)
+
+	if d.Config.BillingCommands.Enabled {
+		d.BillingCommandService = appService.NewBillingCommandService(
+			d.SubscriptionService,
+			d.BillingCommandRepo,
+			d.Database,
+			d.Logger,
+		)
+	}
+
+	if d.Storage != nil {
+		d.ArtifactService = appService.NewArtifactService(
+			d.Storage,
+			d.Config.Storage.URLTTLDuration(),
+			d.Config.Storage.RetentionDuration(),
+			d.Logger,
+		)
+	}
+
+	if d.Config.Consistency.Enabled {
+		d.ConfigConsistencyService = appService.NewConfigConsistencyService(
+			d.ConfigFingerprintRepo,
+			d.Config.Hash(),
+			d.Config.Consistency.StaleAfterDuration(),
+			d.Logger,
+		)
+	}
 
 	d.Logger.Info("services initialized successfully")
 	return nil
 }
 
+func (d *Dependencies) initLiveStats() error {
+	if !d.Config.Metrics.Live.Enabled {
+		return nil
+	}
+
+	d.Logger.Info("initializing live stats")
+
+	d.LiveStats = livestats.New(d.Config.Metrics.Live.IntervalDuration())
+	// Живые SSE-потоки сами не завершатся, закрываем их до остановки сервера.
+	d.ShutdownHooks.Register(shutdown.PhaseServer, "live_stats", func(context.Context) error {
+		d.LiveStats.Stop()
+		return nil
+	})
+
+	d.Logger.Info("live stats initialized successfully")
+	return nil
+}
+
+func (d *Dependencies) initLiveHub() error {
+	if !d.Config.WebSocket.Enabled {
+		return nil
+	}
+
+	d.Logger.Info("initializing websocket hub")
+
+	wsCfg := d.Config.WebSocket
+	d.LiveHub = ws.NewHub(ws.Config{
+		MaxConnections:        wsCfg.MaxConnections,
+		MaxConnectionsPerUser: wsCfg.MaxConnectionsPerUser,
+		PingInterval:          wsCfg.PingIntervalDuration(),
+		SendBuffer:            wsCfg.SendBuffer,
+	}, d.SubscriptionService, d.PublicIDs, d.Logger)
+	d.EventBus.Subscribe("websocket", d.LiveHub.Publish)
+	// WebSocket-соединения после hijack не видны http.Server.Shutdown.
+	d.ShutdownHooks.Register(shutdown.PhaseServer, "live_hub", func(context.Context) error {
+		d.LiveHub.Stop()
+		return nil
+	})
+
+	d.Logger.Info("websocket hub initialized successfully")
+	return nil
+}
+
+func (d *Dependencies) initBillingConsumer() error {
+	if d.BillingCommandService == nil {
+		return nil
+	}
+
+	d.Logger.Info("initializing billing command consumer")
+
+	bcCfg := d.Config.BillingCommands
+	d.BillingConsumer = rabbitmq.NewConsumer(rabbitmq.Config{
+		URL:               bcCfg.URL,
+		Queue:             bcCfg.Queue,
+		ResultExchange:    bcCfg.ResultExchange,
+		ResultRoutingKey:  bcCfg.ResultRoutingKey,
+		Prefetch:          bcCfg.Prefetch,
+		ReconnectInterval: bcCfg.ReconnectIntervalDuration(),
+		RetryDelay:        bcCfg.RetryDelayDuration(),
+		HandleTimeout:     bcCfg.HandleTimeoutDuration(),
+	}, d.BillingCommandService, d.PublicIDs.Shared(), d.Logger)
+	d.ShutdownHooks.Register(shutdown.PhaseWorkers, "billing_consumer", func(context.Context) error {
+		d.BillingConsumer.Stop()
+		return nil
+	})
+
+	d.Logger.Info("billing command consumer initialized successfully")
+	return nil
+}
+
+// initHealth собирает проверки зависимостей: без БД сервис бесполезен,
+// остальные зависимости только снижают функциональность. Критичные же
+// проверки питают liveness через server.Readiness.
+func (d *Dependencies) initHealth() error {
+	hc := d.Config.Health
+
+	d.HealthChecks = health.NewRegistry(hc.CacheTTLDuration(), hc.TimeoutDuration())
+	dbCheck := d.Database.HealthCheck
+	if b := d.breakerFor("postgres"); b != nil {
+		// Пока база недоступна, пробы не ждут таймаут пинга на каждом опросе.
+		dbCheck = func(ctx context.Context) error {
+			return b.Execute(func() error { return d.Database.HealthCheck(ctx) })
+		}
+	}
+	d.HealthChecks.Register("postgres", true, dbCheck)
+	d.HealthChecks.Register("event_bus", false, d.EventBus.HealthCheck)
+	if d.BillingConsumer != nil {
+		d.HealthChecks.Register("billing_commands", false, d.BillingConsumer.HealthCheck)
+	}
+	if d.Storage != nil {
+		d.HealthChecks.Register("object_storage", false, d.Storage.HealthCheck)
+	}
+	if d.DedupStore != nil {
+		d.HealthChecks.Register("redis", false, d.DedupStore.HealthCheck)
+	}
+
+	d.Readiness = server.NewReadiness(server.ReadinessConfig{
+		Warmup:           hc.WarmupDuration(),
+		CheckInterval:    hc.LivenessIntervalDuration(),
+		FailureThreshold: hc.LivenessFailureThreshold,
+	}, func(ctx context.Context) error {
+		report := d.HealthChecks.Check(ctx)
+		if !report.Ready() {
+			return fmt.Errorf("critical checks failed: %s", strings.Join(report.FailedCritical(), ", "))
+		}
+		return nil
+	}, d.Logger)
+
+	mc := d.Config.Maintenance
+	d.Maintenance = maintenance.NewSwitch(mc.Enabled, mc.Reason, maintenance.ReadMode(mc.Reads), d.Logger)
+	// В режиме обслуживания реплика не готова: балансировщик уводит трафик.
+	d.Readiness.SetMaintenance(d.Maintenance)
+
+	lo := d.Config.Logger.LevelOverride
+	d.LogLevel = loglevel.NewSwitch(d.Logger, lo.DefaultDurationValue(), lo.MaxDurationValue(), d.Logger)
+
+	return nil
+}
+
 func (d *Dependencies) initHandlers() error {
 	d.Logger.Info("initializing handlers")
 
-	d.SubscriptionHandler = handlers.NewSubscriptionHandler(d.SubscriptionService, d.Logger)
+	d.SubscriptionHandler = handlers.NewSubscriptionHandler(d.SubscriptionService, d.CommentService, d.CostAlertService, d.PublicIDs, d.Logger)
+	d.SubscriptionV2Handler = handlers.NewSubscriptionV2Handler(d.SubscriptionService, d.PublicIDs, d.Logger)
+	d.BulkHandler = handlers.NewSubscriptionBulkHandler(d.BulkService, d.PublicIDs, d.Logger)
+	d.PlanHandler = handlers.NewPlanHandler(d.PlanService, d.Logger)
+	d.CatalogHandler = handlers.NewServiceCatalogHandler(d.CatalogService, d.Logger)
+	d.CostAlertHandler = handlers.NewCostAlertHandler(d.CostAlertService, d.Logger)
+	d.CalendarFeedHandler = handlers.NewCalendarFeedHandler(d.CalendarFeedService, d.PublicIDs, d.Logger)
+	d.MemberHandler = handlers.NewSubscriptionMemberHandler(d.MemberService, d.PublicIDs, d.Logger)
 
-	d.HealthHandler = handlers.NewHealthHandler(d.Logger, func(ctx context.Context) error {
-		return d.Database.HealthCheck(ctx)
-	})
+	d.AdminHandler = handlers.NewAdminHandler(
+		d.ConfigConsistencyService,
+		d.SpendReportService,
+		d.ServiceNameRules,
+		d.DiscountService,
+		d.AnalyticsService,
+		d.DeadLetterService,
+		d.LiveStats,
+		d.Logger,
+	)
+
+	d.AccessHandler = handlers.NewAccessHandler(d.AuthService, d.Config.Auth.Enabled, d.Logger)
+
+	if d.LiveHub != nil {
+		var auth service.AuthService
+		if d.Config.Auth.Enabled {
+			auth = d.AuthService
+		}
+		d.LiveUpdatesHandler = handlers.NewLiveUpdatesHandler(d.LiveHub, auth, d.Logger)
+	}
+
+	d.HealthHandler = handlers.NewHealthHandler(d.Logger, d.HealthChecks, d.Readiness, d.Breakers)
+	d.VersionHandler = handlers.NewVersionHandler(buildinfo.Get())
+	d.MaintenanceHandler = handlers.NewMaintenanceHandler(d.Maintenance, d.Logger)
+	d.DBPoolHandler = handlers.NewDBPoolHandler(d.Database, d.Logger)
+	d.LogLevelHandler = handlers.NewLogLevelHandler(d.LogLevel, d.Logger)
+	d.ServiceNamesHandler = handlers.NewCanonicalServiceNameHandler(d.CanonicalServiceNames, d.Logger)
+	d.LimitHandler = handlers.NewSubscriptionLimitHandler(d.SubscriptionLimits, d.Logger)
+	if d.ArtifactService != nil {
+		d.ExportHandler = handlers.NewSubscriptionExportHandler(d.SubscriptionService, d.ArtifactService, d.PublicIDs, d.Logger)
+	}
 
 	d.Logger.Info("handlers initialized successfully")
 	return nil
 }
 
+func (d *Dependencies) initMetrics() error {
+	if !d.Config.Metrics.Enabled {
+		return nil
+	}
+
+	d.Logger.Info("initializing metrics")
+
+	d.Metrics = metrics.New()
+
+	d.Logger.Info("metrics initialized successfully")
+	return nil
+}
+
+// initBreakers создаёт автоматы защиты внешних зависимостей. Переходы
+// пишутся в лог и, если включены метрики, в circuit_breaker_state.
+func (d *Dependencies) initBreakers() error {
+	cc := d.Config.CircuitBreaker
+	if !cc.Enabled {
+		return nil
+	}
+
+	log := d.Logger.Named("circuit-breaker")
+	d.Breakers = breaker.NewGroup(breaker.Settings{
+		FailureThreshold: cc.FailureThreshold,
+		OpenTimeout:      cc.OpenTimeoutDuration(),
+		HalfOpenMaxCalls: cc.HalfOpenMaxCalls,
+		OnStateChange: func(name string, from, to breaker.State) {
+			logf := log.Info
+			if to == breaker.StateOpen {
+				logf = log.Warn
+			}
+			logf("circuit breaker state changed",
+				zap.String("breaker", name),
+				zap.String("from", from.String()),
+				zap.String("to", to.String()))
+			if d.Metrics != nil {
+				d.Metrics.ObserveBreakerTransition(name, from, to)
+			}
+		},
+	})
+	return nil
+}
+
+// breakerFor возвращает автомат зависимости name или nil, если автоматы
+// отключены.
+func (d *Dependencies) breakerFor(name string) *breaker.Breaker {
+	if d.Breakers == nil {
+		return nil
+	}
+	b := d.Breakers.Get(name)
+	if d.Metrics != nil {
+		d.Metrics.SetBreakerState(name, b.State())
+	}
+	return b
+}
+
+func (d *Dependencies) initScheduler() error {
+	d.Logger.Info("initializing scheduler")
+
+	var locks repository.LockProvider
+	if d.Config.Scheduler.DistributedLocks {
+		locks = postgres.NewAdvisoryLocks(d.Database, d.Config.Scheduler.LockCheckIntervalDuration(), d.Logger)
+	}
+
+	d.Scheduler = worker.NewScheduler(locks, d.Logger)
+	d.ShutdownHooks.Register(shutdown.PhaseWorkers, "scheduler", func(context.Context) error {
+		d.Scheduler.Stop()
+		return nil
+	})
+
+	if d.Metrics != nil || d.LiveStats != nil {
+		d.Scheduler.Register(worker.NewBusinessKPIsJob(
+			d.SubscriptionService,
+			d.Metrics,
+			d.LiveStats,
+			d.Config.Metrics.KPIRefreshIntervalDuration(),
+		))
+	}
+
+	if d.ExpiryReminderService != nil {
+		d.Scheduler.Register(worker.NewExpiryRemindersJob(
+			d.ExpiryReminderService,
+			d.Config.Reminders.IntervalDuration(),
+		))
+	}
+
+	if d.Config.CostAlerts.Enabled {
+		d.Scheduler.Register(worker.NewCostAlertsJob(
+			d.CostAlertService,
+			d.Config.CostAlerts.IntervalDuration(),
+		))
+	}
+
+	if d.ArchiveService != nil {
+		d.Scheduler.Register(worker.NewSubscriptionArchiveJob(
+			d.ArchiveService,
+			d.Config.Archive.IntervalDuration(),
+		))
+	}
+
+	if d.PartitionService != nil {
+		d.Scheduler.Register(worker.NewPartitionMaintenanceJob(
+			d.PartitionService,
+			d.Config.Partitions.IntervalDuration(),
+		))
+	}
+
+	if d.NormalizationService != nil {
+		d.Scheduler.Register(worker.NewServiceNameNormalizationJob(
+			d.NormalizationService,
+			d.Config.ServiceNames.Normalize.IntervalDuration(),
+		))
+	}
+
+	if d.ArtifactService != nil {
+		d.Scheduler.Register(worker.NewArtifactCleanupJob(
+			d.ArtifactService,
+			d.Config.Storage.CleanupIntervalDuration(),
+		))
+	}
+
+	d.Logger.Info("scheduler initialized successfully")
+	return nil
+}
+
+func (d *Dependencies) initWatchdog() error {
+	if !d.Config.Watchdog.Enabled {
+		return nil
+	}
+
+	d.Logger.Info("initializing watchdog")
+
+	var notifier watchdog.Notifier
+	if d.Config.Watchdog.WebhookURL != "" {
+		notifier = watchdog.NewWebhookNotifier(
+			d.Config.Watchdog.WebhookURL,
+			d.Config.Watchdog.WebhookTimeoutDuration(),
+		)
+		if b := d.breakerFor("watchdog_webhook"); b != nil {
+			notifier = watchdog.NewBreakerNotifier(notifier, b)
+		}
+	} else {
+		d.Logger.Warn("watchdog webhook_url is empty, alerts will only be logged")
+	}
+
+	d.Watchdog = watchdog.New(d.Config.Watchdog, notifier, d.Logger)
+	d.ShutdownHooks.Register(shutdown.PhaseWorkers, "watchdog", func(context.Context) error {
+		d.Watchdog.Stop()
+		return nil
+	})
+
+	d.Logger.Info("watchdog initialized successfully")
+	return nil
+}
+
 func (d *Dependencies) initRouter() error {
 	d.Logger.Info("initializing router")
 
@@ -121,54 +908,235 @@ func (d *Dependencies) initRouter() error {
 
 	r := router.New(routerConfig)
 
+	d.InFlight = server.NewInFlight()
 	middlewares := []gin.HandlerFunc{
+		d.InFlight.Middleware(),
 		middleware.CORS(),
-		middleware.StructuredLogger(d.Logger),
-		middleware.Recovery(d.Logger),
-		middleware.ErrorHandler(d.Logger),
+		middleware.ServiceVersion(buildinfo.Version),
+	}
+	// Сжатие снаружи Timing и SnapshotFallback: они читают тело ответа в открытом виде.
+	if compression := d.Config.Server.Compression; compression.Enabled {
+		middlewares = append(middlewares, middleware.Compression(compression.Level, compression.MinLength))
+	}
+	if d.Config.Timing.Enabled {
+		middlewares = append(middlewares, middleware.Timing(d.Config.Timing.AllowDebug))
+	}
+	bodies := d.Config.Logger.Bodies
+	middlewares = append(middlewares, middleware.StructuredLogger(d.Logger, middleware.BodyLogOptions{
+		Enabled: bodies.Enabled,
+		MaxSize: bodies.MaxSize,
+		Redact:  bodies.Redact,
+	}))
+	if d.Metrics != nil {
+		middlewares = append(middlewares, middleware.RequestMetrics(d.Metrics))
+	}
+	if d.LiveStats != nil {
+		middlewares = append(middlewares, middleware.LiveStats(d.LiveStats, "/api/v1/subscriptions/", "/api/v2/subscriptions/"))
+	}
+	if d.Watchdog != nil {
+		middlewares = append(middlewares, middleware.Watchdog(d.Watchdog))
+	}
+	middlewares = append(middlewares,
+		middleware.Recovery(d.Logger, d.Config.API.ProblemTypeBaseURI),
+		middleware.ErrorHandler(d.Logger, d.Config.API.ProblemTypeBaseURI),
+	)
+	if d.Config.Server.MaxBodySize > 0 {
+		middlewares = append(middlewares, middleware.BodyLimit(d.Config.Server.MaxBodySize))
+	}
+	if d.Config.Degradation.Enabled {
+		d.Snapshots = snapshot.NewStore(d.Config.Degradation.MaxEntries)
+		middlewares = append(middlewares, middleware.SnapshotFallback(d.Snapshots, d.Config.Degradation.Routes, d.breakerFor("postgres"), d.Logger))
 	}
 	r.SetupMiddleware(middlewares...)
 
-	r.RegisterHealthRoutes()
-	r.RegisterAPIRoutes(
-		d.SubscriptionHandler,
-		d.HealthHandler,
-	)
-	r.RegisterSwaggerRoutes()
+	r.RegisterHealthRoutes(d.HealthHandler)
+	if d.Config.Debug.Enabled {
+		var guard []gin.HandlerFunc
+		if d.Config.Debug.RequireAuth {
+			guard = append(guard, middleware.RequirePermission(d.AuthService, models.PermissionAdminWrite))
+		}
+		r.RegisterDebugRoutes(handlers.NewDebugHandler(d.Database).Pool, guard...)
+	}
+	versions := d.apiVersions()
+	r.RegisterAPIRoutes(versions...)
+	if err := r.RegisterOpenAPIRoutes(apidoc.SpecPath, apidoc.Document(versions, d.Config.Auth.Enabled)); err != nil {
+		return err
+	}
+	if d.Config.AdminUI.Enabled {
+		r.RegisterAdminUI("/admin", adminui.FS())
+	}
+	if d.LiveUpdatesHandler != nil {
+		r.RegisterWebSocketRoute(d.Config.WebSocket.Path, d.LiveUpdatesHandler.Connect)
+	}
+	if d.Metrics != nil {
+		r.RegisterMetricsRoute(d.Config.Metrics.Path, d.Metrics.Handler())
+	}
 
 	d.Router = r
 	d.Logger.Info("router initialized successfully")
 	return nil
 }
 
+// apiVersions собирает включённые версии API. v1 заморожена: несовместимые
+// изменения идут только в v2 и её DTO (transport/http/dto/v2).
+func (d *Dependencies) apiVersions() []router.APIVersion {
+	var versions []router.APIVersion
+
+	timeouts := d.Config.Server.RequestTimeout.RouteDurations()
+	// Поток SSE живёт, пока клиент не отключится, срок ему не нужен.
+	if _, ok := timeouts["/api/v1/admin/live"]; !ok {
+		timeouts["/api/v1/admin/live"] = 0
+	}
+	timeout := middleware.Timeout(d.Config.Server.RequestTimeout.DefaultDuration(), timeouts)
+
+	// Dedup ставится после Authorize: дубли различаются по пользователю.
+	var dedupMiddleware gin.HandlerFunc
+	if d.DedupStore != nil {
+		dedupMiddleware = middleware.Dedup(d.DedupStore, middleware.DedupOptions{
+			Window: d.Config.Dedup.WindowDuration(),
+			Wait:   d.Config.Dedup.WaitDuration(),
+		}, d.Logger)
+	}
+
+	if v1 := d.Config.API.V1; v1.Enabled {
+		version := router.APIVersion{
+			Name:        "v1",
+			Middlewares: []gin.HandlerFunc{timeout, middleware.DateFormat(v1.ResponseDateFormat())},
+			Handlers: []router.RouteHandler{
+				d.SubscriptionHandler,
+				d.BulkHandler,
+				d.PlanHandler,
+				d.CatalogHandler,
+				d.CostAlertHandler,
+				d.CalendarFeedHandler,
+				d.MemberHandler,
+				d.HealthHandler,
+				d.VersionHandler,
+				d.AdminHandler,
+				d.ServiceNamesHandler,
+				d.LimitHandler,
+				d.MaintenanceHandler,
+				d.DBPoolHandler,
+				d.LogLevelHandler,
+				d.AccessHandler,
+			},
+		}
+		if d.ExportHandler != nil {
+			version.Handlers = append(version.Handlers, d.ExportHandler)
+		}
+		if d.Config.Auth.Enabled {
+			version.Middlewares = append(version.Middlewares, middleware.Authorize(d.AuthService, "/api/v1", d.Logger))
+		}
+		version.Middlewares = append(version.Middlewares, middleware.Maintenance(d.Maintenance, d.Snapshots,
+			"/api/v1"+handlers.MaintenancePath, "/api/v1"+handlers.DBPoolPath, "/api/v1"+handlers.LogLevelPath,
+			"/api/v1/health/", "/api/v1/health/ready", "/api/v1/health/live",
+			"/api/v1/version",
+		))
+		if dedupMiddleware != nil {
+			version.Middlewares = append(version.Middlewares, dedupMiddleware)
+		}
+		if v1.Deprecated {
+			policy := middleware.DeprecationPolicy{
+				Since:  v1.DeprecatedSinceTime(),
+				Sunset: v1.SunsetTime(),
+			}
+			if d.Config.API.V2.Enabled {
+				policy.Successor = "/api/v2"
+			}
+			version.Middlewares = append(version.Middlewares, middleware.Deprecation(policy))
+		}
+		versions = append(versions, version)
+	}
+
+	if v2 := d.Config.API.V2; v2.Enabled {
+		version := router.APIVersion{
+			Name:        "v2",
+			Middlewares: []gin.HandlerFunc{timeout, middleware.DateFormat(v2.ResponseDateFormat())},
+			Handlers: []router.RouteHandler{
+				d.SubscriptionV2Handler,
+			},
+		}
+		if d.Config.Auth.Enabled {
+			version.Middlewares = append(version.Middlewares, middleware.Authorize(d.AuthService, "/api/v2", d.Logger))
+		}
+		version.Middlewares = append(version.Middlewares, middleware.Maintenance(d.Maintenance, d.Snapshots))
+		if dedupMiddleware != nil {
+			version.Middlewares = append(version.Middlewares, dedupMiddleware)
+		}
+		versions = append(versions, version)
+	}
+
+	return versions
+}
+
 func (d *Dependencies) initServer() error {
 	d.Logger.Info("initializing server")
 
-	d.Server = server.New(
+	opts := []server.Option{
 		server.WithConfig(d.Config.Server),
 		server.WithLogger(d.Logger),
 		server.WithRouter(d.Router.Engine()),
 		server.WithGracefulShutdown(),
+		server.WithShutdownTimeout(d.Config.Server.ShutdownTimeoutDuration()),
+		server.WithMaxConnections(d.Config.Server.MaxConnections),
+		server.WithReadiness(d.Readiness),
+		server.WithDrainDelay(d.Config.Health.DrainDelayDuration()),
 		server.WithHealthCheck(func(ctx context.Context) error {
 			return d.Database.HealthCheck(ctx)
 		}),
-	)
+	}
+
+	if d.Config.Server.H2C {
+		opts = append(opts, server.WithH2C())
+	}
+
+	if d.Metrics != nil {
+		opts = append(opts, server.WithConnectionObserver(d.Metrics))
+	}
+
+	if tlsCfg := d.Config.Server.TLS; tlsCfg.Enabled {
+		opts = append(opts, server.WithTLS(tlsCfg.CertFile, tlsCfg.KeyFile))
+	}
+
+	d.Server = server.New(opts...)
 
 	d.Server.SetupTimeouts()
 
+	d.ShutdownHooks.Register(shutdown.PhaseServer, "http_server", func(context.Context) error {
+		return d.Server.Shutdown()
+	})
+	d.ShutdownHooks.Register(shutdown.PhaseServer, "in_flight_requests", d.waitInFlight)
+
 	d.Logger.Info("server initialized successfully")
 	return nil
 }
 
-func (d *Dependencies) Close() error {
-	d.Logger.Info("closing dependencies")
+// waitInFlight дожидается хендлеров, которые пережили http.Server.Shutdown
+// по таймауту: воркеры и база закрываются только после них.
+func (d *Dependencies) waitInFlight(ctx context.Context) error {
+	active := d.InFlight.Active()
+	if active == 0 {
+		return nil
+	}
+
+	d.Logger.Warn("waiting for in-flight requests", zap.Int("active_requests", active))
 
-	if d.Database != nil {
-		d.Database.Close()
+	ctx, cancel := context.WithTimeout(ctx, d.Config.Server.ShutdownTimeoutDuration())
+	defer cancel()
+
+	if err := d.InFlight.Wait(ctx); err != nil {
+		return fmt.Errorf("%d requests still in flight: %w", d.InFlight.Active(), err)
 	}
+	return nil
+}
+
+// Close выполняет зарегистрированные хуки остановки по фазам: сервер →
+// воркеры → outbox → база → логгер. Повторный вызов ничего не делает.
+func (d *Dependencies) Close() error {
+	d.Logger.Info("closing dependencies", zap.Strings("order", d.ShutdownHooks.Names()))
 
-	if d.Logger != nil {
-		d.Logger.Sync()
+	if err := d.ShutdownHooks.Run(context.Background(), d.Logger); err != nil {
+		return err
 	}
 
 	d.Logger.Info("dependencies closed successfully")
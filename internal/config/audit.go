@@ -0,0 +1,170 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Redacted подставляется вместо непустых секретов в Sanitized.
+const Redacted = "[REDACTED]"
+
+// Source — откуда взято значение ключа конфигурации.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+)
+
+// AuditEntry — одно итоговое значение конфигурации для лога запуска.
+type AuditEntry struct {
+	Value  interface{} `json:"value"`
+	Source Source      `json:"source"`
+}
+
+// Sanitized возвращает копию конфигурации, безопасную для логов: пароли,
+// ключи и соли заменены на Redacted, пароль в URL — на xxxxx.
+func (c *Config) Sanitized() Config {
+	s := *c
+
+	s.Database.Password = redact(s.Database.Password)
+	s.PublicIDs.Salt = redact(s.PublicIDs.Salt)
+	s.PublicIDs.Tenants = make(map[string]PublicIDTenantConfig, len(c.PublicIDs.Tenants))
+	for tenant, tc := range c.PublicIDs.Tenants {
+		tc.Salt = redact(tc.Salt)
+		s.PublicIDs.Tenants[tenant] = tc
+	}
+	s.Encryption.Keys = redact(s.Encryption.Keys)
+	s.Encryption.IndexKey = redact(s.Encryption.IndexKey)
+	s.Auth.AdminKey = redact(s.Auth.AdminKey)
+	s.Auth.JWT.Secret = redact(s.Auth.JWT.Secret)
+	s.Storage.SecretKey = redact(s.Storage.SecretKey)
+	s.Redis.Password = redact(s.Redis.Password)
+	s.BillingCommands.URL = redactURLCredentials(s.BillingCommands.URL)
+	// Токен вебхука (Slack, PagerDuty) обычно зашит в путь, оставляем только хост.
+	s.Watchdog.WebhookURL = redactURLPath(s.Watchdog.WebhookURL)
+
+	// Срезы общие с исходной конфигурацией; Sanitized их не меняет, но
+	// копия не должна давать способа изменить оригинал.
+	s.Server.RequestTimeout.Routes = append([]RouteTimeout(nil), c.Server.RequestTimeout.Routes...)
+	s.Degradation.Routes = append([]DegradationRoute(nil), c.Degradation.Routes...)
+
+	return s
+}
+
+// Source сообщает, откуда Load взял значение key (например "server.port").
+// Для конфигурации, собранной не через Load, всё считается значениями по умолчанию.
+func (c *Config) Source(key string) Source {
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+	return SourceDefault
+}
+
+// Audit — итоговая конфигурация без секретов, по ключу с точками, с
+// источником каждого значения. Пишется в лог при старте.
+func (c *Config) Audit() map[string]AuditEntry {
+	sanitized := c.Sanitized()
+
+	entries := make(map[string]AuditEntry)
+	flatten(reflect.ValueOf(sanitized), "", func(key string, value interface{}) {
+		entries[key] = AuditEntry{Value: value, Source: c.Source(key)}
+	})
+	return entries
+}
+
+// SourceCounts — сколько ключей пришло из каждого источника.
+func (c *Config) SourceCounts() map[Source]int {
+	counts := map[Source]int{SourceDefault: 0, SourceFile: 0, SourceEnv: 0}
+	for _, entry := range c.Audit() {
+		counts[entry.Source]++
+	}
+	return counts
+}
+
+// recordSources запоминает источник каждого известного viper ключа.
+// Переменная окружения перекрывает файл так же, как при чтении значений.
+func (c *Config) recordSources(v *viper.Viper, fromFile bool) {
+	keys := v.AllKeys()
+	c.sources = make(map[string]Source, len(keys))
+	for _, key := range keys {
+		switch {
+		case envSet(key):
+			c.sources[key] = SourceEnv
+		case fromFile && v.InConfig(key):
+			c.sources[key] = SourceFile
+		default:
+			c.sources[key] = SourceDefault
+		}
+	}
+}
+
+func envSet(key string) bool {
+	names, ok := envAliases[key]
+	if !ok {
+		names = []string{strings.ToUpper(strings.ReplaceAll(key, ".", "_"))}
+	}
+	for _, name := range names {
+		// Пустые переменные viper игнорирует (AllowEmptyEnv выключен).
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// flatten обходит структуру по тегам mapstructure; срезы и прочие
+// не-структуры отдаются целиком.
+func flatten(v reflect.Value, prefix string, emit func(key string, value interface{})) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if tag == "" || !field.IsExported() {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+
+		value := v.Field(i)
+		if value.Kind() == reflect.Struct {
+			flatten(value, key, emit)
+			continue
+		}
+		emit(key, value.Interface())
+	}
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return Redacted
+}
+
+func redactURLCredentials(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redact(raw)
+	}
+	return u.Redacted()
+}
+
+func redactURLPath(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return Redacted
+	}
+	return u.Scheme + "://" + u.Host + "/" + Redacted
+}
@@ -1,24 +1,96 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/featureflags"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
 )
 
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Logger   LoggerConfig   `mapstructure:"logger"`
+	Server          ServerConfig          `mapstructure:"server"`
+	Database        DatabaseConfig        `mapstructure:"database"`
+	Logger          LoggerConfig          `mapstructure:"logger"`
+	Watchdog        WatchdogConfig        `mapstructure:"watchdog"`
+	Degradation     DegradationConfig     `mapstructure:"degradation"`
+	Maintenance     MaintenanceConfig     `mapstructure:"maintenance"`
+	Consistency     ConsistencyConfig     `mapstructure:"consistency"`
+	PublicIDs       PublicIDsConfig       `mapstructure:"public_ids"`
+	Timing          TimingConfig          `mapstructure:"timing"`
+	Metrics         MetricsConfig         `mapstructure:"metrics"`
+	Events          EventsConfig          `mapstructure:"events"`
+	Reports         ReportsConfig         `mapstructure:"reports"`
+	ServiceNames    ServiceNamesConfig    `mapstructure:"service_names"`
+	API             APIConfig             `mapstructure:"api"`
+	Billing         BillingConfig         `mapstructure:"billing"`
+	Limits          LimitsConfig          `mapstructure:"limits"`
+	Reminders       RemindersConfig       `mapstructure:"reminders"`
+	CostAlerts      CostAlertsConfig      `mapstructure:"cost_alerts"`
+	Archive         ArchiveConfig         `mapstructure:"archive"`
+	Storage         StorageConfig         `mapstructure:"storage"`
+	Redis           RedisConfig           `mapstructure:"redis"`
+	Dedup           DedupConfig           `mapstructure:"dedup"`
+	Partitions      PartitionsConfig      `mapstructure:"partitions"`
+	Scheduler       SchedulerConfig       `mapstructure:"scheduler"`
+	Encryption      EncryptionConfig      `mapstructure:"encryption"`
+	Auth            AuthConfig            `mapstructure:"auth"`
+	AdminUI         AdminUIConfig         `mapstructure:"admin_ui"`
+	WebSocket       WebSocketConfig       `mapstructure:"websocket"`
+	BillingCommands BillingCommandsConfig `mapstructure:"billing_commands"`
+	Health          HealthConfig          `mapstructure:"health"`
+	Debug           DebugConfig           `mapstructure:"debug"`
+	CircuitBreaker  CircuitBreakerConfig  `mapstructure:"circuit_breaker"`
+	FeatureFlags    FeatureFlagsConfig    `mapstructure:"feature_flags"`
+
+	// sources — источник каждого ключа после Load, см. Source.
+	sources map[string]Source
 }
 
 type ServerConfig struct {
-	Host         string `mapstructure:"host"`
-	Port         string `mapstructure:"port"`
-	ReadTimeout  int    `mapstructure:"read_timeout"`
-	WriteTimeout int    `mapstructure:"write_timeout"`
-	IdleTimeout  int    `mapstructure:"idle_timeout"`
+	Host            string            `mapstructure:"host"`
+	Port            string            `mapstructure:"port"`
+	ReadTimeout     int               `mapstructure:"read_timeout"`
+	WriteTimeout    int               `mapstructure:"write_timeout"`
+	IdleTimeout     int               `mapstructure:"idle_timeout"`
+	TLS             TLSConfig         `mapstructure:"tls"`
+	H2C             bool              `mapstructure:"h2c"`
+	MaxConnections  int               `mapstructure:"max_connections"`
+	ShutdownTimeout int               `mapstructure:"shutdown_timeout"`
+	MaxBodySize     int64             `mapstructure:"max_body_size"`
+	Compression     CompressionConfig `mapstructure:"compression"`
+	RequestTimeout  RequestTimeout    `mapstructure:"request_timeout"`
+}
+
+// RequestTimeout — срок обработки запроса к /api в секундах: Default для
+// всех маршрутов, Routes — переопределения по шаблону пути gin
+// (/api/v1/subscriptions/export). 0 снимает ограничение.
+type RequestTimeout struct {
+	Default int            `mapstructure:"default"`
+	Routes  []RouteTimeout `mapstructure:"routes"`
+}
+
+type RouteTimeout struct {
+	Path    string `mapstructure:"path"`
+	Timeout int    `mapstructure:"timeout"`
+}
+
+type CompressionConfig struct {
+	Enabled   bool `mapstructure:"enabled"`
+	Level     int  `mapstructure:"level"`
+	MinLength int  `mapstructure:"min_length"`
+}
+
+type TLSConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	CertFile string `mapstructure:"cert"`
+	KeyFile  string `mapstructure:"key"`
 }
 
 type DatabaseConfig struct {
@@ -29,42 +101,730 @@ type DatabaseConfig struct {
 	DBName       string `mapstructure:"db_name"`
 	SSLMode      string `mapstructure:"ssl_mode"`
 	MaxOpenConns int    `mapstructure:"max_open_conns"`
-	MaxIdleConns int    `mapstructure:"max_idle_conns"`
-	MaxLifetime  int    `mapstructure:"max_lifetime"`
+	// MaxIdleConns — прежнее имя MinConns: пул держит столько соединений
+	// открытыми. Используется, если min_conns не задан.
+	MaxIdleConns int  `mapstructure:"max_idle_conns"`
+	MaxLifetime  int  `mapstructure:"max_lifetime"`
+	AutoMigrate  bool `mapstructure:"auto_migrate"`
+	// MinConns — сколько соединений пул держит открытыми; 0 — max_idle_conns.
+	MinConns int `mapstructure:"min_conns"`
+	// MinIdleConns — сколько свободных соединений пул готовит заранее, чтобы
+	// запрос в пик не ждал установки соединения; 0 — не готовить.
+	MinIdleConns int `mapstructure:"min_idle_conns"`
+	// MaxConnIdleTime — через сколько секунд простоя соединение закрывается.
+	MaxConnIdleTime int `mapstructure:"max_conn_idle_time"`
+	// HealthCheckPeriod — как часто (в секундах) пул проверяет свободные
+	// соединения и добирает MinConns.
+	HealthCheckPeriod int `mapstructure:"health_check_period"`
+	// MaxLifetimeJitter — случайная добавка к max_lifetime в секундах, чтобы
+	// соединения не переоткрывались все разом.
+	MaxLifetimeJitter int `mapstructure:"max_lifetime_jitter"`
+	// MaxConnsLimit — верхняя граница max_conns при изменении размера пула
+	// через /admin/db/pool; 0 — max_open_conns, то есть только уменьшать.
+	MaxConnsLimit int `mapstructure:"max_conns_limit"`
+	// StatementTimeoutMs — statement_timeout сессий пула в миллисекундах;
+	// 0 — без ограничения.
+	StatementTimeoutMs int `mapstructure:"statement_timeout_ms"`
+	// SlowQueryThresholdMs — запросы дольше порога пишутся в лог; 0 — не писать.
+	SlowQueryThresholdMs int `mapstructure:"slow_query_threshold_ms"`
+	// StatementCacheCapacity — подготовленных выражений на соединение; 0 —
+	// не готовить (нужно за PgBouncer в режиме transaction).
+	StatementCacheCapacity int `mapstructure:"statement_cache_capacity"`
+	// Retry — повторы операций репозитория подписок при временных сбоях базы.
+	Retry DatabaseRetryConfig `mapstructure:"retry"`
+	// QueryLog — журнал всех запросов на уровне debug.
+	QueryLog DatabaseQueryLogConfig `mapstructure:"query_log"`
+}
+
+/*
+DatabaseRetryConfig — политика повторов: ReadAttempts для чтений,
+WriteAttempts для идемпотентных записей (обновление и удаление подписки);
+попытки считаются вместе с первой, 1 — без повторов. Задержка растёт
+экспоненциально от BaseDelayMs до MaxDelayMs со случайным разбросом.
+*/
+type DatabaseRetryConfig struct {
+	Enabled       bool `mapstructure:"enabled"`
+	ReadAttempts  int  `mapstructure:"read_attempts"`
+	WriteAttempts int  `mapstructure:"write_attempts"`
+	BaseDelayMs   int  `mapstructure:"base_delay_ms"`
+	MaxDelayMs    int  `mapstructure:"max_delay_ms"`
+}
+
+/*
+DatabaseQueryLogConfig — журнал SQL: каждый запрос пишется на уровне debug
+с request_id и trace_id HTTP-запроса. SampleRate — доля HTTP-запросов
+(от 0 до 1), чьи запросы попадают в журнал; записи видны только при
+logger.level debug.
+*/
+type DatabaseQueryLogConfig struct {
+	Enabled    bool    `mapstructure:"enabled"`
+	SampleRate float64 `mapstructure:"sample_rate"`
 }
 
 type LoggerConfig struct {
 	Level       string `mapstructure:"level"`
 	Development bool   `mapstructure:"development"`
 	Encoding    string `mapstructure:"encoding"`
+	// Outputs — выходы логгера; пусто — только stdout в формате Encoding.
+	Outputs []LoggerOutputConfig `mapstructure:"outputs"`
+	// LevelOverride — сроки временной смены уровня через /admin/log-level.
+	LevelOverride LoggerLevelOverrideConfig `mapstructure:"level_override"`
+	// Bodies — тела запросов и ответов с ошибкой в access-логе.
+	Bodies LoggerBodiesConfig `mapstructure:"bodies"`
+}
+
+/*
+LoggerBodiesConfig — запись тел в access-лог. Значения полей из Redact
+(пути JSON, см. middleware.BodyLogOptions) заменяются на [REDACTED], тело
+длиннее MaxSize байт обрезается с пометкой. Тела не в JSON не пишутся.
+*/
+type LoggerBodiesConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	MaxSize int      `mapstructure:"max_size"`
+	Redact  []string `mapstructure:"redact"`
+}
+
+// LoggerLevelOverrideConfig — сроки в секундах, через которые уровень,
+// изменённый через /admin/log-level, возвращается к logger.level.
+type LoggerLevelOverrideConfig struct {
+	DefaultDuration int `mapstructure:"default_duration"`
+	MaxDuration     int `mapstructure:"max_duration"`
+}
+
+/*
+LoggerOutputConfig — один выход: stdout, stderr или файл с ротацией по
+размеру. Encoding пустой — берётся logger.encoding. Для файла MaxSizeMB
+0 — 100 МБ, MaxBackups и MaxAgeDays 0 — без ограничения.
+*/
+type LoggerOutputConfig struct {
+	Type       string `mapstructure:"type"`
+	Encoding   string `mapstructure:"encoding"`
+	Path       string `mapstructure:"path"`
+	MaxSizeMB  int    `mapstructure:"max_size_mb"`
+	MaxBackups int    `mapstructure:"max_backups"`
+	MaxAgeDays int    `mapstructure:"max_age_days"`
+	Compress   bool   `mapstructure:"compress"`
+}
+
+type WatchdogConfig struct {
+	Enabled              bool    `mapstructure:"enabled"`
+	Window               int     `mapstructure:"window"`
+	CheckInterval        int     `mapstructure:"check_interval"`
+	BreachDuration       int     `mapstructure:"breach_duration"`
+	RepeatInterval       int     `mapstructure:"repeat_interval"`
+	MinRequests          int     `mapstructure:"min_requests"`
+	ServerErrorThreshold float64 `mapstructure:"server_error_threshold"`
+	DBErrorThreshold     float64 `mapstructure:"db_error_threshold"`
+	WebhookURL           string  `mapstructure:"webhook_url"`
+	WebhookTimeout       int     `mapstructure:"webhook_timeout"`
+}
+
+type DegradationConfig struct {
+	Enabled    bool               `mapstructure:"enabled"`
+	MaxEntries int                `mapstructure:"max_entries"`
+	Routes     []DegradationRoute `mapstructure:"routes"`
+}
+
+type DegradationRoute struct {
+	Path         string `mapstructure:"path"`
+	MaxStaleness int    `mapstructure:"max_staleness"`
+}
+
+// MaintenanceConfig — состояние режима обслуживания при старте; Reads —
+// что делать с чтениями: allow, cache (из снимков degradation) или deny.
+type MaintenanceConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Reason  string `mapstructure:"reason"`
+	Reads   string `mapstructure:"reads"`
+}
+
+type ConsistencyConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	Interval   int  `mapstructure:"interval"`
+	StaleAfter int  `mapstructure:"stale_after"`
+}
+
+// PublicIDsConfig — общие настройки публичных идентификаторов; Tenants —
+// свои настройки для отдельных пользователей (по user_id).
+type PublicIDsConfig struct {
+	Mode       string                          `mapstructure:"mode"`
+	Salt       string                          `mapstructure:"salt"`
+	Prefix     string                          `mapstructure:"prefix"`
+	AcceptUUID bool                            `mapstructure:"accept_uuid"`
+	Tenants    map[string]PublicIDTenantConfig `mapstructure:"tenants"`
+}
+
+// PublicIDTenantConfig — публичные идентификаторы одного пользователя; в
+// режиме hashid префикс обязателен и не должен пересекаться с чужими.
+type PublicIDTenantConfig struct {
+	Mode       string `mapstructure:"mode"`
+	Salt       string `mapstructure:"salt"`
+	Prefix     string `mapstructure:"prefix"`
+	AcceptUUID bool   `mapstructure:"accept_uuid"`
+}
+
+type TimingConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	AllowDebug bool `mapstructure:"allow_debug"`
+}
+
+type MetricsConfig struct {
+	Enabled            bool            `mapstructure:"enabled"`
+	Path               string          `mapstructure:"path"`
+	KPIRefreshInterval int             `mapstructure:"kpi_refresh_interval"`
+	Live               LiveStatsConfig `mapstructure:"live"`
+}
+
+type LiveStatsConfig struct {
+	Enabled  bool `mapstructure:"enabled"`
+	Interval int  `mapstructure:"interval"`
+}
+
+// EventsConfig — запись событий в БД (журнал, аудит, outbox). Шина событий
+// внутри процесса работает и при enabled: false; BusBuffer — очередь
+// каждого её потребителя.
+type EventsConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	Delivery     string `mapstructure:"delivery"`
+	AsyncTimeout int    `mapstructure:"async_timeout"`
+	BusBuffer    int    `mapstructure:"bus_buffer"`
+}
+
+type ReportsConfig struct {
+	Shards      int `mapstructure:"shards"`
+	Parallelism int `mapstructure:"parallelism"`
+	Timeout     int `mapstructure:"timeout"`
+	// AnalyticsCacheTTL — сколько секунд кешировать админскую аналитику.
+	AnalyticsCacheTTL int `mapstructure:"analytics_cache_ttl"`
+	// CostCacheTTL — сколько секунд кешировать /costs/calculate; 0 — не кешировать.
+	CostCacheTTL int `mapstructure:"cost_cache_ttl"`
+	// CostCacheMaxEntries — сколько результатов расчёта хранить самое большее.
+	CostCacheMaxEntries int `mapstructure:"cost_cache_max_entries"`
+}
+
+type APIConfig struct {
+	V1 APIVersionConfig `mapstructure:"v1"`
+	V2 APIVersionConfig `mapstructure:"v2"`
+	// ProblemTypeBaseURI — префикс type в ошибках application/problem+json;
+	// к нему добавляется код ошибки в kebab-case.
+	ProblemTypeBaseURI string `mapstructure:"problem_type_base_uri"`
+}
+
+// APIVersionConfig — включение версии API, сроки её вывода из эксплуатации
+// (YYYY-MM-DD) и формат дат в ответах по умолчанию (MM-YYYY или YYYY-MM).
+type APIVersionConfig struct {
+	Enabled         bool   `mapstructure:"enabled"`
+	Deprecated      bool   `mapstructure:"deprecated"`
+	DeprecatedSince string `mapstructure:"deprecated_since"`
+	Sunset          string `mapstructure:"sunset"`
+	DateFormat      string `mapstructure:"date_format"`
+}
+
+// RemindersConfig — напоминания об окончании подписок: за DaysBefore дней
+// до end_date пишется событие subscription.expiring.
+type RemindersConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	DaysBefore int  `mapstructure:"days_before"`
+	Interval   int  `mapstructure:"interval"`
+	BatchSize  int  `mapstructure:"batch_size"`
+}
+
+// CostAlertsConfig — проверка порогов месячных трат: раз в Interval секунд
+// по превышенным порогам пишется событие cost_alert.exceeded.
+type CostAlertsConfig struct {
+	Enabled   bool `mapstructure:"enabled"`
+	Interval  int  `mapstructure:"interval"`
+	BatchSize int  `mapstructure:"batch_size"`
+}
+
+// ArchiveConfig — перенос подписок, закончившихся больше AfterMonths
+// месяцев назад, в subscriptions_archive; Interval — в секундах.
+type ArchiveConfig struct {
+	Enabled     bool `mapstructure:"enabled"`
+	AfterMonths int  `mapstructure:"after_months"`
+	Interval    int  `mapstructure:"interval"`
+	BatchSize   int  `mapstructure:"batch_size"`
+}
+
+// StorageConfig — S3-совместимое хранилище (AWS S3, MinIO) для выгрузок,
+// которые скачиваются по подписанной ссылке. Endpoint — адрес API со
+// схемой; PathStyle — бакет в пути, а не в имени хоста (нужно MinIO);
+// Prefix добавляется ко всем ключам. URLTTL — срок ссылки, Retention — через
+// сколько секунд файл удаляется, CleanupInterval — как часто это проверять.
+type StorageConfig struct {
+	Enabled         bool   `mapstructure:"enabled"`
+	Endpoint        string `mapstructure:"endpoint"`
+	Region          string `mapstructure:"region"`
+	Bucket          string `mapstructure:"bucket"`
+	AccessKey       string `mapstructure:"access_key"`
+	SecretKey       string `mapstructure:"secret_key"`
+	PathStyle       bool   `mapstructure:"path_style"`
+	Prefix          string `mapstructure:"prefix"`
+	URLTTL          int    `mapstructure:"url_ttl"`
+	Retention       int    `mapstructure:"retention"`
+	CleanupInterval int    `mapstructure:"cleanup_interval"`
+}
+
+// RedisConfig — подключение к Redis. Нужен только функциям, которые хранят
+// в нём общее для реплик состояние (dedup).
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+// DedupConfig — защита от двойной отправки: одинаковые небезопасные
+// запросы пользователя в пределах Window секунд получают ответ первого.
+// Wait — сколько секунд дубль ждёт, пока первый запрос выполняется;
+// KeyPrefix отделяет ключи сервиса в общем Redis.
+type DedupConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	Window    int    `mapstructure:"window"`
+	Wait      int    `mapstructure:"wait"`
+	KeyPrefix string `mapstructure:"key_prefix"`
+}
+
+// PartitionsConfig — создание годовых секций subscriptions на YearsAhead
+// лет вперёд; Interval — в секундах.
+type PartitionsConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	YearsAhead int  `mapstructure:"years_ahead"`
+	Interval   int  `mapstructure:"interval"`
+}
+
+// SchedulerConfig — блокировки фоновых задач между репликами:
+// LockCheckInterval — как часто (в секундах) проверять, что блокировка жива.
+type SchedulerConfig struct {
+	DistributedLocks  bool `mapstructure:"distributed_locks"`
+	LockCheckInterval int  `mapstructure:"lock_check_interval"`
+}
+
+// EncryptionConfig — шифрование заметок и метаданных подписок:
+// Keys — "id:base64,..." (обычно из ENCRYPTION_KEYS), ActiveKey — ключ для
+// новых значений, IndexKey — ключ HMAC для фильтра по метаданным.
+type EncryptionConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	ActiveKey string `mapstructure:"active_key"`
+	Keys      string `mapstructure:"keys"`
+	IndexKey  string `mapstructure:"index_key"`
+}
+
+// AuthConfig — аутентификация и RBAC для /api: роль берётся из JWT (HS256)
+// или из таблицы api_keys; AdminKey — статический ключ администратора,
+// чтобы выпустить первые API-ключи.
+type AuthConfig struct {
+	Enabled  bool      `mapstructure:"enabled"`
+	AdminKey string    `mapstructure:"admin_key"`
+	JWT      JWTConfig `mapstructure:"jwt"`
+}
+
+type JWTConfig struct {
+	Secret    string `mapstructure:"secret"`
+	Issuer    string `mapstructure:"issuer"`
+	Audience  string `mapstructure:"audience"`
+	RoleClaim string `mapstructure:"role_claim"`
+}
+
+// AdminUIConfig — встроенный дашборд администратора на /admin.
+type AdminUIConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// WebSocketConfig — канал живых обновлений для клиентов; события приходят
+// из шины событий.
+type WebSocketConfig struct {
+	Enabled               bool   `mapstructure:"enabled"`
+	Path                  string `mapstructure:"path"`
+	MaxConnections        int    `mapstructure:"max_connections"`
+	MaxConnectionsPerUser int    `mapstructure:"max_connections_per_user"`
+	PingInterval          int    `mapstructure:"ping_interval"`
+	SendBuffer            int    `mapstructure:"send_buffer"`
+}
+
+// DebugConfig — /debug/pprof, /debug/vars и /debug/pool для профилирования
+// на стенде. С RequireAuth доступ только у роли admin (нужен auth.enabled).
+type DebugConfig struct {
+	Enabled     bool `mapstructure:"enabled"`
+	RequireAuth bool `mapstructure:"require_auth"`
+}
+
+// HealthConfig — проверки зависимостей для /health. CacheTTL защищает БД от
+// частых опросов проб, Timeout ограничивает каждую проверку. Warmup и
+// DrainDelay держат пробу готовности в 503 после старта и перед остановкой;
+// liveness падает после LivenessFailureThreshold неудачных проверок подряд.
+type HealthConfig struct {
+	CacheTTL                 int `mapstructure:"cache_ttl"`
+	Timeout                  int `mapstructure:"timeout"`
+	Warmup                   int `mapstructure:"warmup"`
+	DrainDelay               int `mapstructure:"drain_delay"`
+	LivenessInterval         int `mapstructure:"liveness_interval"`
+	LivenessFailureThreshold int `mapstructure:"liveness_failure_threshold"`
+}
+
+// CircuitBreakerConfig — автоматы защиты внешних зависимостей (проверка БД,
+// webhook watchdog): после FailureThreshold сбоев подряд вызовы отклоняются
+// OpenTimeout секунд, затем HalfOpenMaxCalls пробных вызовов решают, замкнуть
+// ли автомат снова.
+type CircuitBreakerConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	FailureThreshold int  `mapstructure:"failure_threshold"`
+	OpenTimeout      int  `mapstructure:"open_timeout"`
+	HalfOpenMaxCalls int  `mapstructure:"half_open_max_calls"`
+}
+
+// BillingCommandsConfig — очередь RabbitMQ с командами legacy-биллинга
+// (создание и отмена подписок). Результаты публикуются в result_exchange с
+// ключом result_routing_key или в reply_to сообщения.
+type BillingCommandsConfig struct {
+	Enabled           bool   `mapstructure:"enabled"`
+	URL               string `mapstructure:"url"`
+	Queue             string `mapstructure:"queue"`
+	ResultExchange    string `mapstructure:"result_exchange"`
+	ResultRoutingKey  string `mapstructure:"result_routing_key"`
+	Prefetch          int    `mapstructure:"prefetch"`
+	ReconnectInterval int    `mapstructure:"reconnect_interval"`
+	RetryDelay        int    `mapstructure:"retry_delay"`
+	HandleTimeout     int    `mapstructure:"handle_timeout"`
+}
+
+type ServiceNamesConfig struct {
+	CacheTTL  int                        `mapstructure:"cache_ttl"`
+	Normalize ServiceNameNormalizeConfig `mapstructure:"normalize"`
+}
+
+// ServiceNameNormalizeConfig — приведение названий сохранённых подписок к
+// словарю канонических названий; Interval — в секундах.
+type ServiceNameNormalizeConfig struct {
+	Enabled   bool `mapstructure:"enabled"`
+	Interval  int  `mapstructure:"interval"`
+	BatchSize int  `mapstructure:"batch_size"`
+}
+
+// BillingConfig — режим расчёта стоимости по умолчанию: monthly (целые
+// месяцы) или prorated (пропорционально дням). Запрос может переопределить
+// его параметром billing.
+type BillingConfig struct {
+	Mode string `mapstructure:"mode"`
+}
+
+// LimitsConfig — мягкие ограничения на данные одного пользователя.
+// MaxSubscriptionsPerUser = 0 — без ограничения; администратор может
+// задать отдельному пользователю свой лимит, он важнее этого значения.
+type LimitsConfig struct {
+	MaxSubscriptionsPerUser int `mapstructure:"max_subscriptions_per_user"`
+}
+
+// FeatureFlagsConfig — флаги окружения: Enabled — значение по умолчанию,
+// Tenants — переопределения для отдельных пользователей (по user_id).
+type FeatureFlagsConfig struct {
+	Flags map[string]FeatureFlagConfig `mapstructure:"flags"`
+}
+
+type FeatureFlagConfig struct {
+	Enabled bool            `mapstructure:"enabled"`
+	Tenants map[string]bool `mapstructure:"tenants"`
 }
 
 func NewConfig() *Config {
 	return &Config{}
 }
 
+// Load читает YAML-файл (если путь задан) и накладывает поверх него
+// переменные окружения. Пустой configPath включает режим "только env".
 func (c *Config) Load(configPath string) error {
-	viper.SetConfigFile(configPath)
-	viper.SetConfigType("yaml")
 	viper.AutomaticEnv()
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
-	if err := viper.ReadInConfig(); err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+	if err := registerEnv(viper.GetViper()); err != nil {
+		return fmt.Errorf("failed to bind environment variables: %w", err)
+	}
+
+	if configPath != "" {
+		viper.SetConfigFile(configPath)
+		viper.SetConfigType("yaml")
+
+		if err := viper.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
 	}
 
 	if err := viper.Unmarshal(c); err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	c.recordSources(viper.GetViper(), configPath != "")
+
 	return nil
 }
 
+func (c *Config) Hash() string {
+	data, _ := json.Marshal(c)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 func (sc *ServerConfig) Address() string {
 	return sc.Host + ":" + sc.Port
 }
 
+func (sc *ServerConfig) ShutdownTimeoutDuration() time.Duration {
+	return secondsOrDefault(sc.ShutdownTimeout, 30*time.Second)
+}
+
 func (dc *DatabaseConfig) DSN() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		dc.Host, dc.Port, dc.User, dc.Password, dc.DBName, dc.SSLMode)
 }
+
+func (dc *DatabaseConfig) StatementTimeout() time.Duration {
+	return time.Duration(dc.StatementTimeoutMs) * time.Millisecond
+}
+
+func (dc *DatabaseConfig) SlowQueryThreshold() time.Duration {
+	return time.Duration(dc.SlowQueryThresholdMs) * time.Millisecond
+}
+
+// MinConnsValue — min_conns или, если он не задан, max_idle_conns.
+func (dc *DatabaseConfig) MinConnsValue() int {
+	if dc.MinConns > 0 {
+		return dc.MinConns
+	}
+	return dc.MaxIdleConns
+}
+
+func (dc *DatabaseConfig) MaxConnIdleTimeDuration() time.Duration {
+	return secondsOrDefault(dc.MaxConnIdleTime, 30*time.Minute)
+}
+
+func (dc *DatabaseConfig) HealthCheckPeriodDuration() time.Duration {
+	return secondsOrDefault(dc.HealthCheckPeriod, time.Minute)
+}
+
+// MaxConnsLimitValue — max_conns_limit, но не меньше max_open_conns.
+func (dc *DatabaseConfig) MaxConnsLimitValue() int {
+	if dc.MaxConnsLimit < dc.MaxOpenConns {
+		return dc.MaxOpenConns
+	}
+	return dc.MaxConnsLimit
+}
+
+func (rc *DatabaseRetryConfig) BaseDelay() time.Duration {
+	return time.Duration(rc.BaseDelayMs) * time.Millisecond
+}
+
+func (rc *DatabaseRetryConfig) MaxDelay() time.Duration {
+	return time.Duration(rc.MaxDelayMs) * time.Millisecond
+}
+
+func (wc *WatchdogConfig) WindowDuration() time.Duration {
+	return secondsOrDefault(wc.Window, 5*time.Minute)
+}
+
+func (wc *WatchdogConfig) CheckIntervalDuration() time.Duration {
+	return secondsOrDefault(wc.CheckInterval, 15*time.Second)
+}
+
+func (wc *WatchdogConfig) BreachDurationValue() time.Duration {
+	return secondsOrDefault(wc.BreachDuration, 5*time.Minute)
+}
+
+func (wc *WatchdogConfig) RepeatIntervalDuration() time.Duration {
+	return secondsOrDefault(wc.RepeatInterval, 30*time.Minute)
+}
+
+func (wc *WatchdogConfig) WebhookTimeoutDuration() time.Duration {
+	return secondsOrDefault(wc.WebhookTimeout, 10*time.Second)
+}
+
+func (rt *RequestTimeout) DefaultDuration() time.Duration {
+	return time.Duration(rt.Default) * time.Second
+}
+
+// RouteDurations — переопределения по шаблону пути.
+func (rt *RequestTimeout) RouteDurations() map[string]time.Duration {
+	routes := make(map[string]time.Duration, len(rt.Routes))
+	for _, route := range rt.Routes {
+		routes[route.Path] = time.Duration(route.Timeout) * time.Second
+	}
+	return routes
+}
+
+func (dr *DegradationRoute) MaxStalenessDuration() time.Duration {
+	return secondsOrDefault(dr.MaxStaleness, time.Hour)
+}
+
+func (cc *ConsistencyConfig) IntervalDuration() time.Duration {
+	return secondsOrDefault(cc.Interval, time.Minute)
+}
+
+func (cc *ConsistencyConfig) StaleAfterDuration() time.Duration {
+	return secondsOrDefault(cc.StaleAfter, 5*time.Minute)
+}
+
+func (mc *MetricsConfig) KPIRefreshIntervalDuration() time.Duration {
+	return secondsOrDefault(mc.KPIRefreshInterval, time.Minute)
+}
+
+func (lc *LiveStatsConfig) IntervalDuration() time.Duration {
+	return secondsOrDefault(lc.Interval, time.Second)
+}
+
+func (wc *WebSocketConfig) PingIntervalDuration() time.Duration {
+	return secondsOrDefault(wc.PingInterval, 30*time.Second)
+}
+
+// CacheTTLDuration: 0 — отчёт не кэшируется.
+func (hc *HealthConfig) CacheTTLDuration() time.Duration {
+	return time.Duration(hc.CacheTTL) * time.Second
+}
+
+func (hc *HealthConfig) TimeoutDuration() time.Duration {
+	return secondsOrDefault(hc.Timeout, 3*time.Second)
+}
+
+// WarmupDuration: 0 — готов после первой успешной проверки.
+func (hc *HealthConfig) WarmupDuration() time.Duration {
+	return time.Duration(hc.Warmup) * time.Second
+}
+
+func (hc *HealthConfig) DrainDelayDuration() time.Duration {
+	return time.Duration(hc.DrainDelay) * time.Second
+}
+
+func (hc *HealthConfig) LivenessIntervalDuration() time.Duration {
+	return secondsOrDefault(hc.LivenessInterval, 10*time.Second)
+}
+
+func (cc *CircuitBreakerConfig) OpenTimeoutDuration() time.Duration {
+	return secondsOrDefault(cc.OpenTimeout, 30*time.Second)
+}
+
+func (bc *BillingCommandsConfig) ReconnectIntervalDuration() time.Duration {
+	return secondsOrDefault(bc.ReconnectInterval, 5*time.Second)
+}
+
+func (bc *BillingCommandsConfig) RetryDelayDuration() time.Duration {
+	return secondsOrDefault(bc.RetryDelay, 5*time.Second)
+}
+
+func (bc *BillingCommandsConfig) HandleTimeoutDuration() time.Duration {
+	return secondsOrDefault(bc.HandleTimeout, 30*time.Second)
+}
+
+func (ec *EventsConfig) AsyncTimeoutDuration() time.Duration {
+	return secondsOrDefault(ec.AsyncTimeout, 5*time.Second)
+}
+
+func (rc *ReportsConfig) TimeoutDuration() time.Duration {
+	return secondsOrDefault(rc.Timeout, 2*time.Minute)
+}
+
+func (rc *ReportsConfig) AnalyticsCacheTTLDuration() time.Duration {
+	return secondsOrDefault(rc.AnalyticsCacheTTL, 5*time.Minute)
+}
+
+// CostCacheTTLDuration: 0 — расчёт стоимости не кешируется.
+func (rc *ReportsConfig) CostCacheTTLDuration() time.Duration {
+	return time.Duration(rc.CostCacheTTL) * time.Second
+}
+
+func (rc *RemindersConfig) IntervalDuration() time.Duration {
+	return secondsOrDefault(rc.Interval, time.Hour)
+}
+
+func (cc *CostAlertsConfig) IntervalDuration() time.Duration {
+	return secondsOrDefault(cc.Interval, time.Hour)
+}
+
+func (ac *ArchiveConfig) IntervalDuration() time.Duration {
+	return secondsOrDefault(ac.Interval, 24*time.Hour)
+}
+
+func (sc *StorageConfig) URLTTLDuration() time.Duration {
+	return secondsOrDefault(sc.URLTTL, time.Hour)
+}
+
+func (sc *StorageConfig) RetentionDuration() time.Duration {
+	return secondsOrDefault(sc.Retention, 24*time.Hour)
+}
+
+func (sc *StorageConfig) CleanupIntervalDuration() time.Duration {
+	return secondsOrDefault(sc.CleanupInterval, time.Hour)
+}
+
+func (lc *LoggerLevelOverrideConfig) DefaultDurationValue() time.Duration {
+	return secondsOrDefault(lc.DefaultDuration, 15*time.Minute)
+}
+
+func (lc *LoggerLevelOverrideConfig) MaxDurationValue() time.Duration {
+	return secondsOrDefault(lc.MaxDuration, time.Hour)
+}
+
+func (dc *DedupConfig) WindowDuration() time.Duration {
+	return secondsOrDefault(dc.Window, 5*time.Second)
+}
+
+func (dc *DedupConfig) WaitDuration() time.Duration {
+	return time.Duration(dc.Wait) * time.Second
+}
+
+func (pc *PartitionsConfig) IntervalDuration() time.Duration {
+	return secondsOrDefault(pc.Interval, 24*time.Hour)
+}
+
+func (sc *SchedulerConfig) LockCheckIntervalDuration() time.Duration {
+	return secondsOrDefault(sc.LockCheckInterval, 5*time.Second)
+}
+
+func (sc *ServiceNamesConfig) CacheTTLDuration() time.Duration {
+	return secondsOrDefault(sc.CacheTTL, 30*time.Second)
+}
+
+func (nc *ServiceNameNormalizeConfig) IntervalDuration() time.Duration {
+	return secondsOrDefault(nc.Interval, time.Hour)
+}
+
+const apiDateLayout = "2006-01-02"
+
+func (vc *APIVersionConfig) DeprecatedSinceTime() time.Time {
+	return parseAPIDate(vc.DeprecatedSince)
+}
+
+func (vc *APIVersionConfig) SunsetTime() time.Time {
+	return parseAPIDate(vc.Sunset)
+}
+
+// ResponseDateFormat — формат дат для клиентов без заголовка Accept-Date-Format.
+func (vc *APIVersionConfig) ResponseDateFormat() utils.DateFormat {
+	format, ok := utils.ParseDateFormat(vc.DateFormat)
+	if !ok {
+		return utils.DateFormatMonthYear
+	}
+	return format
+}
+
+func (fc *FeatureFlagsConfig) Rules() map[featureflags.Flag]featureflags.Rule {
+	rules := make(map[featureflags.Flag]featureflags.Rule, len(fc.Flags))
+	for name, flag := range fc.Flags {
+		rules[featureflags.Flag(name)] = featureflags.Rule{
+			Enabled: flag.Enabled,
+			Tenants: flag.Tenants,
+		}
+	}
+	return rules
+}
+
+func parseAPIDate(value string) time.Time {
+	t, err := time.Parse(apiDateLayout, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func secondsOrDefault(seconds int, defaultValue time.Duration) time.Duration {
+	if seconds <= 0 {
+		return defaultValue
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,701 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/featureflags"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/fieldcrypt"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+var (
+	validLogLevels    = []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
+	validBillingModes = []string{"monthly", "prorated"}
+	validLogEncodings = []string{"json", "console"}
+	validLogOutputs   = []string{logger.OutputStdout, logger.OutputStderr, logger.OutputFile}
+	validSSLModes     = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	validPublicIDMode = []string{"uuid", "hashid"}
+	validEventModes   = []string{"best_effort", "transactional"}
+	validReadModes    = []string{"allow", "cache", "deny"}
+)
+
+// ValidationError собирает все найденные проблемы конфигурации,
+// чтобы при старте показать их списком, а не по одной.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration (%d problem(s)):", len(e.Problems))
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(problem)
+	}
+	return b.String()
+}
+
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Problems = append(e.Problems, field+": "+fmt.Sprintf(format, args...))
+}
+
+func (e *ValidationError) errOrNil() error {
+	if len(e.Problems) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Validate проверяет загруженную конфигурацию целиком и возвращает
+// *ValidationError со всеми нарушениями сразу.
+func (c *Config) Validate() error {
+	errs := &ValidationError{}
+
+	c.Server.validate(errs)
+	c.Database.validate(errs)
+	c.Logger.validate(errs)
+	c.Watchdog.validate(errs)
+	c.Degradation.validate(errs)
+	c.Maintenance.validate(errs, c.Degradation.Enabled)
+	c.Consistency.validate(errs)
+	c.PublicIDs.validate(errs)
+	c.Metrics.validate(errs)
+	c.Events.validate(errs)
+	c.Reports.validate(errs)
+	c.ServiceNames.validate(errs)
+	c.API.validate(errs)
+	c.Billing.validate(errs)
+	c.Limits.validate(errs)
+	c.Reminders.validate(errs)
+	c.CostAlerts.validate(errs)
+	c.Archive.validate(errs)
+	c.Storage.validate(errs)
+	c.Dedup.validate(errs, c.Redis)
+	c.Partitions.validate(errs)
+	c.Scheduler.validate(errs)
+	c.Encryption.validate(errs)
+	c.Auth.validate(errs)
+	c.WebSocket.validate(errs)
+	c.BillingCommands.validate(errs)
+	c.Health.validate(errs)
+	c.Debug.validate(errs, c.Auth.Enabled)
+	c.CircuitBreaker.validate(errs)
+	c.FeatureFlags.validate(errs)
+
+	return errs.errOrNil()
+}
+
+// Validate проверяет только параметры подключения к базе данных.
+// Используется утилитами, которым не нужен HTTP-сервер (например, мигратором).
+func (dc *DatabaseConfig) Validate() error {
+	errs := &ValidationError{}
+	dc.validate(errs)
+	return errs.errOrNil()
+}
+
+func (sc *ServerConfig) validate(errs *ValidationError) {
+	validatePort(errs, "server.port", sc.Port)
+	validateNonNegative(errs, "server.read_timeout", sc.ReadTimeout)
+	validateNonNegative(errs, "server.write_timeout", sc.WriteTimeout)
+	validateNonNegative(errs, "server.idle_timeout", sc.IdleTimeout)
+	validateNonNegative(errs, "server.max_connections", sc.MaxConnections)
+	validateNonNegative(errs, "server.shutdown_timeout", sc.ShutdownTimeout)
+	if sc.MaxBodySize < 0 {
+		errs.add("server.max_body_size", "must not be negative, got %d", sc.MaxBodySize)
+	}
+
+	validateNonNegative(errs, "server.request_timeout.default", sc.RequestTimeout.Default)
+	validateWithinWriteTimeout(errs, "server.request_timeout.default", sc.RequestTimeout.Default, sc.WriteTimeout)
+	for i, route := range sc.RequestTimeout.Routes {
+		field := fmt.Sprintf("server.request_timeout.routes[%d]", i)
+		if !strings.HasPrefix(route.Path, "/") {
+			errs.add(field+".path", "must start with '/', got %q", route.Path)
+		}
+		validateNonNegative(errs, field+".timeout", route.Timeout)
+		validateWithinWriteTimeout(errs, field+".timeout", route.Timeout, sc.WriteTimeout)
+	}
+	if sc.Compression.Enabled && (sc.Compression.Level < 1 || sc.Compression.Level > 9) {
+		errs.add("server.compression.level", "must be between 1 and 9, got %d", sc.Compression.Level)
+	}
+	validateNonNegative(errs, "server.compression.min_length", sc.Compression.MinLength)
+
+	if sc.TLS.Enabled {
+		validateFile(errs, "server.tls.cert", sc.TLS.CertFile)
+		validateFile(errs, "server.tls.key", sc.TLS.KeyFile)
+	}
+}
+
+func (dc *DatabaseConfig) validate(errs *ValidationError) {
+	problems := len(errs.Problems)
+
+	validateRequired(errs, "database.host", dc.Host)
+	validatePort(errs, "database.port", dc.Port)
+	validateRequired(errs, "database.user", dc.User)
+	validateRequired(errs, "database.db_name", dc.DBName)
+	validateOneOf(errs, "database.ssl_mode", dc.SSLMode, validSSLModes)
+
+	validateNonNegative(errs, "database.max_open_conns", dc.MaxOpenConns)
+	validateNonNegative(errs, "database.max_idle_conns", dc.MaxIdleConns)
+	validateNonNegative(errs, "database.max_lifetime", dc.MaxLifetime)
+	validateNonNegative(errs, "database.min_conns", dc.MinConns)
+	validateNonNegative(errs, "database.min_idle_conns", dc.MinIdleConns)
+	validateNonNegative(errs, "database.max_conn_idle_time", dc.MaxConnIdleTime)
+	validateNonNegative(errs, "database.health_check_period", dc.HealthCheckPeriod)
+	validateNonNegative(errs, "database.max_lifetime_jitter", dc.MaxLifetimeJitter)
+	validateNonNegative(errs, "database.max_conns_limit", dc.MaxConnsLimit)
+	validateNonNegative(errs, "database.statement_timeout_ms", dc.StatementTimeoutMs)
+	validateNonNegative(errs, "database.slow_query_threshold_ms", dc.SlowQueryThresholdMs)
+	validateNonNegative(errs, "database.statement_cache_capacity", dc.StatementCacheCapacity)
+	if dc.Retry.Enabled {
+		validatePositive(errs, "database.retry.read_attempts", dc.Retry.ReadAttempts)
+		validatePositive(errs, "database.retry.write_attempts", dc.Retry.WriteAttempts)
+		validateNonNegative(errs, "database.retry.base_delay_ms", dc.Retry.BaseDelayMs)
+		if dc.Retry.MaxDelayMs < dc.Retry.BaseDelayMs {
+			errs.add("database.retry.max_delay_ms", "must not be less than base_delay_ms (%d < %d)", dc.Retry.MaxDelayMs, dc.Retry.BaseDelayMs)
+		}
+	}
+	if dc.QueryLog.Enabled {
+		validateRatio(errs, "database.query_log.sample_rate", dc.QueryLog.SampleRate)
+	}
+	if dc.MaxOpenConns > 0 && dc.MaxIdleConns > dc.MaxOpenConns {
+		errs.add("database.max_idle_conns", "must not exceed max_open_conns (%d > %d)", dc.MaxIdleConns, dc.MaxOpenConns)
+	}
+	if dc.MaxOpenConns > 0 && dc.MinConns > dc.MaxOpenConns {
+		errs.add("database.min_conns", "must not exceed max_open_conns (%d > %d)", dc.MinConns, dc.MaxOpenConns)
+	}
+	if dc.MaxOpenConns > 0 && dc.MinIdleConns > dc.MaxOpenConns {
+		errs.add("database.min_idle_conns", "must not exceed max_open_conns (%d > %d)", dc.MinIdleConns, dc.MaxOpenConns)
+	}
+
+	if len(errs.Problems) > problems {
+		return
+	}
+
+	if _, err := pgconn.ParseConfig(dc.DSN()); err != nil {
+		errs.add("database", "connection settings do not form a valid DSN: %v", err)
+	}
+}
+
+func (lc *LoggerConfig) validate(errs *ValidationError) {
+	if lc.Level != "" {
+		validateOneOf(errs, "logger.level", strings.ToLower(lc.Level), validLogLevels)
+	}
+	if lc.Encoding != "" {
+		validateOneOf(errs, "logger.encoding", lc.Encoding, validLogEncodings)
+	}
+
+	validatePositive(errs, "logger.level_override.default_duration", lc.LevelOverride.DefaultDuration)
+	validatePositive(errs, "logger.level_override.max_duration", lc.LevelOverride.MaxDuration)
+	if lc.LevelOverride.DefaultDuration > lc.LevelOverride.MaxDuration {
+		errs.add("logger.level_override.default_duration", "must not exceed max_duration (%d > %d)", lc.LevelOverride.DefaultDuration, lc.LevelOverride.MaxDuration)
+	}
+
+	if lc.Bodies.Enabled {
+		validatePositive(errs, "logger.bodies.max_size", lc.Bodies.MaxSize)
+	}
+
+	for i, output := range lc.Outputs {
+		field := fmt.Sprintf("logger.outputs[%d]", i)
+		validateOneOf(errs, field+".type", output.Type, validLogOutputs)
+		if output.Encoding != "" {
+			validateOneOf(errs, field+".encoding", output.Encoding, validLogEncodings)
+		}
+		if output.Type != logger.OutputFile {
+			continue
+		}
+		validateRequired(errs, field+".path", output.Path)
+		validateNonNegative(errs, field+".max_size_mb", output.MaxSizeMB)
+		validateNonNegative(errs, field+".max_backups", output.MaxBackups)
+		validateNonNegative(errs, field+".max_age_days", output.MaxAgeDays)
+	}
+}
+
+func (wc *WatchdogConfig) validate(errs *ValidationError) {
+	if !wc.Enabled {
+		return
+	}
+
+	validateRatio(errs, "watchdog.server_error_threshold", wc.ServerErrorThreshold)
+	validateRatio(errs, "watchdog.db_error_threshold", wc.DBErrorThreshold)
+	validateNonNegative(errs, "watchdog.min_requests", wc.MinRequests)
+	if wc.CheckIntervalDuration() > wc.WindowDuration() {
+		errs.add("watchdog.check_interval", "must not exceed window (%s > %s)", wc.CheckIntervalDuration(), wc.WindowDuration())
+	}
+	if wc.WebhookURL != "" {
+		validateURL(errs, "watchdog.webhook_url", wc.WebhookURL)
+	}
+}
+
+func (dc *DegradationConfig) validate(errs *ValidationError) {
+	if !dc.Enabled {
+		return
+	}
+
+	validateNonNegative(errs, "degradation.max_entries", dc.MaxEntries)
+	for i, route := range dc.Routes {
+		field := fmt.Sprintf("degradation.routes[%d]", i)
+		if !strings.HasPrefix(route.Path, "/") {
+			errs.add(field+".path", "must start with '/', got %q", route.Path)
+		}
+		validateNonNegative(errs, field+".max_staleness", route.MaxStaleness)
+	}
+}
+
+func (mc *MaintenanceConfig) validate(errs *ValidationError, degradationEnabled bool) {
+	validateOneOf(errs, "maintenance.reads", mc.Reads, validReadModes)
+	if mc.Reads == "cache" && !degradationEnabled {
+		errs.add("maintenance.reads", "cache requires degradation.enabled")
+	}
+	if len(mc.Reason) > 500 {
+		errs.add("maintenance.reason", "must be at most 500 characters")
+	}
+}
+
+func (cc *ConsistencyConfig) validate(errs *ValidationError) {
+	if !cc.Enabled {
+		return
+	}
+
+	if cc.StaleAfterDuration() < cc.IntervalDuration() {
+		errs.add("consistency.stale_after", "must not be shorter than interval (%s < %s)", cc.StaleAfterDuration(), cc.IntervalDuration())
+	}
+}
+
+func (pc *PublicIDsConfig) validate(errs *ValidationError) {
+	if mode := strings.ToLower(pc.Mode); mode != "" {
+		validateOneOf(errs, "public_ids.mode", mode, validPublicIDMode)
+		if mode == "hashid" && pc.Salt == "" {
+			errs.add("public_ids.salt", "is required when mode is hashid")
+		}
+	}
+
+	tenants := make([]string, 0, len(pc.Tenants))
+	for tenant := range pc.Tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	for _, tenant := range tenants {
+		field := "public_ids.tenants." + tenant
+		if _, err := uuid.Parse(tenant); err != nil {
+			errs.add(field, "must be keyed by user_id")
+		}
+		tc := pc.Tenants[tenant]
+		mode := strings.ToLower(tc.Mode)
+		validateOneOf(errs, field+".mode", mode, validPublicIDMode)
+		if mode == "hashid" && tc.Salt == "" {
+			errs.add(field+".salt", "is required when mode is hashid")
+		}
+		if mode == "hashid" && tc.Prefix == "" {
+			errs.add(field+".prefix", "is required when mode is hashid")
+		}
+	}
+}
+
+func (mc *MetricsConfig) validate(errs *ValidationError) {
+	if mc.Live.Enabled {
+		validateNonNegative(errs, "metrics.live.interval", mc.Live.Interval)
+	}
+
+	if !mc.Enabled {
+		return
+	}
+
+	if !strings.HasPrefix(mc.Path, "/") {
+		errs.add("metrics.path", "must start with '/', got %q", mc.Path)
+	}
+	validateNonNegative(errs, "metrics.kpi_refresh_interval", mc.KPIRefreshInterval)
+}
+
+// validatePlaceholder ловит значения вида "${VAR:-default}": viper не
+// раскрывает их, поэтому переменную нужно задать явно.
+func validatePlaceholder(errs *ValidationError, field, value string) bool {
+	if !strings.Contains(value, "${") {
+		return true
+	}
+	errs.add(field, "contains unexpanded placeholder %q; set the corresponding environment variable", value)
+	return false
+}
+
+func (ec *EventsConfig) validate(errs *ValidationError) {
+	validateNonNegative(errs, "events.bus_buffer", ec.BusBuffer)
+
+	if !ec.Enabled {
+		return
+	}
+
+	validateOneOf(errs, "events.delivery", ec.Delivery, validEventModes)
+	validateNonNegative(errs, "events.async_timeout", ec.AsyncTimeout)
+}
+
+func (wc *WebSocketConfig) validate(errs *ValidationError) {
+	if !wc.Enabled {
+		return
+	}
+
+	if !strings.HasPrefix(wc.Path, "/") {
+		errs.add("websocket.path", "must start with '/', got %q", wc.Path)
+	}
+	validateNonNegative(errs, "websocket.max_connections", wc.MaxConnections)
+	validateNonNegative(errs, "websocket.max_connections_per_user", wc.MaxConnectionsPerUser)
+	validateNonNegative(errs, "websocket.ping_interval", wc.PingInterval)
+	validateNonNegative(errs, "websocket.send_buffer", wc.SendBuffer)
+}
+
+// validate: без auth.enabled проверять роль нечем, и /debug молча оказался бы
+// открыт, поэтому это нужно разрешить явно.
+func (dc *DebugConfig) validate(errs *ValidationError, authEnabled bool) {
+	if dc.Enabled && dc.RequireAuth && !authEnabled {
+		errs.add("debug.require_auth", "requires auth.enabled; set debug.require_auth: false to expose /debug without credentials")
+	}
+}
+
+func (hc *HealthConfig) validate(errs *ValidationError) {
+	validateNonNegative(errs, "health.cache_ttl", hc.CacheTTL)
+	validateNonNegative(errs, "health.timeout", hc.Timeout)
+	validateNonNegative(errs, "health.warmup", hc.Warmup)
+	validateNonNegative(errs, "health.drain_delay", hc.DrainDelay)
+	validateNonNegative(errs, "health.liveness_interval", hc.LivenessInterval)
+	validateNonNegative(errs, "health.liveness_failure_threshold", hc.LivenessFailureThreshold)
+}
+
+func (cc *CircuitBreakerConfig) validate(errs *ValidationError) {
+	if !cc.Enabled {
+		return
+	}
+	validatePositive(errs, "circuit_breaker.failure_threshold", cc.FailureThreshold)
+	validateNonNegative(errs, "circuit_breaker.open_timeout", cc.OpenTimeout)
+	validatePositive(errs, "circuit_breaker.half_open_max_calls", cc.HalfOpenMaxCalls)
+}
+
+func (bc *BillingCommandsConfig) validate(errs *ValidationError) {
+	if !bc.Enabled {
+		return
+	}
+
+	if validatePlaceholder(errs, "billing_commands.url", bc.URL) {
+		if !strings.HasPrefix(bc.URL, "amqp://") && !strings.HasPrefix(bc.URL, "amqps://") {
+			errs.add("billing_commands.url", "must be an amqp:// or amqps:// URL")
+		}
+	}
+	if bc.Queue == "" {
+		errs.add("billing_commands.queue", "is required")
+	}
+	validateNonNegative(errs, "billing_commands.prefetch", bc.Prefetch)
+	validateNonNegative(errs, "billing_commands.reconnect_interval", bc.ReconnectInterval)
+	validateNonNegative(errs, "billing_commands.retry_delay", bc.RetryDelay)
+	validateNonNegative(errs, "billing_commands.handle_timeout", bc.HandleTimeout)
+}
+
+func (rc *ReportsConfig) validate(errs *ValidationError) {
+	validateNonNegative(errs, "reports.shards", rc.Shards)
+	validateNonNegative(errs, "reports.parallelism", rc.Parallelism)
+	validateNonNegative(errs, "reports.timeout", rc.Timeout)
+	validateNonNegative(errs, "reports.analytics_cache_ttl", rc.AnalyticsCacheTTL)
+	validateNonNegative(errs, "reports.cost_cache_ttl", rc.CostCacheTTL)
+	if rc.CostCacheTTL > 0 && rc.CostCacheMaxEntries < 1 {
+		errs.add("reports.cost_cache_max_entries", "must be positive when the cost cache is enabled")
+	}
+}
+
+func (ac *APIConfig) validate(errs *ValidationError) {
+	if !ac.V1.Enabled && !ac.V2.Enabled {
+		errs.add("api", "at least one of v1 and v2 must be enabled")
+	}
+	ac.V1.validate(errs, "api.v1")
+	ac.V2.validate(errs, "api.v2")
+
+	if parsed, err := url.Parse(ac.ProblemTypeBaseURI); err != nil || parsed.Scheme == "" {
+		errs.add("api.problem_type_base_uri", "must be an absolute URI, got %q", ac.ProblemTypeBaseURI)
+	}
+}
+
+func (vc *APIVersionConfig) validate(errs *ValidationError, prefix string) {
+	validateAPIDate(errs, prefix+".deprecated_since", vc.DeprecatedSince)
+	validateAPIDate(errs, prefix+".sunset", vc.Sunset)
+
+	if since, sunset := vc.DeprecatedSinceTime(), vc.SunsetTime(); !since.IsZero() && !sunset.IsZero() && sunset.Before(since) {
+		errs.add(prefix+".sunset", "must not be before deprecated_since")
+	}
+
+	if _, ok := utils.ParseDateFormat(vc.DateFormat); vc.DateFormat != "" && !ok {
+		errs.add(prefix+".date_format", "must be %s or %s, got %q", utils.DateFormatMonthYear, utils.DateFormatISO, vc.DateFormat)
+	}
+}
+
+func validateAPIDate(errs *ValidationError, field, value string) {
+	if value == "" {
+		return
+	}
+	if _, err := time.Parse(apiDateLayout, value); err != nil {
+		errs.add(field, "must be a YYYY-MM-DD date, got %q", value)
+	}
+}
+
+func (sc *ServiceNamesConfig) validate(errs *ValidationError) {
+	validateNonNegative(errs, "service_names.cache_ttl", sc.CacheTTL)
+	if sc.Normalize.Enabled {
+		validateNonNegative(errs, "service_names.normalize.interval", sc.Normalize.Interval)
+		validateNonNegative(errs, "service_names.normalize.batch_size", sc.Normalize.BatchSize)
+	}
+}
+
+func (bc *BillingConfig) validate(errs *ValidationError) {
+	validateOneOf(errs, "billing.mode", strings.ToLower(bc.Mode), validBillingModes)
+}
+
+func (lc *LimitsConfig) validate(errs *ValidationError) {
+	validateNonNegative(errs, "limits.max_subscriptions_per_user", lc.MaxSubscriptionsPerUser)
+}
+
+// Опечатка в имени флага иначе молча оставила бы его выключенным.
+func (fc *FeatureFlagsConfig) validate(errs *ValidationError) {
+	known := make([]string, 0, len(featureflags.Known))
+	for _, flag := range featureflags.Known {
+		known = append(known, string(flag))
+	}
+	for name := range fc.Flags {
+		validateOneOf(errs, "feature_flags.flags", name, known)
+	}
+}
+
+// maxReminderDaysBefore совпадает с models.MaxExpiringWithinDays.
+const maxReminderDaysBefore = 365
+
+func (ac *ArchiveConfig) validate(errs *ValidationError) {
+	if !ac.Enabled {
+		return
+	}
+
+	validateNonNegative(errs, "archive.interval", ac.Interval)
+	validateNonNegative(errs, "archive.batch_size", ac.BatchSize)
+	// Подписки, закончившиеся в этом месяце, ещё попадают в расчёты стоимости.
+	if ac.AfterMonths < 1 {
+		errs.add("archive.after_months", "must be at least 1, got %d", ac.AfterMonths)
+	}
+}
+
+// maxStorageURLTTL — наибольший срок подписанной ссылки SigV4 (7 дней).
+const maxStorageURLTTL = 7 * 24 * 60 * 60
+
+func (sc *StorageConfig) validate(errs *ValidationError) {
+	if !sc.Enabled {
+		return
+	}
+
+	validateURL(errs, "storage.endpoint", sc.Endpoint)
+	validateRequired(errs, "storage.region", sc.Region)
+	validateRequired(errs, "storage.bucket", sc.Bucket)
+	validateRequired(errs, "storage.access_key", sc.AccessKey)
+	validateRequired(errs, "storage.secret_key", sc.SecretKey)
+	validateNonNegative(errs, "storage.cleanup_interval", sc.CleanupInterval)
+	if sc.URLTTL < 1 || sc.URLTTL > maxStorageURLTTL {
+		errs.add("storage.url_ttl", "must be between 1 and %d, got %d", maxStorageURLTTL, sc.URLTTL)
+	}
+	// Ссылка на удалённый файл бесполезна.
+	if sc.Retention < sc.URLTTL {
+		errs.add("storage.retention", "must not be less than storage.url_ttl (%d < %d)", sc.Retention, sc.URLTTL)
+	}
+}
+
+// maxDedupWindow — дольше окна двойной клик не живёт, а одинаковые
+// намеренные запросы (две одинаковые подписки подряд) начнут склеиваться.
+const maxDedupWindow = 300
+
+func (dc *DedupConfig) validate(errs *ValidationError, redis RedisConfig) {
+	if !dc.Enabled {
+		return
+	}
+
+	validateRequired(errs, "redis.addr", redis.Addr)
+	validateNonNegative(errs, "redis.db", redis.DB)
+	if dc.Window < 1 || dc.Window > maxDedupWindow {
+		errs.add("dedup.window", "must be between 1 and %d, got %d", maxDedupWindow, dc.Window)
+	}
+	validateNonNegative(errs, "dedup.wait", dc.Wait)
+}
+
+// maxPartitionYearsAhead ограничивает число пустых секций: каждая —
+// отдельная таблица со всеми индексами.
+const maxPartitionYearsAhead = 10
+
+func (pc *PartitionsConfig) validate(errs *ValidationError) {
+	if !pc.Enabled {
+		return
+	}
+
+	validateNonNegative(errs, "partitions.interval", pc.Interval)
+	// Секция следующего года должна появиться до первой вставки в него.
+	if pc.YearsAhead < 1 || pc.YearsAhead > maxPartitionYearsAhead {
+		errs.add("partitions.years_ahead", "must be between 1 and %d, got %d", maxPartitionYearsAhead, pc.YearsAhead)
+	}
+}
+
+func (rc *RemindersConfig) validate(errs *ValidationError) {
+	if !rc.Enabled {
+		return
+	}
+
+	validateNonNegative(errs, "reminders.days_before", rc.DaysBefore)
+	validateNonNegative(errs, "reminders.interval", rc.Interval)
+	validateNonNegative(errs, "reminders.batch_size", rc.BatchSize)
+	if rc.DaysBefore > maxReminderDaysBefore {
+		errs.add("reminders.days_before", "must be at most %d", maxReminderDaysBefore)
+	}
+}
+
+func (cc *CostAlertsConfig) validate(errs *ValidationError) {
+	if !cc.Enabled {
+		return
+	}
+
+	validateNonNegative(errs, "cost_alerts.interval", cc.Interval)
+	validateNonNegative(errs, "cost_alerts.batch_size", cc.BatchSize)
+}
+
+func (sc *SchedulerConfig) validate(errs *ValidationError) {
+	validateNonNegative(errs, "scheduler.lock_check_interval", sc.LockCheckInterval)
+}
+
+func (ec *EncryptionConfig) validate(errs *ValidationError) {
+	if !ec.Enabled {
+		return
+	}
+
+	if validatePlaceholder(errs, "encryption.keys", ec.Keys) {
+		keys, err := fieldcrypt.ParseKeys(ec.Keys)
+		switch {
+		case err != nil:
+			errs.add("encryption.keys", "%v", err)
+		case len(keys) == 0:
+			errs.add("encryption.keys", "is required when encryption is enabled")
+		case ec.ActiveKey == "":
+			errs.add("encryption.active_key", "is required when encryption is enabled")
+		default:
+			if _, ok := keys[ec.ActiveKey]; !ok {
+				errs.add("encryption.active_key", "%q is not among encryption.keys", ec.ActiveKey)
+			}
+		}
+	}
+
+	if validatePlaceholder(errs, "encryption.index_key", ec.IndexKey) {
+		if ec.IndexKey == "" {
+			errs.add("encryption.index_key", "is required when encryption is enabled")
+		} else if _, err := fieldcrypt.ParseKeys("index:" + ec.IndexKey); err != nil {
+			errs.add("encryption.index_key", "must be %d bytes in base64", fieldcrypt.KeySize)
+		}
+	}
+}
+
+// minAuthSecretLength — минимальная длина секрета HS256 и ключа администратора.
+const minAuthSecretLength = 32
+
+func (ac *AuthConfig) validate(errs *ValidationError) {
+	if !ac.Enabled {
+		return
+	}
+
+	if validatePlaceholder(errs, "auth.admin_key", ac.AdminKey) && ac.AdminKey != "" && len(ac.AdminKey) < minAuthSecretLength {
+		errs.add("auth.admin_key", "must be at least %d characters", minAuthSecretLength)
+	}
+	if validatePlaceholder(errs, "auth.jwt.secret", ac.JWT.Secret) && ac.JWT.Secret != "" {
+		if len(ac.JWT.Secret) < minAuthSecretLength {
+			errs.add("auth.jwt.secret", "must be at least %d characters", minAuthSecretLength)
+		}
+		validateRequired(errs, "auth.jwt.role_claim", ac.JWT.RoleClaim)
+	}
+}
+
+func validateRequired(errs *ValidationError, field, value string) {
+	if !validatePlaceholder(errs, field, value) {
+		return
+	}
+	if strings.TrimSpace(value) == "" {
+		errs.add(field, "is required")
+	}
+}
+
+func validateFile(errs *ValidationError, field, path string) {
+	if !validatePlaceholder(errs, field, path) {
+		return
+	}
+	if path == "" {
+		errs.add(field, "is required")
+		return
+	}
+	if info, err := os.Stat(path); err != nil {
+		errs.add(field, "cannot read %q: %v", path, err)
+	} else if info.IsDir() {
+		errs.add(field, "%q is a directory, expected a file", path)
+	}
+}
+
+func validatePort(errs *ValidationError, field, value string) {
+	if !validatePlaceholder(errs, field, value) {
+		return
+	}
+	if value == "" {
+		errs.add(field, "is required")
+		return
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		errs.add(field, "must be a number between 1 and 65535, got %q", value)
+	}
+}
+
+func validateNonNegative(errs *ValidationError, field string, value int) {
+	if value < 0 {
+		errs.add(field, "must not be negative, got %d", value)
+	}
+}
+
+func validatePositive(errs *ValidationError, field string, value int) {
+	if value < 1 {
+		errs.add(field, "must be at least 1, got %d", value)
+	}
+}
+
+// validateWithinWriteTimeout: ответ, который пишется дольше
+// server.write_timeout, сервер всё равно оборвёт, и клиент не получит 504.
+func validateWithinWriteTimeout(errs *ValidationError, field string, timeout, writeTimeout int) {
+	if writeTimeout > 0 && timeout > writeTimeout {
+		errs.add(field, "must not exceed server.write_timeout (%d > %d)", timeout, writeTimeout)
+	}
+}
+
+func validateRatio(errs *ValidationError, field string, value float64) {
+	if value <= 0 || value > 1 {
+		errs.add(field, "must be in (0, 1], got %g", value)
+	}
+}
+
+func validateOneOf(errs *ValidationError, field, value string, allowed []string) {
+	if !validatePlaceholder(errs, field, value) {
+		return
+	}
+	for _, candidate := range allowed {
+		if value == candidate {
+			return
+		}
+	}
+	errs.add(field, "must be one of [%s], got %q", strings.Join(allowed, ", "), value)
+}
+
+func validateURL(errs *ValidationError, field, value string) {
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		errs.add(field, "must be an absolute http(s) URL, got %q", value)
+	}
+}
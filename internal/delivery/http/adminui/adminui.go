@@ -0,0 +1,20 @@
+package adminui
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed static
+var static embed.FS
+
+// FS — статические файлы дашборда администратора (index.html, app.js, style.css).
+// Дашборд ходит в тот же /api/v1, что и остальные клиенты, и при включённой
+// аутентификации отправляет X-API-Key, введённый оператором.
+func FS() fs.FS {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+	return assets
+}
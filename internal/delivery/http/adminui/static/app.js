@@ -0,0 +1,282 @@
+"use strict";
+
+// Admin dashboard: talks to /api/v1 like any other client. The API key is kept
+// in sessionStorage only, so it is forgotten when the tab is closed.
+(function () {
+  const API = "/api/v1";
+  const PAGE_SIZE = 20;
+  const KEY_STORAGE = "subscription-service.api-key";
+
+  const $ = (id) => document.getElementById(id);
+
+  let offset = 0;
+  let liveAbort = null;
+
+  function apiKey() {
+    return sessionStorage.getItem(KEY_STORAGE) || "";
+  }
+
+  function headers() {
+    const h = { "Accept-Date-Format": "YYYY-MM" };
+    if (apiKey()) {
+      h["X-API-Key"] = apiKey();
+    }
+    return h;
+  }
+
+  async function get(path) {
+    const resp = await fetch(API + path, { headers: headers() });
+    const body = await resp.json().catch(() => ({}));
+    if (!resp.ok) {
+      const err = body.error || {};
+      throw new Error((err.code || resp.status) + ": " + (err.message || resp.statusText));
+    }
+    return body;
+  }
+
+  function text(value) {
+    return value === undefined || value === null || value === "" ? "—" : String(value);
+  }
+
+  function money(value) {
+    return text(value) === "—" ? "—" : Number(value).toLocaleString() + " ₽";
+  }
+
+  function cell(value, className) {
+    const td = document.createElement("td");
+    td.textContent = text(value);
+    if (className) {
+      td.className = className;
+    }
+    return td;
+  }
+
+  function fillList(dl, pairs) {
+    dl.replaceChildren();
+    for (const [label, value, className] of pairs) {
+      const dt = document.createElement("dt");
+      dt.textContent = label;
+      const dd = document.createElement("dd");
+      dd.textContent = text(value);
+      if (className) {
+        dd.className = className;
+      }
+      dl.append(dt, dd);
+    }
+  }
+
+  function showError(id, err) {
+    const el = $(id);
+    el.hidden = !err;
+    el.textContent = err ? err.message : "";
+  }
+
+  // Dates arrive as YYYY-MM; a subscription is active if it started and has
+  // not ended before the current month.
+  function status(sub) {
+    const now = new Date().toISOString().slice(0, 7);
+    if (sub.start_date > now) {
+      return "upcoming";
+    }
+    if (sub.end_date && sub.end_date < now) {
+      return "expired";
+    }
+    return "active";
+  }
+
+  async function loadHealth() {
+    try {
+      // 503 still carries the per-dependency status, so the body is read either way.
+      const resp = await fetch(API + "/health/", { headers: headers() });
+      const health = await resp.json();
+      const pairs = [["Status", health.status, health.status === "healthy" ? "ok" : "bad"]];
+      for (const [name, state] of Object.entries(health.services || {})) {
+        pairs.push([name, state, state === "healthy" ? "ok" : "bad"]);
+      }
+      pairs.push(["Checked", new Date().toLocaleTimeString()]);
+      fillList($("health"), pairs);
+    } catch (err) {
+      fillList($("health"), [["Status", err.message, "bad"]]);
+    }
+  }
+
+  function renderLive(stats) {
+    fillList($("live"), [
+      ["Requests / s", stats.requests_per_second.toFixed(1)],
+      ["Creates / min", stats.creates_per_minute],
+      ["Active subscriptions", stats.active_subscriptions.toLocaleString()],
+      ["Streams", stats.streams],
+      ["Updated", new Date(stats.timestamp).toLocaleTimeString()],
+    ]);
+  }
+
+  // EventSource cannot send X-API-Key, so the SSE stream is read with fetch.
+  async function connectLive() {
+    if (liveAbort) {
+      liveAbort.abort();
+    }
+    liveAbort = new AbortController();
+
+    try {
+      const resp = await fetch(API + "/admin/live", { headers: headers(), signal: liveAbort.signal });
+      if (!resp.ok) {
+        const body = await resp.json().catch(() => ({}));
+        throw new Error((body.error && body.error.message) || resp.statusText);
+      }
+
+      const reader = resp.body.getReader();
+      const decoder = new TextDecoder();
+      let buffer = "";
+      for (;;) {
+        const { value, done } = await reader.read();
+        if (done) {
+          break;
+        }
+        buffer += decoder.decode(value, { stream: true });
+
+        let boundary;
+        while ((boundary = buffer.indexOf("\n\n")) >= 0) {
+          const event = buffer.slice(0, boundary);
+          buffer = buffer.slice(boundary + 2);
+          const data = event.split("\n").filter((line) => line.startsWith("data:")).map((line) => line.slice(5)).join("");
+          if (data) {
+            renderLive(JSON.parse(data));
+          }
+        }
+      }
+      fillList($("live"), [["Stream", "closed", "muted"]]);
+    } catch (err) {
+      if (err.name !== "AbortError") {
+        fillList($("live"), [["Stream", err.message, "bad"]]);
+      }
+    }
+  }
+
+  async function loadSubscriptions() {
+    const params = new URLSearchParams({ limit: PAGE_SIZE, offset: offset });
+    const service = $("service-filter").value.trim();
+    if (service) {
+      params.set("service_name", service);
+    }
+
+    try {
+      const page = await get("/subscriptions/?" + params);
+      const rows = page.data.map((sub) => {
+        const tr = document.createElement("tr");
+        tr.append(
+          cell(sub.service_name),
+          cell(money(sub.price), "num"),
+          cell(sub.user_id, "mono"),
+          cell(sub.start_date),
+          cell(sub.end_date),
+          cell(new Date(sub.created_at).toLocaleString()),
+        );
+        tr.querySelector(".mono").addEventListener("click", () => {
+          $("user-id").value = sub.user_id;
+          lookupUser();
+        });
+        return tr;
+      });
+      $("subscriptions").replaceChildren(...rows);
+
+      const total = page.pagination.total;
+      $("page-info").textContent = total === undefined
+        ? `from ${offset + 1}`
+        : `${Math.min(offset + 1, total)}–${Math.min(offset + PAGE_SIZE, total)} of ${total}`;
+      $("prev").disabled = offset === 0;
+      $("next").disabled = !page.pagination.has_more;
+      showError("list-error", null);
+    } catch (err) {
+      showError("list-error", err);
+    }
+  }
+
+  async function lookupUser() {
+    const userID = $("user-id").value.trim();
+    if (!userID) {
+      return;
+    }
+
+    try {
+      const [stats, subs] = await Promise.all([
+        get(`/users/${encodeURIComponent(userID)}/subscriptions/stats`),
+        get(`/users/${encodeURIComponent(userID)}/subscriptions?limit=100`),
+      ]);
+
+      fillList($("user-stats"), [
+        ["Pays per month", money(stats.monthly_spend)],
+        ["Active", stats.active_subscriptions],
+        ["Upcoming", stats.upcoming_subscriptions],
+        ["Expired", stats.expired_subscriptions],
+        ["Most expensive", stats.most_expensive ? `${stats.most_expensive.service_name} (${money(stats.most_expensive.price)})` : null],
+        ["Next ending", stats.next_ending ? `${stats.next_ending.service_name} (${stats.next_ending.end_date})` : null],
+      ]);
+
+      const rows = subs.data.map((sub) => {
+        const tr = document.createElement("tr");
+        const state = status(sub);
+        tr.append(
+          cell(sub.service_name),
+          cell(money(sub.price), "num"),
+          cell(sub.start_date),
+          cell(sub.end_date),
+          cell(sub.category),
+          cell(state, state === "active" ? "ok" : "muted"),
+        );
+        return tr;
+      });
+      $("user-subscriptions").replaceChildren(...rows);
+      $("user-result").hidden = false;
+      showError("user-error", null);
+    } catch (err) {
+      $("user-result").hidden = true;
+      showError("user-error", err);
+    }
+  }
+
+  $("key-form").addEventListener("submit", (e) => {
+    e.preventDefault();
+    const key = $("api-key").value.trim();
+    if (key) {
+      sessionStorage.setItem(KEY_STORAGE, key);
+    } else {
+      sessionStorage.removeItem(KEY_STORAGE);
+    }
+    $("api-key").value = "";
+    $("api-key").placeholder = key ? "key saved for this tab" : "X-API-Key (if auth is enabled)";
+    refresh();
+  });
+
+  $("user-form").addEventListener("submit", (e) => {
+    e.preventDefault();
+    lookupUser();
+  });
+
+  $("list-form").addEventListener("submit", (e) => {
+    e.preventDefault();
+    offset = 0;
+    loadSubscriptions();
+  });
+
+  $("prev").addEventListener("click", () => {
+    offset = Math.max(0, offset - PAGE_SIZE);
+    loadSubscriptions();
+  });
+
+  $("next").addEventListener("click", () => {
+    offset += PAGE_SIZE;
+    loadSubscriptions();
+  });
+
+  function refresh() {
+    loadHealth();
+    loadSubscriptions();
+    connectLive();
+  }
+
+  if (apiKey()) {
+    $("api-key").placeholder = "key saved for this tab";
+  }
+  refresh();
+  setInterval(loadHealth, 15000);
+})();
@@ -0,0 +1,77 @@
+// Package apidoc собирает документ OpenAPI 3 из маршрутов включённых версий
+// API. Схемы строятся из DTO, поэтому документ меняется вместе с ними.
+package apidoc
+
+import (
+	"net/http"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+)
+
+// SpecPath — путь, по которому сервис отдаёт документ.
+const SpecPath = "/openapi.json"
+
+const (
+	apiKeyScheme = "ApiKeyAuth"
+	bearerScheme = "BearerAuth"
+)
+
+// Document описывает versions так, как их регистрирует router.RegisterAPIRoutes.
+// С authEnabled операции требуют API-ключ или JWT, кроме публичных.
+func Document(versions []router.APIVersion, authEnabled bool) *openapi.Document {
+	builder := openapi.NewBuilder(openapi.Info{
+		Title:       "Subscription Service API",
+		Description: "REST API for managing user subscriptions",
+		Version:     "1.0",
+		License:     &openapi.License{Name: "MIT", URL: "https://opensource.org/licenses/MIT"},
+	}, response.ErrorResponse{})
+	builder.SetErrorBody(http.StatusBadRequest, response.ValidationErrorResponse{})
+	builder.AddErrorContent(response.ProblemContentType, response.ProblemDetails{})
+
+	builder.AddTag("subscriptions", "Subscription management operations")
+	builder.AddTag("subscriptions-v2", "Subscription management operations, API v2 (ISO 8601 dates)")
+	builder.AddTag("plans", "Subscription plans (tariffs)")
+	builder.AddTag("costs", "Cost calculation operations")
+	builder.AddTag("health", "Health check operations")
+	builder.AddTag("admin", "Operational and administrative endpoints")
+
+	if authEnabled {
+		builder.AddSecurityScheme(apiKeyScheme, &openapi.SecurityScheme{
+			Type:        "apiKey",
+			In:          "header",
+			Name:        middleware.APIKeyHeader,
+			Description: "API key issued via POST /api/v1/admin/api-keys",
+		})
+		builder.AddSecurityScheme(bearerScheme, &openapi.SecurityScheme{
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+			Description:  "HS256 token with the role in the configured claim",
+		})
+		builder.SetSecurity(
+			openapi.SecurityRequirement{apiKeyScheme: {}},
+			openapi.SecurityRequirement{bearerScheme: {}},
+		)
+	}
+
+	for _, version := range versions {
+		basePath := "/api/" + version.Name
+		for _, handler := range version.Handlers {
+			for _, route := range handler.Routes() {
+				op := builder.Add(basePath, route)
+
+				permission, _ := middleware.RequiredPermission(route.Method, route.Path)
+				op.Permission = string(permission)
+				if authEnabled && permission == middleware.PermissionPublic {
+					// Пустой список переопределяет глобальное требование.
+					op.Security = &[]openapi.SecurityRequirement{}
+				}
+			}
+		}
+	}
+
+	return builder.Document()
+}
@@ -0,0 +1,765 @@
+package apidoc_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/apidoc"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/handlers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/loglevel"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/maintenance"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/buildinfo"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/health"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+var (
+	subscriptionID = uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+	userID         = uuid.MustParse("60601fee-2bf1-4721-ae6f-7636e79a0cba")
+	planID         = uuid.MustParse("9b2e4f60-3c1d-4a7e-8f5b-2d6c1e0a9b3f")
+
+	now   = time.Date(2025, 7, 15, 10, 30, 0, 0, time.UTC)
+	start = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end   = time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// TestEveryRouteIsDocumented сверяет маршруты gin с документом в обе стороны.
+func TestEveryRouteIsDocumented(t *testing.T) {
+	engine, doc := newTestAPI(t)
+
+	registered := make(map[string]bool)
+	for _, route := range engine.Routes() {
+		registered[route.Method+" "+openapi.GinPath(route.Path)] = true
+	}
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method := range *item {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	for _, key := range sortedKeys(registered) {
+		if !documented[key] {
+			t.Errorf("route %s is registered but not documented", key)
+		}
+	}
+	for _, key := range sortedKeys(documented) {
+		if !registered[key] {
+			t.Errorf("operation %s is documented but not registered", key)
+		}
+	}
+}
+
+// TestResponsesMatchDocument вызывает хендлеры с заглушками сервисов и
+// проверяет коды и тела ответов по схемам документа.
+func TestResponsesMatchDocument(t *testing.T) {
+	engine, doc := newTestAPI(t)
+
+	var (
+		sub     = "/api/v1/subscriptions/" + subscriptionID.String()
+		user    = "/api/v1/users/" + userID.String() + "/subscriptions"
+		alerts  = "/api/v1/users/" + userID.String() + "/alerts"
+		costs   = "/api/v1/users/" + userID.String() + "/costs"
+		plan    = "/api/v1/plans/" + planID.String()
+		catalog = "/api/v1/services/" + planID.String()
+		period  = "start_date=01-2025&end_date=12-2025"
+		subV2   = "/api/v2/subscriptions/" + subscriptionID.String()
+	)
+
+	cases := []struct {
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/api/v1/health/", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/health/ready", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/health/live", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/version", "", http.StatusOK},
+
+		{http.MethodPost, "/api/v1/subscriptions/", `{"service_name":"Yandex Plus","price":400,"user_id":"` + userID.String() + `","start_date":"07-2025"}`, http.StatusCreated},
+		{http.MethodPost, "/api/v1/subscriptions/", `{"price":"free"}`, http.StatusBadRequest},
+		{http.MethodGet, sub, "", http.StatusOK},
+		{http.MethodGet, sub + "?expand=comments", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/subscriptions/not-a-uuid", "", http.StatusBadRequest},
+		{http.MethodPut, sub, `{"price":500}`, http.StatusOK},
+		{http.MethodPatch, "/api/v1/subscriptions/bulk", `{"items":[{"id":"` + subscriptionID.String() + `","price":500}]}`, http.StatusOK},
+		{http.MethodPatch, "/api/v1/subscriptions/bulk", `{"items":[{"id":"` + subscriptionID.String() + `","price":500},{"id":"` + planID.String() + `","price":600}]}`, http.StatusUnprocessableEntity},
+		{http.MethodPatch, "/api/v1/subscriptions/bulk", `{"items":[]}`, http.StatusBadRequest},
+		{http.MethodPatch, "/api/v1/subscriptions/bulk/filter", `{"filter":{"service_name":"Yandex+"},"set":{"service_name":"Yandex Plus"}}`, http.StatusOK},
+		{http.MethodDelete, sub, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/subscriptions/?limit=10", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/subscriptions/?archived=true", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/subscriptions/?archived=maybe", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/subscriptions/search?q=yandex", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/subscriptions/export?tags=music", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/subscriptions/export/file?tags=music", "", http.StatusCreated},
+		{http.MethodPost, "/api/v1/subscriptions/export/file?archived=maybe", "", http.StatusBadRequest},
+		{http.MethodPost, sub + "/comments", `{"author":"support:anna","body":"Cancelled by phone"}`, http.StatusCreated},
+		{http.MethodGet, sub + "/comments", "", http.StatusOK},
+		{http.MethodGet, sub + "/price-history", "", http.StatusOK},
+		{http.MethodPut, sub + "/catalog", `{"catalog_id":"` + planID.String() + `"}`, http.StatusOK},
+		{http.MethodPut, sub + "/catalog", `{"catalog_id":"netflix"}`, http.StatusBadRequest},
+		{http.MethodPost, sub + "/members", `{"user_id":"` + planID.String() + `","share_percent":25}`, http.StatusCreated},
+		{http.MethodPost, sub + "/members", `{"user_id":"` + planID.String() + `","share_percent":0}`, http.StatusBadRequest},
+		{http.MethodGet, sub + "/members", "", http.StatusOK},
+		{http.MethodDelete, sub + "/members/" + planID.String(), "", http.StatusOK},
+		{http.MethodGet, user, "", http.StatusOK},
+		{http.MethodDelete, user, "", http.StatusOK},
+		{http.MethodGet, user + "/stats", "", http.StatusOK},
+		{http.MethodGet, user + "/calendar?year=2025", "", http.StatusOK},
+		{http.MethodGet, user + "/expiring", "", http.StatusOK},
+		{http.MethodGet, user + "/duplicates", "", http.StatusOK},
+		{http.MethodPost, alerts, `{"monthly_limit":3000}`, http.StatusCreated},
+		{http.MethodPost, alerts, `{"monthly_limit":0}`, http.StatusBadRequest},
+		{http.MethodGet, alerts, "", http.StatusOK},
+		{http.MethodDelete, alerts + "/" + planID.String(), "", http.StatusOK},
+		{http.MethodGet, costs + "/forecast?months=3", "", http.StatusOK},
+		{http.MethodGet, costs + "/forecast?billing=weekly", "", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/users/" + userID.String() + "/billing-calendar?month=02-2025", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/users/" + userID.String() + "/billing-calendar?month=2025-13", "", http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/users/" + userID.String() + "/billing-calendar/feed", "", http.StatusCreated},
+		{http.MethodDelete, "/api/v1/users/" + userID.String() + "/billing-calendar/feed", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/users/" + userID.String() + "/billing-calendar.ics?token=cal_test", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/users/" + userID.String() + "/billing-calendar.ics", "", http.StatusUnauthorized},
+		{http.MethodGet, "/api/v1/costs/calculate?" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/costs/by-category?" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/costs/by-payment-method?" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/costs/calculate?locale=ru-RU&" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/costs/by-category?locale=en&" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/costs/by-payment-method?locale=ru&" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/costs/calculate?locale=12345&" + period, "", http.StatusBadRequest},
+
+		{http.MethodPost, "/api/v1/plans/", `{"name":"Family","service_name":"Yandex Plus","price":600,"billing_cycle":"monthly"}`, http.StatusCreated},
+		{http.MethodGet, "/api/v1/plans/", "", http.StatusOK},
+		{http.MethodGet, plan, "", http.StatusOK},
+		{http.MethodPut, plan, `{"price":700}`, http.StatusOK},
+		{http.MethodDelete, plan, "", http.StatusOK},
+		{http.MethodGet, plan + "/price-history", "", http.StatusOK},
+
+		{http.MethodPost, "/api/v1/services/", `{"name":"Netflix","category":"streaming","website":"https://www.netflix.com","typical_price":599}`, http.StatusCreated},
+		{http.MethodGet, "/api/v1/services/", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/services/suggest?q=net", "", http.StatusOK},
+		{http.MethodGet, catalog, "", http.StatusOK},
+		{http.MethodPut, catalog, `{"typical_price":699}`, http.StatusOK},
+		{http.MethodDelete, catalog, "", http.StatusOK},
+
+		{http.MethodGet, "/api/v1/admin/config/consistency", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/reports/user-spend?" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/service-name-rules", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/admin/service-name-rules", `{"list":"deny","match":"exact","pattern":"casino"}`, http.StatusCreated},
+		{http.MethodDelete, "/api/v1/admin/service-name-rules/" + subscriptionID.String(), "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/discounts", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/admin/discounts", `{"code":"SUMMER25","kind":"percentage","amount":25,"valid_from":"06-2025"}`, http.StatusCreated},
+		{http.MethodGet, "/api/v1/admin/discounts/" + subscriptionID.String(), "", http.StatusOK},
+		{http.MethodDelete, "/api/v1/admin/discounts/" + subscriptionID.String(), "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/analytics/top-services?" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/analytics/mrr?" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/analytics/churn?" + period, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/dead-letters", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/admin/dead-letters/" + subscriptionID.String() + "/retry", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/permissions?role=viewer", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/api-keys", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/admin/api-keys", `{"name":"dashboard","role":"viewer"}`, http.StatusCreated},
+		{http.MethodDelete, "/api/v1/admin/api-keys/" + subscriptionID.String(), "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/maintenance", "", http.StatusOK},
+		{http.MethodPut, "/api/v1/admin/maintenance", `{"enabled":true,"reason":"partitioning"}`, http.StatusOK},
+		{http.MethodPut, "/api/v1/admin/maintenance", `{"reason":"partitioning"}`, http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/admin/db/pool", "", http.StatusOK},
+		{http.MethodPut, "/api/v1/admin/db/pool", `{"max_conns":10,"min_conns":2}`, http.StatusOK},
+		{http.MethodPut, "/api/v1/admin/db/pool", `{"max_conns":500}`, http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/admin/log-level", "", http.StatusOK},
+		{http.MethodPut, "/api/v1/admin/log-level", `{"level":"debug","duration":600,"reason":"INC-1234"}`, http.StatusOK},
+		{http.MethodPut, "/api/v1/admin/log-level", `{"level":"debug","duration":86400}`, http.StatusBadRequest},
+		{http.MethodPut, "/api/v1/admin/log-level", `{"level":"verbose"}`, http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/admin/service-names", "", http.StatusOK},
+		{http.MethodPost, "/api/v1/admin/service-names", `{"name":"Netflix","aliases":["Нетфликс"]}`, http.StatusCreated},
+		{http.MethodDelete, "/api/v1/admin/service-names/" + subscriptionID.String(), "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/users/" + userID.String() + "/subscription-limit", "", http.StatusOK},
+		{http.MethodPut, "/api/v1/admin/users/" + userID.String() + "/subscription-limit", `{"max_subscriptions":500,"reason":"billing import"}`, http.StatusOK},
+		{http.MethodPut, "/api/v1/admin/users/" + userID.String() + "/subscription-limit", `{"max_subscriptions":-1}`, http.StatusBadRequest},
+		{http.MethodDelete, "/api/v1/admin/users/" + userID.String() + "/subscription-limit", "", http.StatusOK},
+
+		{http.MethodPost, "/api/v2/subscriptions/", `{"service_name":"Yandex Plus","price":400,"user_id":"` + userID.String() + `","start_date":"2025-07"}`, http.StatusCreated},
+		{http.MethodGet, subV2, "", http.StatusOK},
+		{http.MethodPut, subV2, `{"end_date":""}`, http.StatusOK},
+		{http.MethodDelete, subV2, "", http.StatusNoContent},
+		{http.MethodGet, "/api/v2/subscriptions/", "", http.StatusOK},
+		{http.MethodGet, "/api/v2/users/" + userID.String() + "/subscriptions", "", http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.method+" "+tc.target, func(t *testing.T) {
+			var body any
+			if tc.body != "" {
+				body = tc.body
+			}
+			rec := testutil.Do(t, engine, tc.method, tc.target, body)
+			testutil.AssertStatus(t, rec, tc.status)
+
+			route := rec.Header().Get(routeHeader)
+			if err := doc.ValidateResponse(tc.method, openapi.GinPath(route), rec.Code, rec.Body.Bytes()); err != nil {
+				t.Errorf("%v\nbody: %s", err, rec.Body.String())
+			}
+		})
+	}
+}
+
+// routeHeader передаёт тесту шаблон маршрута, который обработал запрос.
+const routeHeader = "X-Test-Route"
+
+func newTestAPI(t *testing.T) (*gin.Engine, *openapi.Document) {
+	t.Helper()
+	log := testutil.NewLogger(t)
+
+	subscriptions := &subscriptionStub{}
+	ids, err := publicid.NewRegistry(publicid.Config{Mode: publicid.ModeUUID}, nil)
+	if err != nil {
+		t.Fatalf("public ids: %v", err)
+	}
+	versions := []router.APIVersion{
+		testutil.V1(
+			handlers.NewSubscriptionHandler(subscriptions, commentStub{}, alertStub{}, ids, log),
+			handlers.NewSubscriptionBulkHandler(bulkStub{}, ids, log),
+			handlers.NewPlanHandler(planStub{}, log),
+			handlers.NewServiceCatalogHandler(catalogStub{}, log),
+			handlers.NewCostAlertHandler(alertStub{}, log),
+			handlers.NewCalendarFeedHandler(calendarFeedStub{}, ids, log),
+			handlers.NewSubscriptionMemberHandler(memberStub{}, ids, log),
+			handlers.NewHealthHandler(log, health.NewRegistry(0, 0), nil, nil),
+			handlers.NewVersionHandler(buildinfo.Get()),
+			handlers.NewAdminHandler(consistencyStub{}, spendStub{}, ruleStub{}, discountStub{}, analyticsStub{}, deadLetterStub{}, nil, log),
+			handlers.NewAccessHandler(authStub{}, false, log),
+			handlers.NewMaintenanceHandler(maintenance.NewSwitch(false, "", maintenance.ReadsAllow, log), log),
+			handlers.NewDBPoolHandler(newPoolStub(t), log),
+			handlers.NewLogLevelHandler(newLogLevelSwitch(t), log),
+			handlers.NewCanonicalServiceNameHandler(canonicalStub{}, log),
+			handlers.NewSubscriptionLimitHandler(limitStub{}, log),
+			handlers.NewSubscriptionExportHandler(subscriptions, artifactStub{}, ids, log),
+		),
+		testutil.V2(handlers.NewSubscriptionV2Handler(subscriptions, ids, log)),
+	}
+
+	engine := testutil.NewRouter(t, testutil.RouterOptions{
+		Versions: versions,
+		Logger:   log,
+		Middlewares: []gin.HandlerFunc{func(c *gin.Context) {
+			c.Header(routeHeader, c.FullPath())
+			c.Next()
+		}},
+	})
+
+	return engine, apidoc.Document(versions, true)
+}
+
+// newLogLevelSwitch меняет уровень отдельного логгера, а не общего для
+// теста, и возвращает его при завершении теста.
+func newLogLevelSwitch(t *testing.T) *loglevel.Switch {
+	t.Helper()
+	log := testutil.NewLogger(t)
+	sw := loglevel.NewSwitch(log, 15*time.Minute, time.Hour, log)
+	t.Cleanup(sw.Stop)
+	return sw
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sampleSubscription() *models.Subscription {
+	sub := models.NewSubscription("Yandex Plus", models.Rubles(400), userID, start)
+	sub.SetID(subscriptionID)
+	sub.SetEndDate(&end)
+	sub.SetPlanID(&planID)
+	sub.SetTags([]string{"family"})
+	category := models.CategoryStreaming
+	sub.SetCategory(&category)
+	paymentMethod, billingDay := "Tinkoff *1234", 31
+	sub.SetPaymentMethod(&paymentMethod)
+	sub.SetBillingDay(&billingDay)
+	sub.SetNotes("Shared with family")
+	sub.SetMetadata(map[string]string{"team": "platform"})
+	sub.SetCreatedAt(now)
+	sub.SetUpdatedAt(now)
+	return sub
+}
+
+func period() models.DateRange {
+	return models.NewDateRange(start, end)
+}
+
+type subscriptionStub struct {
+	service.SubscriptionService
+}
+
+func (subscriptionStub) CreateSubscription(context.Context, string, int, uuid.UUID, string, *string, *string, *uuid.UUID, *uuid.UUID, []string, *string, *string, *int, string, map[string]string) (*models.Subscription, error) {
+	return sampleSubscription(), nil
+}
+
+func (subscriptionStub) GetSubscriptionByID(context.Context, uuid.UUID) (*models.Subscription, error) {
+	return sampleSubscription(), nil
+}
+
+func (subscriptionStub) GetSubscriptionsByUser(context.Context, uuid.UUID, int, int) ([]*models.Subscription, error) {
+	return []*models.Subscription{sampleSubscription()}, nil
+}
+
+func (subscriptionStub) GetAllSubscriptions(context.Context, *models.SubscriptionFilter, int, int) ([]*models.Subscription, int, error) {
+	return []*models.Subscription{sampleSubscription()}, 1, nil
+}
+
+func (subscriptionStub) ExportSubscriptions(_ context.Context, _ *models.SubscriptionFilter, fn func(*models.Subscription) error) error {
+	return fn(sampleSubscription())
+}
+
+func (subscriptionStub) SearchSubscriptions(context.Context, string, *uuid.UUID, int, int) ([]*models.SubscriptionSearchHit, error) {
+	return []*models.SubscriptionSearchHit{models.NewSubscriptionSearchHit(sampleSubscription(), 0.8)}, nil
+}
+
+func (subscriptionStub) UpdateSubscription(context.Context, uuid.UUID, *string, *int, *string, *string, *[]string, *string, *string, *int, *string, *map[string]string) (*models.Subscription, error) {
+	return sampleSubscription(), nil
+}
+
+func (subscriptionStub) DeleteSubscription(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (subscriptionStub) DeleteUserSubscriptions(context.Context, uuid.UUID) (int, error) {
+	return 2, nil
+}
+
+func (subscriptionStub) CalculateTotalCost(context.Context, *uuid.UUID, *string, string, string, *models.BillingMode, models.PricingMode) (*models.CostSummary, error) {
+	summary := models.NewCostSummary(period(), models.BillingMonthly, models.PricingCurrent)
+	summary.SetBreakdown(models.NewCostBreakdown(4800, 400))
+	return summary, nil
+}
+
+func (subscriptionStub) CalculateCostByCategory(context.Context, *uuid.UUID, string, string, *models.BillingMode, models.PricingMode) (*models.CategoryCostReport, error) {
+	categories := []*models.CategoryCost{
+		models.NewCategoryCost(models.CategoryStreaming, models.NewCostBreakdown(4800, 400), 1),
+		models.NewCategoryCost(models.CategoryUncategorized, models.NewCostBreakdown(1200, 0), 1),
+	}
+	return models.NewCategoryCostReport(period(), models.BillingMonthly, models.PricingCurrent, categories), nil
+}
+
+func (subscriptionStub) CalculateCostByPaymentMethod(context.Context, *uuid.UUID, string, string, *models.BillingMode, models.PricingMode) (*models.PaymentMethodCostReport, error) {
+	card := "Tinkoff *1234"
+	methods := []*models.PaymentMethodCost{
+		models.NewPaymentMethodCost(&card, models.NewCostBreakdown(4800, 400), 1),
+		models.NewPaymentMethodCost(nil, models.NewCostBreakdown(1200, 0), 1),
+	}
+	return models.NewPaymentMethodCostReport(period(), models.BillingMonthly, models.PricingCurrent, methods), nil
+}
+
+func (subscriptionStub) GetUserSubscriptionStats(context.Context, uuid.UUID) (*models.UserSubscriptionStats, error) {
+	stats := models.NewUserSubscriptionStats(userID, now)
+	stats.SetCounts(2, 1, 1, 0)
+	stats.SetSpend(400, 450)
+	stats.SetMostExpensive(models.NewSubscriptionRef(subscriptionID, "Yandex Plus", models.Rubles(400), &end))
+	return stats, nil
+}
+
+func (subscriptionStub) FindDuplicateSubscriptions(context.Context, uuid.UUID) ([]*models.SubscriptionDuplicate, error) {
+	second := models.NewSubscription("yandex plus", models.Rubles(300), userID, start)
+	second.SetID(uuid.New())
+	return []*models.SubscriptionDuplicate{models.NewSubscriptionDuplicate(sampleSubscription(), second, 1)}, nil
+}
+
+func (subscriptionStub) GetExpiringSubscriptions(context.Context, uuid.UUID, int) ([]*models.Subscription, error) {
+	return []*models.Subscription{sampleSubscription()}, nil
+}
+
+func (subscriptionStub) GetSubscriptionCalendar(context.Context, uuid.UUID, int, *models.BillingMode, models.PricingMode) (*models.SubscriptionCalendar, error) {
+	calendar := models.NewSubscriptionCalendar(2025, models.BillingMonthly)
+	calendar.MonthOf(start).AddSubscription(sampleSubscription(), nil, models.NewPriceSchedule(400, nil))
+	return calendar, nil
+}
+
+func (subscriptionStub) GetBillingCalendar(_ context.Context, _ uuid.UUID, month string) (*models.BillingCalendar, error) {
+	from, err := utils.ParseMonthYear(month)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewBillingCalendar(from, []*models.Subscription{sampleSubscription()})
+}
+
+func (subscriptionStub) GetCostForecast(_ context.Context, _ uuid.UUID, months int, _ *models.BillingMode) (*models.CostForecast, error) {
+	forecast := models.NewCostForecast(time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), months, models.BillingMonthly)
+	for _, month := range forecast.Months() {
+		if !month.Month().After(end) {
+			month.AddSubscription(sampleSubscription(), nil, models.NewPriceSchedule(400, nil))
+		}
+	}
+	return forecast, nil
+}
+
+func (subscriptionStub) GetPriceHistory(context.Context, uuid.UUID) ([]*models.PriceChange, error) {
+	return []*models.PriceChange{models.RestorePriceChange(subscriptionID, 300, 400, start, now)}, nil
+}
+
+func (subscriptionStub) LinkCatalogService(_ context.Context, _ uuid.UUID, catalogID *uuid.UUID) (*models.Subscription, error) {
+	sub := sampleSubscription()
+	sub.SetCatalogID(catalogID)
+	return sub, nil
+}
+
+// bulkStub применяет пакет из одного элемента; в пакете из нескольких
+// второй элемент отклоняется, и пакет откатывается.
+type bulkStub struct{}
+
+func (bulkStub) UpdateSubscriptions(_ context.Context, patches []*models.SubscriptionPatch) (*models.BulkUpdateResult, error) {
+	if len(patches) == 1 {
+		return models.NewBulkUpdateResult([]*models.BulkUpdateItemResult{
+			models.NewBulkUpdateItemResult(patches[0].ID(), models.BulkItemUpdated, sampleSubscription()),
+		}), nil
+	}
+	return models.NewBulkUpdateResult([]*models.BulkUpdateItemResult{
+		models.NewBulkUpdateItemResult(patches[0].ID(), models.BulkItemRolledBack, nil),
+		models.NewBulkUpdateItemFailed(patches[1].ID(), apperror.CodeSubscriptionNotFound, "Subscription not found"),
+	}), nil
+}
+
+func (bulkStub) UpdateByFilter(_ context.Context, _ *models.SubscriptionFilter, _ *models.SubscriptionPatch, dryRun bool) (*models.BulkFilterUpdateResult, error) {
+	return models.NewBulkFilterUpdateResult(dryRun, 1, []uuid.UUID{subscriptionID}), nil
+}
+
+type commentStub struct{}
+
+func (commentStub) AddComment(_ context.Context, id uuid.UUID, author, body string) (*models.SubscriptionComment, error) {
+	return models.RestoreSubscriptionComment(uuid.New(), id, author, body, now), nil
+}
+
+func (commentStub) ListComments(_ context.Context, id uuid.UUID, _, _ int) ([]*models.SubscriptionComment, int, error) {
+	return []*models.SubscriptionComment{models.RestoreSubscriptionComment(uuid.New(), id, "support:anna", "Called", now)}, 1, nil
+}
+
+type planStub struct{}
+
+func samplePlan() *models.Plan {
+	return models.RestorePlan(planID, "Family", "Yandex Plus", models.Rubles(600), models.BillingCycleMonthly, map[string]interface{}{"seats": 4}, now, now)
+}
+
+func (planStub) CreatePlan(context.Context, string, string, int, models.BillingCycle, map[string]interface{}) (*models.Plan, error) {
+	return samplePlan(), nil
+}
+
+func (planStub) GetPlan(context.Context, uuid.UUID) (*models.Plan, error) {
+	return samplePlan(), nil
+}
+
+func (planStub) ListPlans(context.Context, int, int) ([]*models.Plan, error) {
+	return []*models.Plan{samplePlan()}, nil
+}
+
+func (planStub) UpdatePlan(context.Context, uuid.UUID, *string, *string, *int, *models.BillingCycle, map[string]interface{}) (*models.Plan, error) {
+	return samplePlan(), nil
+}
+
+func (planStub) DeletePlan(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (planStub) GetPriceHistory(context.Context, uuid.UUID) ([]*models.PlanPrice, error) {
+	return []*models.PlanPrice{models.NewPlanPrice(planID, models.Rubles(600), models.BillingCycleMonthly, start)}, nil
+}
+
+type catalogStub struct{}
+
+func sampleCatalogService() *models.CatalogService {
+	category := models.CategoryStreaming
+	price := 599
+	return models.RestoreCatalogService(planID, "Netflix", &category, "https://www.netflix.com", "", &price, now, now)
+}
+
+func (catalogStub) CreateCatalogService(context.Context, string, *string, string, string, *int) (*models.CatalogService, error) {
+	return sampleCatalogService(), nil
+}
+
+func (catalogStub) GetCatalogService(context.Context, uuid.UUID) (*models.CatalogService, error) {
+	return sampleCatalogService(), nil
+}
+
+func (catalogStub) ListCatalogServices(context.Context, int, int) ([]*models.CatalogService, error) {
+	return []*models.CatalogService{sampleCatalogService()}, nil
+}
+
+func (catalogStub) UpdateCatalogService(context.Context, uuid.UUID, *string, *string, *string, *string, *int) (*models.CatalogService, error) {
+	return sampleCatalogService(), nil
+}
+
+func (catalogStub) DeleteCatalogService(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (catalogStub) SuggestCatalogServices(context.Context, string, int) ([]*models.CatalogService, error) {
+	return []*models.CatalogService{sampleCatalogService()}, nil
+}
+
+type memberStub struct{}
+
+func (memberStub) AddMember(_ context.Context, subscriptionID, userID uuid.UUID, sharePercent int) (*models.SubscriptionMember, error) {
+	return models.RestoreSubscriptionMember(subscriptionID, userID, sharePercent, now), nil
+}
+
+func (memberStub) ListMembers(_ context.Context, subscriptionID uuid.UUID) ([]*models.SubscriptionMember, error) {
+	return []*models.SubscriptionMember{models.RestoreSubscriptionMember(subscriptionID, planID, 25, now)}, nil
+}
+
+func (memberStub) RemoveMember(context.Context, uuid.UUID, uuid.UUID) error {
+	return nil
+}
+
+type alertStub struct{}
+
+func sampleCostAlert() *models.CostAlert {
+	return models.RestoreCostAlert(planID, userID, 3000, now)
+}
+
+func (alertStub) CreateAlert(context.Context, uuid.UUID, int) (*models.CostAlert, error) {
+	return sampleCostAlert(), nil
+}
+
+func (alertStub) ListAlerts(context.Context, uuid.UUID) ([]*models.CostAlert, error) {
+	return []*models.CostAlert{sampleCostAlert()}, nil
+}
+
+func (alertStub) DeleteAlert(context.Context, uuid.UUID, uuid.UUID) error {
+	return nil
+}
+
+func (alertStub) GetAlertStatuses(context.Context, uuid.UUID) ([]*models.CostAlertStatus, error) {
+	return []*models.CostAlertStatus{models.NewCostAlertStatus(sampleCostAlert(), start, 3497, &now)}, nil
+}
+
+func (alertStub) EvaluateAlerts(context.Context) (int, error) {
+	return 0, nil
+}
+
+type calendarFeedStub struct{}
+
+func (calendarFeedStub) IssueToken(context.Context, uuid.UUID) (*models.CalendarFeedToken, string, error) {
+	return models.RestoreCalendarFeedToken(userID, models.HashAPIKey("cal_test"), now), "cal_test", nil
+}
+
+func (calendarFeedStub) RevokeToken(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (calendarFeedStub) GetFeed(_ context.Context, _ uuid.UUID, token string) (*models.CalendarFeed, error) {
+	if token == "" {
+		return nil, apperror.Unauthorized("calendar feed token is required")
+	}
+	forecast := models.NewCostForecast(start, models.CalendarFeedMonths, models.BillingMonthly)
+	for _, month := range forecast.Months() {
+		if !month.Month().After(end) {
+			month.AddSubscription(sampleSubscription(), nil, models.NewPriceSchedule(400, nil))
+		}
+	}
+	return models.NewCalendarFeed(userID, forecast), nil
+}
+
+type artifactStub struct{}
+
+func (artifactStub) Store(_ context.Context, kind models.ArtifactKind, filename, _ string, body io.Reader, size int64) (*models.Artifact, error) {
+	if _, err := io.Copy(io.Discard, body); err != nil {
+		return nil, err
+	}
+	key := models.NewArtifactKey(kind, now, filename)
+	return models.NewArtifact(key, size, "https://storage.example.com/"+key+"?X-Amz-Signature=test", now.Add(time.Hour)), nil
+}
+
+func (artifactStub) CleanupExpired(context.Context) (int, error) {
+	return 0, nil
+}
+
+type limitStub struct{}
+
+func (limitStub) GetSubscriptionLimit(context.Context, uuid.UUID) (*models.SubscriptionLimitUsage, error) {
+	return models.NewSubscriptionLimitUsage(userID, 0, nil, 3), nil
+}
+
+func (limitStub) SetSubscriptionLimit(_ context.Context, id uuid.UUID, maxSubscriptions int, reason string) (*models.SubscriptionLimitUsage, error) {
+	limit := models.RestoreSubscriptionLimit(id, maxSubscriptions, reason, now)
+	return models.NewSubscriptionLimitUsage(id, maxSubscriptions, limit, 3), nil
+}
+
+func (limitStub) ResetSubscriptionLimit(context.Context, uuid.UUID) error {
+	return nil
+}
+
+type consistencyStub struct {
+	service.ConfigConsistencyService
+}
+
+func (consistencyStub) Check(context.Context) (*models.ConfigConsistencyReport, error) {
+	local := models.NewConfigFingerprint("replica-1", "abc123")
+	local.SetReportedAt(now)
+	return models.NewConfigConsistencyReport(local, []*models.ConfigFingerprint{local}), nil
+}
+
+type spendStub struct{}
+
+func (spendStub) BuildUserSpendReport(context.Context, string, string, *models.BillingMode) (*models.UserSpendReport, error) {
+	users := []*models.UserSpend{models.NewUserSpend(userID, 4800, 1)}
+	return models.NewUserSpendReport(period(), models.BillingMonthly, users), nil
+}
+
+type ruleStub struct{}
+
+func sampleRule() *models.ServiceNameRule {
+	return models.RestoreServiceNameRule(subscriptionID, models.ServiceNameDenyList, models.ServiceNameMatchExact, "casino", "spam", now)
+}
+
+func (ruleStub) AddRule(context.Context, models.ServiceNameList, models.ServiceNameMatch, string, string) (*models.ServiceNameRule, error) {
+	return sampleRule(), nil
+}
+
+func (ruleStub) ListRules(context.Context) ([]*models.ServiceNameRule, error) {
+	return []*models.ServiceNameRule{sampleRule()}, nil
+}
+
+func (ruleStub) DeleteRule(context.Context, uuid.UUID) error {
+	return nil
+}
+
+type canonicalStub struct{}
+
+func sampleCanonicalName() *models.CanonicalServiceName {
+	return models.RestoreCanonicalServiceName(subscriptionID, "Netflix", []string{"Нетфликс"}, now)
+}
+
+func (canonicalStub) AddEntry(context.Context, string, []string) (*models.CanonicalServiceName, error) {
+	return sampleCanonicalName(), nil
+}
+
+func (canonicalStub) ListEntries(context.Context) ([]*models.CanonicalServiceName, error) {
+	return []*models.CanonicalServiceName{sampleCanonicalName()}, nil
+}
+
+func (canonicalStub) DeleteEntry(context.Context, uuid.UUID) error {
+	return nil
+}
+
+type discountStub struct{}
+
+func sampleDiscount() *models.Discount {
+	service := "Yandex Plus"
+	return models.RestoreDiscount(subscriptionID, "SUMMER25", models.DiscountPercentage, 25, &service, start, &end, now)
+}
+
+func (discountStub) CreateDiscount(context.Context, string, models.DiscountKind, int, *string, string, *string) (*models.Discount, error) {
+	return sampleDiscount(), nil
+}
+
+func (discountStub) GetDiscount(context.Context, uuid.UUID) (*models.Discount, error) {
+	return sampleDiscount(), nil
+}
+
+func (discountStub) ListDiscounts(context.Context) ([]*models.Discount, error) {
+	return []*models.Discount{sampleDiscount()}, nil
+}
+
+func (discountStub) DeleteDiscount(context.Context, uuid.UUID) error {
+	return nil
+}
+
+type analyticsStub struct{}
+
+func (analyticsStub) GetTopServices(context.Context, string, string, string, int, *models.BillingMode) (*models.TopServicesReport, error) {
+	services := []*models.ServiceStats{models.NewServiceStats("Yandex Plus", 10, 12, 48000)}
+	return models.NewTopServicesReport(period(), models.BillingMonthly, models.TopServicesBySubscribers, services), nil
+}
+
+func (analyticsStub) GetMRR(context.Context, string, string) (*models.MRRReport, error) {
+	return models.NewMRRReport(period(), []*models.MRRPoint{models.NewMRRPoint(start, 4000, 10)}), nil
+}
+
+func (analyticsStub) GetChurn(context.Context, string, string) (*models.ChurnReport, error) {
+	return models.NewChurnReport(period(), []*models.ChurnPoint{models.NewChurnPoint(start, 10, 2, 1)}), nil
+}
+
+type deadLetterStub struct{}
+
+func sampleDeadLetter() *models.DeadLetter {
+	return models.RestoreDeadLetter(subscriptionID, models.DeadLetterSourceOutboxDelivery, "subscription-events", uuid.New(), "subscription.created", []byte(`{"id":"1"}`), "broker unavailable", now, nil, 0)
+}
+
+func (deadLetterStub) ListDeadLetters(context.Context, string, string, string, int, int) ([]*models.DeadLetter, int, error) {
+	return []*models.DeadLetter{sampleDeadLetter()}, 1, nil
+}
+
+func (deadLetterStub) RetryDeadLetter(context.Context, uuid.UUID) (*models.DeadLetter, error) {
+	return sampleDeadLetter(), nil
+}
+
+type authStub struct {
+	service.AuthService
+}
+
+func sampleAPIKey() *models.APIKey {
+	return models.RestoreAPIKey(subscriptionID, "dashboard", "sk_abcdefg", strings.Repeat("0", 64), models.RoleViewer, now, nil)
+}
+
+func (authStub) CreateAPIKey(context.Context, string, string) (*models.APIKey, string, error) {
+	return sampleAPIKey(), "sk_secret", nil
+}
+
+func (authStub) ListAPIKeys(context.Context) ([]*models.APIKey, error) {
+	return []*models.APIKey{sampleAPIKey()}, nil
+}
+
+func (authStub) RevokeAPIKey(context.Context, uuid.UUID) (*models.APIKey, error) {
+	key := sampleAPIKey()
+	return models.RestoreAPIKey(key.ID(), key.Name(), key.Prefix(), key.KeyHash(), key.Role(), key.CreatedAt(), &now), nil
+}
+
+// poolStub — пул без соединений: pgxpool подключается лениво, и Stat
+// работает без базы.
+type poolStub struct {
+	pool   *pgxpool.Pool
+	sizing postgres.PoolSizing
+}
+
+func newPoolStub(t *testing.T) *poolStub {
+	pool, err := pgxpool.New(context.Background(), "postgres://localhost:1/contract")
+	if err != nil {
+		t.Fatalf("pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return &poolStub{pool: pool, sizing: postgres.PoolSizing{MinConns: 5, MaxConns: 25, Limit: 100}}
+}
+
+func (p *poolStub) Stats() *pgxpool.Stat {
+	return p.pool.Stat()
+}
+
+func (p *poolStub) PoolSizing() postgres.PoolSizing {
+	return p.sizing
+}
+
+func (p *poolStub) ResizePool(_ context.Context, minConns, maxConns int32) (postgres.PoolSizing, error) {
+	return postgres.PoolSizing{MinConns: minConns, MaxConns: maxConns, Limit: p.sizing.Limit}, nil
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+)
+
+// AccessHandler — API-ключи и просмотр действующих разрешений.
+type AccessHandler struct {
+	auth        service.AuthService
+	authEnabled bool
+	logger      *logger.Logger
+}
+
+func NewAccessHandler(auth service.AuthService, authEnabled bool, logger *logger.Logger) *AccessHandler {
+	return &AccessHandler{
+		auth:        auth,
+		authEnabled: authEnabled,
+		logger:      logger.Named("access-handler"),
+	}
+}
+
+func (h *AccessHandler) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin")
+	{
+		admin.GET("/permissions", h.GetPermissions)
+		admin.GET("/api-keys", h.ListAPIKeys)
+		admin.POST("/api-keys", h.CreateAPIKey)
+		admin.DELETE("/api-keys/:id", h.RevokeAPIKey)
+	}
+}
+
+// Routes — операции управления API-ключами и правами для OpenAPI.
+func (h *AccessHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/permissions",
+			ID:          "GetPermissions",
+			Summary:     "Effective permissions",
+			Description: "Role, permissions and per-endpoint access of the caller, or of the role given in ?role=. With auth disabled every endpoint is open and the caller is reported as admin.",
+			Tags:        []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("role", "Inspect this role instead of the caller", openapi.Enum("admin", "operator", "viewer")),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.PermissionsResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/api-keys",
+			ID:          "ListAPIKeys",
+			Summary:     "List API keys",
+			Description: "All API keys including revoked ones; the keys themselves are never returned",
+			Tags:        []string{"admin"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.APIKeysListResponse{}},
+			},
+			Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/admin/api-keys",
+			ID:          "CreateAPIKey",
+			Summary:     "Create API key",
+			Description: "Issue a key with a role. The key is returned only in this response; send it as X-API-Key.",
+			Tags:        []string{"admin"},
+			Body:        request.CreateAPIKeyRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.CreatedAPIKeyResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/admin/api-keys/:id",
+			ID:      "RevokeAPIKey",
+			Summary: "Revoke API key",
+			Tags:    []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "API key ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.APIKeyResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *AccessHandler) GetPermissions(c *gin.Context) {
+	resp := response.PermissionsResponse{AuthEnabled: h.authEnabled}
+
+	role := models.RoleAdmin
+	if principal := middleware.CurrentPrincipal(c); principal != nil {
+		role = principal.Role()
+		resp.Subject = principal.Subject()
+		resp.Source = principal.Source()
+	}
+
+	if value := c.Query("role"); value != "" {
+		parsed, err := models.ParseRole(value)
+		if err != nil {
+			c.Error(apperror.InvalidInput("role", err.Error()))
+			return
+		}
+		role = parsed
+		resp.Subject = ""
+		resp.Source = ""
+	}
+
+	resp.Role = string(role)
+	resp.Permissions = mappers.PermissionsToStrings(role.Permissions())
+	resp.Endpoints = endpointPermissions(role)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// endpointPermissions перечисляет маршруты из карты разрешений по пути и методу.
+func endpointPermissions(role models.Role) []response.EndpointPermissionResponse {
+	endpoints := make([]response.EndpointPermissionResponse, 0, len(middleware.EndpointPermissions))
+	for key, permission := range middleware.EndpointPermissions {
+		method, path, _ := strings.Cut(key, " ")
+		endpoints = append(endpoints, response.EndpointPermissionResponse{
+			Method:     method,
+			Path:       path,
+			Permission: string(permission),
+			Allowed:    permission == middleware.PermissionPublic || role.Can(permission),
+		})
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path != endpoints[j].Path {
+			return endpoints[i].Path < endpoints[j].Path
+		}
+		return endpoints[i].Method < endpoints[j].Method
+	})
+	return endpoints
+}
+
+func (h *AccessHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.auth.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.APIKeysToResponse(keys))
+}
+
+func (h *AccessHandler) CreateAPIKey(c *gin.Context) {
+	var req request.CreateAPIKeyRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	key, secret, err := h.auth.CreateAPIKey(c.Request.Context(), req.Name, req.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.CreatedAPIKeyToResponse(key, secret))
+}
+
+func (h *AccessHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	key, err := h.auth.RevokeAPIKey(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.APIKeyToResponse(key))
+}
@@ -0,0 +1,575 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/livestats"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+type AdminHandler struct {
+	consistency service.ConfigConsistencyService
+	reports     service.SpendReportService
+	nameRules   service.ServiceNameRuleService
+	discounts   service.DiscountService
+	analytics   service.AnalyticsService
+	deadLetters service.DeadLetterService
+	live        *livestats.Stats
+	logger      *logger.Logger
+}
+
+func NewAdminHandler(consistency service.ConfigConsistencyService, reports service.SpendReportService, nameRules service.ServiceNameRuleService, discounts service.DiscountService, analytics service.AnalyticsService, deadLetters service.DeadLetterService, live *livestats.Stats, logger *logger.Logger) *AdminHandler {
+	return &AdminHandler{
+		consistency: consistency,
+		reports:     reports,
+		nameRules:   nameRules,
+		discounts:   discounts,
+		analytics:   analytics,
+		deadLetters: deadLetters,
+		live:        live,
+		logger:      logger.Named("admin-handler"),
+	}
+}
+
+func (h *AdminHandler) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin")
+	{
+		admin.GET("/config/consistency", h.GetConfigConsistency)
+		admin.GET("/reports/user-spend", h.GetUserSpendReport)
+		admin.GET("/live", h.StreamLiveStats)
+		admin.GET("/service-name-rules", h.ListServiceNameRules)
+		admin.POST("/service-name-rules", h.CreateServiceNameRule)
+		admin.DELETE("/service-name-rules/:id", h.DeleteServiceNameRule)
+		admin.GET("/discounts", h.ListDiscounts)
+		admin.POST("/discounts", h.CreateDiscount)
+		admin.GET("/discounts/:id", h.GetDiscount)
+		admin.DELETE("/discounts/:id", h.DeleteDiscount)
+		admin.GET("/analytics/top-services", h.GetTopServices)
+		admin.GET("/analytics/mrr", h.GetMRR)
+		admin.GET("/analytics/churn", h.GetChurn)
+		admin.GET("/dead-letters", h.ListDeadLetters)
+		admin.POST("/dead-letters/:id/retry", h.RetryDeadLetter)
+	}
+}
+
+// Routes перечисляет административные операции с их ответами и ошибками.
+func (h *AdminHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/config/consistency",
+			ID:          "GetConfigConsistency",
+			Summary:     "Check configuration consistency across replicas",
+			Description: "Compare the effective configuration hash of this instance with hashes published by other live replicas",
+			Tags:        []string{"admin"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.ConfigConsistencyResponse{}},
+			},
+			Errors: []int{http.StatusInternalServerError, http.StatusServiceUnavailable},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/reports/user-spend",
+			ID:          "GetUserSpendReport",
+			Summary:     "Per-user spend report",
+			Description: "Aggregate spend of every user for a period, sorted by spend descending. Aggregation fans out over user ID ranges in parallel.",
+			Tags:        []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("start_date", "Start date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("end_date", "End date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("billing", "Billing math: monthly or prorated (defaults to billing.mode)", openapi.Enum("monthly", "prorated")),
+				openapi.QueryParam("limit", "Page size", openapi.Integer().WithDefault(20).WithMaximum(100)),
+				openapi.QueryParam("offset", "Page offset", openapi.Integer().WithDefault(0)),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.UserSpendReportResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError, http.StatusServiceUnavailable},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/live",
+			ID:          "StreamLiveStats",
+			Summary:     "Live operational counters",
+			Description: "Server-Sent Events stream of in-process counters (requests per second, subscription creates per minute, active subscriptions). A \"stats\" event is sent on connect and then every metrics.live.interval seconds.",
+			Tags:        []string{"admin"},
+			ContentType: "text/event-stream",
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Description: "Payload of each \"stats\" event", Body: response.LiveStatsResponse{}},
+			},
+			Errors: []int{http.StatusServiceUnavailable},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/service-name-rules",
+			ID:          "ListServiceNameRules",
+			Summary:     "List service name rules",
+			Description: "List admin-managed allow/deny rules applied to service names on create and update",
+			Tags:        []string{"admin"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.ServiceNameRulesListResponse{}},
+			},
+			Errors: []int{http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/admin/service-name-rules",
+			ID:          "CreateServiceNameRule",
+			Summary:     "Add service name rule",
+			Description: "Add an exact (case-insensitive) or regex rule to the allow or deny list. Deny rules win; when any allow rule exists, names must match one of them.",
+			Tags:        []string{"admin"},
+			Body:        request.CreateServiceNameRuleRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.ServiceNameRuleResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/admin/service-name-rules/:id",
+			ID:      "DeleteServiceNameRule",
+			Summary: "Delete service name rule",
+			Tags:    []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Rule ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/admin/discounts",
+			ID:      "ListDiscounts",
+			Summary: "List promo codes",
+			Tags:    []string{"admin"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.DiscountsListResponse{}},
+			},
+			Errors: []int{http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/admin/discounts",
+			ID:          "CreateDiscount",
+			Summary:     "Create promo code",
+			Description: "Create a percentage or fixed monthly discount. Codes are case-insensitive; service_name limits the code to one service; valid_to may be omitted for an open-ended code. Subscriptions redeem it via promo_code on create.",
+			Tags:        []string{"admin"},
+			Body:        request.CreateDiscountRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.DiscountResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/admin/discounts/:id",
+			ID:      "GetDiscount",
+			Summary: "Get promo code",
+			Tags:    []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Discount ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.DiscountResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/admin/discounts/:id",
+			ID:          "DeleteDiscount",
+			Summary:     "Delete promo code",
+			Description: "A promo code attached to any subscription cannot be deleted.",
+			Tags:        []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Discount ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/analytics/top-services",
+			ID:          "GetTopServices",
+			Summary:     "Top services",
+			Description: "Rank services by distinct subscribers or by revenue (historical prices, net of discounts) over a period. Results are cached for reports.analytics_cache_ttl seconds.",
+			Tags:        []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("start_date", "Start date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("end_date", "End date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("sort", "Ranking order", openapi.Enum("subscribers", "revenue").WithDefault("subscribers")),
+				openapi.QueryParam("limit", "Number of services", openapi.Integer().WithDefault(10).WithMaximum(100)),
+				openapi.QueryParam("billing", "Billing math: monthly or prorated (defaults to billing.mode)", openapi.Enum("monthly", "prorated")),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.TopServicesResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/analytics/mrr",
+			ID:          "GetMRR",
+			Summary:     "Monthly recurring revenue",
+			Description: "MRR for every month of the period: monthly price (historical, net of discounts) of subscriptions active in that month. At most 120 months. Results are cached for reports.analytics_cache_ttl seconds.",
+			Tags:        []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("start_date", "Start date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("end_date", "End date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MRRResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/analytics/churn",
+			ID:          "GetChurn",
+			Summary:     "Subscription churn",
+			Description: "Monthly churn computed from start and end dates: subscriptions active at the start of the month, started and ended within it, and churned / active_at_start. At most 120 months. Results are cached for reports.analytics_cache_ttl seconds.",
+			Tags:        []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("start_date", "Start date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("end_date", "End date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.ChurnResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/dead-letters",
+			ID:          "ListDeadLetters",
+			Summary:     "List dead letters",
+			Description: "Subscription events that could not be delivered, newest first",
+			Tags:        []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("source", "Where delivery failed", openapi.Enum("event_recording", "outbox_delivery")),
+				openapi.QueryParam("event_type", "Event type, e.g. subscription.created", openapi.String()),
+				openapi.QueryParam("status", "pending: not retried yet; requeued: already put back into the outbox", openapi.Enum("pending", "requeued")),
+				openapi.QueryParam("limit", "Page size", openapi.Integer().WithDefault(20).WithMaximum(100)),
+				openapi.QueryParam("offset", "Page offset", openapi.Integer().WithDefault(0)),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.DeadLettersListResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/admin/dead-letters/:id/retry",
+			ID:          "RetryDeadLetter",
+			Summary:     "Retry a dead letter",
+			Description: "Put the event back into the outbox (restoring it in the event log if it never got there) and mark the dead letter as requeued",
+			Tags:        []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Dead letter ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.DeadLetterResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *AdminHandler) GetConfigConsistency(c *gin.Context) {
+	if h.consistency == nil {
+		c.Error(apperror.ServiceUnavailable("config-consistency", nil).
+			WithDetail("reason", "consistency check is disabled"))
+		return
+	}
+
+	report, err := h.consistency.Check(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.ConfigConsistencyToResponse(report))
+}
+
+func (h *AdminHandler) GetUserSpendReport(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("limit", "must be an integer"))
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("offset", "must be an integer"))
+		return
+	}
+
+	limit, offset, err = utils.ValidatePagination(limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	billing, err := parseBillingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	report, err := h.reports.BuildUserSpendReport(c.Request.Context(), c.Query("start_date"), c.Query("end_date"), billing)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.UserSpendReportToResponse(report, limit, offset, middleware.ResponseDateFormat(c)))
+}
+
+func (h *AdminHandler) ListServiceNameRules(c *gin.Context) {
+	rules, err := h.nameRules.ListRules(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.ServiceNameRulesToResponse(rules))
+}
+
+func (h *AdminHandler) CreateServiceNameRule(c *gin.Context) {
+	var req request.CreateServiceNameRuleRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	rule, err := h.nameRules.AddRule(
+		c.Request.Context(),
+		models.ServiceNameList(req.List),
+		models.ServiceNameMatch(req.Match),
+		req.Pattern,
+		req.Reason,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.ServiceNameRuleToResponse(rule))
+}
+
+func (h *AdminHandler) DeleteServiceNameRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	if err := h.nameRules.DeleteRule(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MessageResponse{
+		Message: "Service name rule deleted successfully",
+	})
+}
+
+func (h *AdminHandler) ListDiscounts(c *gin.Context) {
+	discounts, err := h.discounts.ListDiscounts(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.DiscountsToResponse(discounts, middleware.ResponseDateFormat(c)))
+}
+
+func (h *AdminHandler) CreateDiscount(c *gin.Context) {
+	var req request.CreateDiscountRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	discount, err := h.discounts.CreateDiscount(
+		c.Request.Context(),
+		req.Code,
+		models.DiscountKind(req.Kind),
+		req.Amount,
+		utils.StringPtr(req.ServiceName),
+		req.ValidFrom,
+		utils.StringPtr(req.ValidTo),
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.DiscountToResponse(discount, middleware.ResponseDateFormat(c)))
+}
+
+func (h *AdminHandler) GetDiscount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	discount, err := h.discounts.GetDiscount(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.DiscountToResponse(discount, middleware.ResponseDateFormat(c)))
+}
+
+func (h *AdminHandler) DeleteDiscount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	if err := h.discounts.DeleteDiscount(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MessageResponse{
+		Message: "Discount deleted successfully",
+	})
+}
+
+func (h *AdminHandler) GetTopServices(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("limit", "must be an integer"))
+		return
+	}
+
+	billing, err := parseBillingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	report, err := h.analytics.GetTopServices(c.Request.Context(), c.Query("start_date"), c.Query("end_date"), c.Query("sort"), limit, billing)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.TopServicesToResponse(report, middleware.ResponseDateFormat(c)))
+}
+
+func (h *AdminHandler) GetMRR(c *gin.Context) {
+	report, err := h.analytics.GetMRR(c.Request.Context(), c.Query("start_date"), c.Query("end_date"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.MRRToResponse(report, middleware.ResponseDateFormat(c)))
+}
+
+func (h *AdminHandler) GetChurn(c *gin.Context) {
+	report, err := h.analytics.GetChurn(c.Request.Context(), c.Query("start_date"), c.Query("end_date"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.ChurnToResponse(report, middleware.ResponseDateFormat(c)))
+}
+
+func (h *AdminHandler) ListDeadLetters(c *gin.Context) {
+	limit, offset, err := utils.ValidatePagination(parseIntQuery(c, "limit", 20), parseIntQuery(c, "offset", 0))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	deadLetters, total, err := h.deadLetters.ListDeadLetters(c.Request.Context(), c.Query("source"), c.Query("event_type"), c.Query("status"), limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.DeadLettersToListResponse(deadLetters, limit, offset, total))
+}
+
+func (h *AdminHandler) RetryDeadLetter(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	deadLetter, err := h.deadLetters.RetryDeadLetter(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.DeadLetterToResponse(deadLetter))
+}
+
+func (h *AdminHandler) StreamLiveStats(c *gin.Context) {
+	if h.live == nil {
+		c.Error(apperror.ServiceUnavailable("live-stats", nil).
+			WithDetail("reason", "live stats are disabled"))
+		return
+	}
+
+	// Поток живёт дольше server.write_timeout, снимаем дедлайн для этого соединения.
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("failed to clear write deadline for live stream", zap.Error(err))
+	}
+
+	updates, unsubscribe := h.live.Subscribe()
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent("stats", mappers.LiveStatsToResponse(h.live.Snapshot()))
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case snap, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.SSEvent("stats", mappers.LiveStatsToResponse(snap))
+			return true
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// calendarFeedCacheControl — календари опрашивают ленту сами, раз в
+// несколько часов; чаще отдавать её из кэша прокси незачем.
+const calendarFeedCacheControl = "private, max-age=3600"
+
+type CalendarFeedHandler struct {
+	service service.CalendarFeedService
+	ids     *publicid.Registry
+	logger  *logger.Logger
+}
+
+func NewCalendarFeedHandler(service service.CalendarFeedService, ids *publicid.Registry, logger *logger.Logger) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		service: service,
+		ids:     ids,
+		logger:  logger.Named("calendar-feed-handler"),
+	}
+}
+
+func (h *CalendarFeedHandler) RegisterRoutes(router *gin.RouterGroup) {
+	users := router.Group("/users")
+	{
+		users.POST("/:user_id/billing-calendar/feed", h.IssueCalendarFeed)
+		users.DELETE("/:user_id/billing-calendar/feed", h.RevokeCalendarFeed)
+		users.GET("/:user_id/billing-calendar.ics", h.GetCalendarFeed)
+	}
+}
+
+// Routes описывает ленту календаря так, как её регистрирует RegisterRoutes.
+func (h *CalendarFeedHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/users/:user_id/billing-calendar/feed",
+			ID:          "IssueCalendarFeed",
+			Summary:     "Issue a calendar feed link",
+			Description: "Returns the URL of the user's iCalendar feed with a new token. The token is shown only in this response; issuing a new one revokes the previous link.",
+			Tags:        []string{"costs"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.CalendarFeedResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/users/:user_id/billing-calendar/feed",
+			ID:      "RevokeCalendarFeed",
+			Summary: "Revoke the calendar feed link",
+			Tags:    []string{"costs"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:user_id/billing-calendar.ics",
+			ID:          "GetCalendarFeed",
+			Summary:     "iCalendar feed of charges and end dates",
+			Description: "All-day events for every charge (billing day, current price) and every end date in the next 12 months, starting with the current one. Protected by the feed token instead of API credentials so Google and Apple Calendar can subscribe to the URL.",
+			Tags:        []string{"costs"},
+			ContentType: "text/calendar",
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+				openapi.QueryParam("token", "Calendar feed token", openapi.String()).Require(),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Description: "iCalendar (RFC 5545) document", Body: ""},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *CalendarFeedHandler) IssueCalendarFeed(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	token, secret, err := h.service.IssueToken(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.CalendarFeedTokenToResponse(token, secret, calendarFeedURL(c, secret)))
+}
+
+func (h *CalendarFeedHandler) RevokeCalendarFeed(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.service.RevokeToken(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MessageResponse{
+		Message: "Calendar feed link revoked successfully",
+	})
+}
+
+func (h *CalendarFeedHandler) GetCalendarFeed(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	feed, err := h.service.GetFeed(c.Request.Context(), userID, c.Query("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	calendar, err := mappers.CalendarFeedToICal(feed, time.Now().UTC(), h.ids.For(feed.UserID().String()))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", `inline; filename="subscriptions.ics"`)
+	c.Header("Cache-Control", calendarFeedCacheControl)
+	c.Status(http.StatusOK)
+	if err := calendar.Write(c.Writer); err != nil {
+		h.logger.Warn("calendar feed write interrupted", zap.Error(err))
+	}
+}
+
+// calendarFeedURL — абсолютная ссылка на ленту рядом с маршрутом выпуска
+// токена. За прокси схема берётся из X-Forwarded-Proto.
+func calendarFeedURL(c *gin.Context, token string) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	path := strings.TrimSuffix(c.Request.URL.Path, "/feed") + ".ics"
+	return scheme + "://" + c.Request.Host + path + "?token=" + token
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+)
+
+// CanonicalServiceNameHandler — словарь канонических названий сервисов.
+type CanonicalServiceNameHandler struct {
+	service service.CanonicalServiceNameService
+	logger  *logger.Logger
+}
+
+func NewCanonicalServiceNameHandler(service service.CanonicalServiceNameService, logger *logger.Logger) *CanonicalServiceNameHandler {
+	return &CanonicalServiceNameHandler{
+		service: service,
+		logger:  logger.Named("canonical-service-name-handler"),
+	}
+}
+
+func (h *CanonicalServiceNameHandler) RegisterRoutes(router *gin.RouterGroup) {
+	names := router.Group("/admin/service-names")
+	{
+		names.GET("", h.ListCanonicalServiceNames)
+		names.POST("", h.CreateCanonicalServiceName)
+		names.DELETE("/:id", h.DeleteCanonicalServiceName)
+	}
+}
+
+func (h *CanonicalServiceNameHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/admin/service-names",
+			ID:          "ListCanonicalServiceNames",
+			Summary:     "List canonical service names",
+			Description: "List the dictionary of canonical service names and their alternative spellings",
+			Tags:        []string{"admin"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CanonicalServiceNamesListResponse{}},
+			},
+			Errors: []int{http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/admin/service-names",
+			ID:          "CreateCanonicalServiceName",
+			Summary:     "Add canonical service name",
+			Description: "Add a canonical spelling with optional aliases. Names that differ only in case and whitespace, or match an alias, are replaced with name on create and update, and the service-name-normalization job renames stored subscriptions. The original spelling is kept in metadata.original_service_name.",
+			Tags:        []string{"admin"},
+			Body:        request.CreateCanonicalServiceNameRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.CanonicalServiceNameResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/admin/service-names/:id",
+			ID:      "DeleteCanonicalServiceName",
+			Summary: "Delete canonical service name",
+			Tags:    []string{"admin"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Entry ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *CanonicalServiceNameHandler) ListCanonicalServiceNames(c *gin.Context) {
+	entries, err := h.service.ListEntries(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.CanonicalServiceNamesToResponse(entries))
+}
+
+func (h *CanonicalServiceNameHandler) CreateCanonicalServiceName(c *gin.Context) {
+	var req request.CreateCanonicalServiceNameRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	entry, err := h.service.AddEntry(c.Request.Context(), req.Name, req.Aliases)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.CanonicalServiceNameToResponse(entry))
+}
+
+func (h *CanonicalServiceNameHandler) DeleteCanonicalServiceName(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	if err := h.service.DeleteEntry(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MessageResponse{
+		Message: "Canonical service name deleted successfully",
+	})
+}
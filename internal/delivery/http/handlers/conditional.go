@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// resourceVersion описывает версию представления ресурса для условных GET.
+type resourceVersion struct {
+	tag          string
+	lastModified time.Time
+}
+
+// newResourceVersion строит непрозрачный тег из частей, однозначно
+// определяющих представление (ID, updated_at, число вложенных записей).
+func newResourceVersion(lastModified time.Time, parts ...interface{}) resourceVersion {
+	hash := fnv.New64a()
+	for _, part := range parts {
+		fmt.Fprint(hash, part, "|")
+	}
+
+	return resourceVersion{
+		tag:          strconv.FormatUint(hash.Sum64(), 36),
+		lastModified: lastModified,
+	}
+}
+
+func (v resourceVersion) etag() string {
+	return `"` + v.tag + `"`
+}
+
+// setValidators выставляет ETag и Last-Modified и отвечает 304, если клиент
+// уже держит актуальную версию. Возвращает true, если ответ отправлен.
+func setValidators(c *gin.Context, version resourceVersion) bool {
+	etag := version.etag()
+	lastModified := version.lastModified.UTC().Truncate(time.Second)
+
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	c.Header("Cache-Control", "no-cache")
+
+	if !isFresh(c.Request, etag, lastModified) {
+		return false
+	}
+
+	c.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+// isFresh следует RFC 9110: If-None-Match приоритетнее If-Modified-Since.
+func isFresh(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.After(since)
+	}
+
+	return false
+}
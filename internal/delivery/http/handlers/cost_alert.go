@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+type CostAlertHandler struct {
+	service service.CostAlertService
+	logger  *logger.Logger
+}
+
+func NewCostAlertHandler(service service.CostAlertService, logger *logger.Logger) *CostAlertHandler {
+	return &CostAlertHandler{
+		service: service,
+		logger:  logger.Named("cost-alert-handler"),
+	}
+}
+
+func (h *CostAlertHandler) RegisterRoutes(router *gin.RouterGroup) {
+	users := router.Group("/users")
+	{
+		users.POST("/:user_id/alerts", h.CreateCostAlert)
+		users.GET("/:user_id/alerts", h.ListCostAlerts)
+		users.DELETE("/:user_id/alerts/:id", h.DeleteCostAlert)
+	}
+}
+
+// Routes описывает пороги трат пользователя так, как их регистрирует RegisterRoutes.
+func (h *CostAlertHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/users/:user_id/alerts",
+			ID:          "CreateCostAlert",
+			Summary:     "Add a monthly cost alert",
+			Description: "A scheduled job compares the current month's spend after discounts with monthly_limit and emits one cost_alert.exceeded event per alert and month once the spend is above it.",
+			Tags:        []string{"alerts"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+			},
+			Body: request.CreateCostAlertRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.CostAlertResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/users/:user_id/alerts",
+			ID:      "ListCostAlerts",
+			Summary: "List a user's cost alerts",
+			Tags:    []string{"alerts"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CostAlertsListResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/users/:user_id/alerts/:id",
+			ID:      "DeleteCostAlert",
+			Summary: "Delete a cost alert",
+			Tags:    []string{"alerts"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+				openapi.PathParam("id", "Cost alert ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *CostAlertHandler) CreateCostAlert(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req request.CreateCostAlertRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	alert, err := h.service.CreateAlert(c.Request.Context(), userID, req.MonthlyLimit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.CostAlertToResponse(alert))
+}
+
+func (h *CostAlertHandler) ListCostAlerts(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	alerts, err := h.service.ListAlerts(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.CostAlertsToListResponse(alerts))
+}
+
+func (h *CostAlertHandler) DeleteCostAlert(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	if err := h.service.DeleteAlert(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MessageResponse{
+		Message: "Cost alert deleted successfully",
+	})
+}
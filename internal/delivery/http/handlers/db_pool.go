@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+)
+
+// DBPoolPath — маршрут размера пула; как и переключатель обслуживания, он
+// нужен во время инцидента, и middleware.Maintenance его не блокирует.
+const DBPoolPath = "/admin/db/pool"
+
+// PoolResizer — пул соединений с БД, размер которого меняется на ходу (postgres.DB).
+type PoolResizer interface {
+	PoolStatsSource
+	PoolSizing() postgres.PoolSizing
+	ResizePool(ctx context.Context, minConns, maxConns int32) (postgres.PoolSizing, error)
+}
+
+// DBPoolHandler — размер пула соединений этой реплики: посмотреть и
+// поменять во время инцидента, не перезапуская сервис.
+type DBPoolHandler struct {
+	pool   PoolResizer
+	logger *logger.Logger
+}
+
+func NewDBPoolHandler(pool PoolResizer, logger *logger.Logger) *DBPoolHandler {
+	return &DBPoolHandler{
+		pool:   pool,
+		logger: logger.Named("db-pool-handler"),
+	}
+}
+
+func (h *DBPoolHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET(DBPoolPath, h.GetPool)
+	router.PUT(DBPoolPath, h.ResizePool)
+}
+
+func (h *DBPoolHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        DBPoolPath,
+			ID:          "GetDBPool",
+			Summary:     "Database pool sizing",
+			Description: "Current min/max connections of this replica's pool, the upper bound for resizing and pool statistics",
+			Tags:        []string{"admin"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.DBPoolResponse{}},
+			},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        DBPoolPath,
+			ID:          "ResizeDBPool",
+			Summary:     "Resize the database pool",
+			Description: "Replace this replica's pool with one of the given size. Queries already running finish on the old pool. max_conns may not exceed database.max_conns_limit; the change is lost on restart.",
+			Tags:        []string{"admin"},
+			Body:        request.ResizeDBPoolRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.DBPoolResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusServiceUnavailable},
+		},
+	}
+}
+
+func (h *DBPoolHandler) GetPool(c *gin.Context) {
+	c.JSON(http.StatusOK, h.poolResponse(h.pool.PoolSizing()))
+}
+
+func (h *DBPoolHandler) ResizePool(c *gin.Context) {
+	var req request.ResizeDBPoolRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	current := h.pool.PoolSizing()
+	maxConns := *req.MaxConns
+	minConns := min(current.MinConns, maxConns)
+	if req.MinConns != nil {
+		minConns = *req.MinConns
+	}
+
+	if maxConns > current.Limit {
+		c.Error(apperror.ValidationFailed("max_conns", fmt.Sprintf("must not exceed %d", current.Limit)))
+		return
+	}
+	if minConns > maxConns {
+		c.Error(apperror.ValidationFailed("min_conns", "must not exceed max_conns"))
+		return
+	}
+
+	var actor string
+	if principal := middleware.CurrentPrincipal(c); principal != nil {
+		actor = principal.Subject()
+	}
+	h.logger.Info("database pool resize requested",
+		zap.Int32("min_conns", minConns),
+		zap.Int32("max_conns", maxConns),
+		zap.String("actor", actor))
+
+	sizing, err := h.pool.ResizePool(c.Request.Context(), minConns, maxConns)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPoolSizing) {
+			c.Error(apperror.ValidationFailed("max_conns", err.Error()))
+			return
+		}
+		if errors.Is(err, postgres.ErrPoolDraining) {
+			c.Error(apperror.Conflict("database pool", err.Error()))
+			return
+		}
+		c.Error(apperror.ServiceUnavailable("database", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.poolResponse(sizing))
+}
+
+func (h *DBPoolHandler) poolResponse(sizing postgres.PoolSizing) response.DBPoolResponse {
+	return response.DBPoolResponse{
+		MinConns:      sizing.MinConns,
+		MaxConns:      sizing.MaxConns,
+		MaxConnsLimit: sizing.Limit,
+		Stats:         poolStatsResponse(h.pool.Stats()),
+	}
+}
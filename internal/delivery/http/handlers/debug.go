@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+// PoolStatsSource — пул соединений с БД (postgres.DB).
+type PoolStatsSource interface {
+	Stats() *pgxpool.Stat
+}
+
+// DebugHandler обслуживает /debug/pool. pprof и expvar подключает роутер:
+// это стандартные обработчики net/http, их не описывает OpenAPI.
+type DebugHandler struct {
+	pool PoolStatsSource
+}
+
+func NewDebugHandler(pool PoolStatsSource) *DebugHandler {
+	return &DebugHandler{pool: pool}
+}
+
+// Pool — текущее состояние пула: сколько соединений занято, сколько раз
+// запросу пришлось ждать свободного (empty_acquire_count) и сколько ушло на ожидание.
+func (h *DebugHandler) Pool(c *gin.Context) {
+	c.JSON(http.StatusOK, poolStatsResponse(h.pool.Stats()))
+}
+
+func poolStatsResponse(stat *pgxpool.Stat) response.DBPoolStatsResponse {
+	return response.DBPoolStatsResponse{
+		MaxConns:                stat.MaxConns(),
+		TotalConns:              stat.TotalConns(),
+		AcquiredConns:           stat.AcquiredConns(),
+		IdleConns:               stat.IdleConns(),
+		ConstructingConns:       stat.ConstructingConns(),
+		AcquireCount:            stat.AcquireCount(),
+		AcquireDurationMs:       float64(stat.AcquireDuration().Microseconds()) / 1000,
+		EmptyAcquireCount:       stat.EmptyAcquireCount(),
+		CanceledAcquireCount:    stat.CanceledAcquireCount(),
+		NewConnsCount:           stat.NewConnsCount(),
+		MaxLifetimeDestroyCount: stat.MaxLifetimeDestroyCount(),
+		MaxIdleDestroyCount:     stat.MaxIdleDestroyCount(),
+	}
+}
@@ -1,26 +1,44 @@
 package handlers
 
 import (
-	"context"
 	"net/http"
-	"time"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/breaker"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/health"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
 )
 
+// Lifecycle — состояние сервера для проб: прогрев, дренаж и счётчик
+// неудачных внутренних проверок (server.Readiness).
+type Lifecycle interface {
+	State() string
+	Ready() bool
+	Alive() bool
+	Failures() (int, error)
+}
+
 type HealthHandler struct {
-	logger      *logger.Logger
-	healthCheck func(ctx context.Context) error
+	logger    *logger.Logger
+	checks    *health.Registry
+	lifecycle Lifecycle
+	breakers  *breaker.Group
 }
 
-func NewHealthHandler(logger *logger.Logger, healthCheck func(ctx context.Context) error) *HealthHandler {
+// NewHealthHandler: lifecycle может быть nil — тогда пробы смотрят только на
+// проверки зависимостей; breakers может быть nil, если автоматы отключены.
+func NewHealthHandler(logger *logger.Logger, checks *health.Registry, lifecycle Lifecycle, breakers *breaker.Group) *HealthHandler {
 	return &HealthHandler{
-		logger:      logger.Named("health-handler"),
-		healthCheck: healthCheck,
+		logger:    logger.Named("health-handler"),
+		checks:    checks,
+		lifecycle: lifecycle,
+		breakers:  breakers,
 	}
 }
 
@@ -33,69 +51,114 @@ func (h *HealthHandler) RegisterRoutes(router *gin.RouterGroup) {
 	}
 }
 
-// Health godoc
-// @Summary Health check
-// @Description Get overall health status of the service and its dependencies
-// @Tags health
-// @Produce json
-// @Success 200 {object} response.HealthResponse
-// @Failure 503 {object} response.HealthResponse
-// @Router /health [get]
-func (h *HealthHandler) Health(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
-	defer cancel()
-
-	services := make(map[string]string)
-	overallStatus := "healthy"
-
-	if h.healthCheck != nil {
-		if err := h.healthCheck(ctx); err != nil {
-			h.logger.Error("health check failed", zap.Error(err))
-			services["database"] = "unhealthy"
-			overallStatus = "unhealthy"
-
-			healthResp := response.HealthResponse{
-				Status:    overallStatus,
-				Timestamp: time.Now(),
-				Services:  services,
-			}
+// Routes — проверки живости и готовности; 503 описан как обычный ответ с телом.
+func (h *HealthHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/health/",
+			ID:          "Health",
+			Summary:     "Health check",
+			Description: "Get the status and latency of every dependency check and the state of every circuit breaker; non-critical failures and open breakers report degraded with 200",
+			Tags:        []string{"health"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.HealthResponse{}},
+				{Status: http.StatusServiceUnavailable, Body: response.HealthResponse{}},
+			},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/health/ready",
+			ID:          "Ready",
+			Summary:     "Readiness check",
+			Description: "Check if the service has finished warming up, is not draining, and its critical dependencies are available",
+			Tags:        []string{"health"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: map[string]string{}},
+				{Status: http.StatusServiceUnavailable, Body: map[string]string{}},
+			},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/health/live",
+			ID:          "Live",
+			Summary:     "Liveness check",
+			Description: "Check if service is alive; fails only after consecutive internal health check failures",
+			Tags:        []string{"health"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: map[string]string{}},
+				{Status: http.StatusServiceUnavailable, Body: map[string]string{}},
+			},
+		},
+	}
+}
 
-			c.JSON(http.StatusServiceUnavailable, healthResp)
-			return
+// Health отдаёт результат каждой проверки и состояние автоматов защиты.
+// Упавшая некритичная проверка или разомкнутый автомат дают degraded и 200:
+// сервис продолжает обслуживать запросы.
+func (h *HealthHandler) Health(c *gin.Context) {
+	report := h.checks.Check(c.Request.Context())
+	h.logFailures(report)
+
+	resp := response.HealthResponse{
+		Status:    report.Status,
+		Timestamp: report.CheckedAt,
+		Services:  make(map[string]string, len(report.Results)),
+		Checks:    make([]response.HealthCheckResponse, 0, len(report.Results)),
+		Cached:    report.Cached,
+	}
+	for _, res := range report.Results {
+		service := "healthy"
+		if res.Status == health.StatusDown {
+			service = "unhealthy"
 		}
-		services["database"] = "healthy"
+		resp.Services[res.Name] = service
+		resp.Checks = append(resp.Checks, response.HealthCheckResponse{
+			Name:      res.Name,
+			Status:    res.Status,
+			Critical:  res.Critical,
+			LatencyMs: float64(res.Latency.Microseconds()) / 1000,
+			Error:     res.Error,
+		})
 	}
 
-	healthResp := response.HealthResponse{
-		Status:    overallStatus,
-		Timestamp: time.Now(),
-		Services:  services,
+	if h.breakers != nil {
+		states := h.breakers.States()
+		resp.Breakers = make(map[string]string, len(states))
+		for name, state := range states {
+			resp.Breakers[name] = state.String()
+			if state == breaker.StateOpen && resp.Status == health.StatusHealthy {
+				resp.Status = health.StatusDegraded
+			}
+		}
 	}
 
-	c.JSON(http.StatusOK, healthResp)
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, resp)
 }
 
-// Ready godoc
-// @Summary Readiness check
-// @Description Check if service is ready to accept traffic
-// @Tags health
-// @Produce json
-// @Success 200 {object} map[string]string
-// @Failure 503 {object} map[string]string
-// @Router /health/ready [get]
+// Ready: сервер в состоянии ready и критичные проверки прошли.
 func (h *HealthHandler) Ready(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
-	defer cancel()
-
-	if h.healthCheck != nil {
-		if err := h.healthCheck(ctx); err != nil {
-			h.logger.Warn("readiness check failed", zap.Error(err))
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"status": "not ready",
-				"error":  "service dependencies unavailable",
-			})
-			return
-		}
+	if h.lifecycle != nil && !h.lifecycle.Ready() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status": "not ready",
+			"state":  h.lifecycle.State(),
+		})
+		return
+	}
+
+	report := h.checks.Check(c.Request.Context())
+	h.logFailures(report)
+
+	if !report.Ready() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status": "not ready",
+			"error":  "critical dependencies unavailable: " + strings.Join(report.FailedCritical(), ", "),
+		})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -103,14 +166,43 @@ func (h *HealthHandler) Ready(c *gin.Context) {
 	})
 }
 
-// Live godoc
-// @Summary Liveness check
-// @Description Check if service is alive
-// @Tags health
-// @Produce json
-// @Success 200 {object} map[string]string
-// @Router /health/live [get]
+// logFailures пишет упавшие проверки только для свежего отчёта, чтобы
+// опросы из кэша не повторяли одно и то же.
+func (h *HealthHandler) logFailures(report health.Report) {
+	if report.Cached {
+		return
+	}
+	for _, res := range report.Results {
+		if res.Status != health.StatusDown {
+			continue
+		}
+		log := h.logger.Warn
+		if res.Critical {
+			log = h.logger.Error
+		}
+		log("health check failed",
+			zap.String("check", res.Name),
+			zap.Duration("latency", res.Latency),
+			zap.String("error", res.Error))
+	}
+}
+
+// Live падает только после серии неудачных внутренних проверок подряд:
+// рестарт пода не лечит кратковременный сбой БД.
 func (h *HealthHandler) Live(c *gin.Context) {
+	if h.lifecycle != nil && !h.lifecycle.Alive() {
+		failures, err := h.lifecycle.Failures()
+		body := gin.H{
+			"status":   "not alive",
+			"failures": strconv.Itoa(failures),
+		}
+		if err != nil {
+			body["error"] = err.Error()
+		}
+		c.JSON(http.StatusServiceUnavailable, body)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "alive",
 	})
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/ws"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// accessTokenParam — токен в query: браузерный WebSocket не умеет задавать
+// заголовки рукопожатия.
+const accessTokenParam = "access_token"
+
+// LiveUpdatesHandler открывает WebSocket с событиями подписок одного пользователя.
+type LiveUpdatesHandler struct {
+	hub *ws.Hub
+	// auth == nil — аутентификация выключена, пользователь берётся из user_id.
+	auth   service.AuthService
+	logger *logger.Logger
+}
+
+func NewLiveUpdatesHandler(hub *ws.Hub, auth service.AuthService, logger *logger.Logger) *LiveUpdatesHandler {
+	return &LiveUpdatesHandler{
+		hub:    hub,
+		auth:   auth,
+		logger: logger.Named("live-updates-handler"),
+	}
+}
+
+// Connect переводит запрос на WebSocket. Ошибки аутентификации и лимитов
+// отдаются обычным JSON-ответом до апгрейда.
+func (h *LiveUpdatesHandler) Connect(c *gin.Context) {
+	userID, tenant, err := h.resolveUser(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.hub.ServeWS(c.Writer, c.Request, userID, tenant); err != nil {
+		c.Error(err)
+	}
+}
+
+/*
+resolveUser определяет, чьи события получит клиент:
+  - JWT с UUID в subject — это сам пользователь, только свои подписки;
+    user_id, если передан, должен совпадать с subject;
+  - API-ключи и прочие токены — сервисные клиенты, user_id обязателен;
+  - без аутентификации user_id обязателен.
+
+tenant — арендатор для публичных идентификаторов: пользователь из JWT,
+для остальных клиентов пусто (общий кодек), как и в HTTP API.
+*/
+func (h *LiveUpdatesHandler) resolveUser(c *gin.Context) (userID uuid.UUID, tenant string, err error) {
+	requested := c.Query("user_id")
+
+	var requestedID uuid.UUID
+	if requested != "" {
+		id, err := uuid.Parse(requested)
+		if err != nil {
+			return uuid.Nil, "", apperror.InvalidUserID(requested)
+		}
+		requestedID = id
+	}
+
+	if h.auth == nil {
+		if requested == "" {
+			return uuid.Nil, "", apperror.InvalidInput("user_id", "is required")
+		}
+		return requestedID, "", nil
+	}
+
+	apiKey, token := middleware.Credentials(c)
+	if apiKey == "" && token == "" {
+		token = c.Query(accessTokenParam)
+	}
+	principal, err := h.auth.Authenticate(c.Request.Context(), apiKey, token)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if !principal.Can(models.PermissionSubscriptionsRead) {
+		return uuid.Nil, "", apperror.Forbidden(string(principal.Role()), string(models.PermissionSubscriptionsRead))
+	}
+
+	if subject := principal.UserID(); subject != nil {
+		if requested != "" && requestedID != *subject {
+			return uuid.Nil, "", apperror.Forbidden(string(principal.Role()), string(models.PermissionSubscriptionsRead)).
+				WithDetail("reason", "a user token can only watch its own subscriptions")
+		}
+		return *subject, subject.String(), nil
+	}
+
+	if requested == "" {
+		return uuid.Nil, "", apperror.InvalidInput("user_id", "is required for API keys and tokens without a user subject")
+	}
+	if !principal.CanAccessUser(requestedID) {
+		return uuid.Nil, "", apperror.Forbidden(string(principal.Role()), string(models.PermissionSubscriptionsRead)).
+			WithDetail("reason", "a token without a user subject needs the admin role")
+	}
+	return requestedID, "", nil
+}
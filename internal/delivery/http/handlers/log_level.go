@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/loglevel"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+)
+
+// LogLevelPath — маршрут уровня логов; он нужен во время инцидента, и
+// middleware.Maintenance его не блокирует.
+const LogLevelPath = "/admin/log-level"
+
+// LogLevelHandler — уровень логов этой реплики: включить debug на время
+// инцидента, не перезапуская сервис.
+type LogLevelHandler struct {
+	sw     *loglevel.Switch
+	logger *logger.Logger
+}
+
+func NewLogLevelHandler(sw *loglevel.Switch, logger *logger.Logger) *LogLevelHandler {
+	return &LogLevelHandler{
+		sw:     sw,
+		logger: logger.Named("log-level-handler"),
+	}
+}
+
+func (h *LogLevelHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET(LogLevelPath, h.GetLogLevel)
+	router.PUT(LogLevelPath, h.SetLogLevel)
+}
+
+func (h *LogLevelHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        LogLevelPath,
+			ID:          "GetLogLevel",
+			Summary:     "Log level",
+			Description: "Current log level of this replica, the configured level and when a temporary change reverts",
+			Tags:        []string{"admin"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.LogLevelResponse{}},
+			},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        LogLevelPath,
+			ID:          "SetLogLevel",
+			Summary:     "Change the log level",
+			Description: "Set this replica's log level for duration seconds (logger.level_override.default_duration if omitted, at most logger.level_override.max_duration), then revert to logger.level. Setting the configured level reverts at once. The change is lost on restart.",
+			Tags:        []string{"admin"},
+			Body:        request.SetLogLevelRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.LogLevelResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest},
+		},
+	}
+}
+
+func (h *LogLevelHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, logLevelResponse(h.sw.Status()))
+}
+
+func (h *LogLevelHandler) SetLogLevel(c *gin.Context) {
+	var req request.SetLogLevelRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	level, err := zapcore.ParseLevel(req.Level)
+	if err != nil {
+		c.Error(apperror.ValidationFailed("level", err.Error()))
+		return
+	}
+
+	var duration time.Duration
+	if req.Duration != nil {
+		duration = time.Duration(*req.Duration) * time.Second
+		if limit := h.sw.MaxDuration(); duration > limit {
+			c.Error(apperror.ValidationFailed("duration", fmt.Sprintf("must not exceed %d", int(limit.Seconds()))))
+			return
+		}
+	}
+
+	var actor string
+	if principal := middleware.CurrentPrincipal(c); principal != nil {
+		actor = principal.Subject()
+	}
+	h.logger.Info("log level change requested",
+		zap.String("level", req.Level),
+		zap.Duration("duration", duration),
+		zap.String("reason", req.Reason),
+		zap.String("actor", actor))
+
+	c.JSON(http.StatusOK, logLevelResponse(h.sw.Set(level, duration, req.Reason)))
+}
+
+func logLevelResponse(status loglevel.Status) response.LogLevelResponse {
+	resp := response.LogLevelResponse{
+		Level:   status.Level.String(),
+		Default: status.Default.String(),
+		Reason:  status.Reason,
+	}
+	if !status.Until.IsZero() {
+		until := status.Until
+		resp.Until = &until
+	}
+	return resp
+}
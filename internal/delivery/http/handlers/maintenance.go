@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/maintenance"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+)
+
+// MaintenancePath — маршрут переключателя; middleware.Maintenance его не блокирует.
+const MaintenancePath = "/admin/maintenance"
+
+type MaintenanceHandler struct {
+	sw     *maintenance.Switch
+	logger *logger.Logger
+}
+
+func NewMaintenanceHandler(sw *maintenance.Switch, logger *logger.Logger) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		sw:     sw,
+		logger: logger.Named("maintenance-handler"),
+	}
+}
+
+func (h *MaintenanceHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET(MaintenancePath, h.GetMaintenance)
+	router.PUT(MaintenancePath, h.SetMaintenance)
+}
+
+func (h *MaintenanceHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        MaintenancePath,
+			ID:          "GetMaintenance",
+			Summary:     "Maintenance mode status",
+			Description: "Get whether this replica is in maintenance mode and how it treats reads",
+			Tags:        []string{"admin"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MaintenanceResponse{}},
+			},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        MaintenancePath,
+			ID:          "SetMaintenance",
+			Summary:     "Switch maintenance mode",
+			Description: "Enable or disable maintenance mode on this replica. While enabled, /health/ready returns 503, writes fail with MAINTENANCE and reads follow maintenance.reads (allow, cache or deny).",
+			Tags:        []string{"admin"},
+			Body:        request.SetMaintenanceRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MaintenanceResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest},
+		},
+	}
+}
+
+func (h *MaintenanceHandler) GetMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, maintenanceResponse(h.sw.Status()))
+}
+
+func (h *MaintenanceHandler) SetMaintenance(c *gin.Context) {
+	var req request.SetMaintenanceRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	var actor string
+	if principal := middleware.CurrentPrincipal(c); principal != nil {
+		actor = principal.Subject()
+	}
+	h.logger.Info("maintenance mode change requested",
+		zap.Bool("enabled", *req.Enabled),
+		zap.String("reason", req.Reason),
+		zap.String("actor", actor))
+
+	c.JSON(http.StatusOK, maintenanceResponse(h.sw.Set(*req.Enabled, req.Reason)))
+}
+
+func maintenanceResponse(status maintenance.Status) response.MaintenanceResponse {
+	resp := response.MaintenanceResponse{
+		Enabled: status.Enabled,
+		Reason:  status.Reason,
+		Reads:   string(status.Reads),
+	}
+	if !status.Since.IsZero() {
+		since := status.Since
+		resp.Since = &since
+	}
+	return resp
+}
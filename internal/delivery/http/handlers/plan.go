@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+)
+
+type PlanHandler struct {
+	service service.PlanService
+	logger  *logger.Logger
+}
+
+func NewPlanHandler(service service.PlanService, logger *logger.Logger) *PlanHandler {
+	return &PlanHandler{
+		service: service,
+		logger:  logger.Named("plan-handler"),
+	}
+}
+
+func (h *PlanHandler) RegisterRoutes(router *gin.RouterGroup) {
+	plans := router.Group("/plans")
+	{
+		plans.POST("/", h.CreatePlan)
+		plans.GET("/", h.ListPlans)
+		plans.GET("/:id", h.GetPlan)
+		plans.PUT("/:id", h.UpdatePlan)
+		plans.DELETE("/:id", h.DeletePlan)
+		plans.GET("/:id/price-history", h.GetPriceHistory)
+	}
+}
+
+// Routes описывает операции с тарифами так, как их регистрирует RegisterRoutes.
+func (h *PlanHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/plans/",
+			ID:          "CreatePlan",
+			Summary:     "Create a plan",
+			Description: "Add a plan to the catalog. price is per billing_cycle; subscriptions created with plan_id get the monthly equivalent.",
+			Tags:        []string{"plans"},
+			Body:        request.CreatePlanRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.PlanResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/plans/",
+			ID:      "ListPlans",
+			Summary: "List plans",
+			Tags:    []string{"plans"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("limit", "Limit number of results", openapi.Integer().WithDefault(20)),
+				openapi.QueryParam("offset", "Offset for pagination", openapi.Integer().WithDefault(0)),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.PlansListResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/plans/:id",
+			ID:      "GetPlan",
+			Summary: "Get plan by ID",
+			Tags:    []string{"plans"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Plan ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.PlanResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/plans/:id",
+			ID:          "UpdatePlan",
+			Summary:     "Update plan",
+			Description: "Update plan fields. A new price or billing_cycle is recorded in the plan's price history; existing subscriptions keep the price they were created with.",
+			Tags:        []string{"plans"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Plan ID", openapi.UUID()),
+			},
+			Body: request.UpdatePlanRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.PlanResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/plans/:id",
+			ID:          "DeletePlan",
+			Summary:     "Delete plan",
+			Description: "A plan that any subscription was created from cannot be deleted.",
+			Tags:        []string{"plans"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Plan ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/plans/:id/price-history",
+			ID:          "GetPriceHistory",
+			Summary:     "Plan price history",
+			Description: "Every price the plan has had, oldest first. Each entry applies from effective_from until the next one.",
+			Tags:        []string{"plans"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Plan ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.PlanPriceHistoryResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *PlanHandler) CreatePlan(c *gin.Context) {
+	var req request.CreatePlanRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	plan, err := h.service.CreatePlan(
+		c.Request.Context(),
+		req.Name,
+		req.ServiceName,
+		req.Price,
+		models.BillingCycle(req.BillingCycle),
+		req.Features,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.PlanToResponse(plan)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *PlanHandler) ListPlans(c *gin.Context) {
+	limit := parseIntQuery(c, "limit", 20)
+	offset := parseIntQuery(c, "offset", 0)
+
+	plans, err := h.service.ListPlans(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.PlansToListResponse(plans, response.NewPaginationResponse(limit, offset, nil))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *PlanHandler) GetPlan(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	plan, err := h.service.GetPlan(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.PlanToResponse(plan)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *PlanHandler) UpdatePlan(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	var req request.UpdatePlanRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	var billingCycle *models.BillingCycle
+	if req.BillingCycle != nil {
+		cycle := models.BillingCycle(*req.BillingCycle)
+		billingCycle = &cycle
+	}
+
+	plan, err := h.service.UpdatePlan(
+		c.Request.Context(),
+		id,
+		req.Name,
+		req.ServiceName,
+		req.Price,
+		billingCycle,
+		req.Features,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.PlanToResponse(plan)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *PlanHandler) DeletePlan(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	if err := h.service.DeletePlan(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MessageResponse{
+		Message: "Plan deleted successfully",
+	})
+}
+
+func (h *PlanHandler) GetPriceHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	prices, err := h.service.GetPriceHistory(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.PlanPricesToResponse(id, prices)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
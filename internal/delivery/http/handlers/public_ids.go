@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+// publicIDs — кодек публичных идентификаторов для клиента запроса.
+// Арендатор — пользователь из JWT (public_ids.tenants); ключи API, токены
+// без пользователя и запросы без аутентификации получают общий кодек.
+func publicIDs(c *gin.Context, ids *publicid.Registry) publicid.Codec {
+	principal := models.PrincipalFromContext(c.Request.Context())
+	if principal == nil {
+		return ids.Shared()
+	}
+	if userID := principal.UserID(); userID != nil {
+		return ids.For(userID.String())
+	}
+	return ids.Shared()
+}
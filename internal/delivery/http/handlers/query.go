@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+// acceptLocaleHeader — заголовок вместо ?locale= для клиентов, которые
+// не хотят менять URL.
+const acceptLocaleHeader = "Accept-Locale"
+
+func parseStringQuery(c *gin.Context, key string) *string {
+	value := c.Query(key)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func parseIntQuery(c *gin.Context, key string, defaultValue int) int {
+	value := c.Query(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+
+	return intValue
+}
+
+// parseArchivedQuery читает ?archived=true|false; true — список из архива
+// давно закончившихся подписок.
+func parseArchivedQuery(c *gin.Context) (bool, error) {
+	value := c.Query("archived")
+	if value == "" {
+		return false, nil
+	}
+
+	archived, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apperror.InvalidInput("archived", "must be true or false")
+	}
+	return archived, nil
+}
+
+// metadataQueryPrefix — префикс параметров фильтра по метаданным:
+// ?metadata.team=platform.
+const metadataQueryPrefix = "metadata."
+
+// parseMetadataQuery собирает параметры metadata.<key>=<value>; при
+// повторе ключа берётся первое значение.
+func parseMetadataQuery(c *gin.Context) map[string]string {
+	var metadata map[string]string
+	for key, values := range c.Request.URL.Query() {
+		name, ok := strings.CutPrefix(key, metadataQueryPrefix)
+		if !ok || name == "" || len(values) == 0 {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[name] = values[0]
+	}
+	return metadata
+}
+
+// parseBillingQuery читает ?billing=monthly|prorated; без параметра
+// возвращает nil — сервис применит режим из конфигурации.
+func parseBillingQuery(c *gin.Context) (*models.BillingMode, error) {
+	value := c.Query("billing")
+	if value == "" {
+		return nil, nil
+	}
+
+	mode, err := models.ParseBillingMode(value)
+	if err != nil {
+		return nil, apperror.InvalidInput("billing", err.Error())
+	}
+	return &mode, nil
+}
+
+// parsePricingQuery читает ?pricing=current|historical; без параметра —
+// текущие цены.
+func parsePricingQuery(c *gin.Context) (models.PricingMode, error) {
+	value := c.Query("pricing")
+	if value == "" {
+		return models.PricingCurrent, nil
+	}
+
+	mode, err := models.ParsePricingMode(value)
+	if err != nil {
+		return "", apperror.InvalidInput("pricing", err.Error())
+	}
+	return mode, nil
+}
+
+// parseLocaleQuery читает ?locale=ru-RU, иначе заголовок Accept-Locale.
+// Без них возвращает nil — ответ без отформатированных строк display.
+func parseLocaleQuery(c *gin.Context) (*mappers.DisplayLocale, error) {
+	c.Writer.Header().Add("Vary", acceptLocaleHeader)
+
+	value := c.Query("locale")
+	if value == "" {
+		value = c.GetHeader(acceptLocaleHeader)
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	locale, err := mappers.ParseDisplayLocale(value)
+	if err != nil {
+		return nil, apperror.InvalidInput("locale", err.Error())
+	}
+	return locale, nil
+}
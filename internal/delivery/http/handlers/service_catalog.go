@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+)
+
+type ServiceCatalogHandler struct {
+	service service.ServiceCatalogService
+	logger  *logger.Logger
+}
+
+func NewServiceCatalogHandler(service service.ServiceCatalogService, logger *logger.Logger) *ServiceCatalogHandler {
+	return &ServiceCatalogHandler{
+		service: service,
+		logger:  logger.Named("service-catalog-handler"),
+	}
+}
+
+func (h *ServiceCatalogHandler) RegisterRoutes(router *gin.RouterGroup) {
+	services := router.Group("/services")
+	{
+		services.POST("/", h.CreateCatalogService)
+		services.GET("/", h.ListCatalogServices)
+		services.GET("/suggest", h.SuggestCatalogServices)
+		services.GET("/:id", h.GetCatalogService)
+		services.PUT("/:id", h.UpdateCatalogService)
+		services.DELETE("/:id", h.DeleteCatalogService)
+	}
+}
+
+// Routes описывает операции с каталогом сервисов так, как их регистрирует RegisterRoutes.
+func (h *ServiceCatalogHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/services/",
+			ID:          "CreateCatalogService",
+			Summary:     "Add a service to the catalog",
+			Description: "The name is canonicalized and checked against the service name rules like a subscription's. typical_price is a hint for clients and does not set subscription prices.",
+			Tags:        []string{"services"},
+			Body:        request.CreateCatalogServiceRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.CatalogServiceResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/services/",
+			ID:      "ListCatalogServices",
+			Summary: "List catalog services",
+			Tags:    []string{"services"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("limit", "Limit number of results", openapi.Integer().WithDefault(20)),
+				openapi.QueryParam("offset", "Offset for pagination", openapi.Integer().WithDefault(0)),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CatalogServicesListResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/services/suggest",
+			ID:          "SuggestCatalogServices",
+			Summary:     "Autocomplete catalog services",
+			Description: "Case-insensitive substring match on the name. Names starting with q come first, then shorter names.",
+			Tags:        []string{"services"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("q", "Part of the service name", openapi.String()).Require(),
+				openapi.QueryParam("limit", "Maximum number of suggestions, 1-50", openapi.Integer().WithDefault(10)),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CatalogSuggestionsResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/services/:id",
+			ID:      "GetCatalogService",
+			Summary: "Get catalog service by ID",
+			Tags:    []string{"services"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Catalog service ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CatalogServiceResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/services/:id",
+			ID:          "UpdateCatalogService",
+			Summary:     "Update catalog service",
+			Description: "Update the given fields; an empty category, website or icon_url clears it. Linked subscriptions keep their names.",
+			Tags:        []string{"services"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Catalog service ID", openapi.UUID()),
+			},
+			Body: request.UpdateCatalogServiceRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CatalogServiceResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/services/:id",
+			ID:          "DeleteCatalogService",
+			Summary:     "Delete catalog service",
+			Description: "A catalog service that any subscription is linked to cannot be deleted.",
+			Tags:        []string{"services"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Catalog service ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *ServiceCatalogHandler) CreateCatalogService(c *gin.Context) {
+	var req request.CreateCatalogServiceRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	entry, err := h.service.CreateCatalogService(
+		c.Request.Context(),
+		req.Name,
+		req.Category,
+		req.Website,
+		req.IconURL,
+		req.TypicalPrice,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.CatalogServiceToResponse(entry))
+}
+
+func (h *ServiceCatalogHandler) ListCatalogServices(c *gin.Context) {
+	limit := parseIntQuery(c, "limit", 20)
+	offset := parseIntQuery(c, "offset", 0)
+
+	entries, err := h.service.ListCatalogServices(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.CatalogServicesToListResponse(entries, response.NewPaginationResponse(limit, offset, nil)))
+}
+
+func (h *ServiceCatalogHandler) SuggestCatalogServices(c *gin.Context) {
+	query := c.Query("q")
+	limit := parseIntQuery(c, "limit", 0)
+
+	entries, err := h.service.SuggestCatalogServices(c.Request.Context(), query, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.CatalogSuggestionsToResponse(query, entries))
+}
+
+func (h *ServiceCatalogHandler) GetCatalogService(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	entry, err := h.service.GetCatalogService(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.CatalogServiceToResponse(entry))
+}
+
+func (h *ServiceCatalogHandler) UpdateCatalogService(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	var req request.UpdateCatalogServiceRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	entry, err := h.service.UpdateCatalogService(
+		c.Request.Context(),
+		id,
+		req.Name,
+		req.Category,
+		req.Website,
+		req.IconURL,
+		req.TypicalPrice,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.CatalogServiceToResponse(entry))
+}
+
+func (h *ServiceCatalogHandler) DeleteCatalogService(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", "must be a valid UUID"))
+		return
+	}
+
+	if err := h.service.DeleteCatalogService(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MessageResponse{
+		Message: "Catalog service deleted successfully",
+	})
+}
@@ -1,31 +1,49 @@
 package handlers
 
 import (
+	"encoding/csv"
 	"net/http"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
 )
 
+const expandComments = "comments"
+
+// exportFlushRows — через сколько строк выгрузка сбрасывается клиенту.
+const exportFlushRows = 500
+
 type SubscriptionHandler struct {
-	service service.SubscriptionService
-	logger  *logger.Logger
+	service  service.SubscriptionService
+	comments service.SubscriptionCommentService
+	alerts   service.CostAlertService
+	ids      *publicid.Registry
+	logger   *logger.Logger
 }
 
-func NewSubscriptionHandler(service service.SubscriptionService, logger *logger.Logger) *SubscriptionHandler {
+func NewSubscriptionHandler(service service.SubscriptionService, comments service.SubscriptionCommentService, alerts service.CostAlertService, ids *publicid.Registry, logger *logger.Logger) *SubscriptionHandler {
 	return &SubscriptionHandler{
-		service: service,
-		logger:  logger.Named("subscription-handler"),
+		service:  service,
+		comments: comments,
+		alerts:   alerts,
+		ids:      ids,
+		logger:   logger.Named("subscription-handler"),
 	}
 }
 
@@ -37,37 +55,434 @@ func (h *SubscriptionHandler) RegisterRoutes(router *gin.RouterGroup) {
 		subscriptions.PUT("/:id", h.UpdateSubscription)
 		subscriptions.DELETE("/:id", h.DeleteSubscription)
 		subscriptions.GET("/", h.GetSubscriptions)
+		subscriptions.GET("/search", h.SearchSubscriptions)
+		subscriptions.GET("/export", h.ExportSubscriptions)
+		subscriptions.POST("/:id/comments", h.CreateComment)
+		subscriptions.GET("/:id/comments", h.GetComments)
+		subscriptions.GET("/:id/price-history", h.GetPriceHistory)
+		subscriptions.PUT("/:id/catalog", h.LinkCatalogService)
 	}
 
 	users := router.Group("/users")
 	{
 		users.GET("/:user_id/subscriptions", h.GetUserSubscriptions)
+		users.DELETE("/:user_id/subscriptions", h.DeleteUserSubscriptions)
 		users.GET("/:user_id/subscriptions/stats", h.GetUserStats)
+		users.GET("/:user_id/subscriptions/calendar", h.GetUserCalendar)
+		users.GET("/:user_id/subscriptions/expiring", h.GetExpiringSubscriptions)
+		users.GET("/:user_id/subscriptions/duplicates", h.GetDuplicateSubscriptions)
+		users.GET("/:user_id/costs/forecast", h.GetCostForecast)
+		users.GET("/:user_id/billing-calendar", h.GetBillingCalendar)
 	}
 
 	costs := router.Group("/costs")
 	{
 		costs.GET("/calculate", h.CalculateTotalCost)
+		costs.GET("/by-category", h.CalculateCostByCategory)
+		costs.GET("/by-payment-method", h.CalculateCostByPaymentMethod)
+	}
+}
+
+// Routes — описание операций v1 для документа OpenAPI; даты в формате MM-YYYY.
+func (h *SubscriptionHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/subscriptions/",
+			ID:          "CreateSubscription",
+			Summary:     "Create a new subscription",
+			Description: "Create a new subscription for a user",
+			Tags:        []string{"subscriptions"},
+			Body:        request.CreateSubscriptionRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.SubscriptionResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/subscriptions/:id",
+			ID:          "GetSubscription",
+			Summary:     "Get subscription by ID",
+			Description: "Get a single subscription by its ID. Pass expand=comments to embed the note history.",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+				openapi.QueryParam("expand", "Comma-separated related resources to embed", openapi.Enum("comments")),
+				openapi.QueryParam("fields", "Comma-separated fields to return, e.g. id,price,service_name", openapi.String()),
+				openapi.HeaderParam("If-None-Match", "ETag from a previous response"),
+				openapi.HeaderParam("If-Modified-Since", "Last-Modified from a previous response"),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.SubscriptionResponse{}},
+				{Status: http.StatusNotModified, Description: "Not modified"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/subscriptions/:id",
+			ID:          "UpdateSubscription",
+			Summary:     "Update subscription",
+			Description: "Update an existing subscription",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+			},
+			Body: request.UpdateSubscriptionRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.SubscriptionResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/subscriptions/:id",
+			ID:          "DeleteSubscription",
+			Summary:     "Delete subscription",
+			Description: "Delete a subscription by ID",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/subscriptions/",
+			ID:          "GetSubscriptions",
+			Summary:     "List subscriptions",
+			Description: "Get list of subscriptions with optional filtering",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("user_id", "User ID filter", openapi.UUID()),
+				openapi.QueryParam("service_name", "Service name filter", openapi.String()),
+				openapi.QueryParam("start_date", "Start date filter (MM-YYYY format)", openapi.String()),
+				openapi.QueryParam("end_date", "End date filter (MM-YYYY format)", openapi.String()),
+				openapi.QueryParam("tags", "Comma-separated tags; subscriptions must have all of them", openapi.String()),
+				openapi.QueryParam("category", "Category filter", openapi.Enum("streaming", "music", "cloud", "software", "gaming", "fitness", "education", "news", "shopping", "other")),
+				openapi.QueryParam("metadata.key", "Metadata filter, e.g. metadata.team=platform; repeat for several keys", openapi.String()),
+				openapi.QueryParam("archived", "List archived subscriptions (ended more than archive.after_months ago) instead of current ones", openapi.Boolean().WithDefault(false)),
+				openapi.QueryParam("limit", "Limit number of results", openapi.Integer().WithDefault(20)),
+				openapi.QueryParam("offset", "Offset for pagination", openapi.Integer().WithDefault(0)),
+				openapi.QueryParam("fields", "Comma-separated fields to return, e.g. id,price,service_name", openapi.String()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.SubscriptionsListResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/subscriptions/search",
+			ID:          "SearchSubscriptions",
+			Summary:     "Search subscriptions",
+			Description: "Full-text and fuzzy search over service names, tags and notes. Tolerates typos (\"netflx\" finds Netflix). Results are ordered by relevance; matches are wrapped in <mark></mark>.",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("q", "Search text, 2-100 characters", openapi.String()).Require(),
+				openapi.QueryParam("user_id", "User ID filter", openapi.UUID()),
+				openapi.QueryParam("limit", "Limit number of results", openapi.Integer().WithDefault(20)),
+				openapi.QueryParam("offset", "Offset for pagination", openapi.Integer().WithDefault(0)),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.SubscriptionSearchResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/subscriptions/export",
+			ID:          "ExportSubscriptions",
+			Summary:     "Export subscriptions as CSV",
+			Description: "Stream every subscription matching the filters as CSV, newest first, without pagination. Columns: id, service_name, price, user_id, start_date, end_date, category, tags (separated by ;), plan_id, discount_id, created_at, updated_at.",
+			Tags:        []string{"subscriptions"},
+			ContentType: "text/csv",
+			Params: []openapi.Parameter{
+				openapi.QueryParam("user_id", "User ID filter", openapi.UUID()),
+				openapi.QueryParam("service_name", "Service name filter", openapi.String()),
+				openapi.QueryParam("start_date", "Start date filter (MM-YYYY format)", openapi.String()),
+				openapi.QueryParam("end_date", "End date filter (MM-YYYY format)", openapi.String()),
+				openapi.QueryParam("tags", "Comma-separated tags; subscriptions must have all of them", openapi.String()),
+				openapi.QueryParam("category", "Category filter", openapi.Enum("streaming", "music", "cloud", "software", "gaming", "fitness", "education", "news", "shopping", "other")),
+				openapi.QueryParam("metadata.key", "Metadata filter, e.g. metadata.team=platform; repeat for several keys", openapi.String()),
+				openapi.QueryParam("archived", "List archived subscriptions (ended more than archive.after_months ago) instead of current ones", openapi.Boolean().WithDefault(false)),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Description: "CSV file with a header row", Body: ""},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/subscriptions/:id/comments",
+			ID:          "CreateComment",
+			Summary:     "Add a comment to a subscription",
+			Description: "Append a note with author attribution to the subscription's comment thread",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+			},
+			Body: request.CreateCommentRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.CommentResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/subscriptions/:id/comments",
+			ID:          "GetComments",
+			Summary:     "List subscription comments",
+			Description: "Get the comment thread of a subscription in chronological order",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+				openapi.QueryParam("limit", "Limit", openapi.Integer().WithDefault(20).WithMaximum(100)),
+				openapi.QueryParam("offset", "Offset", openapi.Integer().WithDefault(0)),
+				openapi.QueryParam("fields", "Comma-separated fields to return, e.g. id,price,service_name", openapi.String()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CommentsListResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/subscriptions/:id/price-history",
+			ID:          "GetPriceHistory",
+			Summary:     "Subscription price history",
+			Description: "Every price change of a subscription, oldest first. A new price applies from the start (UTC) of the day it was changed.",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.PriceHistoryResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/subscriptions/:id/catalog",
+			ID:          "LinkCatalogService",
+			Summary:     "Link subscription to a catalog service",
+			Description: "Set the catalog service the subscription belongs to; catalog_id null removes the link. Name and price are not changed.",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+			},
+			Body: request.LinkCatalogServiceRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.SubscriptionResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:user_id/subscriptions",
+			ID:          "GetUserSubscriptions",
+			Summary:     "Get user subscriptions",
+			Description: "Get all subscriptions for a specific user",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+				openapi.QueryParam("limit", "Limit number of results", openapi.Integer().WithDefault(20)),
+				openapi.QueryParam("offset", "Offset for pagination", openapi.Integer().WithDefault(0)),
+				openapi.QueryParam("fields", "Comma-separated fields to return, e.g. id,price,service_name", openapi.String()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.SubscriptionsListResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/users/:user_id/subscriptions",
+			ID:          "DeleteUserSubscriptions",
+			Summary:     "Delete all subscriptions of a user",
+			Description: "Delete every subscription of a user in one transaction, e.g. for account deletion. Comments, price history and reminders are removed with them. One subscription.bulk_deleted event lists the deleted IDs.",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.DeleteUserSubscriptionsResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:user_id/subscriptions/stats",
+			ID:          "GetUserStats",
+			Summary:     "Get user subscription statistics",
+			Description: "Summary of a user's subscriptions: active, expired and upcoming counts, monthly spend of active subscriptions, average price, the most expensive active subscription and the next one to end. alerts shows each cost alert against the current month's spend after discounts",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.StatsResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:user_id/subscriptions/calendar",
+			ID:          "GetUserCalendar",
+			Summary:     "Get user subscription calendar",
+			Description: "Get active subscriptions and total cost for every month of a year",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+				openapi.QueryParam("year", "Calendar year (defaults to the current year)", openapi.Integer()),
+				openapi.QueryParam("billing", "Billing math: monthly or prorated (defaults to billing.mode)", openapi.Enum("monthly", "prorated")),
+				openapi.QueryParam("pricing", "Prices: current, or historical from the price history", openapi.Enum("current", "historical")),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CalendarResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:user_id/subscriptions/expiring",
+			ID:          "GetExpiringSubscriptions",
+			Summary:     "Get subscriptions ending soon",
+			Description: "Subscriptions of a user that end today or within the next within_days days, ordered by end date",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+				openapi.QueryParam("within_days", "Look-ahead window in days", openapi.Integer().WithDefault(30).WithMaximum(365)),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.ExpiringSubscriptionsResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:user_id/subscriptions/duplicates",
+			ID:          "GetDuplicateSubscriptions",
+			Summary:     "Find likely duplicate subscriptions",
+			Description: "Pairs of a user's subscriptions whose periods overlap and whose service names are equal (ignoring case) or similar by trigram similarity (at least 0.5). Equal names come first, then by descending similarity. monthly_savings is the price of the cheaper subscription of the pair.",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.DuplicateSubscriptionsResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:user_id/costs/forecast",
+			ID:          "GetCostForecast",
+			Summary:     "Forecast a user's spend",
+			Description: "Projected spend for months months starting with the current one, at current prices. Open-ended subscriptions renew every month, subscriptions drop out after their end date, and subscriptions starting later (e.g. after a trial) are charged from their start month. Discounts apply while they are valid.",
+			Tags:        []string{"costs"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+				openapi.QueryParam("months", "Forecast horizon in months, current month included", openapi.Integer().WithDefault(6).WithMaximum(24)),
+				openapi.QueryParam("billing", "Billing math: monthly or prorated (defaults to billing.mode)", openapi.Enum("monthly", "prorated")),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CostForecastResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/users/:user_id/billing-calendar",
+			ID:          "GetBillingCalendar",
+			Summary:     "List a user's charge days for a month",
+			Description: "Days of the month on which subscriptions are charged, at current prices before discounts. A subscription is charged on its billing_day, or on the day of its start date if billing_day is not set. Days past the end of a short month move to its last day.",
+			Tags:        []string{"costs"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+				openapi.QueryParam("month", "Month (MM-YYYY)", openapi.String()).Require(),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.BillingCalendarResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/costs/calculate",
+			ID:          "CalculateTotalCost",
+			Summary:     "Calculate total subscription cost",
+			Description: "Calculate total cost of subscriptions for a given period with optional filtering",
+			Tags:        []string{"costs"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("user_id", "User ID filter", openapi.UUID()),
+				openapi.QueryParam("service_name", "Service name filter", openapi.String()),
+				openapi.QueryParam("start_date", "Start date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("end_date", "End date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("billing", "Billing math: monthly or prorated (defaults to billing.mode)", openapi.Enum("monthly", "prorated")),
+				openapi.QueryParam("pricing", "Prices: current, or historical from the price history", openapi.Enum("current", "historical")),
+				openapi.QueryParam("locale", "Adds display strings formatted for this locale (en, ru, ru-RU...); other languages get en", openapi.String()),
+				openapi.HeaderParam("Accept-Locale", "Same as locale, when the query parameter is absent"),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CostSummaryResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/costs/by-category",
+			ID:          "CalculateCostByCategory",
+			Summary:     "Calculate cost by category",
+			Description: "Calculate spending for a period grouped by subscription category. Subscriptions without a category are reported as uncategorized.",
+			Tags:        []string{"costs"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("user_id", "User ID filter", openapi.UUID()),
+				openapi.QueryParam("start_date", "Start date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("end_date", "End date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("billing", "Billing math: monthly or prorated (defaults to billing.mode)", openapi.Enum("monthly", "prorated")),
+				openapi.QueryParam("pricing", "Prices: current, or historical from the price history", openapi.Enum("current", "historical")),
+				openapi.QueryParam("locale", "Adds display strings formatted for this locale (en, ru, ru-RU...); other languages get en", openapi.String()),
+				openapi.HeaderParam("Accept-Locale", "Same as locale, when the query parameter is absent"),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.CategoryCostReportResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/costs/by-payment-method",
+			ID:          "CalculateCostByPaymentMethod",
+			Summary:     "Calculate cost by payment method",
+			Description: "Calculate spending for a period grouped by payment_method, so it is clear which card pays for what. Subscriptions without a payment method are reported last with payment_method null.",
+			Tags:        []string{"costs"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("user_id", "User ID filter", openapi.UUID()),
+				openapi.QueryParam("start_date", "Start date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("end_date", "End date (MM-YYYY, YYYY-MM or YYYY-MM-DD)", openapi.String()).Require(),
+				openapi.QueryParam("billing", "Billing math: monthly or prorated (defaults to billing.mode)", openapi.Enum("monthly", "prorated")),
+				openapi.QueryParam("pricing", "Prices: current, or historical from the price history", openapi.Enum("current", "historical")),
+				openapi.QueryParam("locale", "Adds display strings formatted for this locale (en, ru, ru-RU...); other languages get en", openapi.String()),
+				openapi.HeaderParam("Accept-Locale", "Same as locale, when the query parameter is absent"),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.PaymentMethodCostReportResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
 	}
 }
 
-// CreateSubscription godoc
-// @Summary Create a new subscription
-// @Description Create a new subscription for a user
-// @Tags subscriptions
-// @Accept json
-// @Produce json
-// @Param subscription body request.CreateSubscriptionRequest true "Subscription data"
-// @Success 201 {object} response.SubscriptionResponse
-// @Failure 400 {object} response.ErrorResponse
-// @Failure 422 {object} response.ValidationErrorResponse
-// @Failure 500 {object} response.ErrorResponse
-// @Router /subscriptions [post]
 func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 	var req request.CreateSubscriptionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := validation.BindJSON(c, &req); err != nil {
 		h.logger.Warn("invalid request body", zap.Error(err))
-		c.Error(apperror.InvalidInput("request_body", err.Error()))
+		c.Error(err)
 		return
 	}
 
@@ -77,6 +492,18 @@ func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 		return
 	}
 
+	planID, err := req.GetPlanID()
+	if err != nil {
+		c.Error(apperror.InvalidInput("plan_id", "must be a valid UUID"))
+		return
+	}
+
+	catalogID, err := req.GetCatalogID()
+	if err != nil {
+		c.Error(apperror.InvalidInput("catalog_id", "must be a valid UUID"))
+		return
+	}
+
 	subscription, err := h.service.CreateSubscription(
 		c.Request.Context(),
 		req.ServiceName,
@@ -84,13 +511,27 @@ func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 		userID,
 		req.StartDate,
 		utils.StringPtr(req.EndDate),
+		utils.StringPtr(req.PromoCode),
+		planID,
+		catalogID,
+		req.Tags,
+		utils.StringPtr(req.Category),
+		utils.StringPtr(req.PaymentMethod),
+		req.BillingDay,
+		req.Notes,
+		req.Metadata,
 	)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	resp := mappers.SubscriptionToResponse(subscription)
+	format := middleware.ResponseDateFormat(c)
+	resp, err := mappers.SubscriptionToResponse(subscription, format, publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	h.logger.Info("subscription created successfully",
 		zap.String("subscription_id", resp.ID),
 		zap.String("service_name", resp.ServiceName))
@@ -98,64 +539,80 @@ func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 	c.JSON(http.StatusCreated, resp)
 }
 
-// GetSubscription godoc
-// @Summary Get subscription by ID
-// @Description Get a single subscription by its ID
-// @Tags subscriptions
-// @Produce json
-// @Param id path string true "Subscription ID" format(uuid)
-// @Success 200 {object} response.SubscriptionResponse
-// @Failure 400 {object} response.ErrorResponse
-// @Failure 404 {object} response.ErrorResponse
-// @Failure 500 {object} response.ErrorResponse
-// @Router /subscriptions/{id} [get]
 func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
 	req := request.GetSubscriptionRequest{
 		ID: c.Param("id"),
 	}
 
-	id, err := req.GetID()
+	id, err := req.GetID(publicIDs(c, h.ids))
 	if err != nil {
 		c.Error(apperror.InvalidInput("id", err.Error()))
 		return
 	}
 
+	fields, err := mappers.ParseFieldSet(c.Query("fields"), response.SubscriptionResponse{})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	subscription, err := h.service.GetSubscriptionByID(c.Request.Context(), id)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	resp := mappers.SubscriptionToResponse(subscription)
-	c.JSON(http.StatusOK, resp)
+	format := middleware.ResponseDateFormat(c)
+	resp, err := mappers.SubscriptionToResponse(subscription, format, publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version := newResourceVersion(subscription.UpdatedAt(), resp.ID, subscription.UpdatedAt().UnixNano(), fields, format)
+
+	if h.isExpanded(c, expandComments) {
+		// Развёрнутые связи возвращаются всегда, даже если не перечислены в fields.
+		if len(fields) > 0 {
+			fields.Add(expandComments)
+		}
+
+		comments, total, err := h.comments.ListComments(c.Request.Context(), id, 100, 0)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		resp.Comments = mappers.CommentsToResponse(comments, publicIDs(c, h.ids))
+
+		// Комментарии только добавляются, поэтому их числа достаточно для версии.
+		lastModified := subscription.UpdatedAt()
+		if len(comments) > 0 && comments[len(comments)-1].CreatedAt().After(lastModified) {
+			lastModified = comments[len(comments)-1].CreatedAt()
+		}
+		version = newResourceVersion(lastModified, resp.ID, subscription.UpdatedAt().UnixNano(), fields, format, expandComments, total)
+	}
+
+	if setValidators(c, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.WithFields(resp, fields))
 }
 
-// UpdateSubscription godoc
-// @Summary Update subscription
-// @Description Update an existing subscription
-// @Tags subscriptions
-// @Accept json
-// @Produce json
-// @Param id path string true "Subscription ID" format(uuid)
-// @Param subscription body request.UpdateSubscriptionRequest true "Updated subscription data"
-// @Success 200 {object} response.SubscriptionResponse
-// @Failure 400 {object} response.ErrorResponse
-// @Failure 404 {object} response.ErrorResponse
-// @Failure 422 {object} response.ValidationErrorResponse
-// @Failure 500 {object} response.ErrorResponse
-// @Router /subscriptions/{id} [put]
 func (h *SubscriptionHandler) UpdateSubscription(c *gin.Context) {
-	id := c.Param("id")
-	parsedID, err := utils.ValidateUUID(id, "id")
+	pathReq := request.UpdateSubscriptionPathRequest{
+		ID: c.Param("id"),
+	}
+
+	parsedID, err := pathReq.GetID(publicIDs(c, h.ids))
 	if err != nil {
-		c.Error(err)
+		c.Error(apperror.InvalidInput("id", err.Error()))
 		return
 	}
 
 	var req request.UpdateSubscriptionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := validation.BindJSON(c, &req); err != nil {
 		h.logger.Warn("invalid request body", zap.Error(err))
-		c.Error(apperror.InvalidInput("request_body", err.Error()))
+		c.Error(err)
 		return
 	}
 
@@ -166,35 +623,36 @@ func (h *SubscriptionHandler) UpdateSubscription(c *gin.Context) {
 		req.Price,
 		req.StartDate,
 		req.EndDate,
+		req.Tags,
+		req.Category,
+		req.PaymentMethod,
+		req.BillingDay,
+		req.Notes,
+		req.Metadata,
 	)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	resp := mappers.SubscriptionToResponse(subscription)
+	format := middleware.ResponseDateFormat(c)
+	resp, err := mappers.SubscriptionToResponse(subscription, format, publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	h.logger.Info("subscription updated successfully",
 		zap.String("subscription_id", resp.ID))
 
 	c.JSON(http.StatusOK, resp)
 }
 
-// DeleteSubscription godoc
-// @Summary Delete subscription
-// @Description Delete a subscription by ID
-// @Tags subscriptions
-// @Param id path string true "Subscription ID" format(uuid)
-// @Success 200 {object} response.MessageResponse
-// @Failure 400 {object} response.ErrorResponse
-// @Failure 404 {object} response.ErrorResponse
-// @Failure 500 {object} response.ErrorResponse
-// @Router /subscriptions/{id} [delete]
 func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
 	req := request.DeleteSubscriptionRequest{
 		ID: c.Param("id"),
 	}
 
-	id, err := req.GetID()
+	id, err := req.GetID(publicIDs(c, h.ids))
 	if err != nil {
 		c.Error(apperror.InvalidInput("id", err.Error()))
 		return
@@ -213,36 +671,37 @@ func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
 	})
 }
 
-// GetSubscriptions godoc
-// @Summary List subscriptions
-// @Description Get list of subscriptions with optional filtering
-// @Tags subscriptions
-// @Produce json
-// @Param user_id query string false "User ID filter" format(uuid)
-// @Param service_name query string false "Service name filter"
-// @Param start_date query string false "Start date filter (MM-YYYY format)"
-// @Param end_date query string false "End date filter (MM-YYYY format)"
-// @Param limit query int false "Limit number of results" default(20)
-// @Param offset query int false "Offset for pagination" default(0)
-// @Success 200 {object} response.SubscriptionsListResponse
-// @Failure 400 {object} response.ErrorResponse
-// @Failure 500 {object} response.ErrorResponse
-// @Router /subscriptions [get]
 func (h *SubscriptionHandler) GetSubscriptions(c *gin.Context) {
 	req := h.parseGetSubscriptionsRequest(c)
 
+	fields, err := mappers.ParseFieldSet(c.Query("fields"), response.SubscriptionResponse{})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	filter, err := mappers.SubscriptionFilterFromRequest(
 		req.UserID,
 		req.ServiceName,
 		req.StartDate,
 		req.EndDate,
+		req.Tags,
+		req.Category,
+		parseMetadataQuery(c),
 	)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	subscriptions, err := h.service.GetAllSubscriptions(
+	archived, err := parseArchivedQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	filter.SetArchived(archived)
+
+	subscriptions, total, err := h.service.GetAllSubscriptions(
 		c.Request.Context(),
 		filter,
 		req.Limit,
@@ -253,35 +712,125 @@ func (h *SubscriptionHandler) GetSubscriptions(c *gin.Context) {
 		return
 	}
 
-	pagination := response.NewPaginationResponse(req.Limit, req.Offset, nil)
-	resp := mappers.SubscriptionsToListResponse(subscriptions, pagination)
+	pagination := response.NewPaginationResponse(req.Limit, req.Offset, &total)
+	format := middleware.ResponseDateFormat(c)
+	resp, err := mappers.SubscriptionsToListResponse(subscriptions, pagination, format, publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	h.logger.Debug("subscriptions retrieved",
 		zap.Int("count", len(subscriptions)),
 		zap.Int("limit", req.Limit),
 		zap.Int("offset", req.Offset))
 
+	c.JSON(http.StatusOK, mappers.WithFields(resp, fields))
+}
+
+/*
+ExportSubscriptions пишет CSV по мере чтения подписок из БД. Пока не
+отправлена первая строка, ошибка уходит обычным JSON-ответом; после этого
+статус уже отправлен, и выгрузка просто обрывается с записью в лог.
+*/
+func (h *SubscriptionHandler) ExportSubscriptions(c *gin.Context) {
+	filter, err := exportFilterFromQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	format := middleware.ResponseDateFormat(c)
+
+	var (
+		writer   *csv.Writer
+		exported int
+	)
+	begin := func() error {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="subscriptions.csv"`)
+		c.Status(http.StatusOK)
+		writer = csv.NewWriter(c.Writer)
+		return writer.Write(mappers.SubscriptionCSVHeader)
+	}
+
+	err = h.service.ExportSubscriptions(c.Request.Context(), filter, func(subscription *models.Subscription) error {
+		if writer == nil {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		record, err := mappers.SubscriptionToCSVRecord(subscription, format, publicIDs(c, h.ids))
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		exported++
+		if exported%exportFlushRows == 0 {
+			writer.Flush()
+			c.Writer.Flush()
+			return writer.Error()
+		}
+		return nil
+	})
+	if err != nil && writer == nil {
+		c.Error(err)
+		return
+	}
+	if err != nil {
+		h.logger.Warn("subscription export interrupted",
+			zap.Int("exported", exported),
+			zap.Error(err))
+		return
+	}
+
+	if writer == nil {
+		if err := begin(); err != nil {
+			return
+		}
+	}
+	writer.Flush()
+
+	h.logger.Debug("subscriptions exported", zap.Int("count", exported))
+}
+
+func (h *SubscriptionHandler) SearchSubscriptions(c *gin.Context) {
+	var userID *uuid.UUID
+	if value := c.Query("user_id"); value != "" {
+		parsedUserID, err := utils.ValidateUUID(value, "user_id")
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		userID = &parsedUserID
+	}
+
+	query := c.Query("q")
+	limit := parseIntQuery(c, "limit", 20)
+	offset := parseIntQuery(c, "offset", 0)
+
+	hits, err := h.service.SearchSubscriptions(c.Request.Context(), query, userID, limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	pagination := response.NewPaginationResponse(limit, offset, nil)
+	resp, err := mappers.SearchHitsToResponse(query, hits, pagination, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	c.JSON(http.StatusOK, resp)
 }
 
-// GetUserSubscriptions godoc
-// @Summary Get user subscriptions
-// @Description Get all subscriptions for a specific user
-// @Tags subscriptions
-// @Produce json
-// @Param user_id path string true "User ID" format(uuid)
-// @Param limit query int false "Limit number of results" default(20)
-// @Param offset query int false "Offset for pagination" default(0)
-// @Success 200 {object} response.SubscriptionsListResponse
-// @Failure 400 {object} response.ErrorResponse
-// @Failure 404 {object} response.ErrorResponse
-// @Failure 500 {object} response.ErrorResponse
-// @Router /users/{user_id}/subscriptions [get]
 func (h *SubscriptionHandler) GetUserSubscriptions(c *gin.Context) {
 	req := request.GetUserSubscriptionsRequest{
 		UserID: c.Param("user_id"),
-		Limit:  h.parseIntQuery(c, "limit", 20),
-		Offset: h.parseIntQuery(c, "offset", 0),
+		Limit:  parseIntQuery(c, "limit", 20),
+		Offset: parseIntQuery(c, "offset", 0),
 	}
 
 	userID, err := req.GetUserID()
@@ -290,6 +839,12 @@ func (h *SubscriptionHandler) GetUserSubscriptions(c *gin.Context) {
 		return
 	}
 
+	fields, err := mappers.ParseFieldSet(c.Query("fields"), response.SubscriptionResponse{})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	subscriptions, err := h.service.GetSubscriptionsByUser(
 		c.Request.Context(),
 		userID,
@@ -302,26 +857,43 @@ func (h *SubscriptionHandler) GetUserSubscriptions(c *gin.Context) {
 	}
 
 	pagination := response.NewPaginationResponse(req.Limit, req.Offset, nil)
-	resp := mappers.SubscriptionsToListResponse(subscriptions, pagination)
+	format := middleware.ResponseDateFormat(c)
+	resp, err := mappers.SubscriptionsToListResponse(subscriptions, pagination, format, publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
 	h.logger.Debug("user subscriptions retrieved",
 		zap.String("user_id", userID.String()),
 		zap.Int("count", len(subscriptions)))
 
-	c.JSON(http.StatusOK, resp)
+	c.JSON(http.StatusOK, mappers.WithFields(resp, fields))
+}
+
+func (h *SubscriptionHandler) DeleteUserSubscriptions(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	deleted, err := h.service.DeleteUserSubscriptions(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.logger.Info("user subscriptions deleted",
+		zap.String("user_id", userID.String()),
+		zap.Int("deleted", deleted))
+
+	c.JSON(http.StatusOK, response.DeleteUserSubscriptionsResponse{
+		Message: "User subscriptions deleted successfully",
+		Deleted: deleted,
+	})
 }
 
-// GetUserStats godoc
-// @Summary Get user subscription statistics
-// @Description Get total number of subscriptions for a user
-// @Tags subscriptions
-// @Produce json
-// @Param user_id path string true "User ID" format(uuid)
-// @Success 200 {object} response.StatsResponse
-// @Failure 400 {object} response.ErrorResponse
-// @Failure 404 {object} response.ErrorResponse
-// @Failure 500 {object} response.ErrorResponse
-// @Router /users/{user_id}/subscriptions/stats [get]
 func (h *SubscriptionHandler) GetUserStats(c *gin.Context) {
 	userID := c.Param("user_id")
 	parsedUserID, err := utils.ValidateUUID(userID, "user_id")
@@ -330,33 +902,172 @@ func (h *SubscriptionHandler) GetUserStats(c *gin.Context) {
 		return
 	}
 
-	count, err := h.service.GetSubscriptionStats(c.Request.Context(), &parsedUserID)
+	stats, err := h.service.GetUserSubscriptionStats(c.Request.Context(), parsedUserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	alerts, err := h.alerts.GetAlertStatuses(c.Request.Context(), parsedUserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.UserStatsToResponse(stats, alerts, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SubscriptionHandler) GetDuplicateSubscriptions(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	duplicates, err := h.service.FindDuplicateSubscriptions(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.DuplicateSubscriptionsToResponse(userID, duplicates, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SubscriptionHandler) GetExpiringSubscriptions(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	resp := response.StatsResponse{
-		TotalSubscriptions: count,
+	withinDays := parseIntQuery(c, "within_days", 30)
+
+	subscriptions, err := h.service.GetExpiringSubscriptions(c.Request.Context(), userID, withinDays)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
+	resp, err := mappers.ExpiringSubscriptionsToResponse(subscriptions, withinDays, time.Now(), middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SubscriptionHandler) GetUserCalendar(c *gin.Context) {
+	req := request.GetUserCalendarRequest{
+		UserID: c.Param("user_id"),
+		Year:   parseIntQuery(c, "year", time.Now().Year()),
+	}
+
+	userID, err := req.GetUserID()
+	if err != nil {
+		c.Error(apperror.InvalidUserID(req.UserID))
+		return
+	}
+
+	billing, err := parseBillingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	pricing, err := parsePricingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	calendar, err := h.service.GetSubscriptionCalendar(c.Request.Context(), userID, req.Year, billing, pricing)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	format := middleware.ResponseDateFormat(c)
+	resp, err := mappers.CalendarToResponse(calendar, format, publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.logger.Debug("user calendar retrieved",
+		zap.String("user_id", userID.String()),
+		zap.Int("year", req.Year))
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SubscriptionHandler) GetCostForecast(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	billing, err := parseBillingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	months := parseIntQuery(c, "months", 6)
+	forecast, err := h.service.GetCostForecast(c.Request.Context(), userID, months, billing)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.logger.Debug("cost forecast retrieved",
+		zap.String("user_id", userID.String()),
+		zap.Int("months", months))
+
+	resp, err := mappers.CostForecastToResponse(userID, forecast, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SubscriptionHandler) GetBillingCalendar(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	month := c.Query("month")
+	calendar, err := h.service.GetBillingCalendar(c.Request.Context(), userID, month)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.logger.Debug("billing calendar retrieved",
+		zap.String("user_id", userID.String()),
+		zap.String("month", month))
+
+	resp, err := mappers.BillingCalendarToResponse(userID, calendar, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	c.JSON(http.StatusOK, resp)
 }
 
-// CalculateTotalCost godoc
-// @Summary Calculate total subscription cost
-// @Description Calculate total cost of subscriptions for a given period with optional filtering
-// @Tags costs
-// @Produce json
-// @Param user_id query string false "User ID filter" format(uuid)
-// @Param service_name query string false "Service name filter"
-// @Param start_date query string true "Start date (MM-YYYY format)"
-// @Param end_date query string true "End date (MM-YYYY format)"
-// @Success 200 {object} response.CostSummaryResponse
-// @Failure 400 {object} response.ErrorResponse
-// @Failure 422 {object} response.ValidationErrorResponse
-// @Failure 500 {object} response.ErrorResponse
-// @Router /costs/calculate [get]
 func (h *SubscriptionHandler) CalculateTotalCost(c *gin.Context) {
 	req := h.parseCalculateCostRequest(c)
 
@@ -370,19 +1081,40 @@ func (h *SubscriptionHandler) CalculateTotalCost(c *gin.Context) {
 		userID = &parsedUserID
 	}
 
+	billing, err := parseBillingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	pricing, err := parsePricingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	locale, err := parseLocaleQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	summary, err := h.service.CalculateTotalCost(
 		c.Request.Context(),
 		userID,
 		req.ServiceName,
 		req.StartDate,
 		req.EndDate,
+		billing,
+		pricing,
 	)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	resp := mappers.CostSummaryToResponse(summary)
+	format := middleware.ResponseDateFormat(c)
+	resp := mappers.CostSummaryToResponse(summary, format, locale)
 
 	h.logger.Info("cost calculated successfully",
 		zap.Int("total_cost", resp.TotalCost),
@@ -391,44 +1123,270 @@ func (h *SubscriptionHandler) CalculateTotalCost(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
-func (h *SubscriptionHandler) parseGetSubscriptionsRequest(c *gin.Context) request.GetSubscriptionsRequest {
-	return request.GetSubscriptionsRequest{
-		UserID:      h.parseStringQuery(c, "user_id"),
-		ServiceName: h.parseStringQuery(c, "service_name"),
-		StartDate:   h.parseStringQuery(c, "start_date"),
-		EndDate:     h.parseStringQuery(c, "end_date"),
-		Limit:       h.parseIntQuery(c, "limit", 20),
-		Offset:      h.parseIntQuery(c, "offset", 0),
+func (h *SubscriptionHandler) CalculateCostByCategory(c *gin.Context) {
+	req := h.parseCalculateCostRequest(c)
+
+	var userID *uuid.UUID
+	if req.UserID != nil && *req.UserID != "" {
+		parsedUserID, err := utils.ValidateUUID(*req.UserID, "user_id")
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		userID = &parsedUserID
+	}
+
+	billing, err := parseBillingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
 	}
+
+	pricing, err := parsePricingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	locale, err := parseLocaleQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	report, err := h.service.CalculateCostByCategory(
+		c.Request.Context(),
+		userID,
+		req.StartDate,
+		req.EndDate,
+		billing,
+		pricing,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.CategoryCostReportToResponse(report, middleware.ResponseDateFormat(c), locale))
 }
 
-func (h *SubscriptionHandler) parseCalculateCostRequest(c *gin.Context) request.CalculateCostRequest {
-	return request.CalculateCostRequest{
-		UserID:      h.parseStringQuery(c, "user_id"),
-		ServiceName: h.parseStringQuery(c, "service_name"),
-		StartDate:   c.Query("start_date"),
-		EndDate:     c.Query("end_date"),
+func (h *SubscriptionHandler) CalculateCostByPaymentMethod(c *gin.Context) {
+	req := h.parseCalculateCostRequest(c)
+
+	var userID *uuid.UUID
+	if req.UserID != nil && *req.UserID != "" {
+		parsedUserID, err := utils.ValidateUUID(*req.UserID, "user_id")
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		userID = &parsedUserID
+	}
+
+	billing, err := parseBillingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	pricing, err := parsePricingQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	locale, err := parseLocaleQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
 	}
+
+	report, err := h.service.CalculateCostByPaymentMethod(
+		c.Request.Context(),
+		userID,
+		req.StartDate,
+		req.EndDate,
+		billing,
+		pricing,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.PaymentMethodCostReportToResponse(report, middleware.ResponseDateFormat(c), locale))
 }
 
-func (h *SubscriptionHandler) parseStringQuery(c *gin.Context, key string) *string {
-	value := c.Query(key)
-	if value == "" {
-		return nil
+func (h *SubscriptionHandler) CreateComment(c *gin.Context) {
+	pathReq := request.GetSubscriptionRequest{
+		ID: c.Param("id"),
 	}
-	return &value
+
+	id, err := pathReq.GetID(publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", err.Error()))
+		return
+	}
+
+	var req request.CreateCommentRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	comment, err := h.comments.AddComment(c.Request.Context(), id, req.Author, req.Body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.CommentToResponse(comment, publicIDs(c, h.ids)))
 }
 
-func (h *SubscriptionHandler) parseIntQuery(c *gin.Context, key string, defaultValue int) int {
-	value := c.Query(key)
-	if value == "" {
-		return defaultValue
+func (h *SubscriptionHandler) GetComments(c *gin.Context) {
+	pathReq := request.GetSubscriptionRequest{
+		ID: c.Param("id"),
+	}
+
+	id, err := pathReq.GetID(publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", err.Error()))
+		return
+	}
+
+	fields, err := mappers.ParseFieldSet(c.Query("fields"), response.CommentResponse{})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	limit := parseIntQuery(c, "limit", 20)
+	offset := parseIntQuery(c, "offset", 0)
+
+	comments, total, err := h.comments.ListComments(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	limit, offset, _ = utils.ValidatePagination(limit, offset)
+
+	c.JSON(http.StatusOK, mappers.WithFields(response.CommentsListResponse{
+		Data:       mappers.CommentsToResponse(comments, publicIDs(c, h.ids)),
+		Pagination: response.NewPaginationResponse(limit, offset, &total),
+	}, fields))
+}
+
+func (h *SubscriptionHandler) GetPriceHistory(c *gin.Context) {
+	pathReq := request.GetSubscriptionRequest{
+		ID: c.Param("id"),
 	}
 
-	intValue, err := strconv.Atoi(value)
+	id, err := pathReq.GetID(publicIDs(c, h.ids))
 	if err != nil {
-		return defaultValue
+		c.Error(apperror.InvalidInput("id", err.Error()))
+		return
 	}
 
-	return intValue
+	history, err := h.service.GetPriceHistory(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.PriceHistoryToResponse(id, history, publicIDs(c, h.ids)))
+}
+
+func (h *SubscriptionHandler) LinkCatalogService(c *gin.Context) {
+	pathReq := request.GetSubscriptionRequest{
+		ID: c.Param("id"),
+	}
+
+	id, err := pathReq.GetID(publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", err.Error()))
+		return
+	}
+
+	var req request.LinkCatalogServiceRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	catalogID, err := req.GetCatalogID()
+	if err != nil {
+		c.Error(apperror.InvalidInput("catalog_id", "must be a valid UUID"))
+		return
+	}
+
+	subscription, err := h.service.LinkCatalogService(c.Request.Context(), id, catalogID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.SubscriptionToResponse(subscription, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SubscriptionHandler) isExpanded(c *gin.Context, resource string) bool {
+	for _, value := range strings.Split(c.Query("expand"), ",") {
+		if strings.TrimSpace(value) == resource {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *SubscriptionHandler) parseGetSubscriptionsRequest(c *gin.Context) request.GetSubscriptionsRequest {
+	return request.GetSubscriptionsRequest{
+		UserID:      parseStringQuery(c, "user_id"),
+		ServiceName: parseStringQuery(c, "service_name"),
+		StartDate:   parseStringQuery(c, "start_date"),
+		EndDate:     parseStringQuery(c, "end_date"),
+		Tags:        parseStringQuery(c, "tags"),
+		Category:    parseStringQuery(c, "category"),
+		Limit:       parseIntQuery(c, "limit", 20),
+		Offset:      parseIntQuery(c, "offset", 0),
+	}
+}
+
+// exportFilterFromQuery — фильтр выгрузки: фильтры списка без пагинации
+// и archived. Общий для потоковой выгрузки и выгрузки в хранилище.
+func exportFilterFromQuery(c *gin.Context) (*models.SubscriptionFilter, error) {
+	filter, err := mappers.SubscriptionFilterFromRequest(
+		parseStringQuery(c, "user_id"),
+		parseStringQuery(c, "service_name"),
+		parseStringQuery(c, "start_date"),
+		parseStringQuery(c, "end_date"),
+		parseStringQuery(c, "tags"),
+		parseStringQuery(c, "category"),
+		parseMetadataQuery(c),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := parseArchivedQuery(c)
+	if err != nil {
+		return nil, err
+	}
+	filter.SetArchived(archived)
+	return filter, nil
+}
+
+func (h *SubscriptionHandler) parseCalculateCostRequest(c *gin.Context) request.CalculateCostRequest {
+	return request.CalculateCostRequest{
+		UserID:      parseStringQuery(c, "user_id"),
+		ServiceName: parseStringQuery(c, "service_name"),
+		StartDate:   c.Query("start_date"),
+		EndDate:     c.Query("end_date"),
+	}
 }
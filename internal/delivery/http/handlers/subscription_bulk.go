@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+type SubscriptionBulkHandler struct {
+	service service.SubscriptionBulkService
+	ids     *publicid.Registry
+	logger  *logger.Logger
+}
+
+func NewSubscriptionBulkHandler(service service.SubscriptionBulkService, ids *publicid.Registry, logger *logger.Logger) *SubscriptionBulkHandler {
+	return &SubscriptionBulkHandler{
+		service: service,
+		ids:     ids,
+		logger:  logger.Named("subscription-bulk-handler"),
+	}
+}
+
+func (h *SubscriptionBulkHandler) RegisterRoutes(router *gin.RouterGroup) {
+	bulk := router.Group("/subscriptions/bulk")
+	{
+		bulk.PATCH("", h.UpdateSubscriptions)
+		bulk.PATCH("/filter", h.UpdateByFilter)
+	}
+}
+
+func (h *SubscriptionBulkHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodPatch,
+			Path:        "/subscriptions/bulk",
+			ID:          "BulkUpdateSubscriptions",
+			Summary:     "Update subscriptions in bulk",
+			Description: "Apply up to 100 updates in one transaction. Each item takes the fields of PUT /subscriptions/{id}. If any item is rejected nothing is saved: the response is 422 with per-item results, where the rejected item is failed, earlier ones rolled_back and later ones skipped.",
+			Tags:        []string{"subscriptions"},
+			Body:        request.BulkUpdateSubscriptionsRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.BulkUpdateResponse{}},
+				{Status: http.StatusUnprocessableEntity, Body: response.BulkUpdateResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPatch,
+			Path:        "/subscriptions/bulk/filter",
+			ID:          "BulkUpdateSubscriptionsByFilter",
+			Summary:     "Update subscriptions matching a filter",
+			Description: "Apply set to every current subscription matching filter, e.g. rename service_name Yandex+ to Yandex Plus. service_name matches the whole name case-insensitively. dry_run defaults to true and only counts affected subscriptions; send dry_run=false to apply, at most 1000 subscriptions in one transaction.",
+			Tags:        []string{"subscriptions"},
+			Body:        request.BulkUpdateByFilterRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.BulkUpdateByFilterResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+	}
+}
+
+// UpdateSubscriptions отвечает 422, если пакет откачен: тело то же, что при
+// успехе, с итогом каждого элемента.
+func (h *SubscriptionBulkHandler) UpdateSubscriptions(c *gin.Context) {
+	var req request.BulkUpdateSubscriptionsRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	patches, err := mappers.SubscriptionPatchesFromRequest(req, publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	result, err := h.service.UpdateSubscriptions(c.Request.Context(), patches)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Applied() {
+		status = http.StatusUnprocessableEntity
+	}
+	resp, err := mappers.BulkUpdateResultToResponse(result, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(status, resp)
+}
+
+func (h *SubscriptionBulkHandler) UpdateByFilter(c *gin.Context) {
+	var req request.BulkUpdateByFilterRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	filter, err := mappers.BulkFilterFromRequest(req.Filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	result, err := h.service.UpdateByFilter(c.Request.Context(), filter,
+		mappers.SubscriptionPatchFromRequest(uuid.Nil, req.Set), req.IsDryRun())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.logger.Info("bulk update by filter",
+		zap.Bool("dry_run", result.DryRun()),
+		zap.Int("matched", result.Matched()),
+		zap.Int("updated", result.Updated()))
+
+	c.JSON(http.StatusOK, mappers.BulkFilterUpdateResultToResponse(result, publicIDs(c, h.ids)))
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+// exportFileName — имя файла выгрузки в хранилище и при скачивании.
+const exportFileName = "subscriptions.csv"
+
+// SubscriptionExportHandler выкладывает CSV-выгрузку в объектное хранилище
+// и отдаёт ссылку вместо файла: ссылку можно передать дальше, а повторное
+// скачивание не нагружает базу. Регистрируется, только если включён storage.
+type SubscriptionExportHandler struct {
+	subscriptions service.SubscriptionService
+	artifacts     service.ArtifactService
+	ids           *publicid.Registry
+	logger        *logger.Logger
+}
+
+func NewSubscriptionExportHandler(subscriptions service.SubscriptionService, artifacts service.ArtifactService, ids *publicid.Registry, logger *logger.Logger) *SubscriptionExportHandler {
+	return &SubscriptionExportHandler{
+		subscriptions: subscriptions,
+		artifacts:     artifacts,
+		ids:           ids,
+		logger:        logger.Named("subscription-export-handler"),
+	}
+}
+
+func (h *SubscriptionExportHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/subscriptions/export/file", h.ExportSubscriptionsFile)
+}
+
+func (h *SubscriptionExportHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/subscriptions/export/file",
+			ID:          "ExportSubscriptionsFile",
+			Summary:     "Export subscriptions to object storage",
+			Description: "Write the same CSV as GET /subscriptions/export to object storage and return a presigned download link. The link expires after storage.url_ttl; the file is deleted after storage.retention.",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("user_id", "User ID filter", openapi.UUID()),
+				openapi.QueryParam("service_name", "Service name filter", openapi.String()),
+				openapi.QueryParam("start_date", "Start date filter (MM-YYYY format)", openapi.String()),
+				openapi.QueryParam("end_date", "End date filter (MM-YYYY format)", openapi.String()),
+				openapi.QueryParam("tags", "Comma-separated tags; subscriptions must have all of them", openapi.String()),
+				openapi.QueryParam("category", "Category filter", openapi.Enum("streaming", "music", "cloud", "software", "gaming", "fitness", "education", "news", "shopping", "other")),
+				openapi.QueryParam("metadata.key", "Metadata filter, e.g. metadata.team=platform; repeat for several keys", openapi.String()),
+				openapi.QueryParam("archived", "Export archived subscriptions instead of current ones", openapi.Boolean().WithDefault(false)),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.ExportFileResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError, http.StatusServiceUnavailable},
+		},
+	}
+}
+
+/*
+ExportSubscriptionsFile пишет выгрузку во временный файл: PUT в S3 требует
+длину тела заранее, а держать всю выгрузку в памяти не хочется.
+*/
+func (h *SubscriptionExportHandler) ExportSubscriptionsFile(c *gin.Context) {
+	filter, err := exportFilterFromQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	file, err := os.CreateTemp("", "subscriptions-export-*.csv")
+	if err != nil {
+		c.Error(apperror.InternalError("failed to create export file", err))
+		return
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	format := middleware.ResponseDateFormat(c)
+	writer := csv.NewWriter(file)
+	if err := writer.Write(mappers.SubscriptionCSVHeader); err != nil {
+		c.Error(apperror.InternalError("failed to write export file", err))
+		return
+	}
+
+	rows := 0
+	err = h.subscriptions.ExportSubscriptions(c.Request.Context(), filter, func(subscription *models.Subscription) error {
+		record, err := mappers.SubscriptionToCSVRecord(subscription, format, publicIDs(c, h.ids))
+		if err != nil {
+			return err
+		}
+		rows++
+		return writer.Write(record)
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		c.Error(apperror.InternalError("failed to write export file", err))
+		return
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		c.Error(apperror.InternalError("failed to read export file", fmt.Errorf("rewind: %w", err)))
+		return
+	}
+
+	artifact, err := h.artifacts.Store(c.Request.Context(), models.ArtifactExport, exportFileName, "text/csv; charset=utf-8", file, size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.logger.Info("subscriptions exported to storage",
+		zap.String("key", artifact.Key()),
+		zap.Int("rows", rows),
+		zap.Int64("size", size))
+
+	c.JSON(http.StatusCreated, mappers.ExportArtifactToResponse(artifact, rows))
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+const subscriptionLimitPath = "/admin/users/:user_id/subscription-limit"
+
+// SubscriptionLimitHandler — лимиты числа подписок отдельных пользователей
+// поверх limits.max_subscriptions_per_user.
+type SubscriptionLimitHandler struct {
+	service service.SubscriptionLimitService
+	logger  *logger.Logger
+}
+
+func NewSubscriptionLimitHandler(service service.SubscriptionLimitService, logger *logger.Logger) *SubscriptionLimitHandler {
+	return &SubscriptionLimitHandler{
+		service: service,
+		logger:  logger.Named("subscription-limit-handler"),
+	}
+}
+
+func (h *SubscriptionLimitHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET(subscriptionLimitPath, h.GetSubscriptionLimit)
+	router.PUT(subscriptionLimitPath, h.SetSubscriptionLimit)
+	router.DELETE(subscriptionLimitPath, h.ResetSubscriptionLimit)
+}
+
+func (h *SubscriptionLimitHandler) Routes() []openapi.Route {
+	userParam := openapi.PathParam("user_id", "User ID", openapi.UUID())
+
+	return []openapi.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        subscriptionLimitPath,
+			ID:          "GetSubscriptionLimit",
+			Summary:     "User subscription limit",
+			Description: "Effective maximum number of current (non-archived) subscriptions for the user, where it comes from and how many the user already has. 0 means unlimited.",
+			Tags:        []string{"admin"},
+			Params:      []openapi.Parameter{userParam},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.SubscriptionLimitResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        subscriptionLimitPath,
+			ID:          "SetSubscriptionLimit",
+			Summary:     "Override user subscription limit",
+			Description: "Replace limits.max_subscriptions_per_user for one user; 0 lifts the limit. Existing subscriptions above the new limit are kept, only new ones are rejected with LIMIT_EXCEEDED.",
+			Tags:        []string{"admin"},
+			Params:      []openapi.Parameter{userParam},
+			Body:        request.SetSubscriptionLimitRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.SubscriptionLimitResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        subscriptionLimitPath,
+			ID:          "ResetSubscriptionLimit",
+			Summary:     "Reset user subscription limit",
+			Description: "Remove the override; limits.max_subscriptions_per_user applies again",
+			Tags:        []string{"admin"},
+			Params:      []openapi.Parameter{userParam},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *SubscriptionLimitHandler) GetSubscriptionLimit(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	usage, err := h.service.GetSubscriptionLimit(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.SubscriptionLimitToResponse(usage))
+}
+
+func (h *SubscriptionLimitHandler) SetSubscriptionLimit(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req request.SetSubscriptionLimitRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	var actor string
+	if principal := middleware.CurrentPrincipal(c); principal != nil {
+		actor = principal.Subject()
+	}
+	h.logger.Info("subscription limit override requested",
+		zap.String("user_id", userID.String()),
+		zap.Int("max_subscriptions", *req.MaxSubscriptions),
+		zap.String("actor", actor))
+
+	usage, err := h.service.SetSubscriptionLimit(c.Request.Context(), userID, *req.MaxSubscriptions, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.SubscriptionLimitToResponse(usage))
+}
+
+func (h *SubscriptionLimitHandler) ResetSubscriptionLimit(c *gin.Context) {
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.service.ResetSubscriptionLimit(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MessageResponse{
+		Message: "Subscription limit reset successfully",
+	})
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+type SubscriptionMemberHandler struct {
+	service service.SubscriptionMemberService
+	ids     *publicid.Registry
+	logger  *logger.Logger
+}
+
+func NewSubscriptionMemberHandler(service service.SubscriptionMemberService, ids *publicid.Registry, logger *logger.Logger) *SubscriptionMemberHandler {
+	return &SubscriptionMemberHandler{
+		service: service,
+		ids:     ids,
+		logger:  logger.Named("subscription-member-handler"),
+	}
+}
+
+func (h *SubscriptionMemberHandler) RegisterRoutes(router *gin.RouterGroup) {
+	subscriptions := router.Group("/subscriptions")
+	{
+		subscriptions.POST("/:id/members", h.AddMember)
+		subscriptions.GET("/:id/members", h.ListMembers)
+		subscriptions.DELETE("/:id/members/:user_id", h.RemoveMember)
+	}
+}
+
+// Routes описывает участников общих подписок так, как их регистрирует RegisterRoutes.
+func (h *SubscriptionMemberHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/subscriptions/:id/members",
+			ID:          "AddSubscriptionMember",
+			Summary:     "Share a subscription with a user",
+			Description: "The member pays share_percent of the price and the owner pays the rest. Per-user cost calculations split the price accordingly. The owner cannot be a member, and member shares cannot add up to more than 100.",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+			},
+			Body: request.AddSubscriptionMemberRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: response.SubscriptionMemberResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/subscriptions/:id/members",
+			ID:      "ListSubscriptionMembers",
+			Summary: "List subscription members and the owner's share",
+			Tags:    []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.SubscriptionMembersResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/subscriptions/:id/members/:user_id",
+			ID:          "RemoveSubscriptionMember",
+			Summary:     "Remove a subscription member",
+			Description: "The member's share goes back to the owner",
+			Tags:        []string{"subscriptions"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+				openapi.PathParam("user_id", "Member user ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.MessageResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *SubscriptionMemberHandler) AddMember(c *gin.Context) {
+	id, err := h.subscriptionID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req request.AddSubscriptionMemberRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		c.Error(apperror.InvalidUserID(req.UserID))
+		return
+	}
+
+	member, err := h.service.AddMember(c.Request.Context(), id, userID, req.SharePercent)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.SubscriptionMemberToResponse(member, publicIDs(c, h.ids)))
+}
+
+func (h *SubscriptionMemberHandler) ListMembers(c *gin.Context) {
+	id, err := h.subscriptionID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	members, err := h.service.ListMembers(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.SubscriptionMembersToResponse(members, publicIDs(c, h.ids)))
+}
+
+func (h *SubscriptionMemberHandler) RemoveMember(c *gin.Context) {
+	id, err := h.subscriptionID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, err := utils.ValidateUUID(c.Param("user_id"), "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.service.RemoveMember(c.Request.Context(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.MessageResponse{
+		Message: "Subscription member removed successfully",
+	})
+}
+
+func (h *SubscriptionMemberHandler) subscriptionID(c *gin.Context) (uuid.UUID, error) {
+	pathReq := request.GetSubscriptionRequest{
+		ID: c.Param("id"),
+	}
+
+	id, err := pathReq.GetID(publicIDs(c, h.ids))
+	if err != nil {
+		return uuid.Nil, apperror.InvalidInput("id", err.Error())
+	}
+	return id, nil
+}
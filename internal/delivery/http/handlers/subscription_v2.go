@@ -0,0 +1,387 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	v2request "github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/v2/request"
+	v2response "github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/v2/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// SubscriptionV2Handler обслуживает /api/v2. Бизнес-логика общая с v1,
+// отличаются только DTO: даты по умолчанию в ISO 8601 (YYYY-MM), end_date всегда присутствует.
+type SubscriptionV2Handler struct {
+	service service.SubscriptionService
+	ids     *publicid.Registry
+	logger  *logger.Logger
+}
+
+func NewSubscriptionV2Handler(service service.SubscriptionService, ids *publicid.Registry, logger *logger.Logger) *SubscriptionV2Handler {
+	return &SubscriptionV2Handler{
+		service: service,
+		ids:     ids,
+		logger:  logger.Named("subscription-v2-handler"),
+	}
+}
+
+func (h *SubscriptionV2Handler) RegisterRoutes(router *gin.RouterGroup) {
+	subscriptions := router.Group("/subscriptions")
+	{
+		subscriptions.POST("/", h.CreateSubscription)
+		subscriptions.GET("/:id", h.GetSubscription)
+		subscriptions.PUT("/:id", h.UpdateSubscription)
+		subscriptions.DELETE("/:id", h.DeleteSubscription)
+		subscriptions.GET("/", h.GetSubscriptions)
+	}
+
+	users := router.Group("/users")
+	{
+		users.GET("/:user_id/subscriptions", h.GetUserSubscriptions)
+	}
+}
+
+// Routes описывает операции v2; даты в схемах — ISO 8601.
+func (h *SubscriptionV2Handler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/subscriptions/",
+			ID:          "CreateSubscription",
+			Summary:     "Create a new subscription (v2)",
+			Description: "Create a new subscription for a user. Dates are accepted as YYYY-MM, YYYY-MM-DD or MM-YYYY.",
+			Tags:        []string{"subscriptions-v2"},
+			Body:        v2request.CreateSubscriptionRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusCreated, Body: v2response.SubscriptionResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/subscriptions/:id",
+			ID:      "GetSubscription",
+			Summary: "Get subscription by ID (v2)",
+			Tags:    []string{"subscriptions-v2"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+				openapi.QueryParam("fields", "Comma-separated fields to return, e.g. id,price,service_name", openapi.String()),
+				openapi.HeaderParam("If-None-Match", "ETag from a previous response"),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: v2response.SubscriptionResponse{}},
+				{Status: http.StatusNotModified, Description: "Not modified"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/subscriptions/:id",
+			ID:          "UpdateSubscription",
+			Summary:     "Update subscription (v2)",
+			Description: "Update an existing subscription. Send end_date as an empty string to clear it.",
+			Tags:        []string{"subscriptions-v2"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+			},
+			Body: v2request.UpdateSubscriptionRequest{},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: v2response.SubscriptionResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/subscriptions/:id",
+			ID:      "DeleteSubscription",
+			Summary: "Delete subscription (v2)",
+			Tags:    []string{"subscriptions-v2"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("id", "Subscription ID", openapi.UUID()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusNoContent, Description: "Deleted"},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/subscriptions/",
+			ID:      "GetSubscriptions",
+			Summary: "List subscriptions (v2)",
+			Tags:    []string{"subscriptions-v2"},
+			Params: []openapi.Parameter{
+				openapi.QueryParam("user_id", "User ID filter", openapi.UUID()),
+				openapi.QueryParam("service_name", "Service name filter", openapi.String()),
+				openapi.QueryParam("start_date", "Start date filter (YYYY-MM)", openapi.String()),
+				openapi.QueryParam("end_date", "End date filter (YYYY-MM)", openapi.String()),
+				openapi.QueryParam("tags", "Comma-separated tags; subscriptions must have all of them", openapi.String()),
+				openapi.QueryParam("category", "Category filter", openapi.Enum("streaming", "music", "cloud", "software", "gaming", "fitness", "education", "news", "shopping", "other")),
+				openapi.QueryParam("metadata.key", "Metadata filter, e.g. metadata.team=platform; repeat for several keys", openapi.String()),
+				openapi.QueryParam("archived", "List archived subscriptions (ended more than archive.after_months ago) instead of current ones", openapi.Boolean().WithDefault(false)),
+				openapi.QueryParam("limit", "Limit number of results", openapi.Integer().WithDefault(20)),
+				openapi.QueryParam("offset", "Offset for pagination", openapi.Integer().WithDefault(0)),
+				openapi.QueryParam("fields", "Comma-separated fields to return, e.g. id,price,service_name", openapi.String()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: v2response.SubscriptionsListResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/users/:user_id/subscriptions",
+			ID:      "GetUserSubscriptions",
+			Summary: "Get user subscriptions (v2)",
+			Tags:    []string{"subscriptions-v2"},
+			Params: []openapi.Parameter{
+				openapi.PathParam("user_id", "User ID", openapi.UUID()),
+				openapi.QueryParam("limit", "Limit number of results", openapi.Integer().WithDefault(20)),
+				openapi.QueryParam("offset", "Offset for pagination", openapi.Integer().WithDefault(0)),
+				openapi.QueryParam("fields", "Comma-separated fields to return, e.g. id,price,service_name", openapi.String()),
+			},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: v2response.SubscriptionsListResponse{}},
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		},
+	}
+}
+
+func (h *SubscriptionV2Handler) CreateSubscription(c *gin.Context) {
+	var req v2request.CreateSubscriptionRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	userReq := request.CreateSubscriptionRequest{UserID: req.UserID, PlanID: req.PlanID, CatalogID: req.CatalogID}
+	userID, err := userReq.GetUserID()
+	if err != nil {
+		c.Error(apperror.InvalidUserID(req.UserID))
+		return
+	}
+
+	planID, err := userReq.GetPlanID()
+	if err != nil {
+		c.Error(apperror.InvalidInput("plan_id", "must be a valid UUID"))
+		return
+	}
+
+	catalogID, err := userReq.GetCatalogID()
+	if err != nil {
+		c.Error(apperror.InvalidInput("catalog_id", "must be a valid UUID"))
+		return
+	}
+
+	subscription, err := h.service.CreateSubscription(
+		c.Request.Context(),
+		req.ServiceName,
+		req.Price,
+		userID,
+		req.StartDate,
+		utils.StringPtr(req.EndDate),
+		utils.StringPtr(req.PromoCode),
+		planID,
+		catalogID,
+		req.Tags,
+		utils.StringPtr(req.Category),
+		utils.StringPtr(req.PaymentMethod),
+		req.BillingDay,
+		req.Notes,
+		req.Metadata,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.SubscriptionToV2Response(subscription, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *SubscriptionV2Handler) GetSubscription(c *gin.Context) {
+	pathReq := request.GetSubscriptionRequest{ID: c.Param("id")}
+	id, err := pathReq.GetID(publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", err.Error()))
+		return
+	}
+
+	fields, err := mappers.ParseFieldSet(c.Query("fields"), v2response.SubscriptionResponse{})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	subscription, err := h.service.GetSubscriptionByID(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	format := middleware.ResponseDateFormat(c)
+	resp, err := mappers.SubscriptionToV2Response(subscription, format, publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	version := newResourceVersion(subscription.UpdatedAt(), "v2", resp.ID, subscription.UpdatedAt().UnixNano(), fields, format)
+	if setValidators(c, version) {
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.WithFields(resp, fields))
+}
+
+func (h *SubscriptionV2Handler) UpdateSubscription(c *gin.Context) {
+	pathReq := request.UpdateSubscriptionPathRequest{ID: c.Param("id")}
+	id, err := pathReq.GetID(publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", err.Error()))
+		return
+	}
+
+	var req v2request.UpdateSubscriptionRequest
+	if err := validation.BindJSON(c, &req); err != nil {
+		h.logger.Warn("invalid request body", zap.Error(err))
+		c.Error(err)
+		return
+	}
+
+	subscription, err := h.service.UpdateSubscription(
+		c.Request.Context(),
+		id,
+		req.ServiceName,
+		req.Price,
+		req.StartDate,
+		req.EndDate,
+		req.Tags,
+		req.Category,
+		req.PaymentMethod,
+		req.BillingDay,
+		req.Notes,
+		req.Metadata,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.SubscriptionToV2Response(subscription, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *SubscriptionV2Handler) DeleteSubscription(c *gin.Context) {
+	pathReq := request.DeleteSubscriptionRequest{ID: c.Param("id")}
+	id, err := pathReq.GetID(publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(apperror.InvalidInput("id", err.Error()))
+		return
+	}
+
+	if err := h.service.DeleteSubscription(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SubscriptionV2Handler) GetSubscriptions(c *gin.Context) {
+	fields, err := mappers.ParseFieldSet(c.Query("fields"), v2response.SubscriptionResponse{})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	filter, err := mappers.SubscriptionFilterFromRequest(
+		parseStringQuery(c, "user_id"),
+		parseStringQuery(c, "service_name"),
+		parseStringQuery(c, "start_date"),
+		parseStringQuery(c, "end_date"),
+		parseStringQuery(c, "tags"),
+		parseStringQuery(c, "category"),
+		parseMetadataQuery(c),
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	archived, err := parseArchivedQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	filter.SetArchived(archived)
+
+	limit := parseIntQuery(c, "limit", 20)
+	offset := parseIntQuery(c, "offset", 0)
+
+	subscriptions, total, err := h.service.GetAllSubscriptions(c.Request.Context(), filter, limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.SubscriptionsToV2ListResponse(subscriptions, v2response.PaginationResponse{Limit: limit, Offset: offset, Total: &total}, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mappers.WithFields(resp, fields))
+}
+
+func (h *SubscriptionV2Handler) GetUserSubscriptions(c *gin.Context) {
+	req := request.GetUserSubscriptionsRequest{
+		UserID: c.Param("user_id"),
+		Limit:  parseIntQuery(c, "limit", 20),
+		Offset: parseIntQuery(c, "offset", 0),
+	}
+
+	userID, err := req.GetUserID()
+	if err != nil {
+		c.Error(apperror.InvalidUserID(req.UserID))
+		return
+	}
+
+	fields, err := mappers.ParseFieldSet(c.Query("fields"), v2response.SubscriptionResponse{})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	subscriptions, err := h.service.GetSubscriptionsByUser(c.Request.Context(), userID, req.Limit, req.Offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resp, err := mappers.SubscriptionsToV2ListResponse(subscriptions, v2response.PaginationResponse{Limit: req.Limit, Offset: req.Offset}, middleware.ResponseDateFormat(c), publicIDs(c, h.ids))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mappers.WithFields(resp, fields))
+}
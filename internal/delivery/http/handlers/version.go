@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/buildinfo"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+)
+
+// VersionHandler отдаёт версию сборки, чтобы по любой реплике было видно,
+// что именно в ней развёрнуто.
+type VersionHandler struct {
+	info buildinfo.Info
+}
+
+func NewVersionHandler(info buildinfo.Info) *VersionHandler {
+	return &VersionHandler{info: info}
+}
+
+func (h *VersionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/version", h.Version)
+}
+
+func (h *VersionHandler) Routes() []openapi.Route {
+	return []openapi.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/version",
+			ID:          "GetVersion",
+			Summary:     "Build information",
+			Description: "Get the version, git commit, build date and Go version of the running build",
+			Tags:        []string{"health"},
+			Responses: []openapi.Reply{
+				{Status: http.StatusOK, Body: response.VersionResponse{}},
+			},
+		},
+	}
+}
+
+func (h *VersionHandler) Version(c *gin.Context) {
+	c.JSON(http.StatusOK, response.VersionResponse{
+		Version:   h.info.Version,
+		Commit:    h.info.Commit,
+		BuildDate: h.info.BuildDate,
+		GoVersion: h.info.GoVersion,
+	})
+}
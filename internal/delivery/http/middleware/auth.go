@@ -0,0 +1,203 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+
+	principalKey = "principal"
+)
+
+// PermissionPublic — маршрут доступен без учётных данных.
+const PermissionPublic models.Permission = ""
+
+// EndpointPermissions — какое разрешение нужно для маршрута. Ключ — метод и
+// шаблон пути без префикса /api/<версия>, поэтому одна запись покрывает
+// одинаковые маршруты всех версий. Маршрут, которого здесь нет, доступен
+// только администратору.
+var EndpointPermissions = map[string]models.Permission{
+	"GET /health/":      PermissionPublic,
+	"GET /health/ready": PermissionPublic,
+	"GET /health/live":  PermissionPublic,
+	"GET /version":      PermissionPublic,
+
+	"POST /subscriptions/":                         models.PermissionSubscriptionsWrite,
+	"GET /subscriptions/":                          models.PermissionSubscriptionsRead,
+	"GET /subscriptions/search":                    models.PermissionSubscriptionsRead,
+	"GET /subscriptions/:id":                       models.PermissionSubscriptionsRead,
+	"PUT /subscriptions/:id":                       models.PermissionSubscriptionsWrite,
+	"DELETE /subscriptions/:id":                    models.PermissionSubscriptionsWrite,
+	"PATCH /subscriptions/bulk":                    models.PermissionSubscriptionsWrite,
+	"PATCH /subscriptions/bulk/filter":             models.PermissionSubscriptionsWrite,
+	"POST /subscriptions/:id/comments":             models.PermissionSubscriptionsWrite,
+	"GET /subscriptions/:id/comments":              models.PermissionSubscriptionsRead,
+	"GET /subscriptions/:id/price-history":         models.PermissionSubscriptionsRead,
+	"PUT /subscriptions/:id/catalog":               models.PermissionSubscriptionsWrite,
+	"POST /subscriptions/:id/members":              models.PermissionSubscriptionsWrite,
+	"GET /subscriptions/:id/members":               models.PermissionSubscriptionsRead,
+	"DELETE /subscriptions/:id/members/:user_id":   models.PermissionSubscriptionsWrite,
+	"GET /users/:user_id/subscriptions":            models.PermissionSubscriptionsRead,
+	"DELETE /users/:user_id/subscriptions":         models.PermissionSubscriptionsWrite,
+	"GET /users/:user_id/subscriptions/stats":      models.PermissionSubscriptionsRead,
+	"GET /users/:user_id/subscriptions/calendar":   models.PermissionSubscriptionsRead,
+	"GET /users/:user_id/subscriptions/expiring":   models.PermissionSubscriptionsRead,
+	"GET /users/:user_id/subscriptions/duplicates": models.PermissionSubscriptionsRead,
+	"POST /users/:user_id/alerts":                  models.PermissionSubscriptionsWrite,
+	"GET /users/:user_id/alerts":                   models.PermissionSubscriptionsRead,
+	"DELETE /users/:user_id/alerts/:id":            models.PermissionSubscriptionsWrite,
+
+	"GET /costs/calculate":                 models.PermissionReportsRead,
+	"GET /costs/by-category":               models.PermissionReportsRead,
+	"GET /costs/by-payment-method":         models.PermissionReportsRead,
+	"GET /users/:user_id/costs/forecast":   models.PermissionReportsRead,
+	"GET /users/:user_id/billing-calendar": models.PermissionReportsRead,
+
+	// Ленту открывают календари без ключа API: доступ проверяет токен в query.
+	"GET /users/:user_id/billing-calendar.ics":     PermissionPublic,
+	"POST /users/:user_id/billing-calendar/feed":   models.PermissionSubscriptionsWrite,
+	"DELETE /users/:user_id/billing-calendar/feed": models.PermissionSubscriptionsWrite,
+
+	"POST /plans/":                 models.PermissionPlansWrite,
+	"GET /plans/":                  models.PermissionSubscriptionsRead,
+	"GET /plans/:id":               models.PermissionSubscriptionsRead,
+	"PUT /plans/:id":               models.PermissionPlansWrite,
+	"DELETE /plans/:id":            models.PermissionPlansWrite,
+	"GET /plans/:id/price-history": models.PermissionSubscriptionsRead,
+
+	"POST /services/":       models.PermissionPlansWrite,
+	"GET /services/":        models.PermissionSubscriptionsRead,
+	"GET /services/suggest": models.PermissionSubscriptionsRead,
+	"GET /services/:id":     models.PermissionSubscriptionsRead,
+	"PUT /services/:id":     models.PermissionPlansWrite,
+	"DELETE /services/:id":  models.PermissionPlansWrite,
+
+	"GET /admin/config/consistency":        models.PermissionAdminRead,
+	"GET /admin/reports/user-spend":        models.PermissionReportsRead,
+	"GET /admin/live":                      models.PermissionAdminRead,
+	"GET /admin/service-name-rules":        models.PermissionAdminRead,
+	"POST /admin/service-name-rules":       models.PermissionAdminWrite,
+	"DELETE /admin/service-name-rules/:id": models.PermissionAdminWrite,
+	"GET /admin/service-names":             models.PermissionAdminRead,
+	"POST /admin/service-names":            models.PermissionAdminWrite,
+	"DELETE /admin/service-names/:id":      models.PermissionAdminWrite,
+	"GET /admin/discounts":                 models.PermissionAdminRead,
+	"POST /admin/discounts":                models.PermissionAdminWrite,
+	"GET /admin/discounts/:id":             models.PermissionAdminRead,
+	"DELETE /admin/discounts/:id":          models.PermissionAdminWrite,
+	"GET /admin/analytics/top-services":    models.PermissionReportsRead,
+	"GET /admin/analytics/mrr":             models.PermissionReportsRead,
+	"GET /admin/analytics/churn":           models.PermissionReportsRead,
+	"GET /admin/dead-letters":              models.PermissionAdminRead,
+	"POST /admin/dead-letters/:id/retry":   models.PermissionAdminWrite,
+	"GET /admin/permissions":               models.PermissionAdminRead,
+	"GET /admin/api-keys":                  models.PermissionAdminRead,
+	"POST /admin/api-keys":                 models.PermissionAdminWrite,
+	"DELETE /admin/api-keys/:id":           models.PermissionAdminWrite,
+	"GET /admin/maintenance":               models.PermissionAdminRead,
+	"PUT /admin/maintenance":               models.PermissionAdminWrite,
+	"GET /admin/db/pool":                   models.PermissionAdminRead,
+	"PUT /admin/db/pool":                   models.PermissionAdminWrite,
+	"GET /admin/log-level":                 models.PermissionAdminRead,
+	"PUT /admin/log-level":                 models.PermissionAdminWrite,
+}
+
+// RequiredPermission возвращает разрешение для маршрута; ok = false —
+// маршрута нет в EndpointPermissions, и он закрыт для всех, кроме admin.
+func RequiredPermission(method, route string) (models.Permission, bool) {
+	permission, ok := EndpointPermissions[method+" "+route]
+	if !ok {
+		return models.PermissionAdminWrite, false
+	}
+	return permission, true
+}
+
+// Authorize аутентифицирует запрос (X-API-Key или Authorization: Bearer)
+// и проверяет, что роли клиента хватает для маршрута. versionPrefix —
+// префикс группы, например /api/v1.
+func Authorize(auth service.AuthService, versionPrefix string, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := strings.TrimPrefix(c.FullPath(), versionPrefix)
+		permission, known := RequiredPermission(c.Request.Method, route)
+		if !known {
+			log.Warn("route has no permission mapping, admin only",
+				zap.String("method", c.Request.Method),
+				zap.String("route", c.FullPath()))
+		}
+		if permission == PermissionPublic {
+			c.Next()
+			return
+		}
+
+		if !authorize(c, auth, permission) {
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission — проверка учётных данных для маршрутов вне групп
+// версий API (например, /debug), где нет записи в EndpointPermissions.
+func RequirePermission(auth service.AuthService, permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authorize(c, auth, permission) {
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// authorize аутентифицирует клиента и проверяет разрешение; при отказе
+// ошибка уже добавлена в контекст.
+func authorize(c *gin.Context, auth service.AuthService, permission models.Permission) bool {
+	apiKey, token := Credentials(c)
+	principal, err := auth.Authenticate(c.Request.Context(), apiKey, token)
+	if err != nil {
+		c.Error(err)
+		return false
+	}
+	c.Set(principalKey, principal)
+	// Сервисы проверяют владельца подписки по клиенту из контекста.
+	c.Request = c.Request.WithContext(models.ContextWithPrincipal(c.Request.Context(), principal))
+
+	if !principal.Can(permission) {
+		c.Error(apperror.Forbidden(string(principal.Role()), string(permission)))
+		return false
+	}
+	return true
+}
+
+// CurrentPrincipal возвращает клиента, аутентифицированного Authorize;
+// nil — аутентификация выключена или маршрут публичный.
+func CurrentPrincipal(c *gin.Context) *models.Principal {
+	if principal, ok := c.Get(principalKey); ok {
+		return principal.(*models.Principal)
+	}
+	return nil
+}
+
+// Credentials — API-ключ из X-API-Key и токен из Authorization: Bearer.
+func Credentials(c *gin.Context) (apiKey, token string) {
+	return c.GetHeader(APIKeyHeader), bearerToken(c)
+}
+
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
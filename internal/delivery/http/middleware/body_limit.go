@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+// BodyLimit отклоняет запросы с телом больше maxBytes. Заявленный
+// Content-Length проверяется сразу, chunked-тело — по мере чтения.
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			c.Error(apperror.PayloadTooLarge(maxBytes))
+			c.Abort()
+			return
+		}
+
+		body := &limitedBody{
+			ReadCloser: http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes),
+		}
+		c.Request.Body = body
+
+		c.Next()
+
+		// Хендлер уже превратил ошибку чтения в INVALID_INPUT;
+		// последняя ошибка в c.Errors определяет ответ, поэтому уточняем её.
+		if body.exceeded {
+			c.Error(apperror.PayloadTooLarge(maxBytes))
+		}
+	}
+}
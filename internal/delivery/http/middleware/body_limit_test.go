@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+// uploadHandler читает тело целиком, как BindJSON.
+type uploadHandler struct{}
+
+func (uploadHandler) Routes() []openapi.Route { return nil }
+
+func (uploadHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.POST("/upload", func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			c.Error(apperror.InvalidInput("body", err.Error()))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+}
+
+// largeBody — chunked-тело размером size; read — сколько байт из него прочитали.
+type largeBody struct {
+	size, read int64
+}
+
+func (b *largeBody) Read(p []byte) (int, error) {
+	if b.read >= b.size {
+		return 0, io.EOF
+	}
+	n := min(int64(len(p)), b.size-b.read)
+	for i := range p[:n] {
+		p[i] = 'a'
+	}
+	b.read += n
+	return int(n), nil
+}
+
+func TestBodyLimit_ChunkedBodyWithBodyLogging(t *testing.T) {
+	const maxBodySize = 1024
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	engine := testutil.NewRouter(t, testutil.RouterOptions{
+		Versions:    []router.APIVersion{testutil.V1(uploadHandler{})},
+		Logger:      logger.FromZap(zap.New(core)),
+		MaxBodySize: maxBodySize,
+		BodyLog:     middleware.BodyLogOptions{Enabled: true, MaxSize: 256},
+	})
+
+	body := &largeBody{size: 16 << 20}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/upload", body)
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := testutil.Serve(engine, req)
+	testutil.DecodeError(t, rec, apperror.CodePayloadTooLarge)
+
+	// Лог читает не больше 64 KiB, обработчик — не больше лимита: всё тело
+	// в памяти не оказывается.
+	if body.read > 128<<10 {
+		t.Errorf("read %d bytes of the body, want the read bounded", body.read)
+	}
+
+	entries := logs.FilterMessage("HTTP Request Completed").All()
+	if len(entries) != 1 {
+		t.Fatalf("access log entries = %d, want 1", len(entries))
+	}
+	if got := entries[0].ContextMap()["request_body"]; got != "[body omitted: over 65536 bytes]" {
+		t.Errorf("request_body = %v", got)
+	}
+}
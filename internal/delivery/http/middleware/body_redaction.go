@@ -0,0 +1,178 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// RedactedValue заменяет в логе значения полей из BodyLogOptions.Redact.
+	RedactedValue = "[REDACTED]"
+
+	// defaultBodyLogSize — MaxSize по умолчанию.
+	defaultBodyLogSize = 1024
+	// maxRedactableBody — тела больше этого не разбираются ради лога: импорт
+	// на мегабайты не стоит разбора, в логе остаётся только размер.
+	maxRedactableBody = 64 << 10
+)
+
+/*
+BodyLogOptions — какие тела пишет StructuredLogger: тело запроса и тело
+ответа с ошибкой. Перед записью значения полей из Redact заменяются на
+[REDACTED], затем тело обрезается до MaxSize байт с пометкой о полном
+размере. Тела, которые не разбираются как JSON (CSV, формы), в лог не
+попадают — по ним нельзя найти персональные данные.
+
+Путь в Redact — ключи через точку, от корня документа: customer.email,
+items.*.token; массивы на пути проходятся насквозь, * — любой ключ. Путь
+из одного ключа (user_id) совпадает с этим ключом на любой глубине. Ключи
+сравниваются без учёта регистра; совпавшее значение скрывается целиком,
+даже если это объект.
+*/
+type BodyLogOptions struct {
+	Enabled bool
+	MaxSize int
+	Redact  []string
+}
+
+type bodyRedactor struct {
+	maxSize int
+	paths   [][]string
+}
+
+func newBodyRedactor(opts BodyLogOptions) *bodyRedactor {
+	r := &bodyRedactor{maxSize: opts.MaxSize}
+	if r.maxSize <= 0 {
+		r.maxSize = defaultBodyLogSize
+	}
+	for _, path := range opts.Redact {
+		if path = strings.TrimSpace(path); path != "" {
+			r.paths = append(r.paths, strings.Split(path, "."))
+		}
+	}
+	return r
+}
+
+// peekedBody — тело запроса после peekRequestBody: прочитанное начало и
+// непрочитанный остаток исходного тела.
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+/*
+peekRequestBody читает для лога не больше maxRedactableBody+1 байт тела и
+возвращает их, а запросу оставляет то же тело целиком. Остаток не
+читается: ограничение server.max_body_size (BodyLimit стоит глубже в
+цепочке) применяется к телу при чтении обработчиком, и chunked-тело
+любого размера не оказывается в памяти ради лога.
+*/
+func peekRequestBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	head, _ := io.ReadAll(io.LimitReader(req.Body, maxRedactableBody+1))
+	req.Body = peekedBody{
+		Reader: io.MultiReader(bytes.NewReader(head), req.Body),
+		Closer: req.Body,
+	}
+	return head
+}
+
+// formatRequest — format для начала тела из peekRequestBody; contentLength
+// — заявленный размер, -1 для chunked.
+func (r *bodyRedactor) formatRequest(head []byte, contentLength int64) string {
+	if len(head) <= maxRedactableBody {
+		return r.format(head)
+	}
+	if contentLength > 0 {
+		return fmt.Sprintf("[body omitted: %d bytes]", contentLength)
+	}
+	return fmt.Sprintf("[body omitted: over %d bytes]", maxRedactableBody)
+}
+
+// format готовит тело к записи в лог; пустая строка — писать нечего.
+func (r *bodyRedactor) format(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	if len(body) > maxRedactableBody {
+		return fmt.Sprintf("[body omitted: %d bytes]", len(body))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil || decoder.More() {
+		return fmt.Sprintf("[non-JSON body omitted: %d bytes]", len(body))
+	}
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r.redact(document, nil)); err != nil {
+		return fmt.Sprintf("[body omitted: %d bytes]", len(body))
+	}
+	return r.truncate(strings.TrimSuffix(out.String(), "\n"))
+}
+
+func (r *bodyRedactor) redact(value any, path []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			childPath := append(path[:len(path):len(path)], key)
+			if r.matches(childPath) {
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = r.redact(child, childPath)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = r.redact(child, path)
+		}
+	}
+	return value
+}
+
+func (r *bodyRedactor) matches(path []string) bool {
+	for _, pattern := range r.paths {
+		if len(pattern) == 1 {
+			if strings.EqualFold(pattern[0], path[len(path)-1]) {
+				return true
+			}
+			continue
+		}
+		if len(pattern) != len(path) {
+			continue
+		}
+		matched := true
+		for i, segment := range pattern {
+			if segment != "*" && !strings.EqualFold(segment, path[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate обрезает тело до maxSize байт, не разрывая символ UTF-8.
+func (r *bodyRedactor) truncate(body string) string {
+	if len(body) <= r.maxSize {
+		return body
+	}
+	cut := r.maxSize
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return body[:cut] + fmt.Sprintf("…[truncated: %d bytes]", len(body))
+}
@@ -0,0 +1,215 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+var incompressibleTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"application/zip",
+	"application/gzip",
+	"application/octet-stream",
+	"text/event-stream",
+}
+
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding  string
+	level     int
+	minLength int
+
+	buffer     []byte
+	compressor io.WriteCloser
+	decided    bool
+	// headerHeld — хендлер уже отправил бы заголовки, но до решения о
+	// сжатии они ждут в буфере.
+	headerHeld bool
+}
+
+// Compression сжимает ответы gzip или deflate в зависимости от Accept-Encoding.
+// Ответы короче minLength байт отдаются как есть.
+func Compression(level, minLength int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{
+			ResponseWriter: c.Writer,
+			encoding:       encoding,
+			level:          level,
+			minLength:      minLength,
+		}
+		c.Writer = writer
+		c.Header("Vary", "Accept-Encoding")
+
+		c.Next()
+
+		writer.finish()
+		c.Writer = writer.ResponseWriter
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.compressor != nil {
+			return w.compressor.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buffer = append(w.buffer, b...)
+	if len(w.buffer) >= w.minLength {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow откладывает отправку заголовков до решения о сжатии.
+func (w *compressWriter) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	w.headerHeld = true
+}
+
+// Written считает ответ записанным, как только в буфере что-то есть:
+// иначе Timeout и обработчик ошибок записали бы свой ответ поверх уже
+// готового короткого.
+func (w *compressWriter) Written() bool {
+	if !w.decided && (w.headerHeld || len(w.buffer) > 0) {
+		return true
+	}
+	return w.ResponseWriter.Written()
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(true)
+	}
+	if flusher, ok := w.compressor.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap даёт http.ResponseController доступ к исходному соединению.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+
+	if compress && w.shouldCompress() {
+		header := w.Header()
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+
+		var err error
+		if w.encoding == encodingGzip {
+			w.compressor, err = gzip.NewWriterLevel(w.ResponseWriter, w.level)
+		} else {
+			w.compressor, err = zlib.NewWriterLevel(w.ResponseWriter, w.level)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	buffered := w.buffer
+	w.buffer = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	_, err := w.Write(buffered)
+	return err
+}
+
+func (w *compressWriter) shouldCompress() bool {
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *compressWriter) finish() {
+	if !w.decided {
+		_ = w.decide(false)
+		if !w.ResponseWriter.Written() {
+			w.ResponseWriter.WriteHeaderNow()
+		}
+	}
+	if w.compressor != nil {
+		_ = w.compressor.Close()
+	}
+}
+
+// negotiateEncoding выбирает gzip или deflate с учётом q-значений.
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != encodingGzip && name != encodingDeflate {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if q > bestQ || (q == bestQ && name == encodingGzip) {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
@@ -35,6 +35,12 @@ func DefaultCORSConfig() CORSConfig {
 			"Authorization",
 			"X-Requested-With",
 			"X-Request-ID",
+			"Traceparent",
+			"X-Debug-Timing",
+			"If-None-Match",
+			"If-Modified-Since",
+			"Accept-Date-Format",
+			"Accept-Locale",
 			"Accept",
 			"Accept-Encoding",
 			"Accept-Language",
@@ -42,6 +48,14 @@ func DefaultCORSConfig() CORSConfig {
 		ExposeHeaders: []string{
 			"Content-Length",
 			"X-Request-ID",
+			"X-Processing-Time",
+			"ETag",
+			"Last-Modified",
+			"Deprecation",
+			"Sunset",
+			"Link",
+			"X-Service-Version",
+			"Idempotent-Replayed",
 		},
 		AllowCredentials: false,
 		MaxAge:           300,
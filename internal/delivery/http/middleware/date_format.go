@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+const (
+	AcceptDateFormatHeader = "Accept-Date-Format"
+
+	dateFormatKey = "date_format"
+)
+
+// DateFormat выбирает формат дат в ответе: заголовок Accept-Date-Format
+// (MM-YYYY, YYYY-MM или iso), иначе defaultFormat версии API.
+// Входные даты от него не зависят — принимаются все форматы.
+func DateFormat(defaultFormat utils.DateFormat) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", AcceptDateFormatHeader)
+
+		format := defaultFormat
+		if requested := c.GetHeader(AcceptDateFormatHeader); requested != "" {
+			parsed, ok := utils.ParseDateFormat(requested)
+			if !ok {
+				c.Error(apperror.InvalidInput(AcceptDateFormatHeader,
+					fmt.Sprintf("unsupported date format %q, use %s or %s", requested, utils.DateFormatMonthYear, utils.DateFormatISO)))
+				c.Abort()
+				return
+			}
+			format = parsed
+		}
+
+		c.Set(dateFormatKey, format)
+		c.Next()
+	}
+}
+
+// ResponseDateFormat возвращает формат, выбранный DateFormat; без
+// middleware — MM-YYYY.
+func ResponseDateFormat(c *gin.Context) utils.DateFormat {
+	if format, ok := c.Get(dateFormatKey); ok {
+		return format.(utils.DateFormat)
+	}
+	return utils.DateFormatMonthYear
+}
@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/dedup"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// ReplayedHeader помечает ответ, повторённый Dedup для дубля запроса.
+const ReplayedHeader = "Idempotent-Replayed"
+
+// dedupPollInterval — как часто дубль проверяет, закончился ли первый запрос.
+const dedupPollInterval = 50 * time.Millisecond
+
+// DedupStore — хранилище ответов для Dedup; в приложении — dedup.RedisStore.
+type DedupStore interface {
+	Claim(ctx context.Context, key string, window time.Duration) (bool, error)
+	Lookup(ctx context.Context, key string) (*dedup.Response, error)
+	Complete(ctx context.Context, key string, resp dedup.Response) error
+	Release(ctx context.Context, key string) error
+}
+
+// DedupOptions — окно, в котором одинаковые запросы считаются дублями, и
+// сколько дубль ждёт ответа первого запроса, прежде чем получить 409.
+type DedupOptions struct {
+	Window time.Duration
+	Wait   time.Duration
+}
+
+/*
+Dedup защищает от двойной отправки формы: POST, PUT, PATCH и DELETE с тем
+же пользователем, путём и телом в пределах окна получают ответ первого
+запроса с заголовком Idempotent-Replayed, не выполняясь повторно. Дубль,
+пришедший, пока первый ещё выполняется, ждёт его до opts.Wait.
+
+Сохраняются только успешные ответы: после ошибки ключ освобождается, и
+повтор выполнится заново. Пользователь берётся из CurrentPrincipal, поэтому
+middleware ставится после Authorize; без аутентификации — IP клиента.
+Если хранилище недоступно, запросы проходят без защиты.
+*/
+func Dedup(store DedupStore, opts DedupOptions, log *logger.Logger) gin.HandlerFunc {
+	log = log.Named("dedup")
+
+	return func(c *gin.Context) {
+		if !isUnsafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			// Слишком большое или оборванное тело: ошибку вернёт хендлер.
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		key := dedupKey(c, body)
+		deadline := time.Now().Add(opts.Wait)
+
+		for {
+			first, err := store.Claim(ctx, key, opts.Window)
+			if err != nil {
+				log.Warn("dedup store unavailable, request is not deduplicated", zap.Error(err))
+				c.Next()
+				return
+			}
+			if first {
+				runFirst(c, store, key, log)
+				return
+			}
+
+			resp, err := store.Lookup(ctx, key)
+			if err != nil {
+				log.Warn("dedup store unavailable, request is not deduplicated", zap.Error(err))
+				c.Next()
+				return
+			}
+			if resp != nil {
+				log.Info("duplicate request replayed",
+					zap.String("method", c.Request.Method),
+					zap.String("path", c.Request.URL.Path))
+				replay(c, resp)
+				return
+			}
+
+			if time.Now().After(deadline) {
+				c.Error(apperror.Conflict("request", "an identical request is still being processed"))
+				c.Abort()
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				c.Error(apperror.RequestTimeout(opts.Wait))
+				c.Abort()
+				return
+			case <-time.After(dedupPollInterval):
+			}
+		}
+	}
+}
+
+// runFirst выполняет первый запрос и сохраняет его ответ для дублей.
+func runFirst(c *gin.Context, store DedupStore, key string, log *logger.Logger) {
+	writer := &dedupWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+
+	c.Next()
+
+	// Контекст запроса к этому моменту может быть отменён, а ключ нужно
+	// сохранить или освободить в любом случае.
+	ctx := context.WithoutCancel(c.Request.Context())
+
+	if len(c.Errors) > 0 || writer.Status() >= http.StatusBadRequest {
+		if err := store.Release(ctx, key); err != nil {
+			log.Warn("failed to release dedup key", zap.Error(err))
+		}
+		return
+	}
+
+	err := store.Complete(ctx, key, dedup.Response{
+		Status:      writer.Status(),
+		ContentType: writer.Header().Get("Content-Type"),
+		Location:    writer.Header().Get("Location"),
+		Body:        writer.body.Bytes(),
+	})
+	if err != nil {
+		log.Warn("failed to store response for duplicates", zap.Error(err))
+	}
+}
+
+func replay(c *gin.Context, resp *dedup.Response) {
+	c.Header(ReplayedHeader, "true")
+	if resp.Location != "" {
+		c.Header("Location", resp.Location)
+	}
+	if len(resp.Body) == 0 {
+		c.AbortWithStatus(resp.Status)
+		return
+	}
+	c.Data(resp.Status, resp.ContentType, resp.Body)
+	c.Abort()
+}
+
+// dedupKey — хеш пользователя, метода, пути со строкой запроса и тела.
+func dedupKey(c *gin.Context, body []byte) string {
+	actor := "ip:" + c.ClientIP()
+	if principal := CurrentPrincipal(c); principal != nil {
+		actor = "subject:" + principal.Subject()
+	}
+
+	h := sha256.New()
+	for _, part := range []string{actor, c.Request.Method, c.Request.URL.RequestURI()} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func isUnsafeMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// dedupWriter копирует тело ответа, чтобы отдать его дублям.
+type dedupWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *dedupWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *dedupWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *dedupWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
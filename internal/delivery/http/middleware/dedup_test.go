@@ -0,0 +1,208 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/dedup"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+// memoryDedupStore — DedupStore в памяти; окно не истекает.
+type memoryDedupStore struct {
+	mu        sync.Mutex
+	responses map[string]*dedup.Response
+	err       error
+}
+
+func newMemoryDedupStore() *memoryDedupStore {
+	return &memoryDedupStore{responses: make(map[string]*dedup.Response)}
+}
+
+func (s *memoryDedupStore) Claim(_ context.Context, key string, _ time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return false, s.err
+	}
+	if _, ok := s.responses[key]; ok {
+		return false, nil
+	}
+	s.responses[key] = nil
+	return true, nil
+}
+
+func (s *memoryDedupStore) Lookup(_ context.Context, key string) (*dedup.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.responses[key], s.err
+}
+
+func (s *memoryDedupStore) Complete(_ context.Context, key string, resp dedup.Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[key] = &resp
+	return nil
+}
+
+func (s *memoryDedupStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.responses, key)
+	return nil
+}
+
+// ordersHandler считает выполненные запросы, чтобы отличить повтор от
+// настоящего выполнения.
+type ordersHandler struct {
+	calls *int
+}
+
+func (ordersHandler) Routes() []openapi.Route { return nil }
+
+func (h ordersHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.POST("/orders", func(c *gin.Context) {
+		*h.calls++
+		c.Header("Location", "/api/v1/orders/1")
+		c.JSON(http.StatusCreated, gin.H{"call": *h.calls})
+	})
+	group.GET("/orders", func(c *gin.Context) {
+		*h.calls++
+		c.JSON(http.StatusOK, gin.H{"call": *h.calls})
+	})
+	group.POST("/failing", func(c *gin.Context) {
+		*h.calls++
+		c.Error(apperror.InvalidInput("item", "out of stock"))
+	})
+}
+
+func newDedupRouter(t *testing.T, store middleware.DedupStore, wait time.Duration) (*gin.Engine, *int) {
+	t.Helper()
+
+	calls := 0
+	version := testutil.V1(ordersHandler{calls: &calls})
+	version.Middlewares = append(version.Middlewares, middleware.Dedup(store, middleware.DedupOptions{
+		Window: time.Minute,
+		Wait:   wait,
+	}, testutil.NewLogger(t)))
+	return testutil.NewRouter(t, testutil.RouterOptions{Versions: []router.APIVersion{version}}), &calls
+}
+
+type orderRequest struct {
+	Item string `json:"item"`
+}
+
+func TestDedup(t *testing.T) {
+	t.Run("duplicate replays first response", func(t *testing.T) {
+		engine, calls := newDedupRouter(t, newMemoryDedupStore(), time.Second)
+
+		first := testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "book"})
+		second := testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "book"})
+
+		testutil.AssertStatus(t, second, http.StatusCreated)
+		if *calls != 1 {
+			t.Errorf("handler calls = %d, want 1", *calls)
+		}
+		if second.Body.String() != first.Body.String() {
+			t.Errorf("replayed body = %s, want %s", second.Body, first.Body)
+		}
+		if got := second.Header().Get(middleware.ReplayedHeader); got != "true" {
+			t.Errorf("%s = %q, want true", middleware.ReplayedHeader, got)
+		}
+		if first.Header().Get(middleware.ReplayedHeader) != "" {
+			t.Errorf("first response is marked as replayed")
+		}
+		if got := second.Header().Get("Location"); got != "/api/v1/orders/1" {
+			t.Errorf("Location = %q, want /api/v1/orders/1", got)
+		}
+	})
+
+	t.Run("different body is executed", func(t *testing.T) {
+		engine, calls := newDedupRouter(t, newMemoryDedupStore(), time.Second)
+
+		testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "book"})
+		testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "pen"})
+
+		if *calls != 2 {
+			t.Errorf("handler calls = %d, want 2", *calls)
+		}
+	})
+
+	t.Run("different user is executed", func(t *testing.T) {
+		engine, calls := newDedupRouter(t, newMemoryDedupStore(), time.Second)
+
+		testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "book"}, testutil.WithHeader("X-Forwarded-For", "10.0.0.1"))
+		testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "book"}, testutil.WithHeader("X-Forwarded-For", "10.0.0.2"))
+
+		if *calls != 2 {
+			t.Errorf("handler calls = %d, want 2", *calls)
+		}
+	})
+
+	t.Run("safe methods are not deduplicated", func(t *testing.T) {
+		engine, calls := newDedupRouter(t, newMemoryDedupStore(), time.Second)
+
+		testutil.Do(t, engine, http.MethodGet, "/api/v1/orders", nil)
+		rec := testutil.Do(t, engine, http.MethodGet, "/api/v1/orders", nil)
+
+		if *calls != 2 {
+			t.Errorf("handler calls = %d, want 2", *calls)
+		}
+		if rec.Header().Get(middleware.ReplayedHeader) != "" {
+			t.Errorf("GET response is marked as replayed")
+		}
+	})
+
+	t.Run("errors are not replayed", func(t *testing.T) {
+		engine, calls := newDedupRouter(t, newMemoryDedupStore(), time.Second)
+
+		testutil.Do(t, engine, http.MethodPost, "/api/v1/failing", orderRequest{Item: "book"})
+		rec := testutil.Do(t, engine, http.MethodPost, "/api/v1/failing", orderRequest{Item: "book"})
+
+		testutil.DecodeError(t, rec, apperror.CodeInvalidInput)
+		if *calls != 2 {
+			t.Errorf("handler calls = %d, want 2", *calls)
+		}
+	})
+
+	t.Run("store failure lets requests through", func(t *testing.T) {
+		store := newMemoryDedupStore()
+		store.err = errors.New("connection refused")
+		engine, calls := newDedupRouter(t, store, time.Second)
+
+		testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "book"})
+		rec := testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "book"})
+
+		testutil.AssertStatus(t, rec, http.StatusCreated)
+		if *calls != 2 {
+			t.Errorf("handler calls = %d, want 2", *calls)
+		}
+	})
+
+	t.Run("duplicate of a pending request conflicts after wait", func(t *testing.T) {
+		store := newMemoryDedupStore()
+		engine, calls := newDedupRouter(t, store, 0)
+
+		// Первый запрос занял ключ, но ещё не закончился.
+		testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "book"})
+		for key := range store.responses {
+			store.responses[key] = nil
+		}
+
+		rec := testutil.Do(t, engine, http.MethodPost, "/api/v1/orders", orderRequest{Item: "book"})
+		testutil.DecodeError(t, rec, apperror.CodeConflict)
+		if *calls != 1 {
+			t.Errorf("handler calls = %d, want 1", *calls)
+		}
+	})
+}
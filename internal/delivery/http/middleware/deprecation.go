@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeprecationPolicy описывает вывод версии API из эксплуатации.
+// Нулевые Since и Sunset не выставляют соответствующие даты.
+type DeprecationPolicy struct {
+	Since     time.Time
+	Sunset    time.Time
+	Successor string
+}
+
+// Deprecation помечает ответы заголовками Deprecation (RFC 9745),
+// Sunset (RFC 8594) и Link на версию-преемника.
+func Deprecation(policy DeprecationPolicy) gin.HandlerFunc {
+	deprecation := "true"
+	if !policy.Since.IsZero() {
+		deprecation = fmt.Sprintf("@%d", policy.Since.Unix())
+	}
+
+	var sunset string
+	if !policy.Sunset.IsZero() {
+		sunset = policy.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	var link string
+	if policy.Successor != "" {
+		link = fmt.Sprintf(`<%s>; rel="successor-version"`, policy.Successor)
+	}
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		if sunset != "" {
+			c.Header("Sunset", sunset)
+		}
+		if link != "" {
+			c.Writer.Header().Add("Link", link)
+		}
+		c.Next()
+	}
+}
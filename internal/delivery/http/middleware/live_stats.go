@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/livestats"
+)
+
+// LiveStats считает запросы и успешные создания на маршрутах createRoutes
+// для живой панели администратора.
+func LiveStats(stats *livestats.Stats, createRoutes ...string) gin.HandlerFunc {
+	creates := make(map[string]struct{}, len(createRoutes))
+	for _, route := range createRoutes {
+		creates[route] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		c.Next()
+
+		stats.RecordRequest()
+
+		if c.Request.Method != http.MethodPost || c.Writer.Status() != http.StatusCreated {
+			return
+		}
+		if _, ok := creates[c.FullPath()]; ok {
+			stats.RecordCreate()
+		}
+	}
+}
@@ -2,12 +2,13 @@ package middleware
 
 import (
 	"bytes"
-	"io"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/correlation"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
 )
 
@@ -21,6 +22,10 @@ func (w responseWriter) Write(b []byte) (int, error) {
 	return w.ResponseWriter.Write(b)
 }
 
+func (w responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 func Logger(log *logger.Logger) gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
 		fields := []zap.Field{
@@ -53,7 +58,11 @@ func Logger(log *logger.Logger) gin.HandlerFunc {
 	})
 }
 
-func StructuredLogger(log *logger.Logger) gin.HandlerFunc {
+// StructuredLogger пишет запись access-лога на каждый запрос; тела
+// запросов и ответов с ошибкой — по bodies, см. BodyLogOptions.
+func StructuredLogger(log *logger.Logger, bodies BodyLogOptions) gin.HandlerFunc {
+	redactor := newBodyRedactor(bodies)
+
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -64,18 +73,25 @@ func StructuredLogger(log *logger.Logger) gin.HandlerFunc {
 			requestID = generateRequestID()
 			c.Header("X-Request-ID", requestID)
 		}
-
-		var requestBody []byte
-		if c.Request.Body != nil {
-			requestBody, _ = io.ReadAll(c.Request.Body)
-			c.Request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+		// Идентификаторы уходят в контекст, чтобы логи репозиториев и SQL
+		// (см. postgres.queryLogTracer) совпадали с этой записью.
+		ids := correlation.IDs{
+			RequestID: requestID,
+			TraceID:   correlation.TraceIDFromTraceparent(c.GetHeader(correlation.TraceparentHeader)),
 		}
+		c.Request = c.Request.WithContext(correlation.WithIDs(c.Request.Context(), ids))
 
-		writer := &responseWriter{
-			ResponseWriter: c.Writer,
-			body:           bytes.NewBufferString(""),
+		var requestBody []byte
+		var writer *responseWriter
+		if bodies.Enabled {
+			requestBody = peekRequestBody(c.Request)
+
+			writer = &responseWriter{
+				ResponseWriter: c.Writer,
+				body:           bytes.NewBufferString(""),
+			}
+			c.Writer = writer
 		}
-		c.Writer = writer
 
 		c.Next()
 
@@ -94,13 +110,18 @@ func StructuredLogger(log *logger.Logger) gin.HandlerFunc {
 			zap.Duration("latency", latency),
 			zap.Int("body_size", c.Writer.Size()),
 		}
+		if ids.TraceID != "" {
+			fields = append(fields, zap.String("trace_id", ids.TraceID))
+		}
 
-		if len(requestBody) > 0 && len(requestBody) < 1024 {
-			fields = append(fields, zap.ByteString("request_body", requestBody))
+		if body := redactor.formatRequest(requestBody, c.Request.ContentLength); body != "" {
+			fields = append(fields, zap.String("request_body", body))
 		}
 
-		if c.Writer.Status() >= 400 && writer.body.Len() > 0 && writer.body.Len() < 1024 {
-			fields = append(fields, zap.ByteString("response_body", writer.body.Bytes()))
+		if writer != nil && c.Writer.Status() >= 400 {
+			if body := redactor.format(writer.body.Bytes()); body != "" {
+				fields = append(fields, zap.String("response_body", body))
+			}
 		}
 
 		message := "HTTP Request Completed"
@@ -0,0 +1,171 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/correlation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+// correlationHandler отдаёт идентификаторы, которые дошли до контекста запроса.
+type correlationHandler struct{}
+
+func (correlationHandler) Routes() []openapi.Route { return nil }
+
+func (correlationHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/correlation", func(c *gin.Context) {
+		ids := correlation.FromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"request_id": ids.RequestID, "trace_id": ids.TraceID})
+	})
+}
+
+func TestStructuredLogger_Correlation(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	engine := testutil.NewRouter(t, testutil.RouterOptions{
+		Versions: []router.APIVersion{testutil.V1(correlationHandler{})},
+		Logger:   logger.FromZap(zap.New(core)),
+	})
+
+	rec := testutil.Do(t, engine, http.MethodGet, "/api/v1/correlation", nil,
+		testutil.WithHeader("X-Request-ID", "req-42"),
+		testutil.WithHeader("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01"))
+	testutil.AssertStatus(t, rec, http.StatusOK)
+
+	got := testutil.DecodeJSON[map[string]string](t, rec)
+	if got["request_id"] != "req-42" || got["trace_id"] != traceID {
+		t.Errorf("context ids = %v, want req-42 and %s", got, traceID)
+	}
+
+	entries := logs.FilterMessage("HTTP Request Completed").All()
+	if len(entries) != 1 {
+		t.Fatalf("access log entries = %d, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["request_id"] != "req-42" || fields["trace_id"] != traceID {
+		t.Errorf("access log = %v, want request_id req-42 and trace_id %s", fields, traceID)
+	}
+
+	t.Run("generated request id reaches the context", func(t *testing.T) {
+		rec := testutil.Do(t, engine, http.MethodGet, "/api/v1/correlation", nil)
+
+		got := testutil.DecodeJSON[map[string]string](t, rec)
+		if got["request_id"] == "" || got["request_id"] != rec.Header().Get("X-Request-ID") {
+			t.Errorf("context request_id = %q, header %q", got["request_id"], rec.Header().Get("X-Request-ID"))
+		}
+		if got["trace_id"] != "" {
+			t.Errorf("trace_id = %q without traceparent, want empty", got["trace_id"])
+		}
+	})
+}
+
+// echoErrorHandler отвечает ошибкой с телом запроса, чтобы проверить
+// скрытие полей и в теле ответа.
+type echoErrorHandler struct{}
+
+func (echoErrorHandler) Routes() []openapi.Route { return nil }
+
+func (echoErrorHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.POST("/echo", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusUnprocessableEntity, "application/json", body)
+	})
+}
+
+func TestStructuredLogger_BodyRedaction(t *testing.T) {
+	options := middleware.BodyLogOptions{
+		Enabled: true,
+		MaxSize: 512,
+		Redact:  []string{"user_id", "Metadata", "payment.card.*", "items.token"},
+	}
+
+	tests := []struct {
+		name    string
+		options middleware.BodyLogOptions
+		body    string
+		want    string
+	}{
+		{
+			name:    "top-level and nested keys",
+			options: options,
+			body:    `{"user_id":"60601fee-2bf1-4721-ae6f-7636e79a0cba","price":400,"owner":{"user_id":"x"}}`,
+			want:    `{"owner":{"user_id":"[REDACTED]"},"price":400,"user_id":"[REDACTED]"}`,
+		},
+		{
+			name:    "object value is hidden whole, keys ignore case",
+			options: options,
+			body:    `{"metadata":{"team":"platform"},"notes":"ok"}`,
+			want:    `{"metadata":"[REDACTED]","notes":"ok"}`,
+		},
+		{
+			name:    "anchored paths with wildcard and arrays",
+			options: options,
+			body:    `{"payment":{"card":{"number":"4111","holder":"Ivan"},"method":"card"},"items":[{"token":"a","id":1},{"token":"b","id":2}],"token":"kept"}`,
+			want:    `{"items":[{"id":1,"token":"[REDACTED]"},{"id":2,"token":"[REDACTED]"}],"payment":{"card":{"holder":"[REDACTED]","number":"[REDACTED]"},"method":"card"},"token":"kept"}`,
+		},
+		{
+			name:    "large numbers keep precision",
+			options: options,
+			body:    `{"amount":12345678901234567890}`,
+			want:    `{"amount":12345678901234567890}`,
+		},
+		{
+			name:    "long body is truncated after redaction",
+			options: middleware.BodyLogOptions{Enabled: true, MaxSize: 24, Redact: []string{"user_id"}},
+			body:    `{"notes":"` + strings.Repeat("я", 20) + `","user_id":"secret"}`,
+			want:    `{"notes":"яяяяяяя…[truncated: 75 bytes]`,
+		},
+		{
+			name:    "non-JSON body is omitted",
+			options: options,
+			body:    "user_id,price\n60601fee-2bf1-4721-ae6f-7636e79a0cba,400\n",
+			want:    "[non-JSON body omitted: 55 bytes]",
+		},
+		{
+			name:    "disabled",
+			options: middleware.BodyLogOptions{Redact: []string{"user_id"}},
+			body:    `{"user_id":"secret"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.InfoLevel)
+			engine := testutil.NewRouter(t, testutil.RouterOptions{
+				Versions: []router.APIVersion{testutil.V1(echoErrorHandler{})},
+				Logger:   logger.FromZap(zap.New(core)),
+				BodyLog:  tt.options,
+			})
+
+			rec := testutil.Do(t, engine, http.MethodPost, "/api/v1/echo", tt.body)
+			if rec.Body.String() != tt.body {
+				t.Fatalf("handler got body %q, want it unchanged", rec.Body.String())
+			}
+
+			entries := logs.FilterMessage("HTTP Request Completed").All()
+			if len(entries) != 1 {
+				t.Fatalf("access log entries = %d, want 1", len(entries))
+			}
+			fields := entries[0].ContextMap()
+			for _, key := range []string{"request_body", "response_body"} {
+				got, _ := fields[key].(string)
+				if got != tt.want {
+					t.Errorf("%s = %q, want %q", key, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/maintenance"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/snapshot"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+/*
+Maintenance отклоняет запросы в режиме обслуживания ошибкой MAINTENANCE
+(503). Записи отклоняются всегда, чтения — по режиму переключателя: идут
+как обычно, отдаются из снапшотов деградации (store) или тоже отклоняются.
+exempt — шаблоны маршрутов, которые работают всегда (пробы, сам
+переключатель).
+*/
+func Maintenance(sw *maintenance.Switch, store *snapshot.Store, exempt ...string) gin.HandlerFunc {
+	skip := make(map[string]struct{}, len(exempt))
+	for _, route := range exempt {
+		skip[route] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if !sw.Enabled() {
+			c.Next()
+			return
+		}
+		if _, ok := skip[c.FullPath()]; ok {
+			c.Next()
+			return
+		}
+
+		status := sw.Status()
+		if isRead(c.Request.Method) {
+			switch status.Reads {
+			case maintenance.ReadsAllow:
+				c.Next()
+				return
+			case maintenance.ReadsCache:
+				if serveCached(c, store) {
+					c.Abort()
+					return
+				}
+			}
+		}
+
+		c.Error(apperror.Maintenance(status.Reason))
+		c.Abort()
+	}
+}
+
+func isRead(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// serveCached отдаёт снапшот маршрута без ограничения возраста: во время
+// обслуживания устаревший ответ лучше ошибки.
+func serveCached(c *gin.Context, store *snapshot.Store) bool {
+	if store == nil {
+		return false
+	}
+	snap, found := store.Get(snapshotKey(c))
+	if !found {
+		return false
+	}
+	body, err := markStale(snap)
+	if err != nil {
+		return false
+	}
+	writeSnapshot(c, snap, body)
+	return true
+}
@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/maintenance"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+type notesHandler struct{}
+
+func (notesHandler) Routes() []openapi.Route { return nil }
+
+func (notesHandler) RegisterRoutes(group *gin.RouterGroup) {
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) }
+	group.GET("/notes", ok)
+	group.POST("/notes", ok)
+	group.PUT("/switch", ok)
+}
+
+func TestMaintenance(t *testing.T) {
+	cases := []struct {
+		name      string
+		enabled   bool
+		reads     maintenance.ReadMode
+		method    string
+		target    string
+		status    int
+		errorCode string
+	}{
+		{"disabled write", false, maintenance.ReadsDeny, http.MethodPost, "/api/v1/notes", http.StatusOK, ""},
+		{"write", true, maintenance.ReadsAllow, http.MethodPost, "/api/v1/notes", http.StatusServiceUnavailable, apperror.CodeMaintenance},
+		{"allowed read", true, maintenance.ReadsAllow, http.MethodGet, "/api/v1/notes", http.StatusOK, ""},
+		{"denied read", true, maintenance.ReadsDeny, http.MethodGet, "/api/v1/notes", http.StatusServiceUnavailable, apperror.CodeMaintenance},
+		{"cache miss", true, maintenance.ReadsCache, http.MethodGet, "/api/v1/notes", http.StatusServiceUnavailable, apperror.CodeMaintenance},
+		{"exempt route", true, maintenance.ReadsDeny, http.MethodPut, "/api/v1/switch", http.StatusOK, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sw := maintenance.NewSwitch(tc.enabled, "schema migration", tc.reads, testutil.NewLogger(t))
+			version := testutil.V1(notesHandler{})
+			version.Middlewares = append(version.Middlewares, middleware.Maintenance(sw, nil, "/api/v1/switch"))
+			engine := testutil.NewRouter(t, testutil.RouterOptions{Versions: []router.APIVersion{version}})
+
+			rec := testutil.Do(t, engine, tc.method, tc.target, nil)
+			testutil.AssertStatus(t, rec, tc.status)
+			if tc.errorCode != "" {
+				testutil.DecodeError(t, rec, tc.errorCode)
+			}
+		})
+	}
+}
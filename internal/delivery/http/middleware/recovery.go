@@ -2,49 +2,48 @@ package middleware
 
 import (
 	"fmt"
-	"net/http"
 	"runtime/debug"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/correlation"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
 )
 
-func Recovery(log *logger.Logger) gin.HandlerFunc {
+// Recovery превращает панику в 500 INTERNAL_ERROR. problemTypeBase —
+// префикс type для ответов application/problem+json.
+func Recovery(log *logger.Logger, problemTypeBase string) gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
-			requestID = "unknown"
-		}
+		requestID := currentRequestID(c)
 
 		stack := string(debug.Stack())
 
 		log.Error("panic recovered",
 			zap.String("request_id", requestID),
+			traceField(c),
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
 			zap.String("ip", c.ClientIP()),
 			zap.Any("error", recovered),
 			zap.String("stack", stack))
 
-		errorResp := response.NewErrorResponse(
-			apperror.CodeInternalError,
-			"Internal server error occurred",
-			map[string]string{
-				"panic": fmt.Sprintf("%v", recovered),
-			},
-			requestID,
-		)
-
-		c.Header("Content-Type", "application/json")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResp)
+		err := apperror.New(apperror.CodeInternalError, "Internal server error occurred").
+			WithDetail("panic", fmt.Sprintf("%v", recovered))
+		writeError(c, err, requestID, problemTypeBase)
 	})
 }
 
-func ErrorHandler(log *logger.Logger) gin.HandlerFunc {
+/*
+ErrorHandler отдаёт последнюю ошибку из c.Errors. По умолчанию — в
+конверте ErrorResponse; клиенту с Accept: application/problem+json — по
+RFC 7807, с type из problemTypeBase и кода ошибки. Сообщение переводится
+по Accept-Language, код остаётся прежним.
+*/
+func ErrorHandler(log *logger.Logger, problemTypeBase string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
 
@@ -52,44 +51,140 @@ func ErrorHandler(log *logger.Logger) gin.HandlerFunc {
 			return
 		}
 
-		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
-			requestID = "unknown"
-		}
+		requestID := currentRequestID(c)
 
 		err := c.Errors.Last().Err
 
 		if appErr, ok := apperror.IsAppError(err); ok {
 			log.Warn("application error occurred",
 				zap.String("request_id", requestID),
+				traceField(c),
 				zap.String("error_code", appErr.Code()),
 				zap.String("error_message", appErr.Message()),
 				zap.Error(appErr.Cause()))
 
-			errorResp := response.NewErrorResponse(
-				appErr.Code(),
-				appErr.Message(),
-				appErr.Details(),
-				requestID,
-			)
-
-			c.Header("Content-Type", "application/json")
-			c.AbortWithStatusJSON(appErr.HTTPStatus(), errorResp)
+			writeError(c, appErr, requestID, problemTypeBase)
 			return
 		}
 
 		log.Error("unexpected error occurred",
 			zap.String("request_id", requestID),
+			traceField(c),
 			zap.Error(err))
 
-		errorResp := response.NewErrorResponse(
-			apperror.CodeInternalError,
-			"An unexpected error occurred",
-			nil,
+		writeError(c, apperror.New(apperror.CodeInternalError, "An unexpected error occurred"), requestID, problemTypeBase)
+	}
+}
+
+// writeError отдаёт ошибку в формате, который выбрал клиент заголовком Accept.
+// currentRequestID — X-Request-ID из контекста (его кладёт StructuredLogger,
+// он же генерирует недостающий), затем из заголовка запроса.
+func currentRequestID(c *gin.Context) string {
+	if requestID := correlation.FromContext(c.Request.Context()).RequestID; requestID != "" {
+		return requestID
+	}
+	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
+		return requestID
+	}
+	return "unknown"
+}
+
+// traceField — trace_id из traceparent запроса, если он был.
+func traceField(c *gin.Context) zap.Field {
+	if traceID := correlation.FromContext(c.Request.Context()).TraceID; traceID != "" {
+		return zap.String("trace_id", traceID)
+	}
+	return zap.Skip()
+}
+
+func writeError(c *gin.Context, err *apperror.AppError, requestID, problemTypeBase string) {
+	message := localize(c, err.Code(), err.Message())
+	violations := validationErrors(err.Violations())
+
+	if wantsProblem(c) {
+		title, _ := apperror.Localize(err.Code(), problemTitle(err), c.GetHeader("Accept-Language"))
+		c.Header("Content-Type", response.ProblemContentType)
+		c.AbortWithStatusJSON(err.HTTPStatus(), response.ProblemDetails{
+			Type:             apperror.ProblemType(problemTypeBase, err.Code()),
+			Title:            title,
+			Status:           err.HTTPStatus(),
+			Detail:           message,
+			Instance:         c.Request.URL.Path,
+			Code:             err.Code(),
+			Details:          nonEmpty(err.Details()),
+			ValidationErrors: violations,
+			Timestamp:        time.Now(),
+			RequestID:        requestID,
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	if len(violations) > 0 {
+		c.AbortWithStatusJSON(err.HTTPStatus(), response.NewValidationErrorResponse(
+			err.Code(),
+			message,
+			err.Details(),
+			violations,
 			requestID,
-		)
+		))
+		return
+	}
+
+	c.AbortWithStatusJSON(err.HTTPStatus(), response.NewErrorResponse(
+		err.Code(),
+		message,
+		err.Details(),
+		requestID,
+	))
+}
+
+// wantsProblem — клиент явно просит application/problem+json раньше
+// application/json; */* и отсутствие Accept дают обычный конверт.
+func wantsProblem(c *gin.Context) bool {
+	if c.GetHeader("Accept") == "" {
+		return false
+	}
+	return c.NegotiateFormat(gin.MIMEJSON, response.ProblemContentType) == response.ProblemContentType
+}
+
+// problemTitle — общее описание типа ошибки: по RFC 7807 title не меняется
+// от случая к случаю, подробности — в detail.
+func problemTitle(err *apperror.AppError) string {
+	if title, ok := apperror.ErrorMessages[err.Code()]; ok {
+		return title
+	}
+	return err.Message()
+}
+
+func nonEmpty(details map[string]string) map[string]string {
+	if len(details) == 0 {
+		return nil
+	}
+	return details
+}
+
+// localize переводит сообщение ошибки на язык из Accept-Language и
+// проставляет Content-Language. Код ошибки остаётся прежним.
+func localize(c *gin.Context, code, message string) string {
+	localized, tag := apperror.Localize(code, message, c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", tag.String())
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	return localized
+}
 
-		c.Header("Content-Type", "application/json")
-		c.AbortWithStatusJSON(http.StatusInternalServerError, errorResp)
+func validationErrors(violations []apperror.FieldViolation) []response.ValidationError {
+	if len(violations) == 0 {
+		return nil
+	}
+	result := make([]response.ValidationError, 0, len(violations))
+	for _, v := range violations {
+		result = append(result, response.ValidationError{
+			Field:   v.Field,
+			Rule:    v.Rule,
+			Message: v.Message,
+			Value:   v.Value,
+		})
 	}
+	return result
 }
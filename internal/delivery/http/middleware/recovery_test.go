@@ -0,0 +1,148 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+type failingHandler struct{}
+
+func (failingHandler) Routes() []openapi.Route { return nil }
+
+func (failingHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/plan", func(c *gin.Context) {
+		c.Error(apperror.New(apperror.CodeConflict, "Plan already exists"))
+	})
+	group.GET("/subscription", func(c *gin.Context) {
+		c.Error(apperror.SubscriptionNotFound("42"))
+	})
+	group.GET("/unexpected", func(c *gin.Context) {
+		c.Error(errors.New("boom"))
+	})
+	group.POST("/invalid", func(c *gin.Context) {
+		c.Error(apperror.InvalidFields(apperror.FieldViolation{Field: "price", Rule: "min", Message: "must be at least 1", Value: "-5"}))
+	})
+	group.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+}
+
+func TestErrorHandler_AcceptLanguage(t *testing.T) {
+	cases := []struct {
+		name           string
+		target         string
+		acceptLanguage string
+		status         int
+		code           string
+		message        string
+		language       string
+	}{
+		{"default", "/api/v1/subscription", "", http.StatusNotFound, apperror.CodeSubscriptionNotFound, "Subscription not found", "en"},
+		{"russian by code", "/api/v1/subscription", "ru-RU,ru;q=0.9,en;q=0.8", http.StatusNotFound, apperror.CodeSubscriptionNotFound, "Подписка не найдена", "ru"},
+		{"russian by text", "/api/v1/plan", "ru", http.StatusConflict, apperror.CodeConflict, "Тариф уже существует", "ru"},
+		{"unsupported language", "/api/v1/plan", "fr", http.StatusConflict, apperror.CodeConflict, "Plan already exists", "en"},
+		{"unexpected error", "/api/v1/unexpected", "ru", http.StatusInternalServerError, apperror.CodeInternalError, "Внутренняя ошибка сервера", "ru"},
+	}
+
+	engine := testutil.NewRouter(t, testutil.RouterOptions{Versions: []router.APIVersion{testutil.V1(failingHandler{})}})
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []testutil.RequestOption
+			if tc.acceptLanguage != "" {
+				opts = append(opts, testutil.WithHeader("Accept-Language", tc.acceptLanguage))
+			}
+
+			rec := testutil.Do(t, engine, http.MethodGet, tc.target, nil, opts...)
+			testutil.AssertStatus(t, rec, tc.status)
+			body := testutil.DecodeError(t, rec, tc.code)
+			if body.Error.Message != tc.message {
+				t.Errorf("message = %q, want %q", body.Error.Message, tc.message)
+			}
+			if got := rec.Header().Get("Content-Language"); got != tc.language {
+				t.Errorf("Content-Language = %q, want %q", got, tc.language)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_ProblemJSON(t *testing.T) {
+	cases := []struct {
+		name        string
+		method      string
+		target      string
+		accept      string
+		status      int
+		contentType string
+		problemType string
+		title       string
+		detail      string
+	}{
+		{"envelope by default", http.MethodGet, "/api/v1/plan", "", http.StatusConflict, "application/json", "", "", ""},
+		{"envelope for any type", http.MethodGet, "/api/v1/plan", "*/*", http.StatusConflict, "application/json", "", "", ""},
+		{"envelope when json comes first", http.MethodGet, "/api/v1/plan", "application/json, application/problem+json", http.StatusConflict, "application/json", "", "", ""},
+		{"problem", http.MethodGet, "/api/v1/plan", "application/problem+json", http.StatusConflict, response.ProblemContentType,
+			"urn:problem-type:subscription-service:conflict", "Resource conflict", "Plan already exists"},
+		{"problem with fallback", http.MethodGet, "/api/v1/subscription", "application/problem+json, application/json;q=0.5", http.StatusNotFound, response.ProblemContentType,
+			"urn:problem-type:subscription-service:subscription-not-found", "Subscription not found", "Subscription not found"},
+		{"problem for unexpected error", http.MethodGet, "/api/v1/unexpected", "application/problem+json", http.StatusInternalServerError, response.ProblemContentType,
+			"urn:problem-type:subscription-service:internal-error", "Internal server error", "An unexpected error occurred"},
+		{"problem for panic", http.MethodGet, "/api/v1/panic", "application/problem+json", http.StatusInternalServerError, response.ProblemContentType,
+			"urn:problem-type:subscription-service:internal-error", "Internal server error", "Internal server error occurred"},
+	}
+
+	engine := testutil.NewRouter(t, testutil.RouterOptions{Versions: []router.APIVersion{testutil.V1(failingHandler{})}})
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []testutil.RequestOption
+			if tc.accept != "" {
+				opts = append(opts, testutil.WithHeader("Accept", tc.accept))
+			}
+
+			rec := testutil.Do(t, engine, tc.method, tc.target, nil, opts...)
+			testutil.AssertStatus(t, rec, tc.status)
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, tc.contentType) {
+				t.Fatalf("Content-Type = %q, want %q", got, tc.contentType)
+			}
+			if tc.problemType == "" {
+				return
+			}
+
+			problem := testutil.DecodeJSON[response.ProblemDetails](t, rec)
+			if problem.Type != tc.problemType || problem.Title != tc.title || problem.Detail != tc.detail || problem.Status != tc.status {
+				t.Errorf("problem = %+v, want type %q, title %q, detail %q, status %d", problem, tc.problemType, tc.title, tc.detail, tc.status)
+			}
+			if problem.Instance != tc.target {
+				t.Errorf("instance = %q, want %q", problem.Instance, tc.target)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_ProblemJSONValidation(t *testing.T) {
+	engine := testutil.NewRouter(t, testutil.RouterOptions{Versions: []router.APIVersion{testutil.V1(failingHandler{})}})
+
+	rec := testutil.Do(t, engine, http.MethodPost, "/api/v1/invalid", nil,
+		testutil.WithHeader("Accept", "application/problem+json"),
+		testutil.WithHeader("Accept-Language", "ru"))
+	testutil.AssertStatus(t, rec, http.StatusBadRequest)
+
+	problem := testutil.DecodeJSON[response.ProblemDetails](t, rec)
+	if problem.Code != apperror.CodeValidationFailed || problem.Title != "Ошибка валидации" {
+		t.Errorf("problem = %+v, want VALIDATION_FAILED with a russian title", problem)
+	}
+	if len(problem.ValidationErrors) != 1 || problem.ValidationErrors[0].Field != "price" {
+		t.Errorf("validation_errors = %+v, want price", problem.ValidationErrors)
+	}
+}
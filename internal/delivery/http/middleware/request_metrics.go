@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+// UnmatchedRoute — метка маршрута для запросов, не попавших ни в один
+// шаблон: сырой путь 404-х раздул бы число рядов в метриках.
+const UnmatchedRoute = "unmatched"
+
+var standardMethods = map[string]struct{}{
+	http.MethodGet: {}, http.MethodHead: {}, http.MethodPost: {}, http.MethodPut: {},
+	http.MethodPatch: {}, http.MethodDelete: {}, http.MethodOptions: {},
+}
+
+// RequestObserver получает итог каждого запроса; errorCode — код apperror
+// или пустая строка для успешного ответа.
+type RequestObserver interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration, errorCode string)
+}
+
+// RequestMetrics снимает метрики по шаблону маршрута gin (/subscriptions/:id),
+// а не по пути запроса, чтобы кардинальность не зависела от идентификаторов.
+func RequestMetrics(observer RequestObserver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		method, route := c.Request.Method, c.FullPath()
+		if route == "" {
+			route = UnmatchedRoute
+			// Метод у несовпавших запросов тоже приходит от клиента как угодно.
+			if _, ok := standardMethods[method]; !ok {
+				method = "OTHER"
+			}
+		}
+
+		status := c.Writer.Status()
+		observer.ObserveHTTPRequest(method, route, status, time.Since(start), errorCode(c, status))
+	}
+}
+
+// errorCode берёт код последней ошибки apperror, как ErrorHandler при
+// формировании ответа. Ответ 5xx без ошибки в контексте (паника) считается
+// INTERNAL_ERROR.
+func errorCode(c *gin.Context, status int) string {
+	if len(c.Errors) > 0 {
+		if appErr, ok := apperror.IsAppError(c.Errors.Last().Err); ok {
+			return appErr.Code()
+		}
+		return apperror.CodeInternalError
+	}
+	if status >= http.StatusInternalServerError {
+		return apperror.CodeInternalError
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+type observed struct {
+	method, route string
+	status        int
+	code          string
+}
+
+type recordingObserver struct {
+	requests []observed
+}
+
+func (o *recordingObserver) ObserveHTTPRequest(method, route string, status int, _ time.Duration, errorCode string) {
+	o.requests = append(o.requests, observed{method: method, route: route, status: status, code: errorCode})
+}
+
+type itemHandler struct{}
+
+func (itemHandler) Routes() []openapi.Route { return nil }
+
+func (itemHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/items/:id", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.Error(apperror.SubscriptionNotFound(c.Param("id")))
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+	group.GET("/panic", func(*gin.Context) { panic("boom") })
+}
+
+func TestRequestMetrics(t *testing.T) {
+	observer := &recordingObserver{}
+	engine := testutil.NewRouter(t, testutil.RouterOptions{
+		Versions:    []router.APIVersion{testutil.V1(itemHandler{})},
+		Middlewares: []gin.HandlerFunc{middleware.RequestMetrics(observer)},
+	})
+
+	testutil.Do(t, engine, http.MethodGet, "/api/v1/items/1", nil)
+	testutil.Do(t, engine, http.MethodGet, "/api/v1/items/2", nil)
+	testutil.Do(t, engine, http.MethodGet, "/api/v1/items/missing", nil)
+	testutil.Do(t, engine, http.MethodGet, "/api/v1/panic", nil)
+	testutil.Do(t, engine, "PROPFIND", "/api/v1/unknown/42", nil)
+
+	want := []observed{
+		{http.MethodGet, "/api/v1/items/:id", http.StatusOK, ""},
+		{http.MethodGet, "/api/v1/items/:id", http.StatusOK, ""},
+		{http.MethodGet, "/api/v1/items/:id", http.StatusNotFound, apperror.CodeSubscriptionNotFound},
+		{http.MethodGet, "/api/v1/panic", http.StatusInternalServerError, apperror.CodeInternalError},
+		{"OTHER", middleware.UnmatchedRoute, http.StatusNotFound, ""},
+	}
+	if len(observer.requests) != len(want) {
+		t.Fatalf("observed %d requests, want %d: %+v", len(observer.requests), len(want), observer.requests)
+	}
+	for i, got := range observer.requests {
+		if got != want[i] {
+			t.Errorf("request %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
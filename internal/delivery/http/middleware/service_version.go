@@ -0,0 +1,14 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+const ServiceVersionHeader = "X-Service-Version"
+
+// ServiceVersion помечает каждый ответ версией сборки: за балансировщиком
+// так видно, какая реплика ответила и успела ли она обновиться.
+func ServiceVersion(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(ServiceVersionHeader, version)
+		c.Next()
+	}
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/snapshot"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/breaker"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+SnapshotFallback запоминает последний успешный ответ маршрутов из routes и
+отдаёт его вместо ошибки, пока база недоступна: разомкнут автомат db
+(может быть nil) или запрос упал на соединении с базой. Остальные ошибки,
+включая прочие 5xx, отдаются как есть.
+*/
+func SnapshotFallback(store *snapshot.Store, routes []config.DegradationRoute, db *breaker.Breaker, log *logger.Logger) gin.HandlerFunc {
+	maxStaleness := make(map[string]time.Duration, len(routes))
+	for _, route := range routes {
+		maxStaleness[route.Path] = route.MaxStalenessDuration()
+	}
+
+	return func(c *gin.Context) {
+		staleness, ok := maxStaleness[c.FullPath()]
+		if !ok || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		writer := &responseWriter{
+			ResponseWriter: c.Writer,
+			body:           bytes.NewBufferString(""),
+		}
+		c.Writer = writer
+
+		c.Next()
+
+		// Ключ строится после обработки: клиента определяет Authorize в группе версии.
+		key := snapshotKey(c)
+		if len(c.Errors) == 0 {
+			if writer.Status() == http.StatusOK {
+				store.Put(key, snapshot.Snapshot{
+					Status:    writer.Status(),
+					Body:      append([]byte(nil), writer.body.Bytes()...),
+					CreatedAt: time.Now(),
+				})
+			}
+			return
+		}
+
+		if !databaseUnavailable(c.Errors.Last().Err, db) {
+			return
+		}
+
+		snap, found := store.Get(key)
+		if !found || snap.Age(time.Now()) > staleness {
+			return
+		}
+
+		body, err := markStale(snap)
+		if err != nil {
+			log.Error("failed to decorate snapshot", zap.Error(err))
+			return
+		}
+
+		log.Warn("serving stale snapshot",
+			zap.String("route", c.FullPath()),
+			zap.Duration("age", snap.Age(time.Now())),
+			zap.Error(c.Errors.Last().Err))
+
+		c.Errors = c.Errors[:0]
+		writeSnapshot(c, snap, body)
+	}
+}
+
+// snapshotKey — ключ снапшота: путь со значениями параметров, строка
+// запроса, заголовки, от которых зависит тело ответа, и клиент. Субъект
+// последний: в нём может быть что угодно, и он не должен сдвигать поля.
+func snapshotKey(c *gin.Context) string {
+	subject := ""
+	if principal := CurrentPrincipal(c); principal != nil {
+		subject = principal.Subject()
+	}
+	return strings.Join([]string{
+		c.Request.URL.EscapedPath(),
+		c.Request.URL.RawQuery,
+		c.GetHeader(AcceptDateFormatHeader),
+		c.GetHeader("Accept-Language"),
+		subject,
+	}, "\n")
+}
+
+// writeSnapshot отдаёт снапшот с пометками устаревшего ответа; body —
+// результат markStale.
+func writeSnapshot(c *gin.Context, snap snapshot.Snapshot, body []byte) {
+	c.Header("X-Snapshot-Stale", "true")
+	c.Header("X-Snapshot-Captured-At", snap.CreatedAt.UTC().Format(time.RFC3339))
+	c.Header("Warning", `110 - "Response is Stale"`)
+	c.Data(snap.Status, "application/json; charset=utf-8", body)
+}
+
+// databaseUnavailable — автомат базы разомкнут или запрос не смог достучаться
+// до базы (DATABASE_ERROR с 503 от репозитория).
+func databaseUnavailable(err error, db *breaker.Breaker) bool {
+	if db != nil && db.State() == breaker.StateOpen {
+		return true
+	}
+	if errors.Is(err, breaker.ErrOpen) {
+		return true
+	}
+	appErr, ok := apperror.IsAppError(err)
+	return ok && appErr.Code() == apperror.CodeDatabaseError && appErr.HTTPStatus() == http.StatusServiceUnavailable
+}
+
+func markStale(snap snapshot.Snapshot) ([]byte, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(snap.Body, &payload); err != nil {
+		return nil, err
+	}
+
+	payload["snapshot"] = gin.H{
+		"stale":       true,
+		"captured_at": snap.CreatedAt.UTC().Format(time.RFC3339),
+		"age_seconds": int(snap.Age(time.Now()).Seconds()),
+	}
+
+	return json.Marshal(payload)
+}
@@ -0,0 +1,117 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/snapshot"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/breaker"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+// usersHandler отвечает телом с user_id из пути, пока failure == nil, и
+// ошибкой failure после этого.
+type usersHandler struct {
+	failure *error
+}
+
+func (usersHandler) Routes() []openapi.Route { return nil }
+
+func (h usersHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/users/:user_id/subscriptions", func(c *gin.Context) {
+		if *h.failure != nil {
+			c.Error(*h.failure)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"user_id": c.Param("user_id")})
+	})
+}
+
+func newSnapshotRouter(t *testing.T, db *breaker.Breaker) (*gin.Engine, *error) {
+	t.Helper()
+
+	var failure error
+	store := snapshot.NewStore(100)
+	version := testutil.V1(usersHandler{failure: &failure})
+	version.Middlewares = append(version.Middlewares, middleware.SnapshotFallback(store, []config.DegradationRoute{
+		{Path: "/api/v1/users/:user_id/subscriptions", MaxStaleness: 3600},
+	}, db, testutil.NewLogger(t)))
+	engine := testutil.NewRouter(t, testutil.RouterOptions{
+		Versions: []router.APIVersion{version},
+		Auth:     testutil.NewStaticAuth(),
+	})
+	return engine, &failure
+}
+
+var errDatabaseDown = apperror.DatabaseError("list subscriptions", errors.New("connection refused")).
+	WithHTTPStatus(http.StatusServiceUnavailable)
+
+func TestSnapshotFallback(t *testing.T) {
+	viewer := testutil.WithAPIKey(testutil.ViewerKey)
+
+	t.Run("database unavailable serves snapshot", func(t *testing.T) {
+		engine, failure := newSnapshotRouter(t, nil)
+		testutil.Do(t, engine, http.MethodGet, "/api/v1/users/alice/subscriptions", nil, viewer)
+
+		*failure = errDatabaseDown
+		rec := testutil.Do(t, engine, http.MethodGet, "/api/v1/users/alice/subscriptions", nil, viewer)
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		if rec.Header().Get("X-Snapshot-Stale") != "true" {
+			t.Errorf("X-Snapshot-Stale is not set")
+		}
+		if got := testutil.DecodeJSON[map[string]any](t, rec)["user_id"]; got != "alice" {
+			t.Errorf("user_id = %v, want alice", got)
+		}
+	})
+
+	t.Run("other server errors are not masked", func(t *testing.T) {
+		engine, failure := newSnapshotRouter(t, nil)
+		testutil.Do(t, engine, http.MethodGet, "/api/v1/users/alice/subscriptions", nil, viewer)
+
+		*failure = apperror.InternalError("report failed", errors.New("nil map"))
+		rec := testutil.Do(t, engine, http.MethodGet, "/api/v1/users/alice/subscriptions", nil, viewer)
+		testutil.DecodeError(t, rec, apperror.CodeInternalError)
+	})
+
+	t.Run("open breaker serves snapshot", func(t *testing.T) {
+		db := breaker.New("postgres", breaker.Settings{FailureThreshold: 1, OpenTimeout: time.Minute})
+		engine, failure := newSnapshotRouter(t, db)
+		testutil.Do(t, engine, http.MethodGet, "/api/v1/users/alice/subscriptions", nil, viewer)
+
+		_ = db.Execute(func() error { return errors.New("ping timeout") })
+		*failure = apperror.DatabaseError("list subscriptions", errors.New("timeout"))
+		rec := testutil.Do(t, engine, http.MethodGet, "/api/v1/users/alice/subscriptions", nil, viewer)
+		testutil.AssertStatus(t, rec, http.StatusOK)
+	})
+
+	misses := []struct {
+		name   string
+		target string
+		opts   []testutil.RequestOption
+	}{
+		{"another path parameter", "/api/v1/users/bob/subscriptions", []testutil.RequestOption{viewer}},
+		{"another query", "/api/v1/users/alice/subscriptions?limit=5", []testutil.RequestOption{viewer}},
+		{"another client", "/api/v1/users/alice/subscriptions", []testutil.RequestOption{testutil.WithAPIKey(testutil.OperatorKey)}},
+		{"another date format", "/api/v1/users/alice/subscriptions", []testutil.RequestOption{viewer, testutil.WithHeader(middleware.AcceptDateFormatHeader, "YYYY-MM")}},
+		{"another language", "/api/v1/users/alice/subscriptions", []testutil.RequestOption{viewer, testutil.WithHeader("Accept-Language", "ru")}},
+	}
+	for _, tc := range misses {
+		t.Run("no snapshot for "+tc.name, func(t *testing.T) {
+			engine, failure := newSnapshotRouter(t, nil)
+			testutil.Do(t, engine, http.MethodGet, "/api/v1/users/alice/subscriptions", nil, viewer)
+
+			*failure = errDatabaseDown
+			rec := testutil.Do(t, engine, http.MethodGet, tc.target, nil, tc.opts...)
+			testutil.DecodeError(t, rec, apperror.CodeDatabaseError)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+/*
+Timeout ограничивает обработку запроса сроком в контексте: defaultTimeout
+или значением из routes по шаблону пути (c.FullPath()), 0 — без срока.
+Хендлер не прерывается насильно: отмена контекста доходит до pgx, запрос
+в БД отменяется, и хендлер возвращает ошибку. Если к этому моменту ответ
+ещё не записан, последней ошибкой становится REQUEST_TIMEOUT (504).
+*/
+func Timeout(defaultTimeout time.Duration, routes map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := routes[c.FullPath()]
+		if !ok {
+			timeout = defaultTimeout
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.Error(apperror.RequestTimeout(timeout))
+		}
+	}
+}
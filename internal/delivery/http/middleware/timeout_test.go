@@ -0,0 +1,90 @@
+package middleware_test
+
+import (
+	"compress/gzip"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+// slowHandler ждёт wait или отмены контекста, как запрос в БД.
+type slowHandler struct {
+	wait time.Duration
+}
+
+func (slowHandler) Routes() []openapi.Route { return nil }
+
+func (h slowHandler) RegisterRoutes(group *gin.RouterGroup) {
+	handle := func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			c.Error(apperror.DatabaseError("query", c.Request.Context().Err()))
+		case <-time.After(h.wait):
+			c.JSON(http.StatusOK, gin.H{"status": "done"})
+		}
+	}
+	group.GET("/reports", handle)
+	group.GET("/export", handle)
+}
+
+func TestTimeout(t *testing.T) {
+	version := testutil.V1(slowHandler{wait: 100 * time.Millisecond})
+	version.Middlewares = append(version.Middlewares, middleware.Timeout(
+		10*time.Millisecond,
+		map[string]time.Duration{"/api/v1/export": time.Second},
+	))
+	engine := testutil.NewRouter(t, testutil.RouterOptions{Versions: []router.APIVersion{version}})
+
+	t.Run("default deadline", func(t *testing.T) {
+		rec := testutil.Do(t, engine, http.MethodGet, "/api/v1/reports", nil)
+		testutil.AssertStatus(t, rec, http.StatusGatewayTimeout)
+		testutil.DecodeError(t, rec, apperror.CodeRequestTimeout)
+	})
+
+	t.Run("route override", func(t *testing.T) {
+		rec := testutil.Do(t, engine, http.MethodGet, "/api/v1/export", nil)
+		testutil.AssertStatus(t, rec, http.StatusOK)
+	})
+}
+
+// answerThenWait отвечает сразу, а потом ждёт отмены контекста, как
+// хендлер, который после ответа ещё пишет аудит.
+type answerThenWait struct{}
+
+func (answerThenWait) Routes() []openapi.Route { return nil }
+
+func (answerThenWait) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/reports", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "done"})
+		<-c.Request.Context().Done()
+	})
+}
+
+func TestTimeoutKeepsBufferedResponse(t *testing.T) {
+	// Короткий ответ сжатие держит в буфере до конца запроса: Timeout не
+	// должен принять его за незаписанный и отдать 504 поверх.
+	version := testutil.V1(answerThenWait{})
+	version.Middlewares = append(version.Middlewares, middleware.Timeout(10*time.Millisecond, nil))
+	engine := testutil.NewRouter(t, testutil.RouterOptions{
+		Versions:    []router.APIVersion{version},
+		Middlewares: []gin.HandlerFunc{middleware.Compression(gzip.DefaultCompression, 1024)},
+	})
+
+	rec := testutil.Do(t, engine, http.MethodGet, "/api/v1/reports", nil,
+		testutil.WithHeader("Accept-Encoding", "gzip"))
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	if got := rec.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want none below min length", got)
+	}
+	if body := testutil.DecodeJSON[map[string]string](t, rec); body["status"] != "done" {
+		t.Errorf("body = %v, want the handler's response", body)
+	}
+}
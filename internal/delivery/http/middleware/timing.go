@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/timing"
+)
+
+const (
+	ProcessingTimeHeader = "X-Processing-Time"
+	DebugTimingHeader    = "X-Debug-Timing"
+)
+
+type timingWriter struct {
+	gin.ResponseWriter
+	recorder  *timing.Recorder
+	buffer    *bytes.Buffer
+	annotated bool
+}
+
+func (w *timingWriter) annotate() {
+	if w.annotated {
+		return
+	}
+	w.annotated = true
+	w.Header().Set(ProcessingTimeHeader, formatMilliseconds(w.recorder.Total()))
+}
+
+func (w *timingWriter) WriteHeaderNow() {
+	if w.buffer != nil {
+		return
+	}
+	w.annotate()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timingWriter) Write(b []byte) (int, error) {
+	if w.buffer != nil {
+		return w.buffer.Write(b)
+	}
+	w.annotate()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *timingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func Timing(allowDebug bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		recorder := timing.NewRecorder()
+		c.Request = c.Request.WithContext(timing.WithRecorder(c.Request.Context(), recorder))
+
+		writer := &timingWriter{
+			ResponseWriter: c.Writer,
+			recorder:       recorder,
+		}
+		if allowDebug && isDebugTimingRequested(c) {
+			writer.buffer = &bytes.Buffer{}
+		}
+		original := c.Writer
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = original
+		if writer.buffer == nil {
+			if !writer.annotated && !original.Written() {
+				original.Header().Set(ProcessingTimeHeader, formatMilliseconds(recorder.Total()))
+			}
+			return
+		}
+
+		body := withTimingMeta(writer.buffer.Bytes(), recorder)
+		original.Header().Set(ProcessingTimeHeader, formatMilliseconds(recorder.Total()))
+		original.Header().Del("Content-Length")
+		original.Write(body)
+	}
+}
+
+func isDebugTimingRequested(c *gin.Context) bool {
+	value := strings.ToLower(c.GetHeader(DebugTimingHeader))
+	return value == "1" || value == "true"
+}
+
+func withTimingMeta(body []byte, recorder *timing.Recorder) []byte {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return body
+	}
+
+	meta, _ := payload["meta"].(map[string]interface{})
+	if meta == nil {
+		meta = make(map[string]interface{})
+	}
+	meta["timing"] = gin.H{
+		"total_ms":   timing.Milliseconds(recorder.Total()),
+		"db_ms":      timing.Milliseconds(recorder.DB()),
+		"db_queries": recorder.DBQueries(),
+	}
+	payload["meta"] = meta
+
+	decorated, err := json.Marshal(payload)
+	if err != nil {
+		return body
+	}
+	return decorated
+}
+
+func formatMilliseconds(d time.Duration) string {
+	return fmt.Sprintf("%.3fms", timing.Milliseconds(d))
+}
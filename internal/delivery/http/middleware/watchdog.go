@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/watchdog"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+func Watchdog(w *watchdog.Watchdog) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		dbError := false
+		for _, ginErr := range c.Errors {
+			if appErr, ok := apperror.IsAppError(ginErr.Err); ok && appErr.Code() == apperror.CodeDatabaseError {
+				dbError = true
+				break
+			}
+		}
+
+		w.Record(c.Writer.Status(), dbError)
+	}
+}
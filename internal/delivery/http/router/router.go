@@ -1,10 +1,20 @@
 package router
 
 import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/pprof"
+
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.uber.org/zap"
+
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
 )
 
 type Router struct {
@@ -38,58 +48,112 @@ func (r *Router) SetupMiddleware(middlewares ...gin.HandlerFunc) {
 	r.engine.Use(middlewares...)
 }
 
-func (r *Router) RegisterHealthRoutes() {
-	health := r.engine.Group("/health")
-	{
-		health.GET("/", r.handleHealthCheck)
-		health.GET("/ready", r.handleReadiness)
-		health.GET("/live", r.handleLiveness)
-	}
+// RegisterHealthRoutes вешает проверки здоровья на корень (/health/...) для
+// проб Kubernetes: вне групп версий на них не действует аутентификация.
+func (r *Router) RegisterHealthRoutes(handler RouteHandler) {
+	handler.RegisterRoutes(&r.engine.RouterGroup)
 }
 
-func (r *Router) RegisterAPIRoutes(handlers ...RouteHandler) {
+// APIVersion — независимая группа маршрутов /api/<Name> со своими
+// middleware (например, заголовками о выводе из эксплуатации) и хендлерами.
+type APIVersion struct {
+	Name        string
+	Middlewares []gin.HandlerFunc
+	Handlers    []RouteHandler
+}
+
+func (r *Router) RegisterAPIRoutes(versions ...APIVersion) {
 	api := r.engine.Group("/api")
-	v1 := api.Group("/v1")
 
-	for _, handler := range handlers {
-		handler.RegisterRoutes(v1)
+	for _, version := range versions {
+		r.logger.Info("registering api version", zap.String("version", version.Name))
+
+		group := api.Group("/"+version.Name, version.Middlewares...)
+		for _, handler := range version.Handlers {
+			handler.RegisterRoutes(group)
+		}
 	}
 }
 
-func (r *Router) RegisterSwaggerRoutes() {
-	r.logger.Info("registering swagger routes")
+// RegisterOpenAPIRoutes отдаёт документ по specPath и Swagger UI, который
+// его читает.
+func (r *Router) RegisterOpenAPIRoutes(specPath string, doc *openapi.Document) error {
+	r.logger.Info("registering openapi routes", zap.String("path", specPath))
 
-	r.engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode openapi document: %w", err)
+	}
+
+	r.engine.GET(specPath, func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+	})
+
+	r.engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL(specPath)))
 
 	r.engine.GET("/docs", func(c *gin.Context) {
 		c.Redirect(302, "/swagger/index.html")
 	})
 
-	r.engine.Static("/api-docs", "./api/swagger")
-
 	r.logger.Info("swagger documentation available at /swagger/index.html")
+	return nil
 }
 
-func (r *Router) handleHealthCheck(c *gin.Context) {
-	c.JSON(200, gin.H{
-		"status":    "ok",
-		"service":   "subscription-service",
-		"timestamp": gin.H{},
-	})
+// RegisterAdminUI раздаёт статический дашборд администратора по path.
+func (r *Router) RegisterAdminUI(path string, assets fs.FS) {
+	r.logger.Info("registering admin ui", zap.String("path", path))
+
+	r.engine.StaticFS(path, http.FS(assets))
 }
 
-func (r *Router) handleReadiness(c *gin.Context) {
-	c.JSON(200, gin.H{
-		"status": "ready",
-	})
+// RegisterWebSocketRoute регистрирует точку подключения WebSocket вне групп
+// версий: аутентификацию handler выполняет сам, до апгрейда.
+func (r *Router) RegisterWebSocketRoute(path string, handler gin.HandlerFunc) {
+	r.logger.Info("registering websocket route", zap.String("path", path))
+
+	r.engine.GET(path, handler)
 }
 
-func (r *Router) handleLiveness(c *gin.Context) {
-	c.JSON(200, gin.H{
-		"status": "alive",
-	})
+// RegisterDebugRoutes подключает pprof (/debug/pprof), expvar (/debug/vars) и
+// состояние пула БД (/debug/pool). middlewares — например, проверка роли.
+func (r *Router) RegisterDebugRoutes(pool gin.HandlerFunc, middlewares ...gin.HandlerFunc) {
+	r.logger.Info("registering debug routes", zap.String("path", "/debug"))
+
+	debug := r.engine.Group("/debug", middlewares...)
+	{
+		debug.GET("/pprof/*profile", handlePprof)
+		debug.POST("/pprof/symbol", gin.WrapF(pprof.Symbol))
+		debug.GET("/vars", gin.WrapH(expvar.Handler()))
+		debug.GET("/pool", pool)
+	}
+}
+
+// handlePprof разводит /debug/pprof/<имя>: у cmdline, profile, symbol и trace
+// свои обработчики, остальные профили (heap, goroutine, ...) отдаёт Index.
+func handlePprof(c *gin.Context) {
+	switch c.Param("profile") {
+	case "/cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "/profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "/symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "/trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		pprof.Index(c.Writer, c.Request)
+	}
+}
+
+func (r *Router) RegisterMetricsRoute(path string, handler http.Handler) {
+	r.logger.Info("registering metrics route", zap.String("path", path))
+
+	r.engine.GET(path, gin.WrapH(handler))
 }
 
+// RouteHandler регистрирует маршруты и описывает их для OpenAPI: каждый
+// маршрут из RegisterRoutes должен быть в Routes.
 type RouteHandler interface {
 	RegisterRoutes(router *gin.RouterGroup)
+	Routes() []openapi.Route
 }
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// ConnectionObserver получает число открытых соединений и признак дренажа,
+// например для экспорта в метрики.
+type ConnectionObserver interface {
+	SetActiveConnections(n int64)
+	SetDraining(draining bool)
+}
+
+// connTracker считает открытые соединения через http.Server.ConnState.
+type connTracker struct {
+	active   atomic.Int64
+	observer ConnectionObserver
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+func newConnTracker(observer ConnectionObserver) *connTracker {
+	return &connTracker{
+		observer: observer,
+		conns:    make(map[net.Conn]struct{}),
+	}
+}
+
+func (t *connTracker) ConnState(conn net.Conn, state http.ConnState) {
+	switch state {
+	case http.StateNew:
+		t.mu.Lock()
+		t.conns[conn] = struct{}{}
+		t.mu.Unlock()
+		t.update(t.active.Add(1))
+	case http.StateHijacked, http.StateClosed:
+		t.mu.Lock()
+		_, tracked := t.conns[conn]
+		delete(t.conns, conn)
+		t.mu.Unlock()
+		if tracked {
+			t.update(t.active.Add(-1))
+		}
+	}
+}
+
+func (t *connTracker) Active() int64 {
+	return t.active.Load()
+}
+
+func (t *connTracker) SetDraining(draining bool) {
+	if t.observer != nil {
+		t.observer.SetDraining(draining)
+	}
+}
+
+func (t *connTracker) update(active int64) {
+	if t.observer != nil {
+		t.observer.SetActiveConnections(active)
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+InFlight считает запросы, которые сейчас обрабатывают хендлеры. В отличие от
+счётчика соединений он видит сами обработчики: http.Server.Shutdown по
+таймауту возвращается, не дождавшись их, и без Wait база закрылась бы под
+ещё работающим запросом.
+*/
+type InFlight struct {
+	mu     sync.Mutex
+	active int
+	idle   chan struct{}
+}
+
+func NewInFlight() *InFlight {
+	idle := make(chan struct{})
+	close(idle)
+	return &InFlight{idle: idle}
+}
+
+// Middleware ставится первым в цепочке, чтобы учесть всё время обработки.
+func (f *InFlight) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		f.add(1)
+		defer f.add(-1)
+		c.Next()
+	}
+}
+
+func (f *InFlight) add(delta int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.active == 0 && delta > 0 {
+		f.idle = make(chan struct{})
+	}
+	f.active += delta
+	if f.active == 0 {
+		close(f.idle)
+	}
+}
+
+func (f *InFlight) Active() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.active
+}
+
+// Wait ждёт, пока не останется запросов в обработке, или отмены ctx.
+func (f *InFlight) Wait(ctx context.Context) error {
+	for {
+		f.mu.Lock()
+		active, idle := f.active, f.idle
+		f.mu.Unlock()
+		if active == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-idle:
+		}
+	}
+}
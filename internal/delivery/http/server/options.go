@@ -64,3 +64,41 @@ func WithHealthCheck(healthCheckFunc func(ctx context.Context) error) Option {
 		s.healthCheck = healthCheckFunc
 	}
 }
+
+func WithTLS(certFile, keyFile string) Option {
+	return func(s *Server) {
+		s.tlsCertFile = certFile
+		s.tlsKeyFile = keyFile
+	}
+}
+
+func WithH2C() Option {
+	return func(s *Server) {
+		s.enableH2C = true
+	}
+}
+
+func WithMaxConnections(n int) Option {
+	return func(s *Server) {
+		s.maxConnections = n
+	}
+}
+
+func WithConnectionObserver(observer ConnectionObserver) Option {
+	return func(s *Server) {
+		s.connObserver = observer
+	}
+}
+
+func WithReadiness(readiness *Readiness) Option {
+	return func(s *Server) {
+		s.readiness = readiness
+	}
+}
+
+// WithDrainDelay — пауза между снятием готовности и закрытием слушателя.
+func WithDrainDelay(delay time.Duration) Option {
+	return func(s *Server) {
+		s.drainDelay = delay
+	}
+}
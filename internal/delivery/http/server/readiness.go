@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// Состояния жизненного цикла сервера для проб Kubernetes.
+const (
+	StateStarting  = "starting"
+	StateWarmingUp = "warming_up"
+	StateReady     = "ready"
+	StateDraining  = "draining"
+	// StateMaintenance — готовый сервер в режиме обслуживания: снаружи
+	// выглядит как неготовый, внутренние проверки продолжаются.
+	StateMaintenance = "maintenance"
+)
+
+// MaintenanceState — переключатель режима обслуживания (maintenance.Switch).
+type MaintenanceState interface {
+	Enabled() bool
+}
+
+type ReadinessConfig struct {
+	// Warmup — сколько после начала приёма соединений сервер ещё не готов:
+	// прогреваются пулы и кэши.
+	Warmup time.Duration
+	// CheckInterval — период внутренней проверки здоровья.
+	CheckInterval time.Duration
+	// FailureThreshold — число проверок подряд, после которого liveness
+	// падает. Одиночный сбой БД не должен приводить к рестарту пода.
+	FailureThreshold int
+}
+
+/*
+Readiness — конечный автомат starting → warming_up → ready → draining.
+Готовность только в ready: во время прогрева и дренажа балансировщик не
+присылает новых запросов. Живость зависит лишь от внутренней проверки:
+она падает после FailureThreshold неудач подряд и восстанавливается первой
+успешной.
+*/
+type Readiness struct {
+	cfg   ReadinessConfig
+	check func(ctx context.Context) error
+	log   *logger.Logger
+
+	maintenance MaintenanceState
+
+	mu        sync.Mutex
+	state     string
+	startedAt time.Time
+	failures  int
+	lastErr   error
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewReadiness: check — внутренняя проверка здоровья (критичные зависимости).
+func NewReadiness(cfg ReadinessConfig, check func(ctx context.Context) error, log *logger.Logger) *Readiness {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = 10 * time.Second
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 1
+	}
+	return &Readiness{
+		cfg:   cfg,
+		check: check,
+		log:   log.Named("readiness"),
+		state: StateStarting,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// SetMaintenance подключает режим обслуживания; вызывается до старта сервера.
+func (r *Readiness) SetMaintenance(m MaintenanceState) {
+	r.maintenance = m
+}
+
+// State — текущее состояние (State*).
+func (r *Readiness) State() string {
+	r.mu.Lock()
+	state := r.state
+	r.mu.Unlock()
+
+	if state == StateReady && r.maintenance != nil && r.maintenance.Enabled() {
+		return StateMaintenance
+	}
+	return state
+}
+
+func (r *Readiness) Ready() bool {
+	return r.State() == StateReady
+}
+
+// Alive — внутренняя проверка не падала FailureThreshold раз подряд.
+func (r *Readiness) Alive() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failures < r.cfg.FailureThreshold
+}
+
+// Failures — число неудачных внутренних проверок подряд и последняя ошибка.
+func (r *Readiness) Failures() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failures, r.lastErr
+}
+
+// start переводит сервер в прогрев и запускает периодическую проверку.
+// Вызывается, когда сервер начал принимать соединения.
+func (r *Readiness) start() {
+	r.mu.Lock()
+	if r.state != StateStarting {
+		r.mu.Unlock()
+		return
+	}
+	r.state = StateWarmingUp
+	r.startedAt = time.Now()
+	r.mu.Unlock()
+
+	r.log.Info("warming up", zap.Duration("warmup", r.cfg.Warmup))
+
+	go r.monitor()
+}
+
+// MarkDraining снимает готовность до закрытия слушателя, чтобы балансировщик
+// успел убрать под из ротации. Вернуться из draining нельзя.
+func (r *Readiness) MarkDraining() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.state == StateDraining {
+		return
+	}
+	r.state = StateDraining
+	r.log.Info("draining, reporting not ready")
+}
+
+// Stop останавливает периодическую проверку.
+func (r *Readiness) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		r.mu.Lock()
+		started := !r.startedAt.IsZero()
+		r.mu.Unlock()
+		if started {
+			<-r.done
+		}
+	})
+}
+
+func (r *Readiness) monitor() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	// Отдельная проверка в конце прогрева, чтобы не ждать следующего тика.
+	warmedUp := time.NewTimer(r.cfg.Warmup)
+	defer warmedUp.Stop()
+
+	r.observe()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-warmedUp.C:
+			r.observe()
+		case <-ticker.C:
+			r.observe()
+		}
+	}
+}
+
+func (r *Readiness) observe() {
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.CheckInterval)
+	defer cancel()
+
+	var err error
+	if r.check != nil {
+		err = r.check(ctx)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state == StateDraining {
+		return
+	}
+
+	if err != nil {
+		r.failures++
+		r.lastErr = err
+		if r.failures == r.cfg.FailureThreshold {
+			r.log.Error("internal health check failed repeatedly, reporting not alive",
+				zap.Int("failures", r.failures),
+				zap.Error(err))
+		} else {
+			r.log.Warn("internal health check failed",
+				zap.Int("failures", r.failures),
+				zap.Int("threshold", r.cfg.FailureThreshold),
+				zap.Error(err))
+		}
+		return
+	}
+
+	if r.failures > 0 {
+		r.log.Info("internal health check recovered", zap.Int("failures", r.failures))
+	}
+	r.failures = 0
+	r.lastErr = nil
+
+	// Прогрев заканчивается первой успешной проверкой после Warmup.
+	if r.state == StateWarmingUp && time.Since(r.startedAt) >= r.cfg.Warmup {
+		r.state = StateReady
+		r.log.Info("ready to accept traffic")
+	}
+}
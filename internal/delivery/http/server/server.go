@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,6 +12,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"golang.org/x/net/netutil"
 
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
@@ -27,6 +31,15 @@ type Server struct {
 	shutdownTimeout        time.Duration
 	enableGracefulShutdown bool
 	healthCheck            func(ctx context.Context) error
+	tlsCertFile            string
+	tlsKeyFile             string
+	certReloader           *certReloader
+	enableH2C              bool
+	maxConnections         int
+	connObserver           ConnectionObserver
+	connTracker            *connTracker
+	readiness              *Readiness
+	drainDelay             time.Duration
 }
 
 func New(opts ...Option) *Server {
@@ -47,27 +60,78 @@ func New(opts ...Option) *Server {
 		server.logger = defaultLogger
 	}
 
+	if server.tlsEnabled() {
+		server.certReloader = newCertReloader(server.tlsCertFile, server.tlsKeyFile, server.logger)
+	}
+	server.connTracker = newConnTracker(server.connObserver)
+
 	server.setupHTTPServer()
 	return server
 }
 
 func (s *Server) setupHTTPServer() {
+	var handler http.Handler = s.router
+	if s.enableH2C {
+		handler = h2c.NewHandler(handler, &http2.Server{IdleTimeout: s.idleTimeout})
+	}
+
 	s.httpServer = &http.Server{
 		Addr:           s.config.Address(),
-		Handler:        s.router,
+		Handler:        handler,
 		ReadTimeout:    s.readTimeout,
 		WriteTimeout:   s.writeTimeout,
 		IdleTimeout:    s.idleTimeout,
 		MaxHeaderBytes: 1 << 20, // 1 MB
+		ConnState:      s.connTracker.ConnState,
+	}
+
+	if s.certReloader != nil {
+		s.httpServer.TLSConfig = newTLSConfig(s.certReloader)
+	}
+}
+
+func (s *Server) tlsEnabled() bool {
+	return s.tlsCertFile != "" && s.tlsKeyFile != ""
+}
+
+func (s *Server) listenAndServe() error {
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+
+	if s.maxConnections > 0 {
+		listener = netutil.LimitListener(listener, s.maxConnections)
+	}
+
+	if s.readiness != nil {
+		s.readiness.start()
+	}
+
+	if s.certReloader == nil {
+		return s.httpServer.Serve(listener)
 	}
+	// Сертификат отдаёт TLSConfig.GetCertificate, поэтому пути не передаём.
+	return s.httpServer.ServeTLS(listener, "", "")
 }
 
 func (s *Server) Start() error {
 	s.logger.Info("starting http server",
 		zap.String("address", s.config.Address()),
+		zap.Bool("tls", s.certReloader != nil),
+		zap.Bool("h2c", s.enableH2C),
+		zap.Int("max_connections", s.maxConnections),
 		zap.Duration("read_timeout", s.readTimeout),
 		zap.Duration("write_timeout", s.writeTimeout))
 
+	if s.certReloader != nil {
+		if err := s.certReloader.Load(); err != nil {
+			s.logger.Error("tls setup failed", zap.Error(err))
+			return err
+		}
+		s.certReloader.WatchSignals()
+	}
+
 	if s.healthCheck != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
@@ -84,13 +148,13 @@ func (s *Server) Start() error {
 	}
 
 	s.logger.Info("server started successfully", zap.String("address", s.config.Address()))
-	return s.httpServer.ListenAndServe()
+	return s.listenAndServe()
 }
 
 func (s *Server) startWithGracefulShutdown() error {
 	go func() {
 		s.logger.Info("server started successfully", zap.String("address", s.config.Address()))
-		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := s.listenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			s.logger.Fatal("server startup failed", zap.Error(err))
 		}
 	}()
@@ -108,11 +172,38 @@ func (s *Server) Shutdown() error {
 	s.logger.Info("shutting down server gracefully",
 		zap.Duration("timeout", s.shutdownTimeout))
 
+	if s.certReloader != nil {
+		s.certReloader.Stop()
+	}
+
+	if s.readiness != nil {
+		s.readiness.MarkDraining()
+		defer s.readiness.Stop()
+
+		// Пробы должны увидеть not ready, пока слушатель ещё открыт, иначе
+		// балансировщик продолжит слать запросы на закрытый порт.
+		if s.drainDelay > 0 {
+			s.logger.Info("waiting for load balancers to observe draining", zap.Duration("delay", s.drainDelay))
+			time.Sleep(s.drainDelay)
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
+	// Отключаем keep-alive до Shutdown, чтобы клиенты получили
+	// Connection: close и переоткрыли соединения на другой реплике.
+	s.httpServer.SetKeepAlivesEnabled(false)
+	s.connTracker.SetDraining(true)
+	defer s.connTracker.SetDraining(false)
+
+	stopProgress := s.reportDrainProgress()
+	defer stopProgress()
+
 	if err := s.httpServer.Shutdown(ctx); err != nil {
-		s.logger.Error("server forced to shutdown", zap.Error(err))
+		s.logger.Error("server forced to shutdown",
+			zap.Int64("active_connections", s.connTracker.Active()),
+			zap.Error(err))
 		return err
 	}
 
@@ -120,6 +211,31 @@ func (s *Server) Shutdown() error {
 	return nil
 }
 
+func (s *Server) reportDrainProgress() func() {
+	s.logger.Info("draining connections", zap.Int64("active_connections", s.connTracker.Active()))
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				s.logger.Info("draining connections", zap.Int64("active_connections", s.connTracker.Active()))
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func (s *Server) ActiveConnections() int64 {
+	return s.connTracker.Active()
+}
+
 func (s *Server) GetHTTPServer() *http.Server {
 	return s.httpServer
 }
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// certReloader держит текущий сертификат и перечитывает его с диска по SIGHUP,
+// чтобы ротация сертификата не требовала рестарта.
+type certReloader struct {
+	certFile string
+	keyFile  string
+	logger   *logger.Logger
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+func newCertReloader(certFile, keyFile string, log *logger.Logger) *certReloader {
+	return &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		logger:   log,
+	}
+}
+
+func (r *certReloader) Load() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load tls certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) WatchSignals() {
+	r.signals = make(chan os.Signal, 1)
+	r.done = make(chan struct{})
+	signal.Notify(r.signals, syscall.SIGHUP)
+
+	go func() {
+		for {
+			select {
+			case <-r.done:
+				return
+			case <-r.signals:
+				if err := r.Load(); err != nil {
+					r.logger.Error("tls certificate reload failed, keeping previous certificate", zap.Error(err))
+					continue
+				}
+				r.logger.Info("tls certificate reloaded", zap.String("cert_file", r.certFile))
+			}
+		}
+	}()
+}
+
+func (r *certReloader) Stop() {
+	if r.done == nil {
+		return
+	}
+	signal.Stop(r.signals)
+	close(r.done)
+	r.done = nil
+}
+
+func newTLSConfig(reloader *certReloader) *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+		CurvePreferences: []tls.CurveID{
+			tls.X25519,
+			tls.CurveP256,
+		},
+		// Для TLS 1.3 набор шифров не настраивается; для TLS 1.2 оставляем
+		// только ECDHE с AEAD-шифрами.
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// rules — собственные правила для тегов binding в DTO запросов.
+var rules = map[string]validator.Func{
+	"monthyear": monthYear,
+	"uuid4":     uuid4,
+}
+
+/*
+monthYear — месяц в формате, который примут ParseStartDate и ParseEndDate
+(MM-YYYY, YYYY-MM или YYYY-MM-DD), в диапазоне лет сервиса. Пустая строка
+проходит: обязательность задаёт required, а "" в обновлении снимает дату
+окончания.
+*/
+func monthYear(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true
+	}
+	_, err := utils.ParseStartDate(value)
+	return err == nil
+}
+
+// uuid4 — UUID версии 4 в любом регистре; встроенное правило validator
+// принимает только нижний регистр.
+func uuid4(fl validator.FieldLevel) bool {
+	id, err := uuid.Parse(fl.Field().String())
+	return err == nil && id.Version() == 4
+}
@@ -0,0 +1,200 @@
+// Package validation связывает тело запроса с DTO и переводит ошибки
+// привязки gin в VALIDATION_FAILED со списком нарушений по полям.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+var setupOnce sync.Once
+
+// setup настраивает валидатор gin: поля в ошибках называются по json-тегу,
+// как их видит клиент, и регистрируются правила из rules.
+func setup() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(jsonFieldName)
+	for tag, fn := range rules {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			panic(fmt.Sprintf("register %s validation: %v", tag, err))
+		}
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// BindJSON разбирает тело запроса в obj; ошибка уже в формате apperror и
+// готова для c.Error.
+func BindJSON(c *gin.Context, obj any) error {
+	setupOnce.Do(setup)
+
+	if err := c.ShouldBindJSON(obj); err != nil {
+		return Translate(err)
+	}
+	return nil
+}
+
+/*
+Translate переводит ошибку привязки: нарушения правил binding и несовпадение
+типов JSON — в VALIDATION_FAILED с перечнем полей, остальное (битый JSON,
+пустое тело) — в INVALID_INPUT с текстом ошибки.
+*/
+func Translate(err error) error {
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		violations := make([]apperror.FieldViolation, 0, len(fieldErrs))
+		for _, fe := range fieldErrs {
+			violations = append(violations, violation(fe))
+		}
+		return apperror.InvalidFields(violations...)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return apperror.InvalidFields(apperror.FieldViolation{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: "must be " + jsonTypeName(typeErr.Type),
+			Value:   typeErr.Value,
+		})
+	}
+
+	return apperror.InvalidInput("request_body", err.Error())
+}
+
+func violation(fe validator.FieldError) apperror.FieldViolation {
+	field := fe.Field()
+	// Namespace начинается с имени DTO: CreateSubscriptionRequest.metadata.team.
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		field = path
+	}
+
+	return apperror.FieldViolation{
+		Field:   field,
+		Rule:    fe.Tag(),
+		Message: message(fe),
+		Value:   value(fe.Value()),
+	}
+}
+
+func message(fe validator.FieldError) string {
+	param := fe.Param()
+	text := fe.Kind() == reflect.String
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return fmt.Sprintf("is required when %s is not set", snakeCase(param))
+	case "required_without_all":
+		fields := strings.Fields(param)
+		for i, name := range fields {
+			fields[i] = snakeCase(name)
+		}
+		return fmt.Sprintf("is required when none of %s is set", strings.Join(fields, ", "))
+	case "min":
+		if text {
+			return fmt.Sprintf("must be at least %s characters long", param)
+		}
+		return "must be at least " + param
+	case "max":
+		if text {
+			return fmt.Sprintf("must be at most %s characters long", param)
+		}
+		return "must be at most " + param
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "uuid":
+		return "must be a valid UUID"
+	case "uuid4":
+		return "must be a valid version 4 UUID"
+	case "monthyear":
+		return "must be a month in " + utils.AcceptedDateFormats + " format"
+	}
+	if param != "" {
+		return fmt.Sprintf("failed %s=%s validation", fe.Tag(), param)
+	}
+	return fmt.Sprintf("failed %s validation", fe.Tag())
+}
+
+// value — присланное значение для ответа; пустые и составные не выводятся.
+func value(v any) string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(rv.Interface())
+	}
+	return ""
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	}
+	return "a " + t.String()
+}
+
+// snakeCase переводит имя поля Go из параметра правила (PlanID) в имя из
+// JSON (plan_id): в параметрах validator ссылается на поля структуры.
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && !unicode.IsUpper(runes[i-1]) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+package validation_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/validation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+type bindHandler struct{}
+
+func (bindHandler) Routes() []openapi.Route { return nil }
+
+func (bindHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.POST("/subscriptions/", func(c *gin.Context) {
+		var req request.CreateSubscriptionRequest
+		if err := validation.BindJSON(c, &req); err != nil {
+			c.Error(err)
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+}
+
+func TestBindJSON(t *testing.T) {
+	engine := testutil.NewRouter(t, testutil.RouterOptions{
+		Versions: []router.APIVersion{testutil.V1(bindHandler{})},
+	})
+
+	cases := []struct {
+		name       string
+		body       string
+		status     int
+		code       string
+		violations []response.ValidationError
+	}{
+		{
+			name:   "valid",
+			body:   `{"service_name":"Netflix","price":599,"user_id":"60601fee-2bf1-4721-ae6f-7636e79a0cba","start_date":"07-2025"}`,
+			status: http.StatusCreated,
+		},
+		{
+			name:   "every invalid field is listed",
+			body:   `{"price":-5,"user_id":"nope","promo_code":"SUMMER2025SUMMER2025SUMMER2025SUMMER2025SUMMER2025SUMMER2025SUMMER2025"}`,
+			status: http.StatusBadRequest,
+			code:   apperror.CodeValidationFailed,
+			violations: []response.ValidationError{
+				{Field: "service_name", Rule: "required_without_all", Message: "is required when none of plan_id, catalog_id is set"},
+				{Field: "price", Rule: "min", Message: "must be at least 1", Value: "-5"},
+				{Field: "user_id", Rule: "uuid4", Message: "must be a valid version 4 UUID", Value: "nope"},
+				{Field: "start_date", Rule: "required", Message: "is required"},
+				{Field: "promo_code", Rule: "max", Message: "must be at most 64 characters long", Value: "SUMMER2025SUMMER2025SUMMER2025SUMMER2025SUMMER2025SUMMER2025SUMMER2025"},
+			},
+		},
+		{
+			name:   "iso month and uppercase uuid",
+			body:   `{"service_name":"Netflix","price":599,"user_id":"60601FEE-2BF1-4721-AE6F-7636E79A0CBA","start_date":"2025-07","end_date":"2025-12-31"}`,
+			status: http.StatusCreated,
+		},
+		{
+			name:   "dates and uuid version",
+			body:   `{"service_name":"Netflix","price":599,"user_id":"123e4567-e89b-12d3-a456-426614174000","plan_id":"9b2e4f60","start_date":"13-2025","end_date":"2025/12"}`,
+			status: http.StatusBadRequest,
+			code:   apperror.CodeValidationFailed,
+			violations: []response.ValidationError{
+				{Field: "plan_id", Rule: "uuid4", Message: "must be a valid version 4 UUID", Value: "9b2e4f60"},
+				{Field: "user_id", Rule: "uuid4", Message: "must be a valid version 4 UUID", Value: "123e4567-e89b-12d3-a456-426614174000"},
+				{Field: "start_date", Rule: "monthyear", Message: "must be a month in MM-YYYY, YYYY-MM or YYYY-MM-DD format", Value: "13-2025"},
+				{Field: "end_date", Rule: "monthyear", Message: "must be a month in MM-YYYY, YYYY-MM or YYYY-MM-DD format", Value: "2025/12"},
+			},
+		},
+		{
+			name:   "wrong json type",
+			body:   `{"service_name":"Netflix","price":"free"}`,
+			status: http.StatusBadRequest,
+			code:   apperror.CodeValidationFailed,
+			violations: []response.ValidationError{
+				{Field: "price", Rule: "type", Message: "must be an integer", Value: "string"},
+			},
+		},
+		{
+			name:   "malformed json",
+			body:   `{"service_name":`,
+			status: http.StatusBadRequest,
+			code:   apperror.CodeInvalidInput,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := testutil.Do(t, engine, http.MethodPost, "/api/v1/subscriptions/", tc.body)
+			testutil.AssertStatus(t, rec, tc.status)
+			if tc.code == "" {
+				return
+			}
+
+			got := testutil.DecodeJSON[response.ValidationErrorResponse](t, rec)
+			if got.Error.Code != tc.code {
+				t.Fatalf("code = %q, want %q", got.Error.Code, tc.code)
+			}
+			if len(got.ValidationErrors) != len(tc.violations) {
+				t.Fatalf("violations = %+v, want %+v", got.ValidationErrors, tc.violations)
+			}
+			for i, want := range tc.violations {
+				if got.ValidationErrors[i] != want {
+					t.Errorf("violation %d = %+v, want %+v", i, got.ValidationErrors[i], want)
+				}
+			}
+		})
+	}
+}
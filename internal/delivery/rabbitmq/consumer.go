@@ -0,0 +1,359 @@
+// Package rabbitmq — приём команд legacy-биллинга из очереди RabbitMQ:
+// команда применяется через сервисный слой, итог публикуется обратно.
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+const consumerTag = "subscription-service"
+
+type Config struct {
+	URL   string
+	Queue string
+	// ResultExchange и ResultRoutingKey — куда публикуются итоги. reply_to
+	// сообщения имеет приоритет; без обоих итог не публикуется.
+	ResultExchange    string
+	ResultRoutingKey  string
+	Prefetch          int
+	ReconnectInterval time.Duration
+	// RetryDelay — пауза перед возвратом в очередь команды, упавшей на
+	// временной ошибке, чтобы не крутить её в цикле, пока БД недоступна.
+	RetryDelay    time.Duration
+	HandleTimeout time.Duration
+}
+
+/*
+Consumer читает очередь команд по одной: следующая команда берётся после
+ack предыдущей, поэтому команды одного пользователя применяются в порядке
+очереди. Повторная доставка безопасна — идемпотентность обеспечивает
+BillingCommandService.
+*/
+type Consumer struct {
+	cfg      Config
+	commands service.BillingCommandService
+	ids      publicid.Codec
+	log      *logger.Logger
+
+	connected atomic.Bool
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func NewConsumer(cfg Config, commands service.BillingCommandService, ids publicid.Codec, log *logger.Logger) *Consumer {
+	if cfg.Prefetch <= 0 {
+		cfg.Prefetch = 1
+	}
+	return &Consumer{
+		cfg:      cfg,
+		commands: commands,
+		ids:      ids,
+		log:      log.Named("billing-consumer"),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start запускает чтение очереди; при разрыве соединения consumer
+// переподключается каждые ReconnectInterval до вызова Stop.
+func (c *Consumer) Start() {
+	go func() {
+		defer close(c.done)
+		for {
+			err := c.consume()
+			if c.stopped() {
+				return
+			}
+			c.log.Warn("billing command consumer disconnected, reconnecting",
+				zap.Duration("retry_in", c.cfg.ReconnectInterval),
+				zap.Error(err))
+			if !c.sleep(c.cfg.ReconnectInterval) {
+				return
+			}
+		}
+	}()
+}
+
+// Stop дожидается обработки текущей команды и закрывает соединение;
+// неподтверждённые сообщения RabbitMQ вернёт в очередь.
+func (c *Consumer) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		<-c.done
+		c.log.Info("billing command consumer stopped")
+	})
+}
+
+func (c *Consumer) stopped() bool {
+	select {
+	case <-c.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// HealthCheck — consumer подключён к брокеру и читает очередь.
+func (c *Consumer) HealthCheck(_ context.Context) error {
+	if !c.connected.Load() {
+		return errors.New("not connected to rabbitmq")
+	}
+	return nil
+}
+
+// sleep ждёт d; false — пришёл Stop.
+func (c *Consumer) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-c.stop:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (c *Consumer) consume() error {
+	conn, err := amqp.Dial(c.cfg.URL)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("open channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.Qos(c.cfg.Prefetch, 0, false); err != nil {
+		return fmt.Errorf("set prefetch: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		return fmt.Errorf("enable publisher confirms: %w", err)
+	}
+	// Очередь и её dead-letter exchange настраивает биллинг; здесь только
+	// проверяется, что она существует.
+	if _, err := ch.QueueDeclarePassive(c.cfg.Queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("queue %q: %w", c.cfg.Queue, err)
+	}
+
+	deliveries, err := ch.Consume(c.cfg.Queue, consumerTag, false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("consume: %w", err)
+	}
+
+	c.connected.Store(true)
+	defer c.connected.Store(false)
+
+	c.log.Info("billing command consumer connected", zap.String("queue", c.cfg.Queue))
+
+	for {
+		select {
+		case <-c.stop:
+			return nil
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("delivery channel closed")
+			}
+			c.handle(confirmedChannel{ch: ch}, d)
+		}
+	}
+}
+
+/*
+handle подтверждает сообщение только после публикации итога: если
+публикация не удалась, команда вернётся в очередь и при повторе отдаст
+сохранённый итог.
+  - неразборчивое сообщение отклоняется без возврата в очередь;
+  - временная ошибка — nack с возвратом после паузы RetryDelay.
+*/
+func (c *Consumer) handle(pub resultPublisher, d amqp.Delivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.HandleTimeout)
+	defer cancel()
+
+	var msg CommandMessage
+	if err := json.Unmarshal(d.Body, &msg); err != nil {
+		c.reject(ctx, pub, d, "", "", apperror.InvalidInput("body", "must be a JSON command envelope"))
+		return
+	}
+	if msg.CommandID == "" {
+		msg.CommandID = d.MessageId
+	}
+	if msg.CommandID == "" {
+		c.reject(ctx, pub, d, "", msg.Type, apperror.InvalidInput("command_id", "cannot be empty"))
+		return
+	}
+
+	result, appErr, err := c.apply(ctx, msg)
+	// Сервис возвращает ошибкой только временные сбои и неверный command_id.
+	if e, ok := apperror.IsAppError(err); ok && e.Code() == apperror.CodeInvalidInput {
+		appErr = e
+	}
+	if appErr != nil {
+		c.reject(ctx, pub, d, msg.CommandID, msg.Type, appErr)
+		return
+	}
+	if err != nil {
+		c.log.Warn("billing command will be retried",
+			zap.String("command_id", msg.CommandID),
+			zap.Error(err))
+		c.sleep(c.cfg.RetryDelay)
+		c.nack(d, true)
+		return
+	}
+
+	if err := c.publish(ctx, pub, d, resultMessage(result, c.ids)); err != nil {
+		c.log.Warn("failed to publish billing command result, requeueing",
+			zap.String("command_id", msg.CommandID),
+			zap.Error(err))
+		c.nack(d, true)
+		return
+	}
+	if err := d.Ack(false); err != nil {
+		c.log.Warn("failed to ack billing command", zap.String("command_id", msg.CommandID), zap.Error(err))
+	}
+}
+
+// apply разбирает payload и вызывает сервис. appErr — команда составлена
+// неверно; err — временный сбой сервиса.
+func (c *Consumer) apply(ctx context.Context, msg CommandMessage) (*models.BillingCommandResult, *apperror.AppError, error) {
+	switch msg.Type {
+	case models.BillingCommandCreateSubscription:
+		var p CreateSubscriptionPayload
+		if err := json.Unmarshal(msg.Payload, &p); err != nil {
+			return nil, apperror.InvalidInput("payload", err.Error()), nil
+		}
+		userID, appErr := parseUserID(p.UserID)
+		if appErr != nil {
+			return nil, appErr, nil
+		}
+		var planID *uuid.UUID
+		if p.PlanID != "" {
+			id, err := uuid.Parse(p.PlanID)
+			if err != nil {
+				return nil, apperror.InvalidInput("plan_id", "must be a UUID"), nil
+			}
+			planID = &id
+		}
+		result, err := c.commands.CreateSubscription(ctx, msg.CommandID, p.ServiceName, p.Price, userID, p.StartDate, p.EndDate, planID)
+		return result, nil, err
+
+	case models.BillingCommandCancelSubscription:
+		var p CancelSubscriptionPayload
+		if err := json.Unmarshal(msg.Payload, &p); err != nil {
+			return nil, apperror.InvalidInput("payload", err.Error()), nil
+		}
+		subscriptionID, err := c.ids.Decode(p.SubscriptionID)
+		if err != nil {
+			return nil, apperror.InvalidInput("subscription_id", "is not a valid subscription ID"), nil
+		}
+		userID, appErr := parseUserID(p.UserID)
+		if appErr != nil {
+			return nil, appErr, nil
+		}
+		result, err := c.commands.CancelSubscription(ctx, msg.CommandID, subscriptionID, userID, p.EndDate)
+		return result, nil, err
+
+	default:
+		return nil, apperror.InvalidInput("type", fmt.Sprintf("must be %s or %s",
+			models.BillingCommandCreateSubscription, models.BillingCommandCancelSubscription)), nil
+	}
+}
+
+// reject отбрасывает сообщение (в dead-letter exchange очереди, если он
+// настроен) и, если известен ID команды, сообщает биллингу причину.
+func (c *Consumer) reject(ctx context.Context, pub resultPublisher, d amqp.Delivery, commandID, commandType string, cause *apperror.AppError) {
+	c.log.Warn("malformed billing command rejected",
+		zap.String("command_id", commandID),
+		zap.String("message_id", d.MessageId),
+		zap.Error(cause))
+
+	if commandID != "" {
+		if err := c.publish(ctx, pub, d, rejectedMessage(commandID, commandType, cause)); err != nil {
+			c.log.Warn("failed to publish billing command rejection",
+				zap.String("command_id", commandID),
+				zap.Error(err))
+		}
+	}
+	if err := d.Reject(false); err != nil {
+		c.log.Warn("failed to reject billing command", zap.Error(err))
+	}
+}
+
+func (c *Consumer) nack(d amqp.Delivery, requeue bool) {
+	if err := d.Nack(false, requeue); err != nil {
+		c.log.Warn("failed to nack billing command", zap.Error(err))
+	}
+}
+
+// publish отправляет итог на reply_to или в ResultExchange.
+func (c *Consumer) publish(ctx context.Context, pub resultPublisher, d amqp.Delivery, result ResultMessage) error {
+	exchange, key := c.cfg.ResultExchange, c.cfg.ResultRoutingKey
+	if d.ReplyTo != "" {
+		exchange, key = "", d.ReplyTo
+	}
+	if exchange == "" && key == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("encode result: %w", err)
+	}
+
+	return pub.Publish(ctx, exchange, key, amqp.Publishing{
+		ContentType:   "application/json",
+		DeliveryMode:  amqp.Persistent,
+		MessageId:     uuid.NewString(),
+		CorrelationId: result.CommandID,
+		Type:          ResultMessageType,
+		Timestamp:     result.ProcessedAt,
+		Body:          body,
+	})
+}
+
+// resultPublisher публикует итог команды и возвращается, когда брокер
+// его принял.
+type resultPublisher interface {
+	Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) error
+}
+
+// confirmedChannel — канал consumer'а в режиме publisher confirms.
+type confirmedChannel struct {
+	ch *amqp.Channel
+}
+
+func (p confirmedChannel) Publish(ctx context.Context, exchange, key string, msg amqp.Publishing) error {
+	confirmation, err := p.ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+	if err != nil {
+		return fmt.Errorf("publish result: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("wait for confirm: %w", err)
+	}
+	if !acked {
+		return errors.New("result was nacked by the broker")
+	}
+	return nil
+}
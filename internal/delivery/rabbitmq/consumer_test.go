@@ -0,0 +1,149 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+// publishedResults запоминает опубликованные итоги.
+type publishedResults struct {
+	keys     []string
+	messages []ResultMessage
+}
+
+func (p *publishedResults) Publish(_ context.Context, _, key string, msg amqp.Publishing) error {
+	var result ResultMessage
+	if err := json.Unmarshal(msg.Body, &result); err != nil {
+		return err
+	}
+	p.keys = append(p.keys, key)
+	p.messages = append(p.messages, result)
+	return nil
+}
+
+// acks считает, чем закончилась каждая доставка.
+type acks struct {
+	acked, nacked, rejected int
+}
+
+func (a *acks) Ack(uint64, bool) error {
+	a.acked++
+	return nil
+}
+
+func (a *acks) Nack(uint64, bool, bool) error {
+	a.nacked++
+	return nil
+}
+
+func (a *acks) Reject(uint64, bool) error {
+	a.rejected++
+	return nil
+}
+
+func TestConsumerRedeliveredCommandAppliedOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	log := testutil.NewLogger(t)
+
+	// Репозиторий итогов в памяти: повторная доставка находит итог первой.
+	stored := map[string]*models.BillingCommandResult{}
+	commands := mocks.NewMockBillingCommandRepository(ctrl)
+	commands.EXPECT().Get(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, commandID string) (*models.BillingCommandResult, error) {
+			r, ok := stored[commandID]
+			if !ok {
+				return nil, nil
+			}
+			return models.RestoreBillingCommandResult(r.CommandID(), r.CommandType(), r.Status(),
+				r.SubscriptionID(), r.ErrorCode(), r.ErrorMessage(), r.ProcessedAt()), nil
+		}).AnyTimes()
+	commands.EXPECT().Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, result *models.BillingCommandResult) (bool, error) {
+			if _, ok := stored[result.CommandID()]; ok {
+				return false, nil
+			}
+			stored[result.CommandID()] = result
+			return true, nil
+		}).AnyTimes()
+
+	tx := mocks.NewMockTransactor(ctrl)
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	userID := uuid.New()
+	subscription := models.NewSubscription("Netflix", models.Rubles(799), userID, time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC))
+	subscription.SetID(uuid.New())
+
+	subscriptions := mocks.NewMockSubscriptionService(ctrl)
+	subscriptions.EXPECT().CreateSubscription(gomock.Any(), "Netflix", 799, userID, "07-2025",
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(subscription, nil).Times(1)
+
+	ids, err := publicid.NewCodec(publicid.Config{Mode: publicid.ModeUUID})
+	if err != nil {
+		t.Fatalf("codec: %v", err)
+	}
+	consumer := NewConsumer(Config{HandleTimeout: time.Second},
+		service.NewBillingCommandService(subscriptions, commands, tx, log), ids, log)
+
+	payload, _ := json.Marshal(CreateSubscriptionPayload{
+		ServiceName: "Netflix",
+		Price:       799,
+		UserID:      userID.String(),
+		StartDate:   "07-2025",
+	})
+	body, _ := json.Marshal(CommandMessage{
+		CommandID: "billing-42",
+		Type:      models.BillingCommandCreateSubscription,
+		Payload:   payload,
+	})
+
+	published := &publishedResults{}
+	ack := &acks{}
+	for range 2 {
+		consumer.handle(published, amqp.Delivery{
+			Acknowledger: ack,
+			ReplyTo:      "billing.results",
+			Body:         body,
+		})
+	}
+
+	if ack.acked != 2 || ack.nacked != 0 || ack.rejected != 0 {
+		t.Fatalf("acks = %+v, want both deliveries acked", *ack)
+	}
+	if len(published.messages) != 2 {
+		t.Fatalf("published %d results, want one per delivery", len(published.messages))
+	}
+
+	first, second := published.messages[0], published.messages[1]
+	if first.Replayed || !second.Replayed {
+		t.Errorf("replayed = %v, %v; want only the redelivery marked", first.Replayed, second.Replayed)
+	}
+	first.Replayed, second.Replayed = false, false
+	if first != second {
+		t.Errorf("redelivery published %+v, want the stored result %+v", second, first)
+	}
+	if first.Status != string(models.BillingCommandSucceeded) || first.SubscriptionID != ids.Encode(subscription.ID()) {
+		t.Errorf("result = %+v, want succeeded for subscription %s", first, subscription.ID())
+	}
+	for _, key := range published.keys {
+		if key != "billing.results" {
+			t.Errorf("published to %q, want reply_to", key)
+		}
+	}
+}
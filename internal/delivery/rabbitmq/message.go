@@ -0,0 +1,100 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+// ResultMessageType — AMQP type сообщений с итогом команды.
+const ResultMessageType = "billing.command_result"
+
+// CommandMessage — конверт команды. command_id может отсутствовать, если
+// биллинг задаёт message_id свойствами AMQP.
+type CommandMessage struct {
+	CommandID string          `json:"command_id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// CreateSubscriptionPayload — те же поля, что у POST /subscriptions; вместо
+// service_name и price можно передать plan_id.
+type CreateSubscriptionPayload struct {
+	ServiceName string  `json:"service_name"`
+	Price       int     `json:"price"`
+	UserID      string  `json:"user_id"`
+	StartDate   string  `json:"start_date"`
+	EndDate     *string `json:"end_date,omitempty"`
+	PlanID      string  `json:"plan_id,omitempty"`
+}
+
+// CancelSubscriptionPayload — subscription_id в том виде, в каком его отдаёт
+// API (публичный ID); end_date по умолчанию — текущий месяц.
+type CancelSubscriptionPayload struct {
+	SubscriptionID string  `json:"subscription_id"`
+	UserID         string  `json:"user_id"`
+	EndDate        *string `json:"end_date,omitempty"`
+}
+
+// ResultMessage — итог команды. replayed = true, если команда пришла
+// повторно и итог взят из сохранённого.
+type ResultMessage struct {
+	CommandID      string       `json:"command_id"`
+	Type           string       `json:"type"`
+	Status         string       `json:"status"`
+	SubscriptionID string       `json:"subscription_id,omitempty"`
+	Error          *ResultError `json:"error,omitempty"`
+	Replayed       bool         `json:"replayed"`
+	ProcessedAt    time.Time    `json:"processed_at"`
+}
+
+type ResultError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func resultMessage(result *models.BillingCommandResult, ids publicid.Codec) ResultMessage {
+	msg := ResultMessage{
+		CommandID:   result.CommandID(),
+		Type:        result.CommandType(),
+		Status:      string(result.Status()),
+		Replayed:    result.Replayed(),
+		ProcessedAt: result.ProcessedAt().UTC(),
+	}
+	if id := result.SubscriptionID(); id != nil {
+		msg.SubscriptionID = ids.Encode(*id)
+	}
+	if result.Status() == models.BillingCommandFailed {
+		msg.Error = &ResultError{Code: result.ErrorCode(), Message: result.ErrorMessage()}
+	}
+	return msg
+}
+
+// rejectedMessage — ответ на команду, которую не удалось разобрать; такой
+// итог не сохраняется, команда до сервиса не доходит.
+func rejectedMessage(commandID, commandType string, err *apperror.AppError) ResultMessage {
+	message := err.Message()
+	if reason := err.Details()["reason"]; reason != "" {
+		message += ": " + reason
+	}
+	return ResultMessage{
+		CommandID:   commandID,
+		Type:        commandType,
+		Status:      string(models.BillingCommandFailed),
+		Error:       &ResultError{Code: err.Code(), Message: message},
+		ProcessedAt: time.Now().UTC(),
+	}
+}
+
+func parseUserID(value string) (uuid.UUID, *apperror.AppError) {
+	id, err := uuid.Parse(value)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, apperror.InvalidUserID(value)
+	}
+	return id, nil
+}
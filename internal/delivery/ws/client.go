@@ -0,0 +1,208 @@
+package ws
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+const (
+	writeWait = 10 * time.Second
+	// maxMessageSize — команды клиента короткие, большее сообщение закрывает соединение.
+	maxMessageSize = 4096
+)
+
+// Client — одно соединение пользователя. По умолчанию подписан только на
+// события подписок; cost_alerts включается командой subscribe с порогом.
+type Client struct {
+	hub    *Hub
+	conn   *websocket.Conn
+	userID uuid.UUID
+	// ids — кодек публичных идентификаторов клиента, выбран по арендатору.
+	ids  publicid.Codec
+	send chan []byte
+
+	mu        sync.Mutex
+	topics    map[string]bool
+	threshold int
+	// alerted — предупреждение о текущем превышении порога уже отправлено.
+	alerted bool
+}
+
+func newClient(hub *Hub, userID uuid.UUID, ids publicid.Codec) *Client {
+	return &Client{
+		hub:    hub,
+		userID: userID,
+		ids:    ids,
+		send:   make(chan []byte, hub.cfg.SendBuffer),
+		topics: map[string]bool{TopicSubscriptions: true},
+	}
+}
+
+func (c *Client) wants(topic string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.topics[topic]
+}
+
+func (c *Client) costThreshold() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.threshold
+}
+
+// observeSpend запоминает, превышен ли порог, и возвращает crossed = true
+// только при переходе через него.
+func (c *Client) observeSpend(spend int) (threshold int, crossed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.threshold <= 0 || spend <= c.threshold {
+		c.alerted = false
+		return c.threshold, false
+	}
+	if c.alerted {
+		return c.threshold, false
+	}
+	c.alerted = true
+	return c.threshold, true
+}
+
+// readPump читает команды клиента и отвечает на pong; при ошибке чтения
+// (клиент ушёл, истёк таймаут pong) отключает клиента.
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		c.conn.Close()
+		c.hub.conns.Done()
+	}()
+
+	pongWait := c.hub.cfg.PingInterval * 2
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseNoStatusReceived) {
+				c.hub.log.Debug("websocket read failed", zap.Error(err))
+			}
+			return
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.reject(apperror.InvalidInput("message", "must be a JSON object"))
+			continue
+		}
+		c.handle(msg)
+	}
+}
+
+func (c *Client) handle(msg ClientMessage) {
+	for _, topic := range msg.Topics {
+		if topic != TopicSubscriptions && topic != TopicCostAlerts {
+			c.reject(apperror.InvalidInput("topics", "unknown topic "+topic))
+			return
+		}
+	}
+	if msg.CostThreshold != nil && *msg.CostThreshold < 0 {
+		c.reject(apperror.InvalidInput("cost_threshold", "must not be negative"))
+		return
+	}
+
+	c.mu.Lock()
+	switch msg.Action {
+	case ActionSubscribe:
+		for _, topic := range msg.Topics {
+			c.topics[topic] = true
+		}
+		if msg.CostThreshold != nil {
+			c.threshold = *msg.CostThreshold
+			c.alerted = false
+		}
+	case ActionUnsubscribe:
+		for _, topic := range msg.Topics {
+			delete(c.topics, topic)
+		}
+	default:
+		c.mu.Unlock()
+		c.reject(apperror.InvalidInput("action", "must be subscribe or unsubscribe"))
+		return
+	}
+	confirmation := c.subscribedLocked()
+	checkNow := c.topics[TopicCostAlerts] && c.threshold > 0
+	c.mu.Unlock()
+
+	c.hub.send(c, Message{Type: MessageSubscribed, OccurredAt: time.Now().UTC(), Data: confirmation})
+
+	// Порог проверяется сразу, чтобы клиент узнал о превышении, не дожидаясь
+	// следующего изменения подписок.
+	if checkNow {
+		c.hub.enqueue(job{userID: c.userID})
+	}
+}
+
+func (c *Client) subscribedLocked() SubscribedData {
+	topics := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	return SubscribedData{
+		UserID:        c.userID.String(),
+		Topics:        topics,
+		CostThreshold: c.threshold,
+	}
+}
+
+func (c *Client) reject(err *apperror.AppError) {
+	c.hub.send(c, Message{
+		Type:       MessageError,
+		OccurredAt: time.Now().UTC(),
+		Data:       ErrorData{Code: err.Code(), Message: err.Message() + ": " + err.Details()["reason"]},
+	})
+}
+
+// writePump — единственный писатель в соединение: сообщения из send и
+// ping раз в PingInterval. Закрытый send означает отключение хабом.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(c.hub.cfg.PingInterval)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+		c.hub.conns.Done()
+	}()
+
+	for {
+		select {
+		case payload, ok := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				_ = c.conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
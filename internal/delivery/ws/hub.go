@@ -0,0 +1,359 @@
+// Package ws — канал живых обновлений по WebSocket: клиент получает события
+// своих подписок и предупреждения о превышении порога месячных трат.
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+const (
+	defaultSendBuffer = 32
+	queueSize         = 256
+	spendTimeout      = 5 * time.Second
+)
+
+// SpendReader — источник текущих месячных трат пользователя для порогов.
+type SpendReader interface {
+	GetUserSubscriptionStats(ctx context.Context, userID uuid.UUID) (*models.UserSubscriptionStats, error)
+}
+
+type Config struct {
+	// MaxConnections — лимит соединений на процесс; 0 — без лимита.
+	MaxConnections int
+	// MaxConnectionsPerUser — лимит соединений одного пользователя; 0 — без лимита.
+	MaxConnectionsPerUser int
+	PingInterval          time.Duration
+	// SendBuffer — очередь исходящих сообщений клиента; переполнение
+	// означает, что клиент не успевает читать, и соединение закрывается.
+	SendBuffer int
+}
+
+// job — событие для рассылки или, если event == nil, только проверка
+// порога трат userID.
+type job struct {
+	event  models.DomainEvent
+	userID uuid.UUID
+}
+
+/*
+Hub держит соединения клиентов и раздаёт им события. Publish не блокирует
+поток запроса: события уходят в очередь, рассылку и проверку порогов
+делает одна горутина, запущенная Start.
+*/
+type Hub struct {
+	cfg      Config
+	spend    SpendReader
+	ids      *publicid.Registry
+	upgrader websocket.Upgrader
+	log      *logger.Logger
+
+	mu      sync.Mutex
+	clients map[*Client]struct{}
+	byUser  map[uuid.UUID]map[*Client]struct{}
+	closed  bool
+
+	queue    chan job
+	stop     chan struct{}
+	stopOnce sync.Once
+	loop     sync.WaitGroup
+	conns    sync.WaitGroup
+}
+
+func NewHub(cfg Config, spend SpendReader, ids *publicid.Registry, log *logger.Logger) *Hub {
+	if cfg.SendBuffer <= 0 {
+		cfg.SendBuffer = defaultSendBuffer
+	}
+	if cfg.PingInterval <= 0 {
+		cfg.PingInterval = 30 * time.Second
+	}
+
+	return &Hub{
+		cfg:   cfg,
+		spend: spend,
+		ids:   ids,
+		upgrader: websocket.Upgrader{
+			// Клиент аутентифицируется ключом или токеном, а не cookie, поэтому
+			// чужой сайт не может открыть соединение от имени пользователя.
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+		log:     log.Named("ws-hub"),
+		clients: make(map[*Client]struct{}),
+		byUser:  make(map[uuid.UUID]map[*Client]struct{}),
+		queue:   make(chan job, queueSize),
+		stop:    make(chan struct{}),
+	}
+}
+
+func (h *Hub) Start() {
+	h.loop.Add(1)
+	go func() {
+		defer h.loop.Done()
+		for {
+			select {
+			case <-h.stop:
+				return
+			case j := <-h.queue:
+				h.dispatch(j)
+			}
+		}
+	}()
+}
+
+/*
+Stop закрывает все соединения кадром close (going away) и ждёт их
+завершения: после hijack сервер не отслеживает эти соединения, и без этого
+graceful shutdown оборвал бы их молча. Новые соединения после Stop
+отклоняются.
+*/
+func (h *Hub) Stop() {
+	h.stopOnce.Do(func() {
+		close(h.stop)
+
+		h.mu.Lock()
+		h.closed = true
+		for client := range h.clients {
+			h.removeLocked(client)
+		}
+		h.mu.Unlock()
+
+		h.loop.Wait()
+		h.conns.Wait()
+		h.log.Info("websocket hub stopped")
+	})
+}
+
+// Publish ставит событие в очередь рассылки; это обработчик подписки хаба
+// на шину событий. При переполненной очереди событие отбрасывается.
+func (h *Hub) Publish(_ context.Context, evt models.DomainEvent) {
+	h.enqueue(job{event: evt, userID: evt.UserID()})
+}
+
+func (h *Hub) enqueue(j job) {
+	select {
+	case h.queue <- j:
+	default:
+		h.log.Warn("websocket queue is full, update dropped",
+			zap.String("user_id", j.userID.String()))
+	}
+}
+
+// Connections — число открытых соединений.
+func (h *Hub) Connections() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+/*
+ServeWS переводит запрос на WebSocket и подключает клиента к событиям
+userID. Ошибка возвращается, только если соединение отклонено до апгрейда
+(лимиты, остановка) — ответ на неё пишет вызывающий. Ошибку самого апгрейда
+upgrader уже отправил клиенту. tenant выбирает кодек публичных
+идентификаторов клиента.
+*/
+func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request, userID uuid.UUID, tenant string) error {
+	client := newClient(h, userID, h.ids.For(tenant))
+	if err := h.register(client); err != nil {
+		return err
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.unregister(client)
+		h.conns.Add(-2)
+		h.log.Debug("websocket upgrade failed", zap.Error(err))
+		return nil
+	}
+
+	client.conn = conn
+	go client.writePump()
+	go client.readPump()
+
+	h.log.Debug("websocket client connected", zap.String("user_id", userID.String()))
+	return nil
+}
+
+func (h *Hub) register(client *Client) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return apperror.ServiceUnavailable("websocket", fmt.Errorf("server is shutting down"))
+	}
+	if h.cfg.MaxConnections > 0 && len(h.clients) >= h.cfg.MaxConnections {
+		return apperror.TooManyRequests(fmt.Sprintf("websocket connection limit of %d reached", h.cfg.MaxConnections))
+	}
+	userClients := h.byUser[client.userID]
+	if h.cfg.MaxConnectionsPerUser > 0 && len(userClients) >= h.cfg.MaxConnectionsPerUser {
+		return apperror.TooManyRequests(fmt.Sprintf("user already has %d websocket connections", h.cfg.MaxConnectionsPerUser))
+	}
+
+	if userClients == nil {
+		userClients = make(map[*Client]struct{})
+		h.byUser[client.userID] = userClients
+	}
+	userClients[client] = struct{}{}
+	h.clients[client] = struct{}{}
+	// Горутины клиента учитываются под мьютексом, чтобы Stop не начал
+	// ждать раньше, чем их добавят.
+	h.conns.Add(2)
+	return nil
+}
+
+func (h *Hub) unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(client)
+}
+
+// removeLocked отключает клиента; закрытие send завершает writePump,
+// который отправит кадр close. Повторный вызов ничего не делает.
+func (h *Hub) removeLocked(client *Client) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	delete(h.clients, client)
+	if userClients := h.byUser[client.userID]; userClients != nil {
+		delete(userClients, client)
+		if len(userClients) == 0 {
+			delete(h.byUser, client.userID)
+		}
+	}
+	close(client.send)
+}
+
+// userClients — снимок соединений пользователя, чтобы не держать мьютекс
+// во время проверки трат.
+func (h *Hub) userClients(userID uuid.UUID) []*Client {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	clients := make([]*Client, 0, len(h.byUser[userID]))
+	for client := range h.byUser[userID] {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+func (h *Hub) dispatch(j job) {
+	clients := h.userClients(j.userID)
+	if len(clients) == 0 {
+		return
+	}
+
+	if j.event != nil {
+		topic := eventTopic(j.event)
+		// Клиенты одного пользователя могут видеть разные публичные
+		// идентификаторы; сообщение кодируется один раз на кодек.
+		payloads := make(map[publicid.Codec][]byte)
+		for _, client := range clients {
+			if !client.wants(topic) {
+				continue
+			}
+			payload, ok := payloads[client.ids]
+			if !ok {
+				var err error
+				if payload, err = h.encodeEvent(j.event, client.ids); err != nil {
+					h.log.Error("failed to encode websocket event", zap.Error(err))
+					return
+				}
+				payloads[client.ids] = payload
+			}
+			h.deliver(client, payload)
+		}
+	}
+
+	h.checkThresholds(j.userID, clients)
+}
+
+func (h *Hub) encodeEvent(event models.DomainEvent, ids publicid.Codec) ([]byte, error) {
+	msg, err := eventMessage(event, ids)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(msg)
+}
+
+// checkThresholds сообщает о превышении порога один раз: повторно клиент
+// получит предупреждение, только если траты опустились до порога и снова
+// его превысили.
+func (h *Hub) checkThresholds(userID uuid.UUID, clients []*Client) {
+	watching := clients[:0:0]
+	for _, client := range clients {
+		if client.wants(TopicCostAlerts) && client.costThreshold() > 0 {
+			watching = append(watching, client)
+		}
+	}
+	if len(watching) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), spendTimeout)
+	defer cancel()
+
+	stats, err := h.spend.GetUserSubscriptionStats(ctx, userID)
+	if err != nil {
+		h.log.Warn("failed to read monthly spend for cost alerts",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return
+	}
+
+	spend := stats.MonthlySpend()
+	for _, client := range watching {
+		threshold, crossed := client.observeSpend(spend)
+		if !crossed {
+			continue
+		}
+		h.send(client, Message{
+			Type:       MessageCostThresholdReached,
+			OccurredAt: time.Now().UTC(),
+			Data: CostAlertData{
+				UserID:       userID.String(),
+				MonthlySpend: spend,
+				Threshold:    threshold,
+			},
+		})
+	}
+}
+
+func (h *Hub) send(client *Client, msg Message) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		h.log.Error("failed to encode websocket message", zap.Error(err))
+		return
+	}
+	h.deliver(client, payload)
+}
+
+// deliver не ждёт медленного клиента: если его очередь заполнена, он
+// отключается — пропуск событий без уведомления хуже разрыва.
+func (h *Hub) deliver(client *Client, payload []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	select {
+	case client.send <- payload:
+	default:
+		h.log.Warn("websocket client is too slow, disconnecting",
+			zap.String("user_id", client.userID.String()))
+		h.removeLocked(client)
+	}
+}
@@ -0,0 +1,122 @@
+package ws
+
+import (
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/mappers"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// Темы, на которые клиент подписывается сообщением subscribe.
+const (
+	TopicSubscriptions = "subscriptions"
+	TopicCostAlerts    = "cost_alerts"
+)
+
+// Действия клиента.
+const (
+	ActionSubscribe   = "subscribe"
+	ActionUnsubscribe = "unsubscribe"
+)
+
+// Типы сообщений сервера, кроме событий подписок: те передаются с типом
+// доменного события (subscription.created и т.д.).
+const (
+	MessageSubscribed           = "subscribed"
+	MessageCostThresholdReached = "cost.threshold_exceeded"
+	MessageError                = "error"
+)
+
+// ClientMessage — команда клиента. cost_threshold (месячные траты в рублях)
+// задаётся вместе с темой cost_alerts; 0 снимает порог.
+type ClientMessage struct {
+	Action        string   `json:"action"`
+	Topics        []string `json:"topics"`
+	CostThreshold *int     `json:"cost_threshold,omitempty"`
+}
+
+// Message — сообщение сервера клиенту.
+type Message struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data,omitempty"`
+}
+
+// SubscribedData подтверждает текущие темы клиента после subscribe/unsubscribe.
+type SubscribedData struct {
+	UserID        string   `json:"user_id"`
+	Topics        []string `json:"topics"`
+	CostThreshold int      `json:"cost_threshold,omitempty"`
+}
+
+// BulkDeletedData — тело агрегированного события удаления подписок.
+type BulkDeletedData struct {
+	UserID          string   `json:"user_id"`
+	SubscriptionIDs []string `json:"subscription_ids"`
+}
+
+// CostAlertData — месячные траты пользователя превысили порог клиента.
+type CostAlertData struct {
+	UserID       string `json:"user_id"`
+	MonthlySpend int    `json:"monthly_spend"`
+	Threshold    int    `json:"threshold"`
+}
+
+// CostAlertExceededData — сработал сохранённый порог пользователя
+// (POST /users/{id}/alerts), в отличие от CostAlertData с порогом клиента.
+type CostAlertExceededData struct {
+	AlertID      string `json:"alert_id"`
+	UserID       string `json:"user_id"`
+	MonthlyLimit int    `json:"monthly_limit"`
+	Spent        int    `json:"spent"`
+	Month        string `json:"month"`
+}
+
+// ErrorData — ошибка обработки команды клиента; соединение остаётся открытым.
+type ErrorData struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// eventTopic — тема, подписчикам которой уходит событие.
+func eventTopic(evt models.DomainEvent) string {
+	if _, ok := evt.(models.CostAlertExceeded); ok {
+		return TopicCostAlerts
+	}
+	return TopicSubscriptions
+}
+
+// eventMessage переводит доменное событие в сообщение. Подписка отдаётся в
+// формате API v2, даты — ISO 8601, идентификаторы подписок — через ids.
+func eventMessage(evt models.DomainEvent, ids publicid.Codec) (Message, error) {
+	msg := Message{Type: evt.Type(), OccurredAt: evt.OccurredAt().UTC()}
+
+	switch e := evt.(type) {
+	case models.SubscriptionsBulkDeleted:
+		encoded := make([]string, 0, len(e.SubscriptionIDs()))
+		for _, id := range e.SubscriptionIDs() {
+			encoded = append(encoded, ids.Encode(id))
+		}
+		msg.Data = BulkDeletedData{UserID: e.UserID().String(), SubscriptionIDs: encoded}
+	case models.CostAlertExceeded:
+		status := e.CostAlert()
+		msg.Data = CostAlertExceededData{
+			AlertID:      status.Alert().ID().String(),
+			UserID:       e.UserID().String(),
+			MonthlyLimit: status.Alert().MonthlyLimit(),
+			Spent:        status.Spent(),
+			Month:        utils.FormatISOMonth(status.Month()),
+		}
+	case interface{ Subscription() *models.Subscription }:
+		if sub := e.Subscription(); sub != nil {
+			data, err := mappers.SubscriptionToV2Response(sub, utils.DateFormatISO, ids)
+			if err != nil {
+				return Message{}, err
+			}
+			msg.Data = data
+		}
+	}
+	return msg, nil
+}
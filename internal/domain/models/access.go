@@ -0,0 +1,178 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+/** Роль клиента API. */
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleOperator Role = "operator"
+	RoleViewer   Role = "viewer"
+)
+
+/** Роли от самой широкой к самой узкой. */
+var Roles = []Role{RoleAdmin, RoleOperator, RoleViewer}
+
+/** Разрешение на группу операций. */
+type Permission string
+
+const (
+	PermissionSubscriptionsRead  Permission = "subscriptions:read"
+	PermissionSubscriptionsWrite Permission = "subscriptions:write"
+	PermissionPlansWrite         Permission = "plans:write"
+	PermissionReportsRead        Permission = "reports:read"
+	PermissionAdminRead          Permission = "admin:read"
+	PermissionAdminWrite         Permission = "admin:write"
+)
+
+/*
+rolePermissions — что разрешено каждой роли:
+- viewer читает подписки, тарифы и отчёты;
+- operator вдобавок меняет подписки и тарифы и смотрит админские данные;
+- admin может всё, включая правила, скидки, dead letters и API-ключи.
+*/
+var rolePermissions = map[Role][]Permission{
+	RoleViewer: {
+		PermissionSubscriptionsRead,
+		PermissionReportsRead,
+	},
+	RoleOperator: {
+		PermissionSubscriptionsRead,
+		PermissionSubscriptionsWrite,
+		PermissionPlansWrite,
+		PermissionReportsRead,
+		PermissionAdminRead,
+	},
+	RoleAdmin: {
+		PermissionSubscriptionsRead,
+		PermissionSubscriptionsWrite,
+		PermissionPlansWrite,
+		PermissionReportsRead,
+		PermissionAdminRead,
+		PermissionAdminWrite,
+	},
+}
+
+/** Разбирает роль. */
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", fmt.Errorf("role must be one of %q, %q or %q", RoleAdmin, RoleOperator, RoleViewer)
+	}
+	return role, nil
+}
+
+/** Разрешения роли в алфавитном порядке. */
+func (r Role) Permissions() []Permission {
+	permissions := append([]Permission(nil), rolePermissions[r]...)
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i] < permissions[j] })
+	return permissions
+}
+
+/** Есть ли у роли разрешение. */
+func (r Role) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+/** Откуда взята роль клиента. */
+const (
+	PrincipalSourceJWT      = "jwt"
+	PrincipalSourceAPIKey   = "api_key"
+	PrincipalSourceAdminKey = "admin_key"
+)
+
+/*
+Principal — аутентифицированный клиент: subject из JWT или ID API-ключа,
+роль и источник, из которого она получена.
+*/
+type Principal struct {
+	subject string
+	role    Role
+	source  string
+}
+
+/** Конструктор. */
+func NewPrincipal(subject string, role Role, source string) *Principal {
+	return &Principal{
+		subject: subject,
+		role:    role,
+		source:  source,
+	}
+}
+
+/** Геттер для subject. */
+func (p *Principal) Subject() string {
+	return p.subject
+}
+
+/** Геттер для роли. */
+func (p *Principal) Role() Role {
+	return p.role
+}
+
+/** Геттер для источника роли (PrincipalSource*). */
+func (p *Principal) Source() string {
+	return p.source
+}
+
+/** Есть ли у клиента разрешение. */
+func (p *Principal) Can(permission Permission) bool {
+	return p.role.Can(permission)
+}
+
+/*
+Пользователь, от имени которого действует клиент: subject JWT, если это
+UUID. У ключей API и токенов с другим subject своего пользователя нет — nil.
+*/
+func (p *Principal) UserID() *uuid.UUID {
+	if p.source != PrincipalSourceJWT {
+		return nil
+	}
+	userID, err := uuid.Parse(p.subject)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
+
+/*
+Можно ли клиенту работать с данными пользователя userID. Пользователь из
+JWT видит только свои подписки, администратор — все; ключи API ограничивает
+только роль. JWT с subject не в виде UUID не принадлежит ни одному
+пользователю, поэтому без роли администратора доступа не даёт.
+*/
+func (p *Principal) CanAccessUser(userID uuid.UUID) bool {
+	if p.role == RoleAdmin {
+		return true
+	}
+	if p.source != PrincipalSourceJWT {
+		return true
+	}
+	own := p.UserID()
+	return own != nil && *own == userID
+}
+
+type principalKey struct{}
+
+/** Кладёт аутентифицированного клиента в контекст запроса. */
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+/** Клиент из контекста; nil — аутентификация выключена или вызов внутренний. */
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPrincipal_CanAccessUser(t *testing.T) {
+	owner := uuid.New()
+	other := uuid.New()
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		userID    uuid.UUID
+		want      bool
+	}{
+		{name: "own subscriptions", principal: NewPrincipal(owner.String(), RoleOperator, PrincipalSourceJWT), userID: owner, want: true},
+		{name: "another user", principal: NewPrincipal(owner.String(), RoleOperator, PrincipalSourceJWT), userID: other, want: false},
+		{name: "admin token", principal: NewPrincipal(owner.String(), RoleAdmin, PrincipalSourceJWT), userID: other, want: true},
+		{name: "non-UUID subject", principal: NewPrincipal("billing-worker", RoleOperator, PrincipalSourceJWT), userID: owner, want: false},
+		{name: "non-UUID subject viewer", principal: NewPrincipal("", RoleViewer, PrincipalSourceJWT), userID: owner, want: false},
+		{name: "non-UUID admin", principal: NewPrincipal("ops", RoleAdmin, PrincipalSourceJWT), userID: owner, want: true},
+		{name: "api key", principal: NewPrincipal(uuid.NewString(), RoleViewer, PrincipalSourceAPIKey), userID: owner, want: true},
+		{name: "admin key", principal: NewPrincipal("admin-key", RoleAdmin, PrincipalSourceAdminKey), userID: owner, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.principal.CanAccessUser(tt.userID); got != tt.want {
+				t.Errorf("CanAccessUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// APIKeyPrefix отличает ключи сервиса от прочих секретов в логах и конфигах.
+	APIKeyPrefix = "sk_"
+	// apiKeyDisplayLength — сколько первых символов ключа хранится открыто,
+	// чтобы ключ можно было узнать в списке.
+	apiKeyDisplayLength = 10
+
+	MaxAPIKeyNameLength = 100
+)
+
+/*
+APIKey — ключ доступа к API с ролью. Сам ключ показывается один раз при
+создании, в БД хранится только его SHA-256 и первые символы.
+*/
+type APIKey struct {
+	id        uuid.UUID
+	name      string
+	prefix    string
+	keyHash   string
+	role      Role
+	createdAt time.Time
+	revokedAt *time.Time
+}
+
+/** Создаёт ключ и возвращает его вместе с открытым значением. */
+func NewAPIKey(name string, role Role) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("name cannot be empty")
+	}
+	if len([]rune(name)) > MaxAPIKeyNameLength {
+		return nil, "", fmt.Errorf("name must be at most %d characters", MaxAPIKeyNameLength)
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", fmt.Errorf("generate api key: %w", err)
+	}
+	secret := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	return &APIKey{
+		id:        uuid.New(),
+		name:      name,
+		prefix:    secret[:apiKeyDisplayLength],
+		keyHash:   HashAPIKey(secret),
+		role:      role,
+		createdAt: time.Now().UTC(),
+	}, secret, nil
+}
+
+/** Восстанавливает ключ из БД. */
+func RestoreAPIKey(id uuid.UUID, name, prefix, keyHash string, role Role, createdAt time.Time, revokedAt *time.Time) *APIKey {
+	return &APIKey{
+		id:        id,
+		name:      name,
+		prefix:    prefix,
+		keyHash:   keyHash,
+		role:      role,
+		createdAt: createdAt,
+		revokedAt: revokedAt,
+	}
+}
+
+/** SHA-256 ключа в hex — по нему ключ ищется в БД. */
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+/** Геттер для ID. */
+func (k *APIKey) ID() uuid.UUID {
+	return k.id
+}
+
+/** Геттер для названия. */
+func (k *APIKey) Name() string {
+	return k.name
+}
+
+/** Первые символы ключа. */
+func (k *APIKey) Prefix() string {
+	return k.prefix
+}
+
+/** Геттер для хеша ключа. */
+func (k *APIKey) KeyHash() string {
+	return k.keyHash
+}
+
+/** Геттер для роли. */
+func (k *APIKey) Role() Role {
+	return k.role
+}
+
+/** Геттер для даты создания. */
+func (k *APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+/** Дата отзыва; nil — ключ действует. */
+func (k *APIKey) RevokedAt() *time.Time {
+	return k.revokedAt
+}
+
+/** Отозван ли ключ. */
+func (k *APIKey) IsRevoked() bool {
+	return k.revokedAt != nil
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** ArtifactKind — назначение файла в хранилище; оно же первый сегмент его ключа. */
+type ArtifactKind string
+
+const (
+	// ArtifactExport — CSV-выгрузка подписок.
+	ArtifactExport ArtifactKind = "exports"
+)
+
+/** Все виды файлов; очистка хранилища обходит их префиксы. */
+var ArtifactKinds = []ArtifactKind{ArtifactExport}
+
+/*
+Artifact — файл, выложенный в объектное хранилище: ключ, размер и
+подписанная ссылка на скачивание, действующая до expiresAt. Сам файл
+удаляется очисткой хранилища через storage.retention после загрузки.
+*/
+type Artifact struct {
+	key       string
+	size      int64
+	url       string
+	expiresAt time.Time
+}
+
+/** Конструктор. */
+func NewArtifact(key string, size int64, url string, expiresAt time.Time) *Artifact {
+	return &Artifact{
+		key:       key,
+		size:      size,
+		url:       url,
+		expiresAt: expiresAt,
+	}
+}
+
+/*
+Ключ нового файла: вид/ГГГГ/ММ/ДД/uuid/имя. Случайный сегмент не даёт
+угадать чужую выгрузку и сохраняет исходное имя для скачивания.
+*/
+func NewArtifactKey(kind ArtifactKind, now time.Time, filename string) string {
+	return path.Join(string(kind), now.UTC().Format("2006/01/02"), uuid.NewString(), path.Base(filename))
+}
+
+/** Геттер для ключа. */
+func (a *Artifact) Key() string {
+	return a.key
+}
+
+/** Геттер для размера в байтах. */
+func (a *Artifact) Size() int64 {
+	return a.size
+}
+
+/** Геттер для ссылки на скачивание. */
+func (a *Artifact) URL() string {
+	return a.url
+}
+
+/** Геттер для срока действия ссылки. */
+func (a *Artifact) ExpiresAt() time.Time {
+	return a.expiresAt
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** Типы команд legacy-биллинга. */
+const (
+	BillingCommandCreateSubscription = "create_subscription"
+	BillingCommandCancelSubscription = "cancel_subscription"
+)
+
+/** Итог обработки команды. */
+type BillingCommandStatus string
+
+const (
+	BillingCommandSucceeded BillingCommandStatus = "succeeded"
+	BillingCommandFailed    BillingCommandStatus = "failed"
+)
+
+/*
+BillingCommandResult — итог обработки команды биллинга. Хранится по ID
+команды: повторная доставка той же команды получает сохранённый итог и
+ничего не меняет.
+*/
+type BillingCommandResult struct {
+	commandID      string
+	commandType    string
+	status         BillingCommandStatus
+	subscriptionID *uuid.UUID
+	errorCode      string
+	errorMessage   string
+	processedAt    time.Time
+	replayed       bool
+}
+
+/** Успешно применённая команда. */
+func NewBillingCommandSucceeded(commandID, commandType string, subscriptionID uuid.UUID) *BillingCommandResult {
+	return &BillingCommandResult{
+		commandID:      commandID,
+		commandType:    commandType,
+		status:         BillingCommandSucceeded,
+		subscriptionID: &subscriptionID,
+		processedAt:    time.Now().UTC(),
+	}
+}
+
+/** Команда, отклонённая бизнес-правилами; повтор даст тот же отказ. */
+func NewBillingCommandFailed(commandID, commandType, errorCode, errorMessage string) *BillingCommandResult {
+	return &BillingCommandResult{
+		commandID:    commandID,
+		commandType:  commandType,
+		status:       BillingCommandFailed,
+		errorCode:    errorCode,
+		errorMessage: errorMessage,
+		processedAt:  time.Now().UTC(),
+	}
+}
+
+/** Восстанавливает итог из БД; такой итог считается повтором (Replayed). */
+func RestoreBillingCommandResult(commandID, commandType string, status BillingCommandStatus, subscriptionID *uuid.UUID, errorCode, errorMessage string, processedAt time.Time) *BillingCommandResult {
+	return &BillingCommandResult{
+		commandID:      commandID,
+		commandType:    commandType,
+		status:         status,
+		subscriptionID: subscriptionID,
+		errorCode:      errorCode,
+		errorMessage:   errorMessage,
+		processedAt:    processedAt,
+		replayed:       true,
+	}
+}
+
+/** Геттер для ID команды, присвоенного биллингом. */
+func (r *BillingCommandResult) CommandID() string {
+	return r.commandID
+}
+
+/** Геттер для типа команды (BillingCommand*). */
+func (r *BillingCommandResult) CommandType() string {
+	return r.commandType
+}
+
+/** Геттер для статуса. */
+func (r *BillingCommandResult) Status() BillingCommandStatus {
+	return r.status
+}
+
+/** Геттер для ID созданной или отменённой подписки; nil при отказе. */
+func (r *BillingCommandResult) SubscriptionID() *uuid.UUID {
+	return r.subscriptionID
+}
+
+/** Геттер для кода ошибки (apperror) при отказе. */
+func (r *BillingCommandResult) ErrorCode() string {
+	return r.errorCode
+}
+
+/** Геттер для текста ошибки при отказе. */
+func (r *BillingCommandResult) ErrorMessage() string {
+	return r.errorMessage
+}
+
+/** Геттер для времени обработки. */
+func (r *BillingCommandResult) ProcessedAt() time.Time {
+	return r.processedAt
+}
+
+/** Команда уже была обработана раньше, итог взят из БД. */
+func (r *BillingCommandResult) Replayed() bool {
+	return r.replayed
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+BillingMode — правило расчёта стоимости подписки за период.
+monthly: каждый затронутый календарный месяц оплачивается целиком.
+prorated: месяц оплачивается пропорционально оплаченным в нём дням.
+*/
+type BillingMode string
+
+const (
+	BillingMonthly  BillingMode = "monthly"
+	BillingProrated BillingMode = "prorated"
+)
+
+/** Разбирает режим без учёта регистра. */
+func ParseBillingMode(value string) (BillingMode, error) {
+	switch mode := BillingMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case BillingMonthly, BillingProrated:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("billing mode must be %q or %q, got %q", BillingMonthly, BillingProrated, value)
+	}
+}
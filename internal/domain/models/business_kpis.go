@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+/*
+BusinessKPIs — агрегированные бизнес-показатели на конкретный месяц:
+суммарные траты по активным подпискам, число пользователей
+хотя бы с одной активной подпиской и число активных подписок.
+*/
+type BusinessKPIs struct {
+	month               time.Time
+	monthlySpend        int
+	activeUsers         int
+	activeSubscriptions int
+}
+
+/** Создаёт набор показателей за месяц. */
+func NewBusinessKPIs(month time.Time, monthlySpend, activeUsers, activeSubscriptions int) *BusinessKPIs {
+	return &BusinessKPIs{
+		month:               month,
+		monthlySpend:        monthlySpend,
+		activeUsers:         activeUsers,
+		activeSubscriptions: activeSubscriptions,
+	}
+}
+
+/** Геттер для месяца, за который посчитаны показатели. */
+func (k *BusinessKPIs) Month() time.Time {
+	return k.month
+}
+
+/** Геттер для суммарных трат за месяц. */
+func (k *BusinessKPIs) MonthlySpend() int {
+	return k.monthlySpend
+}
+
+/** Геттер для числа активных пользователей. */
+func (k *BusinessKPIs) ActiveUsers() int {
+	return k.activeUsers
+}
+
+/** Геттер для числа активных подписок. */
+func (k *BusinessKPIs) ActiveSubscriptions() int {
+	return k.activeSubscriptions
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// CalendarFeedTokenPrefix отличает токены календаря от ключей API.
+	CalendarFeedTokenPrefix = "cal_"
+	// CalendarFeedMonths — сколько месяцев вперёд, начиная с текущего,
+	// попадает в ленту календаря.
+	CalendarFeedMonths = 12
+)
+
+/*
+CalendarFeedToken — токен ссылки на iCalendar-ленту пользователя. Ссылку
+открывают календари (Google, Apple) без заголовков авторизации, поэтому
+токен передаётся в query. Как и у ключей API, в БД хранится только
+SHA-256; у пользователя один токен, выпуск нового отзывает прежний.
+*/
+type CalendarFeedToken struct {
+	userID    uuid.UUID
+	tokenHash string
+	createdAt time.Time
+}
+
+/** Создаёт токен и возвращает его вместе с открытым значением. */
+func NewCalendarFeedToken(userID uuid.UUID) (*CalendarFeedToken, string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, "", fmt.Errorf("generate calendar feed token: %w", err)
+	}
+	secret := CalendarFeedTokenPrefix + base64.RawURLEncoding.EncodeToString(random)
+
+	return &CalendarFeedToken{
+		userID:    userID,
+		tokenHash: HashAPIKey(secret),
+		createdAt: time.Now().UTC(),
+	}, secret, nil
+}
+
+/** Восстанавливает токен из БД. */
+func RestoreCalendarFeedToken(userID uuid.UUID, tokenHash string, createdAt time.Time) *CalendarFeedToken {
+	return &CalendarFeedToken{
+		userID:    userID,
+		tokenHash: tokenHash,
+		createdAt: createdAt,
+	}
+}
+
+/** Геттер для владельца ленты. */
+func (t *CalendarFeedToken) UserID() uuid.UUID {
+	return t.userID
+}
+
+/** Геттер для хеша токена. */
+func (t *CalendarFeedToken) TokenHash() string {
+	return t.tokenHash
+}
+
+/** Геттер для даты выпуска. */
+func (t *CalendarFeedToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+/** Сравнивает открытое значение с токеном за постоянное время. */
+func (t *CalendarFeedToken) Matches(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(secret)), []byte(t.tokenHash)) == 1
+}
+
+/** CalendarFeedEventKind — что происходит с подпиской в день события. */
+type CalendarFeedEventKind string
+
+const (
+	CalendarFeedCharge CalendarFeedEventKind = "charge"
+	CalendarFeedEnd    CalendarFeedEventKind = "end"
+)
+
+/** CalendarFeedEvent — событие ленты: списание или окончание подписки. */
+type CalendarFeedEvent struct {
+	kind         CalendarFeedEventKind
+	date         time.Time
+	subscription *Subscription
+}
+
+/** Геттер для вида события. */
+func (e *CalendarFeedEvent) Kind() CalendarFeedEventKind {
+	return e.kind
+}
+
+/** Дата события (UTC, без времени). */
+func (e *CalendarFeedEvent) Date() time.Time {
+	return e.date
+}
+
+/** Геттер для подписки. */
+func (e *CalendarFeedEvent) Subscription() *Subscription {
+	return e.subscription
+}
+
+/*
+UID — стабильный идентификатор события: по нему календарь обновляет
+событие при следующей синхронизации, а не дублирует его.
+*/
+func (e *CalendarFeedEvent) UID() string {
+	return fmt.Sprintf("%s-%s-%s", e.subscription.ID(), e.kind, e.date.Format("20060102"))
+}
+
+/*
+CalendarFeed — события ленты календаря по месяцам прогноза: списания по
+ChargeDate и окончания подписок, по дате, затем по названию сервиса.
+*/
+type CalendarFeed struct {
+	userID uuid.UUID
+	events []*CalendarFeedEvent
+}
+
+/** Собирает ленту из месяцев прогноза трат. */
+func NewCalendarFeed(userID uuid.UUID, forecast *CostForecast) *CalendarFeed {
+	feed := &CalendarFeed{userID: userID, events: make([]*CalendarFeedEvent, 0)}
+	for _, month := range forecast.Months() {
+		for _, sub := range month.Subscriptions() {
+			if date, ok := sub.ChargeDate(month.Month()); ok {
+				feed.events = append(feed.events, &CalendarFeedEvent{kind: CalendarFeedCharge, date: date, subscription: sub})
+			}
+		}
+		for _, sub := range month.Ending() {
+			end := *sub.EndDate()
+			date := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+			feed.events = append(feed.events, &CalendarFeedEvent{kind: CalendarFeedEnd, date: date, subscription: sub})
+		}
+	}
+
+	sort.SliceStable(feed.events, func(i, j int) bool {
+		a, b := feed.events[i], feed.events[j]
+		if !a.date.Equal(b.date) {
+			return a.date.Before(b.date)
+		}
+		return a.subscription.ServiceName() < b.subscription.ServiceName()
+	})
+	return feed
+}
+
+/** Геттер для владельца ленты. */
+func (f *CalendarFeed) UserID() uuid.UUID {
+	return f.userID
+}
+
+/** События по возрастанию даты. */
+func (f *CalendarFeed) Events() []*CalendarFeedEvent {
+	return f.events
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxServiceNameAliases = 32
+
+	// MetadataOriginalServiceName — ключ метаданных, под которым сохраняется
+	// название в том виде, в каком его прислали, если словарь его заменил.
+	MetadataOriginalServiceName = "original_service_name"
+)
+
+/*
+ServiceNameKey — ключ сравнения названий в словаре: без пробелов по краям,
+с одиночными пробелами внутри и в нижнем регистре. "NETFLIX", "netflix"
+и " Netflix " дают один ключ. SQL-аналог — serviceNameKeySQL в
+репозитории; правила должны совпадать.
+*/
+func ServiceNameKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+/*
+CanonicalServiceName — запись словаря: каноническое написание сервиса и
+другие его написания. Название совпадает с записью, если его ключ равен
+ключу name или одного из aliases.
+*/
+type CanonicalServiceName struct {
+	id        uuid.UUID
+	name      string
+	aliases   []string
+	createdAt time.Time
+}
+
+/** Создаёт запись словаря с новым ID и текущим временем. */
+func NewCanonicalServiceName(name string, aliases []string) *CanonicalServiceName {
+	trimmed := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			trimmed = append(trimmed, alias)
+		}
+	}
+	return &CanonicalServiceName{
+		id:        uuid.New(),
+		name:      strings.TrimSpace(name),
+		aliases:   trimmed,
+		createdAt: time.Now(),
+	}
+}
+
+/** Восстанавливает запись словаря из БД. */
+func RestoreCanonicalServiceName(id uuid.UUID, name string, aliases []string, createdAt time.Time) *CanonicalServiceName {
+	return &CanonicalServiceName{
+		id:        id,
+		name:      name,
+		aliases:   aliases,
+		createdAt: createdAt,
+	}
+}
+
+/** Геттер для ID записи. */
+func (c *CanonicalServiceName) ID() uuid.UUID {
+	return c.id
+}
+
+/** Геттер для канонического названия. */
+func (c *CanonicalServiceName) Name() string {
+	return c.name
+}
+
+/** Геттер для других написаний. */
+func (c *CanonicalServiceName) Aliases() []string {
+	return c.aliases
+}
+
+/** Геттер для времени создания. */
+func (c *CanonicalServiceName) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+/** Ключи всех написаний записи без повторов, ключ name первый. */
+func (c *CanonicalServiceName) Keys() []string {
+	keys := []string{ServiceNameKey(c.name)}
+	for _, alias := range c.aliases {
+		key := ServiceNameKey(alias)
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+/** Проверяет название, число и длину написаний. */
+func (c *CanonicalServiceName) Validate() error {
+	if c.name == "" {
+		return errors.New("name cannot be empty")
+	}
+	if len([]rune(c.name)) > 255 {
+		return errors.New("name must be at most 255 characters")
+	}
+	if len(c.aliases) > MaxServiceNameAliases {
+		return fmt.Errorf("at most %d aliases are allowed", MaxServiceNameAliases)
+	}
+	for _, alias := range c.aliases {
+		if len([]rune(alias)) > 255 {
+			return fmt.Errorf("alias %q must be at most 255 characters", alias)
+		}
+	}
+	return nil
+}
+
+/*
+ServiceNameDictionary — словарь канонических названий, собранный из
+записей. Если ключ встречается в нескольких записях, побеждает более
+ранняя: записи приходят в порядке добавления.
+*/
+type ServiceNameDictionary struct {
+	byKey map[string]*CanonicalServiceName
+}
+
+/** Собирает словарь из записей. */
+func NewServiceNameDictionary(entries []*CanonicalServiceName) *ServiceNameDictionary {
+	dict := &ServiceNameDictionary{byKey: make(map[string]*CanonicalServiceName)}
+	for _, entry := range entries {
+		for _, key := range entry.Keys() {
+			if _, ok := dict.byKey[key]; !ok {
+				dict.byKey[key] = entry
+			}
+		}
+	}
+	return dict
+}
+
+/** Запись, к которой относится название, или nil. */
+func (d *ServiceNameDictionary) Lookup(name string) *CanonicalServiceName {
+	return d.byKey[ServiceNameKey(name)]
+}
+
+/** Каноническое написание названия; без записи в словаре — само название. */
+func (d *ServiceNameDictionary) Canonical(name string) string {
+	if entry := d.Lookup(name); entry != nil {
+		return entry.name
+	}
+	return name
+}
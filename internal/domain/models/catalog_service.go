@@ -0,0 +1,161 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxCatalogServiceNameLength = 255
+	MaxCatalogURLLength         = 2048
+)
+
+/*
+CatalogService — известный сервис из каталога: название, категория, сайт,
+иконка и типичная месячная цена. Клиенты выбирают сервис из каталога
+вместо ввода названия, подписка хранит ссылку на запись (CatalogID).
+typicalPrice == nil — цена неизвестна.
+*/
+type CatalogService struct {
+	id           uuid.UUID
+	name         string
+	category     *SubscriptionCategory
+	website      string
+	iconURL      string
+	typicalPrice *int
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+/** Создаёт запись каталога с новым ID и текущим временем. */
+func NewCatalogService(name string, category *SubscriptionCategory, website, iconURL string, typicalPrice *int) *CatalogService {
+	now := time.Now()
+	return &CatalogService{
+		id:           uuid.New(),
+		name:         strings.TrimSpace(name),
+		category:     category,
+		website:      strings.TrimSpace(website),
+		iconURL:      strings.TrimSpace(iconURL),
+		typicalPrice: typicalPrice,
+		createdAt:    now,
+		updatedAt:    now,
+	}
+}
+
+/** Восстанавливает запись каталога из БД. */
+func RestoreCatalogService(id uuid.UUID, name string, category *SubscriptionCategory, website, iconURL string, typicalPrice *int, createdAt, updatedAt time.Time) *CatalogService {
+	return &CatalogService{
+		id:           id,
+		name:         name,
+		category:     category,
+		website:      website,
+		iconURL:      iconURL,
+		typicalPrice: typicalPrice,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+/** Геттер для ID. */
+func (c *CatalogService) ID() uuid.UUID {
+	return c.id
+}
+
+/** Название сервиса; уникально без учёта регистра. Сеттер обновляет updatedAt. */
+func (c *CatalogService) Name() string {
+	return c.name
+}
+
+func (c *CatalogService) SetName(name string) {
+	c.name = strings.TrimSpace(name)
+	c.updatedAt = time.Now()
+}
+
+/** Категория; nil — не задана. */
+func (c *CatalogService) Category() *SubscriptionCategory {
+	return c.category
+}
+
+func (c *CatalogService) SetCategory(category *SubscriptionCategory) {
+	c.category = category
+	c.updatedAt = time.Now()
+}
+
+/** Сайт сервиса; пустая строка — не задан. */
+func (c *CatalogService) Website() string {
+	return c.website
+}
+
+func (c *CatalogService) SetWebsite(website string) {
+	c.website = strings.TrimSpace(website)
+	c.updatedAt = time.Now()
+}
+
+/** Адрес иконки; пустая строка — не задан. */
+func (c *CatalogService) IconURL() string {
+	return c.iconURL
+}
+
+func (c *CatalogService) SetIconURL(iconURL string) {
+	c.iconURL = strings.TrimSpace(iconURL)
+	c.updatedAt = time.Now()
+}
+
+/** Типичная месячная цена в рублях; nil — неизвестна. */
+func (c *CatalogService) TypicalPrice() *int {
+	return c.typicalPrice
+}
+
+func (c *CatalogService) SetTypicalPrice(typicalPrice *int) {
+	c.typicalPrice = typicalPrice
+	c.updatedAt = time.Now()
+}
+
+/** Метаданные о создании и обновлении. */
+func (c *CatalogService) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *CatalogService) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+/** Проверяет название, адреса и цену. */
+func (c *CatalogService) Validate() error {
+	if c.name == "" {
+		return errors.New("name cannot be empty")
+	}
+	if len([]rune(c.name)) > MaxCatalogServiceNameLength {
+		return fmt.Errorf("name must be at most %d characters", MaxCatalogServiceNameLength)
+	}
+	if err := validateCatalogURL("website", c.website); err != nil {
+		return err
+	}
+	if err := validateCatalogURL("icon_url", c.iconURL); err != nil {
+		return err
+	}
+	if c.typicalPrice != nil && *c.typicalPrice <= 0 {
+		return errors.New("typical price must be greater than zero")
+	}
+	return nil
+}
+
+// validateCatalogURL допускает пустую строку или абсолютный http(s)-адрес.
+func validateCatalogURL(field, value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > MaxCatalogURLLength {
+		return fmt.Errorf("%s must be at most %d characters", field, MaxCatalogURLLength)
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an absolute http or https URL", field)
+	}
+	return nil
+}
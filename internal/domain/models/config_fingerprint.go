@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+/*
+ConfigFingerprint — отпечаток эффективной конфигурации одного инстанса сервиса.
+Каждая реплика периодически публикует хэш своей конфигурации, чтобы
+можно было заметить "одну поду со старым ConfigMap".
+*/
+type ConfigFingerprint struct {
+	instanceID string
+	hash       string
+	reportedAt time.Time
+}
+
+/** Создаёт отпечаток с текущим временем публикации. */
+func NewConfigFingerprint(instanceID, hash string) *ConfigFingerprint {
+	return &ConfigFingerprint{
+		instanceID: instanceID,
+		hash:       hash,
+		reportedAt: time.Now(),
+	}
+}
+
+/** Геттер для идентификатора инстанса. */
+func (f *ConfigFingerprint) InstanceID() string {
+	return f.instanceID
+}
+
+/** Геттер для хэша конфигурации. */
+func (f *ConfigFingerprint) Hash() string {
+	return f.hash
+}
+
+/** Геттер/сеттер для времени публикации. Сеттер нужен при чтении из БД. */
+func (f *ConfigFingerprint) ReportedAt() time.Time {
+	return f.reportedAt
+}
+
+func (f *ConfigFingerprint) SetReportedAt(reportedAt time.Time) {
+	f.reportedAt = reportedAt
+}
+
+/*
+ConfigConsistencyReport — результат сравнения отпечатков всех живых реплик
+с отпечатком текущего инстанса.
+*/
+type ConfigConsistencyReport struct {
+	local     *ConfigFingerprint
+	instances []*ConfigFingerprint
+}
+
+/** Создаёт отчёт по локальному отпечатку и списку отпечатков реплик. */
+func NewConfigConsistencyReport(local *ConfigFingerprint, instances []*ConfigFingerprint) *ConfigConsistencyReport {
+	return &ConfigConsistencyReport{
+		local:     local,
+		instances: instances,
+	}
+}
+
+/** Геттер для отпечатка текущего инстанса. */
+func (r *ConfigConsistencyReport) Local() *ConfigFingerprint {
+	return r.local
+}
+
+/** Геттер для отпечатков всех живых реплик. */
+func (r *ConfigConsistencyReport) Instances() []*ConfigFingerprint {
+	return r.instances
+}
+
+/** Возвращает реплики, чей хэш отличается от локального. */
+func (r *ConfigConsistencyReport) Mismatched() []*ConfigFingerprint {
+	mismatched := make([]*ConfigFingerprint, 0)
+	for _, instance := range r.instances {
+		if instance.Hash() != r.local.Hash() {
+			mismatched = append(mismatched, instance)
+		}
+	}
+	return mismatched
+}
+
+/** Проверяет, что все реплики работают с одинаковой конфигурацией. */
+func (r *ConfigConsistencyReport) IsConsistent() bool {
+	return len(r.Mismatched()) == 0
+}
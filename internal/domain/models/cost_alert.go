@@ -0,0 +1,117 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+CostAlert — порог месячных трат пользователя. Раз в интервал задача
+сверяет с ним траты текущего месяца и при превышении пишет событие
+cost_alert.exceeded; за один месяц по порогу уходит одно уведомление.
+*/
+type CostAlert struct {
+	id           uuid.UUID
+	userID       uuid.UUID
+	monthlyLimit int
+	createdAt    time.Time
+}
+
+/** Создаёт порог с новым ID и текущим временем. */
+func NewCostAlert(userID uuid.UUID, monthlyLimit int) *CostAlert {
+	return &CostAlert{
+		id:           uuid.New(),
+		userID:       userID,
+		monthlyLimit: monthlyLimit,
+		createdAt:    time.Now(),
+	}
+}
+
+/** Восстанавливает порог из БД. */
+func RestoreCostAlert(id, userID uuid.UUID, monthlyLimit int, createdAt time.Time) *CostAlert {
+	return &CostAlert{
+		id:           id,
+		userID:       userID,
+		monthlyLimit: monthlyLimit,
+		createdAt:    createdAt,
+	}
+}
+
+/** Геттер для ID. */
+func (a *CostAlert) ID() uuid.UUID {
+	return a.id
+}
+
+/** Геттер для владельца порога. */
+func (a *CostAlert) UserID() uuid.UUID {
+	return a.userID
+}
+
+/** Порог трат за месяц в рублях. */
+func (a *CostAlert) MonthlyLimit() int {
+	return a.monthlyLimit
+}
+
+/** Геттер для времени создания. */
+func (a *CostAlert) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+/** Проверяет пользователя и порог. */
+func (a *CostAlert) Validate() error {
+	if a.userID == uuid.Nil {
+		return errors.New("user ID cannot be empty")
+	}
+	if a.monthlyLimit <= 0 {
+		return errors.New("monthly limit must be greater than zero")
+	}
+	return nil
+}
+
+/*
+CostAlertStatus — состояние порога за месяц: траты month (первое число
+месяца, UTC) и время уведомления, если оно уже ушло.
+*/
+type CostAlertStatus struct {
+	alert      *CostAlert
+	month      time.Time
+	spent      int
+	notifiedAt *time.Time
+}
+
+/** Конструктор. notifiedAt == nil — уведомления за месяц не было. */
+func NewCostAlertStatus(alert *CostAlert, month time.Time, spent int, notifiedAt *time.Time) *CostAlertStatus {
+	return &CostAlertStatus{
+		alert:      alert,
+		month:      month,
+		spent:      spent,
+		notifiedAt: notifiedAt,
+	}
+}
+
+/** Геттер для порога. */
+func (s *CostAlertStatus) Alert() *CostAlert {
+	return s.alert
+}
+
+/** Первое число месяца, за который посчитаны траты. */
+func (s *CostAlertStatus) Month() time.Time {
+	return s.month
+}
+
+/** Траты за месяц после скидок. */
+func (s *CostAlertStatus) Spent() int {
+	return s.spent
+}
+
+/** Проверяет, превышен ли порог: траты строго больше него. */
+func (s *CostAlertStatus) Exceeded() bool {
+	return s.spent > s.alert.MonthlyLimit()
+}
+
+/** Время уведомления за месяц; nil — не отправлялось. */
+func (s *CostAlertStatus) NotifiedAt() *time.Time {
+	return s.notifiedAt
+}
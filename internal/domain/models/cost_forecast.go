@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+/** Максимальный горизонт прогноза трат в месяцах. */
+const MaxForecastMonths = 24
+
+/*
+CostForecast — прогноз трат пользователя на несколько месяцев подряд,
+начиная с from (первое число месяца, UTC). Каждый месяц считается так же,
+как ячейка календаря, по текущим ценам: бессрочная подписка продлевается
+каждый месяц, подписка с датой окончания выпадает из прогноза после неё,
+а подписка с будущей датой начала (например, после пробного периода)
+стоит денег только с месяца начала. Скидки учитываются в пределах срока
+действия, поэтому окончание промокода видно как рост трат.
+*/
+type CostForecast struct {
+	from   time.Time
+	months []*CalendarMonth
+}
+
+/** Создаёт прогноз на months месяцев с пустыми месяцами. */
+func NewCostForecast(from time.Time, months int, billing BillingMode) *CostForecast {
+	forecast := &CostForecast{
+		from:   from,
+		months: make([]*CalendarMonth, months),
+	}
+	for i := range forecast.months {
+		forecast.months[i] = NewCalendarMonth(from.AddDate(0, i, 0), billing)
+	}
+	return forecast
+}
+
+/** Геттер для первого месяца прогноза. */
+func (f *CostForecast) From() time.Time {
+	return f.from
+}
+
+/** Геттер для месяцев прогноза. */
+func (f *CostForecast) Months() []*CalendarMonth {
+	return f.months
+}
+
+/** Возвращает месяц прогноза по дате или nil, если дата вне горизонта. */
+func (f *CostForecast) MonthOf(date time.Time) *CalendarMonth {
+	i := monthIndex(date) - monthIndex(f.from)
+	if i < 0 || i >= len(f.months) {
+		return nil
+	}
+	return f.months[i]
+}
+
+/** Считает суммарные траты за весь горизонт. */
+func (f *CostForecast) TotalCost() int {
+	total := 0
+	for _, month := range f.months {
+		total += month.TotalCost()
+	}
+	return total
+}
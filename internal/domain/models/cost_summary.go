@@ -3,20 +3,29 @@ package models
 /*
 CostSummary — агрегатор для подсчёта общей стоимости подписок
 за определённый период. Хранит:
-- totalCost — общая сумма
+- totalCost — общая сумма к оплате (после скидок)
+- grossCost, discount — сумма по полной цене и размер скидок
 - period — диапазон дат, за который ведётся расчёт
+- billing — режим расчёта (целые месяцы или пропорционально дням)
+- pricing — текущая цена или цена по истории изменений
 - subscriptions — список подписок, по которым идёт расчёт
 */
 type CostSummary struct {
 	totalCost     int
-	period        DatePeriod
+	grossCost     int
+	discount      int
+	period        DateRange
+	billing       BillingMode
+	pricing       PricingMode
 	subscriptions []Subscription
 }
 
-/** Создаёт новый объект для подсчёта с заданным периодом. */
-func NewCostSummary(period DatePeriod) *CostSummary {
+/** Создаёт новый объект для подсчёта с заданным периодом и режимами расчёта. */
+func NewCostSummary(period DateRange, billing BillingMode, pricing PricingMode) *CostSummary {
 	return &CostSummary{
 		period:        period,
+		billing:       billing,
+		pricing:       pricing,
 		subscriptions: make([]Subscription, 0),
 	}
 }
@@ -30,16 +39,43 @@ func (cs *CostSummary) SetTotalCost(totalCost int) {
 	cs.totalCost = totalCost
 }
 
+/** Сумма по полной цене, без скидок. */
+func (cs *CostSummary) GrossCost() int {
+	return cs.grossCost
+}
+
+/** Размер скидок за период. */
+func (cs *CostSummary) Discount() int {
+	return cs.discount
+}
+
+/** Заполняет суммы из разбивки: totalCost — итог после скидок. */
+func (cs *CostSummary) SetBreakdown(breakdown CostBreakdown) {
+	cs.grossCost = breakdown.Gross()
+	cs.discount = breakdown.Discount()
+	cs.totalCost = breakdown.Net()
+}
+
 /** Геттер/сеттер для периода расчёта. */
-func (cs *CostSummary) Period() DatePeriod {
+func (cs *CostSummary) Period() DateRange {
 	return cs.period
 }
 
-func (cs *CostSummary) SetPeriod(period DatePeriod) {
+func (cs *CostSummary) SetPeriod(period DateRange) {
 	cs.period = period
 	cs.totalCost = 0 // сбрасываем сумму, так как период изменился
 }
 
+/** Геттер для режима расчёта. */
+func (cs *CostSummary) BillingMode() BillingMode {
+	return cs.billing
+}
+
+/** Геттер для источника цен. */
+func (cs *CostSummary) PricingMode() PricingMode {
+	return cs.pricing
+}
+
 /** Геттер/сеттер для списка подписок. */
 func (cs *CostSummary) Subscriptions() []Subscription {
 	return cs.subscriptions
@@ -60,11 +96,15 @@ Calculate — считает суммарную стоимость всех по
 за указанный период, используя CalculateCostForPeriod каждой подписки.
 Результат сохраняется в totalCost и возвращается.
 */
-func (cs *CostSummary) Calculate() int {
+func (cs *CostSummary) Calculate() (int, error) {
 	total := 0
 	for _, sub := range cs.subscriptions {
-		total += sub.CalculateCostForPeriod(cs.period.From(), cs.period.To())
+		cost, err := sub.CalculateCostForPeriod(cs.period, cs.billing)
+		if err != nil {
+			return 0, err
+		}
+		total += cost
 	}
 	cs.totalCost = total
-	return total
+	return total, nil
 }
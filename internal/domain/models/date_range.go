@@ -0,0 +1,189 @@
+package models
+
+import (
+	"errors"
+	"math/big"
+	"time"
+)
+
+/*
+DateRange — интервал дат с включёнными границами. Конец может быть открыт
+(бессрочная подписка). Единственный источник правил биллинга: пересечение
+диапазонов, число оплачиваемых месяцев и дней и стоимость за период
+считаются только здесь, SQL в репозитории повторяет эти же правила.
+*/
+type DateRange struct {
+	from time.Time
+	to   *time.Time
+}
+
+/** Создаёт закрытый диапазон [from, to]. */
+func NewDateRange(from, to time.Time) DateRange {
+	return DateRange{from: from, to: &to}
+}
+
+/** Создаёт диапазон без даты окончания. */
+func NewOpenDateRange(from time.Time) DateRange {
+	return DateRange{from: from}
+}
+
+/*
+NewDaysAheadRange — дни с at по at + days включительно (в UTC): от начала
+дня at до конца последнего дня.
+*/
+func NewDaysAheadRange(at time.Time, days int) DateRange {
+	at = at.UTC()
+	from := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	return NewDateRange(from, from.AddDate(0, 0, days+1).Add(-time.Microsecond))
+}
+
+/** Геттер для даты начала. */
+func (r DateRange) From() time.Time {
+	return r.from
+}
+
+/** Геттер для даты окончания; для открытого диапазона — нулевое время. */
+func (r DateRange) To() time.Time {
+	if r.to == nil {
+		return time.Time{}
+	}
+	return *r.to
+}
+
+/** Проверяет, открыт ли конец диапазона. */
+func (r DateRange) IsOpenEnded() bool {
+	return r.to == nil
+}
+
+/** Проверяет, входит ли дата в диапазон (включительно). */
+func (r DateRange) Contains(date time.Time) bool {
+	if date.Before(r.from) {
+		return false
+	}
+	return r.to == nil || !date.After(*r.to)
+}
+
+/** Проверяет, есть ли у диапазонов хотя бы одна общая дата. */
+func (r DateRange) Overlaps(other DateRange) bool {
+	_, ok := r.Intersect(other)
+	return ok
+}
+
+/*
+Intersect возвращает общую часть двух диапазонов. Результат открыт,
+только если открыты оба. ok == false, если общих дат нет.
+*/
+func (r DateRange) Intersect(other DateRange) (DateRange, bool) {
+	from := r.from
+	if other.from.After(from) {
+		from = other.from
+	}
+
+	to := r.to
+	if to == nil || (other.to != nil && other.to.Before(*to)) {
+		to = other.to
+	}
+
+	if to != nil && to.Before(from) {
+		return DateRange{}, false
+	}
+	return DateRange{from: from, to: to}, true
+}
+
+/*
+Months — число календарных месяцев, которых касается диапазон, включая
+неполные первый и последний. Для открытого диапазона не определено и равно 0:
+его сначала нужно ограничить через Intersect.
+*/
+func (r DateRange) Months() int {
+	if r.to == nil || r.to.Before(r.from) {
+		return 0
+	}
+	return monthIndex(*r.to) - monthIndex(r.from) + 1
+}
+
+/*
+IsWholeMonths — начинается ли закрытый диапазон первого числа и
+заканчивается ли последним днём месяца (в UTC). Для такого периода
+стоимость в режиме monthly складывается из стоимостей его месяцев.
+*/
+func (r DateRange) IsWholeMonths() bool {
+	if r.to == nil {
+		return false
+	}
+	from, to := r.from.UTC(), r.to.UTC()
+	return from.Equal(time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)) &&
+		to.AddDate(0, 0, 1).Day() == 1
+}
+
+/** Число календарных дней диапазона (в UTC), включая первый и последний. */
+func (r DateRange) Days() int {
+	if r.to == nil || r.to.Before(r.from) {
+		return 0
+	}
+	return dayIndex(*r.to) - dayIndex(r.from) + 1
+}
+
+/*
+Prorate — стоимость диапазона при помесячной цене price.
+В режиме monthly каждый затронутый месяц оплачивается целиком.
+В режиме prorated месяц стоит price * (дней в диапазоне / дней в месяце),
+сумма по месяцам округляется до целого один раз, половина — вверх.
+*/
+func (r DateRange) Prorate(price int, mode BillingMode) int {
+	if mode != BillingProrated {
+		return price * r.Months()
+	}
+	if r.to == nil || r.to.Before(r.from) {
+		return 0
+	}
+
+	from, to := r.from.UTC(), r.to.UTC()
+	total := new(big.Rat)
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(to); month = month.AddDate(0, 1, 0) {
+		lastDay := month.AddDate(0, 1, -1)
+
+		start := month
+		if from.After(start) {
+			start = from
+		}
+		end := lastDay
+		if to.Before(end) {
+			end = to
+		}
+
+		days := dayIndex(end) - dayIndex(start) + 1
+		total.Add(total, big.NewRat(int64(price)*int64(days), int64(lastDay.Day())))
+	}
+
+	return roundRat(total)
+}
+
+/** Проверяет, что дата окончания не раньше даты начала. */
+func (r DateRange) Validate() error {
+	if r.to != nil && r.to.Before(r.from) {
+		return errors.New("end date cannot be before start date")
+	}
+	return nil
+}
+
+// dayIndex нумерует дни подряд (в UTC) — разность даёт число дней между датами.
+func dayIndex(t time.Time) int {
+	t = t.UTC()
+	return int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// roundRat округляет неотрицательную дробь до целого, половину — вверх.
+func roundRat(r *big.Rat) int {
+	num := new(big.Int).Mul(r.Num(), big.NewInt(2))
+	num.Add(num, r.Denom())
+	den := new(big.Int).Mul(r.Denom(), big.NewInt(2))
+	return int(num.Quo(num, den).Int64())
+}
+
+// monthIndex нумерует месяцы подряд, чтобы разность давала число месяцев
+// без учёта дней. Месяц берётся в UTC — так же, как даты хранятся в БД.
+func monthIndex(t time.Time) int {
+	t = t.UTC()
+	return t.Year()*12 + int(t.Month()) - 1
+}
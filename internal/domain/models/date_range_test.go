@@ -0,0 +1,374 @@
+package models
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// endOfDay — последний момент дня, как у конца месяца в фильтрах периода.
+func endOfDay(year int, month time.Month, d int) time.Time {
+	return day(year, month, d).AddDate(0, 0, 1).Add(-time.Microsecond)
+}
+
+func TestDateRange_Intersect(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     DateRange
+		want     DateRange
+		wantOpen bool
+		ok       bool
+	}{
+		{
+			name: "closed ranges overlap",
+			a:    NewDateRange(day(2024, time.January, 1), day(2024, time.March, 31)),
+			b:    NewDateRange(day(2024, time.February, 1), day(2024, time.April, 30)),
+			want: NewDateRange(day(2024, time.February, 1), day(2024, time.March, 31)),
+			ok:   true,
+		},
+		{
+			name: "one range inside the other",
+			a:    NewDateRange(day(2024, time.January, 1), day(2024, time.December, 31)),
+			b:    NewDateRange(day(2024, time.May, 10), day(2024, time.May, 20)),
+			want: NewDateRange(day(2024, time.May, 10), day(2024, time.May, 20)),
+			ok:   true,
+		},
+		{
+			name: "open and closed give closed",
+			a:    NewOpenDateRange(day(2024, time.January, 1)),
+			b:    NewDateRange(day(2024, time.March, 1), day(2024, time.March, 31)),
+			want: NewDateRange(day(2024, time.March, 1), day(2024, time.March, 31)),
+			ok:   true,
+		},
+		{
+			name:     "two open ranges stay open",
+			a:        NewOpenDateRange(day(2024, time.January, 1)),
+			b:        NewOpenDateRange(day(2024, time.March, 1)),
+			want:     NewOpenDateRange(day(2024, time.March, 1)),
+			wantOpen: true,
+			ok:       true,
+		},
+		{
+			name: "ranges share one day",
+			a:    NewDateRange(day(2024, time.January, 1), day(2024, time.January, 31)),
+			b:    NewDateRange(day(2024, time.January, 31), day(2024, time.February, 29)),
+			want: NewDateRange(day(2024, time.January, 31), day(2024, time.January, 31)),
+			ok:   true,
+		},
+		{
+			name: "adjacent months do not overlap",
+			a:    NewDateRange(day(2024, time.January, 1), endOfDay(2024, time.January, 31)),
+			b:    NewDateRange(day(2024, time.February, 1), endOfDay(2024, time.February, 29)),
+		},
+		{
+			name: "open range starts after closed one ends",
+			a:    NewDateRange(day(2023, time.January, 1), day(2023, time.December, 31)),
+			b:    NewOpenDateRange(day(2024, time.January, 1)),
+		},
+		{
+			name: "closed range ends before open one starts",
+			a:    NewOpenDateRange(day(2024, time.June, 1)),
+			b:    NewDateRange(day(2024, time.January, 1), day(2024, time.May, 31)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, pair := range [][2]DateRange{{tt.a, tt.b}, {tt.b, tt.a}} {
+				got, ok := pair[0].Intersect(pair[1])
+				if ok != tt.ok {
+					t.Fatalf("Intersect() ok = %v, want %v", ok, tt.ok)
+				}
+				if overlaps := pair[0].Overlaps(pair[1]); overlaps != tt.ok {
+					t.Errorf("Overlaps() = %v, want %v", overlaps, tt.ok)
+				}
+				if !ok {
+					continue
+				}
+				if !got.From().Equal(tt.want.From()) || !got.To().Equal(tt.want.To()) || got.IsOpenEnded() != tt.wantOpen {
+					t.Errorf("Intersect() = [%s, %s] open=%v, want [%s, %s] open=%v",
+						got.From(), got.To(), got.IsOpenEnded(), tt.want.From(), tt.want.To(), tt.wantOpen)
+				}
+			}
+		})
+	}
+}
+
+func TestDateRange_MonthsAndDays(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name   string
+		r      DateRange
+		months int
+		days   int
+		whole  bool
+	}{
+		{
+			name:   "days inside one month",
+			r:      NewDateRange(day(2024, time.January, 15), day(2024, time.January, 20)),
+			months: 1, days: 6,
+		},
+		{
+			name:   "single day",
+			r:      NewDateRange(day(2024, time.March, 10), endOfDay(2024, time.March, 10)),
+			months: 1, days: 1,
+		},
+		{
+			name:   "two days across a month boundary",
+			r:      NewDateRange(day(2024, time.January, 31), day(2024, time.February, 1)),
+			months: 2, days: 2,
+		},
+		{
+			name:   "across a year boundary",
+			r:      NewDateRange(day(2023, time.December, 31), day(2024, time.January, 1)),
+			months: 2, days: 2,
+		},
+		{
+			name:   "whole month",
+			r:      NewDateRange(day(2024, time.January, 1), day(2024, time.January, 31)),
+			months: 1, days: 31, whole: true,
+		},
+		{
+			name:   "whole month to its last microsecond",
+			r:      NewDateRange(day(2024, time.April, 1), endOfDay(2024, time.April, 30)),
+			months: 1, days: 30, whole: true,
+		},
+		{
+			name:   "leap February",
+			r:      NewDateRange(day(2024, time.February, 1), day(2024, time.February, 29)),
+			months: 1, days: 29, whole: true,
+		},
+		{
+			name:   "February 28 in a leap year is not the month end",
+			r:      NewDateRange(day(2024, time.February, 1), day(2024, time.February, 28)),
+			months: 1, days: 28,
+		},
+		{
+			name:   "common February",
+			r:      NewDateRange(day(2023, time.February, 1), day(2023, time.February, 28)),
+			months: 1, days: 28, whole: true,
+		},
+		{
+			name:   "two whole years",
+			r:      NewDateRange(day(2023, time.January, 1), endOfDay(2024, time.December, 31)),
+			months: 24, days: 731, whole: true,
+		},
+		{
+			name:   "starts after the first",
+			r:      NewDateRange(day(2024, time.January, 2), day(2024, time.March, 31)),
+			months: 3, days: 90,
+		},
+		{
+			name:   "starts after midnight",
+			r:      NewDateRange(day(2024, time.January, 1).Add(time.Hour), day(2024, time.January, 31)),
+			months: 1, days: 31,
+		},
+		{
+			name:   "months are taken in UTC",
+			r:      NewDateRange(time.Date(2024, time.February, 1, 1, 0, 0, 0, moscow), day(2024, time.February, 29)),
+			months: 2, days: 30,
+		},
+		{
+			name: "open range",
+			r:    NewOpenDateRange(day(2024, time.January, 1)),
+		},
+		{
+			name: "end before start",
+			r:    NewDateRange(day(2024, time.March, 1), day(2024, time.February, 1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Months(); got != tt.months {
+				t.Errorf("Months() = %d, want %d", got, tt.months)
+			}
+			if got := tt.r.Days(); got != tt.days {
+				t.Errorf("Days() = %d, want %d", got, tt.days)
+			}
+			if got := tt.r.IsWholeMonths(); got != tt.whole {
+				t.Errorf("IsWholeMonths() = %v, want %v", got, tt.whole)
+			}
+		})
+	}
+}
+
+func TestDateRange_Prorate(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        DateRange
+		price    int
+		monthly  int
+		prorated int
+	}{
+		{
+			name:    "whole month",
+			r:       NewDateRange(day(2024, time.January, 1), endOfDay(2024, time.January, 31)),
+			price:   310,
+			monthly: 310, prorated: 310,
+		},
+		{
+			name:    "partial months are paid in full monthly",
+			r:       NewDateRange(day(2024, time.January, 15), day(2024, time.February, 10)),
+			price:   310,
+			monthly: 620, prorated: 277, // 310*17/31 + 310*10/29 = 170 + 106.9
+		},
+		{
+			name:    "half of a leap February",
+			r:       NewDateRange(day(2024, time.February, 1), day(2024, time.February, 15)),
+			price:   290,
+			monthly: 290, prorated: 150,
+		},
+		{
+			name:    "second half of a leap February",
+			r:       NewDateRange(day(2024, time.February, 15), day(2024, time.February, 29)),
+			price:   290,
+			monthly: 290, prorated: 150,
+		},
+		{
+			name:    "second half of a common February",
+			r:       NewDateRange(day(2023, time.February, 15), day(2023, time.February, 28)),
+			price:   290,
+			monthly: 290, prorated: 145,
+		},
+		{
+			name:    "leap day alone",
+			r:       NewDateRange(day(2024, time.February, 29), endOfDay(2024, time.February, 29)),
+			price:   580,
+			monthly: 580, prorated: 20,
+		},
+		{
+			name:    "exact half rounds up",
+			r:       NewDateRange(day(2024, time.April, 1), day(2024, time.April, 15)),
+			price:   1,
+			monthly: 1, prorated: 1,
+		},
+		{
+			name:    "just below half rounds down",
+			r:       NewDateRange(day(2024, time.April, 1), day(2024, time.April, 14)),
+			price:   1,
+			monthly: 1, prorated: 0,
+		},
+		{
+			name:    "half rounds up for odd prices",
+			r:       NewDateRange(day(2024, time.April, 1), day(2024, time.April, 5)),
+			price:   3,
+			monthly: 3, prorated: 1,
+		},
+		{
+			name:    "sum is rounded once",
+			r:       NewDateRange(day(2024, time.April, 30), day(2024, time.May, 1)),
+			price:   100,
+			monthly: 200, prorated: 7, // 100/30 + 100/31 = 6.56; rounded per month it would be 6
+		},
+		{
+			name:    "across a year boundary",
+			r:       NewDateRange(day(2023, time.December, 1), endOfDay(2024, time.January, 31)),
+			price:   499,
+			monthly: 998, prorated: 998,
+		},
+		{
+			name:    "open range",
+			r:       NewOpenDateRange(day(2024, time.January, 1)),
+			price:   100,
+			monthly: 0, prorated: 0,
+		},
+		{
+			name:    "end before start",
+			r:       NewDateRange(day(2024, time.March, 1), day(2024, time.February, 1)),
+			price:   100,
+			monthly: 0, prorated: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Prorate(tt.price, BillingMonthly); got != tt.monthly {
+				t.Errorf("Prorate(monthly) = %d, want %d", got, tt.monthly)
+			}
+			if got := tt.r.Prorate(tt.price, BillingProrated); got != tt.prorated {
+				t.Errorf("Prorate(prorated) = %d, want %d", got, tt.prorated)
+			}
+		})
+	}
+}
+
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		num, den int64
+		want     int
+	}{
+		{0, 1, 0},
+		{7, 1, 7},
+		{1, 3, 0},
+		{1, 2, 1},
+		{2, 3, 1},
+		{3, 2, 2},
+		{5, 2, 3},
+		{149, 100, 1},
+		{150, 100, 2},
+		{2999, 2, 1500},
+	}
+
+	for _, tt := range tests {
+		if got := roundRat(big.NewRat(tt.num, tt.den)); got != tt.want {
+			t.Errorf("roundRat(%d/%d) = %d, want %d", tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+func TestNewDaysAheadRange(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		days     int
+		from, to time.Time
+	}{
+		{
+			name: "today only",
+			at:   time.Date(2024, time.May, 10, 15, 30, 0, 0, time.UTC),
+			from: day(2024, time.May, 10),
+			to:   endOfDay(2024, time.May, 10),
+		},
+		{
+			name: "through a leap day",
+			at:   time.Date(2024, time.February, 27, 9, 0, 0, 0, time.UTC),
+			days: 3,
+			from: day(2024, time.February, 27),
+			to:   endOfDay(2024, time.March, 1),
+		},
+		{
+			name: "into the next year",
+			at:   time.Date(2024, time.December, 30, 23, 0, 0, 0, time.UTC),
+			days: 7,
+			from: day(2024, time.December, 30),
+			to:   endOfDay(2025, time.January, 6),
+		},
+		{
+			name: "day is taken in UTC",
+			at:   time.Date(2024, time.January, 1, 1, 0, 0, 0, moscow),
+			days: 1,
+			from: day(2023, time.December, 31),
+			to:   endOfDay(2024, time.January, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewDaysAheadRange(tt.at, tt.days)
+			if !r.From().Equal(tt.from) || !r.To().Equal(tt.to) {
+				t.Errorf("NewDaysAheadRange() = [%s, %s], want [%s, %s]", r.From(), r.To(), tt.from, tt.to)
+			}
+			if got := r.Days(); got != tt.days+1 {
+				t.Errorf("Days() = %d, want %d", got, tt.days+1)
+			}
+		})
+	}
+}
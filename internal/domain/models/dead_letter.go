@@ -0,0 +1,154 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** Откуда пришло сообщение, которое не удалось доставить. */
+const (
+	// DeadLetterSourceEventRecording — асинхронная (best_effort) запись
+	// события подписки в журнал и outbox не удалась.
+	DeadLetterSourceEventRecording = "event_recording"
+	// DeadLetterSourceOutboxDelivery — публикация из outbox исчерпала попытки.
+	DeadLetterSourceOutboxDelivery = "outbox_delivery"
+)
+
+/** Состояние недоставленного сообщения. */
+type DeadLetterStatus string
+
+const (
+	DeadLetterPending  DeadLetterStatus = "pending"
+	DeadLetterRequeued DeadLetterStatus = "requeued"
+)
+
+/** Разбирает статус; пустая строка — любой статус. */
+func ParseDeadLetterStatus(value string) (*DeadLetterStatus, error) {
+	switch DeadLetterStatus(value) {
+	case "":
+		return nil, nil
+	case DeadLetterPending, DeadLetterRequeued:
+		status := DeadLetterStatus(value)
+		return &status, nil
+	default:
+		return nil, fmt.Errorf("status must be %q or %q", DeadLetterPending, DeadLetterRequeued)
+	}
+}
+
+/*
+DeadLetter — сообщение о событии подписки, которое окончательно не удалось
+доставить. Payload — то же сообщение, что пишется в outbox; повторная
+отправка возвращает его в очередь outbox.
+*/
+type DeadLetter struct {
+	id           uuid.UUID
+	source       string
+	topic        string
+	eventID      uuid.UUID
+	eventType    string
+	payload      []byte
+	errorMessage string
+	failedAt     time.Time
+	requeuedAt   *time.Time
+	requeueCount int
+}
+
+/** Восстанавливает запись из БД. */
+func RestoreDeadLetter(id uuid.UUID, source, topic string, eventID uuid.UUID, eventType string, payload []byte, errorMessage string, failedAt time.Time, requeuedAt *time.Time, requeueCount int) *DeadLetter {
+	return &DeadLetter{
+		id:           id,
+		source:       source,
+		topic:        topic,
+		eventID:      eventID,
+		eventType:    eventType,
+		payload:      payload,
+		errorMessage: errorMessage,
+		failedAt:     failedAt,
+		requeuedAt:   requeuedAt,
+		requeueCount: requeueCount,
+	}
+}
+
+/** Геттер для ID. */
+func (d *DeadLetter) ID() uuid.UUID {
+	return d.id
+}
+
+/** Геттер для источника (DeadLetterSource*). */
+func (d *DeadLetter) Source() string {
+	return d.source
+}
+
+/** Геттер для топика outbox. */
+func (d *DeadLetter) Topic() string {
+	return d.topic
+}
+
+/** Геттер для ID события. */
+func (d *DeadLetter) EventID() uuid.UUID {
+	return d.eventID
+}
+
+/** Геттер для типа события. */
+func (d *DeadLetter) EventType() string {
+	return d.eventType
+}
+
+/** Геттер для сообщения в формате outbox (JSON). */
+func (d *DeadLetter) Payload() []byte {
+	return d.payload
+}
+
+/** Геттер для текста последней ошибки. */
+func (d *DeadLetter) Error() string {
+	return d.errorMessage
+}
+
+/** Геттер для времени отказа. */
+func (d *DeadLetter) FailedAt() time.Time {
+	return d.failedAt
+}
+
+/** Геттер для времени последней повторной постановки в очередь. */
+func (d *DeadLetter) RequeuedAt() *time.Time {
+	return d.requeuedAt
+}
+
+/** Сколько раз сообщение ставили в очередь повторно. */
+func (d *DeadLetter) RequeueCount() int {
+	return d.requeueCount
+}
+
+/** Статус: ожидает разбора или уже возвращено в очередь. */
+func (d *DeadLetter) Status() DeadLetterStatus {
+	if d.requeuedAt != nil {
+		return DeadLetterRequeued
+	}
+	return DeadLetterPending
+}
+
+/** Фильтр списка недоставленных сообщений; nil-поля не ограничивают выборку. */
+type DeadLetterFilter struct {
+	source    *string
+	eventType *string
+	status    *DeadLetterStatus
+}
+
+/** Конструктор. */
+func NewDeadLetterFilter(source, eventType *string, status *DeadLetterStatus) DeadLetterFilter {
+	return DeadLetterFilter{source: source, eventType: eventType, status: status}
+}
+
+func (f DeadLetterFilter) Source() *string {
+	return f.source
+}
+
+func (f DeadLetterFilter) EventType() *string {
+	return f.eventType
+}
+
+func (f DeadLetterFilter) Status() *DeadLetterStatus {
+	return f.status
+}
@@ -0,0 +1,216 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** Вид скидки. */
+type DiscountKind string
+
+const (
+	DiscountPercentage DiscountKind = "percentage"
+	DiscountFixed      DiscountKind = "fixed"
+)
+
+const MaxPromoCodeLength = 64
+
+/*
+Discount — промокод. percentage снижает месячную цену на amount процентов,
+fixed — на amount рублей (не ниже нуля). Скидка действует только в окне
+[validFrom, validTo]; validTo == nil — бессрочно. serviceName ограничивает
+промокод одним сервисом.
+*/
+type Discount struct {
+	id          uuid.UUID
+	code        string
+	kind        DiscountKind
+	amount      int
+	serviceName *string
+	validFrom   time.Time
+	validTo     *time.Time
+	createdAt   time.Time
+}
+
+/** Создаёт промокод с новым ID и текущим временем. */
+func NewDiscount(code string, kind DiscountKind, amount int, serviceName *string, validFrom time.Time, validTo *time.Time) *Discount {
+	if serviceName != nil {
+		trimmed := strings.TrimSpace(*serviceName)
+		serviceName = &trimmed
+		if trimmed == "" {
+			serviceName = nil
+		}
+	}
+
+	return &Discount{
+		id:          uuid.New(),
+		code:        strings.ToUpper(strings.TrimSpace(code)),
+		kind:        kind,
+		amount:      amount,
+		serviceName: serviceName,
+		validFrom:   validFrom,
+		validTo:     validTo,
+		createdAt:   time.Now(),
+	}
+}
+
+/** Восстанавливает промокод из БД. */
+func RestoreDiscount(id uuid.UUID, code string, kind DiscountKind, amount int, serviceName *string, validFrom time.Time, validTo *time.Time, createdAt time.Time) *Discount {
+	return &Discount{
+		id:          id,
+		code:        code,
+		kind:        kind,
+		amount:      amount,
+		serviceName: serviceName,
+		validFrom:   validFrom,
+		validTo:     validTo,
+		createdAt:   createdAt,
+	}
+}
+
+/** Геттер для ID. */
+func (d *Discount) ID() uuid.UUID {
+	return d.id
+}
+
+/** Геттер для промокода (в верхнем регистре). */
+func (d *Discount) Code() string {
+	return d.code
+}
+
+/** Геттер для вида скидки. */
+func (d *Discount) Kind() DiscountKind {
+	return d.kind
+}
+
+/** Геттер для размера скидки: проценты или рубли в месяц. */
+func (d *Discount) Amount() int {
+	return d.amount
+}
+
+/** Геттер для сервиса, которым ограничен промокод; nil — любой. */
+func (d *Discount) ServiceName() *string {
+	return d.serviceName
+}
+
+/** Геттер для начала действия. */
+func (d *Discount) ValidFrom() time.Time {
+	return d.validFrom
+}
+
+/** Геттер для окончания действия; nil — бессрочно. */
+func (d *Discount) ValidTo() *time.Time {
+	return d.validTo
+}
+
+/** Геттер для времени создания. */
+func (d *Discount) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+/** Окно действия скидки. */
+func (d *Discount) Window() DateRange {
+	if d.validTo == nil {
+		return NewOpenDateRange(d.validFrom)
+	}
+	return NewDateRange(d.validFrom, *d.validTo)
+}
+
+/** Проверяет код, вид, размер и окно действия. */
+func (d *Discount) Validate() error {
+	if d.code == "" {
+		return errors.New("code cannot be empty")
+	}
+	if len(d.code) > MaxPromoCodeLength {
+		return fmt.Errorf("code must be at most %d characters", MaxPromoCodeLength)
+	}
+
+	switch d.kind {
+	case DiscountPercentage:
+		if d.amount < 1 || d.amount > 100 {
+			return errors.New("percentage amount must be between 1 and 100")
+		}
+	case DiscountFixed:
+		if d.amount < 1 {
+			return errors.New("fixed amount must be greater than zero")
+		}
+	default:
+		return fmt.Errorf("kind must be %q or %q", DiscountPercentage, DiscountFixed)
+	}
+
+	return d.Window().Validate()
+}
+
+/*
+CheckRedeemable проверяет, можно ли применить промокод к подписке на
+serviceName в момент at: окно действия не закончилось и сервис совпадает.
+*/
+func (d *Discount) CheckRedeemable(serviceName string, at time.Time) error {
+	if d.validTo != nil && at.After(*d.validTo) {
+		return errors.New("promo code has expired")
+	}
+	if d.serviceName != nil && !strings.EqualFold(*d.serviceName, serviceName) {
+		return fmt.Errorf("promo code applies only to %q", *d.serviceName)
+	}
+	return nil
+}
+
+/*
+AmountFor — размер скидки для подписки с ценами prices за период:
+считается только по месяцам (или дням в режиме prorated), попавшим и в
+период, и в окно скидки. Процент округляется половиной вверх. Безопасен для nil.
+*/
+func (d *Discount) AmountFor(sub *Subscription, prices PriceSchedule, period DateRange, mode BillingMode) int {
+	if d == nil {
+		return 0
+	}
+
+	window, ok := period.Intersect(d.Window())
+	if !ok {
+		return 0
+	}
+	overlap, ok := sub.Period().Intersect(window)
+	if !ok {
+		return 0
+	}
+
+	if d.kind == DiscountPercentage {
+		cost := prices.Cost(overlap, mode)
+		return roundRat(big.NewRat(int64(cost)*int64(d.amount), 100))
+	}
+
+	return prices.CappedAt(d.amount).Cost(overlap, mode)
+}
+
+/*
+CostBreakdown — стоимость за период до скидок, размер скидок и итог.
+*/
+type CostBreakdown struct {
+	gross    int
+	discount int
+}
+
+/** Конструктор. */
+func NewCostBreakdown(gross, discount int) CostBreakdown {
+	return CostBreakdown{gross: gross, discount: discount}
+}
+
+/** Стоимость по полной цене. */
+func (b CostBreakdown) Gross() int {
+	return b.gross
+}
+
+/** Сумма скидок. */
+func (b CostBreakdown) Discount() int {
+	return b.discount
+}
+
+/** Итог к оплате. */
+func (b CostBreakdown) Net() int {
+	return b.gross - b.discount
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+DomainEvent — событие, которое шина событий раздаёт подписчикам внутри
+процесса. Подписчик различает события по типу Go (switch по
+SubscriptionCreated и т.д.) или по строке Type().
+*/
+type DomainEvent interface {
+	ID() uuid.UUID
+	Type() string
+	UserID() uuid.UUID
+	OccurredAt() time.Time
+}
+
+/** Подписка создана; Subscription() — сохранённое состояние. */
+type SubscriptionCreated struct{ *SubscriptionEvent }
+
+/** Подписка изменена; Subscription() — состояние после изменения. */
+type SubscriptionUpdated struct{ *SubscriptionEvent }
+
+/** Подписка удалена; Subscription() — последнее известное состояние. */
+type SubscriptionDeleted struct{ *SubscriptionEvent }
+
+/** Удалены все подписки пользователя; их ID — в SubscriptionIDs(). */
+type SubscriptionsBulkDeleted struct{ *SubscriptionEvent }
+
+/** Подписка скоро закончится: отправлено напоминание. */
+type SubscriptionExpiring struct{ *SubscriptionEvent }
+
+/** Траты пользователя за месяц превысили порог; состояние — в CostAlert(). */
+type CostAlertExceeded struct{ *SubscriptionEvent }
+
+/*
+NewDomainEvent оборачивает записанное событие в типизированное по его
+типу. Неизвестный тип возвращается как есть — *SubscriptionEvent тоже
+реализует DomainEvent.
+*/
+func NewDomainEvent(evt *SubscriptionEvent) DomainEvent {
+	switch evt.Type() {
+	case EventSubscriptionCreated:
+		return SubscriptionCreated{evt}
+	case EventSubscriptionUpdated:
+		return SubscriptionUpdated{evt}
+	case EventSubscriptionDeleted:
+		return SubscriptionDeleted{evt}
+	case EventSubscriptionsBulkDeleted:
+		return SubscriptionsBulkDeleted{evt}
+	case EventSubscriptionExpiring:
+		return SubscriptionExpiring{evt}
+	case EventCostAlertExceeded:
+		return CostAlertExceeded{evt}
+	default:
+		return evt
+	}
+}
@@ -0,0 +1,270 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/** Currency — код валюты ISO 4217. */
+type Currency string
+
+const (
+	CurrencyRUB Currency = "RUB"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+
+	// DefaultCurrency — валюта цен, пока подписки и тарифы хранятся в рублях.
+	DefaultCurrency = CurrencyRUB
+)
+
+// currencyExponents — сколько знаков после запятой у минимальной единицы.
+var currencyExponents = map[Currency]int{
+	CurrencyRUB: 2,
+	CurrencyUSD: 2,
+	CurrencyEUR: 2,
+}
+
+/** Разбирает код валюты без учёта регистра; неизвестная валюта — ошибка. */
+func ParseCurrency(value string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(value)))
+	if _, ok := currencyExponents[currency]; !ok {
+		return "", fmt.Errorf("unsupported currency %q", value)
+	}
+	return currency, nil
+}
+
+/** Число знаков после запятой: 2 для копеек и центов. */
+func (c Currency) Exponent() int {
+	return currencyExponents[c]
+}
+
+// unit — сколько минимальных единиц в одной основной.
+func (c Currency) unit() int64 {
+	unit := int64(1)
+	for i := 0; i < c.Exponent(); i++ {
+		unit *= 10
+	}
+	return unit
+}
+
+var (
+	// ErrCurrencyMismatch — арифметика над суммами в разных валютах.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrFractionalAmount — сумму с копейками переводят в целые рубли.
+	ErrFractionalAmount = errors.New("amount has a fractional part")
+)
+
+/*
+Money — сумма в минимальных единицах валюты (копейках, центах) и сама
+валюта. Сумма целая, поэтому сложение и умножение точны, а в JSON она
+уходит десятичной строкой, без float. Нулевое значение — ноль без валюты.
+*/
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+/** Сумма amount в минимальных единицах currency. */
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{amount: amount, currency: currency}
+}
+
+/** Сумма в целых рублях — так цены хранятся в БД и приходят в API. */
+func Rubles(rubles int) Money {
+	return Money{amount: int64(rubles) * CurrencyRUB.unit(), currency: CurrencyRUB}
+}
+
+/*
+ParseMoney разбирает десятичную запись суммы («799», «799.5», «-0.99»)
+без перевода во float. Знаков после точки не больше, чем у валюты.
+*/
+func ParseMoney(value string, currency Currency) (Money, error) {
+	if _, ok := currencyExponents[currency]; !ok {
+		return Money{}, fmt.Errorf("unsupported currency %q", currency)
+	}
+
+	text := strings.TrimSpace(value)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	whole, fraction, _ := strings.Cut(text, ".")
+	if whole == "" || len(fraction) > currency.Exponent() || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("invalid amount %q for %s", value, currency)
+	}
+	fraction += strings.Repeat("0", currency.Exponent()-len(fraction))
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{amount: amount, currency: currency}, nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+/** Сумма в минимальных единицах. */
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+/** Геттер для валюты. */
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+/** Сумма в основных единицах (рублях); сумма с копейками — ErrFractionalAmount. */
+func (m Money) WholeUnits() (int, error) {
+	if !m.IsWhole() {
+		return 0, fmt.Errorf("%w: %s", ErrFractionalAmount, m)
+	}
+	if m.currency == "" {
+		return int(m.amount), nil
+	}
+	return int(m.amount / m.currency.unit()), nil
+}
+
+/** Нет ли у суммы дробной части. */
+func (m Money) IsWhole() bool {
+	return m.currency == "" || m.amount%m.currency.unit() == 0
+}
+
+/** Больше ли сумма нуля. */
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+/** Равен ли ноль сумме. */
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+/** Совпадают ли суммы и валюты. */
+func (m Money) Equal(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+/** Складывает суммы одной валюты; ноль без валюты складывается с любой. */
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.sameCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount + other.amount, currency: currency}, nil
+}
+
+/** Вычитает сумму той же валюты. */
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.sameCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount - other.amount, currency: currency}, nil
+}
+
+/** Умножает сумму на целое число, например цену на число месяцев. */
+func (m Money) Mul(n int) Money {
+	return Money{amount: m.amount * int64(n), currency: m.currency}
+}
+
+/** Сравнивает суммы одной валюты: -1, 0 или 1. */
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (m Money) sameCurrency(other Money) (Currency, error) {
+	switch {
+	case m.currency == other.currency:
+		return m.currency, nil
+	case m.IsZero() && m.currency == "":
+		return other.currency, nil
+	case other.IsZero() && other.currency == "":
+		return m.currency, nil
+	}
+	return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+}
+
+/** Десятичная запись суммы без валюты: «799.00». */
+func (m Money) Decimal() string {
+	exponent := m.currency.Exponent()
+	amount := m.amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	if exponent == 0 {
+		return sign + strconv.FormatInt(amount, 10)
+	}
+
+	unit := m.currency.unit()
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/unit, exponent, amount%unit)
+}
+
+/** Сумма с валютой: «799.00 RUB». */
+func (m Money) String() string {
+	if m.currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + string(m.currency)
+}
+
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency Currency        `json:"currency"`
+}
+
+/** {"amount":"799.00","currency":"RUB"}: сумма строкой, чтобы клиенты не читали её во float. */
+func (m Money) MarshalJSON() ([]byte, error) {
+	amount, err := json.Marshal(m.Decimal())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(moneyJSON{Amount: amount, Currency: m.currency})
+}
+
+/** Принимает сумму строкой или числом; число разбирается как текст, без float. */
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	currency, err := ParseCurrency(string(raw.Currency))
+	if err != nil {
+		return err
+	}
+
+	amount := string(bytes.TrimSpace(raw.Amount))
+	if unquoted, err := strconv.Unquote(amount); err == nil {
+		amount = unquoted
+	}
+
+	parsed, err := ParseMoney(amount, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
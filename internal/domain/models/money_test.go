@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMoney_WholeUnits(t *testing.T) {
+	tests := []struct {
+		name    string
+		money   Money
+		want    int
+		wantErr error
+	}{
+		{name: "whole roubles", money: Rubles(799), want: 799},
+		{name: "negative", money: Rubles(-15), want: -15},
+		{name: "kopecks", money: NewMoney(79950, CurrencyRUB), wantErr: ErrFractionalAmount},
+		{name: "one kopeck", money: NewMoney(1, CurrencyRUB), wantErr: ErrFractionalAmount},
+		{name: "zero without currency", money: Money{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.money.WholeUnits()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WholeUnits() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("WholeUnits() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_KopecksInPricesAreErrors(t *testing.T) {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	sub := NewSubscription("Netflix", NewMoney(79950, CurrencyRUB), uuid.New(), start)
+
+	if _, err := sub.CalculateCostForPeriod(NewDateRange(start, start.AddDate(0, 1, -1)), BillingMonthly); !errors.Is(err, ErrFractionalAmount) {
+		t.Errorf("CalculateCostForPeriod() error = %v, want %v", err, ErrFractionalAmount)
+	}
+	if _, err := NewBillingCalendar(start, []*Subscription{sub}); !errors.Is(err, ErrFractionalAmount) {
+		t.Errorf("NewBillingCalendar() error = %v, want %v", err, ErrFractionalAmount)
+	}
+
+	plan := NewPlan("Premium", "Netflix", NewMoney(999950, CurrencyRUB), BillingCycleYearly, nil)
+	if got := plan.MonthlyPrice(); !got.Equal(Rubles(833)) {
+		t.Errorf("MonthlyPrice() = %s, want 833.00 RUB", got)
+	}
+}
@@ -0,0 +1,219 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** Период, за который указана цена тарифа. */
+type BillingCycle string
+
+const (
+	BillingCycleMonthly BillingCycle = "monthly"
+	BillingCycleYearly  BillingCycle = "yearly"
+)
+
+const MaxPlanNameLength = 255
+
+/*
+Plan — тариф из каталога. Подписка, созданная по тарифу, получает его
+название сервиса и месячную цену на момент создания; последующие
+изменения цены тарифа на неё не влияют. features — произвольные
+свойства тарифа (JSON-объект).
+*/
+type Plan struct {
+	id           uuid.UUID
+	name         string
+	serviceName  string
+	price        Money
+	billingCycle BillingCycle
+	features     map[string]interface{}
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+/** Создаёт тариф с новым ID и текущим временем. */
+func NewPlan(name, serviceName string, price Money, billingCycle BillingCycle, features map[string]interface{}) *Plan {
+	if features == nil {
+		features = map[string]interface{}{}
+	}
+
+	now := time.Now()
+	return &Plan{
+		id:           uuid.New(),
+		name:         strings.TrimSpace(name),
+		serviceName:  strings.TrimSpace(serviceName),
+		price:        price,
+		billingCycle: billingCycle,
+		features:     features,
+		createdAt:    now,
+		updatedAt:    now,
+	}
+}
+
+/** Восстанавливает тариф из БД. */
+func RestorePlan(id uuid.UUID, name, serviceName string, price Money, billingCycle BillingCycle, features map[string]interface{}, createdAt, updatedAt time.Time) *Plan {
+	return &Plan{
+		id:           id,
+		name:         name,
+		serviceName:  serviceName,
+		price:        price,
+		billingCycle: billingCycle,
+		features:     features,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+/** Геттер для ID. */
+func (p *Plan) ID() uuid.UUID {
+	return p.id
+}
+
+/** Название тарифа. Сеттер обновляет updatedAt. */
+func (p *Plan) Name() string {
+	return p.name
+}
+
+func (p *Plan) SetName(name string) {
+	p.name = strings.TrimSpace(name)
+	p.updatedAt = time.Now()
+}
+
+/** Сервис, на который оформляются подписки по тарифу. */
+func (p *Plan) ServiceName() string {
+	return p.serviceName
+}
+
+func (p *Plan) SetServiceName(serviceName string) {
+	p.serviceName = strings.TrimSpace(serviceName)
+	p.updatedAt = time.Now()
+}
+
+/** Цена за billingCycle. */
+func (p *Plan) Price() Money {
+	return p.price
+}
+
+func (p *Plan) SetPrice(price Money) {
+	p.price = price
+	p.updatedAt = time.Now()
+}
+
+/** Период, за который указана цена. */
+func (p *Plan) BillingCycle() BillingCycle {
+	return p.billingCycle
+}
+
+func (p *Plan) SetBillingCycle(billingCycle BillingCycle) {
+	p.billingCycle = billingCycle
+	p.updatedAt = time.Now()
+}
+
+/** Свойства тарифа. */
+func (p *Plan) Features() map[string]interface{} {
+	return p.features
+}
+
+func (p *Plan) SetFeatures(features map[string]interface{}) {
+	if features == nil {
+		features = map[string]interface{}{}
+	}
+	p.features = features
+	p.updatedAt = time.Now()
+}
+
+/** Метаданные о создании и обновлении. */
+func (p *Plan) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *Plan) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+/*
+MonthlyPrice — цена подписки по тарифу: для годового тарифа годовая
+цена делится на 12 с округлением половины вверх до целых рублей — в
+целых рублях хранятся цены подписок.
+*/
+func (p *Plan) MonthlyPrice() Money {
+	if p.billingCycle == BillingCycleYearly {
+		unit := p.price.Currency().unit()
+		monthly := roundRat(big.NewRat(p.price.Amount(), 12*unit))
+		return NewMoney(int64(monthly)*unit, p.price.Currency())
+	}
+	return p.price
+}
+
+/** Проверяет название, сервис, цену и период оплаты. */
+func (p *Plan) Validate() error {
+	if p.name == "" {
+		return errors.New("name cannot be empty")
+	}
+	if len([]rune(p.name)) > MaxPlanNameLength {
+		return fmt.Errorf("name must be at most %d characters", MaxPlanNameLength)
+	}
+	if p.serviceName == "" {
+		return errors.New("service name cannot be empty")
+	}
+	if !p.price.IsPositive() {
+		return errors.New("price must be greater than zero")
+	}
+	if p.price.Currency() != DefaultCurrency {
+		return fmt.Errorf("price currency must be %s", DefaultCurrency)
+	}
+	if !p.price.IsWhole() {
+		return errors.New("price must be a whole number of roubles")
+	}
+	if p.billingCycle != BillingCycleMonthly && p.billingCycle != BillingCycleYearly {
+		return fmt.Errorf("billing cycle must be %q or %q", BillingCycleMonthly, BillingCycleYearly)
+	}
+	return nil
+}
+
+/*
+PlanPrice — запись истории цен тарифа: цена, действующая с effectiveFrom
+до следующей записи.
+*/
+type PlanPrice struct {
+	planID        uuid.UUID
+	price         Money
+	billingCycle  BillingCycle
+	effectiveFrom time.Time
+}
+
+/** Конструктор. */
+func NewPlanPrice(planID uuid.UUID, price Money, billingCycle BillingCycle, effectiveFrom time.Time) *PlanPrice {
+	return &PlanPrice{
+		planID:        planID,
+		price:         price,
+		billingCycle:  billingCycle,
+		effectiveFrom: effectiveFrom,
+	}
+}
+
+/** Геттер для ID тарифа. */
+func (pp *PlanPrice) PlanID() uuid.UUID {
+	return pp.planID
+}
+
+/** Геттер для цены. */
+func (pp *PlanPrice) Price() Money {
+	return pp.price
+}
+
+/** Геттер для периода оплаты, к которому относится цена. */
+func (pp *PlanPrice) BillingCycle() BillingCycle {
+	return pp.billingCycle
+}
+
+/** Геттер для даты, с которой действует цена. */
+func (pp *PlanPrice) EffectiveFrom() time.Time {
+	return pp.effectiveFrom
+}
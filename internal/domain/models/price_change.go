@@ -0,0 +1,180 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+PricingMode — какой ценой считать стоимость за период.
+current: текущей ценой подписки за весь период.
+historical: в каждый момент — ценой, действовавшей тогда по истории изменений.
+*/
+type PricingMode string
+
+const (
+	PricingCurrent    PricingMode = "current"
+	PricingHistorical PricingMode = "historical"
+)
+
+/** Разбирает режим без учёта регистра. */
+func ParsePricingMode(value string) (PricingMode, error) {
+	switch mode := PricingMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case PricingCurrent, PricingHistorical:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("pricing must be %q or %q, got %q", PricingCurrent, PricingHistorical, value)
+	}
+}
+
+/*
+PriceChange — запись об изменении цены подписки. Новая цена действует
+с начала дня изменения (в UTC), старая — до него.
+*/
+type PriceChange struct {
+	subscriptionID uuid.UUID
+	oldPrice       int
+	newPrice       int
+	effectiveFrom  time.Time
+	changedAt      time.Time
+}
+
+/** Создаёт запись об изменении цены в момент at. */
+func NewPriceChange(subscriptionID uuid.UUID, oldPrice, newPrice int, at time.Time) *PriceChange {
+	at = at.UTC()
+	return &PriceChange{
+		subscriptionID: subscriptionID,
+		oldPrice:       oldPrice,
+		newPrice:       newPrice,
+		effectiveFrom:  time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC),
+		changedAt:      at,
+	}
+}
+
+/** Восстанавливает запись из БД. */
+func RestorePriceChange(subscriptionID uuid.UUID, oldPrice, newPrice int, effectiveFrom, changedAt time.Time) *PriceChange {
+	return &PriceChange{
+		subscriptionID: subscriptionID,
+		oldPrice:       oldPrice,
+		newPrice:       newPrice,
+		effectiveFrom:  effectiveFrom,
+		changedAt:      changedAt,
+	}
+}
+
+/** Геттер для ID подписки. */
+func (pc *PriceChange) SubscriptionID() uuid.UUID {
+	return pc.subscriptionID
+}
+
+/** Геттер для цены до изменения. */
+func (pc *PriceChange) OldPrice() int {
+	return pc.oldPrice
+}
+
+/** Геттер для цены после изменения. */
+func (pc *PriceChange) NewPrice() int {
+	return pc.newPrice
+}
+
+/** Геттер для даты, с которой действует новая цена. */
+func (pc *PriceChange) EffectiveFrom() time.Time {
+	return pc.effectiveFrom
+}
+
+/** Геттер для времени изменения. */
+func (pc *PriceChange) ChangedAt() time.Time {
+	return pc.changedAt
+}
+
+/*
+PriceSchedule — месячная цена подписки как функция времени. Без истории
+цена постоянна. С историей до первого изменения действует его oldPrice,
+дальше — newPrice последнего изменения, вступившего в силу.
+*/
+type PriceSchedule struct {
+	price   int
+	changes []*PriceChange
+	ceiling int
+}
+
+/** Постоянная цена. */
+func FixedPrice(price int) PriceSchedule {
+	return PriceSchedule{price: price}
+}
+
+/** Цена по истории изменений; current — цена, если истории нет. */
+func NewPriceSchedule(current int, changes []*PriceChange) PriceSchedule {
+	sorted := make([]*PriceChange, len(changes))
+	copy(sorted, changes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].effectiveFrom.Before(sorted[j].effectiveFrom)
+	})
+	return PriceSchedule{price: current, changes: sorted}
+}
+
+/** Та же цена, но не выше ceiling (для фиксированных скидок). */
+func (p PriceSchedule) CappedAt(ceiling int) PriceSchedule {
+	p.ceiling = ceiling
+	return p
+}
+
+/** Цена, действующая в момент at. */
+func (p PriceSchedule) At(at time.Time) int {
+	price := p.price
+	if len(p.changes) > 0 {
+		price = p.changes[0].oldPrice
+		for _, change := range p.changes {
+			if change.effectiveFrom.After(at) {
+				break
+			}
+			price = change.newPrice
+		}
+	}
+
+	if p.ceiling > 0 && price > p.ceiling {
+		return p.ceiling
+	}
+	return price
+}
+
+/*
+Cost — стоимость диапазона. Без истории совпадает с DateRange.Prorate.
+С историей в режиме monthly месяц оплачивается ценой на его первый день
+(или на начало диапазона, если он позже), в режиме prorated каждый день —
+ценой на этот день, делённой на длину месяца; сумма округляется один раз.
+*/
+func (p PriceSchedule) Cost(r DateRange, mode BillingMode) int {
+	if len(p.changes) == 0 {
+		return r.Prorate(p.At(r.from), mode)
+	}
+	if r.to == nil || r.to.Before(r.from) {
+		return 0
+	}
+
+	from, to := r.from.UTC(), r.to.UTC()
+
+	if mode != BillingProrated {
+		total := 0
+		for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(to); month = month.AddDate(0, 1, 0) {
+			at := month
+			if from.After(at) {
+				at = from
+			}
+			total += p.At(at)
+		}
+		return total
+	}
+
+	total := new(big.Rat)
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC); !day.After(to); day = day.AddDate(0, 0, 1) {
+		daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		total.Add(total, big.NewRat(int64(p.At(day)), int64(daysInMonth)))
+	}
+	return roundRat(total)
+}
@@ -0,0 +1,278 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+/** Порядок сервисов в рейтинге. */
+type TopServicesSort string
+
+const (
+	TopServicesBySubscribers TopServicesSort = "subscribers"
+	TopServicesByRevenue     TopServicesSort = "revenue"
+)
+
+/** Максимальная длина помесячных рядов MRR и оттока. */
+const MaxAnalyticsMonths = 120
+
+/** Разбирает порядок рейтинга; пустая строка — по числу подписчиков. */
+func ParseTopServicesSort(value string) (TopServicesSort, error) {
+	switch TopServicesSort(value) {
+	case "", TopServicesBySubscribers:
+		return TopServicesBySubscribers, nil
+	case TopServicesByRevenue:
+		return TopServicesByRevenue, nil
+	default:
+		return "", fmt.Errorf("sort must be %q or %q", TopServicesBySubscribers, TopServicesByRevenue)
+	}
+}
+
+/*
+ServiceStats — показатели одного сервиса за период: число подписчиков
+(разных пользователей), подписок и выручка с учётом скидок.
+*/
+type ServiceStats struct {
+	serviceName   string
+	subscribers   int
+	subscriptions int
+	revenue       int
+}
+
+/** Конструктор. */
+func NewServiceStats(serviceName string, subscribers, subscriptions, revenue int) *ServiceStats {
+	return &ServiceStats{
+		serviceName:   serviceName,
+		subscribers:   subscribers,
+		subscriptions: subscriptions,
+		revenue:       revenue,
+	}
+}
+
+/** Геттер для названия сервиса. */
+func (s *ServiceStats) ServiceName() string {
+	return s.serviceName
+}
+
+/** Число разных пользователей с подпиской в периоде. */
+func (s *ServiceStats) Subscribers() int {
+	return s.subscribers
+}
+
+/** Число подписок, активных в периоде. */
+func (s *ServiceStats) Subscriptions() int {
+	return s.subscriptions
+}
+
+/** Выручка за период после скидок. */
+func (s *ServiceStats) Revenue() int {
+	return s.revenue
+}
+
+/** TopServicesReport — рейтинг сервисов за период в порядке sortBy. */
+type TopServicesReport struct {
+	period   DateRange
+	billing  BillingMode
+	sortBy   TopServicesSort
+	services []*ServiceStats
+}
+
+/** Конструктор; services уже отсортированы репозиторием. */
+func NewTopServicesReport(period DateRange, billing BillingMode, sortBy TopServicesSort, services []*ServiceStats) *TopServicesReport {
+	return &TopServicesReport{
+		period:   period,
+		billing:  billing,
+		sortBy:   sortBy,
+		services: services,
+	}
+}
+
+/** Геттер для периода. */
+func (r *TopServicesReport) Period() DateRange {
+	return r.period
+}
+
+/** Геттер для режима расчёта выручки. */
+func (r *TopServicesReport) BillingMode() BillingMode {
+	return r.billing
+}
+
+/** Геттер для порядка рейтинга. */
+func (r *TopServicesReport) SortBy() TopServicesSort {
+	return r.sortBy
+}
+
+/** Сервисы рейтинга. */
+func (r *TopServicesReport) Services() []*ServiceStats {
+	return r.services
+}
+
+/*
+MRRPoint — регулярная месячная выручка за месяц: сумма месячных цен
+(по истории цен и после скидок) подписок, активных в этом месяце.
+*/
+type MRRPoint struct {
+	month         time.Time
+	mrr           int
+	subscriptions int
+}
+
+/** Конструктор. */
+func NewMRRPoint(month time.Time, mrr, subscriptions int) *MRRPoint {
+	return &MRRPoint{
+		month:         month,
+		mrr:           mrr,
+		subscriptions: subscriptions,
+	}
+}
+
+/** Геттер для первого дня месяца (UTC). */
+func (p *MRRPoint) Month() time.Time {
+	return p.month
+}
+
+/** Геттер для MRR. */
+func (p *MRRPoint) MRR() int {
+	return p.mrr
+}
+
+/** Число подписок, активных в месяце. */
+func (p *MRRPoint) Subscriptions() int {
+	return p.subscriptions
+}
+
+/** MRRReport — помесячный ряд MRR за период. */
+type MRRReport struct {
+	period DateRange
+	points []*MRRPoint
+}
+
+/** Конструктор. */
+func NewMRRReport(period DateRange, points []*MRRPoint) *MRRReport {
+	return &MRRReport{period: period, points: points}
+}
+
+/** Геттер для периода. */
+func (r *MRRReport) Period() DateRange {
+	return r.period
+}
+
+/** Точки ряда по возрастанию месяца. */
+func (r *MRRReport) Points() []*MRRPoint {
+	return r.points
+}
+
+/** MRR последнего месяца периода. */
+func (r *MRRReport) Current() int {
+	if len(r.points) == 0 {
+		return 0
+	}
+	return r.points[len(r.points)-1].mrr
+}
+
+/** Изменение MRR от первого месяца периода к последнему. */
+func (r *MRRReport) Change() int {
+	if len(r.points) == 0 {
+		return 0
+	}
+	return r.points[len(r.points)-1].mrr - r.points[0].mrr
+}
+
+/*
+ChurnPoint — отток за месяц по датам начала и окончания подписок:
+activeAtStart — подписки, начавшиеся до месяца и не закончившиеся к его
+началу; started — начавшиеся в месяце; churned — закончившиеся в месяце.
+*/
+type ChurnPoint struct {
+	month         time.Time
+	activeAtStart int
+	started       int
+	churned       int
+}
+
+/** Конструктор. */
+func NewChurnPoint(month time.Time, activeAtStart, started, churned int) *ChurnPoint {
+	return &ChurnPoint{
+		month:         month,
+		activeAtStart: activeAtStart,
+		started:       started,
+		churned:       churned,
+	}
+}
+
+/** Геттер для первого дня месяца (UTC). */
+func (p *ChurnPoint) Month() time.Time {
+	return p.month
+}
+
+/** Подписки, активные на начало месяца. */
+func (p *ChurnPoint) ActiveAtStart() int {
+	return p.activeAtStart
+}
+
+/** Подписки, начавшиеся в месяце. */
+func (p *ChurnPoint) Started() int {
+	return p.started
+}
+
+/** Подписки, закончившиеся в месяце. */
+func (p *ChurnPoint) Churned() int {
+	return p.churned
+}
+
+/** Доля оттока: churned / activeAtStart, 0 — если активных не было. */
+func (p *ChurnPoint) Rate() float64 {
+	return churnRate(p.churned, p.activeAtStart)
+}
+
+/** ChurnReport — помесячный отток за период. */
+type ChurnReport struct {
+	period DateRange
+	points []*ChurnPoint
+}
+
+/** Конструктор. */
+func NewChurnReport(period DateRange, points []*ChurnPoint) *ChurnReport {
+	return &ChurnReport{period: period, points: points}
+}
+
+/** Геттер для периода. */
+func (r *ChurnReport) Period() DateRange {
+	return r.period
+}
+
+/** Точки ряда по возрастанию месяца. */
+func (r *ChurnReport) Points() []*ChurnPoint {
+	return r.points
+}
+
+/** Всего подписок, закончившихся за период. */
+func (r *ChurnReport) TotalChurned() int {
+	total := 0
+	for _, point := range r.points {
+		total += point.churned
+	}
+	return total
+}
+
+/*
+AverageRate — средний месячный отток, взвешенный по числу активных
+подписок: сумма churned, делённая на сумму activeAtStart.
+*/
+func (r *ChurnReport) AverageRate() float64 {
+	active := 0
+	for _, point := range r.points {
+		active += point.activeAtStart
+	}
+	return churnRate(r.TotalChurned(), active)
+}
+
+// churnRate округляет долю до 4 знаков, чтобы ответ не зависел от
+// погрешности float.
+func churnRate(churned, active int) float64 {
+	if active == 0 {
+		return 0
+	}
+	return math.Round(float64(churned)/float64(active)*10000) / 10000
+}
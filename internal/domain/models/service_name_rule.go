@@ -0,0 +1,201 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** Список, к которому относится правило. */
+type ServiceNameList string
+
+const (
+	ServiceNameAllowList ServiceNameList = "allow"
+	ServiceNameDenyList  ServiceNameList = "deny"
+)
+
+/** Способ сравнения шаблона с названием сервиса. */
+type ServiceNameMatch string
+
+const (
+	ServiceNameMatchExact ServiceNameMatch = "exact"
+	ServiceNameMatchRegex ServiceNameMatch = "regex"
+)
+
+const MaxServiceNamePatternLength = 255
+
+/*
+ServiceNameRule — правило белого или чёрного списка названий сервисов.
+Exact сравнивает без учёта регистра, regex ищет совпадение в любой
+части названия (якоря ^ и $ задаются в самом шаблоне).
+*/
+type ServiceNameRule struct {
+	id        uuid.UUID
+	list      ServiceNameList
+	match     ServiceNameMatch
+	pattern   string
+	reason    string
+	createdAt time.Time
+}
+
+/** Создаёт правило с новым ID и текущим временем. */
+func NewServiceNameRule(list ServiceNameList, match ServiceNameMatch, pattern, reason string) *ServiceNameRule {
+	return &ServiceNameRule{
+		id:        uuid.New(),
+		list:      list,
+		match:     match,
+		pattern:   strings.TrimSpace(pattern),
+		reason:    strings.TrimSpace(reason),
+		createdAt: time.Now(),
+	}
+}
+
+/** Восстанавливает правило из БД. */
+func RestoreServiceNameRule(id uuid.UUID, list ServiceNameList, match ServiceNameMatch, pattern, reason string, createdAt time.Time) *ServiceNameRule {
+	return &ServiceNameRule{
+		id:        id,
+		list:      list,
+		match:     match,
+		pattern:   pattern,
+		reason:    reason,
+		createdAt: createdAt,
+	}
+}
+
+/** Геттер для ID правила. */
+func (r *ServiceNameRule) ID() uuid.UUID {
+	return r.id
+}
+
+/** Геттер для списка. */
+func (r *ServiceNameRule) List() ServiceNameList {
+	return r.list
+}
+
+/** Геттер для типа сравнения. */
+func (r *ServiceNameRule) Match() ServiceNameMatch {
+	return r.match
+}
+
+/** Геттер для шаблона. */
+func (r *ServiceNameRule) Pattern() string {
+	return r.pattern
+}
+
+/** Геттер для причины, по которой правило добавлено. */
+func (r *ServiceNameRule) Reason() string {
+	return r.reason
+}
+
+/** Геттер для времени создания. */
+func (r *ServiceNameRule) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+/** Проверяет список, тип сравнения и корректность шаблона. */
+func (r *ServiceNameRule) Validate() error {
+	if r.list != ServiceNameAllowList && r.list != ServiceNameDenyList {
+		return fmt.Errorf("list must be %q or %q", ServiceNameAllowList, ServiceNameDenyList)
+	}
+	if r.pattern == "" {
+		return errors.New("pattern cannot be empty")
+	}
+	if len([]rune(r.pattern)) > MaxServiceNamePatternLength {
+		return errors.New("pattern is too long")
+	}
+
+	switch r.match {
+	case ServiceNameMatchExact:
+		return nil
+	case ServiceNameMatchRegex:
+		if _, err := r.compile(); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("match must be %q or %q", ServiceNameMatchExact, ServiceNameMatchRegex)
+	}
+}
+
+// compile сначала разбирает шаблон как есть, чтобы ошибка не содержала
+// добавленного флага (?i).
+func (r *ServiceNameRule) compile() (*regexp.Regexp, error) {
+	if _, err := regexp.Compile(r.pattern); err != nil {
+		return nil, err
+	}
+	return regexp.Compile("(?i)" + r.pattern)
+}
+
+type compiledServiceNameRule struct {
+	rule   *ServiceNameRule
+	regexp *regexp.Regexp
+}
+
+func (c compiledServiceNameRule) matches(name string) bool {
+	if c.regexp != nil {
+		return c.regexp.MatchString(name)
+	}
+	return strings.EqualFold(c.rule.pattern, name)
+}
+
+/*
+ServiceNamePolicy — скомпилированный набор правил.
+Чёрный список проверяется первым; если белый список не пуст,
+название должно совпасть хотя бы с одним его правилом.
+*/
+type ServiceNamePolicy struct {
+	allow []compiledServiceNameRule
+	deny  []compiledServiceNameRule
+}
+
+/** Компилирует правила. Некорректное правило из БД пропускается с ошибкой в ответе. */
+func NewServiceNamePolicy(rules []*ServiceNameRule) (*ServiceNamePolicy, error) {
+	policy := &ServiceNamePolicy{}
+	var errs []error
+
+	for _, rule := range rules {
+		compiled := compiledServiceNameRule{rule: rule}
+		if rule.match == ServiceNameMatchRegex {
+			re, err := rule.compile()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("rule %s: %w", rule.id, err))
+				continue
+			}
+			compiled.regexp = re
+		}
+
+		if rule.list == ServiceNameDenyList {
+			policy.deny = append(policy.deny, compiled)
+		} else {
+			policy.allow = append(policy.allow, compiled)
+		}
+	}
+
+	return policy, errors.Join(errs...)
+}
+
+/*
+Check возвращает сработавшее правило чёрного списка или, если название
+не попало в непустой белый список, nil и false.
+*/
+func (p *ServiceNamePolicy) Check(name string) (*ServiceNameRule, bool) {
+	for _, c := range p.deny {
+		if c.matches(name) {
+			return c.rule, false
+		}
+	}
+
+	if len(p.allow) == 0 {
+		return nil, true
+	}
+	for _, c := range p.allow {
+		if c.matches(name) {
+			return c.rule, true
+		}
+	}
+	return nil, false
+}
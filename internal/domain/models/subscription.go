@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,14 +14,23 @@ Subscription описывает подписку пользователя на 
 только через методы (инкапсуляция и контроль изменений).
 */
 type Subscription struct {
-	id          uuid.UUID
-	serviceName string
-	price       int
-	userID      uuid.UUID
-	startDate   time.Time
-	endDate     *time.Time
-	createdAt   time.Time
-	updatedAt   time.Time
+	id            uuid.UUID
+	serviceName   string
+	price         Money
+	userID        uuid.UUID
+	startDate     time.Time
+	endDate       *time.Time
+	discountID    *uuid.UUID
+	planID        *uuid.UUID
+	catalogID     *uuid.UUID
+	tags          []string
+	category      *SubscriptionCategory
+	paymentMethod *string
+	billingDay    *int
+	notes         string
+	metadata      map[string]string
+	createdAt     time.Time
+	updatedAt     time.Time
 }
 
 /*
@@ -28,7 +38,7 @@ type Subscription struct {
 NewSubscription создаёт новую подписку с текущим временем как createdAt/updatedAt.
 ID генерируется автоматически, чтобы не зависеть от внешнего кода.
 */
-func NewSubscription(serviceName string, price int, userID uuid.UUID, startDate time.Time) *Subscription {
+func NewSubscription(serviceName string, price Money, userID uuid.UUID, startDate time.Time) *Subscription {
 	now := time.Now()
 	return &Subscription{
 		id:          uuid.New(),
@@ -36,6 +46,8 @@ func NewSubscription(serviceName string, price int, userID uuid.UUID, startDate
 		price:       price,
 		userID:      userID,
 		startDate:   startDate,
+		tags:        make([]string, 0),
+		metadata:    make(map[string]string),
 		createdAt:   now,
 		updatedAt:   now,
 	}
@@ -60,12 +72,12 @@ func (s *Subscription) SetServiceName(serviceName string) {
 	s.updatedAt = time.Now()
 }
 
-/** Управление ценой подписки. */
-func (s *Subscription) Price() int {
+/** Управление ценой подписки за месяц. */
+func (s *Subscription) Price() Money {
 	return s.price
 }
 
-func (s *Subscription) SetPrice(price int) {
+func (s *Subscription) SetPrice(price Money) {
 	s.price = price
 	s.updatedAt = time.Now()
 }
@@ -99,6 +111,100 @@ func (s *Subscription) SetEndDate(endDate *time.Time) {
 	s.updatedAt = time.Now()
 }
 
+/** Промокод, применённый при создании; nil — без скидки. */
+func (s *Subscription) DiscountID() *uuid.UUID {
+	return s.discountID
+}
+
+func (s *Subscription) SetDiscountID(discountID *uuid.UUID) {
+	s.discountID = discountID
+}
+
+/** Тарифный план, по которому создана подписка; nil — цена задана вручную. */
+func (s *Subscription) PlanID() *uuid.UUID {
+	return s.planID
+}
+
+func (s *Subscription) SetPlanID(planID *uuid.UUID) {
+	s.planID = planID
+}
+
+/** Запись каталога сервисов, к которой привязана подписка; nil — без привязки. */
+func (s *Subscription) CatalogID() *uuid.UUID {
+	return s.catalogID
+}
+
+func (s *Subscription) SetCatalogID(catalogID *uuid.UUID) {
+	s.catalogID = catalogID
+	s.updatedAt = time.Now()
+}
+
+/** Теги подписки в нижнем регистре, без повторов (см. NormalizeTags). */
+func (s *Subscription) Tags() []string {
+	return s.tags
+}
+
+func (s *Subscription) SetTags(tags []string) {
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+	s.tags = tags
+	s.updatedAt = time.Now()
+}
+
+/** Категория подписки; nil — без категории. */
+func (s *Subscription) Category() *SubscriptionCategory {
+	return s.category
+}
+
+func (s *Subscription) SetCategory(category *SubscriptionCategory) {
+	s.category = category
+	s.updatedAt = time.Now()
+}
+
+/** Способ оплаты («Tinkoff *1234», «Family Sharing»); nil — не указан. */
+func (s *Subscription) PaymentMethod() *string {
+	return s.paymentMethod
+}
+
+func (s *Subscription) SetPaymentMethod(paymentMethod *string) {
+	s.paymentMethod = paymentMethod
+	s.updatedAt = time.Now()
+}
+
+/** День месяца, в который списывается оплата (1–31); nil — день даты начала. */
+func (s *Subscription) BillingDay() *int {
+	return s.billingDay
+}
+
+func (s *Subscription) SetBillingDay(billingDay *int) {
+	s.billingDay = billingDay
+	s.updatedAt = time.Now()
+}
+
+/** Заметка в свободной форме; пустая строка — без заметки. */
+func (s *Subscription) Notes() string {
+	return s.notes
+}
+
+func (s *Subscription) SetNotes(notes string) {
+	s.notes = notes
+	s.updatedAt = time.Now()
+}
+
+/** Произвольные пары ключ-значение, заданные клиентом. */
+func (s *Subscription) Metadata() map[string]string {
+	return s.metadata
+}
+
+func (s *Subscription) SetMetadata(metadata map[string]string) {
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	s.metadata = metadata
+	s.updatedAt = time.Now()
+}
+
 /** Метаданные о создании и обновлении. */
 func (s *Subscription) CreatedAt() time.Time {
 	return s.createdAt
@@ -116,15 +222,17 @@ func (s *Subscription) SetUpdatedAt(updatedAt time.Time) {
 	s.updatedAt = updatedAt
 }
 
+/** Период действия подписки; без даты окончания диапазон открыт. */
+func (s *Subscription) Period() DateRange {
+	if s.endDate == nil {
+		return NewOpenDateRange(s.startDate)
+	}
+	return NewDateRange(s.startDate, *s.endDate)
+}
+
 /** Проверяет, активна ли подписка на конкретную дату. */
 func (s *Subscription) IsActive(date time.Time) bool {
-	if date.Before(s.startDate) {
-		return false
-	}
-	if s.endDate != nil && date.After(*s.endDate) {
-		return false
-	}
-	return true
+	return s.Period().Contains(date)
 }
 
 /** Проверяет, истекла ли подписка на указанную дату. */
@@ -135,46 +243,47 @@ func (s *Subscription) IsExpired(date time.Time) bool {
 	return date.After(*s.endDate)
 }
 
+/** Максимальный горизонт выборки подписок, которые скоро закончатся. */
+const MaxExpiringWithinDays = 365
+
 /*
-*
-CalculateCostForPeriod считает стоимость подписки за определённый диапазон дат.
-Рассчёт идёт по количеству месяцев, начиная от startDate и до endDate (если есть).
+DaysUntilEnd — число календарных дней (в UTC) от date до даты окончания:
+0 — подписка заканчивается в этот день. Для бессрочной подписки ok = false.
 */
-func (s *Subscription) CalculateCostForPeriod(from, to time.Time) int {
-	if !s.IsActive(from) && !s.IsActive(to) {
-		return 0
-	}
-
-	start := s.startDate
-	if from.After(start) {
-		start = from
-	}
-
-	end := to
-	if s.endDate != nil && s.endDate.Before(end) {
-		end = *s.endDate
+func (s *Subscription) DaysUntilEnd(date time.Time) (days int, ok bool) {
+	if s.endDate == nil {
+		return 0, false
 	}
+	return dayIndex(*s.endDate) - dayIndex(date), true
+}
 
-	if start.After(end) {
-		return 0
+/*
+*
+CalculateCostForPeriod считает стоимость подписки за пересечение периода
+с периодом подписки: целыми месяцами или пропорционально дням (см. BillingMode).
+*/
+func (s *Subscription) CalculateCostForPeriod(period DateRange, mode BillingMode) (int, error) {
+	price, err := s.price.WholeUnits()
+	if err != nil {
+		return 0, err
 	}
+	return s.CalculateCostWithPrices(period, mode, FixedPrice(price)), nil
+}
 
-	startMonth := start.Year()*12 + int(start.Month()) - 1
-	endMonth := end.Year()*12 + int(end.Month()) - 1
-
-	months := endMonth - startMonth + 1
-	if months <= 0 {
+/** То же, что CalculateCostForPeriod, но цена берётся из prices (например, по истории изменений). */
+func (s *Subscription) CalculateCostWithPrices(period DateRange, mode BillingMode, prices PriceSchedule) int {
+	overlap, ok := s.Period().Intersect(period)
+	if !ok {
 		return 0
 	}
-
-	return s.price * months
+	return prices.Cost(overlap, mode)
 }
 
 /*
 *
 Validate проверяет, что обязательные поля заполнены корректно:
 - название сервиса не пустое
-- цена > 0
+- цена > 0, в рублях и без копеек: пока цены хранятся и считаются в целых рублях
 - userID задан
 - дата окончания не раньше даты начала
 */
@@ -182,9 +291,12 @@ func (s *Subscription) Validate() error {
 	if s.serviceName == "" {
 		return errors.New("service name cannot be empty")
 	}
-	if s.price <= 0 {
+	if !s.price.IsPositive() {
 		return errors.New("price must be greater than zero")
 	}
+	if s.price.Currency() != DefaultCurrency || !s.price.IsWhole() {
+		return fmt.Errorf("price must be a whole number of %s", DefaultCurrency)
+	}
 	if s.userID == uuid.Nil {
 		return errors.New("user ID cannot be empty")
 	}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxBillingDay — последний допустимый день списания.
+const MaxBillingDay = 31
+
+/** Проверяет день списания: 1–31, в коротких месяцах он сдвигается на последний день. */
+func ValidateBillingDay(day int) error {
+	if day < 1 || day > MaxBillingDay {
+		return fmt.Errorf("billing day must be between 1 and %d", MaxBillingDay)
+	}
+	return nil
+}
+
+/*
+ChargeDate — дата списания за month (любой момент месяца, UTC). День
+списания больше длины месяца сдвигается на последний день: 31 в феврале —
+28 или 29 число. В месяц начала списание не раньше даты начала; ok = false,
+если подписка в этом месяце не активна или закончилась до дня списания.
+*/
+func (s *Subscription) ChargeDate(month time.Time) (date time.Time, ok bool) {
+	day := s.startDate.Day()
+	if s.billingDay != nil {
+		day = *s.billingDay
+	}
+
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	date = first.AddDate(0, 0, day-1)
+
+	start := time.Date(s.startDate.Year(), s.startDate.Month(), s.startDate.Day(), 0, 0, 0, 0, time.UTC)
+	if date.Before(start) {
+		if start.Year() != date.Year() || start.Month() != date.Month() {
+			return time.Time{}, false
+		}
+		date = start
+	}
+	if s.endDate != nil && date.After(*s.endDate) {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+/** BillingCalendarDay — день месяца и подписки, которые в этот день списываются. */
+type BillingCalendarDay struct {
+	date          time.Time
+	subscriptions []*Subscription
+	totalCost     int
+}
+
+/** Геттер для даты списания. */
+func (d *BillingCalendarDay) Date() time.Time {
+	return d.date
+}
+
+/** Подписки, которые списываются в этот день. */
+func (d *BillingCalendarDay) Subscriptions() []*Subscription {
+	return d.subscriptions
+}
+
+/** Сумма списаний за день по текущим ценам, без скидок. */
+func (d *BillingCalendarDay) TotalCost() int {
+	return d.totalCost
+}
+
+/*
+BillingCalendar — календарь списаний пользователя за месяц: только дни,
+в которые что-то списывается, по возрастанию даты.
+*/
+type BillingCalendar struct {
+	month time.Time
+	days  []*BillingCalendarDay
+}
+
+/*
+Раскладывает подписки, активные в month, по дням списания. Цена с
+копейками — ErrFractionalAmount: суммы дней считаются в целых рублях.
+*/
+func NewBillingCalendar(month time.Time, subscriptions []*Subscription) (*BillingCalendar, error) {
+	byDate := make(map[time.Time]*BillingCalendarDay)
+	days := make([]*BillingCalendarDay, 0)
+	for _, sub := range subscriptions {
+		date, ok := sub.ChargeDate(month)
+		if !ok {
+			continue
+		}
+		price, err := sub.Price().WholeUnits()
+		if err != nil {
+			return nil, err
+		}
+		day, ok := byDate[date]
+		if !ok {
+			day = &BillingCalendarDay{date: date}
+			byDate[date] = day
+			days = append(days, day)
+		}
+		day.subscriptions = append(day.subscriptions, sub)
+		day.totalCost += price
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].date.Before(days[j].date)
+	})
+
+	return &BillingCalendar{month: month, days: days}, nil
+}
+
+/** Геттер для первого дня месяца. */
+func (c *BillingCalendar) Month() time.Time {
+	return c.month
+}
+
+/** Дни со списаниями. */
+func (c *BillingCalendar) Days() []*BillingCalendarDay {
+	return c.days
+}
+
+/** Сумма списаний за месяц по текущим ценам, без скидок. */
+func (c *BillingCalendar) TotalCost() int {
+	total := 0
+	for _, day := range c.days {
+		total += day.TotalCost()
+	}
+	return total
+}
@@ -0,0 +1,233 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	// MaxBulkUpdateItems — сколько подписок можно изменить одним списком.
+	MaxBulkUpdateItems = 100
+	// MaxBulkFilterUpdateRows — сколько подписок может задеть изменение по
+	// фильтру; больше — фильтр нужно сузить.
+	MaxBulkFilterUpdateRows = 1000
+)
+
+/*
+SubscriptionPatch — изменение одной подписки в пакетном обновлении. Поля
+повторяют UpdateSubscription: nil — не менять, tags и metadata заменяются
+целиком, пустые category, payment_method и notes очищают поле, billing_day = 0
+возвращает списание в день даты начала.
+*/
+type SubscriptionPatch struct {
+	id            uuid.UUID
+	serviceName   *string
+	price         *int
+	startDate     *string
+	endDate       *string
+	tags          *[]string
+	category      *string
+	paymentMethod *string
+	billingDay    *int
+	notes         *string
+	metadata      *map[string]string
+}
+
+/** Создаёт изменение подписки id; для изменения по фильтру id пустой. */
+func NewSubscriptionPatch(id uuid.UUID, serviceName *string, price *int, startDate, endDate *string, tags *[]string, category, paymentMethod *string, billingDay *int, notes *string, metadata *map[string]string) *SubscriptionPatch {
+	return &SubscriptionPatch{
+		id:            id,
+		serviceName:   serviceName,
+		price:         price,
+		startDate:     startDate,
+		endDate:       endDate,
+		tags:          tags,
+		category:      category,
+		paymentMethod: paymentMethod,
+		billingDay:    billingDay,
+		notes:         notes,
+		metadata:      metadata,
+	}
+}
+
+/** Геттер для ID изменяемой подписки. */
+func (p *SubscriptionPatch) ID() uuid.UUID {
+	return p.id
+}
+
+/** Геттер для нового названия сервиса. */
+func (p *SubscriptionPatch) ServiceName() *string {
+	return p.serviceName
+}
+
+/** Геттер для новой цены. */
+func (p *SubscriptionPatch) Price() *int {
+	return p.price
+}
+
+/** Геттер для новой даты начала. */
+func (p *SubscriptionPatch) StartDate() *string {
+	return p.startDate
+}
+
+/** Геттер для новой даты окончания. */
+func (p *SubscriptionPatch) EndDate() *string {
+	return p.endDate
+}
+
+/** Геттер для новых тегов. */
+func (p *SubscriptionPatch) Tags() *[]string {
+	return p.tags
+}
+
+/** Геттер для новой категории. */
+func (p *SubscriptionPatch) Category() *string {
+	return p.category
+}
+
+/** Геттер для нового способа оплаты. */
+func (p *SubscriptionPatch) PaymentMethod() *string {
+	return p.paymentMethod
+}
+
+/** Геттер для нового дня списания. */
+func (p *SubscriptionPatch) BillingDay() *int {
+	return p.billingDay
+}
+
+/** Геттер для новой заметки. */
+func (p *SubscriptionPatch) Notes() *string {
+	return p.notes
+}
+
+/** Геттер для новых метаданных. */
+func (p *SubscriptionPatch) Metadata() *map[string]string {
+	return p.metadata
+}
+
+/** Проверяет, что изменение задаёт хотя бы одно поле. */
+func (p *SubscriptionPatch) IsEmpty() bool {
+	return p.serviceName == nil && p.price == nil && p.startDate == nil && p.endDate == nil &&
+		p.tags == nil && p.category == nil && p.paymentMethod == nil && p.billingDay == nil && p.notes == nil && p.metadata == nil
+}
+
+/** Итог одного элемента пакетного обновления. */
+type BulkItemStatus string
+
+const (
+	BulkItemUpdated BulkItemStatus = "updated"
+	BulkItemFailed  BulkItemStatus = "failed"
+	// BulkItemRolledBack — элемент применился, но транзакцию откатила
+	// ошибка другого элемента.
+	BulkItemRolledBack BulkItemStatus = "rolled_back"
+	// BulkItemSkipped — элемент не выполнялся: раньше него уже была ошибка.
+	BulkItemSkipped BulkItemStatus = "skipped"
+)
+
+/** BulkUpdateItemResult — итог изменения одной подписки из списка. */
+type BulkUpdateItemResult struct {
+	id           uuid.UUID
+	status       BulkItemStatus
+	subscription *Subscription
+	errorCode    string
+	errorMessage string
+}
+
+/** Итог элемента без ошибки. */
+func NewBulkUpdateItemResult(id uuid.UUID, status BulkItemStatus, subscription *Subscription) *BulkUpdateItemResult {
+	return &BulkUpdateItemResult{id: id, status: status, subscription: subscription}
+}
+
+/** Итог элемента, на котором пакет остановился. */
+func NewBulkUpdateItemFailed(id uuid.UUID, errorCode, errorMessage string) *BulkUpdateItemResult {
+	return &BulkUpdateItemResult{id: id, status: BulkItemFailed, errorCode: errorCode, errorMessage: errorMessage}
+}
+
+/** Геттер для ID подписки. */
+func (r *BulkUpdateItemResult) ID() uuid.UUID {
+	return r.id
+}
+
+/** Геттер для итога элемента. */
+func (r *BulkUpdateItemResult) Status() BulkItemStatus {
+	return r.status
+}
+
+/** Подписка после изменения; только у BulkItemUpdated. */
+func (r *BulkUpdateItemResult) Subscription() *Subscription {
+	if r.status != BulkItemUpdated {
+		return nil
+	}
+	return r.subscription
+}
+
+/** Геттер для кода ошибки элемента. */
+func (r *BulkUpdateItemResult) ErrorCode() string {
+	return r.errorCode
+}
+
+/** Геттер для сообщения об ошибке элемента. */
+func (r *BulkUpdateItemResult) ErrorMessage() string {
+	return r.errorMessage
+}
+
+/** Итог пакетного обновления списком: элементы в порядке запроса. */
+type BulkUpdateResult struct {
+	items []*BulkUpdateItemResult
+}
+
+/** Создаёт итог пакета из итогов элементов. */
+func NewBulkUpdateResult(items []*BulkUpdateItemResult) *BulkUpdateResult {
+	return &BulkUpdateResult{items: items}
+}
+
+/** Геттер для итогов элементов. */
+func (r *BulkUpdateResult) Items() []*BulkUpdateItemResult {
+	return r.items
+}
+
+/** Проверяет, что изменения закоммичены: ни один элемент не упал. */
+func (r *BulkUpdateResult) Applied() bool {
+	for _, item := range r.items {
+		if item.status != BulkItemUpdated {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+BulkFilterUpdateResult — итог изменения по фильтру; при dryRun ничего не
+изменено. subscriptionIDs ограничены MaxBulkFilterUpdateRows, matched —
+полное число подписок под фильтром.
+*/
+type BulkFilterUpdateResult struct {
+	dryRun          bool
+	matched         int
+	subscriptionIDs []uuid.UUID
+}
+
+/** Создаёт итог изменения по фильтру. */
+func NewBulkFilterUpdateResult(dryRun bool, matched int, subscriptionIDs []uuid.UUID) *BulkFilterUpdateResult {
+	return &BulkFilterUpdateResult{dryRun: dryRun, matched: matched, subscriptionIDs: subscriptionIDs}
+}
+
+/** Геттер для признака пробного запуска. */
+func (r *BulkFilterUpdateResult) DryRun() bool {
+	return r.dryRun
+}
+
+/** Подписки под фильтром: изменённые или, при dryRun, которые изменились бы. */
+func (r *BulkFilterUpdateResult) SubscriptionIDs() []uuid.UUID {
+	return r.subscriptionIDs
+}
+
+/** Число подписок под фильтром. */
+func (r *BulkFilterUpdateResult) Matched() int {
+	return r.matched
+}
+
+/** Число изменённых подписок; при dryRun — 0. */
+func (r *BulkFilterUpdateResult) Updated() int {
+	if r.dryRun {
+		return 0
+	}
+	return r.matched
+}
@@ -0,0 +1,123 @@
+package models
+
+import "time"
+
+/*
+CalendarMonth — одна ячейка годового календаря пользователя.
+Хранит первый день месяца, подписки, активные в этом месяце,
+и их суммарную стоимость за вычетом скидок в режиме billing.
+*/
+type CalendarMonth struct {
+	month         time.Time
+	billing       BillingMode
+	subscriptions []*Subscription
+	totalCost     int
+}
+
+/** Создаёт пустой месяц календаря. */
+func NewCalendarMonth(month time.Time, billing BillingMode) *CalendarMonth {
+	return &CalendarMonth{
+		month:         month,
+		billing:       billing,
+		subscriptions: make([]*Subscription, 0),
+	}
+}
+
+/** Геттер для первого дня месяца. */
+func (cm *CalendarMonth) Month() time.Time {
+	return cm.month
+}
+
+/** Геттер для активных в этом месяце подписок. */
+func (cm *CalendarMonth) Subscriptions() []*Subscription {
+	return cm.subscriptions
+}
+
+/** Геттер для суммарной стоимости за месяц. */
+func (cm *CalendarMonth) TotalCost() int {
+	return cm.totalCost
+}
+
+/*
+Добавляет подписку в месяц и увеличивает его стоимость на её стоимость
+за месяц по ценам prices; discount может быть nil.
+*/
+func (cm *CalendarMonth) AddSubscription(sub *Subscription, discount *Discount, prices PriceSchedule) {
+	cm.subscriptions = append(cm.subscriptions, sub)
+	cm.totalCost += sub.CalculateCostWithPrices(cm.period(), cm.billing, prices) - discount.AmountFor(sub, prices, cm.period(), cm.billing)
+}
+
+/** Подписки, которые начинаются в этом месяце. */
+func (cm *CalendarMonth) Starting() []*Subscription {
+	starting := make([]*Subscription, 0)
+	for _, sub := range cm.subscriptions {
+		if cm.period().Contains(sub.StartDate()) {
+			starting = append(starting, sub)
+		}
+	}
+	return starting
+}
+
+/** Подписки, которые заканчиваются в этом месяце и дальше не продлеваются. */
+func (cm *CalendarMonth) Ending() []*Subscription {
+	ending := make([]*Subscription, 0)
+	for _, sub := range cm.subscriptions {
+		if sub.EndDate() != nil && cm.period().Contains(*sub.EndDate()) {
+			ending = append(ending, sub)
+		}
+	}
+	return ending
+}
+
+func (cm *CalendarMonth) period() DateRange {
+	return NewDateRange(cm.month, cm.month.AddDate(0, 1, 0).Add(-time.Nanosecond))
+}
+
+/*
+SubscriptionCalendar — годовой календарь подписок пользователя.
+Всегда содержит ровно 12 месяцев (январь–декабрь), даже если
+в каком-то месяце подписок не было.
+*/
+type SubscriptionCalendar struct {
+	year   int
+	months []*CalendarMonth
+}
+
+/** Создаёт календарь на год с пустыми месяцами. */
+func NewSubscriptionCalendar(year int, billing BillingMode) *SubscriptionCalendar {
+	months := make([]*CalendarMonth, 12)
+	for i := range months {
+		months[i] = NewCalendarMonth(time.Date(year, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC), billing)
+	}
+	return &SubscriptionCalendar{
+		year:   year,
+		months: months,
+	}
+}
+
+/** Геттер для года календаря. */
+func (sc *SubscriptionCalendar) Year() int {
+	return sc.year
+}
+
+/** Геттер для месяцев календаря. */
+func (sc *SubscriptionCalendar) Months() []*CalendarMonth {
+	return sc.months
+}
+
+/** Возвращает месяц календаря по дате или nil, если дата вне года. */
+func (sc *SubscriptionCalendar) MonthOf(date time.Time) *CalendarMonth {
+	if date.Year() != sc.year {
+		return nil
+	}
+	return sc.months[int(date.Month())-1]
+}
+
+/** Считает суммарную стоимость за весь год. */
+func (sc *SubscriptionCalendar) TotalCost() int {
+	total := 0
+	for _, month := range sc.months {
+		total += month.TotalCost()
+	}
+	return total
+}
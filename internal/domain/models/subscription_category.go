@@ -0,0 +1,182 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/** Категория подписки — область жизни, к которой относятся траты. */
+type SubscriptionCategory string
+
+const (
+	CategoryStreaming     SubscriptionCategory = "streaming"
+	CategoryMusic         SubscriptionCategory = "music"
+	CategoryCloud         SubscriptionCategory = "cloud"
+	CategorySoftware      SubscriptionCategory = "software"
+	CategoryGaming        SubscriptionCategory = "gaming"
+	CategoryFitness       SubscriptionCategory = "fitness"
+	CategoryEducation     SubscriptionCategory = "education"
+	CategoryNews          SubscriptionCategory = "news"
+	CategoryShopping      SubscriptionCategory = "shopping"
+	CategoryOther         SubscriptionCategory = "other"
+	CategoryUncategorized SubscriptionCategory = "uncategorized"
+)
+
+/** Категории, которые можно присвоить подписке. */
+var SubscriptionCategories = []SubscriptionCategory{
+	CategoryStreaming,
+	CategoryMusic,
+	CategoryCloud,
+	CategorySoftware,
+	CategoryGaming,
+	CategoryFitness,
+	CategoryEducation,
+	CategoryNews,
+	CategoryShopping,
+	CategoryOther,
+}
+
+const (
+	MaxSubscriptionTags = 20
+	MaxTagLength        = 32
+)
+
+/** Разбирает категорию без учёта регистра. uncategorized задать нельзя — это отсутствие категории. */
+func ParseSubscriptionCategory(value string) (SubscriptionCategory, error) {
+	category := SubscriptionCategory(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range SubscriptionCategories {
+		if category == known {
+			return category, nil
+		}
+	}
+
+	names := make([]string, len(SubscriptionCategories))
+	for i, known := range SubscriptionCategories {
+		names[i] = string(known)
+	}
+	return "", fmt.Errorf("category must be one of: %s", strings.Join(names, ", "))
+}
+
+/*
+NormalizeTags приводит теги к нижнему регистру, убирает пробелы по краям,
+пустые значения и дубликаты и сортирует результат, чтобы одинаковые
+наборы тегов хранились одинаково.
+*/
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len([]rune(tag)) > MaxTagLength {
+			return nil, fmt.Errorf("tag %q must be at most %d characters", tag, MaxTagLength)
+		}
+		if strings.Contains(tag, ",") {
+			return nil, fmt.Errorf("tag %q must not contain commas", tag)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxSubscriptionTags {
+		return nil, fmt.Errorf("at most %d tags are allowed", MaxSubscriptionTags)
+	}
+
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+/*
+CategoryCost — траты за период по одной категории: сумма по полной цене,
+скидки и число подписок. Подписки без категории попадают в uncategorized.
+*/
+type CategoryCost struct {
+	category      SubscriptionCategory
+	breakdown     CostBreakdown
+	subscriptions int
+}
+
+/** Конструктор. */
+func NewCategoryCost(category SubscriptionCategory, breakdown CostBreakdown, subscriptions int) *CategoryCost {
+	return &CategoryCost{
+		category:      category,
+		breakdown:     breakdown,
+		subscriptions: subscriptions,
+	}
+}
+
+/** Геттер для категории. */
+func (c *CategoryCost) Category() SubscriptionCategory {
+	return c.category
+}
+
+/** Разбивка стоимости: полная цена, скидки, итог. */
+func (c *CategoryCost) Breakdown() CostBreakdown {
+	return c.breakdown
+}
+
+/** Количество подписок категории, активных в периоде. */
+func (c *CategoryCost) Subscriptions() int {
+	return c.subscriptions
+}
+
+/*
+CategoryCostReport — траты за период в разрезе категорий, от самой
+дорогой категории к самой дешёвой.
+*/
+type CategoryCostReport struct {
+	period     DateRange
+	billing    BillingMode
+	pricing    PricingMode
+	categories []*CategoryCost
+}
+
+/** Создаёт отчёт и сортирует категории по убыванию итоговой суммы. */
+func NewCategoryCostReport(period DateRange, billing BillingMode, pricing PricingMode, categories []*CategoryCost) *CategoryCostReport {
+	sort.SliceStable(categories, func(i, j int) bool {
+		if categories[i].breakdown.Net() != categories[j].breakdown.Net() {
+			return categories[i].breakdown.Net() > categories[j].breakdown.Net()
+		}
+		return categories[i].category < categories[j].category
+	})
+
+	return &CategoryCostReport{
+		period:     period,
+		billing:    billing,
+		pricing:    pricing,
+		categories: categories,
+	}
+}
+
+/** Геттер для периода. */
+func (r *CategoryCostReport) Period() DateRange {
+	return r.period
+}
+
+/** Геттер для режима расчёта. */
+func (r *CategoryCostReport) BillingMode() BillingMode {
+	return r.billing
+}
+
+/** Геттер для источника цен. */
+func (r *CategoryCostReport) PricingMode() PricingMode {
+	return r.pricing
+}
+
+/** Категории с тратами. */
+func (r *CategoryCostReport) Categories() []*CategoryCost {
+	return r.categories
+}
+
+/** Общий итог по всем категориям. */
+func (r *CategoryCostReport) TotalCost() int {
+	total := 0
+	for _, category := range r.categories {
+		total += category.breakdown.Net()
+	}
+	return total
+}
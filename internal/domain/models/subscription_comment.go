@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** Ограничения на комментарий. */
+const (
+	MaxCommentAuthorLength = 255
+	MaxCommentBodyLength   = 4000
+)
+
+/*
+SubscriptionComment — заметка к подписке от пользователя или поддержки
+("отменена по телефону 12-го"). Комментарии только добавляются,
+поэтому вместе они образуют историю заметок.
+*/
+type SubscriptionComment struct {
+	id             uuid.UUID
+	subscriptionID uuid.UUID
+	author         string
+	body           string
+	createdAt      time.Time
+}
+
+/** Создаёт комментарий с новым ID и текущим временем. */
+func NewSubscriptionComment(subscriptionID uuid.UUID, author, body string) *SubscriptionComment {
+	return &SubscriptionComment{
+		id:             uuid.New(),
+		subscriptionID: subscriptionID,
+		author:         strings.TrimSpace(author),
+		body:           strings.TrimSpace(body),
+		createdAt:      time.Now(),
+	}
+}
+
+/** Восстанавливает комментарий из БД. */
+func RestoreSubscriptionComment(id, subscriptionID uuid.UUID, author, body string, createdAt time.Time) *SubscriptionComment {
+	return &SubscriptionComment{
+		id:             id,
+		subscriptionID: subscriptionID,
+		author:         author,
+		body:           body,
+		createdAt:      createdAt,
+	}
+}
+
+/** Геттер для ID комментария. */
+func (c *SubscriptionComment) ID() uuid.UUID {
+	return c.id
+}
+
+/** Геттер для ID подписки. */
+func (c *SubscriptionComment) SubscriptionID() uuid.UUID {
+	return c.subscriptionID
+}
+
+/** Геттер для автора. */
+func (c *SubscriptionComment) Author() string {
+	return c.author
+}
+
+/** Геттер для текста. */
+func (c *SubscriptionComment) Body() string {
+	return c.body
+}
+
+/** Геттер для времени создания. */
+func (c *SubscriptionComment) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+/** Проверяет автора и текст комментария. */
+func (c *SubscriptionComment) Validate() error {
+	if c.author == "" {
+		return errors.New("author cannot be empty")
+	}
+	if len([]rune(c.author)) > MaxCommentAuthorLength {
+		return errors.New("author is too long")
+	}
+	if c.body == "" {
+		return errors.New("body cannot be empty")
+	}
+	if len([]rune(c.body)) > MaxCommentBodyLength {
+		return errors.New("body is too long")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+/*
+DuplicateNameSimilarity — нижняя граница триграммного сходства (pg_trgm
+similarity, от 0 до 1) названий сервисов, при которой две пересекающиеся
+подписки считаются вероятными дублями: «Netflix» и «Netflix Premium»
+проходят, «Netflix» и «Spotify» — нет.
+*/
+const DuplicateNameSimilarity = 0.5
+
+/** DuplicateReason — почему две подписки похожи на дубль. */
+type DuplicateReason string
+
+const (
+	// DuplicateSameService — одинаковое название сервиса без учёта регистра.
+	DuplicateSameService DuplicateReason = "same_service"
+	// DuplicateSimilarName — названия различаются, но похожи по триграммам.
+	DuplicateSimilarName DuplicateReason = "similar_name"
+)
+
+/*
+SubscriptionDuplicate — пара подписок пользователя, которые действуют
+одновременно и называются одинаково или похоже: скорее всего, за один
+сервис платят дважды. first создан раньше second.
+*/
+type SubscriptionDuplicate struct {
+	first      *Subscription
+	second     *Subscription
+	similarity float64
+}
+
+/** Конструктор; similarity — сходство названий от 0 до 1. */
+func NewSubscriptionDuplicate(first, second *Subscription, similarity float64) *SubscriptionDuplicate {
+	return &SubscriptionDuplicate{
+		first:      first,
+		second:     second,
+		similarity: similarity,
+	}
+}
+
+/** Геттер для первой подписки пары. */
+func (d *SubscriptionDuplicate) First() *Subscription {
+	return d.first
+}
+
+/** Геттер для второй подписки пары. */
+func (d *SubscriptionDuplicate) Second() *Subscription {
+	return d.second
+}
+
+/** Сходство названий от 0 до 1; у одинаковых названий — 1. */
+func (d *SubscriptionDuplicate) Similarity() float64 {
+	return d.similarity
+}
+
+/** Совпадает ли название или только похоже. */
+func (d *SubscriptionDuplicate) Reason() DuplicateReason {
+	if strings.EqualFold(d.first.ServiceName(), d.second.ServiceName()) {
+		return DuplicateSameService
+	}
+	return DuplicateSimilarName
+}
+
+/*
+Overlap — период, когда действуют обе подписки. Конец nil — обе
+бессрочные, и пересечение не закончится само.
+*/
+func (d *SubscriptionDuplicate) Overlap() (from time.Time, to *time.Time) {
+	from = d.first.StartDate()
+	if d.second.StartDate().After(from) {
+		from = d.second.StartDate()
+	}
+
+	to = d.first.EndDate()
+	if end := d.second.EndDate(); end != nil && (to == nil || end.Before(*to)) {
+		to = end
+	}
+	return from, to
+}
+
+/** Сколько в месяц сэкономит отказ от более дешёвой подписки пары. */
+func (d *SubscriptionDuplicate) MonthlySavings() Money {
+	if cmp, err := d.first.Price().Cmp(d.second.Price()); err == nil && cmp > 0 {
+		return d.second.Price()
+	}
+	return d.first.Price()
+}
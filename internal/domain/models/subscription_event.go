@@ -0,0 +1,124 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** Типы доменных событий по подпискам. */
+const (
+	EventSubscriptionCreated = "subscription.created"
+	EventSubscriptionUpdated = "subscription.updated"
+	EventSubscriptionDeleted = "subscription.deleted"
+	// EventSubscriptionExpiring — напоминание о скором окончании подписки.
+	EventSubscriptionExpiring = "subscription.expiring"
+	// EventSubscriptionsBulkDeleted — одно событие на удаление всех подписок
+	// пользователя; ID удалённых подписок — в SubscriptionIDs.
+	EventSubscriptionsBulkDeleted = "subscription.bulk_deleted"
+	// EventCostAlertExceeded — траты пользователя за месяц превысили порог;
+	// событие относится к пользователю, а не к подписке.
+	EventCostAlertExceeded = "cost_alert.exceeded"
+)
+
+/*
+SubscriptionEvent — доменное событие об изменении подписки.
+По одному событию пишутся сразу три записи: сама запись в журнале событий,
+строка аудита и сообщение в outbox для доставки во внешние системы.
+*/
+type SubscriptionEvent struct {
+	id             uuid.UUID
+	eventType      string
+	subscriptionID uuid.UUID
+	userID         uuid.UUID
+	subscription   *Subscription
+	// subscriptionIDs — затронутые подписки агрегированного события;
+	// subscriptionID у такого события пустой.
+	subscriptionIDs []uuid.UUID
+	// costAlert — превышенный порог у события cost_alert.exceeded.
+	costAlert  *CostAlertStatus
+	occurredAt time.Time
+}
+
+/*
+*
+NewSubscriptionEvent создаёт событие по текущему состоянию подписки.
+Для удаления передаётся последнее известное состояние.
+*/
+func NewSubscriptionEvent(eventType string, subscription *Subscription) *SubscriptionEvent {
+	return &SubscriptionEvent{
+		id:             uuid.New(),
+		eventType:      eventType,
+		subscriptionID: subscription.ID(),
+		userID:         subscription.UserID(),
+		subscription:   subscription,
+		occurredAt:     time.Now(),
+	}
+}
+
+/** Создаёт агрегированное событие об удалении всех подписок пользователя. */
+func NewSubscriptionsBulkDeletedEvent(userID uuid.UUID, subscriptionIDs []uuid.UUID) *SubscriptionEvent {
+	return &SubscriptionEvent{
+		id:              uuid.New(),
+		eventType:       EventSubscriptionsBulkDeleted,
+		userID:          userID,
+		subscriptionIDs: subscriptionIDs,
+		occurredAt:      time.Now(),
+	}
+}
+
+/** Создаёт событие о превышении порога трат за месяц. */
+func NewCostAlertExceededEvent(status *CostAlertStatus) *SubscriptionEvent {
+	return &SubscriptionEvent{
+		id:         uuid.New(),
+		eventType:  EventCostAlertExceeded,
+		userID:     status.Alert().UserID(),
+		costAlert:  status,
+		occurredAt: time.Now(),
+	}
+}
+
+/** Геттер для ID события. */
+func (e *SubscriptionEvent) ID() uuid.UUID {
+	return e.id
+}
+
+/** Геттер для типа события. */
+func (e *SubscriptionEvent) Type() string {
+	return e.eventType
+}
+
+/** Геттер для ID подписки. */
+func (e *SubscriptionEvent) SubscriptionID() uuid.UUID {
+	return e.subscriptionID
+}
+
+/** Геттер для ID владельца подписки. */
+func (e *SubscriptionEvent) UserID() uuid.UUID {
+	return e.userID
+}
+
+/** Геттер для состояния подписки на момент события. */
+func (e *SubscriptionEvent) Subscription() *Subscription {
+	return e.subscription
+}
+
+/** Подписки агрегированного события; nil — событие по одной подписке. */
+func (e *SubscriptionEvent) SubscriptionIDs() []uuid.UUID {
+	return e.subscriptionIDs
+}
+
+/** Проверяет, относится ли событие сразу к нескольким подпискам пользователя. */
+func (e *SubscriptionEvent) IsAggregate() bool {
+	return e.subscriptionIDs != nil
+}
+
+/** Превышенный порог; nil — событие не о тратах. */
+func (e *SubscriptionEvent) CostAlert() *CostAlertStatus {
+	return e.costAlert
+}
+
+/** Геттер для времени события. */
+func (e *SubscriptionEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
@@ -19,6 +19,10 @@ type SubscriptionFilter struct {
 	startDate   *time.Time
 	endDate     *time.Time
 	isActive    *bool
+	tags        []string
+	category    *SubscriptionCategory
+	metadata    map[string]string
+	archived    bool
 }
 
 /** Создаёт пустой фильтр без условий. */
@@ -71,6 +75,42 @@ func (f *SubscriptionFilter) SetIsActive(isActive *bool) {
 	f.isActive = isActive
 }
 
+/** Геттер/сеттер для фильтра по тегам: подписка должна иметь все теги. */
+func (f *SubscriptionFilter) Tags() []string {
+	return f.tags
+}
+
+func (f *SubscriptionFilter) SetTags(tags []string) {
+	f.tags = tags
+}
+
+/** Геттер/сеттер для фильтра по категории. */
+func (f *SubscriptionFilter) Category() *SubscriptionCategory {
+	return f.category
+}
+
+func (f *SubscriptionFilter) SetCategory(category *SubscriptionCategory) {
+	f.category = category
+}
+
+/** Геттер/сеттер для фильтра по метаданным: все пары должны совпасть. */
+func (f *SubscriptionFilter) Metadata() map[string]string {
+	return f.metadata
+}
+
+func (f *SubscriptionFilter) SetMetadata(metadata map[string]string) {
+	f.metadata = metadata
+}
+
+/** Геттер/сеттер выбора архива: true — искать среди архивных подписок. */
+func (f *SubscriptionFilter) Archived() bool {
+	return f.archived
+}
+
+func (f *SubscriptionFilter) SetArchived(archived bool) {
+	f.archived = archived
+}
+
 /** Проверки, задано ли конкретное поле в фильтре. */
 func (f *SubscriptionFilter) HasUserID() bool {
 	return f.userID != nil
@@ -80,6 +120,18 @@ func (f *SubscriptionFilter) HasServiceName() bool {
 	return f.serviceName != nil && *f.serviceName != ""
 }
 
+func (f *SubscriptionFilter) HasTags() bool {
+	return len(f.tags) > 0
+}
+
+func (f *SubscriptionFilter) HasCategory() bool {
+	return f.category != nil
+}
+
+func (f *SubscriptionFilter) HasMetadata() bool {
+	return len(f.metadata) > 0
+}
+
 func (f *SubscriptionFilter) HasDateRange() bool {
 	return f.startDate != nil || f.endDate != nil
 }
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** Максимальная длина причины, по которой пользователю задан свой лимит. */
+const MaxSubscriptionLimitReasonLength = 500
+
+/*
+SubscriptionLimit — лимит числа подписок, заданный администратором
+отдельному пользователю вместо limits.max_subscriptions_per_user.
+MaxSubscriptions = 0 снимает ограничение: так доверенному импорту
+разрешают больше, чем остальным.
+*/
+type SubscriptionLimit struct {
+	userID           uuid.UUID
+	maxSubscriptions int
+	reason           string
+	updatedAt        time.Time
+}
+
+/** Конструктор. */
+func NewSubscriptionLimit(userID uuid.UUID, maxSubscriptions int, reason string) *SubscriptionLimit {
+	return &SubscriptionLimit{
+		userID:           userID,
+		maxSubscriptions: maxSubscriptions,
+		reason:           reason,
+		updatedAt:        time.Now().UTC(),
+	}
+}
+
+/** Восстанавливает лимит из БД. */
+func RestoreSubscriptionLimit(userID uuid.UUID, maxSubscriptions int, reason string, updatedAt time.Time) *SubscriptionLimit {
+	return &SubscriptionLimit{
+		userID:           userID,
+		maxSubscriptions: maxSubscriptions,
+		reason:           reason,
+		updatedAt:        updatedAt,
+	}
+}
+
+/** Проверяет инварианты лимита. */
+func (l *SubscriptionLimit) Validate() error {
+	if l.userID == uuid.Nil {
+		return errors.New("user_id cannot be empty")
+	}
+	if l.maxSubscriptions < 0 {
+		return errors.New("max_subscriptions cannot be negative")
+	}
+	if len([]rune(l.reason)) > MaxSubscriptionLimitReasonLength {
+		return errors.New("reason is too long")
+	}
+	return nil
+}
+
+/** Геттер для пользователя. */
+func (l *SubscriptionLimit) UserID() uuid.UUID {
+	return l.userID
+}
+
+/** Геттер для лимита; 0 — без ограничения. */
+func (l *SubscriptionLimit) MaxSubscriptions() int {
+	return l.maxSubscriptions
+}
+
+/** Геттер для причины. */
+func (l *SubscriptionLimit) Reason() string {
+	return l.reason
+}
+
+/** Геттер для времени последнего изменения. */
+func (l *SubscriptionLimit) UpdatedAt() time.Time {
+	return l.updatedAt
+}
+
+/*
+SubscriptionLimitUsage — действующий лимит пользователя и сколько подписок
+у него уже есть. override = nil — действует лимит из конфигурации.
+*/
+type SubscriptionLimitUsage struct {
+	userID   uuid.UUID
+	limit    int
+	override *SubscriptionLimit
+	used     int
+}
+
+/** Конструктор. */
+func NewSubscriptionLimitUsage(userID uuid.UUID, limit int, override *SubscriptionLimit, used int) *SubscriptionLimitUsage {
+	return &SubscriptionLimitUsage{
+		userID:   userID,
+		limit:    limit,
+		override: override,
+		used:     used,
+	}
+}
+
+/** Геттер для пользователя. */
+func (u *SubscriptionLimitUsage) UserID() uuid.UUID {
+	return u.userID
+}
+
+/** Геттер для действующего лимита; 0 — без ограничения. */
+func (u *SubscriptionLimitUsage) Limit() int {
+	return u.limit
+}
+
+/** Геттер для лимита, заданного администратором; nil — его нет. */
+func (u *SubscriptionLimitUsage) Override() *SubscriptionLimit {
+	return u.override
+}
+
+/** Геттер для числа подписок пользователя. */
+func (u *SubscriptionLimitUsage) Used() int {
+	return u.used
+}
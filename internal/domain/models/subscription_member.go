@@ -0,0 +1,80 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+SubscriptionMember — участник общей (семейной) подписки. Участник платит
+sharePercent процентов цены, владелец — остаток; в персональных расчётах
+трат каждый видит только свою долю.
+*/
+type SubscriptionMember struct {
+	subscriptionID uuid.UUID
+	userID         uuid.UUID
+	sharePercent   int
+	createdAt      time.Time
+}
+
+/** Создаёт участника с текущим временем. */
+func NewSubscriptionMember(subscriptionID, userID uuid.UUID, sharePercent int) *SubscriptionMember {
+	return &SubscriptionMember{
+		subscriptionID: subscriptionID,
+		userID:         userID,
+		sharePercent:   sharePercent,
+		createdAt:      time.Now(),
+	}
+}
+
+/** Восстанавливает участника из БД. */
+func RestoreSubscriptionMember(subscriptionID, userID uuid.UUID, sharePercent int, createdAt time.Time) *SubscriptionMember {
+	return &SubscriptionMember{
+		subscriptionID: subscriptionID,
+		userID:         userID,
+		sharePercent:   sharePercent,
+		createdAt:      createdAt,
+	}
+}
+
+/** Геттер для ID подписки. */
+func (m *SubscriptionMember) SubscriptionID() uuid.UUID {
+	return m.subscriptionID
+}
+
+/** Геттер для пользователя-участника. */
+func (m *SubscriptionMember) UserID() uuid.UUID {
+	return m.userID
+}
+
+/** Доля участника в цене, в процентах. */
+func (m *SubscriptionMember) SharePercent() int {
+	return m.sharePercent
+}
+
+/** Геттер для времени добавления. */
+func (m *SubscriptionMember) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+/** Проверяет пользователя и долю (1–100%). */
+func (m *SubscriptionMember) Validate() error {
+	if m.userID == uuid.Nil {
+		return errors.New("user ID cannot be empty")
+	}
+	if m.sharePercent < 1 || m.sharePercent > 100 {
+		return errors.New("share percent must be between 1 and 100")
+	}
+	return nil
+}
+
+/** Доля владельца: всё, что не разобрали участники. */
+func OwnerSharePercent(members []*SubscriptionMember) int {
+	share := 100
+	for _, member := range members {
+		share -= member.SharePercent()
+	}
+	return share
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	MaxNotesLength         = 2000
+	MaxPaymentMethodLength = 100
+	MaxMetadataKeys        = 32
+	MaxMetadataKeyLength   = 64
+	MaxMetadataValueLength = 256
+	MaxMetadataSize        = 4096
+)
+
+// Ключ метаданных попадает в query-параметр metadata.<key>, поэтому
+// допускаются только латиница, цифры, '_', '-' и '.'.
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+/** Проверяет длину заметки. */
+func ValidateNotes(notes string) error {
+	if len([]rune(notes)) > MaxNotesLength {
+		return fmt.Errorf("notes must be at most %d characters", MaxNotesLength)
+	}
+	return nil
+}
+
+/** Проверяет длину способа оплаты. */
+func ValidatePaymentMethod(paymentMethod string) error {
+	if len([]rune(paymentMethod)) > MaxPaymentMethodLength {
+		return fmt.Errorf("payment method must be at most %d characters", MaxPaymentMethodLength)
+	}
+	return nil
+}
+
+/*
+ValidateMetadata проверяет число ключей, формат и длину ключей и значений,
+а также размер метаданных в JSON — так они хранятся в БД.
+*/
+func ValidateMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxMetadataKeys {
+		return fmt.Errorf("at most %d keys are allowed", MaxMetadataKeys)
+	}
+
+	for key, value := range metadata {
+		if len(key) > MaxMetadataKeyLength {
+			return fmt.Errorf("key %q must be at most %d characters", key, MaxMetadataKeyLength)
+		}
+		if !metadataKeyPattern.MatchString(key) {
+			return fmt.Errorf("key %q may contain only letters, digits, '_', '-' and '.'", key)
+		}
+		if len([]rune(value)) > MaxMetadataValueLength {
+			return fmt.Errorf("value of %q must be at most %d characters", key, MaxMetadataValueLength)
+		}
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if len(encoded) > MaxMetadataSize {
+		return fmt.Errorf("metadata must be at most %d bytes as JSON", MaxMetadataSize)
+	}
+	return nil
+}
+
+/** Убирает пробелы по краям заметки. */
+func NormalizeNotes(notes string) string {
+	return strings.TrimSpace(notes)
+}
@@ -0,0 +1,99 @@
+package models
+
+import "sort"
+
+/*
+PaymentMethodCost — траты за период по одному способу оплаты: сумма по
+полной цене, скидки и число подписок. paymentMethod == nil — подписки,
+у которых способ оплаты не указан.
+*/
+type PaymentMethodCost struct {
+	paymentMethod *string
+	breakdown     CostBreakdown
+	subscriptions int
+}
+
+/** Конструктор. */
+func NewPaymentMethodCost(paymentMethod *string, breakdown CostBreakdown, subscriptions int) *PaymentMethodCost {
+	return &PaymentMethodCost{
+		paymentMethod: paymentMethod,
+		breakdown:     breakdown,
+		subscriptions: subscriptions,
+	}
+}
+
+/** Геттер для способа оплаты; nil — не указан. */
+func (c *PaymentMethodCost) PaymentMethod() *string {
+	return c.paymentMethod
+}
+
+/** Разбивка стоимости: полная цена, скидки, итог. */
+func (c *PaymentMethodCost) Breakdown() CostBreakdown {
+	return c.breakdown
+}
+
+/** Количество подписок со способом оплаты, активных в периоде. */
+func (c *PaymentMethodCost) Subscriptions() int {
+	return c.subscriptions
+}
+
+/*
+PaymentMethodCostReport — траты за период в разрезе способов оплаты, от
+самого дорогого к самому дешёвому; подписки без способа оплаты — в конце.
+*/
+type PaymentMethodCostReport struct {
+	period  DateRange
+	billing BillingMode
+	pricing PricingMode
+	methods []*PaymentMethodCost
+}
+
+/** Создаёт отчёт и сортирует способы оплаты по убыванию итоговой суммы. */
+func NewPaymentMethodCostReport(period DateRange, billing BillingMode, pricing PricingMode, methods []*PaymentMethodCost) *PaymentMethodCostReport {
+	sort.SliceStable(methods, func(i, j int) bool {
+		a, b := methods[i], methods[j]
+		if (a.paymentMethod == nil) != (b.paymentMethod == nil) {
+			return b.paymentMethod == nil
+		}
+		if a.breakdown.Net() != b.breakdown.Net() {
+			return a.breakdown.Net() > b.breakdown.Net()
+		}
+		return a.paymentMethod != nil && *a.paymentMethod < *b.paymentMethod
+	})
+
+	return &PaymentMethodCostReport{
+		period:  period,
+		billing: billing,
+		pricing: pricing,
+		methods: methods,
+	}
+}
+
+/** Геттер для периода. */
+func (r *PaymentMethodCostReport) Period() DateRange {
+	return r.period
+}
+
+/** Геттер для режима расчёта. */
+func (r *PaymentMethodCostReport) BillingMode() BillingMode {
+	return r.billing
+}
+
+/** Геттер для источника цен. */
+func (r *PaymentMethodCostReport) PricingMode() PricingMode {
+	return r.pricing
+}
+
+/** Способы оплаты с тратами. */
+func (r *PaymentMethodCostReport) PaymentMethods() []*PaymentMethodCost {
+	return r.methods
+}
+
+/** Общий итог по всем способам оплаты. */
+func (r *PaymentMethodCostReport) TotalCost() int {
+	total := 0
+	for _, method := range r.methods {
+		total += method.breakdown.Net()
+	}
+	return total
+}
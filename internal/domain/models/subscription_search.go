@@ -0,0 +1,267 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	MinSearchQueryLength = 2
+	MaxSearchQueryLength = 100
+
+	// searchTermSimilarity — порог сходства слова с термином запроса
+	// для подсветки; близок к порогу pg_trgm, чтобы подсвечивалось то,
+	// по чему запись нашлась.
+	searchTermSimilarity = 0.4
+	// searchFragmentWords — сколько слов вокруг первого совпадения
+	// оставлять во фрагменте длинного текста.
+	searchFragmentWords = 8
+
+	HighlightOpen  = "<mark>"
+	HighlightClose = "</mark>"
+)
+
+/** Поля подписки, по которым идёт поиск. */
+const (
+	SearchFieldServiceName = "service_name"
+	SearchFieldTags        = "tags"
+	SearchFieldNotes       = "notes"
+)
+
+/*
+SearchQuery — разобранная поисковая строка: исходный текст для БД и
+термины в нижнем регистре для подсветки.
+*/
+type SearchQuery struct {
+	text  string
+	terms []string
+}
+
+/** Разбирает строку запроса и проверяет её длину. */
+func ParseSearchQuery(raw string) (SearchQuery, error) {
+	text := strings.TrimSpace(raw)
+	length := len([]rune(text))
+	if length < MinSearchQueryLength || length > MaxSearchQueryLength {
+		return SearchQuery{}, fmt.Errorf("query must be between %d and %d characters", MinSearchQueryLength, MaxSearchQueryLength)
+	}
+
+	terms := strings.FieldsFunc(strings.ToLower(text), isNotWordRune)
+	if len(terms) == 0 {
+		return SearchQuery{}, fmt.Errorf("query must contain letters or digits")
+	}
+
+	return SearchQuery{text: text, terms: terms}, nil
+}
+
+/** Текст запроса. */
+func (q SearchQuery) Text() string {
+	return q.text
+}
+
+/** Термины запроса в нижнем регистре. */
+func (q SearchQuery) Terms() []string {
+	return q.terms
+}
+
+/** Фрагмент поля с подсвеченными совпадениями. */
+type SearchHighlight struct {
+	field    string
+	fragment string
+}
+
+/** Поле, в котором найдено совпадение. */
+func (h SearchHighlight) Field() string {
+	return h.field
+}
+
+/** Фрагмент текста, совпадения обёрнуты в <mark></mark>. */
+func (h SearchHighlight) Fragment() string {
+	return h.fragment
+}
+
+/*
+SubscriptionSearchHit — найденная подписка, её релевантность (0..1,
+больше — лучше) и подсвеченные фрагменты.
+*/
+type SubscriptionSearchHit struct {
+	subscription *Subscription
+	rank         float64
+	highlights   []SearchHighlight
+}
+
+/** Создаёт результат поиска без подсветки. */
+func NewSubscriptionSearchHit(subscription *Subscription, rank float64) *SubscriptionSearchHit {
+	return &SubscriptionSearchHit{
+		subscription: subscription,
+		rank:         rank,
+		highlights:   make([]SearchHighlight, 0),
+	}
+}
+
+/** Найденная подписка. */
+func (h *SubscriptionSearchHit) Subscription() *Subscription {
+	return h.subscription
+}
+
+/** Релевантность. */
+func (h *SubscriptionSearchHit) Rank() float64 {
+	return h.rank
+}
+
+/** Подсвеченные фрагменты. */
+func (h *SubscriptionSearchHit) Highlights() []SearchHighlight {
+	return h.highlights
+}
+
+/*
+Highlight ищет термины запроса в названии, тегах и заметке подписки.
+Слово совпадает, если начинается с термина или похоже на него по
+триграммам — так подсвечиваются и опечатки ("netflx" → Netflix).
+*/
+func (h *SubscriptionSearchHit) Highlight(query SearchQuery) {
+	h.highlights = make([]SearchHighlight, 0)
+	sub := h.subscription
+
+	if fragment, ok := highlightText(sub.ServiceName(), query.terms, 0); ok {
+		h.highlights = append(h.highlights, SearchHighlight{field: SearchFieldServiceName, fragment: fragment})
+	}
+	for _, tag := range sub.Tags() {
+		if fragment, ok := highlightText(tag, query.terms, 0); ok {
+			h.highlights = append(h.highlights, SearchHighlight{field: SearchFieldTags, fragment: fragment})
+		}
+	}
+	if fragment, ok := highlightText(sub.Notes(), query.terms, searchFragmentWords); ok {
+		h.highlights = append(h.highlights, SearchHighlight{field: SearchFieldNotes, fragment: fragment})
+	}
+}
+
+type textSegment struct {
+	text  string
+	word  bool
+	match bool
+}
+
+/*
+highlightText оборачивает совпавшие слова в <mark>. window > 0 обрезает
+текст до window слов по обе стороны от первого совпадения.
+*/
+func highlightText(text string, terms []string, window int) (string, bool) {
+	segments := splitWords(text)
+
+	first := -1
+	for i := range segments {
+		if segments[i].word && matchesAnyTerm(segments[i].text, terms) {
+			segments[i].match = true
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	from, to := 0, len(segments)
+	if window > 0 {
+		from = wordOffset(segments, first, -window)
+		to = wordOffset(segments, first, window) + 1
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	for _, segment := range segments[from:to] {
+		if segment.match {
+			b.WriteString(HighlightOpen + segment.text + HighlightClose)
+		} else {
+			b.WriteString(segment.text)
+		}
+	}
+	if to < len(segments) {
+		b.WriteString("…")
+	}
+
+	return strings.TrimSpace(b.String()), true
+}
+
+// wordOffset возвращает индекс сегмента, отстоящего от start на words слов.
+func wordOffset(segments []textSegment, start, words int) int {
+	step := 1
+	if words < 0 {
+		step, words = -1, -words
+	}
+
+	i := start
+	for words > 0 && i+step >= 0 && i+step < len(segments) {
+		i += step
+		if segments[i].word {
+			words--
+		}
+	}
+	return i
+}
+
+// splitWords делит текст на слова и разделители между ними.
+func splitWords(text string) []textSegment {
+	var segments []textSegment
+	var current strings.Builder
+	currentWord := false
+
+	for _, r := range text {
+		word := !isNotWordRune(r)
+		if current.Len() > 0 && word != currentWord {
+			segments = append(segments, textSegment{text: current.String(), word: currentWord})
+			current.Reset()
+		}
+		current.WriteRune(r)
+		currentWord = word
+	}
+	if current.Len() > 0 {
+		segments = append(segments, textSegment{text: current.String(), word: currentWord})
+	}
+	return segments
+}
+
+func matchesAnyTerm(word string, terms []string) bool {
+	word = strings.ToLower(word)
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) || trigramSimilarity(word, term) >= searchTermSimilarity {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+trigramSimilarity — сходство слов как в pg_trgm: слово дополняется двумя
+пробелами слева и одним справа, результат — доля общих триграмм.
+*/
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	common := 0
+	for t := range ta {
+		if tb[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+func trigrams(word string) map[string]bool {
+	runes := []rune("  " + word + " ")
+	set := make(map[string]bool, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = true
+	}
+	return set
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+/** UserSpend — суммарные траты одного пользователя за период. */
+type UserSpend struct {
+	userID        uuid.UUID
+	totalCost     int
+	subscriptions int
+}
+
+/** Конструктор строки отчёта. */
+func NewUserSpend(userID uuid.UUID, totalCost, subscriptions int) *UserSpend {
+	return &UserSpend{
+		userID:        userID,
+		totalCost:     totalCost,
+		subscriptions: subscriptions,
+	}
+}
+
+/** Геттер для ID пользователя. */
+func (u *UserSpend) UserID() uuid.UUID {
+	return u.userID
+}
+
+/** Геттер для суммы трат. */
+func (u *UserSpend) TotalCost() int {
+	return u.totalCost
+}
+
+/** Геттер для числа подписок, попавших в период. */
+func (u *UserSpend) Subscriptions() int {
+	return u.subscriptions
+}
+
+/*
+UserSpendRange — диапазон user_id [from, to), по которому считается
+одна часть отчёта. to == nil означает "до конца пространства UUID".
+*/
+type UserSpendRange struct {
+	from uuid.UUID
+	to   *uuid.UUID
+}
+
+/*
+*
+SplitUserIDSpace делит пространство UUID на n непрерывных диапазонов
+равной ширины по старшим 8 байтам. Диапазоны по user_id используют
+индекс, в отличие от шардирования по хэшу.
+*/
+func SplitUserIDSpace(n int) []UserSpendRange {
+	if n < 1 {
+		n = 1
+	}
+
+	step := ^uint64(0)/uint64(n) + 1
+	ranges := make([]UserSpendRange, n)
+	for i := 0; i < n; i++ {
+		ranges[i].from = uuidFromPrefix(uint64(i) * step)
+		if i < n-1 {
+			to := uuidFromPrefix(uint64(i+1) * step)
+			ranges[i].to = &to
+		}
+	}
+	return ranges
+}
+
+func uuidFromPrefix(prefix uint64) uuid.UUID {
+	var id uuid.UUID
+	for i := 0; i < 8; i++ {
+		id[i] = byte(prefix >> (56 - 8*i))
+	}
+	return id
+}
+
+/** Геттер для нижней границы (включительно). */
+func (r UserSpendRange) From() uuid.UUID {
+	return r.from
+}
+
+/** Геттер для верхней границы (не включительно), nil — без ограничения. */
+func (r UserSpendRange) To() *uuid.UUID {
+	return r.to
+}
+
+/*
+UserSpendReport — отчёт по тратам всех пользователей за период.
+Строки отсортированы по убыванию трат.
+*/
+type UserSpendReport struct {
+	period    DateRange
+	billing   BillingMode
+	users     []*UserSpend
+	totalCost int
+}
+
+/** Собирает отчёт из частичных результатов и сортирует строки. */
+func NewUserSpendReport(period DateRange, billing BillingMode, users []*UserSpend) *UserSpendReport {
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].totalCost != users[j].totalCost {
+			return users[i].totalCost > users[j].totalCost
+		}
+		return users[i].userID.String() < users[j].userID.String()
+	})
+
+	total := 0
+	for _, user := range users {
+		total += user.totalCost
+	}
+
+	return &UserSpendReport{
+		period:    period,
+		billing:   billing,
+		users:     users,
+		totalCost: total,
+	}
+}
+
+/** Геттер для периода отчёта. */
+func (r *UserSpendReport) Period() DateRange {
+	return r.period
+}
+
+/** Геттер для режима расчёта трат. */
+func (r *UserSpendReport) BillingMode() BillingMode {
+	return r.billing
+}
+
+/** Геттер для всех строк отчёта. */
+func (r *UserSpendReport) Users() []*UserSpend {
+	return r.users
+}
+
+/** Геттер для суммы трат всех пользователей. */
+func (r *UserSpendReport) TotalCost() int {
+	return r.totalCost
+}
+
+/** Возвращает страницу строк отчёта. */
+func (r *UserSpendReport) Page(limit, offset int) []*UserSpend {
+	if offset >= len(r.users) {
+		return []*UserSpend{}
+	}
+	end := offset + limit
+	if end > len(r.users) {
+		end = len(r.users)
+	}
+	return r.users[offset:end]
+}
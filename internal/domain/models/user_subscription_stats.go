@@ -0,0 +1,143 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/** Краткая ссылка на подписку в сводке: ID, сервис, цена и дата окончания. */
+type SubscriptionRef struct {
+	id          uuid.UUID
+	serviceName string
+	price       Money
+	endDate     *time.Time
+}
+
+/** Конструктор. */
+func NewSubscriptionRef(id uuid.UUID, serviceName string, price Money, endDate *time.Time) *SubscriptionRef {
+	return &SubscriptionRef{
+		id:          id,
+		serviceName: serviceName,
+		price:       price,
+		endDate:     endDate,
+	}
+}
+
+/** Геттер для ID подписки. */
+func (r *SubscriptionRef) ID() uuid.UUID {
+	return r.id
+}
+
+/** Геттер для названия сервиса. */
+func (r *SubscriptionRef) ServiceName() string {
+	return r.serviceName
+}
+
+/** Геттер для месячной цены. */
+func (r *SubscriptionRef) Price() Money {
+	return r.price
+}
+
+/** Геттер для даты окончания; nil — бессрочная. */
+func (r *SubscriptionRef) EndDate() *time.Time {
+	return r.endDate
+}
+
+/*
+UserSubscriptionStats — сводка по подпискам пользователя на момент at:
+- активные (уже начались и не закончились), истёкшие и будущие;
+- monthlySpend — сумма текущих цен активных подписок;
+- averagePrice — средняя цена по всем подпискам;
+- mostExpensive — самая дорогая активная подписка;
+- nextEnding — подписка, которая закончится раньше остальных.
+*/
+type UserSubscriptionStats struct {
+	userID        uuid.UUID
+	at            time.Time
+	total         int
+	active        int
+	expired       int
+	upcoming      int
+	monthlySpend  int
+	averagePrice  float64
+	mostExpensive *SubscriptionRef
+	nextEnding    *SubscriptionRef
+}
+
+/** Создаёт пустую сводку. */
+func NewUserSubscriptionStats(userID uuid.UUID, at time.Time) *UserSubscriptionStats {
+	return &UserSubscriptionStats{
+		userID: userID,
+		at:     at,
+	}
+}
+
+/** Заполняет количество подписок по статусам. */
+func (s *UserSubscriptionStats) SetCounts(total, active, expired, upcoming int) {
+	s.total = total
+	s.active = active
+	s.expired = expired
+	s.upcoming = upcoming
+}
+
+/** Заполняет траты: сумму активных подписок в месяц и среднюю цену. */
+func (s *UserSubscriptionStats) SetSpend(monthlySpend int, averagePrice float64) {
+	s.monthlySpend = monthlySpend
+	s.averagePrice = averagePrice
+}
+
+func (s *UserSubscriptionStats) SetMostExpensive(ref *SubscriptionRef) {
+	s.mostExpensive = ref
+}
+
+func (s *UserSubscriptionStats) SetNextEnding(ref *SubscriptionRef) {
+	s.nextEnding = ref
+}
+
+/** Геттер для пользователя. */
+func (s *UserSubscriptionStats) UserID() uuid.UUID {
+	return s.userID
+}
+
+/** Момент, на который посчитана сводка. */
+func (s *UserSubscriptionStats) At() time.Time {
+	return s.at
+}
+
+/** Количество подписок по статусам. */
+func (s *UserSubscriptionStats) Total() int {
+	return s.total
+}
+
+func (s *UserSubscriptionStats) Active() int {
+	return s.active
+}
+
+func (s *UserSubscriptionStats) Expired() int {
+	return s.expired
+}
+
+func (s *UserSubscriptionStats) Upcoming() int {
+	return s.upcoming
+}
+
+/** Сумма текущих цен активных подписок. */
+func (s *UserSubscriptionStats) MonthlySpend() int {
+	return s.monthlySpend
+}
+
+/** Средняя цена по всем подпискам, с точностью до копеек. */
+func (s *UserSubscriptionStats) AveragePrice() float64 {
+	return s.averagePrice
+}
+
+/** Самая дорогая активная подписка; nil — активных нет. */
+func (s *UserSubscriptionStats) MostExpensive() *SubscriptionRef {
+	return s.mostExpensive
+}
+
+/** Ближайшая по дате окончания подписка; nil — все бессрочные или истекли. */
+func (s *UserSubscriptionStats) NextEnding() *SubscriptionRef {
+	return s.nextEnding
+}
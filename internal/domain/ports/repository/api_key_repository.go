@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	// GetByHash возвращает ключ по SHA-256 (и отозванный тоже); nil — ключа нет.
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	List(ctx context.Context) ([]*models.APIKey, error)
+	// Revoke отзывает ключ; повторный отзыв — конфликт.
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) (*models.APIKey, error)
+}
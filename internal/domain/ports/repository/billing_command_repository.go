@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+// BillingCommandRepository хранит итоги обработанных команд биллинга.
+type BillingCommandRepository interface {
+	// Get возвращает nil, если команда ещё не обрабатывалась.
+	Get(ctx context.Context, commandID string) (*models.BillingCommandResult, error)
+	// Save сохраняет итог; false — итог команды уже сохранён (повторная
+	// доставка обработана параллельно), новый не записан.
+	Save(ctx context.Context, result *models.BillingCommandResult) (bool, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type CalendarFeedRepository interface {
+	// Save записывает токен пользователя, заменяя прежний.
+	Save(ctx context.Context, token *models.CalendarFeedToken) error
+	GetByUser(ctx context.Context, userID uuid.UUID) (*models.CalendarFeedToken, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
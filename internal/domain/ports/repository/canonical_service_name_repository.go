@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type CanonicalServiceNameRepository interface {
+	Create(ctx context.Context, entry *models.CanonicalServiceName) error
+	List(ctx context.Context) ([]*models.CanonicalServiceName, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindVariants — ID подписок после afterID (по возрастанию), чей ключ
+	// названия входит в keys, а само название отличается от canonical.
+	FindVariants(ctx context.Context, keys []string, canonical string, afterID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type ConfigFingerprintRepository interface {
+	Upsert(ctx context.Context, fingerprint *models.ConfigFingerprint) error
+	ListReportedSince(ctx context.Context, since time.Time) ([]*models.ConfigFingerprint, error)
+}
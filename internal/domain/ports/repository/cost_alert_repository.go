@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type CostAlertRepository interface {
+	Create(ctx context.Context, alert *models.CostAlert) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CostAlert, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.CostAlert, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListUnnotified — пороги без уведомления за month с ID больше afterID,
+	// по возрастанию ID.
+	ListUnnotified(ctx context.Context, month time.Time, afterID uuid.UUID, limit int) ([]*models.CostAlert, error)
+	// MarkNotified возвращает false, если уведомление за month уже записано.
+	MarkNotified(ctx context.Context, alertID uuid.UUID, month time.Time, spent int, eventID uuid.UUID) (bool, error)
+	// NotifiedAt — время уведомлений за month по порогам пользователя.
+	NotifiedAt(ctx context.Context, userID uuid.UUID, month time.Time) (map[uuid.UUID]time.Time, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type DeadLetterRepository interface {
+	// CreateForEvent сохраняет событие подписки, которое не удалось доставить.
+	CreateForEvent(ctx context.Context, event *models.SubscriptionEvent, source, reason string) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.DeadLetter, error)
+	List(ctx context.Context, filter models.DeadLetterFilter, limit, offset int) ([]*models.DeadLetter, error)
+	Count(ctx context.Context, filter models.DeadLetterFilter) (int, error)
+	// Requeue возвращает сообщение в outbox (и в журнал событий, если его
+	// там нет) и отмечает запись как повторно поставленную в очередь.
+	Requeue(ctx context.Context, id uuid.UUID, at time.Time) (*models.DeadLetter, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type DiscountRepository interface {
+	Create(ctx context.Context, discount *models.Discount) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Discount, error)
+	GetByCode(ctx context.Context, code string) (*models.Discount, error)
+	List(ctx context.Context) ([]*models.Discount, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
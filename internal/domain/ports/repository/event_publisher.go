@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+// EventPublisher раздаёт доменные события потребителям внутри процесса
+// (WebSocket, метрики, вебхуки). Сервисы публикуют событие только после
+// коммита изменения и не знают, кто и как его доставит.
+type EventPublisher interface {
+	// Publish не блокирует вызывающего; доставка асинхронная и без гарантий:
+	// надёжная доставка во внешние системы идёт через outbox.
+	Publish(ctx context.Context, event models.DomainEvent)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "context"
+
+// LockProvider выдаёт именованные блокировки, общие для всех реплик.
+type LockProvider interface {
+	// TryLock захватывает блокировку name без ожидания; nil без ошибки —
+	// блокировку держит другой экземпляр.
+	TryLock(ctx context.Context, name string) (Lock, error)
+}
+
+// Lock — захваченная блокировка.
+type Lock interface {
+	// Lost закрывается, если блокировка потеряна до Release (например,
+	// оборвалось соединение с БД) и другая реплика может её захватить.
+	Lost() <-chan struct{}
+	// Release освобождает блокировку. Повторный вызов ничего не делает.
+	Release(ctx context.Context) error
+}
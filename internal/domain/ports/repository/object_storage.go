@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ObjectStorage хранит файлы для скачивания (выгрузки) вне БД: в S3 или
+// совместимом хранилище. Клиент скачивает файл по подписанной ссылке
+// напрямую из хранилища, минуя сервис.
+type ObjectStorage interface {
+	// Put сохраняет size байт из body под ключом key, заменяя прежний объект.
+	Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	// PresignGet возвращает ссылку на скачивание key без авторизации,
+	// действующую ttl. Существование объекта не проверяется.
+	PresignGet(key string, ttl time.Duration) (string, error)
+	// List возвращает все объекты, ключи которых начинаются с prefix.
+	List(ctx context.Context, prefix string) ([]StoredObject, error)
+	// Delete удаляет объект; удаление отсутствующего объекта — не ошибка.
+	Delete(ctx context.Context, key string) error
+}
+
+// StoredObject — объект в хранилище.
+type StoredObject struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "context"
+
+type PartitionRepository interface {
+	DefaultPartitionYears(ctx context.Context) ([]int, error)
+	EnsureYearPartition(ctx context.Context, year int) (bool, error)
+}
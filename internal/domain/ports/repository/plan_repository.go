@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type PlanRepository interface {
+	Create(ctx context.Context, plan *models.Plan) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Plan, error)
+	List(ctx context.Context, limit, offset int) ([]*models.Plan, error)
+	Update(ctx context.Context, plan *models.Plan) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	AddPrice(ctx context.Context, price *models.PlanPrice) error
+	ListPrices(ctx context.Context, planID uuid.UUID) ([]*models.PlanPrice, error)
+}
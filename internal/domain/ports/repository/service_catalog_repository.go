@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type ServiceCatalogRepository interface {
+	Create(ctx context.Context, service *models.CatalogService) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.CatalogService, error)
+	List(ctx context.Context, limit, offset int) ([]*models.CatalogService, error)
+	Update(ctx context.Context, service *models.CatalogService) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Suggest(ctx context.Context, query string, limit int) ([]*models.CatalogService, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type ServiceNameRuleRepository interface {
+	Create(ctx context.Context, rule *models.ServiceNameRule) error
+	List(ctx context.Context) ([]*models.ServiceNameRule, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type SubscriptionCommentRepository interface {
+	Create(ctx context.Context, comment *models.SubscriptionComment) error
+	ListBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*models.SubscriptionComment, error)
+	CountBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID) (int, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+// SubscriptionEventRepository сохраняет событие, строку аудита и запись outbox.
+type SubscriptionEventRepository interface {
+	Record(ctx context.Context, event *models.SubscriptionEvent) error
+	// MarkExpiryReminded отмечает напоминание об окончании подписки в endDate;
+	// false — напоминание уже было отправлено.
+	MarkExpiryReminded(ctx context.Context, subscriptionID uuid.UUID, endDate time.Time, eventID uuid.UUID) (bool, error)
+}
+
+// Transactor выполняет fn в одной транзакции; репозитории, вызванные с
+// переданным контекстом, участвуют в ней автоматически.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// InTransaction — ctx уже несёт открытую транзакцию.
+	InTransaction(ctx context.Context) bool
+	// AfterCommit выполняет fn после коммита транзакции из ctx или сразу,
+	// если транзакции нет.
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
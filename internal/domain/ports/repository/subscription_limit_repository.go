@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type SubscriptionLimitRepository interface {
+	// Save записывает лимит пользователя, заменяя прежний.
+	Save(ctx context.Context, limit *models.SubscriptionLimit) error
+	// GetByUser возвращает лимит пользователя; nil — лимит не задан.
+	GetByUser(ctx context.Context, userID uuid.UUID) (*models.SubscriptionLimit, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type SubscriptionMemberRepository interface {
+	Add(ctx context.Context, member *models.SubscriptionMember) error
+	ListBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID) ([]*models.SubscriptionMember, error)
+	Remove(ctx context.Context, subscriptionID, userID uuid.UUID) error
+}
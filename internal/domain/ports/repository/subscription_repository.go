@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
@@ -12,9 +13,29 @@ type SubscriptionRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Subscription, error)
 	GetAll(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) ([]*models.Subscription, error)
+	GetAllWithTotal(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) ([]*models.Subscription, int, error)
+	IterateAll(ctx context.Context, filter *models.SubscriptionFilter, fn func(*models.Subscription) error) error
+	Search(ctx context.Context, query models.SearchQuery, userID *uuid.UUID, limit, offset int) ([]*models.SubscriptionSearchHit, error)
 	Update(ctx context.Context, subscription *models.Subscription) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	GetTotalCostForPeriod(ctx context.Context, filter *models.SubscriptionFilter, period *models.DatePeriod) (int, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetTotalCostForPeriod(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) (models.CostBreakdown, error)
+	GetCostByCategory(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) ([]*models.CategoryCost, error)
+	GetCostByPaymentMethod(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) ([]*models.PaymentMethodCost, error)
 	Count(ctx context.Context, filter *models.SubscriptionFilter) (int, error)
+	GetUserStats(ctx context.Context, userID uuid.UUID, at time.Time) (*models.UserSubscriptionStats, error)
+	GetExpiring(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*models.Subscription, error)
+	FindDuplicates(ctx context.Context, userID uuid.UUID, minSimilarity float64) ([]*models.SubscriptionDuplicate, error)
+	GetDueExpiryReminders(ctx context.Context, from, to time.Time, limit int) ([]*models.Subscription, error)
+	ArchiveEnded(ctx context.Context, before time.Time, limit int) (int, error)
 	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+	GetCalendar(ctx context.Context, userID uuid.UUID, year int, billing models.BillingMode, pricing models.PricingMode) (*models.SubscriptionCalendar, error)
+	GetForecast(ctx context.Context, userID uuid.UUID, from time.Time, months int, billing models.BillingMode) (*models.CostForecast, error)
+	GetBusinessKPIs(ctx context.Context, period models.DateRange, billing models.BillingMode) (*models.BusinessKPIs, error)
+	RecordPriceChange(ctx context.Context, change *models.PriceChange) error
+	GetPriceHistory(ctx context.Context, subscriptionID uuid.UUID) ([]*models.PriceChange, error)
+	GetUserSpendForRange(ctx context.Context, period models.DateRange, billing models.BillingMode, userRange models.UserSpendRange) ([]*models.UserSpend, error)
+	GetTopServices(ctx context.Context, period models.DateRange, billing models.BillingMode, sortBy models.TopServicesSort, limit int) ([]*models.ServiceStats, error)
+	GetMRR(ctx context.Context, period models.DateRange) ([]*models.MRRPoint, error)
+	GetChurn(ctx context.Context, period models.DateRange) ([]*models.ChurnPoint, error)
 }
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type AnalyticsService interface {
+	GetTopServices(ctx context.Context, startDate, endDate, sortBy string, limit int, billing *models.BillingMode) (*models.TopServicesReport, error)
+	GetMRR(ctx context.Context, startDate, endDate string) (*models.MRRReport, error)
+	GetChurn(ctx context.Context, startDate, endDate string) (*models.ChurnReport, error)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type ArtifactService interface {
+	// Store выкладывает size байт из body в хранилище под новым ключом вида
+	// kind и возвращает подписанную ссылку на скачивание.
+	Store(ctx context.Context, kind models.ArtifactKind, filename, contentType string, body io.Reader, size int64) (*models.Artifact, error)
+	// CleanupExpired удаляет файлы старше срока хранения и возвращает их число.
+	CleanupExpired(ctx context.Context) (int, error)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type AuthService interface {
+	// Authenticate определяет клиента по API-ключу или bearer-токену (JWT);
+	// без учётных данных или с неверными — UNAUTHORIZED.
+	Authenticate(ctx context.Context, apiKey, bearerToken string) (*models.Principal, error)
+	// CreateAPIKey возвращает ключ и его открытое значение — оно больше нигде не хранится.
+	CreateAPIKey(ctx context.Context, name, role string) (*models.APIKey, string, error)
+	ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+}
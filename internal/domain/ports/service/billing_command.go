@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+// BillingCommandService применяет команды legacy-биллинга идемпотентно по
+// commandID. Ошибка означает временный сбой — команду нужно повторить;
+// отказ по бизнес-правилам возвращается итогом со статусом failed.
+type BillingCommandService interface {
+	CreateSubscription(ctx context.Context, commandID string, serviceName string, price int, userID uuid.UUID, startDate string, endDate *string, planID *uuid.UUID) (*models.BillingCommandResult, error)
+	// CancelSubscription задаёт дату окончания подписки; endDate == nil —
+	// текущий месяц.
+	CancelSubscription(ctx context.Context, commandID string, subscriptionID, userID uuid.UUID, endDate *string) (*models.BillingCommandResult, error)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type CalendarFeedService interface {
+	// IssueToken выпускает новый токен ленты и возвращает его открытое значение.
+	IssueToken(ctx context.Context, userID uuid.UUID) (*models.CalendarFeedToken, string, error)
+	RevokeToken(ctx context.Context, userID uuid.UUID) error
+	// GetFeed проверяет токен и собирает ленту на CalendarFeedMonths месяцев.
+	GetFeed(ctx context.Context, userID uuid.UUID, token string) (*models.CalendarFeed, error)
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type CanonicalServiceNameService interface {
+	AddEntry(ctx context.Context, name string, aliases []string) (*models.CanonicalServiceName, error)
+	ListEntries(ctx context.Context) ([]*models.CanonicalServiceName, error)
+	DeleteEntry(ctx context.Context, id uuid.UUID) error
+}
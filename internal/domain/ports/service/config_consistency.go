@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type ConfigConsistencyService interface {
+	Publish(ctx context.Context) error
+	Check(ctx context.Context) (*models.ConfigConsistencyReport, error)
+	Run(ctx context.Context, interval time.Duration)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type CostAlertService interface {
+	CreateAlert(ctx context.Context, userID uuid.UUID, monthlyLimit int) (*models.CostAlert, error)
+	ListAlerts(ctx context.Context, userID uuid.UUID) ([]*models.CostAlert, error)
+	DeleteAlert(ctx context.Context, userID, id uuid.UUID) error
+	GetAlertStatuses(ctx context.Context, userID uuid.UUID) ([]*models.CostAlertStatus, error)
+	EvaluateAlerts(ctx context.Context) (int, error)
+}
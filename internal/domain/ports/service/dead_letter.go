@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type DeadLetterService interface {
+	ListDeadLetters(ctx context.Context, source, eventType, status string, limit, offset int) ([]*models.DeadLetter, int, error)
+	RetryDeadLetter(ctx context.Context, id uuid.UUID) (*models.DeadLetter, error)
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type DiscountService interface {
+	CreateDiscount(ctx context.Context, code string, kind models.DiscountKind, amount int, serviceName *string, validFrom string, validTo *string) (*models.Discount, error)
+	GetDiscount(ctx context.Context, id uuid.UUID) (*models.Discount, error)
+	ListDiscounts(ctx context.Context) ([]*models.Discount, error)
+	DeleteDiscount(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,7 @@
+package service
+
+import "context"
+
+type ExpiryReminderService interface {
+	SendExpiryReminders(ctx context.Context) (int, error)
+}
@@ -0,0 +1,7 @@
+package service
+
+import "context"
+
+type PartitionMaintenanceService interface {
+	EnsurePartitions(ctx context.Context) (int, error)
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type PlanService interface {
+	CreatePlan(ctx context.Context, name, serviceName string, price int, billingCycle models.BillingCycle, features map[string]interface{}) (*models.Plan, error)
+	GetPlan(ctx context.Context, id uuid.UUID) (*models.Plan, error)
+	ListPlans(ctx context.Context, limit, offset int) ([]*models.Plan, error)
+	UpdatePlan(ctx context.Context, id uuid.UUID, name, serviceName *string, price *int, billingCycle *models.BillingCycle, features map[string]interface{}) (*models.Plan, error)
+	DeletePlan(ctx context.Context, id uuid.UUID) error
+	GetPriceHistory(ctx context.Context, id uuid.UUID) ([]*models.PlanPrice, error)
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type ServiceCatalogService interface {
+	CreateCatalogService(ctx context.Context, name string, category *string, website, iconURL string, typicalPrice *int) (*models.CatalogService, error)
+	GetCatalogService(ctx context.Context, id uuid.UUID) (*models.CatalogService, error)
+	ListCatalogServices(ctx context.Context, limit, offset int) ([]*models.CatalogService, error)
+	UpdateCatalogService(ctx context.Context, id uuid.UUID, name, category, website, iconURL *string, typicalPrice *int) (*models.CatalogService, error)
+	DeleteCatalogService(ctx context.Context, id uuid.UUID) error
+	SuggestCatalogServices(ctx context.Context, query string, limit int) ([]*models.CatalogService, error)
+}
@@ -0,0 +1,7 @@
+package service
+
+import "context"
+
+type ServiceNameNormalizationService interface {
+	NormalizeExisting(ctx context.Context) (int, error)
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type ServiceNameRuleService interface {
+	AddRule(ctx context.Context, list models.ServiceNameList, match models.ServiceNameMatch, pattern, reason string) (*models.ServiceNameRule, error)
+	ListRules(ctx context.Context) ([]*models.ServiceNameRule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+}
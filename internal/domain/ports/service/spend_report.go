@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type SpendReportService interface {
+	BuildUserSpendReport(ctx context.Context, startDate, endDate string, billing *models.BillingMode) (*models.UserSpendReport, error)
+}
@@ -0,0 +1,7 @@
+package service
+
+import "context"
+
+type SubscriptionArchiveService interface {
+	ArchiveEnded(ctx context.Context) (int, error)
+}
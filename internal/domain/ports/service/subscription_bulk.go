@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type SubscriptionBulkService interface {
+	UpdateSubscriptions(ctx context.Context, patches []*models.SubscriptionPatch) (*models.BulkUpdateResult, error)
+	UpdateByFilter(ctx context.Context, filter *models.SubscriptionFilter, patch *models.SubscriptionPatch, dryRun bool) (*models.BulkFilterUpdateResult, error)
+}
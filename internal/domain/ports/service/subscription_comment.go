@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type SubscriptionCommentService interface {
+	AddComment(ctx context.Context, subscriptionID uuid.UUID, author, body string) (*models.SubscriptionComment, error)
+	ListComments(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*models.SubscriptionComment, int, error)
+}
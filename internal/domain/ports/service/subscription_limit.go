@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type SubscriptionLimitService interface {
+	// GetSubscriptionLimit возвращает действующий лимит пользователя и
+	// число его подписок.
+	GetSubscriptionLimit(ctx context.Context, userID uuid.UUID) (*models.SubscriptionLimitUsage, error)
+	// SetSubscriptionLimit задаёт пользователю свой лимит (0 — без ограничения)
+	// и возвращает его вместе с числом подписок.
+	SetSubscriptionLimit(ctx context.Context, userID uuid.UUID, maxSubscriptions int, reason string) (*models.SubscriptionLimitUsage, error)
+	// ResetSubscriptionLimit возвращает пользователю лимит из конфигурации.
+	ResetSubscriptionLimit(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type SubscriptionMemberService interface {
+	AddMember(ctx context.Context, subscriptionID, userID uuid.UUID, sharePercent int) (*models.SubscriptionMember, error)
+	ListMembers(ctx context.Context, subscriptionID uuid.UUID) ([]*models.SubscriptionMember, error)
+	RemoveMember(ctx context.Context, subscriptionID, userID uuid.UUID) error
+}
@@ -8,12 +8,25 @@ import (
 )
 
 type SubscriptionService interface {
-	CreateSubscription(ctx context.Context, serviceName string, price int, userID uuid.UUID, startDate string, endDate *string) (*models.Subscription, error)
+	CreateSubscription(ctx context.Context, serviceName string, price int, userID uuid.UUID, startDate string, endDate *string, promoCode *string, planID *uuid.UUID, catalogID *uuid.UUID, tags []string, category *string, paymentMethod *string, billingDay *int, notes string, metadata map[string]string) (*models.Subscription, error)
 	GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error)
 	GetSubscriptionsByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Subscription, error)
-	GetAllSubscriptions(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) ([]*models.Subscription, error)
-	UpdateSubscription(ctx context.Context, id uuid.UUID, serviceName *string, price *int, startDate *string, endDate *string) (*models.Subscription, error)
+	GetAllSubscriptions(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) ([]*models.Subscription, int, error)
+	ExportSubscriptions(ctx context.Context, filter *models.SubscriptionFilter, fn func(*models.Subscription) error) error
+	SearchSubscriptions(ctx context.Context, query string, userID *uuid.UUID, limit, offset int) ([]*models.SubscriptionSearchHit, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, serviceName *string, price *int, startDate *string, endDate *string, tags *[]string, category *string, paymentMethod *string, billingDay *int, notes *string, metadata *map[string]string) (*models.Subscription, error)
+	LinkCatalogService(ctx context.Context, id uuid.UUID, catalogID *uuid.UUID) (*models.Subscription, error)
 	DeleteSubscription(ctx context.Context, id uuid.UUID) error
-	CalculateTotalCost(ctx context.Context, userID *uuid.UUID, serviceName *string, startDate, endDate string) (*models.CostSummary, error)
-	GetSubscriptionStats(ctx context.Context, userID *uuid.UUID) (int, error)
+	DeleteUserSubscriptions(ctx context.Context, userID uuid.UUID) (int, error)
+	CalculateTotalCost(ctx context.Context, userID *uuid.UUID, serviceName *string, startDate, endDate string, billing *models.BillingMode, pricing models.PricingMode) (*models.CostSummary, error)
+	CalculateCostByCategory(ctx context.Context, userID *uuid.UUID, startDate, endDate string, billing *models.BillingMode, pricing models.PricingMode) (*models.CategoryCostReport, error)
+	CalculateCostByPaymentMethod(ctx context.Context, userID *uuid.UUID, startDate, endDate string, billing *models.BillingMode, pricing models.PricingMode) (*models.PaymentMethodCostReport, error)
+	GetUserSubscriptionStats(ctx context.Context, userID uuid.UUID) (*models.UserSubscriptionStats, error)
+	FindDuplicateSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.SubscriptionDuplicate, error)
+	GetExpiringSubscriptions(ctx context.Context, userID uuid.UUID, withinDays int) ([]*models.Subscription, error)
+	GetSubscriptionCalendar(ctx context.Context, userID uuid.UUID, year int, billing *models.BillingMode, pricing models.PricingMode) (*models.SubscriptionCalendar, error)
+	GetCostForecast(ctx context.Context, userID uuid.UUID, months int, billing *models.BillingMode) (*models.CostForecast, error)
+	GetBillingCalendar(ctx context.Context, userID uuid.UUID, month string) (*models.BillingCalendar, error)
+	GetPriceHistory(ctx context.Context, id uuid.UUID) ([]*models.PriceChange, error)
+	GetBusinessKPIs(ctx context.Context) (*models.BusinessKPIs, error)
 }
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// advisoryLockNamespace отделяет ключи сервиса от чужих advisory-блокировок
+// в той же базе.
+const advisoryLockNamespace = "subscription-service:"
+
+/*
+AdvisoryLocks — блокировки на сессионных pg_try_advisory_lock. Блокировка
+живёт, пока открыто соединение, поэтому под каждую берётся отдельное
+соединение из пула. Раз в checkInterval соединение проверяется: если оно
+оборвалось, сервер уже снял блокировку и Lost закрывается.
+*/
+type AdvisoryLocks struct {
+	db            *DB
+	checkInterval time.Duration
+	log           *logger.Logger
+}
+
+func NewAdvisoryLocks(db *DB, checkInterval time.Duration, log *logger.Logger) *AdvisoryLocks {
+	return &AdvisoryLocks{
+		db:            db,
+		checkInterval: checkInterval,
+		log:           log.Named("advisory-locks"),
+	}
+}
+
+func (l *AdvisoryLocks) TryLock(ctx context.Context, name string) (repository.Lock, error) {
+	conn, err := l.db.pool.Load().Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire lock connection: %w", err)
+	}
+
+	key := advisoryLockKey(name)
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("try advisory lock %q: %w", name, err)
+	}
+	if !locked {
+		conn.Release()
+		return nil, nil
+	}
+
+	lock := &advisoryLock{
+		name: name,
+		key:  key,
+		conn: conn,
+		lost: make(chan struct{}),
+		stop: make(chan struct{}),
+		log:  l.log,
+	}
+	lock.wg.Add(1)
+	go lock.watch(l.checkInterval)
+
+	return lock, nil
+}
+
+type advisoryLock struct {
+	name string
+	key  int64
+	log  *logger.Logger
+
+	mu       sync.Mutex
+	conn     *pgxpool.Conn
+	released bool
+
+	lost     chan struct{}
+	lostOnce sync.Once
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func (l *advisoryLock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// watch проверяет соединение, пока блокировка не освобождена.
+func (l *advisoryLock) watch(interval time.Duration) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if err := l.check(interval); err != nil {
+			l.log.Warn("advisory lock lost",
+				zap.String("lock", l.name),
+				zap.Error(err))
+			l.markLost()
+			return
+		}
+	}
+}
+
+func (l *advisoryLock) check(timeout time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.released {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return l.conn.Ping(ctx)
+}
+
+// markLost закрывает соединение — если оно ещё живо, сервер снимет
+// блокировку, и состояние на этой реплике совпадёт с реальным.
+func (l *advisoryLock) markLost() {
+	l.lostOnce.Do(func() {
+		l.mu.Lock()
+		if !l.released {
+			l.released = true
+			_ = l.conn.Conn().Close(context.Background())
+			l.conn.Release()
+		}
+		l.mu.Unlock()
+		close(l.lost)
+	})
+}
+
+func (l *advisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	if l.released {
+		l.mu.Unlock()
+		return nil
+	}
+	l.released = true
+	close(l.stop)
+	l.mu.Unlock()
+
+	l.wg.Wait()
+
+	var unlocked bool
+	err := l.conn.QueryRow(ctx, "SELECT pg_advisory_unlock($1)", l.key).Scan(&unlocked)
+	if err != nil || !unlocked {
+		// Соединение в неизвестном состоянии — закрываем, чтобы сервер
+		// гарантированно снял блокировку, и не возвращаем его в пул.
+		_ = l.conn.Conn().Close(ctx)
+	}
+	l.conn.Release()
+
+	if err != nil {
+		return fmt.Errorf("release advisory lock %q: %w", l.name, err)
+	}
+	if !unlocked {
+		return fmt.Errorf("release advisory lock %q: lock was not held", l.name)
+	}
+	return nil
+}
+
+// advisoryLockKey — 64-битный FNV-1a от имени блокировки.
+func advisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(advisoryLockNamespace + name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+/*
+ExecBatch отправляет запросы batch одним походом в базу и дожидается
+результата каждого. Вне транзакции pgx выполняет пакет в неявной
+транзакции: применяются либо все запросы, либо ни один. В транзакции из
+ctx пакет становится её частью. Обработчики, заданные через QueuedQuery
+(Exec, QueryRow), вызываются по порядку; возвращается первая ошибка —
+запроса или обработчика, последняя — как есть.
+
+Отменённый ctx не доходит до пула: пакет не отправляется.
+*/
+func (db *DB) ExecBatch(ctx context.Context, batch *pgx.Batch) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	return db.Conn(ctx).SendBatch(ctx, batch).Close()
+}
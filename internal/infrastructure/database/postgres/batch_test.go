@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestExecBatchCancelledContext(t *testing.T) {
+	// Пула нет: если бы пакет ушёл в базу, тест упал бы с паникой.
+	db := &DB{}
+
+	batch := &pgx.Batch{}
+	batch.Queue(`SELECT 1`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := db.ExecBatch(ctx, batch); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled: got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	if err := db.ExecBatch(ctx, batch); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("deadline exceeded: got %v", err)
+	}
+
+	if err := db.ExecBatch(context.Background(), &pgx.Batch{}); err != nil {
+		t.Errorf("empty batch: got %v", err)
+	}
+}
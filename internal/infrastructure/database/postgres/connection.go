@@ -3,8 +3,12 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 
@@ -12,9 +16,21 @@ import (
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
 )
 
+/*
+DB — пул соединений с PostgreSQL. Пул можно пересоздать с другим
+размером на ходу (ResizePool), поэтому он хранится за atomic.Pointer и
+берётся заново при каждом обращении.
+*/
 type DB struct {
-	pool *pgxpool.Pool
-	log  *logger.Logger
+	pool atomic.Pointer[pgxpool.Pool]
+	// config — настройки, с которыми пересоздаётся пул; MinConns и MaxConns
+	// в нём — текущие.
+	config        *pgxpool.Config
+	maxConnsLimit int32
+	resizeMu      sync.Mutex
+	// draining — старый пул после ResizePool, пока он не закрылся.
+	draining *pgxpool.Pool
+	log      *logger.Logger
 }
 
 func New(cfg config.DatabaseConfig, log *logger.Logger) (*DB, error) {
@@ -23,7 +39,7 @@ func New(cfg config.DatabaseConfig, log *logger.Logger) (*DB, error) {
 		zap.String("port", cfg.Port),
 		zap.String("database", cfg.DBName))
 
-	poolConfig, err := buildPoolConfig(cfg)
+	poolConfig, err := buildPoolConfig(cfg, log)
 	if err != nil {
 		return nil, fmt.Errorf("build pool config: %w", err)
 	}
@@ -37,9 +53,11 @@ func New(cfg config.DatabaseConfig, log *logger.Logger) (*DB, error) {
 	}
 
 	db := &DB{
-		pool: pool,
-		log:  log,
+		config:        poolConfig,
+		maxConnsLimit: int32(cfg.MaxConnsLimitValue()),
+		log:           log,
 	}
+	db.pool.Store(pool)
 
 	if err := db.ping(ctx); err != nil {
 		pool.Close()
@@ -48,24 +66,29 @@ func New(cfg config.DatabaseConfig, log *logger.Logger) (*DB, error) {
 
 	log.Info("postgres connected successfully",
 		zap.Int32("max_conns", poolConfig.MaxConns),
-		zap.Int32("min_conns", poolConfig.MinConns))
+		zap.Int32("min_conns", poolConfig.MinConns),
+		zap.Int32("min_idle_conns", poolConfig.MinIdleConns),
+		zap.Duration("max_conn_idle_time", poolConfig.MaxConnIdleTime),
+		zap.Duration("health_check_period", poolConfig.HealthCheckPeriod),
+		zap.Duration("statement_timeout", cfg.StatementTimeout()),
+		zap.Duration("slow_query_threshold", cfg.SlowQueryThreshold()))
 
 	return db, nil
 }
 
 func (db *DB) Pool() *pgxpool.Pool {
-	return db.pool
+	return db.pool.Load()
 }
 
 func (db *DB) Close() {
-	if db.pool != nil {
-		db.pool.Close()
+	if pool := db.pool.Load(); pool != nil {
+		pool.Close()
 		db.log.Info("postgres connection closed")
 	}
 }
 
 func (db *DB) ping(ctx context.Context) error {
-	if err := db.pool.Ping(ctx); err != nil {
+	if err := db.pool.Load().Ping(ctx); err != nil {
 		db.log.Error("postgres ping failed", zap.Error(err))
 		return fmt.Errorf("ping database: %w", err)
 	}
@@ -79,20 +102,49 @@ func (db *DB) HealthCheck(ctx context.Context) error {
 }
 
 func (db *DB) Stats() *pgxpool.Stat {
-	return db.pool.Stat()
+	return db.pool.Load().Stat()
 }
 
-func buildPoolConfig(cfg config.DatabaseConfig) (*pgxpool.Config, error) {
+func buildPoolConfig(cfg config.DatabaseConfig, log *logger.Logger) (*pgxpool.Config, error) {
 	poolConfig, err := pgxpool.ParseConfig(cfg.DSN())
 	if err != nil {
 		return nil, fmt.Errorf("parse config: %w", err)
 	}
 
 	poolConfig.MaxConns = int32(cfg.MaxOpenConns)
-	poolConfig.MinConns = int32(cfg.MaxIdleConns)
+	poolConfig.MinConns = int32(cfg.MinConnsValue())
+	poolConfig.MinIdleConns = int32(cfg.MinIdleConns)
 	poolConfig.MaxConnLifetime = time.Duration(cfg.MaxLifetime) * time.Second
-	poolConfig.MaxConnIdleTime = 30 * time.Minute
-	poolConfig.HealthCheckPeriod = 1 * time.Minute
+	poolConfig.MaxConnLifetimeJitter = time.Duration(cfg.MaxLifetimeJitter) * time.Second
+	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTimeDuration()
+	poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriodDuration()
+	// statement_timeout прерывает запрос на сервере, и соединение остаётся
+	// в пуле; отмена по контексту закрыла бы его. Тяжёлый агрегат не
+	// держит соединение дольше лимита и не выбирает весь пул.
+	if timeout := cfg.StatementTimeout(); timeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+
+	// Запросы репозиториев готовятся один раз на соединение и дальше идут
+	// по имени. Без кэша каждый запрос описывается заново — так работает
+	// PgBouncer в режиме transaction, где соединение сервера меняется.
+	if cfg.StatementCacheCapacity > 0 {
+		poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+		poolConfig.ConnConfig.StatementCacheCapacity = cfg.StatementCacheCapacity
+	} else {
+		poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeDescribeExec
+		poolConfig.ConnConfig.StatementCacheCapacity = 0
+		poolConfig.ConnConfig.DescriptionCacheCapacity = 0
+	}
+
+	tracers := multiQueryTracer{timingTracer{}}
+	if threshold := cfg.SlowQueryThreshold(); threshold > 0 {
+		tracers = append(tracers, slowQueryTracer{threshold: threshold, log: log.Named("postgres")})
+	}
+	if cfg.QueryLog.Enabled {
+		tracers = append(tracers, queryLogTracer{sampleRate: cfg.QueryLog.SampleRate, log: log.Named("postgres")})
+	}
+	poolConfig.ConnConfig.Tracer = tracers
 
 	return poolConfig, nil
 }
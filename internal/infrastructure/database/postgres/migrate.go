@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratepostgres "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres/migrations"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+func Migrate(cfg config.DatabaseConfig, log *logger.Logger) error {
+	log.Info("applying embedded database migrations")
+
+	db, err := sql.Open("postgres", cfg.DSN())
+	if err != nil {
+		return fmt.Errorf("open migration connection: %w", err)
+	}
+	defer db.Close()
+
+	m, err := NewEmbeddedMigrate(db)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("read migration version: %w", err)
+	}
+
+	log.Info("database migrations applied",
+		zap.Uint("version", version),
+		zap.Bool("dirty", dirty))
+
+	return nil
+}
+
+func NewEmbeddedMigrate(db *sql.DB) (*migrate.Migrate, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("open embedded migrations: %w", err)
+	}
+
+	driver, err := migratepostgres.WithInstance(db, &migratepostgres.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("create postgres driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		return nil, fmt.Errorf("create migrate instance: %w", err)
+	}
+
+	return m, nil
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS config_fingerprints;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE config_fingerprints (
+    instance_id VARCHAR(255) PRIMARY KEY,
+    config_hash VARCHAR(64) NOT NULL,
+    reported_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_config_fingerprints_reported_at ON config_fingerprints(reported_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS audit_log;
+DROP TABLE IF EXISTS subscription_events;
@@ -0,0 +1,32 @@
+CREATE TABLE subscription_events (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    subscription_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_subscription_events_subscription_id ON subscription_events(subscription_id, occurred_at);
+
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES subscription_events(id) ON DELETE CASCADE,
+    entity_type VARCHAR(64) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id);
+
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE REFERENCES subscription_events(id) ON DELETE CASCADE,
+    topic VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_outbox_unpublished ON outbox(id) WHERE published_at IS NULL;
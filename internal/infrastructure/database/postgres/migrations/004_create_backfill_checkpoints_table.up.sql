@@ -0,0 +1,8 @@
+CREATE TABLE backfill_checkpoints (
+    name VARCHAR(255) PRIMARY KEY,
+    last_key TEXT NOT NULL,
+    processed BIGINT NOT NULL DEFAULT 0,
+    affected BIGINT NOT NULL DEFAULT 0,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,9 @@
+CREATE TABLE subscription_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    author VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL CHECK (length(body) > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscription_comments_subscription_id ON subscription_comments(subscription_id, created_at);
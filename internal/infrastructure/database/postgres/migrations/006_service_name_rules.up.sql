@@ -0,0 +1,9 @@
+CREATE TABLE service_name_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    list VARCHAR(10) NOT NULL CHECK (list IN ('allow', 'deny')),
+    match_type VARCHAR(10) NOT NULL CHECK (match_type IN ('exact', 'regex')),
+    pattern VARCHAR(255) NOT NULL CHECK (length(pattern) > 0),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (list, match_type, pattern)
+);
@@ -0,0 +1,19 @@
+CREATE TABLE discounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(64) NOT NULL,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('percentage', 'fixed')),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    service_name VARCHAR(255),
+    valid_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    valid_to TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_percentage_amount CHECK (kind <> 'percentage' OR amount <= 100),
+    CONSTRAINT check_valid_to_after_from CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE UNIQUE INDEX idx_discounts_code ON discounts(lower(code));
+
+ALTER TABLE subscriptions
+    ADD COLUMN discount_id UUID REFERENCES discounts(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_subscriptions_discount_id ON subscriptions(discount_id) WHERE discount_id IS NOT NULL;
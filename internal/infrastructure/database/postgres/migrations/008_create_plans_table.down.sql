@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS plan_id;
+DROP TABLE IF EXISTS plan_price_history;
+DROP TABLE IF EXISTS plans;
@@ -0,0 +1,28 @@
+CREATE TABLE plans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    service_name VARCHAR(255) NOT NULL,
+    price INTEGER NOT NULL CHECK (price > 0),
+    billing_cycle VARCHAR(16) NOT NULL CHECK (billing_cycle IN ('monthly', 'yearly')),
+    features JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_features_object CHECK (jsonb_typeof(features) = 'object')
+);
+
+CREATE UNIQUE INDEX idx_plans_name ON plans(lower(name));
+
+CREATE TABLE plan_price_history (
+    id BIGSERIAL PRIMARY KEY,
+    plan_id UUID NOT NULL REFERENCES plans(id) ON DELETE CASCADE,
+    price INTEGER NOT NULL CHECK (price > 0),
+    billing_cycle VARCHAR(16) NOT NULL CHECK (billing_cycle IN ('monthly', 'yearly')),
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_plan_price_history_plan_id ON plan_price_history(plan_id, effective_from);
+
+ALTER TABLE subscriptions
+    ADD COLUMN plan_id UUID REFERENCES plans(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_subscriptions_plan_id ON subscriptions(plan_id) WHERE plan_id IS NOT NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE subscription_price_history (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    old_price INTEGER NOT NULL CHECK (old_price > 0),
+    new_price INTEGER NOT NULL CHECK (new_price > 0),
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscription_price_history_subscription
+    ON subscription_price_history(subscription_id, effective_from, id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_subscriptions_category;
+DROP INDEX IF EXISTS idx_subscriptions_tags;
+
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,9 @@
+ALTER TABLE subscriptions
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN category VARCHAR(32) CHECK (category IN (
+        'streaming', 'music', 'cloud', 'software', 'gaming',
+        'fitness', 'education', 'news', 'shopping', 'other'
+    ));
+
+CREATE INDEX idx_subscriptions_tags ON subscriptions USING GIN (tags);
+CREATE INDEX idx_subscriptions_category ON subscriptions(category) WHERE category IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_subscriptions_metadata;
+
+ALTER TABLE subscriptions
+    DROP CONSTRAINT IF EXISTS check_metadata_object,
+    DROP COLUMN IF EXISTS metadata,
+    DROP COLUMN IF EXISTS notes;
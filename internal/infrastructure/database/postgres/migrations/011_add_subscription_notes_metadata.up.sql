@@ -0,0 +1,6 @@
+ALTER TABLE subscriptions
+    ADD COLUMN notes TEXT NOT NULL DEFAULT '' CHECK (char_length(notes) <= 2000),
+    ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    ADD CONSTRAINT check_metadata_object CHECK (jsonb_typeof(metadata) = 'object');
+
+CREATE INDEX idx_subscriptions_metadata ON subscriptions USING GIN (metadata jsonb_path_ops);
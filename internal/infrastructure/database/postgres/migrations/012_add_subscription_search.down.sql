@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_subscriptions_search_fts;
+DROP INDEX IF EXISTS idx_subscriptions_search_trgm;
+DROP TRIGGER IF EXISTS trg_subscriptions_search_text ON subscriptions;
+DROP FUNCTION IF EXISTS subscriptions_search_text();
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS search_text;
@@ -0,0 +1,20 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE subscriptions ADD COLUMN search_text TEXT NOT NULL DEFAULT '';
+
+CREATE OR REPLACE FUNCTION subscriptions_search_text() RETURNS trigger AS $$
+BEGIN
+    NEW.search_text := lower(concat_ws(' ', NEW.service_name, array_to_string(NEW.tags, ' '), NEW.notes));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_subscriptions_search_text
+    BEFORE INSERT OR UPDATE OF service_name, tags, notes ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION subscriptions_search_text();
+
+UPDATE subscriptions
+SET search_text = lower(concat_ws(' ', service_name, array_to_string(tags, ' '), notes));
+
+CREATE INDEX idx_subscriptions_search_trgm ON subscriptions USING GIN (search_text gin_trgm_ops);
+CREATE INDEX idx_subscriptions_search_fts ON subscriptions USING GIN (to_tsvector('simple', search_text));
@@ -0,0 +1,9 @@
+-- Напоминание отправляется один раз на каждую дату окончания: если
+-- подписку продлили, для новой end_date уйдёт новое напоминание.
+CREATE TABLE subscription_expiry_reminders (
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    event_id UUID NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (subscription_id, end_date)
+);
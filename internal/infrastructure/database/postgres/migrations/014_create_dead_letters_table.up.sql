@@ -0,0 +1,15 @@
+CREATE TABLE dead_letters (
+    id UUID PRIMARY KEY,
+    source VARCHAR(64) NOT NULL,
+    topic VARCHAR(64) NOT NULL,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    error TEXT NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    requeued_at TIMESTAMP WITH TIME ZONE,
+    requeue_count INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_dead_letters_pending ON dead_letters(failed_at DESC) WHERE requeued_at IS NULL;
+CREATE INDEX idx_dead_letters_event_id ON dead_letters(event_id);
@@ -0,0 +1,13 @@
+CREATE OR REPLACE FUNCTION subscriptions_search_text() RETURNS trigger AS $$
+BEGIN
+    NEW.search_text := lower(concat_ws(' ', NEW.service_name, array_to_string(NEW.tags, ' '), NEW.notes));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_subscriptions_metadata_digest;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS metadata_digest;
+
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_notes_check CHECK (char_length(notes) <= 2000) NOT VALID;
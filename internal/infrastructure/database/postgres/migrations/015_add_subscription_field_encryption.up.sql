@@ -0,0 +1,17 @@
+-- Зашифрованная заметка длиннее исходной: лимит 2000 символов проверяет приложение.
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_notes_check;
+
+-- Слепой индекс метаданных: ключ -> HMAC значения, для фильтра metadata.<key>
+-- по зашифрованным значениям.
+ALTER TABLE subscriptions ADD COLUMN metadata_digest JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX idx_subscriptions_metadata_digest ON subscriptions USING GIN (metadata_digest jsonb_path_ops);
+
+-- Шифротекст заметки в поисковый текст не попадает.
+CREATE OR REPLACE FUNCTION subscriptions_search_text() RETURNS trigger AS $$
+BEGIN
+    NEW.search_text := lower(concat_ws(' ', NEW.service_name, array_to_string(NEW.tags, ' '),
+        CASE WHEN NEW.notes LIKE 'enc:%' THEN NULL ELSE NEW.notes END));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,9 @@
+CREATE TABLE billing_commands (
+    command_id VARCHAR(128) PRIMARY KEY,
+    command_type VARCHAR(32) NOT NULL,
+    status VARCHAR(16) NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    subscription_id UUID,
+    error_code VARCHAR(64),
+    error_message TEXT,
+    processed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
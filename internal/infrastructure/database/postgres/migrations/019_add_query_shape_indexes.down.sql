@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_subscriptions_open_ended;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_start_date ON subscriptions(start_date);
+DROP INDEX IF EXISTS idx_subscriptions_period;
+
+DROP INDEX IF EXISTS idx_subscriptions_service_name_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions(user_id);
+DROP INDEX IF EXISTS idx_subscriptions_user_created;
@@ -0,0 +1,15 @@
+-- Подписки пользователя: WHERE user_id = $1 ORDER BY created_at DESC LIMIT n.
+-- Индекс по одному user_id становится лишним.
+CREATE INDEX idx_subscriptions_user_created ON subscriptions(user_id, created_at DESC);
+DROP INDEX IF EXISTS idx_subscriptions_user_id;
+
+-- Фильтр service_name ILIKE '%...%': btree по service_name подходит только
+-- для точного совпадения.
+CREATE INDEX idx_subscriptions_service_name_trgm ON subscriptions USING GIN (service_name gin_trgm_ops);
+
+-- Пересечение с периодом: start_date <= $to AND (end_date IS NULL OR end_date >= $from).
+CREATE INDEX idx_subscriptions_period ON subscriptions(start_date, end_date);
+DROP INDEX IF EXISTS idx_subscriptions_start_date;
+
+-- Бессрочные подписки — ветка end_date IS NULL того же условия.
+CREATE INDEX idx_subscriptions_open_ended ON subscriptions(start_date) WHERE end_date IS NULL;
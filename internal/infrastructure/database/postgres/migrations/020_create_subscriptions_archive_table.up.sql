@@ -0,0 +1,12 @@
+-- Архив подписок, закончившихся давно (archive.after_months). Схема та же,
+-- что у subscriptions, без внешних ключей и триггеров: строки только читаются.
+-- Новые колонки subscriptions нужно добавлять и сюда, и в запрос архивации.
+CREATE TABLE subscriptions_archive (
+    LIKE subscriptions INCLUDING DEFAULTS,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id)
+);
+
+-- Архив читается только списком (?archived=true): по пользователю или целиком.
+CREATE INDEX idx_subscriptions_archive_user_created ON subscriptions_archive(user_id, created_at DESC);
+CREATE INDEX idx_subscriptions_archive_created_at_id ON subscriptions_archive(created_at DESC, id DESC);
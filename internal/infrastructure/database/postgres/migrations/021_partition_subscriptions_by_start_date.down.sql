@@ -0,0 +1,70 @@
+ALTER TABLE subscriptions RENAME TO subscriptions_partitioned;
+
+CREATE TABLE subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    service_name VARCHAR(255) NOT NULL,
+    price INTEGER NOT NULL CHECK (price > 0),
+    user_id UUID NOT NULL,
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    discount_id UUID REFERENCES discounts(id) ON DELETE RESTRICT,
+    plan_id UUID REFERENCES plans(id) ON DELETE RESTRICT,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    category VARCHAR(32) CHECK (category IN (
+        'streaming', 'music', 'cloud', 'software', 'gaming',
+        'fitness', 'education', 'news', 'shopping', 'other'
+    )),
+    notes TEXT NOT NULL DEFAULT '',
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    search_text TEXT NOT NULL DEFAULT '',
+    metadata_digest JSONB NOT NULL DEFAULT '{}'::jsonb,
+    CONSTRAINT check_end_date_after_start CHECK (end_date IS NULL OR end_date >= start_date),
+    CONSTRAINT check_metadata_object CHECK (jsonb_typeof(metadata) = 'object')
+);
+
+INSERT INTO subscriptions (id, service_name, price, user_id, start_date, end_date, created_at, updated_at,
+    discount_id, plan_id, tags, category, notes, metadata, search_text, metadata_digest)
+SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at,
+    discount_id, plan_id, tags, category, notes, metadata, search_text, metadata_digest
+FROM subscriptions_partitioned;
+
+DROP TABLE subscriptions_partitioned;
+DROP FUNCTION IF EXISTS subscriptions_delete_dependents();
+DROP FUNCTION IF EXISTS ensure_subscriptions_partition(INT);
+
+-- Строки, чья подписка удалена, пока внешних ключей не было, восстановить нельзя.
+DELETE FROM subscription_comments c WHERE NOT EXISTS (SELECT 1 FROM subscriptions s WHERE s.id = c.subscription_id);
+DELETE FROM subscription_price_history h WHERE NOT EXISTS (SELECT 1 FROM subscriptions s WHERE s.id = h.subscription_id);
+DELETE FROM subscription_expiry_reminders r WHERE NOT EXISTS (SELECT 1 FROM subscriptions s WHERE s.id = r.subscription_id);
+
+ALTER TABLE subscription_comments
+    ADD CONSTRAINT subscription_comments_subscription_id_fkey
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions(id) ON DELETE CASCADE;
+ALTER TABLE subscription_price_history
+    ADD CONSTRAINT subscription_price_history_subscription_id_fkey
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions(id) ON DELETE CASCADE;
+ALTER TABLE subscription_expiry_reminders
+    ADD CONSTRAINT subscription_expiry_reminders_subscription_id_fkey
+    FOREIGN KEY (subscription_id) REFERENCES subscriptions(id) ON DELETE CASCADE;
+
+CREATE TRIGGER trg_subscriptions_search_text
+    BEFORE INSERT OR UPDATE OF service_name, tags, notes ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION subscriptions_search_text();
+
+CREATE INDEX idx_subscriptions_service_name ON subscriptions(service_name);
+CREATE INDEX idx_subscriptions_end_date ON subscriptions(end_date) WHERE end_date IS NOT NULL;
+CREATE INDEX idx_subscriptions_discount_id ON subscriptions(discount_id) WHERE discount_id IS NOT NULL;
+CREATE INDEX idx_subscriptions_plan_id ON subscriptions(plan_id) WHERE plan_id IS NOT NULL;
+CREATE INDEX idx_subscriptions_tags ON subscriptions USING GIN (tags);
+CREATE INDEX idx_subscriptions_category ON subscriptions(category) WHERE category IS NOT NULL;
+CREATE INDEX idx_subscriptions_metadata ON subscriptions USING GIN (metadata jsonb_path_ops);
+CREATE INDEX idx_subscriptions_metadata_digest ON subscriptions USING GIN (metadata_digest jsonb_path_ops);
+CREATE INDEX idx_subscriptions_search_trgm ON subscriptions USING GIN (search_text gin_trgm_ops);
+CREATE INDEX idx_subscriptions_search_fts ON subscriptions USING GIN (to_tsvector('simple', search_text));
+CREATE INDEX idx_subscriptions_created_at_id ON subscriptions(created_at DESC, id DESC);
+CREATE INDEX idx_subscriptions_user_created ON subscriptions(user_id, created_at DESC);
+CREATE INDEX idx_subscriptions_service_name_trgm ON subscriptions USING GIN (service_name gin_trgm_ops);
+CREATE INDEX idx_subscriptions_period ON subscriptions(start_date, end_date);
+CREATE INDEX idx_subscriptions_open_ended ON subscriptions(start_date) WHERE end_date IS NULL;
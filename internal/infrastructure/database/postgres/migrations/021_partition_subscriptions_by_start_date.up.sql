@@ -0,0 +1,139 @@
+-- Секционирование subscriptions по start_date: одна секция на год
+-- (subscriptions_yYYYY) и subscriptions_default для лет без секции.
+-- Запросы с условием на start_date читают только нужные годы.
+
+ALTER TABLE subscriptions RENAME TO subscriptions_legacy;
+ALTER INDEX subscriptions_pkey RENAME TO subscriptions_legacy_pkey;
+
+-- Первичный ключ секционированной таблицы обязан включать ключ секционирования.
+CREATE TABLE subscriptions (
+    id UUID NOT NULL DEFAULT uuid_generate_v4(),
+    service_name VARCHAR(255) NOT NULL,
+    price INTEGER NOT NULL CHECK (price > 0),
+    user_id UUID NOT NULL,
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    discount_id UUID REFERENCES discounts(id) ON DELETE RESTRICT,
+    plan_id UUID REFERENCES plans(id) ON DELETE RESTRICT,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    category VARCHAR(32) CHECK (category IN (
+        'streaming', 'music', 'cloud', 'software', 'gaming',
+        'fitness', 'education', 'news', 'shopping', 'other'
+    )),
+    notes TEXT NOT NULL DEFAULT '',
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    search_text TEXT NOT NULL DEFAULT '',
+    metadata_digest JSONB NOT NULL DEFAULT '{}'::jsonb,
+    CONSTRAINT check_end_date_after_start CHECK (end_date IS NULL OR end_date >= start_date),
+    CONSTRAINT check_metadata_object CHECK (jsonb_typeof(metadata) = 'object'),
+    PRIMARY KEY (id, start_date)
+) PARTITION BY RANGE (start_date);
+
+CREATE TABLE subscriptions_default PARTITION OF subscriptions DEFAULT;
+
+/*
+ensure_subscriptions_partition создаёт секцию года, если её нет. Строки
+этого года, попавшие в subscriptions_default, переносятся в новую таблицу
+до подключения: иначе ATTACH PARTITION отказывает. Возвращает true, если
+секция создана. Вызывается миграцией и задачей partition-maintenance.
+*/
+CREATE OR REPLACE FUNCTION ensure_subscriptions_partition(partition_year INT) RETURNS BOOLEAN AS $$
+DECLARE
+    partition_name TEXT := format('subscriptions_y%s', partition_year);
+    from_date TIMESTAMP WITH TIME ZONE := make_timestamptz(partition_year, 1, 1, 0, 0, 0, 'UTC');
+    to_date TIMESTAMP WITH TIME ZONE := make_timestamptz(partition_year + 1, 1, 1, 0, 0, 0, 'UTC');
+BEGIN
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN FALSE;
+    END IF;
+
+    EXECUTE format('CREATE TABLE %I (LIKE subscriptions INCLUDING DEFAULTS INCLUDING CONSTRAINTS)', partition_name);
+
+    -- Перенос не удаление: зависимые строки не трогаем, см. subscriptions_delete_dependents.
+    PERFORM set_config('subscriptions.partition_move', 'on', true);
+    EXECUTE format(
+        'WITH moved AS (DELETE FROM subscriptions_default WHERE start_date >= $1 AND start_date < $2 RETURNING *) '
+        'INSERT INTO %I SELECT * FROM moved', partition_name)
+        USING from_date, to_date;
+    PERFORM set_config('subscriptions.partition_move', 'off', true);
+
+    EXECUTE format('ALTER TABLE subscriptions ATTACH PARTITION %I FOR VALUES FROM (%L) TO (%L)',
+        partition_name, from_date, to_date);
+    RETURN TRUE;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Секции для лет с данными и для следующего года.
+DO $$
+DECLARE
+    first_year INT;
+    last_year INT := extract(year FROM NOW() AT TIME ZONE 'UTC')::INT + 1;
+BEGIN
+    SELECT COALESCE(extract(year FROM MIN(start_date) AT TIME ZONE 'UTC')::INT, last_year - 1)
+    INTO first_year
+    FROM subscriptions_legacy;
+
+    FOR y IN first_year..last_year LOOP
+        PERFORM ensure_subscriptions_partition(y);
+    END LOOP;
+END $$;
+
+INSERT INTO subscriptions (id, service_name, price, user_id, start_date, end_date, created_at, updated_at,
+    discount_id, plan_id, tags, category, notes, metadata, search_text, metadata_digest)
+SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at,
+    discount_id, plan_id, tags, category, notes, metadata, search_text, metadata_digest
+FROM subscriptions_legacy;
+
+-- Внешний ключ на секционированную таблицу требует уникальности по (id, start_date),
+-- поэтому ON DELETE CASCADE заменяется триггером.
+ALTER TABLE subscription_comments DROP CONSTRAINT IF EXISTS subscription_comments_subscription_id_fkey;
+ALTER TABLE subscription_price_history DROP CONSTRAINT IF EXISTS subscription_price_history_subscription_id_fkey;
+ALTER TABLE subscription_expiry_reminders DROP CONSTRAINT IF EXISTS subscription_expiry_reminders_subscription_id_fkey;
+
+DROP TABLE subscriptions_legacy;
+
+/*
+Удаление подписки удаляет её комментарии, историю цен и напоминания.
+Строка, которую UPDATE start_date перенёс в другую секцию, или перенос
+из subscriptions_default в новую секцию — не удаление.
+*/
+CREATE OR REPLACE FUNCTION subscriptions_delete_dependents() RETURNS trigger AS $$
+BEGIN
+    IF current_setting('subscriptions.partition_move', true) = 'on'
+        OR EXISTS (SELECT 1 FROM subscriptions WHERE id = OLD.id) THEN
+        RETURN NULL;
+    END IF;
+
+    DELETE FROM subscription_comments WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_price_history WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_expiry_reminders WHERE subscription_id = OLD.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_subscriptions_delete_dependents
+    AFTER DELETE ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION subscriptions_delete_dependents();
+
+CREATE TRIGGER trg_subscriptions_search_text
+    BEFORE INSERT OR UPDATE OF service_name, tags, notes ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION subscriptions_search_text();
+
+-- Индексы секционированной таблицы создаются в каждой секции, в том числе будущих.
+CREATE INDEX idx_subscriptions_service_name ON subscriptions(service_name);
+CREATE INDEX idx_subscriptions_end_date ON subscriptions(end_date) WHERE end_date IS NOT NULL;
+CREATE INDEX idx_subscriptions_discount_id ON subscriptions(discount_id) WHERE discount_id IS NOT NULL;
+CREATE INDEX idx_subscriptions_plan_id ON subscriptions(plan_id) WHERE plan_id IS NOT NULL;
+CREATE INDEX idx_subscriptions_tags ON subscriptions USING GIN (tags);
+CREATE INDEX idx_subscriptions_category ON subscriptions(category) WHERE category IS NOT NULL;
+CREATE INDEX idx_subscriptions_metadata ON subscriptions USING GIN (metadata jsonb_path_ops);
+CREATE INDEX idx_subscriptions_metadata_digest ON subscriptions USING GIN (metadata_digest jsonb_path_ops);
+CREATE INDEX idx_subscriptions_search_trgm ON subscriptions USING GIN (search_text gin_trgm_ops);
+CREATE INDEX idx_subscriptions_search_fts ON subscriptions USING GIN (to_tsvector('simple', search_text));
+CREATE INDEX idx_subscriptions_created_at_id ON subscriptions(created_at DESC, id DESC);
+CREATE INDEX idx_subscriptions_user_created ON subscriptions(user_id, created_at DESC);
+CREATE INDEX idx_subscriptions_service_name_trgm ON subscriptions USING GIN (service_name gin_trgm_ops);
+CREATE INDEX idx_subscriptions_period ON subscriptions(start_date, end_date);
+CREATE INDEX idx_subscriptions_open_ended ON subscriptions(start_date) WHERE end_date IS NULL;
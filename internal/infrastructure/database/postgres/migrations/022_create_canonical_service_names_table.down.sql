@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_subscriptions_service_name_key;
+DROP TABLE IF EXISTS canonical_service_names;
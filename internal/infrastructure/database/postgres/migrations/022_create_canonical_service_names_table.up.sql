@@ -0,0 +1,15 @@
+-- Словарь канонических названий сервисов: name — как название должно
+-- выглядеть, aliases — другие написания. Регистр и пробелы сравниваются
+-- по ключу, см. models.ServiceNameKey.
+CREATE TABLE canonical_service_names (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL CHECK (length(name) > 0),
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_canonical_service_names_name ON canonical_service_names (lower(name));
+
+-- Задача service-name-normalization ищет подписки по ключу названия.
+CREATE INDEX idx_subscriptions_service_name_key
+    ON subscriptions (lower(btrim(regexp_replace(service_name, '\s+', ' ', 'g'))));
@@ -0,0 +1,4 @@
+ALTER TABLE subscriptions_archive DROP COLUMN IF EXISTS catalog_id;
+DROP INDEX IF EXISTS idx_subscriptions_catalog_id;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS catalog_id;
+DROP TABLE IF EXISTS service_catalog;
@@ -0,0 +1,27 @@
+-- Каталог известных сервисов для выбора в клиентах. typical_price —
+-- ориентир в рублях в месяц, цену подписки не задаёт.
+CREATE TABLE service_catalog (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL CHECK (length(name) > 0),
+    category VARCHAR(32) CHECK (category IN (
+        'streaming', 'music', 'cloud', 'software', 'gaming',
+        'fitness', 'education', 'news', 'shopping', 'other'
+    )),
+    website TEXT NOT NULL DEFAULT '',
+    icon_url TEXT NOT NULL DEFAULT '',
+    typical_price INTEGER CHECK (typical_price > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_service_catalog_name ON service_catalog (lower(name));
+-- Подсказки (/services/suggest) ищут подстроку в названии.
+CREATE INDEX idx_service_catalog_name_trgm ON service_catalog USING GIN (name gin_trgm_ops);
+
+ALTER TABLE subscriptions
+    ADD COLUMN catalog_id UUID REFERENCES service_catalog(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_subscriptions_catalog_id ON subscriptions(catalog_id) WHERE catalog_id IS NOT NULL;
+
+-- Архив повторяет колонки subscriptions, см. 020.
+ALTER TABLE subscriptions_archive ADD COLUMN catalog_id UUID;
@@ -0,0 +1,18 @@
+-- Пороги месячных трат пользователей; траты считаются после скидок.
+CREATE TABLE cost_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    monthly_limit INTEGER NOT NULL CHECK (monthly_limit > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, monthly_limit)
+);
+
+-- Уведомление о превышении уходит один раз за месяц на каждый порог.
+CREATE TABLE cost_alert_notifications (
+    alert_id UUID NOT NULL REFERENCES cost_alerts(id) ON DELETE CASCADE,
+    month DATE NOT NULL,
+    spent INTEGER NOT NULL,
+    event_id UUID NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (alert_id, month)
+);
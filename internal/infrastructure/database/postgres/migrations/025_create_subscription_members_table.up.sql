@@ -0,0 +1,13 @@
+-- Участники общих подписок. Владелец платит то, что не разобрали участники,
+-- поэтому сумма долей по подписке не больше 100 (проверяется при добавлении).
+-- Внешнего ключа нет: subscriptions секционирована и уникальна только по
+-- (id, start_date); участников удаляет subscriptions_delete_dependents (031).
+CREATE TABLE subscription_members (
+    subscription_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    share_percent INTEGER NOT NULL CHECK (share_percent BETWEEN 1 AND 100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (subscription_id, user_id)
+);
+
+CREATE INDEX idx_subscription_members_user_id ON subscription_members(user_id);
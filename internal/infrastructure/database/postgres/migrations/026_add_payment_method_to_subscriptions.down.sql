@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions_archive DROP COLUMN IF EXISTS payment_method;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS payment_method;
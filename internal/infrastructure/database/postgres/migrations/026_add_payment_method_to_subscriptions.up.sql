@@ -0,0 +1,5 @@
+-- Способ оплаты в свободной форме: подпись карты, банк, «Family Sharing».
+ALTER TABLE subscriptions ADD COLUMN payment_method VARCHAR(100);
+
+-- Архив повторяет колонки subscriptions, см. 020.
+ALTER TABLE subscriptions_archive ADD COLUMN payment_method VARCHAR(100);
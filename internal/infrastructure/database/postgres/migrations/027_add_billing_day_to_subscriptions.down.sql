@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions_archive DROP COLUMN IF EXISTS billing_day;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS billing_day;
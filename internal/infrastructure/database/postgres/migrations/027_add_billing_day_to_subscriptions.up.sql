@@ -0,0 +1,7 @@
+-- День месяца, в который списывается оплата; NULL — день даты начала.
+-- В коротких месяцах 29–31 сдвигаются на последний день.
+ALTER TABLE subscriptions
+    ADD COLUMN billing_day SMALLINT CHECK (billing_day BETWEEN 1 AND 31);
+
+-- Архив повторяет колонки subscriptions, см. 020.
+ALTER TABLE subscriptions_archive ADD COLUMN billing_day SMALLINT;
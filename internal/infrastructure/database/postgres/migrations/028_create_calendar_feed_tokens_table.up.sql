@@ -0,0 +1,6 @@
+-- Токены ссылок на iCalendar-ленту: один на пользователя, хранится SHA-256.
+CREATE TABLE calendar_feed_tokens (
+    user_id UUID PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS trg_subscription_members_cost_rollups ON subscription_members;
+DROP TRIGGER IF EXISTS trg_subscriptions_cost_rollups ON subscriptions;
+DROP FUNCTION IF EXISTS subscription_members_refresh_cost_rollups();
+DROP FUNCTION IF EXISTS subscriptions_refresh_cost_rollups();
+DROP FUNCTION IF EXISTS monthly_cost_rollups_refresh(UUID);
+DROP FUNCTION IF EXISTS monthly_cost_rollups_add(UUID, VARCHAR, DATE, BIGINT);
+DROP TABLE IF EXISTS monthly_cost_rollup_subscriptions;
+DROP TABLE IF EXISTS monthly_cost_rollups;
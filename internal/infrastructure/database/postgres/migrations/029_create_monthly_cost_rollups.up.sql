@@ -0,0 +1,140 @@
+/*
+Помесячные свёртки стоимости для /costs/calculate. monthly_cost_rollups
+хранит изменение суммарной месячной цены подписок пользователя на сервис:
++price в месяце начала и -price в месяце после окончания. Стоимость за
+месяцы [A, B] при помесячном биллинге — сумма price_delta * (B - max(month, A) + 1)
+по строкам с month <= B, поэтому бессрочные подписки не нужно
+разворачивать по месяцам.
+
+В свёртки попадают только подписки без промокода и без участников: скидка
+и доля округляются по каждой подписке, такие подписки считаются по-прежнему.
+monthly_cost_rollup_subscriptions — что каждая подписка внесла в свёртки,
+чтобы при изменении вычесть ровно это.
+*/
+CREATE TABLE monthly_cost_rollups (
+    user_id UUID NOT NULL,
+    service_name VARCHAR(255) NOT NULL,
+    month DATE NOT NULL,
+    price_delta BIGINT NOT NULL,
+    PRIMARY KEY (user_id, service_name, month)
+);
+
+CREATE INDEX idx_monthly_cost_rollups_month ON monthly_cost_rollups(month);
+
+CREATE TABLE monthly_cost_rollup_subscriptions (
+    subscription_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    service_name VARCHAR(255) NOT NULL,
+    price INTEGER NOT NULL,
+    first_month DATE NOT NULL,
+    -- Первый месяц, который уже не оплачивается; NULL — бессрочная.
+    stop_month DATE
+);
+
+CREATE OR REPLACE FUNCTION monthly_cost_rollups_add(p_user UUID, p_service VARCHAR, p_month DATE, p_delta BIGINT) RETURNS void AS $$
+BEGIN
+    INSERT INTO monthly_cost_rollups AS r (user_id, service_name, month, price_delta)
+    VALUES (p_user, p_service, p_month, p_delta)
+    ON CONFLICT (user_id, service_name, month) DO UPDATE SET price_delta = r.price_delta + EXCLUDED.price_delta;
+
+    DELETE FROM monthly_cost_rollups
+    WHERE user_id = p_user AND service_name = p_service AND month = p_month AND price_delta = 0;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+monthly_cost_rollups_refresh приводит вклад подписки в свёртки к её
+текущему состоянию: вычитает записанный вклад и добавляет новый. Функция
+читает подписку заново, а не берёт OLD/NEW, поэтому порядок триггеров не
+важен: перенос строки в другую секцию (DELETE + INSERT), каскадное
+удаление участников и повторный вызов дают один и тот же результат.
+*/
+CREATE OR REPLACE FUNCTION monthly_cost_rollups_refresh(p_subscription UUID) RETURNS void AS $$
+DECLARE
+    stored monthly_cost_rollup_subscriptions%ROWTYPE;
+    actual monthly_cost_rollup_subscriptions%ROWTYPE;
+BEGIN
+    SELECT s.id, s.user_id, s.service_name, s.price,
+        date_trunc('month', s.start_date AT TIME ZONE 'UTC')::date,
+        (date_trunc('month', s.end_date AT TIME ZONE 'UTC') + interval '1 month')::date
+    INTO actual
+    FROM subscriptions s
+    WHERE s.id = p_subscription
+        AND s.discount_id IS NULL
+        AND NOT EXISTS (SELECT 1 FROM subscription_members m WHERE m.subscription_id = s.id);
+
+    SELECT * INTO stored
+    FROM monthly_cost_rollup_subscriptions
+    WHERE subscription_id = p_subscription
+    FOR UPDATE;
+
+    IF stored IS NOT DISTINCT FROM actual THEN
+        RETURN;
+    END IF;
+
+    IF stored.subscription_id IS NOT NULL THEN
+        PERFORM monthly_cost_rollups_add(stored.user_id, stored.service_name, stored.first_month, -stored.price);
+        IF stored.stop_month IS NOT NULL THEN
+            PERFORM monthly_cost_rollups_add(stored.user_id, stored.service_name, stored.stop_month, stored.price);
+        END IF;
+        DELETE FROM monthly_cost_rollup_subscriptions WHERE subscription_id = p_subscription;
+    END IF;
+
+    IF actual.subscription_id IS NOT NULL THEN
+        PERFORM monthly_cost_rollups_add(actual.user_id, actual.service_name, actual.first_month, actual.price);
+        IF actual.stop_month IS NOT NULL THEN
+            PERFORM monthly_cost_rollups_add(actual.user_id, actual.service_name, actual.stop_month, -actual.price);
+        END IF;
+        INSERT INTO monthly_cost_rollup_subscriptions VALUES (actual.*);
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Перенос строк между секциями (ensure_subscriptions_partition) — не изменение подписки.
+CREATE OR REPLACE FUNCTION subscriptions_refresh_cost_rollups() RETURNS trigger AS $$
+BEGIN
+    IF current_setting('subscriptions.partition_move', true) = 'on' THEN
+        RETURN NULL;
+    END IF;
+
+    PERFORM monthly_cost_rollups_refresh(COALESCE(NEW.id, OLD.id));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_subscriptions_cost_rollups
+    AFTER INSERT OR DELETE OR UPDATE OF service_name, price, user_id, start_date, end_date, discount_id ON subscriptions
+    FOR EACH ROW EXECUTE FUNCTION subscriptions_refresh_cost_rollups();
+
+CREATE OR REPLACE FUNCTION subscription_members_refresh_cost_rollups() RETURNS trigger AS $$
+BEGIN
+    PERFORM monthly_cost_rollups_refresh(COALESCE(NEW.subscription_id, OLD.subscription_id));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_subscription_members_cost_rollups
+    AFTER INSERT OR DELETE ON subscription_members
+    FOR EACH ROW EXECUTE FUNCTION subscription_members_refresh_cost_rollups();
+
+-- Свёртки для уже существующих подписок.
+INSERT INTO monthly_cost_rollup_subscriptions (subscription_id, user_id, service_name, price, first_month, stop_month)
+SELECT s.id, s.user_id, s.service_name, s.price,
+    date_trunc('month', s.start_date AT TIME ZONE 'UTC')::date,
+    (date_trunc('month', s.end_date AT TIME ZONE 'UTC') + interval '1 month')::date
+FROM subscriptions s
+WHERE s.discount_id IS NULL
+    AND NOT EXISTS (SELECT 1 FROM subscription_members m WHERE m.subscription_id = s.id);
+
+INSERT INTO monthly_cost_rollups (user_id, service_name, month, price_delta)
+SELECT user_id, service_name, month, SUM(delta)
+FROM (
+    SELECT user_id, service_name, first_month AS month, price AS delta
+    FROM monthly_cost_rollup_subscriptions
+    UNION ALL
+    SELECT user_id, service_name, stop_month, -price
+    FROM monthly_cost_rollup_subscriptions
+    WHERE stop_month IS NOT NULL
+) deltas
+GROUP BY user_id, service_name, month
+HAVING SUM(delta) <> 0;
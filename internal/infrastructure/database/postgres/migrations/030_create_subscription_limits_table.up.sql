@@ -0,0 +1,8 @@
+-- Лимиты числа подписок, заданные администратором отдельным пользователям
+-- вместо limits.max_subscriptions_per_user; 0 — без ограничения.
+CREATE TABLE subscription_limits (
+    user_id UUID PRIMARY KEY,
+    max_subscriptions INTEGER NOT NULL CHECK (max_subscriptions >= 0),
+    reason TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
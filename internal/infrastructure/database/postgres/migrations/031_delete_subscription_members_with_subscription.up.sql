@@ -0,0 +1,21 @@
+-- Участники удаляются вместе с подпиской, как комментарии, история цен и
+-- напоминания (см. 021).
+CREATE OR REPLACE FUNCTION subscriptions_delete_dependents() RETURNS trigger AS $$
+BEGIN
+    IF current_setting('subscriptions.partition_move', true) = 'on'
+        OR EXISTS (SELECT 1 FROM subscriptions WHERE id = OLD.id) THEN
+        RETURN NULL;
+    END IF;
+
+    DELETE FROM subscription_comments WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_price_history WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_expiry_reminders WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_members WHERE subscription_id = OLD.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Участники подписок, удалённых до этой миграции; архивные подписки не удалены.
+DELETE FROM subscription_members m
+WHERE NOT EXISTS (SELECT 1 FROM subscriptions s WHERE s.id = m.subscription_id)
+    AND NOT EXISTS (SELECT 1 FROM subscriptions_archive a WHERE a.id = m.subscription_id);
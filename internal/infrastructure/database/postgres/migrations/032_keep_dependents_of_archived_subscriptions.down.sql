@@ -0,0 +1,17 @@
+DROP TRIGGER IF EXISTS trg_subscriptions_archive_delete_dependents ON subscriptions_archive;
+DROP FUNCTION IF EXISTS subscriptions_archive_delete_dependents();
+
+CREATE OR REPLACE FUNCTION subscriptions_delete_dependents() RETURNS trigger AS $$
+BEGIN
+    IF current_setting('subscriptions.partition_move', true) = 'on'
+        OR EXISTS (SELECT 1 FROM subscriptions WHERE id = OLD.id) THEN
+        RETURN NULL;
+    END IF;
+
+    DELETE FROM subscription_comments WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_price_history WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_expiry_reminders WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_members WHERE subscription_id = OLD.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,36 @@
+/*
+Архивация (ArchiveEnded) удаляет строку из subscriptions, но подписка не
+удалена: комментарии, история цен, напоминания и участники остаются за
+архивной строкой. Архиватор включает subscriptions.archiving на время
+переноса. Зависимые строки удаляются, когда удаляется архивная строка
+(удаление аккаунта).
+*/
+CREATE OR REPLACE FUNCTION subscriptions_delete_dependents() RETURNS trigger AS $$
+BEGIN
+    IF current_setting('subscriptions.partition_move', true) = 'on'
+        OR current_setting('subscriptions.archiving', true) = 'on'
+        OR EXISTS (SELECT 1 FROM subscriptions WHERE id = OLD.id) THEN
+        RETURN NULL;
+    END IF;
+
+    DELETE FROM subscription_comments WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_price_history WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_expiry_reminders WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_members WHERE subscription_id = OLD.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION subscriptions_archive_delete_dependents() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM subscription_comments WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_price_history WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_expiry_reminders WHERE subscription_id = OLD.id;
+    DELETE FROM subscription_members WHERE subscription_id = OLD.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_subscriptions_archive_delete_dependents
+    AFTER DELETE ON subscriptions_archive
+    FOR EACH ROW EXECUTE FUNCTION subscriptions_archive_delete_dependents();
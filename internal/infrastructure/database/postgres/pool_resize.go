@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// ErrPoolSizing — размер пула вне допустимых границ.
+var ErrPoolSizing = errors.New("invalid pool sizing")
+
+// ErrPoolDraining — предыдущий пул после ResizePool ещё не закрылся.
+var ErrPoolDraining = errors.New("previous pool is still draining")
+
+// PoolSizing — размер пула: сколько соединений держать открытыми и
+// сколько открывать самое большее. Limit — потолок MaxConns для ResizePool.
+type PoolSizing struct {
+	MinConns int32
+	MaxConns int32
+	Limit    int32
+}
+
+// PoolSizing возвращает текущий размер пула.
+func (db *DB) PoolSizing() PoolSizing {
+	db.resizeMu.Lock()
+	defer db.resizeMu.Unlock()
+
+	return PoolSizing{
+		MinConns: db.config.MinConns,
+		MaxConns: db.config.MaxConns,
+		Limit:    db.maxConnsLimit,
+	}
+}
+
+/*
+ResizePool меняет размер пула на ходу — например, чтобы во время
+инцидента снять нагрузку с базы или, наоборот, дать сервису больше
+соединений. pgxpool не умеет менять MaxConns у живого пула, поэтому
+создаётся новый пул с теми же настройками, проверяется ping и
+подменяет старый. Старый пул сразу закрывается: новых соединений он не
+выдаёт, а запросы и транзакции, которые уже держат его соединения,
+дорабатывают. Счётчики Stats начинаются заново.
+
+Пока старый пул не закрылся, новый открывает соединение, только если у
+обоих вместе их не больше max(старый MaxConns, новый) — база не видит
+лишних соединений ни при уменьшении пула, ни при увеличении. Если старый
+пул занят целиком, ping нового ждёт первого освободившегося соединения
+в пределах ctx. Следующий ResizePool возможен, когда старый пул закрыт, до того —
+ErrPoolDraining.
+
+Допустимо 1 <= maxConns <= Limit и 0 <= minConns <= maxConns; иначе
+ErrPoolSizing. Если новый пул не смог подключиться, остаётся старый.
+*/
+func (db *DB) ResizePool(ctx context.Context, minConns, maxConns int32) (PoolSizing, error) {
+	db.resizeMu.Lock()
+	defer db.resizeMu.Unlock()
+
+	if maxConns < 1 || maxConns > db.maxConnsLimit {
+		return PoolSizing{}, fmt.Errorf("%w: max_conns must be between 1 and %d", ErrPoolSizing, db.maxConnsLimit)
+	}
+	if minConns < 0 || minConns > maxConns {
+		return PoolSizing{}, fmt.Errorf("%w: min_conns must be between 0 and max_conns (%d)", ErrPoolSizing, maxConns)
+	}
+	if db.draining != nil {
+		return PoolSizing{}, fmt.Errorf("%w: %d connections are still checked out",
+			ErrPoolDraining, db.draining.Stat().AcquiredConns())
+	}
+
+	old := db.pool.Load()
+	budget := max(db.config.MaxConns, maxConns)
+
+	var current atomic.Pointer[pgxpool.Pool]
+	gate := &drainGate{
+		budget:   budget,
+		previous: func() int32 { return old.Stat().TotalConns() },
+		current: func() int32 {
+			// Пока NewWithConfig не вернул пул, его соединения не посчитать;
+			// открыть можно, только если старый пул уже пуст.
+			if pool := current.Load(); pool != nil {
+				return pool.Stat().TotalConns()
+			}
+			return budget
+		},
+		poll: drainPollInterval,
+	}
+
+	config := db.config.Copy()
+	config.MinConns = minConns
+	config.MaxConns = maxConns
+	if config.MinIdleConns > maxConns {
+		config.MinIdleConns = maxConns
+	}
+	config.BeforeConnect = func(ctx context.Context, _ *pgx.ConnConfig) error {
+		return gate.wait(ctx)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return PoolSizing{}, fmt.Errorf("create connection pool: %w", err)
+	}
+	current.Store(pool)
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return PoolSizing{}, fmt.Errorf("ping database: %w", err)
+	}
+
+	previous := db.config
+	db.pool.Store(pool)
+	db.config = config
+	db.draining = old
+
+	go db.drain(old)
+
+	db.log.Warn("postgres pool resized",
+		zap.Int32("min_conns", minConns),
+		zap.Int32("max_conns", maxConns),
+		zap.Int32("previous_min_conns", previous.MinConns),
+		zap.Int32("previous_max_conns", previous.MaxConns))
+
+	return PoolSizing{MinConns: minConns, MaxConns: maxConns, Limit: db.maxConnsLimit}, nil
+}
+
+// drain закрывает старый пул. Close ждёт, пока вернутся все занятые
+// соединения, — в том числе соединения сессионных advisory-блокировок, —
+// поэтому, пока он ждёт, раз в drainReportInterval пишется предупреждение.
+func (db *DB) drain(old *pgxpool.Pool) {
+	closed := make(chan struct{})
+	go func() {
+		old.Close()
+		close(closed)
+	}()
+
+	ticker := time.NewTicker(drainReportInterval)
+	defer ticker.Stop()
+
+	started := time.Now()
+	for {
+		select {
+		case <-closed:
+			db.resizeMu.Lock()
+			db.draining = nil
+			db.resizeMu.Unlock()
+			db.log.Info("previous postgres pool closed", zap.Duration("waited", time.Since(started)))
+			return
+		case <-ticker.C:
+			db.log.Warn("previous postgres pool is waiting for checked-out connections",
+				zap.Int32("acquired_conns", old.Stat().AcquiredConns()),
+				zap.Duration("waited", time.Since(started)))
+		}
+	}
+}
+
+const (
+	drainPollInterval   = 10 * time.Millisecond
+	drainReportInterval = 10 * time.Second
+)
+
+// drainGate — BeforeConnect нового пула, пока закрывается старый:
+// соединение открывается, только когда у обоих пулов вместе их не больше
+// budget. Соединение, которое открывается сейчас, pgxpool уже учёл в
+// current, а старый пул после Close только убывает, поэтому одновременные
+// подключения не проскочат лимит вдвоём.
+type drainGate struct {
+	budget   int32
+	previous func() int32
+	current  func() int32
+	poll     time.Duration
+}
+
+func (g *drainGate) wait(ctx context.Context) error {
+	if g.previous()+g.current() <= g.budget {
+		return nil
+	}
+
+	ticker := time.NewTicker(g.poll)
+	defer ticker.Stop()
+
+	for g.previous()+g.current() > g.budget {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for previous pool to drain: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
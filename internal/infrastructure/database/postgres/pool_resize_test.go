@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDrainGateKeepsConnectionsWithinBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldConns    int32
+		newMaxConns int32
+	}{
+		{name: "grow", oldConns: 4, newMaxConns: 10},
+		{name: "shrink", oldConns: 10, newMaxConns: 3},
+		{name: "same size", oldConns: 6, newMaxConns: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := max(tt.oldConns, tt.newMaxConns)
+
+			// previous — соединения старого пула, занятые запросами; они
+			// возвращаются по одному. current — соединения нового пула, как
+			// их считает pgxpool: вместе с теми, что ещё открываются.
+			var previous, current, opened atomic.Int32
+			previous.Store(tt.oldConns)
+
+			gate := &drainGate{
+				budget:   budget,
+				previous: previous.Load,
+				current:  current.Load,
+				poll:     time.Millisecond,
+			}
+
+			var peak atomic.Int32
+			var wg sync.WaitGroup
+			for range tt.newMaxConns {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					current.Add(1)
+					if err := gate.wait(context.Background()); err != nil {
+						t.Errorf("wait: %v", err)
+						return
+					}
+					total := previous.Load() + opened.Add(1)
+					for {
+						p := peak.Load()
+						if total <= p || peak.CompareAndSwap(p, total) {
+							break
+						}
+					}
+				}()
+			}
+
+			for previous.Load() > 0 {
+				time.Sleep(2 * time.Millisecond)
+				previous.Add(-1)
+			}
+			wg.Wait()
+
+			if opened.Load() != tt.newMaxConns {
+				t.Errorf("new pool opened %d connections, want %d", opened.Load(), tt.newMaxConns)
+			}
+			if peak.Load() > budget {
+				t.Errorf("old and new pools held %d connections together, budget is %d", peak.Load(), budget)
+			}
+		})
+	}
+}
+
+func TestDrainGateStopsWithContext(t *testing.T) {
+	// Старый пул не отдаёт соединение: ожидание заканчивается вместе с ctx.
+	gate := &drainGate{
+		budget:   2,
+		previous: func() int32 { return 2 },
+		current:  func() int32 { return 1 },
+		poll:     time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := gate.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want deadline exceeded", err)
+	}
+}
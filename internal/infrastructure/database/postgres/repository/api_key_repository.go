@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+const apiKeyColumns = `id, name, prefix, key_hash, role, created_at, revoked_at`
+
+type apiKeyRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewAPIKeyRepository(db *postgres.DB, log *logger.Logger) *apiKeyRepository {
+	return &apiKeyRepository{
+		db:  db,
+		log: log.Named("api-key-repository"),
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, name, prefix, key_hash, role, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		key.ID(),
+		key.Name(),
+		key.Prefix(),
+		key.KeyHash(),
+		string(key.Role()),
+		key.CreatedAt(),
+	)
+	if err != nil {
+		r.log.Error("failed to create api key",
+			zap.String("api_key_id", key.ID().String()),
+			zap.Error(err))
+		return dbError("create api key", err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := fmt.Sprintf(`SELECT %s FROM api_keys WHERE key_hash = $1`, apiKeyColumns)
+
+	key, err := scanAPIKey(r.db.Conn(ctx).QueryRow(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		r.log.Error("failed to get api key", zap.Error(err))
+		return nil, dbError("get api key", err)
+	}
+
+	return key, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	query := fmt.Sprintf(`SELECT %s FROM api_keys ORDER BY created_at, id`, apiKeyColumns)
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query)
+	if err != nil {
+		r.log.Error("failed to list api keys", zap.Error(err))
+		return nil, dbError("list api keys", err)
+	}
+	defer rows.Close()
+
+	keys := make([]*models.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, dbError("scan api key", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate api keys", err)
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) (*models.APIKey, error) {
+	var revoked *models.APIKey
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := r.db.Conn(ctx)
+
+		query := fmt.Sprintf(`SELECT %s FROM api_keys WHERE id = $1 FOR UPDATE`, apiKeyColumns)
+		key, err := scanAPIKey(conn.QueryRow(ctx, query, id))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperror.NotFound("api key")
+			}
+			return dbError("get api key", err)
+		}
+
+		if key.IsRevoked() {
+			return apperror.Conflict("api key", "already revoked")
+		}
+
+		if _, err := conn.Exec(ctx, `UPDATE api_keys SET revoked_at = $2 WHERE id = $1`, id, at); err != nil {
+			return dbError("revoke api key", err)
+		}
+
+		revoked = models.RestoreAPIKey(key.ID(), key.Name(), key.Prefix(), key.KeyHash(), key.Role(), key.CreatedAt(), &at)
+		return nil
+	})
+	if err != nil {
+		r.log.Error("failed to revoke api key",
+			zap.String("api_key_id", id.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return revoked, nil
+}
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var (
+		id        uuid.UUID
+		name      string
+		prefix    string
+		keyHash   string
+		role      string
+		createdAt time.Time
+		revokedAt *time.Time
+	)
+	if err := row.Scan(&id, &name, &prefix, &keyHash, &role, &createdAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	return models.RestoreAPIKey(id, name, prefix, keyHash, models.Role(role), createdAt, revokedAt), nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type billingCommandRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewBillingCommandRepository(db *postgres.DB, log *logger.Logger) *billingCommandRepository {
+	return &billingCommandRepository{
+		db:  db,
+		log: log.Named("billing-command-repository"),
+	}
+}
+
+func (r *billingCommandRepository) Get(ctx context.Context, commandID string) (*models.BillingCommandResult, error) {
+	query := `
+		SELECT command_id, command_type, status, subscription_id, error_code, error_message, processed_at
+		FROM billing_commands
+		WHERE command_id = $1`
+
+	var (
+		id             string
+		commandType    string
+		status         string
+		subscriptionID *uuid.UUID
+		errorCode      *string
+		errorMessage   *string
+		processedAt    time.Time
+	)
+	err := r.db.Conn(ctx).QueryRow(ctx, query, commandID).Scan(
+		&id, &commandType, &status, &subscriptionID, &errorCode, &errorMessage, &processedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		r.log.Error("failed to get billing command", zap.String("command_id", commandID), zap.Error(err))
+		return nil, dbError("get billing command", err)
+	}
+
+	return models.RestoreBillingCommandResult(id, commandType, models.BillingCommandStatus(status),
+		subscriptionID, derefString(errorCode), derefString(errorMessage), processedAt), nil
+}
+
+// Save полагается на первичный ключ: параллельная вставка той же команды
+// ждёт коммита первой транзакции и затем ничего не вставляет.
+func (r *billingCommandRepository) Save(ctx context.Context, result *models.BillingCommandResult) (bool, error) {
+	query := `
+		INSERT INTO billing_commands (command_id, command_type, status, subscription_id, error_code, error_message, processed_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7)
+		ON CONFLICT (command_id) DO NOTHING`
+
+	tag, err := r.db.Conn(ctx).Exec(ctx, query,
+		result.CommandID(),
+		result.CommandType(),
+		string(result.Status()),
+		result.SubscriptionID(),
+		result.ErrorCode(),
+		result.ErrorMessage(),
+		result.ProcessedAt(),
+	)
+	if err != nil {
+		r.log.Error("failed to save billing command",
+			zap.String("command_id", result.CommandID()),
+			zap.Error(err))
+		return false, dbError("save billing command", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type calendarFeedRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewCalendarFeedRepository(db *postgres.DB, log *logger.Logger) *calendarFeedRepository {
+	return &calendarFeedRepository{
+		db:  db,
+		log: log.Named("calendar-feed-repository"),
+	}
+}
+
+func (r *calendarFeedRepository) Save(ctx context.Context, token *models.CalendarFeedToken) error {
+	query := `
+		INSERT INTO calendar_feed_tokens (user_id, token_hash, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+			SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query, token.UserID(), token.TokenHash(), token.CreatedAt())
+	if err != nil {
+		r.log.Error("failed to save calendar feed token",
+			zap.String("user_id", token.UserID().String()),
+			zap.Error(err))
+		return dbError("save calendar feed token", err)
+	}
+
+	return nil
+}
+
+func (r *calendarFeedRepository) GetByUser(ctx context.Context, userID uuid.UUID) (*models.CalendarFeedToken, error) {
+	query := `SELECT token_hash, created_at FROM calendar_feed_tokens WHERE user_id = $1`
+
+	var (
+		tokenHash string
+		createdAt time.Time
+	)
+	if err := r.db.Conn(ctx).QueryRow(ctx, query, userID).Scan(&tokenHash, &createdAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NotFound("calendar feed token")
+		}
+		r.log.Error("failed to get calendar feed token",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, dbError("get calendar feed token", err)
+	}
+
+	return models.RestoreCalendarFeedToken(userID, tokenHash, createdAt), nil
+}
+
+func (r *calendarFeedRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `DELETE FROM calendar_feed_tokens WHERE user_id = $1`, userID)
+	if err != nil {
+		r.log.Error("failed to delete calendar feed token",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return dbError("delete calendar feed token", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("calendar feed token")
+	}
+
+	return nil
+}
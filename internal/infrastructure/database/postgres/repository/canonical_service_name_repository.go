@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// serviceNameKeySQL повторяет models.ServiceNameKey и совпадает с
+// выражением индекса idx_subscriptions_service_name_key.
+const serviceNameKeySQL = `lower(btrim(regexp_replace(service_name, '\s+', ' ', 'g')))`
+
+type canonicalServiceNameRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewCanonicalServiceNameRepository(db *postgres.DB, log *logger.Logger) *canonicalServiceNameRepository {
+	return &canonicalServiceNameRepository{
+		db:  db,
+		log: log.Named("canonical-service-name-repository"),
+	}
+}
+
+func (r *canonicalServiceNameRepository) Create(ctx context.Context, entry *models.CanonicalServiceName) error {
+	query := `
+		INSERT INTO canonical_service_names (id, name, aliases, created_at)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		entry.ID(),
+		entry.Name(),
+		entry.Aliases(),
+		entry.CreatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return apperror.New(apperror.CodeConflict, "Canonical service name already exists").
+				WithDetail("name", entry.Name())
+		}
+
+		r.log.Error("failed to create canonical service name",
+			zap.String("name", entry.Name()),
+			zap.Error(err))
+		return dbError("create canonical service name", err)
+	}
+
+	return nil
+}
+
+func (r *canonicalServiceNameRepository) List(ctx context.Context) ([]*models.CanonicalServiceName, error) {
+	query := `
+		SELECT id, name, aliases, created_at
+		FROM canonical_service_names
+		ORDER BY created_at, id`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query)
+	if err != nil {
+		r.log.Error("failed to list canonical service names", zap.Error(err))
+		return nil, dbError("list canonical service names", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*models.CanonicalServiceName, 0)
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			name      string
+			aliases   []string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&id, &name, &aliases, &createdAt); err != nil {
+			return nil, dbError("scan canonical service name", err)
+		}
+		entries = append(entries, models.RestoreCanonicalServiceName(id, name, aliases, createdAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate canonical service names", err)
+	}
+
+	return entries, nil
+}
+
+func (r *canonicalServiceNameRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `DELETE FROM canonical_service_names WHERE id = $1`, id)
+	if err != nil {
+		r.log.Error("failed to delete canonical service name",
+			zap.String("entry_id", id.String()),
+			zap.Error(err))
+		return dbError("delete canonical service name", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("canonical service name")
+	}
+
+	return nil
+}
+
+// FindVariants не отсекает секции subscriptions: ключ названия не связан
+// со start_date, поиск идёт по индексу в каждой секции.
+func (r *canonicalServiceNameRepository) FindVariants(ctx context.Context, keys []string, canonical string, afterID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT id
+		FROM subscriptions
+		WHERE ` + serviceNameKeySQL + ` = ANY($1)
+			AND service_name <> $2
+			AND id > $3
+		ORDER BY id
+		LIMIT $4`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, keys, canonical, afterID, limit)
+	if err != nil {
+		r.log.Error("failed to find service name variants",
+			zap.String("canonical", canonical),
+			zap.Error(err))
+		return nil, dbError("find service name variants", err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, dbError("scan service name variant", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate service name variants", err)
+	}
+
+	return ids, nil
+}
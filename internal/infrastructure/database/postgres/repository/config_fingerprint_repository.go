@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type configFingerprintRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewConfigFingerprintRepository(db *postgres.DB, log *logger.Logger) *configFingerprintRepository {
+	return &configFingerprintRepository{
+		db:  db,
+		log: log.Named("config-fingerprint-repository"),
+	}
+}
+
+func (r *configFingerprintRepository) Upsert(ctx context.Context, fingerprint *models.ConfigFingerprint) error {
+	query := `
+		INSERT INTO config_fingerprints (instance_id, config_hash, reported_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (instance_id) DO UPDATE
+		SET config_hash = EXCLUDED.config_hash, reported_at = EXCLUDED.reported_at`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		fingerprint.InstanceID(),
+		fingerprint.Hash(),
+		fingerprint.ReportedAt(),
+	)
+	if err != nil {
+		r.log.Error("failed to upsert config fingerprint",
+			zap.String("instance_id", fingerprint.InstanceID()),
+			zap.Error(err))
+		return dbError("upsert config fingerprint", err)
+	}
+
+	return nil
+}
+
+func (r *configFingerprintRepository) ListReportedSince(ctx context.Context, since time.Time) ([]*models.ConfigFingerprint, error) {
+	query := `
+		SELECT instance_id, config_hash, reported_at
+		FROM config_fingerprints
+		WHERE reported_at >= $1
+		ORDER BY instance_id`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, since)
+	if err != nil {
+		r.log.Error("failed to list config fingerprints", zap.Error(err))
+		return nil, dbError("list config fingerprints", err)
+	}
+	defer rows.Close()
+
+	fingerprints := make([]*models.ConfigFingerprint, 0)
+	for rows.Next() {
+		var (
+			instanceID string
+			hash       string
+			reportedAt time.Time
+		)
+		if err := rows.Scan(&instanceID, &hash, &reportedAt); err != nil {
+			return nil, dbError("scan config fingerprint", err)
+		}
+
+		fingerprint := models.NewConfigFingerprint(instanceID, hash)
+		fingerprint.SetReportedAt(reportedAt)
+		fingerprints = append(fingerprints, fingerprint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate config fingerprints", err)
+	}
+
+	return fingerprints, nil
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+const costAlertColumns = `id, user_id, monthly_limit, created_at`
+
+type costAlertRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewCostAlertRepository(db *postgres.DB, log *logger.Logger) *costAlertRepository {
+	return &costAlertRepository{
+		db:  db,
+		log: log.Named("cost-alert-repository"),
+	}
+}
+
+func (r *costAlertRepository) Create(ctx context.Context, alert *models.CostAlert) error {
+	query := `
+		INSERT INTO cost_alerts (` + costAlertColumns + `)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		alert.ID(),
+		alert.UserID(),
+		alert.MonthlyLimit(),
+		alert.CreatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return apperror.New(apperror.CodeConflict, "Cost alert already exists").
+				WithDetail("monthly_limit", strconv.Itoa(alert.MonthlyLimit()))
+		}
+
+		r.log.Error("failed to create cost alert",
+			zap.String("user_id", alert.UserID().String()),
+			zap.Error(err))
+		return dbError("create cost alert", err)
+	}
+
+	return nil
+}
+
+func (r *costAlertRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CostAlert, error) {
+	query := `SELECT ` + costAlertColumns + ` FROM cost_alerts WHERE id = $1`
+
+	alert, err := r.scanCostAlert(r.db.Conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NotFound("cost alert")
+		}
+		r.log.Error("failed to get cost alert",
+			zap.String("alert_id", id.String()),
+			zap.Error(err))
+		return nil, dbError("get cost alert", err)
+	}
+
+	return alert, nil
+}
+
+func (r *costAlertRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.CostAlert, error) {
+	query := `
+		SELECT ` + costAlertColumns + `
+		FROM cost_alerts
+		WHERE user_id = $1
+		ORDER BY monthly_limit, id`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID)
+	if err != nil {
+		r.log.Error("failed to list cost alerts",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, dbError("list cost alerts", err)
+	}
+	defer rows.Close()
+
+	return r.scanCostAlerts(rows)
+}
+
+func (r *costAlertRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `DELETE FROM cost_alerts WHERE id = $1`, id)
+	if err != nil {
+		r.log.Error("failed to delete cost alert",
+			zap.String("alert_id", id.String()),
+			zap.Error(err))
+		return dbError("delete cost alert", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("cost alert")
+	}
+
+	return nil
+}
+
+func (r *costAlertRepository) ListUnnotified(ctx context.Context, month time.Time, afterID uuid.UUID, limit int) ([]*models.CostAlert, error) {
+	query := `
+		SELECT ` + costAlertColumns + `
+		FROM cost_alerts a
+		WHERE a.id > $2
+			AND NOT EXISTS (
+				SELECT 1 FROM cost_alert_notifications n
+				WHERE n.alert_id = a.id AND n.month = $1
+			)
+		ORDER BY a.id
+		LIMIT $3`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, month, afterID, limit)
+	if err != nil {
+		r.log.Error("failed to list unnotified cost alerts", zap.Error(err))
+		return nil, dbError("list unnotified cost alerts", err)
+	}
+	defer rows.Close()
+
+	return r.scanCostAlerts(rows)
+}
+
+func (r *costAlertRepository) MarkNotified(ctx context.Context, alertID uuid.UUID, month time.Time, spent int, eventID uuid.UUID) (bool, error) {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `
+		INSERT INTO cost_alert_notifications (alert_id, month, spent, event_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (alert_id, month) DO NOTHING`,
+		alertID, month, spent, eventID)
+	if err != nil {
+		r.log.Error("failed to mark cost alert notified",
+			zap.String("alert_id", alertID.String()),
+			zap.Error(err))
+		return false, dbError("mark cost alert notified", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *costAlertRepository) NotifiedAt(ctx context.Context, userID uuid.UUID, month time.Time) (map[uuid.UUID]time.Time, error) {
+	query := `
+		SELECT n.alert_id, n.sent_at
+		FROM cost_alert_notifications n
+		JOIN cost_alerts a ON a.id = n.alert_id
+		WHERE a.user_id = $1 AND n.month = $2`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID, month)
+	if err != nil {
+		r.log.Error("failed to get cost alert notifications",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, dbError("get cost alert notifications", err)
+	}
+	defer rows.Close()
+
+	notified := make(map[uuid.UUID]time.Time)
+	for rows.Next() {
+		var (
+			alertID uuid.UUID
+			sentAt  time.Time
+		)
+		if err := rows.Scan(&alertID, &sentAt); err != nil {
+			return nil, dbError("scan cost alert notification", err)
+		}
+		notified[alertID] = sentAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate cost alert notifications", err)
+	}
+
+	return notified, nil
+}
+
+func (r *costAlertRepository) scanCostAlerts(rows pgx.Rows) ([]*models.CostAlert, error) {
+	alerts := make([]*models.CostAlert, 0)
+	for rows.Next() {
+		alert, err := r.scanCostAlert(rows)
+		if err != nil {
+			return nil, dbError("scan cost alert", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate cost alerts", err)
+	}
+
+	return alerts, nil
+}
+
+func (r *costAlertRepository) scanCostAlert(row pgx.Row) (*models.CostAlert, error) {
+	var (
+		id           uuid.UUID
+		userID       uuid.UUID
+		monthlyLimit int
+		createdAt    time.Time
+	)
+	if err := row.Scan(&id, &userID, &monthlyLimit, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return models.RestoreCostAlert(id, userID, monthlyLimit, createdAt), nil
+}
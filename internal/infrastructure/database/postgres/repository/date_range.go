@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+/*
+SQL-эквиваленты правил models.DateRange для колонок подписки
+start_date/end_date (NULL — бессрочная). from и to — SQL-выражения
+границ закрытого периода, обычно плейсхолдеры вроде "$1".
+Любой запрос, считающий стоимость за период, должен строиться через них,
+иначе результат разойдётся с Subscription.CalculateCostForPeriod.
+
+subscriptions секционирована по start_date (миграция 021), и секции
+отсекаются только по условию на start_date. Запросы, которые выбирают по
+end_date, добавляют выведенную из него границу start_date: end_date не
+раньше start_date.
+*/
+
+// periodOverlapSQL повторяет DateRange.Overlaps: подписка пересекается с периодом.
+func periodOverlapSQL(prefix, from, to string) string {
+	return fmt.Sprintf("%[1]sstart_date <= %[3]s AND (%[1]send_date IS NULL OR %[1]send_date >= %[2]s)",
+		prefix, from, to)
+}
+
+// periodCostSQL повторяет Subscription.CalculateCostWithPrices для текущей
+// цены подписки или цены по истории изменений.
+func periodCostSQL(prefix, from, to string, mode models.BillingMode, pricing models.PricingMode) string {
+	start, end := periodBoundsSQL(prefix, from, to)
+	if pricing == models.PricingHistorical {
+		return rangeCostAtSQL(priceAtSQL(prefix), start, end, mode)
+	}
+	return rangeCostSQL(prefix+"price", start, end, mode)
+}
+
+/*
+discountSQL повторяет Discount.AmountFor для скидки, присоединённой к
+подписке под алиасом discount (LEFT JOIN discounts): скидка считается по
+пересечению подписки, периода и окна действия промокода. Без скидки — 0.
+*/
+func discountSQL(prefix, discount, from, to string, mode models.BillingMode, pricing models.PricingMode) string {
+	start := fmt.Sprintf("GREATEST(%sstart_date, %s, %svalid_from)", prefix, from, discount)
+	end := fmt.Sprintf("LEAST(COALESCE(%[1]send_date, %[2]s), %[2]s, COALESCE(%[3]svalid_to, %[2]s))", prefix, to, discount)
+
+	cost := rangeCostSQL(prefix+"price", start, end, mode)
+	capped := rangeCostSQL(fmt.Sprintf("LEAST(%samount, %sprice)", discount, prefix), start, end, mode)
+	if pricing == models.PricingHistorical {
+		priceAt := priceAtSQL(prefix)
+		cost = rangeCostAtSQL(priceAt, start, end, mode)
+		capped = rangeCostAtSQL(func(at string) string {
+			return fmt.Sprintf("LEAST(%samount, %s)", discount, priceAt(at))
+		}, start, end, mode)
+	}
+
+	return fmt.Sprintf(`CASE
+		WHEN %[1]sid IS NULL OR %[2]s > %[3]s THEN 0
+		WHEN %[1]skind = '%[4]s' THEN ROUND(%[5]s * %[1]samount / 100.0)::int
+		ELSE %[6]s
+	END`,
+		discount, start, end, models.DiscountPercentage, cost, capped)
+}
+
+// periodBoundsSQL — начало и конец пересечения подписки с периодом.
+func periodBoundsSQL(prefix, from, to string) (string, string) {
+	return fmt.Sprintf("GREATEST(%sstart_date, %s)", prefix, from),
+		fmt.Sprintf("LEAST(COALESCE(%[1]send_date, %[2]s), %[2]s)", prefix, to)
+}
+
+// rangeMonthsSQL повторяет DateRange.Months(): число календарных месяцев
+// (в UTC) в [start, end]. Для пустого диапазона значение не имеет смысла.
+func rangeMonthsSQL(start, end string) string {
+	return fmt.Sprintf("(%s - %s + 1)::int", monthIndexSQL(end), monthIndexSQL(start))
+}
+
+// monthIndexSQL нумерует месяцы момента expr (в UTC) подряд, как monthIndex в models.
+func monthIndexSQL(expr string) string {
+	return fmt.Sprintf("(EXTRACT(YEAR FROM (%[1]s) AT TIME ZONE 'UTC') * 12 + EXTRACT(MONTH FROM (%[1]s) AT TIME ZONE 'UTC'))", expr)
+}
+
+// rangeCostSQL — стоимость [start, end] при месячной цене price в режиме mode.
+func rangeCostSQL(price, start, end string, mode models.BillingMode) string {
+	if mode != models.BillingProrated {
+		return fmt.Sprintf("%s * %s", price, rangeMonthsSQL(start, end))
+	}
+
+	// Дни пересечения в каждом месяце делятся на длину месяца; сумма
+	// округляется один раз на подписку, как в DateRange.Prorate.
+	start = fmt.Sprintf("((%s) AT TIME ZONE 'UTC')", start)
+	end = fmt.Sprintf("((%s) AT TIME ZONE 'UTC')", end)
+	return fmt.Sprintf(`ROUND(%[1]s * (
+		SELECT SUM((LEAST(%[3]s, m + interval '1 month' - interval '1 day')::date - GREATEST(%[2]s, m)::date + 1)::numeric
+			/ EXTRACT(DAY FROM m + interval '1 month' - interval '1 day'))
+		FROM generate_series(date_trunc('month', %[2]s), %[3]s, interval '1 month') AS m
+	))::int`, price, start, end)
+}
+
+// priceAtSQL повторяет PriceSchedule.At: цена подписки в момент at по
+// subscription_price_history, без истории — текущая цена.
+func priceAtSQL(prefix string) func(at string) string {
+	return func(at string) string {
+		return fmt.Sprintf(`COALESCE(
+			(SELECT h.new_price FROM subscription_price_history h
+				WHERE h.subscription_id = %[1]sid AND h.effective_from <= %[2]s
+				ORDER BY h.effective_from DESC, h.id DESC LIMIT 1),
+			(SELECT h.old_price FROM subscription_price_history h
+				WHERE h.subscription_id = %[1]sid
+				ORDER BY h.effective_from, h.id LIMIT 1),
+			%[1]sprice)`, prefix, at)
+	}
+}
+
+// rangeCostAtSQL повторяет PriceSchedule.Cost для цены, зависящей от
+// времени: priceAt получает момент как timestamptz.
+func rangeCostAtSQL(priceAt func(at string) string, start, end string, mode models.BillingMode) string {
+	start = fmt.Sprintf("((%s) AT TIME ZONE 'UTC')", start)
+	end = fmt.Sprintf("((%s) AT TIME ZONE 'UTC')", end)
+
+	if mode != models.BillingProrated {
+		return fmt.Sprintf(`(
+		SELECT SUM(%[1]s)
+		FROM generate_series(date_trunc('month', %[2]s), %[3]s, interval '1 month') AS price_month
+	)::int`, priceAt(fmt.Sprintf("(GREATEST(price_month, %s) AT TIME ZONE 'UTC')", start)), start, end)
+	}
+
+	return fmt.Sprintf(`ROUND((
+		SELECT SUM((%[1]s)::numeric / EXTRACT(DAY FROM date_trunc('month', price_day) + interval '1 month' - interval '1 day'))
+		FROM generate_series(date_trunc('day', %[2]s), %[3]s, interval '1 day') AS price_day
+	))::int`, priceAt("(price_day AT TIME ZONE 'UTC')"), start, end)
+}
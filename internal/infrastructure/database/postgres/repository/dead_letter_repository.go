@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+const deadLetterColumns = "id, source, topic, event_id, event_type, payload, error, failed_at, requeued_at, requeue_count"
+
+type deadLetterRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewDeadLetterRepository(db *postgres.DB, log *logger.Logger) *deadLetterRepository {
+	return &deadLetterRepository{
+		db:  db,
+		log: log.Named("dead-letter-repository"),
+	}
+}
+
+func (r *deadLetterRepository) CreateForEvent(ctx context.Context, event *models.SubscriptionEvent, source, reason string) error {
+	eventPayload, err := newSubscriptionEventPayload(event)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(eventPayload)
+	if err != nil {
+		return apperror.InternalError("marshal subscription event", err)
+	}
+
+	_, err = r.db.Conn(ctx).Exec(ctx, `
+		INSERT INTO dead_letters (id, source, topic, event_id, event_type, payload, error, failed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		uuid.New(), source, subscriptionEventTopic, event.ID(), event.Type(), payload, reason, time.Now(),
+	)
+	if err != nil {
+		r.log.Error("failed to create dead letter",
+			zap.String("event_id", event.ID().String()),
+			zap.String("source", source),
+			zap.Error(err))
+		return dbError("create dead letter", err)
+	}
+
+	return nil
+}
+
+func (r *deadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.DeadLetter, error) {
+	query := fmt.Sprintf(`SELECT %s FROM dead_letters WHERE id = $1`, deadLetterColumns)
+
+	deadLetter, err := scanDeadLetter(r.db.Conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NotFound("dead letter")
+		}
+		r.log.Error("failed to get dead letter",
+			zap.String("dead_letter_id", id.String()),
+			zap.Error(err))
+		return nil, dbError("get dead letter", err)
+	}
+
+	return deadLetter, nil
+}
+
+func (r *deadLetterRepository) List(ctx context.Context, filter models.DeadLetterFilter, limit, offset int) ([]*models.DeadLetter, error) {
+	where, args := deadLetterFilterSQL(filter)
+	args = append(args, limit, offset)
+
+	query := fmt.Sprintf(`
+		SELECT %s FROM dead_letters
+		%s
+		ORDER BY failed_at DESC, id
+		LIMIT $%d OFFSET $%d`, deadLetterColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		r.log.Error("failed to list dead letters", zap.Error(err))
+		return nil, dbError("list dead letters", err)
+	}
+	defer rows.Close()
+
+	deadLetters := make([]*models.DeadLetter, 0)
+	for rows.Next() {
+		deadLetter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, dbError("scan dead letter", err)
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate dead letters", err)
+	}
+
+	return deadLetters, nil
+}
+
+func (r *deadLetterRepository) Count(ctx context.Context, filter models.DeadLetterFilter) (int, error) {
+	where, args := deadLetterFilterSQL(filter)
+
+	var count int
+	if err := r.db.Conn(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM dead_letters "+where, args...).Scan(&count); err != nil {
+		r.log.Error("failed to count dead letters", zap.Error(err))
+		return 0, dbError("count dead letters", err)
+	}
+
+	return count, nil
+}
+
+/*
+Requeue в одной транзакции восстанавливает событие в журнале и аудите,
+если асинхронная запись так и не дошла до БД, и ставит сообщение в outbox
+заново: существующая строка outbox снова становится неопубликованной.
+*/
+func (r *deadLetterRepository) Requeue(ctx context.Context, id uuid.UUID, at time.Time) (*models.DeadLetter, error) {
+	var requeued *models.DeadLetter
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := r.db.Conn(ctx)
+
+		query := fmt.Sprintf(`SELECT %s FROM dead_letters WHERE id = $1 FOR UPDATE`, deadLetterColumns)
+		deadLetter, err := scanDeadLetter(conn.QueryRow(ctx, query, id))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return apperror.NotFound("dead letter")
+			}
+			return dbError("get dead letter", err)
+		}
+
+		if deadLetter.Status() == models.DeadLetterRequeued {
+			return apperror.Conflict("dead letter", "already requeued")
+		}
+
+		var event subscriptionEventPayload
+		if err := json.Unmarshal(deadLetter.Payload(), &event); err != nil {
+			return apperror.InternalError("decode dead letter payload", err)
+		}
+
+		// Событие, аудит, outbox и отметка о повторе уходят одним пакетом.
+		// Аудит пишется, только если событие действительно вставлено.
+		entityType, entityID := event.auditEntity()
+		batch := &pgx.Batch{}
+		batch.Queue(`
+			WITH inserted AS (
+				INSERT INTO subscription_events (id, event_type, subscription_id, user_id, payload, occurred_at)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				ON CONFLICT (id) DO NOTHING
+				RETURNING id
+			)
+			INSERT INTO audit_log (event_id, entity_type, entity_id, action, recorded_at)
+			SELECT id, $7::varchar, $8::uuid, $2, $6 FROM inserted`,
+			event.EventID, event.Type, event.SubscriptionID, event.UserID, deadLetter.Payload(), event.OccurredAt,
+			entityType, entityID)
+		batch.Queue(`
+			INSERT INTO outbox (event_id, topic, payload, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (event_id) DO UPDATE SET published_at = NULL, created_at = EXCLUDED.created_at`,
+			event.EventID, deadLetter.Topic(), deadLetter.Payload(), at)
+		batch.Queue(fmt.Sprintf(`
+			UPDATE dead_letters SET requeued_at = $2, requeue_count = requeue_count + 1
+			WHERE id = $1
+			RETURNING %s`, deadLetterColumns), id, at,
+		).QueryRow(func(row pgx.Row) error {
+			requeued, err = scanDeadLetter(row)
+			return err
+		})
+
+		if err := r.db.ExecBatch(ctx, batch); err != nil {
+			return dbError("requeue dead letter", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := apperror.IsAppError(err); !ok {
+			err = dbError("requeue dead letter", err)
+		}
+		r.log.Error("failed to requeue dead letter",
+			zap.String("dead_letter_id", id.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return requeued, nil
+}
+
+func deadLetterFilterSQL(filter models.DeadLetterFilter) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if source := filter.Source(); source != nil {
+		args = append(args, *source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+	if eventType := filter.EventType(); eventType != nil {
+		args = append(args, *eventType)
+		conditions = append(conditions, fmt.Sprintf("event_type = $%d", len(args)))
+	}
+	if status := filter.Status(); status != nil {
+		if *status == models.DeadLetterPending {
+			conditions = append(conditions, "requeued_at IS NULL")
+		} else {
+			conditions = append(conditions, "requeued_at IS NOT NULL")
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func scanDeadLetter(row pgx.Row) (*models.DeadLetter, error) {
+	var (
+		id           uuid.UUID
+		source       string
+		topic        string
+		eventID      uuid.UUID
+		eventType    string
+		payload      []byte
+		errorMessage string
+		failedAt     time.Time
+		requeuedAt   *time.Time
+		requeueCount int
+	)
+
+	if err := row.Scan(&id, &source, &topic, &eventID, &eventType, &payload, &errorMessage, &failedAt, &requeuedAt, &requeueCount); err != nil {
+		return nil, err
+	}
+
+	return models.RestoreDeadLetter(id, source, topic, eventID, eventType, payload, errorMessage, failedAt, requeuedAt, requeueCount), nil
+}
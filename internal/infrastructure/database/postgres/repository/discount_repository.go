@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type discountRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewDiscountRepository(db *postgres.DB, log *logger.Logger) *discountRepository {
+	return &discountRepository{
+		db:  db,
+		log: log.Named("discount-repository"),
+	}
+}
+
+func (r *discountRepository) Create(ctx context.Context, discount *models.Discount) error {
+	query := `
+		INSERT INTO discounts (id, code, kind, amount, service_name, valid_from, valid_to, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		discount.ID(),
+		discount.Code(),
+		string(discount.Kind()),
+		discount.Amount(),
+		discount.ServiceName(),
+		discount.ValidFrom(),
+		discount.ValidTo(),
+		discount.CreatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return apperror.New(apperror.CodeConflict, "Promo code already exists").
+				WithDetail("code", discount.Code())
+		}
+
+		r.log.Error("failed to create discount",
+			zap.String("code", discount.Code()),
+			zap.Error(err))
+		return dbError("create discount", err)
+	}
+
+	return nil
+}
+
+func (r *discountRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Discount, error) {
+	query := fmt.Sprintf(`SELECT %s FROM discounts WHERE id = $1`, discountColumns(""))
+
+	discount, err := r.scanDiscount(r.db.Conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NotFound("discount")
+		}
+		r.log.Error("failed to get discount",
+			zap.String("discount_id", id.String()),
+			zap.Error(err))
+		return nil, dbError("get discount", err)
+	}
+
+	return discount, nil
+}
+
+func (r *discountRepository) GetByCode(ctx context.Context, code string) (*models.Discount, error) {
+	query := fmt.Sprintf(`SELECT %s FROM discounts WHERE lower(code) = lower($1)`, discountColumns(""))
+
+	discount, err := r.scanDiscount(r.db.Conn(ctx).QueryRow(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NotFound("promo code")
+		}
+		r.log.Error("failed to get discount by code",
+			zap.String("code", code),
+			zap.Error(err))
+		return nil, dbError("get discount by code", err)
+	}
+
+	return discount, nil
+}
+
+func (r *discountRepository) List(ctx context.Context) ([]*models.Discount, error) {
+	query := fmt.Sprintf(`SELECT %s FROM discounts ORDER BY created_at, id`, discountColumns(""))
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query)
+	if err != nil {
+		r.log.Error("failed to list discounts", zap.Error(err))
+		return nil, dbError("list discounts", err)
+	}
+	defer rows.Close()
+
+	discounts := make([]*models.Discount, 0)
+	for rows.Next() {
+		discount, err := r.scanDiscount(rows)
+		if err != nil {
+			return nil, dbError("scan discount", err)
+		}
+		discounts = append(discounts, discount)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate discounts", err)
+	}
+
+	return discounts, nil
+}
+
+func (r *discountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `DELETE FROM discounts WHERE id = $1`, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return apperror.New(apperror.CodeConflict, "Promo code is attached to subscriptions").
+				WithDetail("discount_id", id.String())
+		}
+
+		r.log.Error("failed to delete discount",
+			zap.String("discount_id", id.String()),
+			zap.Error(err))
+		return dbError("delete discount", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("discount")
+	}
+
+	return nil
+}
+
+func (r *discountRepository) scanDiscount(row pgx.Row) (*models.Discount, error) {
+	var discount nullableDiscount
+	if err := row.Scan(discount.dest()...); err != nil {
+		return nil, err
+	}
+	return discount.model(), nil
+}
+
+// discountColumns — колонки discounts в порядке nullableDiscount.dest.
+func discountColumns(prefix string) string {
+	return fmt.Sprintf("%[1]sid, %[1]scode, %[1]skind, %[1]samount, %[1]sservice_name, %[1]svalid_from, %[1]svalid_to, %[1]screated_at", prefix)
+}
+
+// nullableDiscount сканирует скидку, в том числе из LEFT JOIN, где все
+// колонки могут быть NULL.
+type nullableDiscount struct {
+	id          *uuid.UUID
+	code        *string
+	kind        *string
+	amount      *int
+	serviceName *string
+	validFrom   *time.Time
+	validTo     *time.Time
+	createdAt   *time.Time
+}
+
+func (d *nullableDiscount) dest() []interface{} {
+	return []interface{}{&d.id, &d.code, &d.kind, &d.amount, &d.serviceName, &d.validFrom, &d.validTo, &d.createdAt}
+}
+
+// model возвращает nil, если скидки в строке нет.
+func (d *nullableDiscount) model() *models.Discount {
+	if d.id == nil {
+		return nil
+	}
+	return models.RestoreDiscount(
+		*d.id,
+		*d.code,
+		models.DiscountKind(*d.kind),
+		*d.amount,
+		d.serviceName,
+		*d.validFrom,
+		d.validTo,
+		*d.createdAt,
+	)
+}
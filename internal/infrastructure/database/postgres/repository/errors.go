@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+// Коды SQLSTATE, которые репозитории различают.
+const (
+	uniqueViolation      = "23505"
+	foreignKeyViolation  = "23503"
+	notNullViolation     = "23502"
+	checkViolation       = "23514"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+	lockNotAvailable     = "55P03"
+)
+
+/*
+dbError — общий перевод ошибок pgx в apperror для операции operation.
+Нарушение уникальности и ссылок — CONFLICT, нарушение CHECK и NOT NULL —
+INVALID_INPUT. Конфликт сериализации, взаимоблокировка и недоступность
+базы остаются DATABASE_ERROR (сбой инфраструктуры: повтор может пройти),
+но отдаются с 409 и 503 и помечаются retryable. Уже готовая AppError
+возвращается как есть. Репозитории, которым нужна своя формулировка
+(например, «Promo code already exists»), проверяют код до вызова dbError.
+*/
+func dbError(operation string, err error) *apperror.AppError {
+	if appErr, ok := apperror.IsAppError(err); ok {
+		return appErr
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case uniqueViolation, foreignKeyViolation:
+			return apperror.Wrap(err, apperror.CodeConflict, apperror.ErrorMessages[apperror.CodeConflict]).
+				WithDetail("operation", operation).
+				WithDetail("constraint", pgErr.ConstraintName)
+		case notNullViolation, checkViolation:
+			return apperror.Wrap(err, apperror.CodeInvalidInput, apperror.ErrorMessages[apperror.CodeInvalidInput]).
+				WithDetail("operation", operation).
+				WithDetail("constraint", pgErr.ConstraintName)
+		case serializationFailure, deadlockDetected, lockNotAvailable:
+			return apperror.DatabaseError(operation, err).
+				WithHTTPStatus(http.StatusConflict).
+				WithDetail("retryable", "true")
+		}
+	}
+
+	// Отменённый или просроченный ctx — не сбой базы: повтор с тем же ctx
+	// упадёт так же, хотя pgconn и считает такую ошибку безопасной для повтора.
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return apperror.DatabaseError(operation, err)
+	}
+
+	if pgconn.SafeToRetry(err) || isConnectionError(err) {
+		return apperror.DatabaseError(operation, err).
+			WithHTTPStatus(http.StatusServiceUnavailable).
+			WithDetail("retryable", "true")
+	}
+
+	return apperror.DatabaseError(operation, err)
+}
+
+// isConnectionError — соединение с базой не установлено или оборвано
+// (класс SQLSTATE 08, отказ при подключении).
+func isConnectionError(err error) bool {
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && len(pgErr.Code) == 5 && pgErr.Code[:2] == "08"
+}
+
+// IsRetryable — ошибку вернул dbError для сбоя, после которого повтор той же
+// операции может пройти.
+func IsRetryable(err error) bool {
+	appErr, ok := apperror.IsAppError(err)
+	return ok && appErr.Details()["retryable"] == "true"
+}
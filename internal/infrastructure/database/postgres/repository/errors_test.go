@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+// contextDoneError — как ошибка pgconn для уже отменённого ctx: помечена
+// безопасной для повтора и оборачивает ошибку контекста.
+type contextDoneError struct{ err error }
+
+func (e contextDoneError) Error() string     { return "context already done: " + e.err.Error() }
+func (e contextDoneError) Unwrap() error     { return e.err }
+func (e contextDoneError) SafeToRetry() bool { return true }
+
+func TestDBError(t *testing.T) {
+	pgErr := func(code string) error {
+		return fmt.Errorf("exec: %w", &pgconn.PgError{Code: code, ConstraintName: "subscriptions_pkey"})
+	}
+
+	cases := []struct {
+		name      string
+		err       error
+		code      string
+		status    int
+		retryable bool
+	}{
+		{"unique violation", pgErr(uniqueViolation), apperror.CodeConflict, http.StatusConflict, false},
+		{"foreign key violation", pgErr(foreignKeyViolation), apperror.CodeConflict, http.StatusConflict, false},
+		{"check violation", pgErr(checkViolation), apperror.CodeInvalidInput, http.StatusBadRequest, false},
+		{"not null violation", pgErr(notNullViolation), apperror.CodeInvalidInput, http.StatusBadRequest, false},
+		{"serialization failure", pgErr(serializationFailure), apperror.CodeDatabaseError, http.StatusConflict, true},
+		{"deadlock", pgErr(deadlockDetected), apperror.CodeDatabaseError, http.StatusConflict, true},
+		{"admin shutdown", pgErr("08006"), apperror.CodeDatabaseError, http.StatusServiceUnavailable, true},
+		{"other sqlstate", pgErr("42P01"), apperror.CodeDatabaseError, http.StatusInternalServerError, false},
+		{"plain error", errors.New("boom"), apperror.CodeDatabaseError, http.StatusInternalServerError, false},
+		{"context canceled", context.Canceled, apperror.CodeDatabaseError, http.StatusInternalServerError, false},
+		{"context done before send", contextDoneError{context.DeadlineExceeded}, apperror.CodeDatabaseError, http.StatusInternalServerError, false},
+		{"app error passes through", apperror.SubscriptionNotFound("id"), apperror.CodeSubscriptionNotFound, http.StatusNotFound, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := dbError("update subscription", tc.err)
+			if got.Code() != tc.code {
+				t.Fatalf("code = %s, want %s", got.Code(), tc.code)
+			}
+			if got.HTTPStatus() != tc.status {
+				t.Errorf("status = %d, want %d", got.HTTPStatus(), tc.status)
+			}
+			if retryable := got.Details()["retryable"] == "true"; retryable != tc.retryable {
+				t.Errorf("retryable = %v, want %v", retryable, tc.retryable)
+			}
+		})
+	}
+}
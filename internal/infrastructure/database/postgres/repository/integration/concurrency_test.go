@@ -0,0 +1,277 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	domainRepo "github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+const workers = 16
+
+// parallel запускает fn в workers горутинах одновременно и ждёт их.
+func parallel(fn func(i int)) {
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			fn(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+}
+
+func TestConcurrentCreateAndCount(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	const perWorker = 10
+
+	errs := make(chan error, workers*perWorker)
+	parallel(func(i int) {
+		for j := 0; j < perWorker; j++ {
+			sub := models.NewSubscription(fmt.Sprintf("Service %d-%d", i, j), models.Rubles(100+j), userID, month(2024, time.January))
+			if err := repo.Create(ctx, sub); err != nil {
+				errs <- err
+			}
+		}
+	})
+	close(errs)
+	for err := range errs {
+		t.Fatalf("concurrent create: %v", err)
+	}
+
+	filter := models.NewSubscriptionFilter()
+	filter.SetUserID(&userID)
+
+	count, err := repo.Count(ctx, filter)
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	_, total, err := repo.GetAllWithTotal(ctx, filter, 10, 0)
+	if err != nil {
+		t.Fatalf("list with total: %v", err)
+	}
+	if count != workers*perWorker || total != count {
+		t.Errorf("count %d, total %d, want %d", count, total, workers*perWorker)
+	}
+}
+
+// Параллельные обновления одной строки не должны терять запись: в базе
+// остаётся ровно одно из записанных значений.
+func TestConcurrentUpdates(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	sub := createSubscriptions(t, repo,
+		subscriptionSpec{userID: uuid.New(), service: "Netflix", price: 100, start: month(2024, time.January)})[0]
+
+	var failed atomic.Int32
+	parallel(func(i int) {
+		update := models.NewSubscription("Netflix", models.Rubles(1000+i), sub.UserID(), sub.StartDate())
+		update.SetID(sub.ID())
+		if err := repo.Update(ctx, update); err != nil {
+			failed.Add(1)
+		}
+	})
+	if failed.Load() != 0 {
+		t.Fatalf("%d concurrent updates failed", failed.Load())
+	}
+
+	got, err := repo.GetByID(ctx, sub.ID())
+	if err != nil || got == nil {
+		t.Fatalf("get: got %v, %v", got, err)
+	}
+	if price, err := got.Price().WholeUnits(); err != nil || price < 1000 || price >= 1000+workers {
+		t.Errorf("price %s is not one of the written values", got.Price())
+	}
+}
+
+// Идемпотентность биллинговых команд держится на первичном ключе: из
+// одновременных Save выигрывает ровно один.
+func TestConcurrentBillingCommandSave(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewBillingCommandRepository(testDB, testLog)
+	ctx := context.Background()
+
+	var saved, failed atomic.Int32
+	parallel(func(i int) {
+		ok, err := repo.Save(ctx, models.NewBillingCommandSucceeded("cmd-race", "subscription.create", uuid.New()))
+		switch {
+		case err != nil:
+			failed.Add(1)
+		case ok:
+			saved.Add(1)
+		}
+	})
+	if failed.Load() != 0 || saved.Load() != 1 {
+		t.Errorf("saved %d, failed %d, want exactly one save", saved.Load(), failed.Load())
+	}
+}
+
+func TestConcurrentAPIKeyRevoke(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewAPIKeyRepository(testDB, testLog)
+	ctx := context.Background()
+
+	key, _, err := models.NewAPIKey("race", models.RoleViewer)
+	if err != nil {
+		t.Fatalf("new key: %v", err)
+	}
+	if err := repo.Create(ctx, key); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	var revoked, conflicts, other atomic.Int32
+	parallel(func(i int) {
+		_, err := repo.Revoke(ctx, key.ID(), time.Now())
+		if err == nil {
+			revoked.Add(1)
+			return
+		}
+		if appErr, ok := apperror.IsAppError(err); ok && appErr.Code() == apperror.CodeConflict {
+			conflicts.Add(1)
+			return
+		}
+		other.Add(1)
+	})
+	if revoked.Load() != 1 || conflicts.Load() != workers-1 || other.Load() != 0 {
+		t.Errorf("revoked %d, conflicts %d, other errors %d", revoked.Load(), conflicts.Load(), other.Load())
+	}
+}
+
+func TestConcurrentExpiryReminders(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	events := repository.NewSubscriptionEventRepository(testDB, testLog)
+	ctx := context.Background()
+
+	sub := createSubscriptions(t, repo, subscriptionSpec{userID: uuid.New(), service: "Netflix", price: 599,
+		start: month(2024, time.January), end: ptr(endOfMonth(2024, time.June))})[0]
+
+	var marked atomic.Int32
+	parallel(func(i int) {
+		ok, err := events.MarkExpiryReminded(ctx, sub.ID(), *sub.EndDate(), uuid.New())
+		if err == nil && ok {
+			marked.Add(1)
+		}
+	})
+	if marked.Load() != 1 {
+		t.Errorf("reminder marked %d times, want once", marked.Load())
+	}
+}
+
+func TestAdvisoryLockExclusive(t *testing.T) {
+	locks := postgres.NewAdvisoryLocks(testDB, time.Second, testLog)
+	ctx := context.Background()
+
+	var acquired atomic.Int32
+	var held sync.Mutex
+	var holders []domainRepo.Lock
+
+	parallel(func(i int) {
+		lock, err := locks.TryLock(ctx, "integration-test")
+		if err != nil {
+			t.Errorf("try lock: %v", err)
+			return
+		}
+		if lock == nil {
+			return
+		}
+		acquired.Add(1)
+		held.Lock()
+		holders = append(holders, lock)
+		held.Unlock()
+	})
+	if acquired.Load() != 1 {
+		t.Fatalf("lock acquired %d times, want once", acquired.Load())
+	}
+
+	if err := holders[0].Release(ctx); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	lock, err := locks.TryLock(ctx, "integration-test")
+	if err != nil || lock == nil {
+		t.Fatalf("lock after release: got %v, %v", lock, err)
+	}
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+}
+
+func TestResizePoolConnectionBudget(t *testing.T) {
+	cfg := testConfig
+	cfg.MaxOpenConns = 4
+	cfg.MaxIdleConns = 0
+	cfg.MaxConnsLimit = 8
+
+	db, err := postgres.New(cfg, testLog)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	old := db.Pool()
+	held := make([]*pgxpool.Conn, 0, cfg.MaxOpenConns)
+	for range cfg.MaxOpenConns {
+		conn, err := old.Acquire(ctx)
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		held = append(held, conn)
+	}
+
+	// Пока старый пул держит все свои соединения, новый может открыть
+	// не больше четырёх.
+	if _, err := db.ResizePool(ctx, 8, 8); err != nil {
+		t.Fatalf("resize: %v", err)
+	}
+	resized := db.Pool()
+
+	var peak int32
+	sample := func() int32 {
+		total := old.Stat().TotalConns() + resized.Stat().TotalConns()
+		peak = max(peak, total)
+		return resized.Stat().TotalConns()
+	}
+
+	for range 20 {
+		sample()
+		time.Sleep(10 * time.Millisecond)
+	}
+	for _, conn := range held {
+		conn.Release()
+		sample()
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for sample() < 8 {
+		if time.Now().After(deadline) {
+			t.Fatalf("new pool opened %d connections, want 8", resized.Stat().TotalConns())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if peak > 8 {
+		t.Errorf("old and new pools held %d connections together, want at most 8", peak)
+	}
+}
@@ -0,0 +1,13 @@
+/*
+Package integration — интеграционные тесты postgres-репозиториев на
+настоящей базе. TestMain поднимает Postgres в контейнере через
+testcontainers-go, применяет встроенные миграции и прогоняет все методы
+репозиториев, включая комбинации фильтров и конкурентные сценарии.
+
+Тесты собираются только с тегом integration и требуют Docker:
+
+	go test -tags integration ./internal/infrastructure/database/postgres/repository/integration/
+
+Образ можно переопределить через INTEGRATION_POSTGRES_IMAGE.
+*/
+package integration
@@ -0,0 +1,191 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+const defaultImage = "postgres:16-alpine"
+
+var (
+	testDB     *postgres.DB
+	testLog    *logger.Logger
+	testConfig config.DatabaseConfig
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+/*
+run поднимает один контейнер на весь прогон: миграции применяются один раз,
+а между тестами таблицы очищаются через resetDB.
+*/
+func run(m *testing.M) int {
+	ctx := context.Background()
+
+	image := os.Getenv("INTEGRATION_POSTGRES_IMAGE")
+	if image == "" {
+		image = defaultImage
+	}
+
+	container, err := tcpostgres.Run(ctx, image,
+		tcpostgres.WithDatabase("subscription_service_test"),
+		tcpostgres.WithUsername("postgres"),
+		tcpostgres.WithPassword("postgres"),
+		tcpostgres.BasicWaitStrategies(),
+	)
+	defer func() {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			fmt.Fprintf(os.Stderr, "terminate postgres container: %v\n", err)
+		}
+	}()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "start postgres container: %v\n", err)
+		return 1
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "container host: %v\n", err)
+		return 1
+	}
+	port, err := container.MappedPort(ctx, "5432/tcp")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "container port: %v\n", err)
+		return 1
+	}
+
+	cfg := config.DatabaseConfig{
+		Host:                   host,
+		Port:                   port.Port(),
+		User:                   "postgres",
+		Password:               "postgres",
+		DBName:                 "subscription_service_test",
+		SSLMode:                "disable",
+		MaxOpenConns:           16,
+		MaxIdleConns:           16,
+		StatementCacheCapacity: 64,
+	}
+
+	log, err := logger.NewLogger(logger.Config{Level: "error"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "logger: %v\n", err)
+		return 1
+	}
+
+	if err := postgres.Migrate(cfg, log); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %v\n", err)
+		return 1
+	}
+
+	db, err := postgres.New(cfg, log)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "connect: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	testDB, testLog, testConfig = db, log, cfg
+	return m.Run()
+}
+
+// resetDB очищает все таблицы схемы, кроме служебной таблицы миграций.
+func resetDB(t *testing.T) {
+	t.Helper()
+
+	_, err := testDB.Pool().Exec(context.Background(), `
+		DO $$
+		DECLARE
+			tables text;
+		BEGIN
+			SELECT string_agg(format('%I', tablename), ', ') INTO tables
+			FROM pg_tables
+			WHERE schemaname = 'public' AND tablename <> 'schema_migrations';
+			EXECUTE 'TRUNCATE ' || tables || ' CASCADE';
+		END $$`)
+	if err != nil {
+		t.Fatalf("reset database: %v", err)
+	}
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func endOfMonth(year int, m time.Month) time.Time {
+	return month(year, m).AddDate(0, 1, 0).Add(-time.Microsecond)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// costFor — ожидаемая стоимость подписки по доменной модели.
+func costFor(t *testing.T, sub *models.Subscription, period models.DateRange, billing models.BillingMode) int {
+	t.Helper()
+	cost, err := sub.CalculateCostForPeriod(period, billing)
+	if err != nil {
+		t.Fatalf("domain cost of %s: %v", sub.ServiceName(), err)
+	}
+	return cost
+}
+
+// subscriptionSpec описывает строку фикстуры; пустые поля не задаются.
+type subscriptionSpec struct {
+	userID     uuid.UUID
+	service    string
+	price      int
+	start      time.Time
+	end        *time.Time
+	tags       []string
+	category   models.SubscriptionCategory
+	payment    string
+	billingDay int
+	notes      string
+	metadata   map[string]string
+}
+
+func (s subscriptionSpec) build() *models.Subscription {
+	sub := models.NewSubscription(s.service, models.Rubles(s.price), s.userID, s.start)
+	sub.SetEndDate(s.end)
+	if len(s.tags) > 0 {
+		sub.SetTags(s.tags)
+	}
+	if s.category != "" {
+		sub.SetCategory(&s.category)
+	}
+	if s.payment != "" {
+		sub.SetPaymentMethod(&s.payment)
+	}
+	if s.billingDay != 0 {
+		sub.SetBillingDay(&s.billingDay)
+	}
+	sub.SetNotes(s.notes)
+	if s.metadata != nil {
+		sub.SetMetadata(s.metadata)
+	}
+	return sub
+}
+
+func idSet(subs []*models.Subscription) map[uuid.UUID]bool {
+	ids := make(map[uuid.UUID]bool, len(subs))
+	for _, sub := range subs {
+		ids[sub.ID()] = true
+	}
+	return ids
+}
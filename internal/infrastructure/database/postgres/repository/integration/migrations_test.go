@@ -0,0 +1,103 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres/migrations"
+)
+
+var upMigration = regexp.MustCompile(`^([0-9]+)_.*\.up\.sql$`)
+
+// migrationVersions — номера всех встроенных миграций по возрастанию.
+func migrationVersions(t *testing.T) []uint {
+	t.Helper()
+
+	entries, err := fs.ReadDir(migrations.FS, ".")
+	if err != nil {
+		t.Fatalf("read embedded migrations: %v", err)
+	}
+
+	var versions []uint
+	for _, entry := range entries {
+		match := upMigration.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			t.Fatalf("parse %s: %v", entry.Name(), err)
+		}
+		versions = append(versions, uint(version))
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+/*
+TestMigrations_ApplyInOrder применяет миграции по одной к пустой базе,
+откатывает все и применяет снова: так ломающаяся миграция (например,
+внешний ключ на секционированную таблицу) видна по номеру.
+*/
+func TestMigrations_ApplyInOrder(t *testing.T) {
+	ctx := context.Background()
+	const dbName = "migrations_in_order"
+
+	if _, err := testDB.Pool().Exec(ctx, "CREATE DATABASE "+dbName); err != nil {
+		t.Fatalf("create database: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := testDB.Pool().Exec(context.Background(), "DROP DATABASE IF EXISTS "+dbName+" WITH (FORCE)"); err != nil {
+			t.Errorf("drop database: %v", err)
+		}
+	})
+
+	cfg := testConfig
+	cfg.DBName = dbName
+	db, err := sql.Open("postgres", cfg.DSN())
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	m, err := postgres.NewEmbeddedMigrate(db)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	versions := migrationVersions(t)
+	for _, want := range versions {
+		if err := m.Steps(1); err != nil {
+			t.Fatalf("apply migration %03d: %v", want, err)
+		}
+		version, dirty, err := m.Version()
+		if err != nil || version != want || dirty {
+			t.Fatalf("after migration %03d: version %d, dirty %t, %v", want, version, dirty, err)
+		}
+	}
+
+	if err := m.Down(); err != nil {
+		t.Fatalf("roll back all migrations: %v", err)
+	}
+	if _, _, err := m.Version(); !errors.Is(err, migrate.ErrNilVersion) {
+		t.Fatalf("version after rollback: %v, want none", err)
+	}
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("apply migrations after rollback: %v", err)
+	}
+	if version, _, _ := m.Version(); version != versions[len(versions)-1] {
+		t.Errorf("version = %d, want %d", version, versions[len(versions)-1])
+	}
+}
@@ -0,0 +1,705 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+func assertCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	appErr, ok := apperror.IsAppError(err)
+	if !ok {
+		t.Fatalf("expected %s, got %v", code, err)
+	}
+	if appErr.Code() != code {
+		t.Fatalf("expected %s, got %s: %v", code, appErr.Code(), err)
+	}
+}
+
+func TestAPIKeyRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewAPIKeyRepository(testDB, testLog)
+	ctx := context.Background()
+
+	key, _, err := models.NewAPIKey("ci", models.RoleOperator)
+	if err != nil {
+		t.Fatalf("new key: %v", err)
+	}
+	if err := repo.Create(ctx, key); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	got, err := repo.GetByHash(ctx, key.KeyHash())
+	if err != nil || got == nil {
+		t.Fatalf("get by hash: got %v, %v", got, err)
+	}
+	if got.ID() != key.ID() || got.Role() != models.RoleOperator || got.IsRevoked() {
+		t.Errorf("get by hash: got %s/%s revoked=%v", got.ID(), got.Role(), got.IsRevoked())
+	}
+
+	missing, err := repo.GetByHash(ctx, "unknown")
+	if err != nil || missing != nil {
+		t.Fatalf("get unknown hash: got %v, %v", missing, err)
+	}
+
+	keys, err := repo.List(ctx)
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("list: got %d keys, %v", len(keys), err)
+	}
+
+	revoked, err := repo.Revoke(ctx, key.ID(), time.Now())
+	if err != nil || !revoked.IsRevoked() {
+		t.Fatalf("revoke: got %v, %v", revoked, err)
+	}
+	_, err = repo.Revoke(ctx, key.ID(), time.Now())
+	assertCode(t, err, apperror.CodeConflict)
+	_, err = repo.Revoke(ctx, uuid.New(), time.Now())
+	assertCode(t, err, apperror.CodeNotFound)
+}
+
+func TestBillingCommandRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewBillingCommandRepository(testDB, testLog)
+	ctx := context.Background()
+
+	missing, err := repo.Get(ctx, "cmd-1")
+	if err != nil || missing != nil {
+		t.Fatalf("get missing: got %v, %v", missing, err)
+	}
+
+	subscriptionID := uuid.New()
+	saved, err := repo.Save(ctx, models.NewBillingCommandSucceeded("cmd-1", "subscription.create", subscriptionID))
+	if err != nil || !saved {
+		t.Fatalf("save: got %v, %v", saved, err)
+	}
+	saved, err = repo.Save(ctx, models.NewBillingCommandFailed("cmd-1", "subscription.create", "CONFLICT", "duplicate"))
+	if err != nil || saved {
+		t.Fatalf("second save must keep the first result: got %v, %v", saved, err)
+	}
+
+	got, err := repo.Get(ctx, "cmd-1")
+	if err != nil || got == nil {
+		t.Fatalf("get: got %v, %v", got, err)
+	}
+	if got.SubscriptionID() == nil || *got.SubscriptionID() != subscriptionID || got.ErrorCode() != "" {
+		t.Errorf("get: got subscription %v, error code %q", got.SubscriptionID(), got.ErrorCode())
+	}
+}
+
+func TestCalendarFeedRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewCalendarFeedRepository(testDB, testLog)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	assertCode(t, repo.Delete(ctx, userID), apperror.CodeNotFound)
+
+	first, _, err := models.NewCalendarFeedToken(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, secret, err := models.NewCalendarFeedToken(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []*models.CalendarFeedToken{first, second} {
+		if err := repo.Save(ctx, token); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+	}
+
+	// Второй токен заменил первый.
+	stored, err := repo.GetByUser(ctx, userID)
+	if err != nil || !stored.Matches(secret) {
+		t.Fatalf("get by user: got %v, %v", stored, err)
+	}
+
+	if err := repo.Delete(ctx, userID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = repo.GetByUser(ctx, userID)
+	assertCode(t, err, apperror.CodeNotFound)
+}
+
+func TestCanonicalServiceNameRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewCanonicalServiceNameRepository(testDB, testLog)
+	subs := repository.NewSubscriptionRepository(testDB, nil, testLog)
+	ctx := context.Background()
+
+	entry := models.NewCanonicalServiceName("Netflix", []string{"Нетфликс"})
+	if err := repo.Create(ctx, entry); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	assertCode(t, repo.Create(ctx, models.NewCanonicalServiceName("NETFLIX", nil)), apperror.CodeConflict)
+
+	entries, err := repo.List(ctx)
+	if err != nil || len(entries) != 1 || len(entries[0].Aliases()) != 1 {
+		t.Fatalf("list: got %d, %v", len(entries), err)
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	var variants []uuid.UUID
+	for _, name := range []string{"Netflix", "netflix", "NETFLIX  ", "нетфликс", "Netflix Kids"} {
+		sub := models.NewSubscription(name, models.Rubles(100), userID, start)
+		if err := subs.Create(ctx, sub); err != nil {
+			t.Fatalf("create subscription %q: %v", name, err)
+		}
+		if name != "Netflix" && name != "Netflix Kids" {
+			variants = append(variants, sub.ID())
+		}
+	}
+
+	found, err := repo.FindVariants(ctx, entry.Keys(), entry.Name(), uuid.Nil, 10)
+	if err != nil {
+		t.Fatalf("find variants: %v", err)
+	}
+	if len(found) != len(variants) {
+		t.Fatalf("find variants: got %d, want %d", len(found), len(variants))
+	}
+	for _, id := range variants {
+		if !slices.Contains(found, id) {
+			t.Errorf("variant %s not found", id)
+		}
+	}
+
+	rest, err := repo.FindVariants(ctx, entry.Keys(), entry.Name(), found[0], 10)
+	if err != nil || len(rest) != len(variants)-1 {
+		t.Fatalf("find variants after %s: got %d, %v", found[0], len(rest), err)
+	}
+
+	if err := repo.Delete(ctx, entry.ID()); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	assertCode(t, repo.Delete(ctx, entry.ID()), apperror.CodeNotFound)
+}
+
+func TestCostAlertRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewCostAlertRepository(testDB, testLog)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	july := month(2025, time.July)
+	low := models.NewCostAlert(userID, 1000)
+	high := models.NewCostAlert(userID, 5000)
+	other := models.NewCostAlert(uuid.New(), 1000)
+	for _, alert := range []*models.CostAlert{low, high, other} {
+		if err := repo.Create(ctx, alert); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	assertCode(t, repo.Create(ctx, models.NewCostAlert(userID, 1000)), apperror.CodeConflict)
+
+	if alerts, err := repo.ListByUser(ctx, userID); err != nil || len(alerts) != 2 || alerts[0].ID() != low.ID() {
+		t.Fatalf("list by user: got %v, %v", alerts, err)
+	}
+
+	marked, err := repo.MarkNotified(ctx, low.ID(), july, 1200, uuid.New())
+	if err != nil || !marked {
+		t.Fatalf("mark notified: got %v, %v", marked, err)
+	}
+	if marked, err := repo.MarkNotified(ctx, low.ID(), july, 1300, uuid.New()); err != nil || marked {
+		t.Fatalf("mark notified twice: got %v, %v", marked, err)
+	}
+
+	unnotified, err := repo.ListUnnotified(ctx, july, uuid.Nil, 10)
+	if err != nil || len(unnotified) != 2 || slices.ContainsFunc(unnotified, func(a *models.CostAlert) bool { return a.ID() == low.ID() }) {
+		t.Fatalf("list unnotified: got %v, %v", unnotified, err)
+	}
+	if next, err := repo.ListUnnotified(ctx, july, unnotified[0].ID(), 10); err != nil || len(next) != 1 || next[0].ID() != unnotified[1].ID() {
+		t.Fatalf("list unnotified after %s: got %v, %v", unnotified[0].ID(), next, err)
+	}
+	if august, err := repo.ListUnnotified(ctx, month(2025, time.August), uuid.Nil, 10); err != nil || len(august) != 3 {
+		t.Fatalf("list unnotified next month: got %d, %v", len(august), err)
+	}
+
+	notified, err := repo.NotifiedAt(ctx, userID, july)
+	if _, ok := notified[low.ID()]; err != nil || !ok || len(notified) != 1 {
+		t.Fatalf("notified at: got %v, %v", notified, err)
+	}
+
+	if err := repo.Delete(ctx, low.ID()); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = repo.GetByID(ctx, low.ID())
+	assertCode(t, err, apperror.CodeNotFound)
+	assertCode(t, repo.Delete(ctx, low.ID()), apperror.CodeNotFound)
+}
+
+func TestConfigFingerprintRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewConfigFingerprintRepository(testDB, testLog)
+	ctx := context.Background()
+
+	since := time.Now().Add(-time.Minute)
+	for _, fp := range []*models.ConfigFingerprint{
+		models.NewConfigFingerprint("api-1", "aaa"),
+		models.NewConfigFingerprint("api-2", "aaa"),
+		models.NewConfigFingerprint("api-1", "bbb"),
+	} {
+		if err := repo.Upsert(ctx, fp); err != nil {
+			t.Fatalf("upsert %s: %v", fp.InstanceID(), err)
+		}
+	}
+
+	stale := models.NewConfigFingerprint("api-3", "ccc")
+	stale.SetReportedAt(time.Now().Add(-time.Hour))
+	if err := repo.Upsert(ctx, stale); err != nil {
+		t.Fatalf("upsert stale: %v", err)
+	}
+
+	reported, err := repo.ListReportedSince(ctx, since)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	hashes := map[string]string{}
+	for _, fp := range reported {
+		hashes[fp.InstanceID()] = fp.Hash()
+	}
+	if len(hashes) != 2 || hashes["api-1"] != "bbb" || hashes["api-2"] != "aaa" {
+		t.Errorf("list: got %v", hashes)
+	}
+}
+
+func TestDeadLetterRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewDeadLetterRepository(testDB, testLog)
+	ctx := context.Background()
+
+	sub := models.NewSubscription("Netflix", models.Rubles(599), uuid.New(), month(2024, time.January))
+	created := models.NewSubscriptionEvent(models.EventSubscriptionCreated, sub)
+	deleted := models.NewSubscriptionEvent(models.EventSubscriptionDeleted, sub)
+
+	if err := repo.CreateForEvent(ctx, created, "webhook", "timeout"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := repo.CreateForEvent(ctx, deleted, "kafka", "broker unavailable"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	all := models.NewDeadLetterFilter(nil, nil, nil)
+	if count, err := repo.Count(ctx, all); err != nil || count != 2 {
+		t.Fatalf("count: got %d, %v", count, err)
+	}
+
+	webhook := models.NewDeadLetterFilter(ptr("webhook"), nil, nil)
+	list, err := repo.List(ctx, webhook, 10, 0)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("list by source: got %d, %v", len(list), err)
+	}
+	letter := list[0]
+	if letter.EventID() != created.ID() || letter.Status() != models.DeadLetterPending {
+		t.Errorf("list by source: got event %s, status %s", letter.EventID(), letter.Status())
+	}
+
+	byType := models.NewDeadLetterFilter(nil, ptr(models.EventSubscriptionDeleted), nil)
+	if count, err := repo.Count(ctx, byType); err != nil || count != 1 {
+		t.Errorf("count by event type: got %d, %v", count, err)
+	}
+
+	requeued, err := repo.Requeue(ctx, letter.ID(), time.Now())
+	if err != nil || requeued.Status() != models.DeadLetterRequeued || requeued.RequeueCount() != 1 {
+		t.Fatalf("requeue: got %v, %v", requeued, err)
+	}
+	_, err = repo.Requeue(ctx, letter.ID(), time.Now())
+	assertCode(t, err, apperror.CodeConflict)
+
+	var audit, outbox int
+	err = testDB.Pool().QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM audit_log WHERE event_id = $1),
+			(SELECT COUNT(*) FROM outbox WHERE event_id = $1 AND published_at IS NULL)`, created.ID()).
+		Scan(&audit, &outbox)
+	if err != nil || audit != 1 || outbox != 1 {
+		t.Errorf("requeue must restore audit and outbox rows: got %d/%d, %v", audit, outbox, err)
+	}
+
+	pending := models.NewDeadLetterFilter(nil, nil, ptr(models.DeadLetterPending))
+	if count, err := repo.Count(ctx, pending); err != nil || count != 1 {
+		t.Errorf("count pending: got %d, %v", count, err)
+	}
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assertCode(t, err, apperror.CodeNotFound)
+}
+
+func TestDiscountRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewDiscountRepository(testDB, testLog)
+	subscriptions := repository.NewSubscriptionRepository(testDB, nil, testLog)
+	ctx := context.Background()
+
+	discount := models.NewDiscount("SPRING24", models.DiscountPercentage, 20, ptr("Netflix"), month(2024, time.March), nil)
+	if err := repo.Create(ctx, discount); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	duplicate := models.NewDiscount("spring24", models.DiscountFixed, 100, nil, month(2024, time.March), nil)
+	assertCode(t, repo.Create(ctx, duplicate), apperror.CodeConflict)
+
+	got, err := repo.GetByCode(ctx, "spring24")
+	if err != nil || got.ID() != discount.ID() {
+		t.Fatalf("get by code: got %v, %v", got, err)
+	}
+	if got, err := repo.GetByID(ctx, discount.ID()); err != nil || got.Amount() != 20 {
+		t.Fatalf("get by id: got %v, %v", got, err)
+	}
+	if list, err := repo.List(ctx); err != nil || len(list) != 1 {
+		t.Fatalf("list: got %d, %v", len(list), err)
+	}
+
+	sub := models.NewSubscription("Netflix", models.Rubles(600), uuid.New(), month(2024, time.March))
+	discountID := discount.ID()
+	sub.SetDiscountID(&discountID)
+	if err := subscriptions.Create(ctx, sub); err != nil {
+		t.Fatalf("create discounted subscription: %v", err)
+	}
+
+	filter := models.NewSubscriptionFilter()
+	period := models.NewDateRange(month(2024, time.March), endOfMonth(2024, time.March))
+	cost, err := subscriptions.GetTotalCostForPeriod(ctx, filter, period, models.BillingMonthly, models.PricingCurrent)
+	if err != nil {
+		t.Fatalf("discounted cost: %v", err)
+	}
+	if cost.Gross() != 600 || cost.Discount() != 120 || cost.Net() != 480 {
+		t.Errorf("discounted cost: gross %d, discount %d, net %d", cost.Gross(), cost.Discount(), cost.Net())
+	}
+
+	assertCode(t, repo.Delete(ctx, discount.ID()), apperror.CodeConflict)
+	if err := subscriptions.Delete(ctx, sub.ID()); err != nil {
+		t.Fatalf("delete subscription: %v", err)
+	}
+	if err := repo.Delete(ctx, discount.ID()); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	assertCode(t, repo.Delete(ctx, discount.ID()), apperror.CodeNotFound)
+	_, err = repo.GetByCode(ctx, "SPRING24")
+	assertCode(t, err, apperror.CodeNotFound)
+}
+
+func TestPartitionRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewPartitionRepository(testDB, testLog)
+	subscriptions := repository.NewSubscriptionRepository(testDB, nil, testLog)
+	comments := repository.NewSubscriptionCommentRepository(testDB, testLog)
+	ctx := context.Background()
+
+	// Год без секции: миграция создаёт их только до следующего года.
+	year := time.Now().UTC().Year() + 7
+	sub := models.NewSubscription("Netflix", models.Rubles(599), uuid.New(), month(year, time.March))
+	if err := subscriptions.Create(ctx, sub); err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	if err := comments.Create(ctx, models.NewSubscriptionComment(sub.ID(), "support", "far future")); err != nil {
+		t.Fatalf("create comment: %v", err)
+	}
+
+	years, err := repo.DefaultPartitionYears(ctx)
+	if err != nil || len(years) != 1 || years[0] != year {
+		t.Fatalf("default partition years: got %v, %v", years, err)
+	}
+
+	created, err := repo.EnsureYearPartition(ctx, year)
+	if err != nil || !created {
+		t.Fatalf("ensure partition: got %v, %v", created, err)
+	}
+	created, err = repo.EnsureYearPartition(ctx, year)
+	if err != nil || created {
+		t.Fatalf("ensure existing partition: got %v, %v", created, err)
+	}
+
+	if years, err := repo.DefaultPartitionYears(ctx); err != nil || len(years) != 0 {
+		t.Fatalf("default partition after move: got %v, %v", years, err)
+	}
+	if _, err := subscriptions.GetByID(ctx, sub.ID()); err != nil {
+		t.Fatalf("moved subscription: %v", err)
+	}
+	// Перенос между секциями не удаление: комментарии остаются.
+	if count, err := comments.CountBySubscriptionID(ctx, sub.ID()); err != nil || count != 1 {
+		t.Errorf("comments after move: got %d, %v", count, err)
+	}
+}
+
+func TestPlanRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewPlanRepository(testDB, testLog)
+	subscriptions := repository.NewSubscriptionRepository(testDB, nil, testLog)
+	ctx := context.Background()
+
+	plan := models.NewPlan("Netflix Standard", "Netflix", models.Rubles(899), models.BillingCycleMonthly, map[string]interface{}{"screens": float64(2)})
+	if err := repo.Create(ctx, plan); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	duplicate := models.NewPlan("Netflix Standard", "Netflix", models.Rubles(999), models.BillingCycleMonthly, nil)
+	assertCode(t, repo.Create(ctx, duplicate), apperror.CodeConflict)
+
+	plan.SetPrice(models.Rubles(999))
+	if err := repo.Update(ctx, plan); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	got, err := repo.GetByID(ctx, plan.ID())
+	if err != nil || !got.Price().Equal(models.Rubles(999)) || got.Features()["screens"] != float64(2) {
+		t.Fatalf("get: got %v, %v", got, err)
+	}
+	if list, err := repo.List(ctx, 10, 0); err != nil || len(list) != 1 {
+		t.Fatalf("list: got %d, %v", len(list), err)
+	}
+
+	for _, price := range []*models.PlanPrice{
+		models.NewPlanPrice(plan.ID(), models.Rubles(899), models.BillingCycleMonthly, month(2024, time.January)),
+		models.NewPlanPrice(plan.ID(), models.Rubles(999), models.BillingCycleMonthly, month(2024, time.June)),
+	} {
+		if err := repo.AddPrice(ctx, price); err != nil {
+			t.Fatalf("add price: %v", err)
+		}
+	}
+	if prices, err := repo.ListPrices(ctx, plan.ID()); err != nil || len(prices) != 2 {
+		t.Fatalf("list prices: got %d, %v", len(prices), err)
+	}
+
+	sub := models.NewSubscription("Netflix", models.Rubles(999), uuid.New(), month(2024, time.June))
+	planID := plan.ID()
+	sub.SetPlanID(&planID)
+	if err := subscriptions.Create(ctx, sub); err != nil {
+		t.Fatalf("create plan subscription: %v", err)
+	}
+	assertCode(t, repo.Delete(ctx, plan.ID()), apperror.CodeConflict)
+
+	if err := subscriptions.Delete(ctx, sub.ID()); err != nil {
+		t.Fatalf("delete subscription: %v", err)
+	}
+	if err := repo.Delete(ctx, plan.ID()); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = repo.GetByID(ctx, plan.ID())
+	assertCode(t, err, apperror.CodeNotFound)
+	assertCode(t, repo.Update(ctx, plan), apperror.CodeNotFound)
+}
+
+func TestServiceCatalogRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewServiceCatalogRepository(testDB, testLog)
+	subscriptions := repository.NewSubscriptionRepository(testDB, nil, testLog)
+	ctx := context.Background()
+
+	price := 599
+	netflix := models.NewCatalogService("Netflix", nil, "https://www.netflix.com", "", &price)
+	for _, entry := range []*models.CatalogService{
+		netflix,
+		models.NewCatalogService("Kinopoisk Netflix Bundle", nil, "", "", nil),
+		models.NewCatalogService("100%_Music", nil, "", "", nil),
+	} {
+		if err := repo.Create(ctx, entry); err != nil {
+			t.Fatalf("create %s: %v", entry.Name(), err)
+		}
+	}
+	assertCode(t, repo.Create(ctx, models.NewCatalogService("NETFLIX", nil, "", "", nil)), apperror.CodeConflict)
+
+	// Совпадение с начала названия идёт первым; % и _ ищутся буквально.
+	suggested, err := repo.Suggest(ctx, "netf", 10)
+	if err != nil || len(suggested) != 2 || suggested[0].ID() != netflix.ID() {
+		t.Fatalf("suggest: got %v, %v", suggested, err)
+	}
+	if suggested, err := repo.Suggest(ctx, "0%_", 10); err != nil || len(suggested) != 1 {
+		t.Fatalf("suggest literal wildcards: got %d, %v", len(suggested), err)
+	}
+
+	netflix.SetWebsite("")
+	if err := repo.Update(ctx, netflix); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	got, err := repo.GetByID(ctx, netflix.ID())
+	if err != nil || got.Website() != "" || got.TypicalPrice() == nil || *got.TypicalPrice() != 599 {
+		t.Fatalf("get: got %v, %v", got, err)
+	}
+
+	sub := models.NewSubscription("Netflix", models.Rubles(599), uuid.New(), month(2024, time.June))
+	catalogID := netflix.ID()
+	sub.SetCatalogID(&catalogID)
+	if err := subscriptions.Create(ctx, sub); err != nil {
+		t.Fatalf("create linked subscription: %v", err)
+	}
+	if stored, err := subscriptions.GetByID(ctx, sub.ID()); err != nil || stored.CatalogID() == nil || *stored.CatalogID() != catalogID {
+		t.Fatalf("linked subscription: got %v, %v", stored, err)
+	}
+	assertCode(t, repo.Delete(ctx, netflix.ID()), apperror.CodeConflict)
+
+	if err := subscriptions.Delete(ctx, sub.ID()); err != nil {
+		t.Fatalf("delete subscription: %v", err)
+	}
+	if err := repo.Delete(ctx, netflix.ID()); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = repo.GetByID(ctx, netflix.ID())
+	assertCode(t, err, apperror.CodeNotFound)
+}
+
+func TestServiceNameRuleRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewServiceNameRuleRepository(testDB, testLog)
+	ctx := context.Background()
+
+	rule := models.NewServiceNameRule(models.ServiceNameDenyList, models.ServiceNameMatchRegex, "(?i)^test", "no test services")
+	if err := repo.Create(ctx, rule); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	duplicate := models.NewServiceNameRule(models.ServiceNameDenyList, models.ServiceNameMatchRegex, "(?i)^test", "again")
+	assertCode(t, repo.Create(ctx, duplicate), apperror.CodeConflict)
+
+	rules, err := repo.List(ctx)
+	if err != nil || len(rules) != 1 || rules[0].Pattern() != "(?i)^test" {
+		t.Fatalf("list: got %d, %v", len(rules), err)
+	}
+
+	if err := repo.Delete(ctx, rule.ID()); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	assertCode(t, repo.Delete(ctx, rule.ID()), apperror.CodeNotFound)
+}
+
+func TestSubscriptionCommentRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewSubscriptionCommentRepository(testDB, testLog)
+	subscriptions := repository.NewSubscriptionRepository(testDB, nil, testLog)
+	ctx := context.Background()
+
+	sub := models.NewSubscription("Netflix", models.Rubles(599), uuid.New(), month(2024, time.January))
+	if err := subscriptions.Create(ctx, sub); err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+
+	for _, body := range []string{"first", "second", "third"} {
+		if err := repo.Create(ctx, models.NewSubscriptionComment(sub.ID(), "support", body)); err != nil {
+			t.Fatalf("create comment: %v", err)
+		}
+	}
+
+	if count, err := repo.CountBySubscriptionID(ctx, sub.ID()); err != nil || count != 3 {
+		t.Fatalf("count: got %d, %v", count, err)
+	}
+	page, err := repo.ListBySubscriptionID(ctx, sub.ID(), 2, 2)
+	if err != nil || len(page) != 1 {
+		t.Fatalf("list page: got %d, %v", len(page), err)
+	}
+
+	if err := subscriptions.Delete(ctx, sub.ID()); err != nil {
+		t.Fatalf("delete subscription: %v", err)
+	}
+	if count, err := repo.CountBySubscriptionID(ctx, sub.ID()); err != nil || count != 0 {
+		t.Errorf("comments must be removed with the subscription: got %d, %v", count, err)
+	}
+}
+
+func TestSubscriptionMemberRepository(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewSubscriptionMemberRepository(testDB, testLog)
+	subscriptions := repository.NewSubscriptionRepository(testDB, nil, testLog)
+	ctx := context.Background()
+
+	owner, member, other := uuid.New(), uuid.New(), uuid.New()
+	sub := models.NewSubscription("Yandex Plus", models.Rubles(600), owner, month(2024, time.January))
+	if err := subscriptions.Create(ctx, sub); err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+
+	if err := repo.Add(ctx, models.NewSubscriptionMember(sub.ID(), member, 25)); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	assertCode(t, repo.Add(ctx, models.NewSubscriptionMember(sub.ID(), member, 10)), apperror.CodeConflict)
+	assertCode(t, repo.Add(ctx, models.NewSubscriptionMember(sub.ID(), other, 80)), apperror.CodeConflict)
+	assertCode(t, repo.Add(ctx, models.NewSubscriptionMember(sub.ID(), owner, 10)), apperror.CodeValidationFailed)
+	assertCode(t, repo.Add(ctx, models.NewSubscriptionMember(uuid.New(), other, 10)), apperror.CodeSubscriptionNotFound)
+
+	if members, err := repo.ListBySubscriptionID(ctx, sub.ID()); err != nil || len(members) != 1 || members[0].UserID() != member {
+		t.Fatalf("list: got %v, %v", members, err)
+	}
+
+	// Полгода по 600: участник платит 25%, владелец — остальное.
+	period := models.NewDateRange(month(2024, time.January), endOfMonth(2024, time.June))
+	for user, want := range map[uuid.UUID]int{owner: 2700, member: 900, other: 0} {
+		filter := models.NewSubscriptionFilter()
+		filter.SetUserID(&user)
+		total, err := subscriptions.GetTotalCostForPeriod(ctx, filter, period, models.BillingMonthly, models.PricingCurrent)
+		if err != nil || total.Net() != want {
+			t.Errorf("total cost for %s: got %d, %v; want %d", user, total.Net(), err, want)
+		}
+	}
+
+	if err := repo.Remove(ctx, sub.ID(), member); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	assertCode(t, repo.Remove(ctx, sub.ID(), member), apperror.CodeNotFound)
+
+	if err := repo.Add(ctx, models.NewSubscriptionMember(sub.ID(), other, 30)); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	if err := subscriptions.Delete(ctx, sub.ID()); err != nil {
+		t.Fatalf("delete subscription: %v", err)
+	}
+	if members, err := repo.ListBySubscriptionID(ctx, sub.ID()); err != nil || len(members) != 0 {
+		t.Errorf("members must be removed with the subscription: got %d, %v", len(members), err)
+	}
+}
+
+func TestSubscriptionEventRepository_Record(t *testing.T) {
+	resetDB(t)
+	repo := repository.NewSubscriptionEventRepository(testDB, testLog)
+	ctx := context.Background()
+
+	sub := models.NewSubscription("Netflix", models.Rubles(599), uuid.New(), month(2024, time.January))
+	event := models.NewSubscriptionEvent(models.EventSubscriptionCreated, sub)
+	if err := repo.Record(ctx, event); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	var events, audit, outbox int
+	err := testDB.Pool().QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM subscription_events WHERE id = $1),
+			(SELECT COUNT(*) FROM audit_log WHERE event_id = $1),
+			(SELECT COUNT(*) FROM outbox WHERE event_id = $1)`, event.ID()).
+		Scan(&events, &audit, &outbox)
+	if err != nil {
+		t.Fatalf("count rows: %v", err)
+	}
+	if events != 1 || audit != 1 || outbox != 1 {
+		t.Errorf("record must write event, audit and outbox rows: got %d/%d/%d", events, audit, outbox)
+	}
+
+	// Пакет атомарен: повтор того же события падает на первом INSERT и не
+	// оставляет лишних строк аудита и outbox.
+	assertCode(t, repo.Record(ctx, event), apperror.CodeConflict)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	other := models.NewSubscriptionEvent(models.EventSubscriptionDeleted, sub)
+	if err := repo.Record(cancelled, other); !errors.Is(err, context.Canceled) {
+		t.Errorf("record with cancelled context: got %v", err)
+	}
+
+	err = testDB.Pool().QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM subscription_events),
+			(SELECT COUNT(*) FROM audit_log),
+			(SELECT COUNT(*) FROM outbox)`).
+		Scan(&events, &audit, &outbox)
+	if err != nil {
+		t.Fatalf("count rows: %v", err)
+	}
+	if events != 1 || audit != 1 || outbox != 1 {
+		t.Errorf("failed records must not write rows: got %d/%d/%d", events, audit, outbox)
+	}
+}
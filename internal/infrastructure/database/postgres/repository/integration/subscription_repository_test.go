@@ -0,0 +1,1050 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	domainRepo "github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+func newSubscriptionRepo(t *testing.T) domainRepo.SubscriptionRepository {
+	t.Helper()
+	resetDB(t)
+	return repository.NewSubscriptionRepository(testDB, nil, testLog)
+}
+
+func createSubscriptions(t *testing.T, repo domainRepo.SubscriptionRepository, specs ...subscriptionSpec) []*models.Subscription {
+	t.Helper()
+
+	subs := make([]*models.Subscription, 0, len(specs))
+	for _, spec := range specs {
+		sub := spec.build()
+		if err := repo.Create(context.Background(), sub); err != nil {
+			t.Fatalf("create %s: %v", spec.service, err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+func TestSubscriptionRepository_CRUD(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	created := createSubscriptions(t, repo, subscriptionSpec{
+		userID:     uuid.New(),
+		service:    "Yandex Plus",
+		price:      399,
+		start:      month(2024, time.February),
+		end:        ptr(endOfMonth(2024, time.December)),
+		tags:       []string{"family", "personal"},
+		category:   models.CategoryStreaming,
+		payment:    "Family Sharing",
+		billingDay: 31,
+		notes:      "Shared with family",
+		metadata:   map[string]string{"team": "platform"},
+	})[0]
+
+	got, err := repo.GetByID(ctx, created.ID())
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got == nil {
+		t.Fatal("get: subscription not found")
+	}
+	if got.ServiceName() != "Yandex Plus" || !got.Price().Equal(models.Rubles(399)) || got.UserID() != created.UserID() {
+		t.Errorf("get: got %s/%s/%s", got.ServiceName(), got.Price(), got.UserID())
+	}
+	if !got.StartDate().Equal(created.StartDate()) {
+		t.Errorf("start date: got %s, want %s", got.StartDate(), created.StartDate())
+	}
+	if got.EndDate() == nil || !got.EndDate().Equal(*created.EndDate()) {
+		t.Errorf("end date: got %v, want %s", got.EndDate(), created.EndDate())
+	}
+	if !reflect.DeepEqual(got.Tags(), []string{"family", "personal"}) {
+		t.Errorf("tags: got %v", got.Tags())
+	}
+	if got.Category() == nil || *got.Category() != models.CategoryStreaming {
+		t.Errorf("category: got %v", got.Category())
+	}
+	if got.PaymentMethod() == nil || *got.PaymentMethod() != "Family Sharing" {
+		t.Errorf("payment method: got %v", got.PaymentMethod())
+	}
+	if got.BillingDay() == nil || *got.BillingDay() != 31 {
+		t.Errorf("billing day: got %v", got.BillingDay())
+	}
+	if got.Notes() != "Shared with family" {
+		t.Errorf("notes: got %q", got.Notes())
+	}
+	if !reflect.DeepEqual(got.Metadata(), map[string]string{"team": "platform"}) {
+		t.Errorf("metadata: got %v", got.Metadata())
+	}
+
+	exists, err := repo.Exists(ctx, created.ID())
+	if err != nil || !exists {
+		t.Fatalf("exists: got %v, %v", exists, err)
+	}
+
+	got.SetPrice(models.Rubles(649))
+	got.SetEndDate(nil)
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	updated, err := repo.GetByID(ctx, created.ID())
+	if err != nil {
+		t.Fatalf("get after update: %v", err)
+	}
+	if !updated.Price().Equal(models.Rubles(649)) || updated.EndDate() != nil {
+		t.Errorf("after update: price %s, end date %v", updated.Price(), updated.EndDate())
+	}
+
+	if err := repo.Delete(ctx, created.ID()); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	deleted, err := repo.GetByID(ctx, created.ID())
+	if err != nil || deleted != nil {
+		t.Fatalf("get after delete: got %v, %v", deleted, err)
+	}
+	exists, err = repo.Exists(ctx, created.ID())
+	if err != nil || exists {
+		t.Fatalf("exists after delete: got %v, %v", exists, err)
+	}
+
+	assertCode(t, repo.Update(ctx, got), apperror.CodeSubscriptionNotFound)
+	assertCode(t, repo.Delete(ctx, created.ID()), apperror.CodeSubscriptionNotFound)
+}
+
+func TestSubscriptionRepository_Filters(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	alice, bob := uuid.New(), uuid.New()
+	subs := createSubscriptions(t, repo,
+		subscriptionSpec{userID: alice, service: "Netflix", price: 599, start: month(2024, time.January),
+			tags: []string{"family"}, category: models.CategoryStreaming, metadata: map[string]string{"team": "data"}},
+		subscriptionSpec{userID: alice, service: "Spotify", price: 269, start: month(2024, time.March),
+			end: ptr(endOfMonth(2024, time.June)), tags: []string{"work"}, category: models.CategoryMusic},
+		subscriptionSpec{userID: bob, service: "Netflix Premium", price: 1199, start: month(2023, time.June),
+			end: ptr(endOfMonth(2024, time.December)), tags: []string{"family", "work"}, category: models.CategoryStreaming},
+		subscriptionSpec{userID: bob, service: "iCloud+", price: 149, start: month(2024, time.May),
+			category: models.CategoryCloud, metadata: map[string]string{"team": "mobile"}},
+	)
+	aliceNetflix, aliceSpotify, bobNetflix, bobICloud := subs[0], subs[1], subs[2], subs[3]
+
+	tests := []struct {
+		name   string
+		filter func(f *models.SubscriptionFilter)
+		want   []*models.Subscription
+	}{
+		{"no filter", func(f *models.SubscriptionFilter) {}, subs},
+		{"user", func(f *models.SubscriptionFilter) { f.SetUserID(&alice) },
+			[]*models.Subscription{aliceNetflix, aliceSpotify}},
+		{"service name substring, case-insensitive", func(f *models.SubscriptionFilter) { f.SetServiceName(ptr("netflix")) },
+			[]*models.Subscription{aliceNetflix, bobNetflix}},
+		{"start date", func(f *models.SubscriptionFilter) { f.SetStartDate(ptr(month(2024, time.January))) },
+			[]*models.Subscription{aliceNetflix, aliceSpotify, bobICloud}},
+		{"end date keeps open-ended", func(f *models.SubscriptionFilter) { f.SetEndDate(ptr(endOfMonth(2024, time.June))) },
+			[]*models.Subscription{aliceNetflix, aliceSpotify, bobICloud}},
+		{"single tag", func(f *models.SubscriptionFilter) { f.SetTags([]string{"family"}) },
+			[]*models.Subscription{aliceNetflix, bobNetflix}},
+		{"all tags", func(f *models.SubscriptionFilter) { f.SetTags([]string{"family", "work"}) },
+			[]*models.Subscription{bobNetflix}},
+		{"category", func(f *models.SubscriptionFilter) { f.SetCategory(ptr(models.CategoryStreaming)) },
+			[]*models.Subscription{aliceNetflix, bobNetflix}},
+		{"metadata", func(f *models.SubscriptionFilter) { f.SetMetadata(map[string]string{"team": "data"}) },
+			[]*models.Subscription{aliceNetflix}},
+		{"user and category", func(f *models.SubscriptionFilter) {
+			f.SetUserID(&alice)
+			f.SetCategory(ptr(models.CategoryStreaming))
+		}, []*models.Subscription{aliceNetflix}},
+		{"user, service name and tag", func(f *models.SubscriptionFilter) {
+			f.SetUserID(&bob)
+			f.SetServiceName(ptr("netflix"))
+			f.SetTags([]string{"work"})
+		}, []*models.Subscription{bobNetflix}},
+		{"date window", func(f *models.SubscriptionFilter) {
+			f.SetStartDate(ptr(month(2024, time.February)))
+			f.SetEndDate(ptr(endOfMonth(2024, time.June)))
+		}, []*models.Subscription{aliceSpotify, bobICloud}},
+		{"no match", func(f *models.SubscriptionFilter) {
+			f.SetUserID(&alice)
+			f.SetCategory(ptr(models.CategoryCloud))
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := models.NewSubscriptionFilter()
+			tt.filter(filter)
+			want := idSet(tt.want)
+
+			list, err := repo.GetAll(ctx, filter, 100, 0)
+			if err != nil {
+				t.Fatalf("GetAll: %v", err)
+			}
+			if got := idSet(list); !reflect.DeepEqual(got, want) {
+				t.Errorf("GetAll: got %d rows, want %d", len(got), len(want))
+			}
+
+			count, err := repo.Count(ctx, filter)
+			if err != nil {
+				t.Fatalf("Count: %v", err)
+			}
+			if count != len(want) {
+				t.Errorf("Count: got %d, want %d", count, len(want))
+			}
+
+			page, total, err := repo.GetAllWithTotal(ctx, filter, 1, 0)
+			if err != nil {
+				t.Fatalf("GetAllWithTotal: %v", err)
+			}
+			if total != len(want) || len(page) != min(1, len(want)) {
+				t.Errorf("GetAllWithTotal: got %d rows of %d, want %d total", len(page), total, len(want))
+			}
+
+			iterated := make([]*models.Subscription, 0)
+			err = repo.IterateAll(ctx, filter, func(sub *models.Subscription) error {
+				iterated = append(iterated, sub)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("IterateAll: %v", err)
+			}
+			if got := idSet(iterated); !reflect.DeepEqual(got, want) || len(iterated) != len(want) {
+				t.Errorf("IterateAll: got %d rows, want %d", len(iterated), len(want))
+			}
+		})
+	}
+}
+
+func TestSubscriptionRepository_Pagination(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	for i := 0; i < 7; i++ {
+		createSubscriptions(t, repo, subscriptionSpec{userID: userID, service: "Okko", price: 199 + i, start: month(2024, time.January)})
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for offset := 0; offset < 7; offset += 3 {
+		page, err := repo.GetByUserID(ctx, userID, 3, offset)
+		if err != nil {
+			t.Fatalf("GetByUserID offset %d: %v", offset, err)
+		}
+		for _, sub := range page {
+			if seen[sub.ID()] {
+				t.Fatalf("subscription %s returned on two pages", sub.ID())
+			}
+			seen[sub.ID()] = true
+		}
+	}
+	if len(seen) != 7 {
+		t.Errorf("pages covered %d subscriptions, want 7", len(seen))
+	}
+
+	stop := errors.New("stop")
+	visited := 0
+	err := repo.IterateAll(ctx, models.NewSubscriptionFilter(), func(*models.Subscription) error {
+		visited++
+		if visited == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || visited != 2 {
+		t.Errorf("IterateAll must stop on callback error: got %v after %d rows", err, visited)
+	}
+}
+
+func TestSubscriptionRepository_Search(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	alice, bob := uuid.New(), uuid.New()
+	createSubscriptions(t, repo,
+		subscriptionSpec{userID: alice, service: "Netflix", price: 599, start: month(2024, time.January), notes: "Family plan"},
+		subscriptionSpec{userID: alice, service: "Spotify", price: 269, start: month(2024, time.January)},
+		subscriptionSpec{userID: bob, service: "Netflix", price: 899, start: month(2024, time.January)},
+	)
+
+	query, err := models.ParseSearchQuery("netflix")
+	if err != nil {
+		t.Fatalf("parse query: %v", err)
+	}
+
+	hits, err := repo.Search(ctx, query, nil, 10, 0)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(hits) != 2 {
+		t.Fatalf("search: got %d hits, want 2", len(hits))
+	}
+	for _, hit := range hits {
+		if hit.Subscription().ServiceName() != "Netflix" || hit.Rank() <= 0 {
+			t.Errorf("unexpected hit %s with rank %f", hit.Subscription().ServiceName(), hit.Rank())
+		}
+	}
+
+	hits, err = repo.Search(ctx, query, &bob, 10, 0)
+	if err != nil {
+		t.Fatalf("search by user: %v", err)
+	}
+	if len(hits) != 1 || hits[0].Subscription().UserID() != bob {
+		t.Errorf("search by user: got %d hits", len(hits))
+	}
+}
+
+func TestSubscriptionRepository_FindDuplicates(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	alice, bob := uuid.New(), uuid.New()
+	march := endOfMonth(2024, time.March)
+	subs := createSubscriptions(t, repo,
+		subscriptionSpec{userID: alice, service: "Netflix", price: 599, start: month(2024, time.January)},
+		subscriptionSpec{userID: alice, service: "netflix", price: 799, start: month(2024, time.June)},
+		// Похожее название; закончилась до начала второй Netflix и с ней не пересекается.
+		subscriptionSpec{userID: alice, service: "Netflix Premium", price: 999, start: month(2024, time.January), end: &march},
+		subscriptionSpec{userID: alice, service: "Spotify", price: 269, start: month(2024, time.January)},
+		subscriptionSpec{userID: bob, service: "Netflix", price: 899, start: month(2024, time.January)},
+	)
+
+	duplicates, err := repo.FindDuplicates(ctx, alice, models.DuplicateNameSimilarity)
+	if err != nil {
+		t.Fatalf("find duplicates: %v", err)
+	}
+	if len(duplicates) != 2 {
+		t.Fatalf("find duplicates: got %d pairs, want 2", len(duplicates))
+	}
+
+	same := duplicates[0]
+	if same.Reason() != models.DuplicateSameService || same.Similarity() != 1 ||
+		!reflect.DeepEqual(idSet([]*models.Subscription{same.First(), same.Second()}), idSet(subs[:2])) {
+		t.Errorf("first pair: %s %s (%s, %.2f)", same.First().ServiceName(), same.Second().ServiceName(), same.Reason(), same.Similarity())
+	}
+	similar := duplicates[1]
+	if similar.Reason() != models.DuplicateSimilarName ||
+		!reflect.DeepEqual(idSet([]*models.Subscription{similar.First(), similar.Second()}), idSet([]*models.Subscription{subs[0], subs[2]})) {
+		t.Errorf("second pair: %s %s (%s, %.2f)", similar.First().ServiceName(), similar.Second().ServiceName(), similar.Reason(), similar.Similarity())
+	}
+
+	if duplicates, err := repo.FindDuplicates(ctx, bob, models.DuplicateNameSimilarity); err != nil || len(duplicates) != 0 {
+		t.Errorf("find duplicates for bob: got %d, %v", len(duplicates), err)
+	}
+}
+
+func TestSubscriptionRepository_ContextCancellation(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+
+	userID := uuid.New()
+	createSubscriptions(t, repo,
+		subscriptionSpec{userID: userID, service: "Netflix", price: 599, start: month(2024, time.January)},
+		subscriptionSpec{userID: userID, service: "Spotify", price: 269, start: month(2024, time.January)},
+		subscriptionSpec{userID: userID, service: "Okko", price: 399, start: month(2024, time.January)},
+	)
+
+	// Отмена посреди страницы останавливает обход до следующей подписки.
+	ctx, cancel := context.WithCancel(context.Background())
+	visited := 0
+	err := repo.IterateAll(ctx, models.NewSubscriptionFilter(), func(*models.Subscription) error {
+		visited++
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || visited != 1 {
+		t.Errorf("iterate after cancel: visited %d, err %v", visited, err)
+	}
+
+	// Отменённый ctx не доходит до базы: ничего не записано и не прочитано.
+	sub := subscriptionSpec{userID: userID, service: "Kinopoisk", price: 269, start: month(2024, time.January)}.build()
+	if err := repo.Create(ctx, sub); !errors.Is(err, context.Canceled) {
+		t.Errorf("create with cancelled context: got %v", err)
+	}
+	if _, err := repo.GetByUserID(ctx, userID, 10, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("list with cancelled context: got %v", err)
+	}
+	if exists, err := repo.Exists(context.Background(), sub.ID()); err != nil || exists {
+		t.Errorf("subscription created with cancelled context: exists %v, %v", exists, err)
+	}
+}
+
+func TestSubscriptionRepository_DeleteByUserID(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	alice, bob := uuid.New(), uuid.New()
+	subs := createSubscriptions(t, repo,
+		subscriptionSpec{userID: alice, service: "Netflix", price: 599, start: month(2024, time.January)},
+		subscriptionSpec{userID: alice, service: "Spotify", price: 269, start: month(2024, time.January)},
+		subscriptionSpec{userID: bob, service: "Okko", price: 199, start: month(2024, time.January)},
+		subscriptionSpec{userID: alice, service: "Ivi", price: 399, start: month(2021, time.January),
+			end: ptr(endOfMonth(2021, time.June)), notes: "family plan"},
+		subscriptionSpec{userID: bob, service: "Start", price: 299, start: month(2021, time.January),
+			end: ptr(endOfMonth(2021, time.June))},
+	)
+	if moved, err := repo.ArchiveEnded(ctx, month(2023, time.January), 10); err != nil || moved != 2 {
+		t.Fatalf("archive: got %d, %v", moved, err)
+	}
+
+	ids, err := repo.DeleteByUserID(ctx, alice)
+	if err != nil {
+		t.Fatalf("delete by user: %v", err)
+	}
+	if got := len(ids); got != 3 {
+		t.Errorf("deleted %d subscriptions, want 3 including the archived one", got)
+	}
+	if !slices.Contains(ids, subs[3].ID()) {
+		t.Error("archived subscription ID is not returned")
+	}
+
+	count, err := repo.Count(ctx, models.NewSubscriptionFilter())
+	if err != nil || count != 1 {
+		t.Fatalf("count after delete: got %d, %v", count, err)
+	}
+	if exists, _ := repo.Exists(ctx, subs[2].ID()); !exists {
+		t.Error("other user's subscription was deleted")
+	}
+
+	archived := models.NewSubscriptionFilter()
+	archived.SetArchived(true)
+	count, err = repo.Count(ctx, archived)
+	if err != nil || count != 1 {
+		t.Fatalf("archived count after delete: got %d, %v, want only the other user's row", count, err)
+	}
+
+	ids, err = repo.DeleteByUserID(ctx, alice)
+	if err != nil || len(ids) != 0 {
+		t.Errorf("repeated delete by user: got %v, %v", ids, err)
+	}
+}
+
+/*
+TestSubscriptionRepository_Costs сверяет суммы, посчитанные в SQL, с
+расчётом доменной модели: для одного и того же набора подписок они должны
+совпадать в обоих режимах тарификации.
+*/
+func TestSubscriptionRepository_Costs(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	subs := createSubscriptions(t, repo,
+		subscriptionSpec{userID: userID, service: "Netflix", price: 600, start: month(2024, time.January),
+			category: models.CategoryStreaming, payment: "Tinkoff *1234"},
+		subscriptionSpec{userID: userID, service: "Spotify", price: 300, start: month(2024, time.March),
+			end: ptr(endOfMonth(2024, time.May)), category: models.CategoryMusic, payment: "Tinkoff *1234"},
+		subscriptionSpec{userID: userID, service: "Kinopoisk", price: 270, start: month(2023, time.November),
+			end: ptr(endOfMonth(2024, time.February)), category: models.CategoryStreaming},
+		subscriptionSpec{userID: uuid.New(), service: "Okko", price: 199, start: month(2024, time.January)},
+	)
+	own := subs[:3]
+
+	filter := models.NewSubscriptionFilter()
+	filter.SetUserID(&userID)
+	period := models.NewDateRange(month(2024, time.January), endOfMonth(2024, time.June))
+
+	for _, billing := range []models.BillingMode{models.BillingMonthly, models.BillingProrated} {
+		t.Run(string(billing), func(t *testing.T) {
+			want := 0
+			wantByCategory := map[models.SubscriptionCategory]int{}
+			wantByPaymentMethod := map[string]int{}
+			for _, sub := range own {
+				cost := costFor(t, sub, period, billing)
+				want += cost
+				wantByCategory[*sub.Category()] += cost
+				if sub.PaymentMethod() != nil {
+					wantByPaymentMethod[*sub.PaymentMethod()] += cost
+				} else {
+					wantByPaymentMethod[""] += cost
+				}
+			}
+
+			total, err := repo.GetTotalCostForPeriod(ctx, filter, period, billing, models.PricingCurrent)
+			if err != nil {
+				t.Fatalf("total cost: %v", err)
+			}
+			if total.Net() != want || total.Discount() != 0 {
+				t.Errorf("total cost: got %d (discount %d), want %d", total.Net(), total.Discount(), want)
+			}
+
+			categories, err := repo.GetCostByCategory(ctx, filter, period, billing, models.PricingCurrent)
+			if err != nil {
+				t.Fatalf("cost by category: %v", err)
+			}
+			got := map[models.SubscriptionCategory]int{}
+			for _, c := range categories {
+				got[c.Category()] = c.Breakdown().Net()
+			}
+			if !reflect.DeepEqual(got, wantByCategory) {
+				t.Errorf("cost by category: got %v, want %v", got, wantByCategory)
+			}
+
+			methods, err := repo.GetCostByPaymentMethod(ctx, filter, period, billing, models.PricingCurrent)
+			if err != nil {
+				t.Fatalf("cost by payment method: %v", err)
+			}
+			gotByPaymentMethod := map[string]int{}
+			for _, m := range methods {
+				key := ""
+				if m.PaymentMethod() != nil {
+					key = *m.PaymentMethod()
+				}
+				gotByPaymentMethod[key] = m.Breakdown().Net()
+			}
+			if !reflect.DeepEqual(gotByPaymentMethod, wantByPaymentMethod) {
+				t.Errorf("cost by payment method: got %v, want %v", gotByPaymentMethod, wantByPaymentMethod)
+			}
+		})
+	}
+}
+
+/*
+TestSubscriptionRepository_DateRangeParity сверяет rangeMonthsSQL и
+rangeCostSQL с DateRange.Months и DateRange.Prorate на краевых диапазонах:
+стык месяцев и лет, февраль високосного и обычного года, округление
+половины вверх и сумма по месяцам, округлённая один раз. Фильтр по тегу
+держит запрос на живом SQL, а не на свёртках.
+*/
+func TestSubscriptionRepository_DateRangeParity(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	day := func(year int, m time.Month, d int) time.Time {
+		return time.Date(year, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	endOfDay := func(year int, m time.Month, d int) time.Time {
+		return day(year, m, d).AddDate(0, 0, 1).Add(-time.Microsecond)
+	}
+
+	tests := []struct {
+		name   string
+		price  int
+		start  time.Time
+		end    *time.Time
+		period models.DateRange
+	}{
+		{"whole months", 599, month(2024, time.January), nil,
+			models.NewDateRange(month(2024, time.January), endOfMonth(2024, time.June))},
+		{"month boundary", 310, month(2024, time.January), nil,
+			models.NewDateRange(day(2024, time.January, 15), endOfDay(2024, time.February, 10))},
+		{"year boundary", 499, month(2023, time.June), nil,
+			models.NewDateRange(day(2023, time.December, 31), endOfDay(2024, time.January, 1))},
+		{"leap February", 290, month(2024, time.January), ptr(endOfMonth(2024, time.March)),
+			models.NewDateRange(day(2024, time.February, 15), endOfDay(2024, time.February, 29))},
+		{"common February", 290, month(2023, time.January), ptr(endOfMonth(2023, time.March)),
+			models.NewDateRange(day(2023, time.February, 15), endOfDay(2023, time.February, 28))},
+		{"leap day", 580, month(2024, time.February), ptr(endOfMonth(2024, time.February)),
+			models.NewDateRange(day(2024, time.February, 29), endOfDay(2024, time.February, 29))},
+		{"half rounds up", 1, month(2024, time.April), nil,
+			models.NewDateRange(day(2024, time.April, 1), endOfDay(2024, time.April, 15))},
+		{"below half rounds down", 1, month(2024, time.April), nil,
+			models.NewDateRange(day(2024, time.April, 1), endOfDay(2024, time.April, 14))},
+		{"sum is rounded once", 100, month(2024, time.April), nil,
+			models.NewDateRange(day(2024, time.April, 30), endOfDay(2024, time.May, 1))},
+		{"subscription ends inside the period", 300, month(2024, time.March), ptr(endOfMonth(2024, time.May)),
+			models.NewDateRange(day(2024, time.April, 10), endOfDay(2024, time.August, 20))},
+	}
+
+	for _, tt := range tests {
+		userID := uuid.New()
+		sub := createSubscriptions(t, repo, subscriptionSpec{
+			userID: userID, service: "Parity", price: tt.price, start: tt.start, end: tt.end, tags: []string{"parity"},
+		})[0]
+
+		filter := models.NewSubscriptionFilter()
+		filter.SetUserID(&userID)
+		filter.SetTags([]string{"parity"})
+
+		for _, billing := range []models.BillingMode{models.BillingMonthly, models.BillingProrated} {
+			t.Run(tt.name+"/"+string(billing), func(t *testing.T) {
+				overlap, ok := sub.Period().Intersect(tt.period)
+				want := 0
+				if ok {
+					want = overlap.Prorate(tt.price, billing)
+				}
+				if got := costFor(t, sub, tt.period, billing); got != want {
+					t.Fatalf("domain cost %d differs from Prorate %d", got, want)
+				}
+
+				total, err := repo.GetTotalCostForPeriod(ctx, filter, tt.period, billing, models.PricingCurrent)
+				if err != nil {
+					t.Fatalf("total cost: %v", err)
+				}
+				if total.Net() != want {
+					t.Errorf("SQL cost = %d, DateRange.Prorate = %d (months %d)", total.Net(), want, overlap.Months())
+				}
+			})
+		}
+	}
+}
+
+func TestSubscriptionRepository_PriceHistory(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	sub := createSubscriptions(t, repo,
+		subscriptionSpec{userID: userID, service: "Netflix", price: 600, start: month(2024, time.January)})[0]
+
+	changedAt := month(2024, time.April).Add(time.Hour)
+	if err := repo.RecordPriceChange(ctx, models.NewPriceChange(sub.ID(), 500, 600, changedAt)); err != nil {
+		t.Fatalf("record price change: %v", err)
+	}
+
+	history, err := repo.GetPriceHistory(ctx, sub.ID())
+	if err != nil {
+		t.Fatalf("price history: %v", err)
+	}
+	if len(history) != 1 || history[0].OldPrice() != 500 || history[0].NewPrice() != 600 {
+		t.Fatalf("price history: got %d entries", len(history))
+	}
+
+	filter := models.NewSubscriptionFilter()
+	filter.SetUserID(&userID)
+	period := models.NewDateRange(month(2024, time.January), endOfMonth(2024, time.June))
+
+	current, err := repo.GetTotalCostForPeriod(ctx, filter, period, models.BillingMonthly, models.PricingCurrent)
+	if err != nil {
+		t.Fatalf("current pricing: %v", err)
+	}
+	historical, err := repo.GetTotalCostForPeriod(ctx, filter, period, models.BillingMonthly, models.PricingHistorical)
+	if err != nil {
+		t.Fatalf("historical pricing: %v", err)
+	}
+	if current.Net() != 6*600 {
+		t.Errorf("current pricing: got %d, want %d", current.Net(), 6*600)
+	}
+	if historical.Net() >= current.Net() {
+		t.Errorf("historical pricing must count the old price: got %d, current %d", historical.Net(), current.Net())
+	}
+}
+
+/*
+TestSubscriptionRepository_CostRollups сверяет расчёт по свёрткам
+(monthly, current, период из целых месяцев) с моделью после каждого
+изменения, которое меняет вклад подписки: цены, дат (в том числе с
+переносом в другую секцию), сервиса и удаления.
+*/
+func TestSubscriptionRepository_CostRollups(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	subs := createSubscriptions(t, repo,
+		subscriptionSpec{userID: userID, service: "Netflix", price: 600, start: month(2023, time.November)},
+		subscriptionSpec{userID: userID, service: "Spotify", price: 300, start: month(2024, time.March).AddDate(0, 0, 14),
+			end: ptr(endOfMonth(2024, time.May))},
+		subscriptionSpec{userID: userID, service: "Kinopoisk", price: 270, start: month(2024, time.February),
+			end: ptr(endOfMonth(2024, time.February))},
+		subscriptionSpec{userID: uuid.New(), service: "Netflix", price: 199, start: month(2024, time.January)},
+	)
+
+	periods := []models.DateRange{
+		models.NewDateRange(month(2024, time.January), endOfMonth(2024, time.June)),
+		models.NewDateRange(month(2024, time.March), endOfMonth(2024, time.March)),
+		models.NewDateRange(month(2025, time.January), endOfMonth(2025, time.December)),
+	}
+
+	byUser := models.NewSubscriptionFilter()
+	byUser.SetUserID(&userID)
+
+	check := func(t *testing.T, step string) {
+		t.Helper()
+
+		for _, period := range periods {
+			for _, filter := range []*models.SubscriptionFilter{byUser, models.NewSubscriptionFilter()} {
+				all, err := repo.GetAll(ctx, filter, 100, 0)
+				if err != nil {
+					t.Fatalf("%s: list: %v", step, err)
+				}
+				want := 0
+				for _, sub := range all {
+					want += costFor(t, sub, period, models.BillingMonthly)
+				}
+
+				total, err := repo.GetTotalCostForPeriod(ctx, filter, period, models.BillingMonthly, models.PricingCurrent)
+				if err != nil {
+					t.Fatalf("%s: total cost: %v", step, err)
+				}
+				if total.Net() != want {
+					t.Errorf("%s: total cost for %s..%s (user filter %v): got %d, want %d",
+						step, period.From().Format("2006-01"), period.To().Format("2006-01"), filter.HasUserID(), total.Net(), want)
+				}
+			}
+		}
+	}
+
+	check(t, "created")
+
+	subs[0].SetPrice(models.Rubles(650))
+	subs[1].SetEndDate(nil)
+	// Другой год начала — строка переезжает в другую секцию.
+	subs[2].SetStartDate(month(2023, time.December))
+	subs[2].SetServiceName("Okko")
+	for _, sub := range subs[:3] {
+		if err := repo.Update(ctx, sub); err != nil {
+			t.Fatalf("update %s: %v", sub.ServiceName(), err)
+		}
+	}
+	check(t, "updated")
+
+	for _, sub := range subs {
+		if err := repo.Delete(ctx, sub.ID()); err != nil {
+			t.Fatalf("delete %s: %v", sub.ServiceName(), err)
+		}
+	}
+	check(t, "deleted")
+
+	var rollups, ledger int
+	err := testDB.Pool().QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM monthly_cost_rollups), (SELECT COUNT(*) FROM monthly_cost_rollup_subscriptions)`).
+		Scan(&rollups, &ledger)
+	if err != nil {
+		t.Fatalf("count rollups: %v", err)
+	}
+	if rollups != 0 || ledger != 0 {
+		t.Errorf("rollups must be empty after deleting every subscription: got %d rows, %d subscriptions", rollups, ledger)
+	}
+}
+
+func TestSubscriptionRepository_UserViews(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	at := month(2024, time.June).AddDate(0, 0, 14)
+	subs := createSubscriptions(t, repo,
+		subscriptionSpec{userID: userID, service: "Netflix", price: 600, start: month(2024, time.January)},
+		subscriptionSpec{userID: userID, service: "Spotify", price: 300, start: month(2024, time.March),
+			end: ptr(endOfMonth(2024, time.June))},
+		subscriptionSpec{userID: userID, service: "Kinopoisk", price: 270, start: month(2023, time.November),
+			end: ptr(endOfMonth(2024, time.February))},
+		subscriptionSpec{userID: userID, service: "Okko", price: 199, start: month(2024, time.September)},
+	)
+
+	stats, err := repo.GetUserStats(ctx, userID, at)
+	if err != nil {
+		t.Fatalf("user stats: %v", err)
+	}
+	if stats.Total() != 4 || stats.Active() != 2 || stats.Expired() != 1 || stats.Upcoming() != 1 {
+		t.Errorf("user stats: total %d, active %d, expired %d, upcoming %d",
+			stats.Total(), stats.Active(), stats.Expired(), stats.Upcoming())
+	}
+	if stats.MonthlySpend() != 900 {
+		t.Errorf("monthly spend: got %d, want 900", stats.MonthlySpend())
+	}
+	if stats.MostExpensive() == nil || stats.MostExpensive().ID() != subs[0].ID() {
+		t.Errorf("most expensive: got %v", stats.MostExpensive())
+	}
+
+	expiring, err := repo.GetExpiring(ctx, userID, at, at.AddDate(0, 0, 30))
+	if err != nil {
+		t.Fatalf("expiring: %v", err)
+	}
+	if len(expiring) != 1 || expiring[0].ID() != subs[1].ID() {
+		t.Errorf("expiring: got %d subscriptions", len(expiring))
+	}
+
+	calendar, err := repo.GetCalendar(ctx, userID, 2024, models.BillingMonthly, models.PricingCurrent)
+	if err != nil {
+		t.Fatalf("calendar: %v", err)
+	}
+	if len(calendar.Months()) != 12 {
+		t.Fatalf("calendar: got %d months", len(calendar.Months()))
+	}
+	want := 0
+	for _, sub := range subs {
+		want += costFor(t, sub, models.NewDateRange(month(2024, time.January), endOfMonth(2024, time.December)), models.BillingMonthly)
+	}
+	if calendar.TotalCost() != want {
+		t.Errorf("calendar total: got %d, want %d", calendar.TotalCost(), want)
+	}
+	if june := calendar.MonthOf(at); june == nil || len(june.Subscriptions()) != 2 {
+		t.Errorf("calendar june: got %v", june)
+	}
+
+	forecast, err := repo.GetForecast(ctx, userID, month(2024, time.June), 6, models.BillingMonthly)
+	if err != nil {
+		t.Fatalf("forecast: %v", err)
+	}
+	if forecast.TotalCost() != 900+600+600+799+799+799 {
+		t.Errorf("forecast total: got %d", forecast.TotalCost())
+	}
+	if june := forecast.Months()[0]; len(june.Ending()) != 1 || june.Ending()[0].ID() != subs[1].ID() {
+		t.Errorf("forecast june ending: got %d", len(june.Ending()))
+	}
+	if september := forecast.MonthOf(month(2024, time.September)); len(september.Starting()) != 1 || september.Starting()[0].ID() != subs[3].ID() {
+		t.Errorf("forecast september starting: got %d", len(september.Starting()))
+	}
+}
+
+func TestSubscriptionRepository_ExpiryReminders(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	events := repository.NewSubscriptionEventRepository(testDB, testLog)
+	ctx := context.Background()
+
+	from := month(2024, time.June)
+	subs := createSubscriptions(t, repo,
+		subscriptionSpec{userID: uuid.New(), service: "Netflix", price: 600, start: month(2024, time.January),
+			end: ptr(endOfMonth(2024, time.June))},
+		subscriptionSpec{userID: uuid.New(), service: "Spotify", price: 300, start: month(2024, time.January),
+			end: ptr(endOfMonth(2024, time.June))},
+		subscriptionSpec{userID: uuid.New(), service: "Okko", price: 199, start: month(2024, time.January),
+			end: ptr(endOfMonth(2024, time.September))},
+	)
+
+	due, err := repo.GetDueExpiryReminders(ctx, from, endOfMonth(2024, time.July), 10)
+	if err != nil {
+		t.Fatalf("due reminders: %v", err)
+	}
+	if len(due) != 2 {
+		t.Fatalf("due reminders: got %d, want 2", len(due))
+	}
+
+	marked, err := events.MarkExpiryReminded(ctx, subs[0].ID(), *subs[0].EndDate(), uuid.New())
+	if err != nil || !marked {
+		t.Fatalf("mark reminded: got %v, %v", marked, err)
+	}
+	marked, err = events.MarkExpiryReminded(ctx, subs[0].ID(), *subs[0].EndDate(), uuid.New())
+	if err != nil || marked {
+		t.Fatalf("repeated mark must be a no-op: got %v, %v", marked, err)
+	}
+
+	due, err = repo.GetDueExpiryReminders(ctx, from, endOfMonth(2024, time.July), 10)
+	if err != nil {
+		t.Fatalf("due reminders after mark: %v", err)
+	}
+	if len(due) != 1 || due[0].ID() != subs[1].ID() {
+		t.Errorf("due reminders after mark: got %d", len(due))
+	}
+}
+
+func TestSubscriptionRepository_ArchiveEnded(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	subs := createSubscriptions(t, repo,
+		subscriptionSpec{userID: userID, service: "Netflix", price: 599, start: month(2021, time.January),
+			end: ptr(endOfMonth(2021, time.December)), tags: []string{"family"}},
+		subscriptionSpec{userID: userID, service: "Spotify", price: 269, start: month(2022, time.January),
+			end: ptr(endOfMonth(2022, time.June))},
+		subscriptionSpec{userID: userID, service: "Okko", price: 199, start: month(2024, time.January),
+			end: ptr(endOfMonth(2024, time.June))},
+		subscriptionSpec{userID: userID, service: "iCloud+", price: 149, start: month(2021, time.January)},
+	)
+
+	before := month(2023, time.January)
+	moved, err := repo.ArchiveEnded(ctx, before, 1)
+	if err != nil || moved != 1 {
+		t.Fatalf("first batch: got %d, %v", moved, err)
+	}
+	moved, err = repo.ArchiveEnded(ctx, before, 10)
+	if err != nil || moved != 1 {
+		t.Fatalf("second batch: got %d, %v", moved, err)
+	}
+	moved, err = repo.ArchiveEnded(ctx, before, 10)
+	if err != nil || moved != 0 {
+		t.Fatalf("nothing left: got %d, %v", moved, err)
+	}
+
+	_, err = repo.GetByID(ctx, subs[0].ID())
+	assertCode(t, err, apperror.CodeSubscriptionNotFound)
+
+	filter := models.NewSubscriptionFilter()
+	filter.SetUserID(&userID)
+	current, total, err := repo.GetAllWithTotal(ctx, filter, 10, 0)
+	if err != nil || total != 2 {
+		t.Fatalf("current subscriptions: got %d, %v", total, err)
+	}
+	if ids := idSet(current); !ids[subs[2].ID()] || !ids[subs[3].ID()] {
+		t.Errorf("current subscriptions: got %v", ids)
+	}
+
+	filter.SetArchived(true)
+	filter.SetTags([]string{"family"})
+	archived, total, err := repo.GetAllWithTotal(ctx, filter, 10, 0)
+	if err != nil || total != 1 {
+		t.Fatalf("archived subscriptions: got %d, %v", total, err)
+	}
+	if archived[0].ID() != subs[0].ID() || !archived[0].Price().Equal(models.Rubles(599)) {
+		t.Errorf("archived subscription: got %s (%s)", archived[0].ID(), archived[0].Price())
+	}
+}
+
+/*
+TestSubscriptionRepository_ArchiveKeepsDependents: перенос в архив не
+удаляет комментарии, историю цен и участников подписки; они удаляются
+вместе с архивной строкой при удалении аккаунта.
+*/
+func TestSubscriptionRepository_ArchiveKeepsDependents(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	comments := repository.NewSubscriptionCommentRepository(testDB, testLog)
+	members := repository.NewSubscriptionMemberRepository(testDB, testLog)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	sub := createSubscriptions(t, repo,
+		subscriptionSpec{userID: userID, service: "Netflix", price: 599, start: month(2021, time.January),
+			end: ptr(endOfMonth(2021, time.December))})[0]
+
+	if err := comments.Create(ctx, models.NewSubscriptionComment(sub.ID(), "support", "refund issued")); err != nil {
+		t.Fatalf("create comment: %v", err)
+	}
+	if err := repo.RecordPriceChange(ctx, models.NewPriceChange(sub.ID(), 499, 599, month(2021, time.June))); err != nil {
+		t.Fatalf("record price change: %v", err)
+	}
+	if err := members.Add(ctx, models.NewSubscriptionMember(sub.ID(), uuid.New(), 50)); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+
+	assertDependents := func(stage string, want int) {
+		t.Helper()
+		if count, err := comments.CountBySubscriptionID(ctx, sub.ID()); err != nil || count != want {
+			t.Errorf("%s: comments = %d, %v; want %d", stage, count, err, want)
+		}
+		if history, err := repo.GetPriceHistory(ctx, sub.ID()); err != nil || len(history) != want {
+			t.Errorf("%s: price history = %d, %v; want %d", stage, len(history), err, want)
+		}
+		if list, err := members.ListBySubscriptionID(ctx, sub.ID()); err != nil || len(list) != want {
+			t.Errorf("%s: members = %d, %v; want %d", stage, len(list), err, want)
+		}
+	}
+
+	if moved, err := repo.ArchiveEnded(ctx, month(2023, time.January), 10); err != nil || moved != 1 {
+		t.Fatalf("archive: got %d, %v", moved, err)
+	}
+	assertDependents("after archiving", 1)
+
+	if _, err := repo.DeleteByUserID(ctx, userID); err != nil {
+		t.Fatalf("delete user subscriptions: %v", err)
+	}
+	assertDependents("after account deletion", 0)
+}
+
+/*
+TestSubscriptionRepository_TotalsExcludeArchived фиксирует документированное
+поведение: суммы за прошлые периоды считаются только по subscriptions, так
+что архивная подписка из них выпадает — и в расчёте по свёрткам, и в
+обычном.
+*/
+func TestSubscriptionRepository_TotalsExcludeArchived(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	createSubscriptions(t, repo,
+		subscriptionSpec{userID: userID, service: "Netflix", price: 599, start: month(2021, time.January),
+			end: ptr(endOfMonth(2021, time.December))},
+		subscriptionSpec{userID: userID, service: "iCloud+", price: 149, start: month(2021, time.January)},
+	)
+
+	filter := models.NewSubscriptionFilter()
+	filter.SetUserID(&userID)
+	period := models.NewDateRange(month(2021, time.January), endOfMonth(2021, time.December))
+
+	totals := func() (rollups, daily int) {
+		t.Helper()
+		monthly, err := repo.GetTotalCostForPeriod(ctx, filter, period, models.BillingMonthly, models.PricingCurrent)
+		if err != nil {
+			t.Fatalf("monthly total: %v", err)
+		}
+		prorated, err := repo.GetTotalCostForPeriod(ctx, filter, period, models.BillingProrated, models.PricingCurrent)
+		if err != nil {
+			t.Fatalf("daily total: %v", err)
+		}
+		return monthly.Net(), prorated.Net()
+	}
+
+	if monthly, daily := totals(); monthly != 12*(599+149) || daily != 12*(599+149) {
+		t.Fatalf("before archiving: monthly %d, daily %d; want %d", monthly, daily, 12*(599+149))
+	}
+
+	if moved, err := repo.ArchiveEnded(ctx, month(2023, time.January), 10); err != nil || moved != 1 {
+		t.Fatalf("archive: got %d, %v", moved, err)
+	}
+
+	if monthly, daily := totals(); monthly != 12*149 || daily != 12*149 {
+		t.Errorf("after archiving: monthly %d, daily %d; want %d", monthly, daily, 12*149)
+	}
+}
+
+func TestSubscriptionRepository_Analytics(t *testing.T) {
+	repo := newSubscriptionRepo(t)
+	ctx := context.Background()
+
+	alice, bob := uuid.New(), uuid.New()
+	createSubscriptions(t, repo,
+		subscriptionSpec{userID: alice, service: "Netflix", price: 600, start: month(2024, time.January)},
+		subscriptionSpec{userID: alice, service: "Spotify", price: 300, start: month(2024, time.February),
+			end: ptr(endOfMonth(2024, time.February))},
+		subscriptionSpec{userID: bob, service: "Netflix", price: 900, start: month(2024, time.February)},
+	)
+
+	period := models.NewDateRange(month(2024, time.January), endOfMonth(2024, time.March))
+
+	kpis, err := repo.GetBusinessKPIs(ctx, models.NewDateRange(month(2024, time.February), endOfMonth(2024, time.February)), models.BillingMonthly)
+	if err != nil {
+		t.Fatalf("kpis: %v", err)
+	}
+	if kpis.ActiveUsers() != 2 || kpis.ActiveSubscriptions() != 3 || kpis.MonthlySpend() != 1800 {
+		t.Errorf("kpis: users %d, subscriptions %d, spend %d",
+			kpis.ActiveUsers(), kpis.ActiveSubscriptions(), kpis.MonthlySpend())
+	}
+
+	top, err := repo.GetTopServices(ctx, period, models.BillingMonthly, models.TopServicesByRevenue, 10)
+	if err != nil {
+		t.Fatalf("top services: %v", err)
+	}
+	if len(top) != 2 || top[0].ServiceName() != "Netflix" || top[0].Subscribers() != 2 || top[0].Revenue() != 3*600+2*900 {
+		t.Errorf("top services: got %d entries", len(top))
+	}
+
+	mrr, err := repo.GetMRR(ctx, period)
+	if err != nil {
+		t.Fatalf("mrr: %v", err)
+	}
+	wantMRR := []int{600, 1800, 1500}
+	if len(mrr) != len(wantMRR) {
+		t.Fatalf("mrr: got %d points", len(mrr))
+	}
+	for i, point := range mrr {
+		if point.MRR() != wantMRR[i] {
+			t.Errorf("mrr %s: got %d, want %d", point.Month().Format("2006-01"), point.MRR(), wantMRR[i])
+		}
+	}
+
+	churn, err := repo.GetChurn(ctx, period)
+	if err != nil {
+		t.Fatalf("churn: %v", err)
+	}
+	if len(churn) != 3 || churn[1].Started() != 2 || churn[1].Churned() != 1 || churn[2].ActiveAtStart() != 2 {
+		t.Errorf("churn: got %d points", len(churn))
+	}
+
+	total := 0
+	for _, userRange := range models.SplitUserIDSpace(4) {
+		users, err := repo.GetUserSpendForRange(ctx, period, models.BillingMonthly, userRange)
+		if err != nil {
+			t.Fatalf("user spend: %v", err)
+		}
+		for _, user := range users {
+			total += user.TotalCost()
+		}
+	}
+	if want := 3*600 + 300 + 2*900; total != want {
+		t.Errorf("user spend over all ranges: got %d, want %d", total, want)
+	}
+}
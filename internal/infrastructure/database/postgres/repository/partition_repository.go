@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// partitionRepository обслуживает годовые секции subscriptions, см.
+// миграцию 021_partition_subscriptions_by_start_date.
+type partitionRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewPartitionRepository(db *postgres.DB, log *logger.Logger) *partitionRepository {
+	return &partitionRepository{
+		db:  db,
+		log: log.Named("partition-repository"),
+	}
+}
+
+// DefaultPartitionYears — годы start_date (UTC) строк, попавших в
+// subscriptions_default, потому что секции их года ещё нет.
+func (r *partitionRepository) DefaultPartitionYears(ctx context.Context) ([]int, error) {
+	query := `
+		SELECT DISTINCT extract(year FROM start_date AT TIME ZONE 'UTC')::INT
+		FROM subscriptions_default
+		ORDER BY 1`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query)
+	if err != nil {
+		r.log.Error("failed to list default partition years", zap.Error(err))
+		return nil, dbError("list default partition years", err)
+	}
+	defer rows.Close()
+
+	years := make([]int, 0)
+	for rows.Next() {
+		var year int
+		if err := rows.Scan(&year); err != nil {
+			return nil, dbError("scan default partition year", err)
+		}
+		years = append(years, year)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate default partition years", err)
+	}
+
+	return years, nil
+}
+
+// EnsureYearPartition создаёт секцию года и переносит в неё строки из
+// subscriptions_default. false — секция уже была.
+func (r *partitionRepository) EnsureYearPartition(ctx context.Context, year int) (bool, error) {
+	var created bool
+	err := r.db.Conn(ctx).QueryRow(ctx, `SELECT ensure_subscriptions_partition($1)`, year).Scan(&created)
+	if err != nil {
+		r.log.Error("failed to ensure subscriptions partition",
+			zap.Int("year", year),
+			zap.Error(err))
+		return false, dbError("ensure subscriptions partition", err)
+	}
+
+	return created, nil
+}
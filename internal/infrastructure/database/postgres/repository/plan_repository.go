@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type planRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewPlanRepository(db *postgres.DB, log *logger.Logger) *planRepository {
+	return &planRepository{
+		db:  db,
+		log: log.Named("plan-repository"),
+	}
+}
+
+func (r *planRepository) Create(ctx context.Context, plan *models.Plan) error {
+	price, err := priceValue(plan.Price())
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO plans (id, name, service_name, price, billing_cycle, features, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err = r.db.Conn(ctx).Exec(ctx, query,
+		plan.ID(),
+		plan.Name(),
+		plan.ServiceName(),
+		price,
+		string(plan.BillingCycle()),
+		plan.Features(),
+		plan.CreatedAt(),
+		plan.UpdatedAt(),
+	)
+	if err != nil {
+		if err := planConflict(err, plan); err != nil {
+			return err
+		}
+
+		r.log.Error("failed to create plan",
+			zap.String("name", plan.Name()),
+			zap.Error(err))
+		return dbError("create plan", err)
+	}
+
+	return nil
+}
+
+func (r *planRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Plan, error) {
+	query := `
+		SELECT id, name, service_name, price, billing_cycle, features, created_at, updated_at
+		FROM plans
+		WHERE id = $1`
+
+	plan, err := r.scanPlan(r.db.Conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NotFound("plan")
+		}
+		r.log.Error("failed to get plan",
+			zap.String("plan_id", id.String()),
+			zap.Error(err))
+		return nil, dbError("get plan", err)
+	}
+
+	return plan, nil
+}
+
+func (r *planRepository) List(ctx context.Context, limit, offset int) ([]*models.Plan, error) {
+	query := `
+		SELECT id, name, service_name, price, billing_cycle, features, created_at, updated_at
+		FROM plans
+		ORDER BY service_name, price, id
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, limit, offset)
+	if err != nil {
+		r.log.Error("failed to list plans", zap.Error(err))
+		return nil, dbError("list plans", err)
+	}
+	defer rows.Close()
+
+	plans := make([]*models.Plan, 0)
+	for rows.Next() {
+		plan, err := r.scanPlan(rows)
+		if err != nil {
+			return nil, dbError("scan plan", err)
+		}
+		plans = append(plans, plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate plans", err)
+	}
+
+	return plans, nil
+}
+
+func (r *planRepository) Update(ctx context.Context, plan *models.Plan) error {
+	price, err := priceValue(plan.Price())
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE plans
+		SET name = $2, service_name = $3, price = $4, billing_cycle = $5, features = $6, updated_at = $7
+		WHERE id = $1`
+
+	tag, err := r.db.Conn(ctx).Exec(ctx, query,
+		plan.ID(),
+		plan.Name(),
+		plan.ServiceName(),
+		price,
+		string(plan.BillingCycle()),
+		plan.Features(),
+		plan.UpdatedAt(),
+	)
+	if err != nil {
+		if err := planConflict(err, plan); err != nil {
+			return err
+		}
+
+		r.log.Error("failed to update plan",
+			zap.String("plan_id", plan.ID().String()),
+			zap.Error(err))
+		return dbError("update plan", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("plan")
+	}
+
+	return nil
+}
+
+func (r *planRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `DELETE FROM plans WHERE id = $1`, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return apperror.New(apperror.CodeConflict, "Plan has subscriptions").
+				WithDetail("plan_id", id.String())
+		}
+
+		r.log.Error("failed to delete plan",
+			zap.String("plan_id", id.String()),
+			zap.Error(err))
+		return dbError("delete plan", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("plan")
+	}
+
+	return nil
+}
+
+func (r *planRepository) AddPrice(ctx context.Context, price *models.PlanPrice) error {
+	amount, err := priceValue(price.Price())
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO plan_price_history (plan_id, price, billing_cycle, effective_from)
+		VALUES ($1, $2, $3, $4)`
+
+	_, err = r.db.Conn(ctx).Exec(ctx, query,
+		price.PlanID(),
+		amount,
+		string(price.BillingCycle()),
+		price.EffectiveFrom(),
+	)
+	if err != nil {
+		r.log.Error("failed to record plan price",
+			zap.String("plan_id", price.PlanID().String()),
+			zap.Error(err))
+		return dbError("record plan price", err)
+	}
+
+	return nil
+}
+
+func (r *planRepository) ListPrices(ctx context.Context, planID uuid.UUID) ([]*models.PlanPrice, error) {
+	query := `
+		SELECT price, billing_cycle, effective_from
+		FROM plan_price_history
+		WHERE plan_id = $1
+		ORDER BY effective_from, id`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, planID)
+	if err != nil {
+		r.log.Error("failed to list plan prices",
+			zap.String("plan_id", planID.String()),
+			zap.Error(err))
+		return nil, dbError("list plan prices", err)
+	}
+	defer rows.Close()
+
+	prices := make([]*models.PlanPrice, 0)
+	for rows.Next() {
+		var (
+			price         int
+			billingCycle  string
+			effectiveFrom time.Time
+		)
+		if err := rows.Scan(&price, &billingCycle, &effectiveFrom); err != nil {
+			return nil, dbError("scan plan price", err)
+		}
+		prices = append(prices, models.NewPlanPrice(planID, models.Rubles(price), models.BillingCycle(billingCycle), effectiveFrom))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate plan prices", err)
+	}
+
+	return prices, nil
+}
+
+func (r *planRepository) scanPlan(row pgx.Row) (*models.Plan, error) {
+	var (
+		id           uuid.UUID
+		name         string
+		serviceName  string
+		price        int
+		billingCycle string
+		features     map[string]interface{}
+		createdAt    time.Time
+		updatedAt    time.Time
+	)
+	if err := row.Scan(&id, &name, &serviceName, &price, &billingCycle, &features, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	return models.RestorePlan(id, name, serviceName, models.Rubles(price), models.BillingCycle(billingCycle), features, createdAt, updatedAt), nil
+}
+
+// planConflict переводит нарушение уникальности названия в CONFLICT.
+func planConflict(err error, plan *models.Plan) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return apperror.New(apperror.CodeConflict, "Plan already exists").
+			WithDetail("name", plan.Name())
+	}
+	return nil
+}
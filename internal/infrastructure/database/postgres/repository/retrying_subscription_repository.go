@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	domainRepo "github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// RetryObserver считает повторы; operation — имя метода репозитория.
+type RetryObserver interface {
+	ObserveDBRetry(operation string)
+}
+
+// transactions сообщает, выполняется ли вызов внутри транзакции
+// (реализует *postgres.DB).
+type transactions interface {
+	InTransaction(ctx context.Context) bool
+}
+
+/*
+retryingSubscriptionRepository повторяет операции, упавшие с retryable
+ошибкой (IsRetryable): чтения — по политике reads, из записей — только
+идемпотентные Update и Delete по политике writes. Create, DeleteByUserID,
+ArchiveEnded, RecordPriceChange и IterateAll не повторяются: повтор после
+потерянного ответа изменил бы результат. Внутри транзакции повторов нет —
+после ошибки она всё равно прервана, повторять должен тот, кто её открыл.
+*/
+type retryingSubscriptionRepository struct {
+	domainRepo.SubscriptionRepository
+
+	reads    postgres.RetryPolicy
+	writes   postgres.RetryPolicy
+	tx       transactions
+	observer RetryObserver
+	log      *logger.Logger
+}
+
+// NewRetryingSubscriptionRepository оборачивает inner; observer может быть nil.
+func NewRetryingSubscriptionRepository(inner domainRepo.SubscriptionRepository, tx transactions, reads, writes postgres.RetryPolicy, observer RetryObserver, log *logger.Logger) domainRepo.SubscriptionRepository {
+	return &retryingSubscriptionRepository{
+		SubscriptionRepository: inner,
+		reads:                  reads,
+		writes:                 writes,
+		tx:                     tx,
+		observer:               observer,
+		log:                    log.Named("db-retry"),
+	}
+}
+
+func (r *retryingSubscriptionRepository) do(ctx context.Context, operation string, policy postgres.RetryPolicy, op func() error) error {
+	if r.tx.InTransaction(ctx) {
+		return op()
+	}
+
+	return policy.Retry(ctx, op, IsRetryable, func(attempt int, err error) {
+		r.log.Warn("retrying database operation",
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt+1),
+			zap.Error(err))
+		if r.observer != nil {
+			r.observer.ObserveDBRetry(operation)
+		}
+	})
+}
+
+func (r *retryingSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (sub *models.Subscription, err error) {
+	err = r.do(ctx, "GetByID", r.reads, func() error {
+		sub, err = r.SubscriptionRepository.GetByID(ctx, id)
+		return err
+	})
+	return sub, err
+}
+
+func (r *retryingSubscriptionRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) (subs []*models.Subscription, err error) {
+	err = r.do(ctx, "GetByUserID", r.reads, func() error {
+		subs, err = r.SubscriptionRepository.GetByUserID(ctx, userID, limit, offset)
+		return err
+	})
+	return subs, err
+}
+
+func (r *retryingSubscriptionRepository) GetAll(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) (subs []*models.Subscription, err error) {
+	err = r.do(ctx, "GetAll", r.reads, func() error {
+		subs, err = r.SubscriptionRepository.GetAll(ctx, filter, limit, offset)
+		return err
+	})
+	return subs, err
+}
+
+func (r *retryingSubscriptionRepository) GetAllWithTotal(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) (subs []*models.Subscription, total int, err error) {
+	err = r.do(ctx, "GetAllWithTotal", r.reads, func() error {
+		subs, total, err = r.SubscriptionRepository.GetAllWithTotal(ctx, filter, limit, offset)
+		return err
+	})
+	return subs, total, err
+}
+
+func (r *retryingSubscriptionRepository) Search(ctx context.Context, query models.SearchQuery, userID *uuid.UUID, limit, offset int) (hits []*models.SubscriptionSearchHit, err error) {
+	err = r.do(ctx, "Search", r.reads, func() error {
+		hits, err = r.SubscriptionRepository.Search(ctx, query, userID, limit, offset)
+		return err
+	})
+	return hits, err
+}
+
+func (r *retryingSubscriptionRepository) Update(ctx context.Context, subscription *models.Subscription) error {
+	return r.do(ctx, "Update", r.writes, func() error {
+		return r.SubscriptionRepository.Update(ctx, subscription)
+	})
+}
+
+// Delete: если повтор не нашёл подписку, её удалила предыдущая попытка,
+// ответ которой потерялся, — это успех.
+func (r *retryingSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	attempts := 0
+	return r.do(ctx, "Delete", r.writes, func() error {
+		attempts++
+		err := r.SubscriptionRepository.Delete(ctx, id)
+		if appErr, ok := apperror.IsAppError(err); ok && attempts > 1 && appErr.Code() == apperror.CodeSubscriptionNotFound {
+			return nil
+		}
+		return err
+	})
+}
+
+func (r *retryingSubscriptionRepository) GetTotalCostForPeriod(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) (cost models.CostBreakdown, err error) {
+	err = r.do(ctx, "GetTotalCostForPeriod", r.reads, func() error {
+		cost, err = r.SubscriptionRepository.GetTotalCostForPeriod(ctx, filter, period, billing, pricing)
+		return err
+	})
+	return cost, err
+}
+
+func (r *retryingSubscriptionRepository) GetCostByCategory(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) (costs []*models.CategoryCost, err error) {
+	err = r.do(ctx, "GetCostByCategory", r.reads, func() error {
+		costs, err = r.SubscriptionRepository.GetCostByCategory(ctx, filter, period, billing, pricing)
+		return err
+	})
+	return costs, err
+}
+
+func (r *retryingSubscriptionRepository) GetCostByPaymentMethod(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) (costs []*models.PaymentMethodCost, err error) {
+	err = r.do(ctx, "GetCostByPaymentMethod", r.reads, func() error {
+		costs, err = r.SubscriptionRepository.GetCostByPaymentMethod(ctx, filter, period, billing, pricing)
+		return err
+	})
+	return costs, err
+}
+
+func (r *retryingSubscriptionRepository) Count(ctx context.Context, filter *models.SubscriptionFilter) (count int, err error) {
+	err = r.do(ctx, "Count", r.reads, func() error {
+		count, err = r.SubscriptionRepository.Count(ctx, filter)
+		return err
+	})
+	return count, err
+}
+
+func (r *retryingSubscriptionRepository) GetUserStats(ctx context.Context, userID uuid.UUID, at time.Time) (stats *models.UserSubscriptionStats, err error) {
+	err = r.do(ctx, "GetUserStats", r.reads, func() error {
+		stats, err = r.SubscriptionRepository.GetUserStats(ctx, userID, at)
+		return err
+	})
+	return stats, err
+}
+
+func (r *retryingSubscriptionRepository) GetExpiring(ctx context.Context, userID uuid.UUID, from, to time.Time) (subs []*models.Subscription, err error) {
+	err = r.do(ctx, "GetExpiring", r.reads, func() error {
+		subs, err = r.SubscriptionRepository.GetExpiring(ctx, userID, from, to)
+		return err
+	})
+	return subs, err
+}
+
+func (r *retryingSubscriptionRepository) FindDuplicates(ctx context.Context, userID uuid.UUID, minSimilarity float64) (duplicates []*models.SubscriptionDuplicate, err error) {
+	err = r.do(ctx, "FindDuplicates", r.reads, func() error {
+		duplicates, err = r.SubscriptionRepository.FindDuplicates(ctx, userID, minSimilarity)
+		return err
+	})
+	return duplicates, err
+}
+
+func (r *retryingSubscriptionRepository) GetDueExpiryReminders(ctx context.Context, from, to time.Time, limit int) (subs []*models.Subscription, err error) {
+	err = r.do(ctx, "GetDueExpiryReminders", r.reads, func() error {
+		subs, err = r.SubscriptionRepository.GetDueExpiryReminders(ctx, from, to, limit)
+		return err
+	})
+	return subs, err
+}
+
+func (r *retryingSubscriptionRepository) Exists(ctx context.Context, id uuid.UUID) (exists bool, err error) {
+	err = r.do(ctx, "Exists", r.reads, func() error {
+		exists, err = r.SubscriptionRepository.Exists(ctx, id)
+		return err
+	})
+	return exists, err
+}
+
+func (r *retryingSubscriptionRepository) GetCalendar(ctx context.Context, userID uuid.UUID, year int, billing models.BillingMode, pricing models.PricingMode) (calendar *models.SubscriptionCalendar, err error) {
+	err = r.do(ctx, "GetCalendar", r.reads, func() error {
+		calendar, err = r.SubscriptionRepository.GetCalendar(ctx, userID, year, billing, pricing)
+		return err
+	})
+	return calendar, err
+}
+
+func (r *retryingSubscriptionRepository) GetBusinessKPIs(ctx context.Context, period models.DateRange, billing models.BillingMode) (kpis *models.BusinessKPIs, err error) {
+	err = r.do(ctx, "GetBusinessKPIs", r.reads, func() error {
+		kpis, err = r.SubscriptionRepository.GetBusinessKPIs(ctx, period, billing)
+		return err
+	})
+	return kpis, err
+}
+
+func (r *retryingSubscriptionRepository) GetPriceHistory(ctx context.Context, subscriptionID uuid.UUID) (changes []*models.PriceChange, err error) {
+	err = r.do(ctx, "GetPriceHistory", r.reads, func() error {
+		changes, err = r.SubscriptionRepository.GetPriceHistory(ctx, subscriptionID)
+		return err
+	})
+	return changes, err
+}
+
+func (r *retryingSubscriptionRepository) GetUserSpendForRange(ctx context.Context, period models.DateRange, billing models.BillingMode, userRange models.UserSpendRange) (spend []*models.UserSpend, err error) {
+	err = r.do(ctx, "GetUserSpendForRange", r.reads, func() error {
+		spend, err = r.SubscriptionRepository.GetUserSpendForRange(ctx, period, billing, userRange)
+		return err
+	})
+	return spend, err
+}
+
+func (r *retryingSubscriptionRepository) GetTopServices(ctx context.Context, period models.DateRange, billing models.BillingMode, sortBy models.TopServicesSort, limit int) (services []*models.ServiceStats, err error) {
+	err = r.do(ctx, "GetTopServices", r.reads, func() error {
+		services, err = r.SubscriptionRepository.GetTopServices(ctx, period, billing, sortBy, limit)
+		return err
+	})
+	return services, err
+}
+
+func (r *retryingSubscriptionRepository) GetMRR(ctx context.Context, period models.DateRange) (points []*models.MRRPoint, err error) {
+	err = r.do(ctx, "GetMRR", r.reads, func() error {
+		points, err = r.SubscriptionRepository.GetMRR(ctx, period)
+		return err
+	})
+	return points, err
+}
+
+func (r *retryingSubscriptionRepository) GetChurn(ctx context.Context, period models.DateRange) (points []*models.ChurnPoint, err error) {
+	err = r.do(ctx, "GetChurn", r.reads, func() error {
+		points, err = r.SubscriptionRepository.GetChurn(ctx, period)
+		return err
+	})
+	return points, err
+}
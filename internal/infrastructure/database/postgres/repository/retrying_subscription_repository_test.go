@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type txStub bool
+
+func (s txStub) InTransaction(context.Context) bool { return bool(s) }
+
+type retryCounter map[string]int
+
+func (c retryCounter) ObserveDBRetry(operation string) { c[operation]++ }
+
+func newRetrying(t *testing.T, inTx bool) (*mocks.MockSubscriptionRepository, retryCounter, *retryingSubscriptionRepository) {
+	t.Helper()
+
+	log, err := logger.NewLogger(logger.Config{Level: "fatal"})
+	if err != nil {
+		t.Fatalf("logger: %v", err)
+	}
+
+	inner := mocks.NewMockSubscriptionRepository(gomock.NewController(t))
+	observed := retryCounter{}
+	policy := postgres.RetryPolicy{Attempts: 3, BaseDelay: time.Microsecond, MaxDelay: time.Microsecond}
+	repo := NewRetryingSubscriptionRepository(inner, txStub(inTx), policy, policy, observed, log)
+	return inner, observed, repo.(*retryingSubscriptionRepository)
+}
+
+func transient() error {
+	return apperror.DatabaseError("get", errors.New("conn reset")).WithDetail("retryable", "true")
+}
+
+func TestRetryingSubscriptionRepository(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("read retried until success", func(t *testing.T) {
+		inner, observed, repo := newRetrying(t, false)
+		want := &models.Subscription{}
+		gomock.InOrder(
+			inner.EXPECT().GetByID(ctx, id).Return(nil, transient()),
+			inner.EXPECT().GetByID(ctx, id).Return(want, nil),
+		)
+
+		got, err := repo.GetByID(ctx, id)
+		if err != nil || got != want {
+			t.Fatalf("GetByID = %v, %v; want subscription", got, err)
+		}
+		if observed["GetByID"] != 1 {
+			t.Errorf("retries observed = %d, want 1", observed["GetByID"])
+		}
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		inner, observed, repo := newRetrying(t, false)
+		inner.EXPECT().Count(ctx, nil).Return(0, transient()).Times(3)
+
+		if _, err := repo.Count(ctx, nil); !IsRetryable(err) {
+			t.Fatalf("Count error = %v, want retryable", err)
+		}
+		if observed["Count"] != 2 {
+			t.Errorf("retries observed = %d, want 2", observed["Count"])
+		}
+	})
+
+	t.Run("permanent error not retried", func(t *testing.T) {
+		inner, _, repo := newRetrying(t, false)
+		inner.EXPECT().GetByID(ctx, id).Return(nil, apperror.SubscriptionNotFound(id.String())).Times(1)
+
+		if _, err := repo.GetByID(ctx, id); err == nil {
+			t.Fatal("GetByID error = nil, want not found")
+		}
+	})
+
+	t.Run("no retries inside transaction", func(t *testing.T) {
+		inner, _, repo := newRetrying(t, true)
+		inner.EXPECT().Update(ctx, gomock.Any()).Return(transient()).Times(1)
+
+		if err := repo.Update(ctx, &models.Subscription{}); !IsRetryable(err) {
+			t.Fatalf("Update error = %v, want retryable", err)
+		}
+	})
+
+	t.Run("create passes through", func(t *testing.T) {
+		inner, _, repo := newRetrying(t, false)
+		inner.EXPECT().Create(ctx, gomock.Any()).Return(transient()).Times(1)
+
+		if err := repo.Create(ctx, &models.Subscription{}); !IsRetryable(err) {
+			t.Fatalf("Create error = %v, want retryable", err)
+		}
+	})
+
+	t.Run("delete not found on retry is success", func(t *testing.T) {
+		inner, _, repo := newRetrying(t, false)
+		gomock.InOrder(
+			inner.EXPECT().Delete(ctx, id).Return(transient()),
+			inner.EXPECT().Delete(ctx, id).Return(apperror.SubscriptionNotFound(id.String())),
+		)
+
+		if err := repo.Delete(ctx, id); err != nil {
+			t.Fatalf("Delete error = %v, want nil", err)
+		}
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := postgres.RetryPolicy{Attempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	cases := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	}
+	for _, tc := range cases {
+		for range 20 {
+			got := policy.Backoff(tc.attempt)
+			if got < tc.max/2 || got > tc.max {
+				t.Fatalf("Backoff(%d) = %v, want in [%v, %v]", tc.attempt, got, tc.max/2, tc.max)
+			}
+		}
+	}
+}
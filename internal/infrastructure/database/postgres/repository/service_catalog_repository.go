@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+const catalogServiceColumns = `id, name, category, website, icon_url, typical_price, created_at, updated_at`
+
+type serviceCatalogRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewServiceCatalogRepository(db *postgres.DB, log *logger.Logger) *serviceCatalogRepository {
+	return &serviceCatalogRepository{
+		db:  db,
+		log: log.Named("service-catalog-repository"),
+	}
+}
+
+func (r *serviceCatalogRepository) Create(ctx context.Context, service *models.CatalogService) error {
+	query := `
+		INSERT INTO service_catalog (` + catalogServiceColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		service.ID(),
+		service.Name(),
+		categoryValue(service.Category()),
+		service.Website(),
+		service.IconURL(),
+		service.TypicalPrice(),
+		service.CreatedAt(),
+		service.UpdatedAt(),
+	)
+	if err != nil {
+		if err := catalogServiceConflict(err, service); err != nil {
+			return err
+		}
+
+		r.log.Error("failed to create catalog service",
+			zap.String("name", service.Name()),
+			zap.Error(err))
+		return dbError("create catalog service", err)
+	}
+
+	return nil
+}
+
+func (r *serviceCatalogRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.CatalogService, error) {
+	query := `SELECT ` + catalogServiceColumns + ` FROM service_catalog WHERE id = $1`
+
+	service, err := r.scanCatalogService(r.db.Conn(ctx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperror.NotFound("catalog service")
+		}
+		r.log.Error("failed to get catalog service",
+			zap.String("catalog_id", id.String()),
+			zap.Error(err))
+		return nil, dbError("get catalog service", err)
+	}
+
+	return service, nil
+}
+
+func (r *serviceCatalogRepository) List(ctx context.Context, limit, offset int) ([]*models.CatalogService, error) {
+	query := `
+		SELECT ` + catalogServiceColumns + `
+		FROM service_catalog
+		ORDER BY lower(name), id
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, limit, offset)
+	if err != nil {
+		r.log.Error("failed to list catalog services", zap.Error(err))
+		return nil, dbError("list catalog services", err)
+	}
+	defer rows.Close()
+
+	return r.scanCatalogServices(rows)
+}
+
+func (r *serviceCatalogRepository) Update(ctx context.Context, service *models.CatalogService) error {
+	query := `
+		UPDATE service_catalog
+		SET name = $2, category = $3, website = $4, icon_url = $5, typical_price = $6, updated_at = $7
+		WHERE id = $1`
+
+	tag, err := r.db.Conn(ctx).Exec(ctx, query,
+		service.ID(),
+		service.Name(),
+		categoryValue(service.Category()),
+		service.Website(),
+		service.IconURL(),
+		service.TypicalPrice(),
+		service.UpdatedAt(),
+	)
+	if err != nil {
+		if err := catalogServiceConflict(err, service); err != nil {
+			return err
+		}
+
+		r.log.Error("failed to update catalog service",
+			zap.String("catalog_id", service.ID().String()),
+			zap.Error(err))
+		return dbError("update catalog service", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("catalog service")
+	}
+
+	return nil
+}
+
+func (r *serviceCatalogRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `DELETE FROM service_catalog WHERE id = $1`, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return apperror.New(apperror.CodeConflict, "Catalog service has subscriptions").
+				WithDetail("catalog_id", id.String())
+		}
+
+		r.log.Error("failed to delete catalog service",
+			zap.String("catalog_id", id.String()),
+			zap.Error(err))
+		return dbError("delete catalog service", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("catalog service")
+	}
+
+	return nil
+}
+
+/*
+Suggest ищет подстроку в названии без учёта регистра. Сначала идут
+названия, начинающиеся с запроса, затем более короткие.
+*/
+func (r *serviceCatalogRepository) Suggest(ctx context.Context, query string, limit int) ([]*models.CatalogService, error) {
+	sql := `
+		SELECT ` + catalogServiceColumns + `
+		FROM service_catalog
+		WHERE name ILIKE ('%' || $1 || '%')
+		ORDER BY name ILIKE ($1 || '%') DESC, length(name), lower(name), id
+		LIMIT $2`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, sql, escapeLike(query), limit)
+	if err != nil {
+		r.log.Error("failed to suggest catalog services",
+			zap.String("query", query),
+			zap.Error(err))
+		return nil, dbError("suggest catalog services", err)
+	}
+	defer rows.Close()
+
+	return r.scanCatalogServices(rows)
+}
+
+func (r *serviceCatalogRepository) scanCatalogServices(rows pgx.Rows) ([]*models.CatalogService, error) {
+	services := make([]*models.CatalogService, 0)
+	for rows.Next() {
+		service, err := r.scanCatalogService(rows)
+		if err != nil {
+			return nil, dbError("scan catalog service", err)
+		}
+		services = append(services, service)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate catalog services", err)
+	}
+
+	return services, nil
+}
+
+func (r *serviceCatalogRepository) scanCatalogService(row pgx.Row) (*models.CatalogService, error) {
+	var (
+		id           uuid.UUID
+		name         string
+		category     *string
+		website      string
+		iconURL      string
+		typicalPrice *int
+		createdAt    time.Time
+		updatedAt    time.Time
+	)
+	if err := row.Scan(&id, &name, &category, &website, &iconURL, &typicalPrice, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var parsed *models.SubscriptionCategory
+	if category != nil {
+		value := models.SubscriptionCategory(*category)
+		parsed = &value
+	}
+
+	return models.RestoreCatalogService(id, name, parsed, website, iconURL, typicalPrice, createdAt, updatedAt), nil
+}
+
+// catalogServiceConflict переводит нарушение уникальности названия в CONFLICT.
+func catalogServiceConflict(err error, service *models.CatalogService) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return apperror.New(apperror.CodeConflict, "Catalog service already exists").
+			WithDetail("name", service.Name())
+	}
+	return nil
+}
+
+// escapeLike экранирует символы шаблона LIKE, чтобы запрос искался буквально.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
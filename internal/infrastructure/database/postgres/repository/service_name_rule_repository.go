@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type serviceNameRuleRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewServiceNameRuleRepository(db *postgres.DB, log *logger.Logger) *serviceNameRuleRepository {
+	return &serviceNameRuleRepository{
+		db:  db,
+		log: log.Named("service-name-rule-repository"),
+	}
+}
+
+func (r *serviceNameRuleRepository) Create(ctx context.Context, rule *models.ServiceNameRule) error {
+	query := `
+		INSERT INTO service_name_rules (id, list, match_type, pattern, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		rule.ID(),
+		string(rule.List()),
+		string(rule.Match()),
+		rule.Pattern(),
+		rule.Reason(),
+		rule.CreatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return apperror.New(apperror.CodeConflict, "Service name rule already exists").
+				WithDetail("list", string(rule.List())).
+				WithDetail("match", string(rule.Match())).
+				WithDetail("pattern", rule.Pattern())
+		}
+
+		r.log.Error("failed to create service name rule",
+			zap.String("pattern", rule.Pattern()),
+			zap.Error(err))
+		return dbError("create service name rule", err)
+	}
+
+	return nil
+}
+
+func (r *serviceNameRuleRepository) List(ctx context.Context) ([]*models.ServiceNameRule, error) {
+	query := `
+		SELECT id, list, match_type, pattern, reason, created_at
+		FROM service_name_rules
+		ORDER BY created_at, id`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query)
+	if err != nil {
+		r.log.Error("failed to list service name rules", zap.Error(err))
+		return nil, dbError("list service name rules", err)
+	}
+	defer rows.Close()
+
+	rules := make([]*models.ServiceNameRule, 0)
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			list      string
+			match     string
+			pattern   string
+			reason    string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&id, &list, &match, &pattern, &reason, &createdAt); err != nil {
+			return nil, dbError("scan service name rule", err)
+		}
+		rules = append(rules, models.RestoreServiceNameRule(
+			id,
+			models.ServiceNameList(list),
+			models.ServiceNameMatch(match),
+			pattern,
+			reason,
+			createdAt,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate service name rules", err)
+	}
+
+	return rules, nil
+}
+
+func (r *serviceNameRuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `DELETE FROM service_name_rules WHERE id = $1`, id)
+	if err != nil {
+		r.log.Error("failed to delete service name rule",
+			zap.String("rule_id", id.String()),
+			zap.Error(err))
+		return dbError("delete service name rule", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("service name rule")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type subscriptionCommentRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewSubscriptionCommentRepository(db *postgres.DB, log *logger.Logger) *subscriptionCommentRepository {
+	return &subscriptionCommentRepository{
+		db:  db,
+		log: log.Named("subscription-comment-repository"),
+	}
+}
+
+func (r *subscriptionCommentRepository) Create(ctx context.Context, comment *models.SubscriptionComment) error {
+	query := `
+		INSERT INTO subscription_comments (id, subscription_id, author, body, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		comment.ID(),
+		comment.SubscriptionID(),
+		comment.Author(),
+		comment.Body(),
+		comment.CreatedAt(),
+	)
+	if err != nil {
+		r.log.Error("failed to create subscription comment",
+			zap.String("subscription_id", comment.SubscriptionID().String()),
+			zap.Error(err))
+		return dbError("create subscription comment", err)
+	}
+
+	return nil
+}
+
+func (r *subscriptionCommentRepository) ListBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*models.SubscriptionComment, error) {
+	query := `
+		SELECT id, subscription_id, author, body, created_at
+		FROM subscription_comments
+		WHERE subscription_id = $1
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, subscriptionID, limit, offset)
+	if err != nil {
+		r.log.Error("failed to list subscription comments",
+			zap.String("subscription_id", subscriptionID.String()),
+			zap.Error(err))
+		return nil, dbError("list subscription comments", err)
+	}
+	defer rows.Close()
+
+	comments := make([]*models.SubscriptionComment, 0)
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			subID     uuid.UUID
+			author    string
+			body      string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&id, &subID, &author, &body, &createdAt); err != nil {
+			return nil, dbError("scan subscription comment", err)
+		}
+		comments = append(comments, models.RestoreSubscriptionComment(id, subID, author, body, createdAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate subscription comments", err)
+	}
+
+	return comments, nil
+}
+
+func (r *subscriptionCommentRepository) CountBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM subscription_comments WHERE subscription_id = $1`
+
+	var count int
+	if err := r.db.Conn(ctx).QueryRow(ctx, query, subscriptionID).Scan(&count); err != nil {
+		r.log.Error("failed to count subscription comments",
+			zap.String("subscription_id", subscriptionID.String()),
+			zap.Error(err))
+		return 0, dbError("count subscription comments", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+/*
+Свёртки monthly_cost_rollups (миграция 029) хранят помесячные изменения
+суммарной цены подписок без промокода и без участников. Сумма по ним
+совпадает с живым расчётом, только если каждый месяц периода оплачивается
+целиком текущей ценой: режим monthly, цены current, период из целых
+месяцев. Остальные подписки периода — с промокодом или общие — и любые
+другие запросы считаются по subscriptions, как раньше.
+*/
+
+// usesCostRollups сообщает, можно ли посчитать стоимость по свёрткам.
+func usesCostRollups(filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) bool {
+	return billing == models.BillingMonthly &&
+		pricing == models.PricingCurrent &&
+		period.IsWholeMonths() &&
+		!filter.HasTags() && !filter.HasCategory() && !filter.HasMetadata()
+}
+
+// rollupMonthIndexSQL нумерует месяцы колонки month (date, без часового
+// пояса) так же, как monthIndexSQL.
+const rollupMonthIndexSQL = "(EXTRACT(YEAR FROM r.month) * 12 + EXTRACT(MONTH FROM r.month))"
+
+/*
+totalCostFromRollups — GetTotalCostForPeriod по свёрткам: строка с
+изменением цены delta в месяце month добавляет delta за каждый месяц от
+max(month, начало периода) до конца периода. Подписки, которых нет в
+свёртках, досчитываются живым запросом с теми же условиями фильтра.
+*/
+func (r *subscriptionRepository) totalCostFromRollups(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange) (models.CostBreakdown, error) {
+	conditions, share, args := r.costFilterConditions(filter, []interface{}{period.From(), period.To()})
+
+	// Параметры фильтра идут в том же порядке, что в costFilterConditions.
+	// Типы $1 и $2 указаны явно: в свёртках их не с чем сравнить.
+	rollupConditions := []string{"r.month <= date_trunc('month', $2::timestamptz AT TIME ZONE 'UTC')::date"}
+	argIndex := 3
+	if filter.HasUserID() {
+		rollupConditions = append(rollupConditions, fmt.Sprintf("r.user_id = $%d", argIndex))
+		argIndex++
+	}
+	if filter.HasServiceName() {
+		rollupConditions = append(rollupConditions, fmt.Sprintf("r.service_name ILIKE $%d", argIndex))
+	}
+
+	conditions = append([]string{
+		periodOverlapSQL("s.", "$1", "$2"),
+		"NOT EXISTS (SELECT 1 FROM monthly_cost_rollup_subscriptions c WHERE c.subscription_id = s.id)",
+	}, conditions...)
+
+	query := fmt.Sprintf(`
+		WITH rolled AS (
+			SELECT COALESCE(SUM(r.price_delta * (%[1]s - GREATEST(%[2]s, %[3]s) + 1)), 0)::bigint AS cost
+			FROM monthly_cost_rollups r
+			WHERE %[4]s
+		), live AS (
+			SELECT COALESCE(SUM(%[5]s), 0) AS cost, COALESCE(SUM(%[6]s), 0) AS discount
+			FROM subscriptions s
+			LEFT JOIN discounts d ON d.id = s.discount_id
+			WHERE %[7]s
+		)
+		SELECT rolled.cost + live.cost, live.discount FROM rolled, live`,
+		monthIndexSQL("$2::timestamptz"),
+		rollupMonthIndexSQL,
+		monthIndexSQL("$1::timestamptz"),
+		strings.Join(rollupConditions, " AND "),
+		shareCostSQL(periodCostSQL("s.", "$1", "$2", models.BillingMonthly, models.PricingCurrent), share),
+		shareCostSQL(discountSQL("s.", "d.", "$1", "$2", models.BillingMonthly, models.PricingCurrent), share),
+		strings.Join(conditions, " AND "))
+
+	var totalCost, discount int
+	err := r.db.Conn(ctx).QueryRow(ctx, query, args...).Scan(&totalCost, &discount)
+	if err != nil {
+		r.log.Error("failed to get total cost from rollups", zap.Error(err))
+		return models.CostBreakdown{}, dbError("get total cost from rollups", err)
+	}
+
+	return models.NewCostBreakdown(totalCost, discount), nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+func TestUsesCostRollups(t *testing.T) {
+	userID := uuid.New()
+	byUser := models.NewSubscriptionFilter()
+	byUser.SetUserID(&userID)
+	byTags := models.NewSubscriptionFilter()
+	byTags.SetTags([]string{"video"})
+
+	months := models.NewDateRange(
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+	)
+	days := models.NewDateRange(
+		time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 30, 23, 59, 59, 0, time.UTC),
+	)
+	partialLastMonth := models.NewDateRange(
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 15, 23, 59, 59, 0, time.UTC),
+	)
+
+	cases := []struct {
+		name    string
+		filter  *models.SubscriptionFilter
+		period  models.DateRange
+		billing models.BillingMode
+		pricing models.PricingMode
+		want    bool
+	}{
+		{"whole months by user", byUser, months, models.BillingMonthly, models.PricingCurrent, true},
+		{"whole months, all users", models.NewSubscriptionFilter(), months, models.BillingMonthly, models.PricingCurrent, true},
+		{"prorated billing", byUser, months, models.BillingProrated, models.PricingCurrent, false},
+		{"historical pricing", byUser, months, models.BillingMonthly, models.PricingHistorical, false},
+		{"period starts mid-month", byUser, days, models.BillingMonthly, models.PricingCurrent, false},
+		{"period ends mid-month", byUser, partialLastMonth, models.BillingMonthly, models.PricingCurrent, false},
+		{"tags are not rolled up", byTags, months, models.BillingMonthly, models.PricingCurrent, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := usesCostRollups(tc.filter, tc.period, tc.billing, tc.pricing); got != tc.want {
+				t.Errorf("usesCostRollups() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/fieldcrypt"
+)
+
+/*
+Контекст шифрования привязывает шифротекст к подписке и полю: заметку
+одной подписки нельзя подставить в другую. Контекст слепого индекса
+подписку не включает — по нему ищут среди всех подписок.
+*/
+func NotesContext(id uuid.UUID) string {
+	return "subscription:" + id.String() + ":notes"
+}
+
+func MetadataContext(id uuid.UUID, key string) string {
+	return "subscription:" + id.String() + ":metadata:" + key
+}
+
+func MetadataDigestContext(key string) string {
+	return "subscription:metadata:" + key
+}
+
+/*
+SealSubscriptionFields шифрует заметку и значения метаданных (ключи
+остаются открытыми) и строит слепой индекс метаданных. Без cipher
+возвращает значения как есть и пустой индекс.
+*/
+func SealSubscriptionFields(cipher *fieldcrypt.Cipher, id uuid.UUID, notes string, metadata map[string]string) (string, map[string]string, map[string]string, error) {
+	digest := make(map[string]string)
+	if cipher == nil {
+		return notes, metadata, digest, nil
+	}
+
+	sealedNotes, err := cipher.Encrypt(notes, NotesContext(id))
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("encrypt notes: %w", err)
+	}
+
+	sealedMetadata := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		sealed, err := cipher.Encrypt(value, MetadataContext(id, key))
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("encrypt metadata %q: %w", key, err)
+		}
+		sealedMetadata[key] = sealed
+		digest[key] = cipher.Digest(value, MetadataDigestContext(key))
+	}
+
+	return sealedNotes, sealedMetadata, digest, nil
+}
+
+/*
+OpenSubscriptionFields расшифровывает заметку и метаданные. Открытые
+значения (записанные до включения шифрования) возвращаются как есть. Без
+cipher зашифрованная заметка или любое зашифрованное значение метаданных —
+ошибка: иначе шифротекст ушёл бы клиенту как текст.
+*/
+func OpenSubscriptionFields(cipher *fieldcrypt.Cipher, id uuid.UUID, notes string, metadata map[string]string) (string, map[string]string, error) {
+	if cipher == nil {
+		if fieldcrypt.IsEncrypted(notes) {
+			return "", nil, fmt.Errorf("subscription %s has encrypted notes but encryption is not configured", id)
+		}
+		for key, value := range metadata {
+			if fieldcrypt.IsEncrypted(value) {
+				return "", nil, fmt.Errorf("subscription %s has encrypted metadata %q but encryption is not configured", id, key)
+			}
+		}
+		return notes, metadata, nil
+	}
+
+	opened, err := cipher.Decrypt(notes, NotesContext(id))
+	if err != nil {
+		return "", nil, fmt.Errorf("decrypt notes: %w", err)
+	}
+
+	openedMetadata := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		plain, err := cipher.Decrypt(value, MetadataContext(id, key))
+		if err != nil {
+			return "", nil, fmt.Errorf("decrypt metadata %q: %w", key, err)
+		}
+		openedMetadata[key] = plain
+	}
+
+	return opened, openedMetadata, nil
+}
+
+/*
+metadataCondition строит фильтр по метаданным. При включённом шифровании
+зашифрованные строки сравниваются по слепому индексу, а записанные до
+шифрования — по открытым значениям.
+*/
+func (r *subscriptionRepository) metadataCondition(column string, argIndex int, metadata map[string]string) (string, []interface{}) {
+	return r.metadataClause(column, argIndex), r.metadataArgs(metadata)
+}
+
+// metadataClause — условие metadataCondition без значений; занимает
+// metadataArgCount параметров начиная с argIndex.
+func (r *subscriptionRepository) metadataClause(column string, argIndex int) string {
+	if r.cipher == nil {
+		return fmt.Sprintf("%smetadata @> $%d::jsonb", column, argIndex)
+	}
+	return fmt.Sprintf("(%[1]smetadata @> $%[2]d::jsonb OR %[1]smetadata_digest @> $%[3]d::jsonb)", column, argIndex, argIndex+1)
+}
+
+func (r *subscriptionRepository) metadataArgCount() int {
+	if r.cipher == nil {
+		return 1
+	}
+	return 2
+}
+
+func (r *subscriptionRepository) metadataArgs(metadata map[string]string) []interface{} {
+	if r.cipher == nil {
+		return []interface{}{metadata}
+	}
+
+	digest := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		digest[key] = r.cipher.Digest(value, MetadataDigestContext(key))
+	}
+	return []interface{}{metadata, digest}
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestOpenSubscriptionFields_EncryptedWithoutCipher(t *testing.T) {
+	const sealed = "enc:v1:2024-06:AAAA"
+
+	tests := []struct {
+		name     string
+		notes    string
+		metadata map[string]string
+		wantErr  bool
+	}{
+		{name: "plaintext", notes: "note", metadata: map[string]string{"team": "core"}},
+		{name: "encrypted notes", notes: sealed, wantErr: true},
+		{name: "encrypted metadata", notes: "note", metadata: map[string]string{"team": "core", "owner": sealed}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes, metadata, err := OpenSubscriptionFields(nil, uuid.New(), tt.notes, tt.metadata)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OpenSubscriptionFields() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && (notes != tt.notes || len(metadata) != len(tt.metadata)) {
+				t.Errorf("OpenSubscriptionFields() = %q, %v; want values unchanged", notes, metadata)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+const (
+	subscriptionEntityType = "subscription"
+	userEntityType         = "user"
+	subscriptionEventTopic = "subscriptions"
+)
+
+type subscriptionEventRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewSubscriptionEventRepository(db *postgres.DB, log *logger.Logger) *subscriptionEventRepository {
+	return &subscriptionEventRepository{
+		db:  db,
+		log: log.Named("subscription-event-repository"),
+	}
+}
+
+type subscriptionSnapshot struct {
+	ServiceName string     `json:"service_name"`
+	Price       int        `json:"price"`
+	Currency    string     `json:"currency"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	BillingDay  *int       `json:"billing_day,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type costAlertSnapshot struct {
+	AlertID      uuid.UUID `json:"alert_id"`
+	MonthlyLimit int       `json:"monthly_limit"`
+	Spent        int       `json:"spent"`
+	Month        time.Time `json:"month"`
+}
+
+type subscriptionEventPayload struct {
+	EventID        uuid.UUID             `json:"event_id"`
+	Type           string                `json:"type"`
+	SubscriptionID uuid.UUID             `json:"subscription_id"`
+	UserID         uuid.UUID             `json:"user_id"`
+	OccurredAt     time.Time             `json:"occurred_at"`
+	Subscription   *subscriptionSnapshot `json:"subscription,omitempty"`
+	// SubscriptionIDs и Count заполняются только у агрегированных событий.
+	SubscriptionIDs []uuid.UUID `json:"subscription_ids,omitempty"`
+	Count           int         `json:"count,omitempty"`
+	// CostAlert заполняется только у событий cost_alert.exceeded.
+	CostAlert *costAlertSnapshot `json:"cost_alert,omitempty"`
+}
+
+// auditEntity — сущность, к которой относится строка аудита: агрегированное
+// событие и событие о тратах записываются на пользователя, остальные — на
+// подписку.
+func (p subscriptionEventPayload) auditEntity() (string, uuid.UUID) {
+	if p.SubscriptionIDs != nil || p.CostAlert != nil {
+		return userEntityType, p.UserID
+	}
+	return subscriptionEntityType, p.SubscriptionID
+}
+
+/*
+Record пишет событие, аудит и outbox одним пакетом запросов: один поход в
+базу и неявная транзакция pgx вместо BEGIN, трёх INSERT и COMMIT. Если
+контекст уже несёт транзакцию (строгий режим), пакет выполняется в ней
+вместе с подпиской.
+*/
+func (r *subscriptionEventRepository) Record(ctx context.Context, event *models.SubscriptionEvent) error {
+	eventPayload, err := newSubscriptionEventPayload(event)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(eventPayload)
+	if err != nil {
+		return apperror.InternalError("marshal subscription event", err)
+	}
+	entityType, entityID := eventPayload.auditEntity()
+
+	batch := &pgx.Batch{}
+	batch.Queue(`
+		INSERT INTO subscription_events (id, event_type, subscription_id, user_id, payload, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		event.ID(), event.Type(), event.SubscriptionID(), event.UserID(), payload, event.OccurredAt())
+	batch.Queue(`
+		INSERT INTO audit_log (event_id, entity_type, entity_id, action, recorded_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		event.ID(), entityType, entityID, event.Type(), event.OccurredAt())
+	batch.Queue(`
+		INSERT INTO outbox (event_id, topic, payload, created_at)
+		VALUES ($1, $2, $3, $4)`,
+		event.ID(), subscriptionEventTopic, payload, event.OccurredAt())
+
+	err = r.db.ExecBatch(ctx, batch)
+	if err != nil {
+		r.log.Error("failed to record subscription event",
+			zap.String("event_id", event.ID().String()),
+			zap.String("event_type", event.Type()),
+			zap.String("subscription_id", event.SubscriptionID().String()),
+			zap.Error(err))
+		return dbError("record subscription event", err)
+	}
+
+	return nil
+}
+
+func (r *subscriptionEventRepository) MarkExpiryReminded(ctx context.Context, subscriptionID uuid.UUID, endDate time.Time, eventID uuid.UUID) (bool, error) {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `
+		INSERT INTO subscription_expiry_reminders (subscription_id, end_date, event_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (subscription_id, end_date) DO NOTHING`,
+		subscriptionID, endDate, eventID)
+	if err != nil {
+		r.log.Error("failed to mark expiry reminder",
+			zap.String("subscription_id", subscriptionID.String()),
+			zap.Error(err))
+		return false, dbError("mark expiry reminder", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func newSubscriptionEventPayload(event *models.SubscriptionEvent) (subscriptionEventPayload, error) {
+	payload := subscriptionEventPayload{
+		EventID:        event.ID(),
+		Type:           event.Type(),
+		SubscriptionID: event.SubscriptionID(),
+		UserID:         event.UserID(),
+		OccurredAt:     event.OccurredAt(),
+	}
+
+	if event.IsAggregate() {
+		payload.SubscriptionIDs = event.SubscriptionIDs()
+		payload.Count = len(event.SubscriptionIDs())
+	}
+
+	if status := event.CostAlert(); status != nil {
+		payload.CostAlert = &costAlertSnapshot{
+			AlertID:      status.Alert().ID(),
+			MonthlyLimit: status.Alert().MonthlyLimit(),
+			Spent:        status.Spent(),
+			Month:        status.Month(),
+		}
+	}
+
+	if sub := event.Subscription(); sub != nil {
+		price, err := sub.Price().WholeUnits()
+		if err != nil {
+			return subscriptionEventPayload{}, apperror.InternalError("marshal subscription event", err)
+		}
+		payload.Subscription = &subscriptionSnapshot{
+			ServiceName: sub.ServiceName(),
+			Price:       price,
+			Currency:    string(sub.Price().Currency()),
+			StartDate:   sub.StartDate(),
+			EndDate:     sub.EndDate(),
+			BillingDay:  sub.BillingDay(),
+			UpdatedAt:   sub.UpdatedAt(),
+		}
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+const subscriptionColumns = `id, service_name, price, user_id, start_date, end_date, discount_id, plan_id, catalog_id, tags, category, payment_method, billing_day, notes, metadata, created_at, updated_at`
+
+// filterShape — набор заданных полей фильтра. Текст запроса зависит только
+// от него, значения идут параметрами.
+type filterShape uint8
+
+const (
+	filterByUserID filterShape = 1 << iota
+	filterByServiceName
+	filterByTags
+	filterByCategory
+	filterByMetadata
+	filterByStartDate
+	filterByEndDate
+	// filterArchived — не условие, а таблица: subscriptions_archive.
+	filterArchived
+
+	filterShapeCount = int(filterArchived) << 1
+)
+
+/*
+filterQueries — тексты запросов списка и подсчёта для всех комбинаций
+фильтра, собранные при создании репозитория. Запрос с данным набором полей
+всегда получает один и тот же текст, поэтому кэш подготовленных выражений
+pgx (database.statement_cache_capacity) держит ограниченное число планов,
+а на каждый запрос не собирается строка.
+*/
+type filterQueries struct {
+	list          [filterShapeCount]string
+	listWithTotal [filterShapeCount]string
+	count         [filterShapeCount]string
+	// iterate — страница IterateAll после курсора (created_at, id).
+	iterate [filterShapeCount]string
+}
+
+func newFilterQueries(r *subscriptionRepository) *filterQueries {
+	q := &filterQueries{}
+	for shape := 0; shape < filterShapeCount; shape++ {
+		where, next := r.filterWhere(filterShape(shape))
+		table := "subscriptions"
+		if filterShape(shape)&filterArchived != 0 {
+			table = "subscriptions_archive"
+		}
+
+		q.list[shape] = fmt.Sprintf("SELECT %s FROM %s%s ORDER BY created_at DESC LIMIT $%d OFFSET $%d",
+			subscriptionColumns, table, where, next, next+1)
+		q.listWithTotal[shape] = fmt.Sprintf("SELECT COUNT(*) OVER (), %s FROM %s%s ORDER BY created_at DESC LIMIT $%d OFFSET $%d",
+			subscriptionColumns, table, where, next, next+1)
+		q.count[shape] = "SELECT COUNT(*) FROM " + table + where
+
+		cursor := fmt.Sprintf("(created_at, id) < ($%d, $%d)", next, next+1)
+		if where == "" {
+			cursor = " WHERE " + cursor
+		} else {
+			cursor = where + " AND " + cursor
+		}
+		q.iterate[shape] = fmt.Sprintf("SELECT %s FROM %s%s ORDER BY created_at DESC, id DESC LIMIT $%d",
+			subscriptionColumns, table, cursor, next+2)
+	}
+	return q
+}
+
+// filterWhere собирает WHERE для shape; next — номер следующего параметра.
+// Порядок условий совпадает с порядком аргументов в filterArgs.
+func (r *subscriptionRepository) filterWhere(shape filterShape) (string, int) {
+	conditions := []string{}
+	argIndex := 1
+
+	if shape&filterByUserID != 0 {
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIndex))
+		argIndex++
+	}
+	if shape&filterByServiceName != 0 {
+		conditions = append(conditions, fmt.Sprintf("service_name ILIKE $%d", argIndex))
+		argIndex++
+	}
+	if shape&filterByTags != 0 {
+		conditions = append(conditions, fmt.Sprintf("tags @> $%d", argIndex))
+		argIndex++
+	}
+	if shape&filterByCategory != 0 {
+		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
+		argIndex++
+	}
+	if shape&filterByMetadata != 0 {
+		conditions = append(conditions, r.metadataClause("", argIndex))
+		argIndex += r.metadataArgCount()
+	}
+	if shape&filterByStartDate != 0 {
+		conditions = append(conditions, fmt.Sprintf("start_date >= $%d", argIndex))
+		argIndex++
+	}
+	if shape&filterByEndDate != 0 {
+		conditions = append(conditions, fmt.Sprintf("(end_date IS NULL OR end_date <= $%d)", argIndex))
+		argIndex++
+	}
+
+	if len(conditions) == 0 {
+		return "", argIndex
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), argIndex
+}
+
+// filterArgs — набор полей фильтра и значения параметров для его запроса.
+func (r *subscriptionRepository) filterArgs(filter *models.SubscriptionFilter) (filterShape, []interface{}) {
+	var shape filterShape
+	args := make([]interface{}, 0, 8)
+
+	if filter.HasUserID() {
+		shape |= filterByUserID
+		args = append(args, *filter.UserID())
+	}
+	if filter.HasServiceName() {
+		shape |= filterByServiceName
+		args = append(args, "%"+*filter.ServiceName()+"%")
+	}
+	if filter.HasTags() {
+		shape |= filterByTags
+		args = append(args, filter.Tags())
+	}
+	if filter.HasCategory() {
+		shape |= filterByCategory
+		args = append(args, string(*filter.Category()))
+	}
+	if filter.HasMetadata() {
+		shape |= filterByMetadata
+		args = append(args, r.metadataArgs(filter.Metadata())...)
+	}
+	if filter.StartDate() != nil {
+		shape |= filterByStartDate
+		args = append(args, *filter.StartDate())
+	}
+	if filter.EndDate() != nil {
+		shape |= filterByEndDate
+		args = append(args, *filter.EndDate())
+	}
+	if filter.Archived() {
+		shape |= filterArchived
+	}
+
+	return shape, args
+}
+
+func (r *subscriptionRepository) buildFilterQuery(filter *models.SubscriptionFilter, limit, offset int) (string, []interface{}) {
+	shape, args := r.filterArgs(filter)
+	return r.filterQueries.list[shape], append(args, limit, offset)
+}
+
+func (r *subscriptionRepository) buildFilterQueryWithTotal(filter *models.SubscriptionFilter, limit, offset int) (string, []interface{}) {
+	shape, args := r.filterArgs(filter)
+	return r.filterQueries.listWithTotal[shape], append(args, limit, offset)
+}
+
+// buildIterateQuery — очередная страница IterateAll: строки строго после
+// курсора (createdAt, id) в порядке убывания.
+func (r *subscriptionRepository) buildIterateQuery(filter *models.SubscriptionFilter, createdAt time.Time, id uuid.UUID, limit int) (string, []interface{}) {
+	shape, args := r.filterArgs(filter)
+	return r.filterQueries.iterate[shape], append(args, createdAt, id, limit)
+}
+
+func (r *subscriptionRepository) buildCountQuery(filter *models.SubscriptionFilter) (string, []interface{}) {
+	shape, args := r.filterArgs(filter)
+	return r.filterQueries.count[shape], args
+}
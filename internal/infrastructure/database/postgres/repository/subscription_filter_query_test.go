@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+func benchmarkFilter() *models.SubscriptionFilter {
+	filter := &models.SubscriptionFilter{}
+	userID := uuid.New()
+	filter.SetUserID(&userID)
+	serviceName := "net"
+	filter.SetServiceName(&serviceName)
+	filter.SetTags([]string{"video"})
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter.SetStartDate(&startDate)
+	return filter
+}
+
+// Сборка запроса списка и подсчёта на один запрос к API.
+func BenchmarkBuildFilterQuery(b *testing.B) {
+	log, _ := logger.NewDefaultLogger()
+	r := NewSubscriptionRepository(nil, nil, log)
+	filter := benchmarkFilter()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.buildFilterQuery(filter, 20, 0)
+		r.buildCountQuery(filter)
+	}
+}
+
+/*
+BenchmarkGetAll сравнивает QPS списка подписок с кэшем подготовленных
+выражений и без него; база — как в openTestDB.
+*/
+func BenchmarkGetAll(b *testing.B) {
+	for _, bc := range []struct {
+		name     string
+		capacity int
+	}{
+		{"describe_exec", 0},
+		{"statement_cache", 512},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			db := openTestDB(b, bc.capacity)
+			log, _ := logger.NewDefaultLogger()
+			r := NewSubscriptionRepository(db, nil, log)
+			filter := benchmarkFilter()
+			ctx := context.Background()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := r.GetAll(ctx, filter, 20, 0); err != nil {
+						b.Error(err)
+						return
+					}
+					if _, err := r.Count(ctx, filter); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "qps")
+		})
+	}
+}
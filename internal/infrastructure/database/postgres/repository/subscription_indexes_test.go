@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+TestQueriesUseIndexes проверяет по EXPLAIN, что запросы репозитория
+попадают в индексы миграции 019. В тестовой базе мало строк, поэтому
+последовательное сканирование запрещается: планировщик выбирает индекс,
+если он вообще применим к условию. После секционирования (миграция 021)
+в плане видны индексы секций, унаследованные от индекса таблицы.
+*/
+func TestQueriesUseIndexes(t *testing.T) {
+	db := openTestDB(t, 0)
+	log, _ := logger.NewDefaultLogger()
+	r := NewSubscriptionRepository(db, nil, log)
+
+	userID := uuid.New()
+	serviceName := "netflix"
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	byUser := models.NewSubscriptionFilter()
+	byUser.SetUserID(&userID)
+	byServiceName := models.NewSubscriptionFilter()
+	byServiceName.SetServiceName(&serviceName)
+
+	listByUser, listByUserArgs := r.buildFilterQuery(byUser, 20, 0)
+	// Подсчёт, а не страница: для страницы с ORDER BY created_at планировщик
+	// вправе пройти индекс по created_at и отфильтровать строки.
+	countByServiceName, countByServiceNameArgs := r.buildCountQuery(byServiceName)
+
+	cases := []struct {
+		name    string
+		query   string
+		args    []interface{}
+		indexes []string
+	}{
+		{
+			name:    "list by user ordered by created_at",
+			query:   listByUser,
+			args:    listByUserArgs,
+			indexes: []string{"idx_subscriptions_user_created"},
+		},
+		{
+			name:    "service name substring",
+			query:   countByServiceName,
+			args:    countByServiceNameArgs,
+			indexes: []string{"idx_subscriptions_service_name_trgm"},
+		},
+		{
+			name:    "period overlap",
+			query:   "SELECT id FROM subscriptions WHERE " + periodOverlapSQL("", "$1", "$2"),
+			args:    []interface{}{from, to},
+			indexes: []string{"idx_subscriptions_period", "idx_subscriptions_open_ended", "idx_subscriptions_end_date"},
+		},
+		{
+			name:    "open-ended subscriptions",
+			query:   "SELECT COUNT(*) FROM subscriptions WHERE end_date IS NULL AND start_date <= $1",
+			args:    []interface{}{to},
+			indexes: []string{"idx_subscriptions_open_ended"},
+		},
+		{
+			name:    "service name variants",
+			query:   "SELECT COUNT(*) FROM subscriptions WHERE " + serviceNameKeySQL + " = ANY($1)",
+			args:    []interface{}{[]string{"netflix", "нетфликс"}},
+			indexes: []string{"idx_subscriptions_service_name_key"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := explain(t, r, tc.query, tc.args...)
+
+			if strings.Contains(plan, "Seq Scan") {
+				t.Fatalf("query falls back to a sequential scan:\n%s", plan)
+			}
+			for _, index := range tc.indexes {
+				for _, name := range partitionIndexes(t, r, index) {
+					if strings.Contains(plan, name) {
+						return
+					}
+				}
+			}
+			t.Fatalf("plan uses none of %v:\n%s", tc.indexes, plan)
+		})
+	}
+}
+
+// TestQueriesPrunePartitions проверяет, что запросы за период не читают
+// секции лет после его конца.
+func TestQueriesPrunePartitions(t *testing.T) {
+	db := openTestDB(t, 0)
+	log, _ := logger.NewDefaultLogger()
+	r := NewSubscriptionRepository(db, nil, log)
+
+	// Миграция создаёт секции текущего и следующего года.
+	year := time.Now().UTC().Year()
+	from := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, 12, 31, 23, 59, 59, 0, time.UTC)
+	current := fmt.Sprintf("subscriptions_y%d", year)
+	next := fmt.Sprintf("subscriptions_y%d", year+1)
+
+	cases := []struct {
+		name  string
+		query string
+		args  []interface{}
+	}{
+		{
+			name:  "period overlap",
+			query: "SELECT id FROM subscriptions s WHERE " + periodOverlapSQL("s.", "$1", "$2"),
+			args:  []interface{}{from, to},
+		},
+		{
+			name:  "ending within period",
+			query: "SELECT id FROM subscriptions WHERE end_date BETWEEN $1 AND $2 AND start_date <= $2",
+			args:  []interface{}{from, to},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := explain(t, r, tc.query, tc.args...)
+
+			if !strings.Contains(plan, current) {
+				t.Fatalf("plan does not read %s:\n%s", current, plan)
+			}
+			if strings.Contains(plan, next) {
+				t.Fatalf("plan reads %s past the period:\n%s", next, plan)
+			}
+		})
+	}
+}
+
+// partitionIndexes возвращает индекс таблицы и индексы секций, созданные по нему.
+func partitionIndexes(t *testing.T, r *subscriptionRepository, index string) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	rows, err := r.db.Pool().Query(ctx, `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = $1`, index)
+	if err != nil {
+		t.Fatalf("partition indexes: %v", err)
+	}
+	defer rows.Close()
+
+	names := []string{index}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatalf("scan partition index: %v", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("partition indexes: %v", err)
+	}
+	return names
+}
+
+func explain(t *testing.T, r *subscriptionRepository, query string, args ...interface{}) string {
+	t.Helper()
+	ctx := context.Background()
+
+	tx, err := r.db.Pool().Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+		t.Fatalf("disable seqscan: %v", err)
+	}
+
+	rows, err := tx.Query(ctx, "EXPLAIN "+query, args...)
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	defer rows.Close()
+
+	var plan strings.Builder
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			t.Fatalf("scan plan: %v", err)
+		}
+		plan.WriteString(line)
+		plan.WriteByte('\n')
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	return plan.String()
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type subscriptionLimitRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewSubscriptionLimitRepository(db *postgres.DB, log *logger.Logger) *subscriptionLimitRepository {
+	return &subscriptionLimitRepository{
+		db:  db,
+		log: log.Named("subscription-limit-repository"),
+	}
+}
+
+func (r *subscriptionLimitRepository) Save(ctx context.Context, limit *models.SubscriptionLimit) error {
+	query := `
+		INSERT INTO subscription_limits (user_id, max_subscriptions, reason, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+			SET max_subscriptions = EXCLUDED.max_subscriptions,
+				reason = EXCLUDED.reason,
+				updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		limit.UserID(),
+		limit.MaxSubscriptions(),
+		limit.Reason(),
+		limit.UpdatedAt(),
+	)
+	if err != nil {
+		r.log.Error("failed to save subscription limit",
+			zap.String("user_id", limit.UserID().String()),
+			zap.Error(err))
+		return dbError("save subscription limit", err)
+	}
+
+	return nil
+}
+
+func (r *subscriptionLimitRepository) GetByUser(ctx context.Context, userID uuid.UUID) (*models.SubscriptionLimit, error) {
+	query := `SELECT max_subscriptions, reason, updated_at FROM subscription_limits WHERE user_id = $1`
+
+	var (
+		maxSubscriptions int
+		reason           string
+		updatedAt        time.Time
+	)
+	if err := r.db.Conn(ctx).QueryRow(ctx, query, userID).Scan(&maxSubscriptions, &reason, &updatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		r.log.Error("failed to get subscription limit",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, dbError("get subscription limit", err)
+	}
+
+	return models.RestoreSubscriptionLimit(userID, maxSubscriptions, reason, updatedAt), nil
+}
+
+func (r *subscriptionLimitRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	tag, err := r.db.Conn(ctx).Exec(ctx, `DELETE FROM subscription_limits WHERE user_id = $1`, userID)
+	if err != nil {
+		r.log.Error("failed to delete subscription limit",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return dbError("delete subscription limit", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("subscription limit")
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type subscriptionMemberRepository struct {
+	db  *postgres.DB
+	log *logger.Logger
+}
+
+func NewSubscriptionMemberRepository(db *postgres.DB, log *logger.Logger) *subscriptionMemberRepository {
+	return &subscriptionMemberRepository{
+		db:  db,
+		log: log.Named("subscription-member-repository"),
+	}
+}
+
+/*
+Add блокирует строку подписки, чтобы параллельные добавления видели доли
+друг друга, и проверяет, что участник не владелец, ещё не состоит в
+подписке и что сумма долей не превысит 100%.
+*/
+func (r *subscriptionMemberRepository) Add(ctx context.Context, member *models.SubscriptionMember) error {
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := r.db.Conn(ctx)
+
+		// Блокировка подписки и подсчёт долей — один поход в базу.
+		var (
+			ownerID uuid.UUID
+			taken   int
+			exists  bool
+		)
+		batch := &pgx.Batch{}
+		batch.Queue(`SELECT user_id FROM subscriptions WHERE id = $1 FOR UPDATE`, member.SubscriptionID()).
+			QueryRow(func(row pgx.Row) error {
+				if err := row.Scan(&ownerID); err != nil {
+					if errors.Is(err, pgx.ErrNoRows) {
+						return apperror.SubscriptionNotFound(member.SubscriptionID().String())
+					}
+					return dbError("lock subscription", err)
+				}
+				return nil
+			})
+		batch.Queue(`
+			SELECT COALESCE(SUM(share_percent), 0), COALESCE(BOOL_OR(user_id = $2), false)
+			FROM subscription_members
+			WHERE subscription_id = $1`,
+			member.SubscriptionID(), member.UserID()).
+			QueryRow(func(row pgx.Row) error {
+				if err := row.Scan(&taken, &exists); err != nil {
+					return dbError("get subscription shares", err)
+				}
+				return nil
+			})
+		if err := r.db.ExecBatch(ctx, batch); err != nil {
+			return dbError("lock subscription", err)
+		}
+
+		if ownerID == member.UserID() {
+			return apperror.ValidationFailed("user_id", "the owner cannot be a member of their own subscription")
+		}
+		if exists {
+			return apperror.Conflict("subscription member", "user is already a member")
+		}
+		if taken+member.SharePercent() > 100 {
+			return apperror.Conflict("subscription member", "member shares would exceed 100%")
+		}
+
+		_, err := conn.Exec(ctx, `
+			INSERT INTO subscription_members (subscription_id, user_id, share_percent, created_at)
+			VALUES ($1, $2, $3, $4)`,
+			member.SubscriptionID(), member.UserID(), member.SharePercent(), member.CreatedAt())
+		if err != nil {
+			return dbError("add subscription member", err)
+		}
+		return nil
+	})
+	if err != nil {
+		r.log.Error("failed to add subscription member",
+			zap.String("subscription_id", member.SubscriptionID().String()),
+			zap.String("user_id", member.UserID().String()),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *subscriptionMemberRepository) ListBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID) ([]*models.SubscriptionMember, error) {
+	query := `
+		SELECT subscription_id, user_id, share_percent, created_at
+		FROM subscription_members
+		WHERE subscription_id = $1
+		ORDER BY created_at, user_id`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, subscriptionID)
+	if err != nil {
+		r.log.Error("failed to list subscription members",
+			zap.String("subscription_id", subscriptionID.String()),
+			zap.Error(err))
+		return nil, dbError("list subscription members", err)
+	}
+	defer rows.Close()
+
+	members := make([]*models.SubscriptionMember, 0)
+	for rows.Next() {
+		var (
+			memberSubscriptionID uuid.UUID
+			userID               uuid.UUID
+			sharePercent         int
+			createdAt            time.Time
+		)
+		if err := rows.Scan(&memberSubscriptionID, &userID, &sharePercent, &createdAt); err != nil {
+			return nil, dbError("scan subscription member", err)
+		}
+		members = append(members, models.RestoreSubscriptionMember(memberSubscriptionID, userID, sharePercent, createdAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate subscription members", err)
+	}
+
+	return members, nil
+}
+
+func (r *subscriptionMemberRepository) Remove(ctx context.Context, subscriptionID, userID uuid.UUID) error {
+	tag, err := r.db.Conn(ctx).Exec(ctx,
+		`DELETE FROM subscription_members WHERE subscription_id = $1 AND user_id = $2`,
+		subscriptionID, userID)
+	if err != nil {
+		r.log.Error("failed to remove subscription member",
+			zap.String("subscription_id", subscriptionID.String()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return dbError("remove subscription member", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound("subscription member")
+	}
+
+	return nil
+}
@@ -13,33 +13,60 @@ import (
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/fieldcrypt"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
 )
 
 type subscriptionRepository struct {
-	db  *postgres.DB
-	log *logger.Logger
+	db            *postgres.DB
+	cipher        *fieldcrypt.Cipher
+	filterQueries *filterQueries
+	log           *logger.Logger
 }
 
-func NewSubscriptionRepository(db *postgres.DB, log *logger.Logger) *subscriptionRepository {
-	return &subscriptionRepository{
-		db:  db,
-		log: log.Named("subscription-repository"),
+// cipher == nil — заметки и метаданные хранятся открытыми.
+func NewSubscriptionRepository(db *postgres.DB, cipher *fieldcrypt.Cipher, log *logger.Logger) *subscriptionRepository {
+	r := &subscriptionRepository{
+		db:     db,
+		cipher: cipher,
+		log:    log.Named("subscription-repository"),
 	}
+	r.filterQueries = newFilterQueries(r)
+	return r
 }
 
 func (r *subscriptionRepository) Create(ctx context.Context, subscription *models.Subscription) error {
 	query := `
-		INSERT INTO subscriptions (id, service_name, price, user_id, start_date, end_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		INSERT INTO subscriptions (id, service_name, price, user_id, start_date, end_date, discount_id, plan_id, catalog_id, tags, category, payment_method, billing_day, notes, metadata, metadata_digest, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
 
-	_, err := r.db.Pool().Exec(ctx, query,
+	price, err := priceValue(subscription.Price())
+	if err != nil {
+		return err
+	}
+
+	notes, metadata, digest, err := SealSubscriptionFields(r.cipher, subscription.ID(), subscription.Notes(), subscription.Metadata())
+	if err != nil {
+		return apperror.InternalError("encrypt subscription", err)
+	}
+
+	_, err = r.db.Conn(ctx).Exec(ctx, query,
 		subscription.ID(),
 		subscription.ServiceName(),
-		subscription.Price(),
+		price,
 		subscription.UserID(),
 		subscription.StartDate(),
 		subscription.EndDate(),
+		subscription.DiscountID(),
+		subscription.PlanID(),
+		subscription.CatalogID(),
+		subscription.Tags(),
+		categoryValue(subscription.Category()),
+		subscription.PaymentMethod(),
+		subscription.BillingDay(),
+		notes,
+		metadata,
+		digest,
 		subscription.CreatedAt(),
 		subscription.UpdatedAt(),
 	)
@@ -48,7 +75,7 @@ func (r *subscriptionRepository) Create(ctx context.Context, subscription *model
 		r.log.Error("failed to create subscription",
 			zap.String("subscription_id", subscription.ID().String()),
 			zap.Error(err))
-		return apperror.DatabaseError("create subscription", err)
+		return dbError("create subscription", err)
 	}
 
 	r.log.Debug("subscription created",
@@ -60,11 +87,11 @@ func (r *subscriptionRepository) Create(ctx context.Context, subscription *model
 
 func (r *subscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
 	query := `
-		SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+		SELECT id, service_name, price, user_id, start_date, end_date, discount_id, plan_id, catalog_id, tags, category, payment_method, billing_day, notes, metadata, created_at, updated_at
 		FROM subscriptions 
 		WHERE id = $1`
 
-	row := r.db.Pool().QueryRow(ctx, query, id)
+	row := r.db.Conn(ctx).QueryRow(ctx, query, id)
 
 	subscription, err := r.scanSubscription(row)
 	if err != nil {
@@ -74,7 +101,7 @@ func (r *subscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 		r.log.Error("failed to get subscription by id",
 			zap.String("subscription_id", id.String()),
 			zap.Error(err))
-		return nil, apperror.DatabaseError("get subscription by id", err)
+		return nil, dbError("get subscription by id", err)
 	}
 
 	return subscription, nil
@@ -82,18 +109,18 @@ func (r *subscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*mo
 
 func (r *subscriptionRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Subscription, error) {
 	query := `
-		SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+		SELECT id, service_name, price, user_id, start_date, end_date, discount_id, plan_id, catalog_id, tags, category, payment_method, billing_day, notes, metadata, created_at, updated_at
 		FROM subscriptions 
 		WHERE user_id = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.Pool().Query(ctx, query, userID, limit, offset)
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID, limit, offset)
 	if err != nil {
 		r.log.Error("failed to get subscriptions by user id",
 			zap.String("user_id", userID.String()),
 			zap.Error(err))
-		return nil, fmt.Errorf("get subscriptions by user id: %w", err)
+		return nil, dbError("get subscriptions by user id", err)
 	}
 	defer rows.Close()
 
@@ -103,29 +130,187 @@ func (r *subscriptionRepository) GetByUserID(ctx context.Context, userID uuid.UU
 func (r *subscriptionRepository) GetAll(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) ([]*models.Subscription, error) {
 	query, args := r.buildFilterQuery(filter, limit, offset)
 
-	rows, err := r.db.Pool().Query(ctx, query, args...)
+	rows, err := r.db.Conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		r.log.Error("failed to get filtered subscriptions", zap.Error(err))
-		return nil, fmt.Errorf("get filtered subscriptions: %w", err)
+		return nil, dbError("get filtered subscriptions", err)
 	}
 	defer rows.Close()
 
 	return r.scanSubscriptions(rows)
 }
 
+/*
+GetAllWithTotal — страница подписок и общее число подходящих под фильтр
+одним запросом: COUNT(*) OVER () считается до LIMIT. Если страница пуста
+(offset за концом выборки), считать не по чему, и total берётся отдельным
+Count.
+*/
+func (r *subscriptionRepository) GetAllWithTotal(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) ([]*models.Subscription, int, error) {
+	query, args := r.buildFilterQueryWithTotal(filter, limit, offset)
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		r.log.Error("failed to get filtered subscriptions with total", zap.Error(err))
+		return nil, 0, dbError("get filtered subscriptions with total", err)
+	}
+	defer rows.Close()
+
+	var total int
+	subscriptions := make([]*models.Subscription, 0)
+	for rows.Next() {
+		subscription, err := r.scanSubscriptionWithPrefix(rows, &total)
+		if err != nil {
+			return nil, 0, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if len(subscriptions) == 0 && offset > 0 {
+		total, err = r.Count(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return subscriptions, total, nil
+}
+
+// iterateBatchSize — строк в одном запросе IterateAll.
+const iterateBatchSize = 1000
+
+/*
+IterateAll передаёт fn все подписки под фильтром, от новых к старым, не
+собирая их в один срез. Строки читаются страницами по курсору (created_at,
+id): fn вызывается между запросами, поэтому медленный получатель не держит
+соединение и не упирается в statement_timeout. Подписки, созданные во
+время обхода, в него не попадают. Ошибка fn прерывает обход и
+возвращается как есть.
+*/
+func (r *subscriptionRepository) IterateAll(ctx context.Context, filter *models.SubscriptionFilter, fn func(*models.Subscription) error) error {
+	// Курсор первой страницы — позже любой записи.
+	cursorAt := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	cursorID := uuid.Max
+
+	batch := make([]*models.Subscription, 0, iterateBatchSize)
+	for {
+		query, args := r.buildIterateQuery(filter, cursorAt, cursorID, iterateBatchSize)
+
+		rows, err := r.db.Conn(ctx).Query(ctx, query, args...)
+		if err != nil {
+			r.log.Error("failed to iterate subscriptions", zap.Error(err))
+			return dbError("iterate subscriptions", err)
+		}
+
+		batch = batch[:0]
+		for rows.Next() {
+			subscription, err := r.scanSubscription(rows)
+			if err != nil {
+				rows.Close()
+				return dbError("iterate subscriptions", err)
+			}
+			batch = append(batch, subscription)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return dbError("iterate subscriptions", err)
+		}
+
+		// Обработка страницы может быть долгой (экспорт, рассылки): отмену
+		// ctx проверяем между подписками, а не только на следующем запросе.
+		for _, subscription := range batch {
+			if err := ctx.Err(); err != nil {
+				return dbError("iterate subscriptions", err)
+			}
+			if err := fn(subscription); err != nil {
+				return err
+			}
+		}
+
+		if len(batch) < iterateBatchSize {
+			return nil
+		}
+		last := batch[len(batch)-1]
+		cursorAt, cursorID = last.CreatedAt(), last.ID()
+	}
+}
+
+/*
+Search находит подписки по search_text (название, теги и заметка):
+полнотекстово и по триграммам, чтобы находились слова с опечатками.
+Ранг — лучшая из двух оценок.
+*/
+func (r *subscriptionRepository) Search(ctx context.Context, query models.SearchQuery, userID *uuid.UUID, limit, offset int) ([]*models.SubscriptionSearchHit, error) {
+	sql := `
+		SELECT GREATEST(
+				ts_rank(to_tsvector('simple', s.search_text), websearch_to_tsquery('simple', $1)),
+				word_similarity($1, s.search_text)
+			) AS rank,
+			s.id, s.service_name, s.price, s.user_id, s.start_date, s.end_date, s.discount_id, s.plan_id, s.catalog_id, s.tags, s.category, s.payment_method, s.billing_day, s.notes, s.metadata, s.created_at, s.updated_at
+		FROM subscriptions s
+		WHERE (to_tsvector('simple', s.search_text) @@ websearch_to_tsquery('simple', $1) OR $1 <% s.search_text)
+			AND ($2::uuid IS NULL OR s.user_id = $2)
+		ORDER BY rank DESC, s.created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, sql, strings.ToLower(query.Text()), userID, limit, offset)
+	if err != nil {
+		r.log.Error("failed to search subscriptions", zap.Error(err))
+		return nil, dbError("search subscriptions", err)
+	}
+	defer rows.Close()
+
+	hits := make([]*models.SubscriptionSearchHit, 0)
+	for rows.Next() {
+		var rank float64
+		subscription, err := r.scanSubscriptionWithPrefix(rows, &rank)
+		if err != nil {
+			return nil, dbError("scan search results", err)
+		}
+		hits = append(hits, models.NewSubscriptionSearchHit(subscription, rank))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate search results", err)
+	}
+
+	return hits, nil
+}
+
 func (r *subscriptionRepository) Update(ctx context.Context, subscription *models.Subscription) error {
 	query := `
 		UPDATE subscriptions 
-		SET service_name = $2, price = $3, user_id = $4, start_date = $5, end_date = $6, updated_at = $7
+		SET service_name = $2, price = $3, user_id = $4, start_date = $5, end_date = $6, catalog_id = $7, tags = $8, category = $9, payment_method = $10, billing_day = $11, notes = $12, metadata = $13, metadata_digest = $14, updated_at = $15
 		WHERE id = $1`
 
-	result, err := r.db.Pool().Exec(ctx, query,
+	price, err := priceValue(subscription.Price())
+	if err != nil {
+		return err
+	}
+
+	notes, metadata, digest, err := SealSubscriptionFields(r.cipher, subscription.ID(), subscription.Notes(), subscription.Metadata())
+	if err != nil {
+		return apperror.InternalError("encrypt subscription", err)
+	}
+
+	result, err := r.db.Conn(ctx).Exec(ctx, query,
 		subscription.ID(),
 		subscription.ServiceName(),
-		subscription.Price(),
+		price,
 		subscription.UserID(),
 		subscription.StartDate(),
 		subscription.EndDate(),
+		subscription.CatalogID(),
+		subscription.Tags(),
+		categoryValue(subscription.Category()),
+		subscription.PaymentMethod(),
+		subscription.BillingDay(),
+		notes,
+		metadata,
+		digest,
 		subscription.UpdatedAt(),
 	)
 
@@ -133,11 +318,11 @@ func (r *subscriptionRepository) Update(ctx context.Context, subscription *model
 		r.log.Error("failed to update subscription",
 			zap.String("subscription_id", subscription.ID().String()),
 			zap.Error(err))
-		return fmt.Errorf("update subscription: %w", err)
+		return dbError("update subscription", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("subscription not found")
+		return apperror.SubscriptionNotFound(subscription.ID().String())
 	}
 
 	r.log.Debug("subscription updated",
@@ -149,16 +334,16 @@ func (r *subscriptionRepository) Update(ctx context.Context, subscription *model
 func (r *subscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM subscriptions WHERE id = $1`
 
-	result, err := r.db.Pool().Exec(ctx, query, id)
+	result, err := r.db.Conn(ctx).Exec(ctx, query, id)
 	if err != nil {
 		r.log.Error("failed to delete subscription",
 			zap.String("subscription_id", id.String()),
 			zap.Error(err))
-		return fmt.Errorf("delete subscription: %w", err)
+		return dbError("delete subscription", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("subscription not found")
+		return apperror.SubscriptionNotFound(id.String())
 	}
 
 	r.log.Debug("subscription deleted",
@@ -167,72 +352,875 @@ func (r *subscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
-func (r *subscriptionRepository) GetTotalCostForPeriod(ctx context.Context, filter *models.SubscriptionFilter, period *models.DatePeriod) (int, error) {
-	baseQuery := `
-		SELECT COALESCE(SUM(price), 0) as total_cost
-		FROM subscriptions
-		WHERE start_date <= $1 AND (end_date IS NULL OR end_date >= $2)`
+/*
+DeleteByUserID удаляет все подписки пользователя, текущие и архивные, одним
+запросом и возвращает их ID; комментарии, история цен и напоминания
+удаляются каскадно. Архив тоже хранит user_id, заметки и метаданные, так
+что удаление аккаунта без него было бы неполным.
+*/
+func (r *subscriptionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		WITH current AS (
+			DELETE FROM subscriptions WHERE user_id = $1 RETURNING id
+		), archived AS (
+			DELETE FROM subscriptions_archive WHERE user_id = $1 RETURNING id
+		)
+		SELECT id FROM current
+		UNION ALL
+		SELECT id FROM archived`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID)
+	if err != nil {
+		r.log.Error("failed to delete user subscriptions",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, dbError("delete user subscriptions", err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, dbError("scan deleted subscription", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("delete user subscriptions", err)
+	}
+
+	r.log.Debug("user subscriptions deleted",
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(ids)))
+
+	return ids, nil
+}
+
+// GetTotalCostForPeriod считает по свёрткам, когда это даёт тот же
+// результат (см. usesCostRollups), иначе — по subscriptions.
+func (r *subscriptionRepository) GetTotalCostForPeriod(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) (models.CostBreakdown, error) {
+	if usesCostRollups(filter, period, billing, pricing) {
+		return r.totalCostFromRollups(ctx, filter, period)
+	}
+
+	conditions, share, args := r.costFilterConditions(filter, []interface{}{period.From(), period.To()})
+
+	query := fmt.Sprintf(`
+		SELECT COALESCE(SUM(%s), 0) as total_cost, COALESCE(SUM(%s), 0) as discount
+		FROM subscriptions s
+		LEFT JOIN discounts d ON d.id = s.discount_id
+		WHERE %s`,
+		shareCostSQL(periodCostSQL("s.", "$1", "$2", billing, pricing), share),
+		shareCostSQL(discountSQL("s.", "d.", "$1", "$2", billing, pricing), share),
+		periodOverlapSQL("s.", "$1", "$2"))
+
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+
+	var totalCost, discount int
+	err := r.db.Conn(ctx).QueryRow(ctx, query, args...).Scan(&totalCost, &discount)
+	if err != nil {
+		r.log.Error("failed to get total cost for period", zap.Error(err))
+		return models.CostBreakdown{}, dbError("get total cost for period", err)
+	}
+
+	return models.NewCostBreakdown(totalCost, discount), nil
+}
+
+func (r *subscriptionRepository) GetCostByCategory(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) ([]*models.CategoryCost, error) {
+	conditions, share, args := r.costFilterConditions(filter, []interface{}{period.From(), period.To()})
+	conditions = append([]string{periodOverlapSQL("s.", "$1", "$2")}, conditions...)
+
+	query := fmt.Sprintf(`
+		SELECT COALESCE(s.category, '%s'), COALESCE(SUM(%s), 0), COALESCE(SUM(%s), 0), COUNT(*)
+		FROM subscriptions s
+		LEFT JOIN discounts d ON d.id = s.discount_id
+		WHERE %s
+		GROUP BY 1`,
+		models.CategoryUncategorized,
+		shareCostSQL(periodCostSQL("s.", "$1", "$2", billing, pricing), share),
+		shareCostSQL(discountSQL("s.", "d.", "$1", "$2", billing, pricing), share),
+		strings.Join(conditions, " AND "))
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		r.log.Error("failed to get cost by category", zap.Error(err))
+		return nil, dbError("get cost by category", err)
+	}
+	defer rows.Close()
+
+	costs := make([]*models.CategoryCost, 0)
+	for rows.Next() {
+		var (
+			category      string
+			gross         int
+			discount      int
+			subscriptions int
+		)
+		if err := rows.Scan(&category, &gross, &discount, &subscriptions); err != nil {
+			return nil, dbError("scan cost by category", err)
+		}
+		costs = append(costs, models.NewCategoryCost(models.SubscriptionCategory(category), models.NewCostBreakdown(gross, discount), subscriptions))
+	}
 
-	args := []interface{}{period.To(), period.From()}
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate cost by category", err)
+	}
+
+	return costs, nil
+}
+
+func (r *subscriptionRepository) GetCostByPaymentMethod(ctx context.Context, filter *models.SubscriptionFilter, period models.DateRange, billing models.BillingMode, pricing models.PricingMode) ([]*models.PaymentMethodCost, error) {
+	conditions, share, args := r.costFilterConditions(filter, []interface{}{period.From(), period.To()})
+	conditions = append([]string{periodOverlapSQL("s.", "$1", "$2")}, conditions...)
+
+	query := fmt.Sprintf(`
+		SELECT s.payment_method, COALESCE(SUM(%s), 0), COALESCE(SUM(%s), 0), COUNT(*)
+		FROM subscriptions s
+		LEFT JOIN discounts d ON d.id = s.discount_id
+		WHERE %s
+		GROUP BY 1`,
+		shareCostSQL(periodCostSQL("s.", "$1", "$2", billing, pricing), share),
+		shareCostSQL(discountSQL("s.", "d.", "$1", "$2", billing, pricing), share),
+		strings.Join(conditions, " AND "))
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		r.log.Error("failed to get cost by payment method", zap.Error(err))
+		return nil, dbError("get cost by payment method", err)
+	}
+	defer rows.Close()
+
+	costs := make([]*models.PaymentMethodCost, 0)
+	for rows.Next() {
+		var (
+			paymentMethod *string
+			gross         int
+			discount      int
+			subscriptions int
+		)
+		if err := rows.Scan(&paymentMethod, &gross, &discount, &subscriptions); err != nil {
+			return nil, dbError("scan cost by payment method", err)
+		}
+		costs = append(costs, models.NewPaymentMethodCost(paymentMethod, models.NewCostBreakdown(gross, discount), subscriptions))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate cost by payment method", err)
+	}
+
+	return costs, nil
+}
+
+/*
+costFilterConditions добавляет к args параметры фильтра и возвращает
+условия для запросов по subscriptions с псевдонимом s. С фильтром по
+пользователю в выборку попадают и общие подписки, где он участник, а share —
+его доля в процентах (см. shareCostSQL); без фильтра share пустая.
+*/
+func (r *subscriptionRepository) costFilterConditions(filter *models.SubscriptionFilter, args []interface{}) ([]string, string, []interface{}) {
 	conditions := []string{}
-	argIndex := 3
+	share := ""
+	argIndex := len(args) + 1
 
 	if filter.HasUserID() {
-		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIndex))
+		user := fmt.Sprintf("$%d", argIndex)
+		conditions = append(conditions, fmt.Sprintf(`(s.user_id = %[1]s OR EXISTS (
+			SELECT 1 FROM subscription_members m WHERE m.subscription_id = s.id AND m.user_id = %[1]s))`, user))
+		share = userShareSQL("s.", user)
 		args = append(args, *filter.UserID())
 		argIndex++
 	}
 
 	if filter.HasServiceName() {
-		conditions = append(conditions, fmt.Sprintf("service_name ILIKE $%d", argIndex))
+		conditions = append(conditions, fmt.Sprintf("s.service_name ILIKE $%d", argIndex))
 		args = append(args, "%"+*filter.ServiceName()+"%")
 		argIndex++
 	}
 
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " AND " + strings.Join(conditions, " AND ")
+	if filter.HasTags() {
+		conditions = append(conditions, fmt.Sprintf("s.tags @> $%d", argIndex))
+		args = append(args, filter.Tags())
+		argIndex++
 	}
 
-	var totalCost int
-	err := r.db.Pool().QueryRow(ctx, query, args...).Scan(&totalCost)
-	if err != nil {
-		r.log.Error("failed to get total cost for period", zap.Error(err))
-		return 0, fmt.Errorf("get total cost for period: %w", err)
+	if filter.HasCategory() {
+		conditions = append(conditions, fmt.Sprintf("s.category = $%d", argIndex))
+		args = append(args, string(*filter.Category()))
+		argIndex++
 	}
 
-	return totalCost, nil
+	if filter.HasMetadata() {
+		condition, metadataArgs := r.metadataCondition("s.", argIndex, filter.Metadata())
+		conditions = append(conditions, condition)
+		args = append(args, metadataArgs...)
+	}
+
+	return conditions, share, args
+}
+
+// userShareSQL — доля пользователя user в подписке, в процентах: у владельца
+// всё, что не разобрали участники, у участника — его share_percent.
+func userShareSQL(prefix, user string) string {
+	return fmt.Sprintf(`CASE WHEN %[1]suser_id = %[2]s
+		THEN 100 - COALESCE((SELECT SUM(m.share_percent) FROM subscription_members m WHERE m.subscription_id = %[1]sid), 0)
+		ELSE (SELECT m.share_percent FROM subscription_members m WHERE m.subscription_id = %[1]sid AND m.user_id = %[2]s)
+	END`, prefix, user)
+}
+
+// shareCostSQL берёт от стоимости cost долю share (округление до рубля по
+// каждой подписке); без доли стоимость не меняется.
+func shareCostSQL(cost, share string) string {
+	if share == "" {
+		return cost
+	}
+	return fmt.Sprintf("ROUND((%s) * (%s) / 100.0)::int", cost, share)
 }
 
 func (r *subscriptionRepository) Count(ctx context.Context, filter *models.SubscriptionFilter) (int, error) {
 	query, args := r.buildCountQuery(filter)
 
 	var count int
-	err := r.db.Pool().QueryRow(ctx, query, args...).Scan(&count)
+	err := r.db.Conn(ctx).QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		r.log.Error("failed to count subscriptions", zap.Error(err))
-		return 0, fmt.Errorf("count subscriptions: %w", err)
+		return 0, dbError("count subscriptions", err)
 	}
 
 	return count, nil
 }
 
+/*
+GetUserStats считает сводку по подпискам пользователя одним запросом:
+счётчики по статусам через FILTER, самая дорогая активная и ближайшая
+к окончанию подписки — первыми элементами упорядоченных array_agg.
+*/
+func (r *subscriptionRepository) GetUserStats(ctx context.Context, userID uuid.UUID, at time.Time) (*models.UserSubscriptionStats, error) {
+	query := `
+		WITH s AS (
+			SELECT id, service_name, price, start_date, end_date, created_at,
+				start_date <= $2 AND (end_date IS NULL OR end_date >= $2) AS active
+			FROM subscriptions
+			WHERE user_id = $1
+		)
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE active),
+			COUNT(*) FILTER (WHERE end_date < $2),
+			COUNT(*) FILTER (WHERE start_date > $2),
+			COALESCE(SUM(price) FILTER (WHERE active), 0),
+			COALESCE(ROUND(AVG(price), 2), 0)::float8,
+			(array_agg(id ORDER BY price DESC, created_at) FILTER (WHERE active))[1],
+			(array_agg(service_name ORDER BY price DESC, created_at) FILTER (WHERE active))[1],
+			MAX(price) FILTER (WHERE active),
+			(array_agg(id ORDER BY end_date, price DESC) FILTER (WHERE end_date >= $2))[1],
+			(array_agg(service_name ORDER BY end_date, price DESC) FILTER (WHERE end_date >= $2))[1],
+			(array_agg(price ORDER BY end_date, price DESC) FILTER (WHERE end_date >= $2))[1],
+			MIN(end_date) FILTER (WHERE end_date >= $2)
+		FROM s`
+
+	var (
+		total, active, expired, upcoming int
+		monthlySpend                     int
+		averagePrice                     float64
+		expensiveID                      *uuid.UUID
+		expensiveName                    *string
+		expensivePrice                   *int
+		endingID                         *uuid.UUID
+		endingName                       *string
+		endingPrice                      *int
+		endingDate                       *time.Time
+	)
+
+	err := r.db.Conn(ctx).QueryRow(ctx, query, userID, at).Scan(
+		&total, &active, &expired, &upcoming,
+		&monthlySpend, &averagePrice,
+		&expensiveID, &expensiveName, &expensivePrice,
+		&endingID, &endingName, &endingPrice, &endingDate,
+	)
+	if err != nil {
+		r.log.Error("failed to get user stats",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, dbError("get user stats", err)
+	}
+
+	stats := models.NewUserSubscriptionStats(userID, at)
+	stats.SetCounts(total, active, expired, upcoming)
+	stats.SetSpend(monthlySpend, averagePrice)
+	if expensiveID != nil {
+		stats.SetMostExpensive(models.NewSubscriptionRef(*expensiveID, *expensiveName, models.Rubles(*expensivePrice), nil))
+	}
+	if endingID != nil {
+		stats.SetNextEnding(models.NewSubscriptionRef(*endingID, *endingName, models.Rubles(*endingPrice), endingDate))
+	}
+
+	return stats, nil
+}
+
+func (r *subscriptionRepository) GetExpiring(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*models.Subscription, error) {
+	query := `
+		SELECT id, service_name, price, user_id, start_date, end_date, discount_id, plan_id, catalog_id, tags, category, payment_method, billing_day, notes, metadata, created_at, updated_at
+		FROM subscriptions
+		WHERE user_id = $1 AND end_date BETWEEN $2 AND $3
+			AND start_date <= $3
+		ORDER BY end_date, service_name`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID, from, to)
+	if err != nil {
+		r.log.Error("failed to get expiring subscriptions",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, dbError("get expiring subscriptions", err)
+	}
+	defer rows.Close()
+
+	return r.scanSubscriptions(rows)
+}
+
+/*
+FindDuplicates — пары подписок пользователя, действующих одновременно, с
+одинаковым названием сервиса или с триграммным сходством названий не ниже
+minSimilarity. Сначала одинаковые названия, затем по убыванию сходства.
+*/
+func (r *subscriptionRepository) FindDuplicates(ctx context.Context, userID uuid.UUID, minSimilarity float64) ([]*models.SubscriptionDuplicate, error) {
+	query := `
+		SELECT a.id, b.id, similarity(lower(a.service_name), lower(b.service_name)) AS score
+		FROM subscriptions a
+		JOIN subscriptions b ON b.user_id = a.user_id AND (a.created_at, a.id) < (b.created_at, b.id)
+		WHERE a.user_id = $1
+			AND a.start_date <= COALESCE(b.end_date, 'infinity')
+			AND b.start_date <= COALESCE(a.end_date, 'infinity')
+			AND (lower(a.service_name) = lower(b.service_name)
+				OR similarity(lower(a.service_name), lower(b.service_name)) >= $2)
+		ORDER BY lower(a.service_name) = lower(b.service_name) DESC, score DESC, a.created_at, b.created_at`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID, minSimilarity)
+	if err != nil {
+		r.log.Error("failed to find duplicate subscriptions",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return nil, dbError("find duplicate subscriptions", err)
+	}
+
+	type pair struct {
+		first, second uuid.UUID
+		score         float64
+	}
+	pairs := make([]pair, 0)
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var p pair
+		if err := rows.Scan(&p.first, &p.second, &p.score); err != nil {
+			rows.Close()
+			return nil, dbError("scan duplicate subscriptions", err)
+		}
+		pairs = append(pairs, p)
+		ids = append(ids, p.first, p.second)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate duplicate subscriptions", err)
+	}
+	if len(pairs) == 0 {
+		return []*models.SubscriptionDuplicate{}, nil
+	}
+
+	rows, err = r.db.Conn(ctx).Query(ctx, `
+		SELECT id, service_name, price, user_id, start_date, end_date, discount_id, plan_id, catalog_id, tags, category, payment_method, billing_day, notes, metadata, created_at, updated_at
+		FROM subscriptions
+		WHERE user_id = $1 AND id = ANY($2)`, userID, ids)
+	if err != nil {
+		return nil, dbError("get duplicate subscriptions", err)
+	}
+	defer rows.Close()
+
+	subscriptions, err := r.scanSubscriptions(rows)
+	if err != nil {
+		return nil, dbError("scan duplicate subscriptions", err)
+	}
+	byID := make(map[uuid.UUID]*models.Subscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		byID[subscription.ID()] = subscription
+	}
+
+	duplicates := make([]*models.SubscriptionDuplicate, 0, len(pairs))
+	for _, p := range pairs {
+		first, second := byID[p.first], byID[p.second]
+		// Подписку могли удалить между запросами.
+		if first == nil || second == nil {
+			continue
+		}
+		duplicates = append(duplicates, models.NewSubscriptionDuplicate(first, second, p.score))
+	}
+
+	return duplicates, nil
+}
+
+// GetDueExpiryReminders возвращает подписки, заканчивающиеся в [from, to],
+// о текущей дате окончания которых ещё не напоминали.
+func (r *subscriptionRepository) GetDueExpiryReminders(ctx context.Context, from, to time.Time, limit int) ([]*models.Subscription, error) {
+	query := `
+		SELECT s.id, s.service_name, s.price, s.user_id, s.start_date, s.end_date, s.discount_id, s.plan_id, s.catalog_id, s.tags, s.category, s.payment_method, s.billing_day, s.notes, s.metadata, s.created_at, s.updated_at
+		FROM subscriptions s
+		WHERE s.end_date BETWEEN $1 AND $2
+			AND s.start_date <= $2
+			AND NOT EXISTS (
+				SELECT 1 FROM subscription_expiry_reminders er
+				WHERE er.subscription_id = s.id AND er.end_date = s.end_date
+			)
+		ORDER BY s.end_date, s.id
+		LIMIT $3`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, from, to, limit)
+	if err != nil {
+		r.log.Error("failed to get due expiry reminders", zap.Error(err))
+		return nil, dbError("get due expiry reminders", err)
+	}
+	defer rows.Close()
+
+	return r.scanSubscriptions(rows)
+}
+
+/*
+ArchiveEnded переносит до limit подписок с end_date раньше before в
+subscriptions_archive одним запросом: строка либо уже в архиве, либо ещё в
+основной таблице. Строки, занятые другой транзакцией, пропускаются до
+следующего запуска. Комментарии, история цен и напоминания удаляются
+триггером, как при Delete.
+*/
+func (r *subscriptionRepository) ArchiveEnded(ctx context.Context, before time.Time, limit int) (int, error) {
+	query := `
+		WITH moved AS (
+			DELETE FROM subscriptions
+			WHERE id IN (
+				SELECT id FROM subscriptions
+				WHERE end_date < $1 AND start_date < $1
+				ORDER BY end_date
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, service_name, price, user_id, start_date, end_date, discount_id, plan_id, catalog_id, tags, category, payment_method, billing_day, notes, metadata, metadata_digest, search_text, created_at, updated_at
+		)
+		INSERT INTO subscriptions_archive (id, service_name, price, user_id, start_date, end_date, discount_id, plan_id, catalog_id, tags, category, payment_method, billing_day, notes, metadata, metadata_digest, search_text, created_at, updated_at)
+		SELECT id, service_name, price, user_id, start_date, end_date, discount_id, plan_id, catalog_id, tags, category, payment_method, billing_day, notes, metadata, metadata_digest, search_text, created_at, updated_at
+		FROM moved`
+
+	// Перенос в архив не удаление: subscriptions.archiving не даёт триггеру
+	// удалить комментарии, историю цен, напоминания и участников (миграция 032).
+	var moved int64
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		conn := r.db.Conn(ctx)
+		if _, err := conn.Exec(ctx, `SELECT set_config('subscriptions.archiving', 'on', true)`); err != nil {
+			return err
+		}
+		result, err := conn.Exec(ctx, query, before, limit)
+		if err != nil {
+			return err
+		}
+		moved = result.RowsAffected()
+		_, err = conn.Exec(ctx, `SELECT set_config('subscriptions.archiving', 'off', true)`)
+		return err
+	})
+	if err != nil {
+		r.log.Error("failed to archive subscriptions",
+			zap.Time("before", before),
+			zap.Error(err))
+		return 0, dbError("archive subscriptions", err)
+	}
+
+	return int(moved), nil
+}
+
 func (r *subscriptionRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM subscriptions WHERE id = $1)`
 
 	var exists bool
-	err := r.db.Pool().QueryRow(ctx, query, id).Scan(&exists)
+	err := r.db.Conn(ctx).QueryRow(ctx, query, id).Scan(&exists)
 	if err != nil {
 		r.log.Error("failed to check subscription existence",
 			zap.String("subscription_id", id.String()),
 			zap.Error(err))
-		return false, fmt.Errorf("check subscription existence: %w", err)
+		return false, dbError("check subscription existence", err)
 	}
 
 	return exists, nil
 }
 
+func (r *subscriptionRepository) GetCalendar(ctx context.Context, userID uuid.UUID, year int, billing models.BillingMode, pricing models.PricingMode) (*models.SubscriptionCalendar, error) {
+	calendar := models.NewSubscriptionCalendar(year, billing)
+	from := calendar.Months()[0].Month()
+	to := calendar.Months()[11].Month()
+
+	if err := r.fillMonths(ctx, userID, from, to, pricing, calendar.MonthOf); err != nil {
+		r.log.Error("failed to get subscription calendar",
+			zap.String("user_id", userID.String()),
+			zap.Int("year", year),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return calendar, nil
+}
+
+func (r *subscriptionRepository) GetForecast(ctx context.Context, userID uuid.UUID, from time.Time, months int, billing models.BillingMode) (*models.CostForecast, error) {
+	forecast := models.NewCostForecast(from, months, billing)
+	to := forecast.Months()[months-1].Month()
+
+	if err := r.fillMonths(ctx, userID, from, to, models.PricingCurrent, forecast.MonthOf); err != nil {
+		r.log.Error("failed to get cost forecast",
+			zap.String("user_id", userID.String()),
+			zap.Int("months", months),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return forecast, nil
+}
+
+// fillMonths раскладывает подписки пользователя по месяцам с from по to
+// (первые числа месяцев); monthOf находит ячейку для месяца.
+func (r *subscriptionRepository) fillMonths(ctx context.Context, userID uuid.UUID, from, to time.Time, pricing models.PricingMode, monthOf func(time.Time) *models.CalendarMonth) error {
+	query := fmt.Sprintf(`
+		SELECT m.month, %s, s.id, s.service_name, s.price, s.user_id, s.start_date, s.end_date, s.discount_id, s.plan_id, s.catalog_id, s.tags, s.category, s.payment_method, s.billing_day, s.notes, s.metadata, s.created_at, s.updated_at
+		FROM generate_series($2::timestamptz, $3::timestamptz, interval '1 month') AS m(month)
+		JOIN subscriptions s
+			ON s.user_id = $1
+			AND s.start_date < $3::timestamptz + interval '1 month'
+			AND %s
+		LEFT JOIN discounts d ON d.id = s.discount_id
+		ORDER BY m.month, s.start_date, s.service_name`,
+		discountColumns("d."),
+		periodOverlapSQL("s.", "m.month", "m.month + interval '1 month' - interval '1 microsecond'"))
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID, from, to)
+	if err != nil {
+		return dbError("get subscription months", err)
+	}
+	defer rows.Close()
+
+	type monthRow struct {
+		month        time.Time
+		subscription *models.Subscription
+		discount     *models.Discount
+	}
+
+	var entries []monthRow
+	subscriptionIDs := make([]uuid.UUID, 0)
+	seen := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var (
+			month    time.Time
+			discount nullableDiscount
+		)
+		subscription, err := r.scanSubscriptionWithPrefix(rows, append([]interface{}{&month}, discount.dest()...)...)
+		if err != nil {
+			return dbError("scan subscription months", err)
+		}
+
+		entries = append(entries, monthRow{month: month.UTC(), subscription: subscription, discount: discount.model()})
+		if !seen[subscription.ID()] {
+			seen[subscription.ID()] = true
+			subscriptionIDs = append(subscriptionIDs, subscription.ID())
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return dbError("iterate subscription months", err)
+	}
+	rows.Close()
+
+	history := make(map[uuid.UUID][]*models.PriceChange)
+	if pricing == models.PricingHistorical && len(subscriptionIDs) > 0 {
+		history, err = r.getPriceHistories(ctx, subscriptionIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		price, err := entry.subscription.Price().WholeUnits()
+		if err != nil {
+			return apperror.InternalError("get subscription months", err)
+		}
+		prices := models.FixedPrice(price)
+		if pricing == models.PricingHistorical {
+			prices = models.NewPriceSchedule(price, history[entry.subscription.ID()])
+		}
+
+		if month := monthOf(entry.month); month != nil {
+			month.AddSubscription(entry.subscription, entry.discount, prices)
+		}
+	}
+
+	return nil
+}
+
+func (r *subscriptionRepository) GetBusinessKPIs(ctx context.Context, period models.DateRange, billing models.BillingMode) (*models.BusinessKPIs, error) {
+	query := fmt.Sprintf(`
+		SELECT COALESCE(SUM(%s - %s), 0), COUNT(DISTINCT s.user_id), COUNT(*)
+		FROM subscriptions s
+		LEFT JOIN discounts d ON d.id = s.discount_id
+		WHERE %s`, periodCostSQL("s.", "$1", "$2", billing, models.PricingCurrent), discountSQL("s.", "d.", "$1", "$2", billing, models.PricingCurrent), periodOverlapSQL("s.", "$1", "$2"))
+
+	var monthlySpend, activeUsers, activeSubscriptions int
+	err := r.db.Conn(ctx).QueryRow(ctx, query, period.From(), period.To()).
+		Scan(&monthlySpend, &activeUsers, &activeSubscriptions)
+	if err != nil {
+		r.log.Error("failed to get business kpis", zap.Error(err))
+		return nil, dbError("get business kpis", err)
+	}
+
+	return models.NewBusinessKPIs(period.From(), monthlySpend, activeUsers, activeSubscriptions), nil
+}
+
+func (r *subscriptionRepository) GetUserSpendForRange(ctx context.Context, period models.DateRange, billing models.BillingMode, userRange models.UserSpendRange) ([]*models.UserSpend, error) {
+	query := fmt.Sprintf(`
+		SELECT s.user_id, COALESCE(SUM(%s - %s), 0), COUNT(*)
+		FROM subscriptions s
+		LEFT JOIN discounts d ON d.id = s.discount_id
+		WHERE %s
+			AND s.user_id >= $3 AND ($4::uuid IS NULL OR s.user_id < $4)
+		GROUP BY s.user_id`, periodCostSQL("s.", "$1", "$2", billing, models.PricingCurrent), discountSQL("s.", "d.", "$1", "$2", billing, models.PricingCurrent), periodOverlapSQL("s.", "$1", "$2"))
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, period.From(), period.To(), userRange.From(), userRange.To())
+	if err != nil {
+		r.log.Error("failed to get user spend for range",
+			zap.String("from", userRange.From().String()),
+			zap.Error(err))
+		return nil, dbError("get user spend", err)
+	}
+	defer rows.Close()
+
+	spends := make([]*models.UserSpend, 0)
+	for rows.Next() {
+		var (
+			userID        uuid.UUID
+			totalCost     int
+			subscriptions int
+		)
+		if err := rows.Scan(&userID, &totalCost, &subscriptions); err != nil {
+			return nil, dbError("scan user spend", err)
+		}
+		spends = append(spends, models.NewUserSpend(userID, totalCost, subscriptions))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate user spend", err)
+	}
+
+	return spends, nil
+}
+
+func (r *subscriptionRepository) GetTopServices(ctx context.Context, period models.DateRange, billing models.BillingMode, sortBy models.TopServicesSort, limit int) ([]*models.ServiceStats, error) {
+	order := "subscribers DESC, revenue DESC"
+	if sortBy == models.TopServicesByRevenue {
+		order = "revenue DESC, subscribers DESC"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT s.service_name, COUNT(DISTINCT s.user_id) AS subscribers, COUNT(*), COALESCE(SUM(%s - %s), 0) AS revenue
+		FROM subscriptions s
+		LEFT JOIN discounts d ON d.id = s.discount_id
+		WHERE %s
+		GROUP BY s.service_name
+		ORDER BY %s, s.service_name
+		LIMIT $3`,
+		periodCostSQL("s.", "$1", "$2", billing, models.PricingHistorical),
+		discountSQL("s.", "d.", "$1", "$2", billing, models.PricingHistorical),
+		periodOverlapSQL("s.", "$1", "$2"),
+		order)
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, period.From(), period.To(), limit)
+	if err != nil {
+		r.log.Error("failed to get top services", zap.Error(err))
+		return nil, dbError("get top services", err)
+	}
+	defer rows.Close()
+
+	services := make([]*models.ServiceStats, 0)
+	for rows.Next() {
+		var (
+			serviceName   string
+			subscribers   int
+			subscriptions int
+			revenue       int
+		)
+		if err := rows.Scan(&serviceName, &subscribers, &subscriptions, &revenue); err != nil {
+			return nil, dbError("scan top services", err)
+		}
+		services = append(services, models.NewServiceStats(serviceName, subscribers, subscriptions, revenue))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate top services", err)
+	}
+
+	return services, nil
+}
+
+// GetMRR считает MRR каждого месяца периода как месячную стоимость
+// активных в нём подписок по истории цен за вычетом скидок.
+func (r *subscriptionRepository) GetMRR(ctx context.Context, period models.DateRange) ([]*models.MRRPoint, error) {
+	monthEnd := "m.month + interval '1 month' - interval '1 microsecond'"
+	query := fmt.Sprintf(`
+		SELECT m.month, COALESCE(SUM(%s - %s), 0), COUNT(s.id)
+		FROM generate_series(date_trunc('month', $1::timestamptz, 'UTC'), $2::timestamptz, interval '1 month') AS m(month)
+		LEFT JOIN subscriptions s
+			ON s.start_date < date_trunc('month', $2::timestamptz, 'UTC') + interval '1 month'
+			AND %s
+		LEFT JOIN discounts d ON d.id = s.discount_id
+		GROUP BY m.month
+		ORDER BY m.month`,
+		periodCostSQL("s.", "m.month", monthEnd, models.BillingMonthly, models.PricingHistorical),
+		discountSQL("s.", "d.", "m.month", monthEnd, models.BillingMonthly, models.PricingHistorical),
+		periodOverlapSQL("s.", "m.month", monthEnd))
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, period.From(), period.To())
+	if err != nil {
+		r.log.Error("failed to get mrr", zap.Error(err))
+		return nil, dbError("get mrr", err)
+	}
+	defer rows.Close()
+
+	points := make([]*models.MRRPoint, 0)
+	for rows.Next() {
+		var (
+			month         time.Time
+			mrr           int
+			subscriptions int
+		)
+		if err := rows.Scan(&month, &mrr, &subscriptions); err != nil {
+			return nil, dbError("scan mrr", err)
+		}
+		points = append(points, models.NewMRRPoint(month.UTC(), mrr, subscriptions))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate mrr", err)
+	}
+
+	return points, nil
+}
+
+func (r *subscriptionRepository) GetChurn(ctx context.Context, period models.DateRange) ([]*models.ChurnPoint, error) {
+	query := `
+		SELECT m.month,
+			COUNT(s.id) FILTER (WHERE s.start_date < m.month AND (s.end_date IS NULL OR s.end_date >= m.month)),
+			COUNT(s.id) FILTER (WHERE s.start_date >= m.month AND s.start_date < m.month + interval '1 month'),
+			COUNT(s.id) FILTER (WHERE s.end_date >= m.month AND s.end_date < m.month + interval '1 month')
+		FROM generate_series(date_trunc('month', $1::timestamptz, 'UTC'), $2::timestamptz, interval '1 month') AS m(month)
+		LEFT JOIN subscriptions s
+			ON s.start_date < m.month + interval '1 month'
+			AND s.start_date < date_trunc('month', $2::timestamptz, 'UTC') + interval '1 month'
+			AND (s.end_date IS NULL OR s.end_date >= m.month)
+		GROUP BY m.month
+		ORDER BY m.month`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, period.From(), period.To())
+	if err != nil {
+		r.log.Error("failed to get churn", zap.Error(err))
+		return nil, dbError("get churn", err)
+	}
+	defer rows.Close()
+
+	points := make([]*models.ChurnPoint, 0)
+	for rows.Next() {
+		var (
+			month         time.Time
+			activeAtStart int
+			started       int
+			churned       int
+		)
+		if err := rows.Scan(&month, &activeAtStart, &started, &churned); err != nil {
+			return nil, dbError("scan churn", err)
+		}
+		points = append(points, models.NewChurnPoint(month.UTC(), activeAtStart, started, churned))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate churn", err)
+	}
+
+	return points, nil
+}
+
+func (r *subscriptionRepository) RecordPriceChange(ctx context.Context, change *models.PriceChange) error {
+	query := `
+		INSERT INTO subscription_price_history (subscription_id, old_price, new_price, effective_from, changed_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.Conn(ctx).Exec(ctx, query,
+		change.SubscriptionID(),
+		change.OldPrice(),
+		change.NewPrice(),
+		change.EffectiveFrom(),
+		change.ChangedAt(),
+	)
+	if err != nil {
+		r.log.Error("failed to record price change",
+			zap.String("subscription_id", change.SubscriptionID().String()),
+			zap.Error(err))
+		return dbError("record price change", err)
+	}
+
+	return nil
+}
+
+func (r *subscriptionRepository) GetPriceHistory(ctx context.Context, subscriptionID uuid.UUID) ([]*models.PriceChange, error) {
+	history, err := r.getPriceHistories(ctx, []uuid.UUID{subscriptionID})
+	if err != nil {
+		return nil, err
+	}
+	return history[subscriptionID], nil
+}
+
+// getPriceHistories возвращает изменения цен подписок в порядке вступления в силу.
+func (r *subscriptionRepository) getPriceHistories(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID][]*models.PriceChange, error) {
+	query := `
+		SELECT subscription_id, old_price, new_price, effective_from, changed_at
+		FROM subscription_price_history
+		WHERE subscription_id = ANY($1)
+		ORDER BY subscription_id, effective_from, id`
+
+	rows, err := r.db.Conn(ctx).Query(ctx, query, subscriptionIDs)
+	if err != nil {
+		r.log.Error("failed to get price history", zap.Error(err))
+		return nil, dbError("get price history", err)
+	}
+	defer rows.Close()
+
+	history := make(map[uuid.UUID][]*models.PriceChange, len(subscriptionIDs))
+	for rows.Next() {
+		var (
+			subscriptionID uuid.UUID
+			oldPrice       int
+			newPrice       int
+			effectiveFrom  time.Time
+			changedAt      time.Time
+		)
+		if err := rows.Scan(&subscriptionID, &oldPrice, &newPrice, &effectiveFrom, &changedAt); err != nil {
+			return nil, dbError("scan price history", err)
+		}
+		history[subscriptionID] = append(history[subscriptionID],
+			models.RestorePriceChange(subscriptionID, oldPrice, newPrice, effectiveFrom, changedAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError("iterate price history", err)
+	}
+
+	return history, nil
+}
+
 func (r *subscriptionRepository) scanSubscription(row pgx.Row) (*models.Subscription, error) {
+	return r.scanSubscriptionWithPrefix(row)
+}
+
+func (r *subscriptionRepository) scanSubscriptionWithPrefix(row pgx.Row, prefix ...interface{}) (*models.Subscription, error) {
 	var (
 		id          uuid.UUID
 		serviceName string
@@ -240,18 +1228,44 @@ func (r *subscriptionRepository) scanSubscription(row pgx.Row) (*models.Subscrip
 		userID      uuid.UUID
 		startDate   time.Time
 		endDate     *time.Time
+		discountID  *uuid.UUID
+		planID      *uuid.UUID
+		catalogID   *uuid.UUID
+		tags        []string
+		category    *string
+		payment     *string
+		billingDay  *int
+		notes       string
+		metadata    map[string]string
 		createdAt   time.Time
 		updatedAt   time.Time
 	)
 
-	err := row.Scan(&id, &serviceName, &price, &userID, &startDate, &endDate, &createdAt, &updatedAt)
+	dest := append(prefix, &id, &serviceName, &price, &userID, &startDate, &endDate, &discountID, &planID, &catalogID, &tags, &category, &payment, &billingDay, &notes, &metadata, &createdAt, &updatedAt)
+	err := row.Scan(dest...)
 	if err != nil {
 		return nil, err
 	}
 
-	subscription := models.NewSubscription(serviceName, price, userID, startDate)
+	subscription := models.NewSubscription(serviceName, models.Rubles(price), userID, startDate)
 	subscription.SetID(id)
 	subscription.SetEndDate(endDate)
+	subscription.SetDiscountID(discountID)
+	subscription.SetPlanID(planID)
+	subscription.SetCatalogID(catalogID)
+	subscription.SetTags(tags)
+	if category != nil {
+		value := models.SubscriptionCategory(*category)
+		subscription.SetCategory(&value)
+	}
+	subscription.SetPaymentMethod(payment)
+	subscription.SetBillingDay(billingDay)
+	notes, metadata, err = OpenSubscriptionFields(r.cipher, id, notes, metadata)
+	if err != nil {
+		return nil, err
+	}
+	subscription.SetNotes(notes)
+	subscription.SetMetadata(metadata)
 	subscription.SetCreatedAt(createdAt)
 	subscription.SetUpdatedAt(updatedAt)
 
@@ -276,88 +1290,24 @@ func (r *subscriptionRepository) scanSubscriptions(rows pgx.Rows) ([]*models.Sub
 	return subscriptions, nil
 }
 
-func (r *subscriptionRepository) buildFilterQuery(filter *models.SubscriptionFilter, limit, offset int) (string, []interface{}) {
-	baseQuery := `
-		SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
-		FROM subscriptions`
-
-	conditions := []string{}
-	args := []interface{}{}
-	argIndex := 1
-
-	if filter.HasUserID() {
-		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIndex))
-		args = append(args, *filter.UserID())
-		argIndex++
-	}
-
-	if filter.HasServiceName() {
-		conditions = append(conditions, fmt.Sprintf("service_name ILIKE $%d", argIndex))
-		args = append(args, "%"+*filter.ServiceName()+"%")
-		argIndex++
-	}
-
-	if filter.HasDateRange() {
-		if filter.StartDate() != nil {
-			conditions = append(conditions, fmt.Sprintf("start_date >= $%d", argIndex))
-			args = append(args, *filter.StartDate())
-			argIndex++
-		}
-		if filter.EndDate() != nil {
-			conditions = append(conditions, fmt.Sprintf("(end_date IS NULL OR end_date <= $%d)", argIndex))
-			args = append(args, *filter.EndDate())
-			argIndex++
-		}
+// priceValue — цена для записи в БД: колонки price хранят целые рубли,
+// поэтому другая валюта или копейки — ошибка, а не округление.
+func priceValue(price models.Money) (int, error) {
+	if price.Currency() != models.DefaultCurrency {
+		return 0, apperror.InternalError("store price", fmt.Errorf("price currency %s, want %s", price.Currency(), models.DefaultCurrency))
 	}
-
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	rubles, err := price.WholeUnits()
+	if err != nil {
+		return 0, apperror.InternalError("store price", err)
 	}
-
-	query += " ORDER BY created_at DESC"
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, limit, offset)
-
-	return query, args
+	return rubles, nil
 }
 
-func (r *subscriptionRepository) buildCountQuery(filter *models.SubscriptionFilter) (string, []interface{}) {
-	baseQuery := `SELECT COUNT(*) FROM subscriptions`
-
-	conditions := []string{}
-	args := []interface{}{}
-	argIndex := 1
-
-	if filter.HasUserID() {
-		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIndex))
-		args = append(args, *filter.UserID())
-		argIndex++
-	}
-
-	if filter.HasServiceName() {
-		conditions = append(conditions, fmt.Sprintf("service_name ILIKE $%d", argIndex))
-		args = append(args, "%"+*filter.ServiceName()+"%")
-		argIndex++
-	}
-
-	if filter.HasDateRange() {
-		if filter.StartDate() != nil {
-			conditions = append(conditions, fmt.Sprintf("start_date >= $%d", argIndex))
-			args = append(args, *filter.StartDate())
-			argIndex++
-		}
-		if filter.EndDate() != nil {
-			conditions = append(conditions, fmt.Sprintf("(end_date IS NULL OR end_date <= $%d)", argIndex))
-			args = append(args, *filter.EndDate())
-			argIndex++
-		}
-	}
-
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+// categoryValue — категория для записи в БД; nil пишется как NULL.
+func categoryValue(category *models.SubscriptionCategory) *string {
+	if category == nil {
+		return nil
 	}
-
-	return query, args
+	value := string(*category)
+	return &value
 }
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/database/postgres"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+var (
+	migrateOnce sync.Once
+	migrateErr  error
+)
+
+/*
+openTestDB подключается к базе из TEST_DATABASE_* и один раз за прогон
+применяет к ней миграции; без TEST_DATABASE_HOST тест пропускается:
+
+	TEST_DATABASE_HOST=localhost go test ./internal/infrastructure/database/postgres/repository/
+*/
+func openTestDB(tb testing.TB, statementCacheCapacity int) *postgres.DB {
+	tb.Helper()
+
+	host := os.Getenv("TEST_DATABASE_HOST")
+	if host == "" {
+		tb.Skip("TEST_DATABASE_HOST is not set")
+	}
+
+	cfg := config.DatabaseConfig{
+		Host:                   host,
+		Port:                   envOr("TEST_DATABASE_PORT", "5432"),
+		User:                   envOr("TEST_DATABASE_USER", "postgres"),
+		Password:               envOr("TEST_DATABASE_PASSWORD", "postgres"),
+		DBName:                 envOr("TEST_DATABASE_DB_NAME", "subscription_service_test"),
+		SSLMode:                "disable",
+		MaxOpenConns:           8,
+		MaxIdleConns:           8,
+		StatementCacheCapacity: statementCacheCapacity,
+	}
+
+	log, err := logger.NewLogger(logger.Config{Level: "error"})
+	if err != nil {
+		tb.Fatalf("logger: %v", err)
+	}
+
+	migrateOnce.Do(func() {
+		migrateErr = postgres.Migrate(cfg, log)
+	})
+	if migrateErr != nil {
+		tb.Fatalf("migrate: %v", migrateErr)
+	}
+
+	db, err := postgres.New(cfg, log)
+	if err != nil {
+		tb.Fatalf("connect: %v", err)
+	}
+	tb.Cleanup(db.Close)
+	return db
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+/*
+RetryPolicy — повтор операции с экспоненциальной задержкой и джиттером:
+перед попыткой n (с единицы) ждём случайное время из [d/2, d], где
+d = min(BaseDelay·2^(n-1), MaxDelay). Attempts — всего попыток вместе с
+первой; 1 и меньше — без повторов.
+*/
+type RetryPolicy struct {
+	Attempts  int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// Retry вызывает op, пока она возвращает ошибку, для которой retryable
+// истинно, и не исчерпаны попытки. onRetry (может быть nil) вызывается
+// перед каждым повтором. Отмена ctx прерывает ожидание: возвращается
+// последняя ошибка op.
+func (p RetryPolicy) Retry(ctx context.Context, op func() error, retryable func(error) bool, onRetry func(attempt int, err error)) error {
+	err := op()
+	for attempt := 1; attempt < p.Attempts && err != nil && retryable(err); attempt++ {
+		if onRetry != nil {
+			onRetry(attempt, err)
+		}
+
+		timer := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		err = op()
+	}
+	return err
+}
+
+// Backoff — задержка перед повтором attempt (с единицы).
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
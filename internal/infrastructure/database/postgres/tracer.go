@@ -0,0 +1,293 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/correlation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/timing"
+)
+
+type multiQueryTracer []pgx.QueryTracer
+
+func (t multiQueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	for _, tracer := range t {
+		ctx = tracer.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (t multiQueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	for i := len(t) - 1; i >= 0; i-- {
+		t[i].TraceQueryEnd(ctx, conn, data)
+	}
+}
+
+// Запросы pgx.Batch идут мимо TraceQueryStart/End: pgx сообщает о них
+// только трассировщикам, которые реализуют pgx.BatchTracer.
+
+func (t multiQueryTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	for _, tracer := range t {
+		if batchTracer, ok := tracer.(pgx.BatchTracer); ok {
+			ctx = batchTracer.TraceBatchStart(ctx, conn, data)
+		}
+	}
+	return ctx
+}
+
+func (t multiQueryTracer) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchQueryData) {
+	for _, tracer := range t {
+		if batchTracer, ok := tracer.(pgx.BatchTracer); ok {
+			batchTracer.TraceBatchQuery(ctx, conn, data)
+		}
+	}
+}
+
+func (t multiQueryTracer) TraceBatchEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchEndData) {
+	for i := len(t) - 1; i >= 0; i-- {
+		if batchTracer, ok := t[i].(pgx.BatchTracer); ok {
+			batchTracer.TraceBatchEnd(ctx, conn, data)
+		}
+	}
+}
+
+type timingStartKey struct{}
+
+type timingTracer struct{}
+
+func (timingTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	if timing.FromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, timingStartKey{}, time.Now())
+}
+
+func (timingTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	recorder := timing.FromContext(ctx)
+	if recorder == nil {
+		return
+	}
+	if start, ok := ctx.Value(timingStartKey{}).(time.Time); ok {
+		recorder.ObserveDB(time.Since(start))
+	}
+}
+
+// Пакет запросов — один поход в базу: время учитывается целиком.
+
+func (timingTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, _ pgx.TraceBatchStartData) context.Context {
+	return timingTracer{}.TraceQueryStart(ctx, conn, pgx.TraceQueryStartData{})
+}
+
+func (timingTracer) TraceBatchQuery(context.Context, *pgx.Conn, pgx.TraceBatchQueryData) {}
+
+func (timingTracer) TraceBatchEnd(ctx context.Context, conn *pgx.Conn, _ pgx.TraceBatchEndData) {
+	timingTracer{}.TraceQueryEnd(ctx, conn, pgx.TraceQueryEndData{})
+}
+
+type slowQueryStartKey struct{}
+
+type slowQueryStart struct {
+	at   time.Time
+	sql  string
+	args []any
+}
+
+/*
+slowQueryTracer пишет в лог запросы дольше threshold. Значения аргументов
+в лог не попадают — только их типы: в них бывают идентификаторы
+пользователей и суммы.
+*/
+type slowQueryTracer struct {
+	threshold time.Duration
+	log       *logger.Logger
+}
+
+func (t slowQueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, slowQueryStartKey{}, slowQueryStart{at: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+func (t slowQueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(slowQueryStartKey{}).(slowQueryStart)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start.at)
+	if elapsed < t.threshold {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Duration("duration", elapsed),
+		zap.String("sql", compactSQL(start.sql)),
+		zap.Strings("args", redactArgs(start.args)),
+		zap.Int64("rows_affected", data.CommandTag.RowsAffected()),
+	}
+	if data.Err != nil {
+		fields = append(fields, zap.Error(data.Err))
+	}
+	fields = append(fields, correlation.Fields(ctx)...)
+	t.log.Warn("slow query", fields...)
+}
+
+// slowBatchStart — начало пакета и его запросы по мере выполнения.
+type slowBatchStart struct {
+	at      time.Time
+	queries []string
+	rows    int64
+}
+
+type slowBatchStartKey struct{}
+
+func (t slowQueryTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceBatchStartData) context.Context {
+	return context.WithValue(ctx, slowBatchStartKey{}, &slowBatchStart{at: time.Now()})
+}
+
+func (t slowQueryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	recordBatchQuery(ctx, slowBatchStartKey{}, data)
+}
+
+// recordBatchQuery добавляет выполненный запрос к пакету, начатому под key.
+func recordBatchQuery(ctx context.Context, key any, data pgx.TraceBatchQueryData) {
+	if start, ok := ctx.Value(key).(*slowBatchStart); ok {
+		start.queries = append(start.queries, compactSQL(data.SQL))
+		start.rows += data.CommandTag.RowsAffected()
+	}
+}
+
+// TraceBatchEnd пишет медленный пакет целиком: pgx не знает, сколько
+// длился каждый запрос пакета в отдельности.
+func (t slowQueryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	start, ok := ctx.Value(slowBatchStartKey{}).(*slowBatchStart)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start.at)
+	if elapsed < t.threshold {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Duration("duration", elapsed),
+		zap.Strings("batch", start.queries),
+		zap.Int64("rows_affected", start.rows),
+	}
+	if data.Err != nil {
+		fields = append(fields, zap.Error(data.Err))
+	}
+	fields = append(fields, correlation.Fields(ctx)...)
+	t.log.Warn("slow batch", fields...)
+}
+
+type queryLogStartKey struct{}
+
+/*
+queryLogTracer пишет каждый запрос на уровне debug с request_id и trace_id
+HTTP-запроса, в котором он выполнен. Под нагрузкой таких строк слишком
+много, поэтому пишется только доля sampleRate запросов. Решение принимается
+по request_id, так что все запросы одного HTTP-запроса либо попадают в лог
+вместе, либо не попадают; запросы фоновых задач без request_id отбираются
+по одному. Аргументы, как и в slowQueryTracer, заменяются их типами.
+*/
+type queryLogTracer struct {
+	sampleRate float64
+	log        *logger.Logger
+}
+
+// sampled решает, писать ли запросы из ctx. Уровень проверяется здесь, а не
+// при сборке пула: его можно поменять на работающем сервисе.
+func (t queryLogTracer) sampled(ctx context.Context) bool {
+	if !t.log.Enabled(zapcore.DebugLevel) || t.sampleRate <= 0 {
+		return false
+	}
+	if t.sampleRate >= 1 {
+		return true
+	}
+	if requestID := correlation.FromContext(ctx).RequestID; requestID != "" {
+		h := fnv.New32a()
+		h.Write([]byte(requestID))
+		return float64(h.Sum32()) < t.sampleRate*math.MaxUint32
+	}
+	return rand.Float64() < t.sampleRate
+}
+
+func (t queryLogTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !t.sampled(ctx) {
+		return ctx
+	}
+	return context.WithValue(ctx, queryLogStartKey{}, slowQueryStart{at: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+func (t queryLogTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryLogStartKey{}).(slowQueryStart)
+	if !ok {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Duration("duration", time.Since(start.at)),
+		zap.String("sql", compactSQL(start.sql)),
+		zap.Strings("args", redactArgs(start.args)),
+		zap.Int64("rows_affected", data.CommandTag.RowsAffected()),
+	}
+	if data.Err != nil {
+		fields = append(fields, zap.Error(data.Err))
+	}
+	fields = append(fields, correlation.Fields(ctx)...)
+	t.log.Debug("query", fields...)
+}
+
+type queryLogBatchKey struct{}
+
+func (t queryLogTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceBatchStartData) context.Context {
+	if !t.sampled(ctx) {
+		return ctx
+	}
+	return context.WithValue(ctx, queryLogBatchKey{}, &slowBatchStart{at: time.Now()})
+}
+
+func (t queryLogTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	recordBatchQuery(ctx, queryLogBatchKey{}, data)
+}
+
+func (t queryLogTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	start, ok := ctx.Value(queryLogBatchKey{}).(*slowBatchStart)
+	if !ok {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Duration("duration", time.Since(start.at)),
+		zap.Strings("batch", start.queries),
+		zap.Int64("rows_affected", start.rows),
+	}
+	if data.Err != nil {
+		fields = append(fields, zap.Error(data.Err))
+	}
+	fields = append(fields, correlation.Fields(ctx)...)
+	t.log.Debug("batch", fields...)
+}
+
+// compactSQL сворачивает переводы строк и отступы многострочных запросов
+// репозиториев в одну строку лога.
+func compactSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// redactArgs заменяет значения аргументов их типами: $1=uuid.UUID.
+func redactArgs(args []any) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = fmt.Sprintf("$%d=%T", i+1, arg)
+	}
+	return redacted
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/correlation"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+func newObservedLogger(level zapcore.Level) (*logger.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(level)
+	return logger.FromZap(zap.New(core)), logs
+}
+
+func runQuery(ctx context.Context, tracer pgx.QueryTracer) {
+	ctx = tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{
+		SQL:  "SELECT id\n\t\tFROM subscriptions\n\t\tWHERE user_id = $1",
+		Args: []any{"secret"},
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+}
+
+func TestQueryLogTracer_Correlation(t *testing.T) {
+	log, logs := newObservedLogger(zapcore.DebugLevel)
+	tracer := queryLogTracer{sampleRate: 1, log: log}
+
+	ctx := correlation.WithIDs(context.Background(), correlation.IDs{
+		RequestID: "20250701120000-abc123",
+		TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+	})
+	runQuery(ctx, tracer)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d entries, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if entries[0].Level != zapcore.DebugLevel || entries[0].Message != "query" {
+		t.Errorf("entry = %s %q, want debug \"query\"", entries[0].Level, entries[0].Message)
+	}
+	want := map[string]any{
+		"request_id":    "20250701120000-abc123",
+		"trace_id":      "4bf92f3577b34da6a3ce929d0e0e4736",
+		"sql":           "SELECT id FROM subscriptions WHERE user_id = $1",
+		"rows_affected": int64(1),
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("%s = %v, want %v", key, fields[key], value)
+		}
+	}
+	if args := fmt.Sprint(fields["args"]); args != "[$1=string]" {
+		t.Errorf("args = %s, want redacted types", args)
+	}
+}
+
+func TestQueryLogTracer_Batch(t *testing.T) {
+	log, logs := newObservedLogger(zapcore.DebugLevel)
+	tracer := queryLogTracer{sampleRate: 1, log: log}
+
+	ctx := correlation.WithIDs(context.Background(), correlation.IDs{RequestID: "req-1"})
+	ctx = tracer.TraceBatchStart(ctx, nil, pgx.TraceBatchStartData{})
+	tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{SQL: "UPDATE a SET x = 1", CommandTag: pgconn.NewCommandTag("UPDATE 2")})
+	tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{SQL: "UPDATE b SET y = 2", CommandTag: pgconn.NewCommandTag("UPDATE 1")})
+	tracer.TraceBatchEnd(ctx, nil, pgx.TraceBatchEndData{})
+
+	entries := logs.FilterMessage("batch").All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d batch entries, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["request_id"] != "req-1" || fields["rows_affected"] != int64(3) {
+		t.Errorf("fields = %v, want request_id req-1 and 3 rows", fields)
+	}
+}
+
+func TestQueryLogTracer_Sampling(t *testing.T) {
+	t.Run("nothing below debug level", func(t *testing.T) {
+		log, logs := newObservedLogger(zapcore.InfoLevel)
+		runQuery(context.Background(), queryLogTracer{sampleRate: 1, log: log})
+		if logs.Len() != 0 {
+			t.Errorf("logged %d entries at info level, want 0", logs.Len())
+		}
+	})
+
+	t.Run("request is logged whole or not at all", func(t *testing.T) {
+		log, logs := newObservedLogger(zapcore.DebugLevel)
+		tracer := queryLogTracer{sampleRate: 0.5, log: log}
+
+		sampled := 0
+		const requests = 200
+		for i := 0; i < requests; i++ {
+			ctx := correlation.WithIDs(context.Background(), correlation.IDs{RequestID: fmt.Sprintf("request-%d", i)})
+			before := logs.Len()
+			for q := 0; q < 3; q++ {
+				runQuery(ctx, tracer)
+			}
+			switch logs.Len() - before {
+			case 0:
+			case 3:
+				sampled++
+			default:
+				t.Fatalf("request-%d: logged %d of 3 queries", i, logs.Len()-before)
+			}
+		}
+		if sampled < requests/4 || sampled > requests*3/4 {
+			t.Errorf("sampled %d of %d requests at rate 0.5", sampled, requests)
+		}
+	})
+}
+
+func TestSlowQueryTracer_Correlation(t *testing.T) {
+	log, logs := newObservedLogger(zapcore.WarnLevel)
+	tracer := slowQueryTracer{threshold: 0, log: log}
+
+	runQuery(correlation.WithIDs(context.Background(), correlation.IDs{RequestID: "req-1"}), tracer)
+
+	entries := logs.FilterMessage("slow query").All()
+	if len(entries) != 1 || entries[0].ContextMap()["request_id"] != "req-1" {
+		t.Errorf("slow query entries = %v, want one with request_id req-1", entries)
+	}
+}
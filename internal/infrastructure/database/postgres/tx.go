@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// Querier — общий набор методов пула и транзакции, которым пользуются репозитории.
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
+}
+
+type txKey struct{}
+
+// txState — открытая транзакция и действия, отложенные до её коммита.
+type txState struct {
+	tx          pgx.Tx
+	afterCommit []func(ctx context.Context)
+}
+
+func stateFrom(ctx context.Context) *txState {
+	state, _ := ctx.Value(txKey{}).(*txState)
+	return state
+}
+
+// Conn возвращает транзакцию из контекста, если она открыта через
+// WithinTransaction, иначе — пул соединений.
+func (db *DB) Conn(ctx context.Context) Querier {
+	if state := stateFrom(ctx); state != nil {
+		return state.tx
+	}
+	return db.pool.Load()
+}
+
+// InTransaction сообщает, открыта ли в ctx транзакция.
+func (db *DB) InTransaction(ctx context.Context) bool {
+	return stateFrom(ctx) != nil
+}
+
+// AfterCommit откладывает fn до коммита внешней транзакции; при откате fn не
+// вызывается. Вне транзакции fn выполняется сразу.
+func (db *DB) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if state := stateFrom(ctx); state != nil {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn(ctx)
+}
+
+// WithinTransaction выполняет fn в одной транзакции. Вложенные вызовы
+// переиспользуют уже открытую транзакцию.
+func (db *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if stateFrom(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := db.pool.Load().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	state := &txState{tx: tx}
+	if err := fn(context.WithValue(ctx, txKey{}, state)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			db.log.Error("failed to rollback transaction", zap.Error(rbErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	// Отложенные действия получают исходный контекст без транзакции.
+	for _, hook := range state.afterCommit {
+		hook(ctx)
+	}
+
+	return nil
+}
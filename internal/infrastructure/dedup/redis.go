@@ -0,0 +1,122 @@
+package dedup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// pendingMarker — значение ключа, пока первый запрос ещё обрабатывается.
+const pendingMarker = "pending"
+
+// Response — ответ на первый из одинаковых запросов, который получат повторы.
+type Response struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Location    string `json:"location,omitempty"`
+	Body        []byte `json:"body"`
+}
+
+// Config — подключение к Redis и префикс ключей.
+type Config struct {
+	Addr     string
+	Password string
+	DB       int
+	// Prefix добавляется ко всем ключам, чтобы несколько сервисов делили Redis.
+	Prefix string
+}
+
+/*
+RedisStore хранит ответы для middleware.Dedup в Redis, чтобы повтор,
+пришедший на другую реплику, получил тот же ответ. Ключ живёт одно окно:
+сначала в нём маркер «обрабатывается», затем сохранённый ответ.
+*/
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+/** Конструктор. Соединение открывается лениво, при первой команде. */
+func NewRedisStore(cfg Config) *RedisStore {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+	return &RedisStore{client: client, prefix: cfg.Prefix}
+}
+
+/*
+Claim занимает ключ на window (SET NX). true — запрос первый и должен
+выполниться; false — такой же запрос уже выполняется или выполнен.
+*/
+func (s *RedisStore) Claim(ctx context.Context, key string, window time.Duration) (bool, error) {
+	ok, err := s.client.SetNX(ctx, s.prefix+key, pendingMarker, window).Result()
+	if err != nil {
+		return false, fmt.Errorf("dedup claim: %w", err)
+	}
+	return ok, nil
+}
+
+/*
+Lookup возвращает сохранённый ответ. nil без ошибки — первый запрос ещё
+выполняется либо ключ уже истёк или освобождён.
+*/
+func (s *RedisStore) Lookup(ctx context.Context, key string) (*Response, error) {
+	value, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dedup lookup: %w", err)
+	}
+	if string(value) == pendingMarker {
+		return nil, nil
+	}
+
+	var resp Response
+	if err := json.Unmarshal(value, &resp); err != nil {
+		return nil, fmt.Errorf("dedup lookup: %w", err)
+	}
+	return &resp, nil
+}
+
+/*
+Complete сохраняет ответ первого запроса до конца его окна (SET XX
+KEEPTTL). Если обработка заняла дольше окна, ключа уже нет и сохранять
+нечего.
+*/
+func (s *RedisStore) Complete(ctx context.Context, key string, resp Response) error {
+	value, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("dedup complete: %w", err)
+	}
+
+	err = s.client.SetArgs(ctx, s.prefix+key, value, redis.SetArgs{Mode: "XX", KeepTTL: true}).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("dedup complete: %w", err)
+	}
+	return nil
+}
+
+/** Release освобождает ключ: повтор выполнится заново. */
+func (s *RedisStore) Release(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("dedup release: %w", err)
+	}
+	return nil
+}
+
+/** HealthCheck — PING для /health; Redis некритичен, Dedup без него пропускает запросы. */
+func (s *RedisStore) HealthCheck(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+/** Закрывает соединения с Redis. */
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,186 @@
+// Package events — шина доменных событий внутри процесса. Сервисы
+// публикуют через порт repository.EventPublisher, потребители
+// (WebSocket, метрики, вебхуки) подписываются на шину и не зависят друг от
+// друга.
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+const defaultBuffer = 256
+
+// Handler обрабатывает событие в горутине своего подписчика. ctx сохраняет
+// значения контекста запроса, но не его отмену.
+type Handler func(ctx context.Context, event models.DomainEvent)
+
+type delivery struct {
+	ctx   context.Context
+	event models.DomainEvent
+}
+
+// subscriber — свой буфер и своя горутина: медленный потребитель теряет
+// только свои события и не задерживает остальных.
+type subscriber struct {
+	name    string
+	handler Handler
+	types   map[string]bool
+	queue   chan delivery
+}
+
+func (s *subscriber) accepts(event models.DomainEvent) bool {
+	return len(s.types) == 0 || s.types[event.Type()]
+}
+
+/*
+Bus реализует repository.EventPublisher. Publish не блокирует: событие
+кладётся в очередь каждого подходящего подписчика, при переполненной
+очереди оно для этого подписчика отбрасывается.
+*/
+type Bus struct {
+	buffer int
+	log    *logger.Logger
+
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+	closed      bool
+	wg          sync.WaitGroup
+}
+
+// NewBus создаёт шину; buffer — размер очереди подписчика, 0 — по умолчанию.
+func NewBus(buffer int, log *logger.Logger) *Bus {
+	if buffer <= 0 {
+		buffer = defaultBuffer
+	}
+	return &Bus{
+		buffer:      buffer,
+		log:         log.Named("event-bus"),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+/*
+Subscribe регистрирует потребителя name. types ограничивает типы событий
+(models.EventSubscription*); без них приходят все. Возвращённая функция
+отписывает потребителя, дождавшись обработки его очереди.
+*/
+func (b *Bus) Subscribe(name string, handler Handler, types ...string) func() {
+	sub := &subscriber{
+		name:    name,
+		handler: handler,
+		queue:   make(chan delivery, b.buffer),
+	}
+	if len(types) > 0 {
+		sub.types = make(map[string]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return func() {}
+	}
+	b.subscribers[sub] = struct{}{}
+	b.wg.Add(1)
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer b.wg.Done()
+		defer close(done)
+		for d := range sub.queue {
+			b.handle(sub, d)
+		}
+	}()
+
+	b.log.Debug("event consumer subscribed", zap.String("consumer", name))
+
+	return func() {
+		b.mu.Lock()
+		if _, ok := b.subscribers[sub]; ok {
+			delete(b.subscribers, sub)
+			close(sub.queue)
+		}
+		b.mu.Unlock()
+		<-done
+	}
+}
+
+func (b *Bus) Publish(ctx context.Context, event models.DomainEvent) {
+	ctx = context.WithoutCancel(ctx)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers {
+		if !sub.accepts(event) {
+			continue
+		}
+		select {
+		case sub.queue <- delivery{ctx: ctx, event: event}:
+		default:
+			b.log.Warn("event consumer queue is full, event dropped",
+				zap.String("consumer", sub.name),
+				zap.String("event_type", event.Type()),
+				zap.String("event_id", event.ID().String()))
+		}
+	}
+}
+
+// saturatedFill — доля заполнения очереди, после которой потребитель
+// считается не успевающим.
+const saturatedFill = 0.9
+
+// HealthCheck сообщает о потребителе, очередь которого почти заполнена:
+// ещё немного — и он начнёт терять события.
+func (b *Bus) HealthCheck(_ context.Context) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers {
+		if depth := len(sub.queue); float64(depth) >= saturatedFill*float64(b.buffer) {
+			return fmt.Errorf("consumer %s has %d of %d events queued", sub.name, depth, b.buffer)
+		}
+	}
+	return nil
+}
+
+// handle изолирует панику потребителя, чтобы она не остановила его горутину.
+func (b *Bus) handle(sub *subscriber, d delivery) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.log.Error("event consumer panicked",
+				zap.String("consumer", sub.name),
+				zap.String("event_type", d.event.Type()),
+				zap.Any("panic", r))
+		}
+	}()
+	sub.handler(d.ctx, d.event)
+}
+
+// Close перестаёт принимать события и ждёт, пока потребители обработают
+// уже поставленные в очередь. Вызывается после остановки HTTP-сервера.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for sub := range b.subscribers {
+		delete(b.subscribers, sub)
+		close(sub.queue)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
@@ -0,0 +1,186 @@
+package livestats
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	windowSize = 60
+	rateWindow = 10
+)
+
+type Snapshot struct {
+	Timestamp           time.Time
+	RequestsPerSecond   float64
+	CreatesPerMinute    int64
+	ActiveSubscriptions int64
+	Streams             int
+}
+
+// counter считает события по секундным корзинам за последнюю минуту.
+type counter struct {
+	buckets [windowSize]int64
+	seconds [windowSize]int64
+}
+
+func (c *counter) add(now time.Time) {
+	second := now.Unix()
+	i := second % windowSize
+	if c.seconds[i] != second {
+		c.seconds[i] = second
+		c.buckets[i] = 0
+	}
+	c.buckets[i]++
+}
+
+// sum возвращает число событий за последние span полных секунд.
+func (c *counter) sum(now time.Time, span int64) int64 {
+	current := now.Unix()
+	var total int64
+	for i := range c.buckets {
+		age := current - c.seconds[i]
+		if age >= 1 && age <= span {
+			total += c.buckets[i]
+		}
+	}
+	return total
+}
+
+// Stats агрегирует счётчики в памяти процесса и раз в interval рассылает
+// снимок подписчикам живого канала.
+type Stats struct {
+	mu       sync.Mutex
+	requests counter
+	creates  counter
+
+	activeSubscriptions atomic.Int64
+
+	subMu       sync.Mutex
+	subscribers map[chan Snapshot]struct{}
+
+	interval time.Duration
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func New(interval time.Duration) *Stats {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &Stats{
+		subscribers: make(map[chan Snapshot]struct{}),
+		interval:    interval,
+		stop:        make(chan struct{}),
+	}
+}
+
+func (s *Stats) RecordRequest() {
+	now := time.Now()
+	s.mu.Lock()
+	s.requests.add(now)
+	s.mu.Unlock()
+}
+
+func (s *Stats) RecordCreate() {
+	now := time.Now()
+	s.mu.Lock()
+	s.creates.add(now)
+	s.mu.Unlock()
+}
+
+func (s *Stats) SetActiveSubscriptions(n int64) {
+	s.activeSubscriptions.Store(n)
+}
+
+func (s *Stats) Snapshot() Snapshot {
+	now := time.Now()
+
+	s.mu.Lock()
+	requests := s.requests.sum(now, rateWindow)
+	creates := s.creates.sum(now, windowSize)
+	s.mu.Unlock()
+
+	s.subMu.Lock()
+	streams := len(s.subscribers)
+	s.subMu.Unlock()
+
+	return Snapshot{
+		Timestamp:           now,
+		RequestsPerSecond:   float64(requests) / rateWindow,
+		CreatesPerMinute:    creates,
+		ActiveSubscriptions: s.activeSubscriptions.Load(),
+		Streams:             streams,
+	}
+}
+
+// Subscribe регистрирует слушателя. Канал закрывается при Stop или вызове
+// возвращённой функции отписки.
+func (s *Stats) Subscribe() (<-chan Snapshot, func()) {
+	ch := make(chan Snapshot, 1)
+
+	s.subMu.Lock()
+	select {
+	case <-s.stop:
+		close(ch)
+	default:
+		s.subscribers[ch] = struct{}{}
+	}
+	s.subMu.Unlock()
+
+	return ch, func() {
+		s.subMu.Lock()
+		defer s.subMu.Unlock()
+		if _, ok := s.subscribers[ch]; ok {
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (s *Stats) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.broadcast(s.Snapshot())
+			}
+		}
+	}()
+}
+
+// Stop завершает рассылку и закрывает все каналы, чтобы открытые потоки
+// не задерживали graceful shutdown.
+func (s *Stats) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+
+		s.subMu.Lock()
+		defer s.subMu.Unlock()
+		for ch := range s.subscribers {
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	})
+}
+
+// broadcast не блокируется на медленных клиентах: непрочитанный снимок
+// заменяется свежим.
+func (s *Stats) broadcast(snap Snapshot) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- snap
+	}
+}
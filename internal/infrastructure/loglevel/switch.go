@@ -0,0 +1,151 @@
+package loglevel
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// Leveler — логгер, уровень которого меняется на ходу (logger.Logger).
+type Leveler interface {
+	Level() zapcore.Level
+	SetLevel(level zapcore.Level)
+}
+
+type Status struct {
+	// Level — действующий уровень.
+	Level zapcore.Level
+	// Default — уровень из конфигурации, к которому логгер вернётся.
+	Default zapcore.Level
+	// Until — когда уровень вернётся к Default; нулевое — он уже на нём.
+	Until  time.Time
+	Reason string
+}
+
+/*
+Switch временно меняет уровень логов реплики, например включает debug на
+время разбора инцидента, и сам возвращает уровень из конфигурации по
+истечении срока: забытый debug не заполнит диск и не замедлит сервис.
+Как и режим обслуживания, состояние живёт в памяти процесса и касается
+только реплики, которая обслужила запрос.
+*/
+type Switch struct {
+	target          Leveler
+	base            zapcore.Level
+	defaultDuration time.Duration
+	maxDuration     time.Duration
+	log             *logger.Logger
+
+	mu     sync.Mutex
+	until  time.Time
+	reason string
+	timer  *time.Timer
+	// generation отличает таймер текущего изменения от уже отменённых.
+	generation uint64
+}
+
+/*
+NewSwitch запоминает текущий уровень target как уровень по умолчанию.
+defaultDuration — срок изменения, если он не указан; maxDuration — самый
+долгий допустимый срок.
+*/
+func NewSwitch(target Leveler, defaultDuration, maxDuration time.Duration, log *logger.Logger) *Switch {
+	return &Switch{
+		target:          target,
+		base:            target.Level(),
+		defaultDuration: defaultDuration,
+		maxDuration:     maxDuration,
+		log:             log.Named("log-level"),
+	}
+}
+
+/** Самый долгий срок, на который можно поменять уровень. */
+func (s *Switch) MaxDuration() time.Duration {
+	return s.maxDuration
+}
+
+func (s *Switch) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statusLocked()
+}
+
+/*
+Set ставит уровень level на duration (0 — на срок по умолчанию), после
+чего уровень вернётся к уровню из конфигурации. Уровень из конфигурации
+ставится сразу и бессрочно. Повторный вызов заменяет предыдущее изменение
+вместе с его сроком.
+*/
+func (s *Switch) Set(level zapcore.Level, duration time.Duration, reason string) Status {
+	if duration <= 0 {
+		duration = s.defaultDuration
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopLocked()
+	if level == s.base {
+		s.target.SetLevel(level)
+		s.log.Info("log level restored", zap.Stringer("level", level))
+		return s.statusLocked()
+	}
+
+	generation := s.generation
+	s.until = time.Now().UTC().Add(duration)
+	s.reason = reason
+	s.timer = time.AfterFunc(duration, func() { s.expire(generation) })
+	s.target.SetLevel(level)
+
+	// warn — чтобы изменение было видно и в логах с уровнем warn.
+	s.log.Warn("log level changed",
+		zap.Stringer("level", level),
+		zap.Stringer("default", s.base),
+		zap.Duration("duration", duration),
+		zap.String("reason", reason))
+	return s.statusLocked()
+}
+
+/** Stop возвращает уровень из конфигурации и отменяет таймер; для остановки сервиса. */
+func (s *Switch) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopLocked()
+	s.target.SetLevel(s.base)
+}
+
+func (s *Switch) expire(generation uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if generation != s.generation {
+		return
+	}
+
+	s.stopLocked()
+	s.target.SetLevel(s.base)
+	s.log.Info("log level reverted", zap.Stringer("level", s.base))
+}
+
+// stopLocked отменяет текущее изменение; уровень не трогает.
+func (s *Switch) stopLocked() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.generation++
+	s.until = time.Time{}
+	s.reason = ""
+}
+
+func (s *Switch) statusLocked() Status {
+	return Status{
+		Level:   s.target.Level(),
+		Default: s.base,
+		Until:   s.until,
+		Reason:  s.reason,
+	}
+}
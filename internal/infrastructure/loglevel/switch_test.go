@@ -0,0 +1,95 @@
+package loglevel
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// levelStub — Leveler, уровень которого можно читать из теста без гонок.
+type levelStub struct {
+	mu    sync.Mutex
+	level zapcore.Level
+}
+
+func (l *levelStub) Level() zapcore.Level {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.level
+}
+
+func (l *levelStub) SetLevel(level zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+func newTestSwitch(defaultDuration time.Duration) (*Switch, *levelStub) {
+	target := &levelStub{level: zapcore.InfoLevel}
+	return NewSwitch(target, defaultDuration, time.Hour, logger.FromZap(zap.NewNop())), target
+}
+
+func waitForLevel(t *testing.T, target *levelStub, want zapcore.Level) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for target.Level() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("level = %s, want %s", target.Level(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSwitch_RevertsAfterDuration(t *testing.T) {
+	sw, target := newTestSwitch(time.Hour)
+
+	status := sw.Set(zapcore.DebugLevel, 20*time.Millisecond, "incident")
+	if status.Level != zapcore.DebugLevel || status.Default != zapcore.InfoLevel || status.Until.IsZero() || status.Reason != "incident" {
+		t.Fatalf("status = %+v, want debug until set", status)
+	}
+
+	waitForLevel(t, target, zapcore.InfoLevel)
+	if status := sw.Status(); !status.Until.IsZero() || status.Reason != "" {
+		t.Errorf("status after revert = %+v, want no override", status)
+	}
+}
+
+func TestSwitch_DefaultDuration(t *testing.T) {
+	sw, target := newTestSwitch(20 * time.Millisecond)
+
+	sw.Set(zapcore.DebugLevel, 0, "")
+	if target.Level() != zapcore.DebugLevel {
+		t.Fatalf("level = %s, want debug", target.Level())
+	}
+	waitForLevel(t, target, zapcore.InfoLevel)
+}
+
+func TestSwitch_NewChangeReplacesTimer(t *testing.T) {
+	sw, target := newTestSwitch(time.Hour)
+
+	sw.Set(zapcore.DebugLevel, 20*time.Millisecond, "")
+	sw.Set(zapcore.WarnLevel, time.Hour, "")
+	defer sw.Stop()
+
+	// Таймер первого изменения отменён и не вернёт уровень раньше срока.
+	time.Sleep(60 * time.Millisecond)
+	if target.Level() != zapcore.WarnLevel {
+		t.Errorf("level = %s, want warn", target.Level())
+	}
+}
+
+func TestSwitch_ConfiguredLevelRevertsAtOnce(t *testing.T) {
+	sw, target := newTestSwitch(time.Hour)
+
+	sw.Set(zapcore.DebugLevel, time.Hour, "")
+	status := sw.Set(zapcore.InfoLevel, time.Hour, "")
+
+	if target.Level() != zapcore.InfoLevel || !status.Until.IsZero() {
+		t.Errorf("level = %s, until = %v; want info without deadline", target.Level(), status.Until)
+	}
+}
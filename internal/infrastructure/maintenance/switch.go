@@ -0,0 +1,94 @@
+package maintenance
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// ReadMode — что делать с чтениями в режиме обслуживания.
+type ReadMode string
+
+const (
+	// ReadsAllow — чтения идут в базу как обычно.
+	ReadsAllow ReadMode = "allow"
+	// ReadsCache — чтения отдаются только из снапшотов деградации.
+	ReadsCache ReadMode = "cache"
+	// ReadsDeny — чтения тоже получают MAINTENANCE.
+	ReadsDeny ReadMode = "deny"
+)
+
+type Status struct {
+	Enabled bool
+	Reason  string
+	Since   time.Time
+	Reads   ReadMode
+}
+
+/*
+Switch — режим обслуживания реплики (например, на время миграции схемы).
+Состояние живёт в памяти процесса: админский эндпоинт переключает только
+ту реплику, которая его обслужила; для всего парка — maintenance.enabled.
+*/
+type Switch struct {
+	reads ReadMode
+	log   *logger.Logger
+
+	mu      sync.RWMutex
+	enabled bool
+	reason  string
+	since   time.Time
+}
+
+func NewSwitch(enabled bool, reason string, reads ReadMode, log *logger.Logger) *Switch {
+	s := &Switch{
+		reads: reads,
+		log:   log.Named("maintenance"),
+	}
+	if enabled {
+		s.Set(true, reason)
+	}
+	return s
+}
+
+func (s *Switch) Enabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enabled
+}
+
+func (s *Switch) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Status{
+		Enabled: s.enabled,
+		Reason:  s.reason,
+		Since:   s.since,
+		Reads:   s.reads,
+	}
+}
+
+// Set включает или выключает режим. Повторное включение обновляет причину,
+// но не время начала.
+func (s *Switch) Set(enabled bool, reason string) Status {
+	s.mu.Lock()
+	switch {
+	case enabled && !s.enabled:
+		s.since = time.Now().UTC()
+		s.log.Warn("maintenance mode enabled", zap.String("reason", reason), zap.String("reads", string(s.reads)))
+	case !enabled && s.enabled:
+		s.log.Info("maintenance mode disabled", zap.Duration("duration", time.Since(s.since)))
+		s.since = time.Time{}
+	}
+	s.enabled = enabled
+	s.reason = ""
+	if enabled {
+		s.reason = reason
+	}
+	s.mu.Unlock()
+
+	return s.Status()
+}
@@ -0,0 +1,229 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/breaker"
+)
+
+const namespace = "subscription_service"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	MonthlySpend        prometheus.Gauge
+	ActiveUsers         prometheus.Gauge
+	ActiveSubscriptions prometheus.Gauge
+	KPIRefreshedAt      prometheus.Gauge
+
+	HTTPActiveConnections prometheus.Gauge
+	HTTPDraining          prometheus.Gauge
+
+	HTTPRequests        *prometheus.CounterVec
+	HTTPRequestDuration *prometheus.HistogramVec
+	HTTPErrors          *prometheus.CounterVec
+
+	SubscriptionEvents *prometheus.CounterVec
+
+	DBRetries *prometheus.CounterVec
+
+	CostCacheRequests *prometheus.CounterVec
+
+	BreakerState       *prometheus.GaugeVec
+	BreakerTransitions *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	m := &Metrics{
+		registry: registry,
+		MonthlySpend: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "business",
+			Name:      "monthly_spend_rub",
+			Help:      "Total tracked spend of subscriptions active in the current month.",
+		}),
+		ActiveUsers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "business",
+			Name:      "active_users",
+			Help:      "Users with at least one subscription active in the current month.",
+		}),
+		ActiveSubscriptions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "business",
+			Name:      "active_subscriptions",
+			Help:      "Subscriptions active in the current month.",
+		}),
+		KPIRefreshedAt: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "business",
+			Name:      "kpi_refreshed_timestamp_seconds",
+			Help:      "Unix time of the last successful business KPI refresh.",
+		}),
+	}
+
+	m.HTTPActiveConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "active_connections",
+		Help:      "Number of open client connections, including idle keep-alive ones.",
+	})
+	m.HTTPDraining = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "draining",
+		Help:      "1 while the server is draining connections during shutdown.",
+	})
+
+	// route — шаблон маршрута gin, поэтому число рядов ограничено набором эндпоинтов.
+	m.HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+	m.HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method and route template.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "route"})
+	m.HTTPErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "errors_total",
+		Help:      "Failed HTTP requests by method, route template and application error code.",
+	}, []string{"method", "route", "code"})
+
+	m.SubscriptionEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "published_total",
+		Help:      "Domain events published to the in-process event bus, by type.",
+	}, []string{"type"})
+
+	m.DBRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "retries_total",
+		Help:      "Repository operations retried after a transient database error, by operation.",
+	}, []string{"operation"})
+
+	// Доля попаданий: rate(...{result="hit"}) / rate(...) по всем result.
+	m.CostCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cost_cache",
+		Name:      "requests_total",
+		Help:      "Cost calculation lookups in the cost cache, by result (hit or miss).",
+	}, []string{"result"})
+
+	m.BreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "circuit_breaker",
+		Name:      "state",
+		Help:      "Circuit breaker state by dependency: 0 closed, 1 half-open, 2 open.",
+	}, []string{"name"})
+	m.BreakerTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "circuit_breaker",
+		Name:      "transitions_total",
+		Help:      "Circuit breaker state changes by dependency and new state.",
+	}, []string{"name", "state"})
+
+	registry.MustRegister(
+		m.MonthlySpend,
+		m.ActiveUsers,
+		m.ActiveSubscriptions,
+		m.KPIRefreshedAt,
+		m.HTTPActiveConnections,
+		m.HTTPDraining,
+		m.HTTPRequests,
+		m.HTTPRequestDuration,
+		m.HTTPErrors,
+		m.SubscriptionEvents,
+		m.DBRetries,
+		m.CostCacheRequests,
+		m.BreakerState,
+		m.BreakerTransitions,
+	)
+
+	return m
+}
+
+// SetActiveConnections и SetDraining реализуют server.ConnectionObserver.
+func (m *Metrics) SetActiveConnections(n int64) {
+	m.HTTPActiveConnections.Set(float64(n))
+}
+
+func (m *Metrics) SetDraining(draining bool) {
+	if draining {
+		m.HTTPDraining.Set(1)
+		return
+	}
+	m.HTTPDraining.Set(0)
+}
+
+// ObserveHTTPRequest реализует middleware.RequestObserver.
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration, errorCode string) {
+	m.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.HTTPRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+	if errorCode != "" {
+		m.HTTPErrors.WithLabelValues(method, route, errorCode).Inc()
+	}
+}
+
+// ObserveEvent — обработчик подписки метрик на шину событий.
+func (m *Metrics) ObserveEvent(_ context.Context, event models.DomainEvent) {
+	m.SubscriptionEvents.WithLabelValues(event.Type()).Inc()
+}
+
+// ObserveDBRetry реализует repository.RetryObserver.
+func (m *Metrics) ObserveDBRetry(operation string) {
+	m.DBRetries.WithLabelValues(operation).Inc()
+}
+
+// ObserveCostCache реализует service.CostCacheObserver.
+func (m *Metrics) ObserveCostCache(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.CostCacheRequests.WithLabelValues(result).Inc()
+}
+
+// SetBreakerState выставляет начальное состояние автомата, пока переходов
+// ещё не было.
+func (m *Metrics) SetBreakerState(name string, state breaker.State) {
+	m.BreakerState.WithLabelValues(name).Set(float64(state))
+}
+
+// ObserveBreakerTransition подходит для breaker.Settings.OnStateChange.
+func (m *Metrics) ObserveBreakerTransition(name string, _, to breaker.State) {
+	m.BreakerState.WithLabelValues(name).Set(float64(to))
+	m.BreakerTransitions.WithLabelValues(name, to.String()).Inc()
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+	})
+}
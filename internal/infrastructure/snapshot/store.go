@@ -0,0 +1,83 @@
+package snapshot
+
+import (
+	"sync"
+	"time"
+)
+
+// Snapshot — последний успешный ответ маршрута, который отдаётся, пока
+// база недоступна или сервис в режиме обслуживания.
+type Snapshot struct {
+	Status    int
+	Body      []byte
+	CreatedAt time.Time
+}
+
+/** Age — сколько прошло с момента, когда снапшот был сохранён. */
+func (s Snapshot) Age(now time.Time) time.Duration {
+	return now.Sub(s.CreatedAt)
+}
+
+/*
+Store — снапшоты ответов в памяти процесса по ключу запроса. Размер
+ограничен maxEntries: когда место кончается, новый снапшот вытесняет
+самый старый. Снапшоты живут до рестарта и у каждой реплики свои.
+*/
+type Store struct {
+	mu         sync.RWMutex
+	entries    map[string]Snapshot
+	maxEntries int
+}
+
+/** Конструктор. maxEntries <= 0 — лимит по умолчанию, 1000 снапшотов. */
+func NewStore(maxEntries int) *Store {
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+	return &Store{
+		entries:    make(map[string]Snapshot),
+		maxEntries: maxEntries,
+	}
+}
+
+/** Get возвращает снапшот по ключу; false — снапшота нет. */
+func (s *Store) Get(key string) (Snapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap, ok := s.entries[key]
+	return snap, ok
+}
+
+/** Put сохраняет снапшот, заменяя прежний по тому же ключу. */
+func (s *Store) Put(key string, snap Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[key]; !exists && len(s.entries) >= s.maxEntries {
+		s.evictOldest()
+	}
+	s.entries[key] = snap
+}
+
+/** Len — число сохранённых снапшотов. */
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// evictOldest удаляет самый старый снапшот; вызывается под s.mu.
+func (s *Store) evictOldest() {
+	var (
+		oldestKey string
+		oldestAt  time.Time
+	)
+	for key, snap := range s.entries {
+		if oldestKey == "" || snap.CreatedAt.Before(oldestAt) {
+			oldestKey = key
+			oldestAt = snap.CreatedAt
+		}
+	}
+	delete(s.entries, oldestKey)
+}
@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// Config — подключение к S3-совместимому хранилищу.
+type Config struct {
+	// Endpoint — адрес API вместе со схемой: https://s3.eu-central-1.amazonaws.com,
+	// http://minio:9000.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PathStyle — бакет в пути (http://minio:9000/bucket/key), а не в
+	// имени хоста (https://bucket.s3.amazonaws.com/key). MinIO по умолчанию
+	// понимает только такой вид.
+	PathStyle bool
+	// Prefix добавляется ко всем ключам, чтобы несколько окружений делили бакет.
+	Prefix string
+}
+
+// S3 — repository.ObjectStorage поверх REST API S3 без SDK: сервису нужны
+// пять операций, а подпись SigV4 проще написать, чем тянуть AWS SDK.
+type S3 struct {
+	cfg    Config
+	base   *url.URL
+	signer signer
+	client *http.Client
+	now    func() time.Time
+	log    *logger.Logger
+}
+
+var _ repository.ObjectStorage = (*S3)(nil)
+
+func NewS3(cfg Config, log *logger.Logger) (*S3, error) {
+	base, err := url.Parse(cfg.Endpoint)
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid storage endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, errors.New("storage bucket is required")
+	}
+
+	return &S3{
+		cfg:  cfg,
+		base: base,
+		signer: signer{
+			accessKey: cfg.AccessKey,
+			secretKey: cfg.SecretKey,
+			region:    cfg.Region,
+		},
+		client: &http.Client{},
+		now:    time.Now,
+		log:    log.Named("storage"),
+	}, nil
+}
+
+// objectURL — адрес объекта key (без префикса); пустой key — адрес бакета.
+func (s *S3) objectURL(key string) *url.URL {
+	u := *s.base
+	path := strings.TrimSuffix(u.Path, "/")
+	if s.cfg.PathStyle {
+		path += "/" + s.cfg.Bucket
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+	}
+	path += "/"
+	if key != "" {
+		path += s.cfg.Prefix + key
+	}
+
+	u.Path = path
+	u.RawPath = canonicalURI(&url.URL{Path: path})
+	u.RawQuery = ""
+	return &u
+}
+
+// Put загружает объект одним запросом PUT; size должен совпадать с длиной body.
+func (s *S3) Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), body)
+	if err != nil {
+		return fmt.Errorf("build put request: %w", err)
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	resp.Body.Close()
+
+	s.log.Debug("object stored", zap.String("key", key), zap.Int64("size", size))
+	return nil
+}
+
+// PresignGet подписывает ссылку на скачивание объекта; к хранилищу не обращается.
+func (s *S3) PresignGet(key string, ttl time.Duration) (string, error) {
+	if ttl <= 0 || ttl > maxPresignExpiry {
+		return "", fmt.Errorf("presigned url ttl must be in (0, %s], got %s", maxPresignExpiry, ttl)
+	}
+	return s.signer.presign(http.MethodGet, s.objectURL(key), ttl, s.now()), nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List перебирает страницы ListObjectsV2 и возвращает все объекты под prefix.
+func (s *S3) List(ctx context.Context, prefix string) ([]repository.StoredObject, error) {
+	var (
+		objects []repository.StoredObject
+		token   string
+	)
+	for {
+		u := s.objectURL("")
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", s.cfg.Prefix+prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		u.RawQuery = canonicalQuery(query)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("build list request: %w", err)
+		}
+
+		resp, err := s.do(req, emptyPayloadHash)
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, err)
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode list response: %w", err)
+		}
+
+		for _, object := range page.Contents {
+			objects = append(objects, repository.StoredObject{
+				Key:          strings.TrimPrefix(object.Key, s.cfg.Prefix),
+				Size:         object.Size,
+				LastModified: object.LastModified,
+			})
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// Delete удаляет объект; S3 отвечает 204 и на отсутствующий ключ.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return fmt.Errorf("build delete request: %w", err)
+	}
+
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// HealthCheck проверяет, что бакет доступен с настроенными ключами (HEAD bucket).
+func (s *S3) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.objectURL("").String(), nil)
+	if err != nil {
+		return fmt.Errorf("build head request: %w", err)
+	}
+
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return fmt.Errorf("head bucket %s: %w", s.cfg.Bucket, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// s3Error — тело ответа S3 с ошибкой.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// do подписывает и отправляет запрос. Ответ не 2xx превращается в ошибку
+// с кодом S3 (NoSuchBucket, AccessDenied), тело закрывается.
+func (s *S3) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.signer.sign(req, payloadHash, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var body s3Error
+	// У HEAD тела нет, у части ошибок оно не XML — тогда хватит статуса.
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body); err != nil || body.Code == "" {
+		return nil, fmt.Errorf("storage responded with status %d", resp.StatusCode)
+	}
+	return nil, fmt.Errorf("storage responded with status %d: %s: %s", resp.StatusCode, body.Code, body.Message)
+}
@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Подпись запросов AWS Signature Version 4 — её понимают S3, MinIO и
+// прочие S3-совместимые хранилища.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+
+const (
+	signAlgorithm = "AWS4-HMAC-SHA256"
+	signService   = "s3"
+	amzDateFormat = "20060102T150405Z"
+
+	// unsignedPayload — тело не входит в подпись: его не нужно читать
+	// дважды, целостность обеспечивает TLS и Content-Length.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	// emptyPayloadHash — SHA-256 пустого тела.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// maxPresignExpiry — наибольший срок подписанной ссылки в SigV4.
+	maxPresignExpiry = 7 * 24 * time.Hour
+)
+
+type signer struct {
+	accessKey string
+	secretKey string
+	region    string
+}
+
+// sign подписывает запрос заголовком Authorization.
+func (s signer) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	canonicalHeaders, signedHeaders := canonicalHeaders(headers)
+
+	canonical := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signAlgorithm, s.accessKey, scope, signedHeaders, s.signature(canonical, amzDate, scope, now)))
+}
+
+// presign возвращает копию u с подписью в query: по такой ссылке запрос
+// method проходит без заголовков авторизации, пока не истечёт expires.
+func (s signer) presign(method string, u *url.URL, expires time.Duration, now time.Time) string {
+	amzDate := now.UTC().Format(amzDateFormat)
+	scope := s.scope(now)
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", signAlgorithm)
+	query.Set("X-Amz-Credential", s.accessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires/time.Second)))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalHeaders, signedHeaders := canonicalHeaders(map[string]string{"host": u.Host})
+	canonical := strings.Join([]string{
+		method,
+		canonicalURI(u),
+		canonicalQuery(query),
+		canonicalHeaders,
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	signed := *u
+	signed.RawQuery = canonicalQuery(query) + "&X-Amz-Signature=" + s.signature(canonical, amzDate, scope, now)
+	return signed.String()
+}
+
+func (s signer) scope(now time.Time) string {
+	return strings.Join([]string{now.UTC().Format("20060102"), s.region, signService, "aws4_request"}, "/")
+}
+
+func (s signer) signature(canonicalRequest, amzDate, scope string, now time.Time) string {
+	stringToSign := strings.Join([]string{signAlgorithm, amzDate, scope, sha256Hex(canonicalRequest)}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.UTC().Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, signService)
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalHeaders возвращает заголовки в каноническом виде и список их имён.
+func canonicalHeaders(headers map[string]string) (string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(strings.TrimSpace(headers[name]))
+		b.WriteByte('\n')
+	}
+	return b.String(), strings.Join(names, ";")
+}
+
+// canonicalURI кодирует путь по правилам SigV4 для S3: каждый сегмент
+// отдельно, без повторного кодирования.
+func canonicalURI(u *url.URL) string {
+	path := u.Path
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode — процентное кодирование RFC 3986: не кодируются только
+// буквы, цифры и -._~ (url.QueryEscape кодирует пробел как +).
+func uriEncode(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
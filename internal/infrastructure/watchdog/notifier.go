@@ -0,0 +1,91 @@
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/breaker"
+)
+
+type AlertStatus string
+
+const (
+	AlertStatusFiring   AlertStatus = "firing"
+	AlertStatusResolved AlertStatus = "resolved"
+)
+
+type Alert struct {
+	Status        AlertStatus `json:"status"`
+	Rule          string      `json:"rule"`
+	Service       string      `json:"service"`
+	Rate          float64     `json:"rate"`
+	Threshold     float64     `json:"threshold"`
+	Requests      int         `json:"requests"`
+	Window        string      `json:"window"`
+	BreachedSince time.Time   `json:"breached_since"`
+	Timestamp     time.Time   `json:"timestamp"`
+	Description   string      `json:"description"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
+
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// BreakerNotifier не обращается к приёмнику, пока автомат разомкнут: при
+// недоступном webhook каждый алерт не ждёт таймаут, а сразу получает
+// breaker.ErrOpen, который watchdog логирует как неотправленный алерт.
+type BreakerNotifier struct {
+	next    Notifier
+	breaker *breaker.Breaker
+}
+
+func NewBreakerNotifier(next Notifier, b *breaker.Breaker) *BreakerNotifier {
+	return &BreakerNotifier{next: next, breaker: b}
+}
+
+func (n *BreakerNotifier) Notify(ctx context.Context, alert Alert) error {
+	return n.breaker.Execute(func() error {
+		return n.next.Notify(ctx, alert)
+	})
+}
@@ -0,0 +1,193 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/config"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+const (
+	RuleServerErrorRate   = "server_error_rate"
+	RuleDatabaseErrorRate = "database_error_rate"
+)
+
+type rule struct {
+	name          string
+	threshold     float64
+	rate          func(Snapshot) float64
+	breachedSince time.Time
+	firing        bool
+	lastNotified  time.Time
+}
+
+type Watchdog struct {
+	cfg      config.WatchdogConfig
+	window   *rollingWindow
+	rules    []*rule
+	notifier Notifier
+	log      *logger.Logger
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+func New(cfg config.WatchdogConfig, notifier Notifier, log *logger.Logger) *Watchdog {
+	return &Watchdog{
+		cfg:    cfg,
+		window: newRollingWindow(cfg.WindowDuration()),
+		rules: []*rule{
+			{
+				name:      RuleServerErrorRate,
+				threshold: cfg.ServerErrorThreshold,
+				rate:      Snapshot.ServerErrorRate,
+			},
+			{
+				name:      RuleDatabaseErrorRate,
+				threshold: cfg.DBErrorThreshold,
+				rate:      Snapshot.DBErrorRate,
+			},
+		},
+		notifier: notifier,
+		log:      log.Named("watchdog"),
+	}
+}
+
+func (w *Watchdog) Record(status int, dbError bool) {
+	w.window.record(time.Now(), status >= http.StatusInternalServerError, dbError)
+}
+
+func (w *Watchdog) Snapshot() Snapshot {
+	return w.window.snapshot(time.Now())
+}
+
+func (w *Watchdog) Start(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stop != nil {
+		return
+	}
+
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+
+	w.log.Info("watchdog started",
+		zap.Duration("window", w.cfg.WindowDuration()),
+		zap.Duration("breach_duration", w.cfg.BreachDurationValue()),
+		zap.Float64("server_error_threshold", w.cfg.ServerErrorThreshold),
+		zap.Float64("db_error_threshold", w.cfg.DBErrorThreshold))
+
+	go w.loop(ctx, w.stop, w.done)
+}
+
+func (w *Watchdog) Stop() {
+	w.mu.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+	w.log.Info("watchdog stopped")
+}
+
+func (w *Watchdog) loop(ctx context.Context, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.cfg.CheckIntervalDuration())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			w.evaluate(ctx, now)
+		}
+	}
+}
+
+func (w *Watchdog) evaluate(ctx context.Context, now time.Time) {
+	snapshot := w.window.snapshot(now)
+
+	for _, r := range w.rules {
+		rate := r.rate(snapshot)
+		breached := snapshot.Requests >= w.cfg.MinRequests && rate >= r.threshold
+
+		if !breached {
+			if r.firing {
+				r.firing = false
+				w.notify(ctx, r, snapshot, rate, AlertStatusResolved, now)
+			}
+			r.breachedSince = time.Time{}
+			continue
+		}
+
+		if r.breachedSince.IsZero() {
+			r.breachedSince = now
+			w.log.Warn("watchdog threshold breached",
+				zap.String("rule", r.name),
+				zap.Float64("rate", rate),
+				zap.Float64("threshold", r.threshold))
+		}
+
+		if now.Sub(r.breachedSince) < w.cfg.BreachDurationValue() {
+			continue
+		}
+
+		if r.firing && now.Sub(r.lastNotified) < w.cfg.RepeatIntervalDuration() {
+			continue
+		}
+
+		r.firing = true
+		w.notify(ctx, r, snapshot, rate, AlertStatusFiring, now)
+	}
+}
+
+func (w *Watchdog) notify(ctx context.Context, r *rule, snapshot Snapshot, rate float64, status AlertStatus, now time.Time) {
+	r.lastNotified = now
+
+	alert := Alert{
+		Status:        status,
+		Rule:          r.name,
+		Service:       "subscription-service",
+		Rate:          rate,
+		Threshold:     r.threshold,
+		Requests:      snapshot.Requests,
+		Window:        w.cfg.WindowDuration().String(),
+		BreachedSince: r.breachedSince,
+		Timestamp:     now,
+		Description: fmt.Sprintf("%s is %.2f%% over the last %s (threshold %.2f%%)",
+			r.name, rate*100, w.cfg.WindowDuration(), r.threshold*100),
+	}
+
+	w.log.Error("watchdog alert",
+		zap.String("status", string(status)),
+		zap.String("rule", r.name),
+		zap.Float64("rate", rate),
+		zap.Int("requests", snapshot.Requests))
+
+	if w.notifier == nil {
+		return
+	}
+
+	if err := w.notifier.Notify(ctx, alert); err != nil {
+		w.log.Error("failed to deliver watchdog alert",
+			zap.String("rule", r.name),
+			zap.Error(err))
+	}
+}
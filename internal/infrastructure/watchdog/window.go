@@ -0,0 +1,88 @@
+package watchdog
+
+import (
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	second      int64
+	requests    int
+	serverError int
+	dbError     int
+}
+
+type Snapshot struct {
+	Requests    int
+	ServerError int
+	DBError     int
+}
+
+func (s Snapshot) ServerErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.ServerError) / float64(s.Requests)
+}
+
+func (s Snapshot) DBErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.DBError) / float64(s.Requests)
+}
+
+type rollingWindow struct {
+	mu      sync.Mutex
+	buckets []bucket
+}
+
+func newRollingWindow(size time.Duration) *rollingWindow {
+	seconds := int(size / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return &rollingWindow{
+		buckets: make([]bucket, seconds),
+	}
+}
+
+func (w *rollingWindow) record(now time.Time, serverError, dbError bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	b := w.bucketAt(now.Unix())
+	b.requests++
+	if serverError {
+		b.serverError++
+	}
+	if dbError {
+		b.dbError++
+	}
+}
+
+func (w *rollingWindow) snapshot(now time.Time) Snapshot {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	oldest := now.Unix() - int64(len(w.buckets)) + 1
+
+	var s Snapshot
+	for _, b := range w.buckets {
+		if b.second < oldest {
+			continue
+		}
+		s.Requests += b.requests
+		s.ServerError += b.serverError
+		s.DBError += b.dbError
+	}
+	return s
+}
+
+func (w *rollingWindow) bucketAt(second int64) *bucket {
+	b := &w.buckets[second%int64(len(w.buckets))]
+	if b.second != second {
+		*b = bucket{second: second}
+	}
+	return b
+}
@@ -0,0 +1,54 @@
+/*
+Package mocks — сгенерированные gomock-моки портов из internal/domain/ports.
+Файлы не правятся руками: после изменения интерфейса порта запустите
+
+	make mocks
+
+или go generate ./internal/mocks/.
+*/
+package mocks
+
+//go:generate mockgen -source=../domain/ports/repository/api_key_repository.go -destination=api_key_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/billing_command_repository.go -destination=billing_command_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/calendar_feed_repository.go -destination=calendar_feed_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/canonical_service_name_repository.go -destination=canonical_service_name_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/config_fingerprint_repository.go -destination=config_fingerprint_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/cost_alert_repository.go -destination=cost_alert_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/dead_letter_repository.go -destination=dead_letter_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/discount_repository.go -destination=discount_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/event_publisher.go -destination=event_publisher_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/lock_provider.go -destination=lock_provider_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/object_storage.go -destination=object_storage_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/partition_repository.go -destination=partition_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/plan_repository.go -destination=plan_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/service_catalog_repository.go -destination=service_catalog_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/service_name_rule_repository.go -destination=service_name_rule_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/subscription_comment_repository.go -destination=subscription_comment_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/subscription_event_repository.go -destination=subscription_event_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/subscription_limit_repository.go -destination=subscription_limit_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/subscription_member_repository.go -destination=subscription_member_repository_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/repository/subscription_repository.go -destination=subscription_repository_mock.go -package=mocks
+
+//go:generate mockgen -source=../domain/ports/service/analytics.go -destination=analytics_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/artifact.go -destination=artifact_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/auth.go -destination=auth_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/billing_command.go -destination=billing_command_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/calendar_feed.go -destination=calendar_feed_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/canonical_service_name.go -destination=canonical_service_name_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/config_consistency.go -destination=config_consistency_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/cost_alert.go -destination=cost_alert_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/dead_letter.go -destination=dead_letter_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/discount.go -destination=discount_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/expiry_reminder.go -destination=expiry_reminder_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/partition_maintenance.go -destination=partition_maintenance_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/plan.go -destination=plan_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/service_catalog.go -destination=service_catalog_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/service_name_normalization.go -destination=service_name_normalization_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/service_name_rule.go -destination=service_name_rule_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/spend_report.go -destination=spend_report_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/subscription_archive.go -destination=subscription_archive_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/subscription_bulk.go -destination=subscription_bulk_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/subscription_comment.go -destination=subscription_comment_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/subscription_limit.go -destination=subscription_limit_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/subscription_member.go -destination=subscription_member_service_mock.go -package=mocks
+//go:generate mockgen -source=../domain/ports/service/subscription_usecase.go -destination=subscription_usecase_mock.go -package=mocks
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+const maxTopServicesLimit = 100
+
+/*
+analyticsService — аналитика по всем подпискам для администраторов:
+рейтинг сервисов, MRR и отток. Агрегаты считаются по всей таблице,
+поэтому результат каждого запроса кешируется на cacheTTL.
+*/
+type analyticsService struct {
+	repo     repository.SubscriptionRepository
+	billing  models.BillingMode
+	cacheTTL time.Duration
+	log      *logger.Logger
+
+	mu    sync.Mutex
+	cache map[string]analyticsCacheEntry
+}
+
+type analyticsCacheEntry struct {
+	value    interface{}
+	loadedAt time.Time
+}
+
+/** Конструктор сервиса аналитики. */
+func NewAnalyticsService(repo repository.SubscriptionRepository, billing models.BillingMode, cacheTTL time.Duration, log *logger.Logger) *analyticsService {
+	return &analyticsService{
+		repo:     repo,
+		billing:  billing,
+		cacheTTL: cacheTTL,
+		log:      log.Named("analytics"),
+		cache:    make(map[string]analyticsCacheEntry),
+	}
+}
+
+/** Рейтинг сервисов за период по числу подписчиков или выручке. */
+func (s *analyticsService) GetTopServices(ctx context.Context, startDate, endDate, sortBy string, limit int, billing *models.BillingMode) (*models.TopServicesReport, error) {
+	period, err := s.parsePeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := models.ParseTopServicesSort(sortBy)
+	if err != nil {
+		return nil, apperror.InvalidInput("sort", err.Error())
+	}
+
+	if limit < 1 || limit > maxTopServicesLimit {
+		return nil, apperror.InvalidInput("limit", fmt.Sprintf("must be between 1 and %d", maxTopServicesLimit))
+	}
+
+	mode := billingModeOrDefault(billing, s.billing)
+	key := fmt.Sprintf("top-services:%s:%s:%s:%s:%d", period.From(), period.To(), mode, order, limit)
+
+	value, err := s.cached(key, func() (interface{}, error) {
+		services, err := s.repo.GetTopServices(ctx, period, mode, order, limit)
+		if err != nil {
+			return nil, err
+		}
+		return models.NewTopServicesReport(period, mode, order, services), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*models.TopServicesReport), nil
+}
+
+/** Помесячный MRR за период. */
+func (s *analyticsService) GetMRR(ctx context.Context, startDate, endDate string) (*models.MRRReport, error) {
+	period, err := s.parseSeriesPeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("mrr:%s:%s", period.From(), period.To())
+	value, err := s.cached(key, func() (interface{}, error) {
+		points, err := s.repo.GetMRR(ctx, period)
+		if err != nil {
+			return nil, err
+		}
+		return models.NewMRRReport(period, points), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*models.MRRReport), nil
+}
+
+/** Помесячный отток за период. */
+func (s *analyticsService) GetChurn(ctx context.Context, startDate, endDate string) (*models.ChurnReport, error) {
+	period, err := s.parseSeriesPeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("churn:%s:%s", period.From(), period.To())
+	value, err := s.cached(key, func() (interface{}, error) {
+		points, err := s.repo.GetChurn(ctx, period)
+		if err != nil {
+			return nil, err
+		}
+		return models.NewChurnReport(period, points), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*models.ChurnReport), nil
+}
+
+func (s *analyticsService) parsePeriod(startDate, endDate string) (models.DateRange, error) {
+	startTime, endTime, err := utils.ParseDateRange(startDate, endDate)
+	if err != nil {
+		return models.DateRange{}, err
+	}
+
+	if startTime == nil || endTime == nil {
+		return models.DateRange{}, apperror.InvalidInput("date_range", "both start_date and end_date are required")
+	}
+
+	period := models.NewDateRange(*startTime, *endTime)
+	if err := period.Validate(); err != nil {
+		return models.DateRange{}, apperror.InvalidDateRange(startDate, endDate)
+	}
+	return period, nil
+}
+
+// parseSeriesPeriod дополнительно ограничивает длину помесячного ряда.
+func (s *analyticsService) parseSeriesPeriod(startDate, endDate string) (models.DateRange, error) {
+	period, err := s.parsePeriod(startDate, endDate)
+	if err != nil {
+		return models.DateRange{}, err
+	}
+
+	if period.Months() > models.MaxAnalyticsMonths {
+		return models.DateRange{}, apperror.InvalidInput("date_range", fmt.Sprintf("must span at most %d months", models.MaxAnalyticsMonths))
+	}
+	return period, nil
+}
+
+/*
+cached возвращает результат, посчитанный не раньше cacheTTL назад, иначе
+вызывает load. Блокировка не держится во время запроса к БД: одинаковые
+запросы при холодном кеше могут посчитаться параллельно, это дешевле,
+чем очередь за одним мьютексом. Ошибки не кешируются.
+*/
+func (s *analyticsService) cached(key string, load func() (interface{}, error)) (interface{}, error) {
+	s.mu.Lock()
+	entry, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && time.Since(entry.loadedAt) < s.cacheTTL {
+		return entry.value, nil
+	}
+
+	started := time.Now()
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	now := time.Now()
+	for k, e := range s.cache {
+		if now.Sub(e.loadedAt) >= s.cacheTTL {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = analyticsCacheEntry{value: value, loadedAt: now}
+	s.mu.Unlock()
+
+	s.log.Debug("analytics computed",
+		zap.String("key", key),
+		zap.Duration("duration", time.Since(started)))
+
+	return value, nil
+}
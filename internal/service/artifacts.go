@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+artifactService выкладывает готовые файлы (выгрузки) в объектное хранилище
+и раздаёт подписанные ссылки на них. Хранилище не чистится само: файлы
+старше retention удаляет CleanupExpired по расписанию, поэтому ссылка
+живёт не дольше файла.
+*/
+type artifactService struct {
+	storage   repository.ObjectStorage
+	urlTTL    time.Duration
+	retention time.Duration
+	now       func() time.Time
+	log       *logger.Logger
+}
+
+/*
+Конструктор. urlTTL — срок ссылки, retention — срок хранения файла; ссылка
+не переживает файл, поэтому urlTTL ограничивается retention.
+*/
+func NewArtifactService(storage repository.ObjectStorage, urlTTL, retention time.Duration, log *logger.Logger) *artifactService {
+	return &artifactService{
+		storage:   storage,
+		urlTTL:    min(urlTTL, retention),
+		retention: retention,
+		now:       time.Now,
+		log:       log.Named("artifacts"),
+	}
+}
+
+func (s *artifactService) Store(ctx context.Context, kind models.ArtifactKind, filename, contentType string, body io.Reader, size int64) (*models.Artifact, error) {
+	now := s.now()
+	key := models.NewArtifactKey(kind, now, filename)
+
+	if err := s.storage.Put(ctx, key, contentType, body, size); err != nil {
+		s.log.Error("failed to store artifact", zap.String("key", key), zap.Error(err))
+		return nil, apperror.ServiceUnavailable("object-storage", err)
+	}
+
+	url, err := s.storage.PresignGet(key, s.urlTTL)
+	if err != nil {
+		s.log.Error("failed to presign artifact url", zap.String("key", key), zap.Error(err))
+		return nil, apperror.InternalError("failed to sign download link", err)
+	}
+
+	s.log.Info("artifact stored",
+		zap.String("key", key),
+		zap.Int64("size", size))
+	return models.NewArtifact(key, size, url, now.Add(s.urlTTL).UTC()), nil
+}
+
+// CleanupExpired обходит префиксы всех видов файлов и удаляет загруженные
+// раньше now - retention. Ошибка удаления прерывает проход: оставшиеся
+// файлы удалит следующий запуск.
+func (s *artifactService) CleanupExpired(ctx context.Context) (int, error) {
+	cutoff := s.now().Add(-s.retention)
+
+	deleted := 0
+	for _, kind := range models.ArtifactKinds {
+		objects, err := s.storage.List(ctx, string(kind)+"/")
+		if err != nil {
+			return deleted, err
+		}
+		for _, object := range objects {
+			if !object.LastModified.Before(cutoff) {
+				continue
+			}
+			if err := s.storage.Delete(ctx, object.Key); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+
+	if deleted > 0 {
+		s.log.Info("expired artifacts deleted",
+			zap.Int("count", deleted),
+			zap.Time("uploaded_before", cutoff))
+	}
+	return deleted, nil
+}
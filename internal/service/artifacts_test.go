@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+func TestArtifactService(t *testing.T) {
+	now := time.Date(2025, 7, 15, 10, 30, 0, 0, time.UTC)
+
+	newService := func(t *testing.T) (*artifactService, *mocks.MockObjectStorage) {
+		storage := mocks.NewMockObjectStorage(gomock.NewController(t))
+		// Ссылка на 2 часа при хранении в 1 час укорачивается до часа.
+		s := NewArtifactService(storage, 2*time.Hour, time.Hour, testLogger(t))
+		s.now = func() time.Time { return now }
+		return s, storage
+	}
+
+	t.Run("store uploads under a dated key and presigns it", func(t *testing.T) {
+		s, storage := newService(t)
+		var key string
+		storage.EXPECT().Put(gomock.Any(), gomock.Any(), "text/csv", gomock.Any(), int64(3)).
+			DoAndReturn(func(_ context.Context, k, _ string, _ any, _ int64) error {
+				key = k
+				return nil
+			})
+		storage.EXPECT().PresignGet(gomock.Any(), time.Hour).Return("https://storage/link", nil)
+
+		artifact, err := s.Store(context.Background(), models.ArtifactExport, "subscriptions.csv", "text/csv", strings.NewReader("id\n"), 3)
+		if err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		if !strings.HasPrefix(key, "exports/2025/07/15/") || !strings.HasSuffix(key, "/subscriptions.csv") || artifact.Key() != key {
+			t.Fatalf("key = %q, artifact key = %q", key, artifact.Key())
+		}
+		if artifact.URL() != "https://storage/link" || !artifact.ExpiresAt().Equal(now.Add(time.Hour)) {
+			t.Fatalf("artifact = %s until %s", artifact.URL(), artifact.ExpiresAt())
+		}
+	})
+
+	t.Run("storage failure is a service unavailable error", func(t *testing.T) {
+		s, storage := newService(t)
+		storage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+
+		_, err := s.Store(context.Background(), models.ArtifactExport, "subscriptions.csv", "text/csv", strings.NewReader(""), 0)
+		appErr, ok := apperror.IsAppError(err)
+		if !ok || appErr.Code() != apperror.CodeServiceUnavailable {
+			t.Fatalf("Store() error = %v, want service unavailable", err)
+		}
+	})
+
+	t.Run("cleanup deletes only files older than retention", func(t *testing.T) {
+		s, storage := newService(t)
+		storage.EXPECT().List(gomock.Any(), "exports/").Return([]repository.StoredObject{
+			{Key: "exports/old.csv", LastModified: now.Add(-2 * time.Hour)},
+			{Key: "exports/fresh.csv", LastModified: now.Add(-time.Minute)},
+		}, nil)
+		storage.EXPECT().Delete(gomock.Any(), "exports/old.csv").Return(nil)
+
+		deleted, err := s.CleanupExpired(context.Background())
+		if err != nil || deleted != 1 {
+			t.Fatalf("CleanupExpired() = %d, %v, want 1", deleted, err)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/** Параметры проверки JWT; пустой Secret — JWT не принимаются. */
+type JWTOptions struct {
+	Secret    string
+	Issuer    string
+	Audience  string
+	RoleClaim string
+}
+
+/*
+authService определяет роль клиента. Источники по порядку: статический
+ключ администратора, ключ из таблицы api_keys, JWT, подписанный HS256.
+*/
+type authService struct {
+	keys     repository.APIKeyRepository
+	adminKey string
+	jwt      JWTOptions
+	parser   *jwt.Parser
+	log      *logger.Logger
+}
+
+/** Конструктор сервиса аутентификации. */
+func NewAuthService(keys repository.APIKeyRepository, adminKey string, jwtOptions JWTOptions, log *logger.Logger) *authService {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if jwtOptions.Issuer != "" {
+		options = append(options, jwt.WithIssuer(jwtOptions.Issuer))
+	}
+	if jwtOptions.Audience != "" {
+		options = append(options, jwt.WithAudience(jwtOptions.Audience))
+	}
+
+	return &authService{
+		keys:     keys,
+		adminKey: adminKey,
+		jwt:      jwtOptions,
+		parser:   jwt.NewParser(options...),
+		log:      log.Named("auth"),
+	}
+}
+
+func (s *authService) Authenticate(ctx context.Context, apiKey, bearerToken string) (*models.Principal, error) {
+	switch {
+	case apiKey != "":
+		return s.authenticateAPIKey(ctx, apiKey)
+	case bearerToken != "":
+		return s.authenticateJWT(bearerToken)
+	default:
+		return nil, apperror.Unauthorized("credentials are required: send X-API-Key or Authorization: Bearer <token>")
+	}
+}
+
+func (s *authService) authenticateAPIKey(ctx context.Context, apiKey string) (*models.Principal, error) {
+	if s.adminKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(s.adminKey)) == 1 {
+		return models.NewPrincipal("admin-key", models.RoleAdmin, models.PrincipalSourceAdminKey), nil
+	}
+
+	key, err := s.keys.GetByHash(ctx, models.HashAPIKey(apiKey))
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, apperror.Unauthorized("unknown api key")
+	}
+	if key.IsRevoked() {
+		return nil, apperror.Unauthorized("api key has been revoked")
+	}
+
+	return models.NewPrincipal(key.ID().String(), key.Role(), models.PrincipalSourceAPIKey), nil
+}
+
+func (s *authService) authenticateJWT(token string) (*models.Principal, error) {
+	if s.jwt.Secret == "" {
+		return nil, apperror.Unauthorized("bearer tokens are not accepted")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := s.parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(s.jwt.Secret), nil
+	})
+	if err != nil {
+		return nil, apperror.Unauthorized(fmt.Sprintf("invalid token: %v", err))
+	}
+
+	role, ok := roleFromClaim(claims[s.jwt.RoleClaim])
+	if !ok {
+		return nil, apperror.Unauthorized(fmt.Sprintf("token has no known role in claim %q", s.jwt.RoleClaim))
+	}
+
+	subject, _ := claims.GetSubject()
+	return models.NewPrincipal(subject, role, models.PrincipalSourceJWT), nil
+}
+
+/*
+roleFromClaim принимает роль строкой или списком строк; из списка
+берётся самая широкая известная роль.
+*/
+func roleFromClaim(claim interface{}) (models.Role, bool) {
+	var values []string
+	switch value := claim.(type) {
+	case string:
+		values = []string{value}
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	for _, role := range models.Roles {
+		for _, value := range values {
+			if value == string(role) {
+				return role, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (s *authService) CreateAPIKey(ctx context.Context, name, role string) (*models.APIKey, string, error) {
+	parsedRole, err := models.ParseRole(role)
+	if err != nil {
+		return nil, "", apperror.InvalidInput("role", err.Error())
+	}
+
+	key, secret, err := models.NewAPIKey(name, parsedRole)
+	if err != nil {
+		return nil, "", apperror.InvalidInput("name", err.Error())
+	}
+
+	if err := s.keys.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	s.log.Info("api key created",
+		zap.String("api_key_id", key.ID().String()),
+		zap.String("name", key.Name()),
+		zap.String("role", string(key.Role())))
+
+	return key, secret, nil
+}
+
+func (s *authService) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	return s.keys.List(ctx)
+}
+
+func (s *authService) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	key, err := s.keys.Revoke(ctx, id, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("api key revoked", zap.String("api_key_id", id.String()))
+	return key, nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// maxCommandIDLength совпадает с размером колонки billing_commands.command_id.
+const maxCommandIDLength = 128
+
+// errCommandRaced — итог команды сохранила параллельная доставка; своё
+// изменение откатывается.
+var errCommandRaced = errors.New("billing command already processed concurrently")
+
+/*
+billingCommandService — команды legacy-биллинга поверх SubscriptionService.
+Изменение подписки, её событие и итог команды коммитятся одной
+транзакцией, поэтому повторная доставка после сбоя либо не найдёт итога
+и применит команду заново, либо найдёт его и вернёт без изменений.
+*/
+type billingCommandService struct {
+	subscriptions service.SubscriptionService
+	commands      repository.BillingCommandRepository
+	tx            repository.Transactor
+	log           *logger.Logger
+}
+
+/** Конструктор сервиса команд биллинга. */
+func NewBillingCommandService(subscriptions service.SubscriptionService, commands repository.BillingCommandRepository, tx repository.Transactor, log *logger.Logger) *billingCommandService {
+	return &billingCommandService{
+		subscriptions: subscriptions,
+		commands:      commands,
+		tx:            tx,
+		log:           log.Named("billing-commands"),
+	}
+}
+
+func (s *billingCommandService) CreateSubscription(ctx context.Context, commandID string, serviceName string, price int, userID uuid.UUID, startDate string, endDate *string, planID *uuid.UUID) (*models.BillingCommandResult, error) {
+	return s.execute(ctx, commandID, models.BillingCommandCreateSubscription, func(ctx context.Context) (uuid.UUID, error) {
+		subscription, err := s.subscriptions.CreateSubscription(ctx, serviceName, price, userID, startDate, endDate, nil, planID, nil, nil, nil, nil, nil, "", nil)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return subscription.ID(), nil
+	})
+}
+
+func (s *billingCommandService) CancelSubscription(ctx context.Context, commandID string, subscriptionID, userID uuid.UUID, endDate *string) (*models.BillingCommandResult, error) {
+	return s.execute(ctx, commandID, models.BillingCommandCancelSubscription, func(ctx context.Context) (uuid.UUID, error) {
+		subscription, err := s.subscriptions.GetSubscriptionByID(ctx, subscriptionID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		// Биллинг знает владельца; расхождение — ошибка в команде, а не
+		// повод отменить чужую подписку.
+		if subscription.UserID() != userID {
+			return uuid.Nil, apperror.InvalidInput("user_id", "subscription belongs to another user")
+		}
+
+		end := utils.FormatISOMonth(time.Now())
+		if endDate != nil && *endDate != "" {
+			end = *endDate
+		}
+		if _, err := s.subscriptions.UpdateSubscription(ctx, subscriptionID, nil, nil, nil, &end, nil, nil, nil, nil, nil, nil); err != nil {
+			return uuid.Nil, err
+		}
+		return subscriptionID, nil
+	})
+}
+
+/*
+execute применяет команду не более одного раза:
+  - итог уже сохранён — возвращается он (Replayed);
+  - apply и сохранение итога идут в одной транзакции; если параллельная
+    доставка успела сохранить итог раньше, транзакция откатывается и
+    возвращается её итог;
+  - отказ по бизнес-правилам сохраняется как failed, чтобы повтор команды
+    получил тот же ответ;
+  - временная ошибка возвращается как есть, итог не сохраняется.
+*/
+func (s *billingCommandService) execute(ctx context.Context, commandID, commandType string, apply func(ctx context.Context) (uuid.UUID, error)) (*models.BillingCommandResult, error) {
+	if commandID == "" {
+		return nil, apperror.InvalidInput("command_id", "cannot be empty")
+	}
+	if len(commandID) > maxCommandIDLength {
+		return nil, apperror.InvalidInput("command_id", "must be at most 128 characters")
+	}
+
+	stored, err := s.commands.Get(ctx, commandID)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		s.log.Info("billing command already processed, replaying result",
+			zap.String("command_id", commandID),
+			zap.String("status", string(stored.Status())))
+		return stored, nil
+	}
+
+	var result *models.BillingCommandResult
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		subscriptionID, err := apply(ctx)
+		if err != nil {
+			return err
+		}
+
+		result = models.NewBillingCommandSucceeded(commandID, commandType, subscriptionID)
+		saved, err := s.commands.Save(ctx, result)
+		if err != nil {
+			return err
+		}
+		if !saved {
+			return errCommandRaced
+		}
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		s.log.Info("billing command applied",
+			zap.String("command_id", commandID),
+			zap.String("command_type", commandType),
+			zap.String("subscription_id", result.SubscriptionID().String()))
+		return result, nil
+	case errors.Is(err, errCommandRaced):
+		return s.storedResult(ctx, commandID)
+	}
+
+	appErr, ok := apperror.IsAppError(err)
+	if !ok || isTransientError(appErr) {
+		s.log.Warn("billing command failed transiently",
+			zap.String("command_id", commandID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	result = models.NewBillingCommandFailed(commandID, commandType, appErr.Code(), rejectionMessage(appErr))
+	saved, err := s.commands.Save(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return s.storedResult(ctx, commandID)
+	}
+
+	s.log.Info("billing command rejected",
+		zap.String("command_id", commandID),
+		zap.String("command_type", commandType),
+		zap.String("error_code", appErr.Code()))
+	return result, nil
+}
+
+func (s *billingCommandService) storedResult(ctx context.Context, commandID string) (*models.BillingCommandResult, error) {
+	stored, err := s.commands.Get(ctx, commandID)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, apperror.InternalError("billing command result disappeared", nil).WithDetail("command_id", commandID)
+	}
+	return stored, nil
+}
+
+// isTransientError — сбой инфраструктуры: повтор той же команды может
+// пройти, поэтому отказ не сохраняется.
+func isTransientError(err *apperror.AppError) bool {
+	switch err.Code() {
+	case apperror.CodeDatabaseError,
+		apperror.CodeInternalError,
+		apperror.CodeExternalServiceError,
+		apperror.CodeServiceUnavailable,
+		apperror.CodeTooManyRequests:
+		return true
+	}
+	return false
+}
+
+// rejectionMessage дополняет сообщение причиной из деталей ошибки: биллингу
+// нужно понять, что поправить в команде.
+func rejectionMessage(err *apperror.AppError) string {
+	if reason := err.Details()["reason"]; reason != "" {
+		return err.Message() + ": " + reason
+	}
+	return err.Message()
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+/*
+calendarFeedService — iCalendar-лента списаний и окончаний подписок.
+Ленту открывают календари по ссылке с токеном, без ключа API, поэтому
+токен — единственная проверка доступа: неизвестный, чужой и отозванный
+токены неотличимы. События берутся из прогноза трат на
+CalendarFeedMonths месяцев начиная с текущего.
+*/
+type calendarFeedService struct {
+	repo          repository.CalendarFeedRepository
+	subscriptions repository.SubscriptionRepository
+	now           func() time.Time
+	log           *logger.Logger
+}
+
+/** Конструктор сервиса ленты календаря. */
+func NewCalendarFeedService(repo repository.CalendarFeedRepository, subscriptions repository.SubscriptionRepository, log *logger.Logger) *calendarFeedService {
+	return &calendarFeedService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		now:           time.Now,
+		log:           log.Named("calendar-feed"),
+	}
+}
+
+/** Выпускает токен ленты; прежняя ссылка перестаёт работать. */
+func (s *calendarFeedService) IssueToken(ctx context.Context, userID uuid.UUID) (*models.CalendarFeedToken, string, error) {
+	if userID == uuid.Nil {
+		return nil, "", apperror.InvalidUserID(userID.String())
+	}
+
+	token, secret, err := models.NewCalendarFeedToken(userID)
+	if err != nil {
+		return nil, "", apperror.InternalError("Failed to generate calendar feed token", err)
+	}
+
+	if err := s.repo.Save(ctx, token); err != nil {
+		return nil, "", err
+	}
+
+	s.log.Info("calendar feed token issued", zap.String("user_id", userID.String()))
+	return token, secret, nil
+}
+
+/** Отзывает токен ленты пользователя. */
+func (s *calendarFeedService) RevokeToken(ctx context.Context, userID uuid.UUID) error {
+	if userID == uuid.Nil {
+		return apperror.InvalidUserID(userID.String())
+	}
+
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.log.Info("calendar feed token revoked", zap.String("user_id", userID.String()))
+	return nil
+}
+
+/** Проверяет токен и собирает ленту пользователя. */
+func (s *calendarFeedService) GetFeed(ctx context.Context, userID uuid.UUID, token string) (*models.CalendarFeed, error) {
+	if token == "" {
+		return nil, apperror.Unauthorized("calendar feed token is required")
+	}
+
+	stored, err := s.repo.GetByUser(ctx, userID)
+	if err != nil {
+		if appErr, ok := apperror.IsAppError(err); ok && appErr.Code() == apperror.CodeNotFound {
+			return nil, apperror.Unauthorized("invalid calendar feed token")
+		}
+		return nil, err
+	}
+	if !stored.Matches(token) {
+		return nil, apperror.Unauthorized("invalid calendar feed token")
+	}
+
+	from := utils.StartOfMonth(s.now().UTC())
+	forecast, err := s.subscriptions.GetForecast(ctx, userID, from, models.CalendarFeedMonths, models.BillingMonthly)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := models.NewCalendarFeed(userID, forecast)
+
+	s.log.Debug("calendar feed built",
+		zap.String("user_id", userID.String()),
+		zap.Int("events", len(feed.Events())))
+
+	return feed, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+func newTestCalendarFeedService(t *testing.T) (*calendarFeedService, *mocks.MockCalendarFeedRepository, *mocks.MockSubscriptionRepository) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockCalendarFeedRepository(ctrl)
+	subscriptions := mocks.NewMockSubscriptionRepository(ctrl)
+
+	s := NewCalendarFeedService(repo, subscriptions, testLogger(t))
+	s.now = func() time.Time { return july }
+	return s, repo, subscriptions
+}
+
+func TestCalendarFeedService_IssueToken(t *testing.T) {
+	userID := uuid.New()
+	s, repo, _ := newTestCalendarFeedService(t)
+
+	var saved *models.CalendarFeedToken
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token *models.CalendarFeedToken) error {
+		saved = token
+		return nil
+	})
+
+	token, secret, err := s.IssueToken(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("IssueToken() error = %v", err)
+	}
+	if token != saved || token.UserID() != userID || !token.Matches(secret) || token.TokenHash() == secret {
+		t.Errorf("token for %s does not match the returned secret", token.UserID())
+	}
+}
+
+func TestCalendarFeedService_GetFeed(t *testing.T) {
+	userID := uuid.New()
+	stored, secret, err := models.NewCalendarFeedToken(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		stored error
+		code   string
+	}{
+		{name: "valid token", token: secret},
+		{name: "missing token", token: "", code: apperror.CodeUnauthorized},
+		{name: "wrong token", token: "cal_wrong", code: apperror.CodeUnauthorized},
+		{name: "no token issued", token: secret, stored: apperror.NotFound("calendar feed token"), code: apperror.CodeUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, subscriptions := newTestCalendarFeedService(t)
+			if tt.token != "" {
+				if tt.stored != nil {
+					repo.EXPECT().GetByUser(gomock.Any(), userID).Return(nil, tt.stored)
+				} else {
+					repo.EXPECT().GetByUser(gomock.Any(), userID).Return(stored, nil)
+				}
+			}
+
+			from := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+			sub := models.NewSubscription("Netflix", models.Rubles(799), userID, time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC))
+			endDate := time.Date(2025, time.August, 20, 0, 0, 0, 0, time.UTC)
+			sub.SetEndDate(&endDate)
+			billingDay := 31
+			sub.SetBillingDay(&billingDay)
+
+			if tt.code == "" {
+				forecast := models.NewCostForecast(from, models.CalendarFeedMonths, models.BillingMonthly)
+				forecast.MonthOf(from).AddSubscription(sub, nil, models.NewPriceSchedule(799, nil))
+				forecast.MonthOf(endDate).AddSubscription(sub, nil, models.NewPriceSchedule(799, nil))
+				subscriptions.EXPECT().GetForecast(gomock.Any(), userID, from, models.CalendarFeedMonths, models.BillingMonthly).Return(forecast, nil)
+			}
+
+			feed, err := s.GetFeed(context.Background(), userID, tt.token)
+			assertErrorCode(t, err, tt.code)
+			if tt.code != "" {
+				return
+			}
+
+			// Списание 31 июля; в августе подписка заканчивается 20-го, раньше дня списания.
+			events := feed.Events()
+			if len(events) != 2 {
+				t.Fatalf("got %d events, want 2", len(events))
+			}
+			if events[0].Kind() != models.CalendarFeedCharge || !events[0].Date().Equal(time.Date(2025, time.July, 31, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("first event: %s on %s", events[0].Kind(), events[0].Date())
+			}
+			if events[1].Kind() != models.CalendarFeedEnd || !events[1].Date().Equal(endDate) {
+				t.Errorf("second event: %s on %s", events[1].Kind(), events[1].Date())
+			}
+		})
+	}
+}
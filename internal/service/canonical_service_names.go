@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+/*
+CanonicalServiceNames — словарь канонических названий сервисов. При
+создании и изменении подписки название заменяется каноническим, а
+задача service-name-normalization приводит к нему уже сохранённые
+подписки. Словарь кешируется на cacheTTL, как ServiceNameRules.
+*/
+type CanonicalServiceNames struct {
+	repo     repository.CanonicalServiceNameRepository
+	cacheTTL time.Duration
+	log      *logger.Logger
+
+	mu       sync.Mutex
+	dict     *models.ServiceNameDictionary
+	loadedAt time.Time
+}
+
+/** Конструктор. */
+func NewCanonicalServiceNames(repo repository.CanonicalServiceNameRepository, cacheTTL time.Duration, log *logger.Logger) *CanonicalServiceNames {
+	return &CanonicalServiceNames{
+		repo:     repo,
+		cacheTTL: cacheTTL,
+		log:      log.Named("canonical-service-names"),
+	}
+}
+
+/*
+AddEntry добавляет запись; название и варианты очищаются так же, как
+названия подписок. Написание, которое уже относится к другой записи, —
+CONFLICT: иначе одно название приводилось бы к двум вариантам.
+*/
+func (s *CanonicalServiceNames) AddEntry(ctx context.Context, name string, aliases []string) (*models.CanonicalServiceName, error) {
+	sanitized := make([]string, len(aliases))
+	for i, alias := range aliases {
+		sanitized[i] = utils.SanitizeText(alias)
+	}
+	entry := models.NewCanonicalServiceName(utils.SanitizeText(name), sanitized)
+	if err := entry.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("entry", err.Error())
+	}
+
+	existing, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dict := models.NewServiceNameDictionary(existing)
+	for _, spelling := range append([]string{entry.Name()}, entry.Aliases()...) {
+		if owner := dict.Lookup(spelling); owner != nil {
+			return nil, apperror.New(apperror.CodeConflict, "Service name spelling already belongs to another entry").
+				WithDetail("spelling", spelling).
+				WithDetail("canonical", owner.Name())
+		}
+	}
+
+	if err := s.repo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	s.log.Info("canonical service name added",
+		zap.String("entry_id", entry.ID().String()),
+		zap.String("name", entry.Name()),
+		zap.Strings("aliases", entry.Aliases()))
+
+	return entry, nil
+}
+
+/** Возвращает все записи в порядке добавления. */
+func (s *CanonicalServiceNames) ListEntries(ctx context.Context) ([]*models.CanonicalServiceName, error) {
+	return s.repo.List(ctx)
+}
+
+/** Удаляет запись; уже изменённые подписки остаются как есть. */
+func (s *CanonicalServiceNames) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return apperror.InvalidInput("id", "cannot be empty")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	s.log.Info("canonical service name deleted", zap.String("entry_id", id.String()))
+	return nil
+}
+
+/*
+Canonicalize возвращает каноническое написание названия; без записи в
+словаре — название как есть. Безопасен для nil: без словаря названия не
+меняются.
+*/
+func (s *CanonicalServiceNames) Canonicalize(ctx context.Context, name string) (string, error) {
+	if s == nil {
+		return name, nil
+	}
+
+	dict, err := s.currentDictionary(ctx)
+	if err != nil {
+		return "", err
+	}
+	return dict.Canonical(name), nil
+}
+
+/*
+currentDictionary перечитывает словарь по истечении cacheTTL. Если БД
+недоступна, но словарь уже загружался, используется прежняя версия.
+*/
+func (s *CanonicalServiceNames) currentDictionary(ctx context.Context) (*models.ServiceNameDictionary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dict != nil && time.Since(s.loadedAt) < s.cacheTTL {
+		return s.dict, nil
+	}
+
+	entries, err := s.repo.List(ctx)
+	if err != nil {
+		if s.dict != nil {
+			s.log.Warn("failed to refresh canonical service names, using cached dictionary", zap.Error(err))
+			return s.dict, nil
+		}
+		return nil, err
+	}
+
+	s.dict = models.NewServiceNameDictionary(entries)
+	s.loadedAt = time.Now()
+	return s.dict, nil
+}
+
+func (s *CanonicalServiceNames) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
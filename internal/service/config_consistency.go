@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+configConsistencyService — публикует хэш эффективной конфигурации
+текущего инстанса в общую таблицу и сравнивает его с хэшами остальных реплик.
+*/
+type configConsistencyService struct {
+	repo       repository.ConfigFingerprintRepository
+	instanceID string
+	hash       string
+	staleAfter time.Duration
+	log        *logger.Logger
+}
+
+/** Конструктор сервиса. instanceID строится из hostname и pid процесса. */
+func NewConfigConsistencyService(repo repository.ConfigFingerprintRepository, hash string, staleAfter time.Duration, log *logger.Logger) *configConsistencyService {
+	return &configConsistencyService{
+		repo:       repo,
+		instanceID: instanceID(),
+		hash:       hash,
+		staleAfter: staleAfter,
+		log:        log.Named("config-consistency"),
+	}
+}
+
+/** Публикует (или обновляет) отпечаток конфигурации текущего инстанса. */
+func (s *configConsistencyService) Publish(ctx context.Context) error {
+	fingerprint := models.NewConfigFingerprint(s.instanceID, s.hash)
+	if err := s.repo.Upsert(ctx, fingerprint); err != nil {
+		return err
+	}
+
+	s.log.Debug("config fingerprint published",
+		zap.String("instance_id", s.instanceID),
+		zap.String("config_hash", s.hash))
+
+	return nil
+}
+
+/*
+Check — сравнивает отпечатки реплик, отчитавшихся за последние staleAfter,
+с локальным. Расхождения логируются как предупреждения.
+*/
+func (s *configConsistencyService) Check(ctx context.Context) (*models.ConfigConsistencyReport, error) {
+	instances, err := s.repo.ListReportedSince(ctx, time.Now().Add(-s.staleAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	report := models.NewConfigConsistencyReport(models.NewConfigFingerprint(s.instanceID, s.hash), instances)
+
+	for _, instance := range report.Mismatched() {
+		s.log.Warn("replica configuration differs from this instance",
+			zap.String("instance_id", s.instanceID),
+			zap.String("config_hash", s.hash),
+			zap.String("replica_instance_id", instance.InstanceID()),
+			zap.String("replica_config_hash", instance.Hash()),
+			zap.Time("replica_reported_at", instance.ReportedAt()))
+	}
+
+	return report, nil
+}
+
+/*
+Run — публикует отпечаток и проверяет согласованность при старте
+и затем каждые interval, пока не отменён контекст.
+*/
+func (s *configConsistencyService) Run(ctx context.Context, interval time.Duration) {
+	s.log.Info("config consistency check started",
+		zap.String("instance_id", s.instanceID),
+		zap.String("config_hash", s.hash),
+		zap.Duration("interval", interval))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *configConsistencyService) tick(ctx context.Context) {
+	if err := s.Publish(ctx); err != nil {
+		s.log.Error("failed to publish config fingerprint", zap.Error(err))
+		return
+	}
+
+	if _, err := s.Check(ctx); err != nil {
+		s.log.Error("failed to check config consistency", zap.Error(err))
+	}
+}
+
+func instanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
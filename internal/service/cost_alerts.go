@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+/*
+costAlertService — пороги месячных трат. Траты текущего месяца считаются
+через CalculateTotalCost, как в GET /costs/calculate, с текущими ценами и
+режимом расчёта пользователя. Уведомление о превышении отмечается в той
+же транзакции, что и событие cost_alert.exceeded, поэтому за месяц по
+порогу уходит ровно одно уведомление, даже на нескольких репликах. После
+коммита событие публикуется в шину (publisher может быть nil).
+*/
+type costAlertService struct {
+	repo          repository.CostAlertRepository
+	subscriptions service.SubscriptionService
+	events        repository.SubscriptionEventRepository
+	tx            repository.Transactor
+	publisher     repository.EventPublisher
+	batchSize     int
+	now           func() time.Time
+	log           *logger.Logger
+}
+
+/** Конструктор сервиса порогов трат. */
+func NewCostAlertService(repo repository.CostAlertRepository, subscriptions service.SubscriptionService, events repository.SubscriptionEventRepository, tx repository.Transactor, publisher repository.EventPublisher, batchSize int, log *logger.Logger) *costAlertService {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return &costAlertService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		events:        events,
+		tx:            tx,
+		publisher:     publisher,
+		batchSize:     batchSize,
+		now:           time.Now,
+		log:           log.Named("cost-alerts"),
+	}
+}
+
+/** Добавляет порог; у пользователя не может быть двух одинаковых порогов. */
+func (s *costAlertService) CreateAlert(ctx context.Context, userID uuid.UUID, monthlyLimit int) (*models.CostAlert, error) {
+	if userID == uuid.Nil {
+		return nil, apperror.InvalidUserID(userID.String())
+	}
+
+	alert := models.NewCostAlert(userID, monthlyLimit)
+	if err := alert.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("cost_alert", err.Error())
+	}
+
+	if err := s.repo.Create(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("cost alert created",
+		zap.String("alert_id", alert.ID().String()),
+		zap.String("user_id", userID.String()),
+		zap.Int("monthly_limit", monthlyLimit))
+
+	return alert, nil
+}
+
+/** Пороги пользователя по возрастанию. */
+func (s *costAlertService) ListAlerts(ctx context.Context, userID uuid.UUID) ([]*models.CostAlert, error) {
+	if userID == uuid.Nil {
+		return nil, apperror.InvalidUserID(userID.String())
+	}
+	return s.repo.ListByUser(ctx, userID)
+}
+
+/** Удаляет порог; чужой порог не находится. */
+func (s *costAlertService) DeleteAlert(ctx context.Context, userID, id uuid.UUID) error {
+	if userID == uuid.Nil {
+		return apperror.InvalidUserID(userID.String())
+	}
+
+	alert, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if alert.UserID() != userID {
+		return apperror.NotFound("cost alert")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.log.Info("cost alert deleted", zap.String("alert_id", id.String()))
+	return nil
+}
+
+/*
+GetAlertStatuses — состояние порогов пользователя за текущий месяц. Без
+порогов траты не считаются.
+*/
+func (s *costAlertService) GetAlertStatuses(ctx context.Context, userID uuid.UUID) ([]*models.CostAlertStatus, error) {
+	alerts, err := s.ListAlerts(ctx, userID)
+	if err != nil || len(alerts) == 0 {
+		return []*models.CostAlertStatus{}, err
+	}
+
+	month := utils.StartOfMonth(s.now().UTC())
+	spent, err := s.monthSpend(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	notified, err := s.repo.NotifiedAt(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*models.CostAlertStatus, len(alerts))
+	for i, alert := range alerts {
+		var notifiedAt *time.Time
+		if at, ok := notified[alert.ID()]; ok {
+			notifiedAt = &at
+		}
+		statuses[i] = models.NewCostAlertStatus(alert, month, spent, notifiedAt)
+	}
+	return statuses, nil
+}
+
+/*
+EvaluateAlerts сверяет с тратами текущего месяца все пороги, по которым в
+этом месяце ещё не было уведомления, пачками по batchSize. Траты
+пользователя считаются один раз за запуск. Возвращает число отправленных
+уведомлений.
+*/
+func (s *costAlertService) EvaluateAlerts(ctx context.Context) (int, error) {
+	month := utils.StartOfMonth(s.now().UTC())
+	spent := make(map[uuid.UUID]int)
+
+	sent := 0
+	after := uuid.Nil
+	for {
+		alerts, err := s.repo.ListUnnotified(ctx, month, after, s.batchSize)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, alert := range alerts {
+			after = alert.ID()
+
+			userSpent, ok := spent[alert.UserID()]
+			if !ok {
+				if userSpent, err = s.monthSpend(ctx, alert.UserID(), month); err != nil {
+					return sent, err
+				}
+				spent[alert.UserID()] = userSpent
+			}
+
+			status := models.NewCostAlertStatus(alert, month, userSpent, nil)
+			if !status.Exceeded() {
+				continue
+			}
+
+			ok, err := s.notify(ctx, status)
+			if err != nil {
+				return sent, err
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if len(alerts) < s.batchSize {
+			break
+		}
+	}
+
+	if sent > 0 {
+		s.log.Info("cost alerts sent",
+			zap.Int("count", sent),
+			zap.String("month", utils.FormatISOMonth(month)))
+	}
+	return sent, nil
+}
+
+// monthSpend — траты пользователя за month после скидок.
+func (s *costAlertService) monthSpend(ctx context.Context, userID uuid.UUID, month time.Time) (int, error) {
+	period := utils.FormatISOMonth(month)
+	summary, err := s.subscriptions.CalculateTotalCost(ctx, &userID, nil, period, period, nil, models.PricingCurrent)
+	if err != nil {
+		return 0, err
+	}
+	return summary.TotalCost(), nil
+}
+
+// notify возвращает false, если уведомление уже отправила другая реплика.
+func (s *costAlertService) notify(ctx context.Context, status *models.CostAlertStatus) (bool, error) {
+	event := models.NewCostAlertExceededEvent(status)
+	alert := status.Alert()
+
+	sent := false
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		marked, err := s.repo.MarkNotified(ctx, alert.ID(), status.Month(), status.Spent(), event.ID())
+		if err != nil || !marked {
+			return err
+		}
+		if err := s.events.Record(ctx, event); err != nil {
+			return err
+		}
+		sent = true
+		return nil
+	})
+	if err != nil {
+		s.log.Error("failed to send cost alert",
+			zap.String("alert_id", alert.ID().String()),
+			zap.Error(err))
+		return false, err
+	}
+
+	if sent && s.publisher != nil {
+		s.publisher.Publish(ctx, models.NewDomainEvent(event))
+	}
+	return sent, nil
+}
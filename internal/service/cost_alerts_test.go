@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+type costAlertMocks struct {
+	repo          *mocks.MockCostAlertRepository
+	subscriptions *mocks.MockSubscriptionService
+	events        *mocks.MockSubscriptionEventRepository
+	publisher     *mocks.MockEventPublisher
+}
+
+// july — «сейчас» в тестах: траты считаются за июль 2025.
+var july = time.Date(2025, time.July, 15, 12, 0, 0, 0, time.UTC)
+
+func newTestCostAlertService(t *testing.T, batchSize int) (*costAlertService, *costAlertMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	m := &costAlertMocks{
+		repo:          mocks.NewMockCostAlertRepository(ctrl),
+		subscriptions: mocks.NewMockSubscriptionService(ctrl),
+		events:        mocks.NewMockSubscriptionEventRepository(ctrl),
+		publisher:     mocks.NewMockEventPublisher(ctrl),
+	}
+	tx := mocks.NewMockTransactor(ctrl)
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	s := NewCostAlertService(m.repo, m.subscriptions, m.events, tx, m.publisher, batchSize, testLogger(t))
+	s.now = func() time.Time { return july }
+	return s, m
+}
+
+// expectSpend ожидает один расчёт трат пользователя за июль.
+func (m *costAlertMocks) expectSpend(userID uuid.UUID, spent int) {
+	summary := models.NewCostSummary(models.NewDateRange(july, july), models.BillingMonthly, models.PricingCurrent)
+	summary.SetBreakdown(models.NewCostBreakdown(spent, 0))
+	m.subscriptions.EXPECT().
+		CalculateTotalCost(gomock.Any(), &userID, nil, "2025-07", "2025-07", nil, models.PricingCurrent).
+		Return(summary, nil)
+}
+
+func TestCostAlertService_CreateAlert(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		limit  int
+		code   string
+	}{
+		{name: "valid alert", userID: uuid.New(), limit: 3000},
+		{name: "zero limit", userID: uuid.New(), limit: 0, code: apperror.CodeValidationFailed},
+		{name: "empty user", userID: uuid.Nil, limit: 3000, code: apperror.CodeInvalidUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestCostAlertService(t, 10)
+			if tt.code == "" {
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			alert, err := s.CreateAlert(context.Background(), tt.userID, tt.limit)
+			assertErrorCode(t, err, tt.code)
+			if tt.code == "" && (alert.UserID() != tt.userID || alert.MonthlyLimit() != tt.limit) {
+				t.Errorf("alert: got %s %d", alert.UserID(), alert.MonthlyLimit())
+			}
+		})
+	}
+}
+
+func TestCostAlertService_DeleteAlert(t *testing.T) {
+	owner := uuid.New()
+	alert := models.NewCostAlert(owner, 3000)
+
+	t.Run("own alert", func(t *testing.T) {
+		s, m := newTestCostAlertService(t, 10)
+		m.repo.EXPECT().GetByID(gomock.Any(), alert.ID()).Return(alert, nil)
+		m.repo.EXPECT().Delete(gomock.Any(), alert.ID()).Return(nil)
+
+		assertErrorCode(t, s.DeleteAlert(context.Background(), owner, alert.ID()), "")
+	})
+
+	t.Run("another user's alert", func(t *testing.T) {
+		s, m := newTestCostAlertService(t, 10)
+		m.repo.EXPECT().GetByID(gomock.Any(), alert.ID()).Return(alert, nil)
+
+		assertErrorCode(t, s.DeleteAlert(context.Background(), uuid.New(), alert.ID()), apperror.CodeNotFound)
+	})
+}
+
+func TestCostAlertService_GetAlertStatuses(t *testing.T) {
+	userID := uuid.New()
+	low := models.NewCostAlert(userID, 1000)
+	high := models.NewCostAlert(userID, 5000)
+	notifiedAt := july.Add(-time.Hour)
+
+	t.Run("no alerts skips the cost query", func(t *testing.T) {
+		s, m := newTestCostAlertService(t, 10)
+		m.repo.EXPECT().ListByUser(gomock.Any(), userID).Return([]*models.CostAlert{}, nil)
+
+		statuses, err := s.GetAlertStatuses(context.Background(), userID)
+		if err != nil || len(statuses) != 0 {
+			t.Fatalf("got %v, %v", statuses, err)
+		}
+	})
+
+	t.Run("statuses against the current month", func(t *testing.T) {
+		s, m := newTestCostAlertService(t, 10)
+		m.repo.EXPECT().ListByUser(gomock.Any(), userID).Return([]*models.CostAlert{low, high}, nil)
+		m.expectSpend(userID, 2500)
+		m.repo.EXPECT().NotifiedAt(gomock.Any(), userID, time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)).
+			Return(map[uuid.UUID]time.Time{low.ID(): notifiedAt}, nil)
+
+		statuses, err := s.GetAlertStatuses(context.Background(), userID)
+		if err != nil || len(statuses) != 2 {
+			t.Fatalf("got %v, %v", statuses, err)
+		}
+		if !statuses[0].Exceeded() || statuses[0].NotifiedAt() == nil || !statuses[0].NotifiedAt().Equal(notifiedAt) {
+			t.Errorf("low alert: exceeded %v, notified %v", statuses[0].Exceeded(), statuses[0].NotifiedAt())
+		}
+		if statuses[1].Exceeded() || statuses[1].NotifiedAt() != nil || statuses[1].Spent() != 2500 {
+			t.Errorf("high alert: exceeded %v, notified %v, spent %d", statuses[1].Exceeded(), statuses[1].NotifiedAt(), statuses[1].Spent())
+		}
+	})
+}
+
+func TestCostAlertService_EvaluateAlerts(t *testing.T) {
+	month := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+	spender := uuid.New()
+	saver := uuid.New()
+
+	// Пороги в порядке ID, как их отдаёт репозиторий.
+	low := models.NewCostAlert(spender, 1000)
+	high := models.NewCostAlert(spender, 5000)
+	taken := models.NewCostAlert(spender, 2000)
+	under := models.NewCostAlert(saver, 1000)
+
+	s, m := newTestCostAlertService(t, 2)
+	gomock.InOrder(
+		m.repo.EXPECT().ListUnnotified(gomock.Any(), month, uuid.Nil, 2).Return([]*models.CostAlert{low, high}, nil),
+		m.repo.EXPECT().ListUnnotified(gomock.Any(), month, high.ID(), 2).Return([]*models.CostAlert{taken, under}, nil),
+		m.repo.EXPECT().ListUnnotified(gomock.Any(), month, under.ID(), 2).Return([]*models.CostAlert{}, nil),
+	)
+	// Траты каждого пользователя считаются один раз за запуск.
+	m.expectSpend(spender, 3000)
+	m.expectSpend(saver, 800)
+
+	m.repo.EXPECT().MarkNotified(gomock.Any(), low.ID(), month, 3000, gomock.Any()).Return(true, nil)
+	// Другая реплика успела раньше: событие не пишется.
+	m.repo.EXPECT().MarkNotified(gomock.Any(), taken.ID(), month, 3000, gomock.Any()).Return(false, nil)
+
+	m.events.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *models.SubscriptionEvent) error {
+		if event.Type() != models.EventCostAlertExceeded || event.UserID() != spender || event.CostAlert().Alert() != low {
+			t.Errorf("event: %s for %s", event.Type(), event.UserID())
+		}
+		return nil
+	})
+	m.publisher.EXPECT().Publish(gomock.Any(), gomock.AssignableToTypeOf(models.CostAlertExceeded{}))
+
+	sent, err := s.EvaluateAlerts(context.Background())
+	if err != nil || sent != 1 {
+		t.Fatalf("EvaluateAlerts() = %d, %v; want 1", sent, err)
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/** CostCacheObserver считает обращения к кешу стоимости (реализует *metrics.Metrics). */
+type CostCacheObserver interface {
+	ObserveCostCache(hit bool)
+}
+
+/*
+CostCache — короткий кеш результатов CalculateTotalCost по фильтру и
+периоду: популярные пары «пользователь, период» не пересчитываются на
+каждый запрос. Записи пользователя сбрасываются любым изменением его
+подписок (событиями шины, см. ObserveEvent), записи без фильтра по
+пользователю — любым изменением вообще. Изменения, после которых неясно,
+чьи траты поменялись (участники общих подписок, архивация), сбрасывают
+весь кеш. Доля участника в общей подписке, которую изменил владелец,
+обновится по истечении ttl.
+
+nil-кеш ничего не хранит: каждый вызов Get считает заново.
+*/
+type CostCache struct {
+	ttl        time.Duration
+	maxEntries int
+	observer   CostCacheObserver
+	log        *logger.Logger
+
+	mu      sync.Mutex
+	entries map[string]costCacheEntry
+	// generation растёт при каждом сбросе: результат, посчитанный до
+	// сброса, не кладётся в кеш.
+	generation uint64
+}
+
+type costCacheEntry struct {
+	summary  *models.CostSummary
+	userID   *uuid.UUID
+	loadedAt time.Time
+}
+
+/*
+Конструктор. ttl <= 0 выключает кеш (возвращается nil); maxEntries —
+сколько результатов хранить самое большее; observer может быть nil.
+*/
+func NewCostCache(ttl time.Duration, maxEntries int, observer CostCacheObserver, log *logger.Logger) *CostCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &CostCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		observer:   observer,
+		log:        log.Named("cost-cache"),
+		entries:    make(map[string]costCacheEntry),
+	}
+}
+
+// costCacheKey — фильтр и период запроса после нормализации.
+func costCacheKey(filter *models.SubscriptionFilter, period models.DateRange, mode models.BillingMode, pricing models.PricingMode) string {
+	user, service := "*", "*"
+	if id := filter.UserID(); id != nil {
+		user = id.String()
+	}
+	if name := filter.ServiceName(); name != nil {
+		service = *name
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s", user, service, period.From(), period.To(), mode, pricing)
+}
+
+/*
+Get возвращает результат для filter и period, посчитанный не раньше ttl
+назад, иначе вызывает load. Как и кеш аналитики, блокировка не держится во
+время запроса к БД, ошибки не кешируются.
+*/
+func (c *CostCache) Get(filter *models.SubscriptionFilter, period models.DateRange, mode models.BillingMode, pricing models.PricingMode, load func() (*models.CostSummary, error)) (*models.CostSummary, error) {
+	if c == nil {
+		return load()
+	}
+
+	key := costCacheKey(filter, period, mode, pricing)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+
+	hit := ok && time.Since(entry.loadedAt) < c.ttl
+	if c.observer != nil {
+		c.observer.ObserveCostCache(hit)
+	}
+	if hit {
+		return entry.summary, nil
+	}
+
+	summary, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return summary, nil
+	}
+
+	now := time.Now()
+	for k, e := range c.entries {
+		if now.Sub(e.loadedAt) >= c.ttl {
+			delete(c.entries, k)
+		}
+	}
+	// Полный кеш не вытесняет свежие записи: новый результат подождёт,
+	// пока истечут старые.
+	if len(c.entries) >= c.maxEntries {
+		return summary, nil
+	}
+	c.entries[key] = costCacheEntry{summary: summary, userID: filter.UserID(), loadedAt: now}
+
+	return summary, nil
+}
+
+/** Сбрасывает записи пользователя и записи без фильтра по пользователю. */
+func (c *CostCache) InvalidateUser(userID uuid.UUID) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for k, e := range c.entries {
+		if e.userID == nil || *e.userID == userID {
+			delete(c.entries, k)
+		}
+	}
+}
+
+/** Сбрасывает весь кеш. */
+func (c *CostCache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	clear(c.entries)
+}
+
+/*
+ObserveEvent — обработчик подписки кеша на шину событий: изменение
+подписки сбрасывает записи её владельца. Шина доставляет события после
+коммита, поэтому результат, посчитанный до коммита, сбрасывается этим же
+событием.
+*/
+func (c *CostCache) ObserveEvent(_ context.Context, event models.DomainEvent) {
+	if c == nil {
+		return
+	}
+	c.InvalidateUser(event.UserID())
+	c.log.Debug("cost cache invalidated",
+		zap.String("event_type", event.Type()),
+		zap.String("user_id", event.UserID().String()))
+}
+
+/** Типы событий, после которых меняются траты пользователя. */
+var CostCacheEventTypes = []string{
+	models.EventSubscriptionCreated,
+	models.EventSubscriptionUpdated,
+	models.EventSubscriptionDeleted,
+	models.EventSubscriptionsBulkDeleted,
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+)
+
+type costCacheCounter map[bool]int
+
+func (c costCacheCounter) ObserveCostCache(hit bool) { c[hit]++ }
+
+func TestCostCache(t *testing.T) {
+	period := models.NewDateRange(
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+	)
+	alice, bob := uuid.New(), uuid.New()
+
+	userFilter := func(id uuid.UUID) *models.SubscriptionFilter {
+		filter := models.NewSubscriptionFilter()
+		filter.SetUserID(&id)
+		return filter
+	}
+
+	// get считает, сколько раз кеш обратился к БД.
+	loads := 0
+	get := func(t *testing.T, cache *CostCache, filter *models.SubscriptionFilter) {
+		t.Helper()
+		_, err := cache.Get(filter, period, models.BillingMonthly, models.PricingCurrent, func() (*models.CostSummary, error) {
+			loads++
+			return models.NewCostSummary(period, models.BillingMonthly, models.PricingCurrent), nil
+		})
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	}
+
+	t.Run("repeated request is served from cache", func(t *testing.T) {
+		loads = 0
+		counter := costCacheCounter{}
+		cache := NewCostCache(time.Minute, 100, counter, testLogger(t))
+
+		get(t, cache, userFilter(alice))
+		get(t, cache, userFilter(alice))
+
+		if loads != 1 {
+			t.Fatalf("loads = %d, want 1", loads)
+		}
+		if counter[true] != 1 || counter[false] != 1 {
+			t.Fatalf("hits = %d, misses = %d, want 1 and 1", counter[true], counter[false])
+		}
+	})
+
+	t.Run("event invalidates only its user and unfiltered totals", func(t *testing.T) {
+		loads = 0
+		cache := NewCostCache(time.Minute, 100, nil, testLogger(t))
+
+		get(t, cache, userFilter(alice))
+		get(t, cache, userFilter(bob))
+		get(t, cache, models.NewSubscriptionFilter())
+
+		sub := models.NewSubscription("Netflix", models.Rubles(799), alice, period.From())
+		cache.ObserveEvent(context.Background(), models.NewDomainEvent(models.NewSubscriptionEvent(models.EventSubscriptionUpdated, sub)))
+
+		get(t, cache, userFilter(alice))
+		get(t, cache, userFilter(bob))
+		get(t, cache, models.NewSubscriptionFilter())
+
+		if loads != 5 {
+			t.Fatalf("loads = %d, want 5 (alice and unfiltered reloaded)", loads)
+		}
+	})
+
+	t.Run("result loaded across an invalidation is not cached", func(t *testing.T) {
+		loads = 0
+		cache := NewCostCache(time.Minute, 100, nil, testLogger(t))
+
+		_, err := cache.Get(userFilter(alice), period, models.BillingMonthly, models.PricingCurrent, func() (*models.CostSummary, error) {
+			loads++
+			cache.InvalidateUser(alice)
+			return models.NewCostSummary(period, models.BillingMonthly, models.PricingCurrent), nil
+		})
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		get(t, cache, userFilter(alice))
+
+		if loads != 2 {
+			t.Fatalf("loads = %d, want 2", loads)
+		}
+	})
+
+	t.Run("full cache does not store new results", func(t *testing.T) {
+		loads = 0
+		cache := NewCostCache(time.Minute, 1, nil, testLogger(t))
+
+		get(t, cache, userFilter(alice))
+		get(t, cache, userFilter(bob))
+		get(t, cache, userFilter(bob))
+		get(t, cache, userFilter(alice))
+
+		if loads != 3 {
+			t.Fatalf("loads = %d, want 3", loads)
+		}
+	})
+
+	t.Run("zero ttl disables the cache", func(t *testing.T) {
+		loads = 0
+		cache := NewCostCache(0, 100, nil, testLogger(t))
+
+		get(t, cache, userFilter(alice))
+		get(t, cache, userFilter(alice))
+		cache.InvalidateAll()
+
+		if loads != 2 {
+			t.Fatalf("loads = %d, want 2", loads)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+/*
+deadLetterService — разбор событий, которые не удалось доставить: просмотр
+и ручная повторная постановка в outbox после восстановления потребителей.
+*/
+type deadLetterService struct {
+	repo repository.DeadLetterRepository
+	log  *logger.Logger
+}
+
+/** Конструктор сервиса недоставленных сообщений. */
+func NewDeadLetterService(repo repository.DeadLetterRepository, log *logger.Logger) *deadLetterService {
+	return &deadLetterService{
+		repo: repo,
+		log:  log.Named("dead-letters"),
+	}
+}
+
+/** Список сообщений по фильтру, от новых к старым, и общее число подходящих. */
+func (s *deadLetterService) ListDeadLetters(ctx context.Context, source, eventType, status string, limit, offset int) ([]*models.DeadLetter, int, error) {
+	parsedStatus, err := models.ParseDeadLetterStatus(strings.ToLower(strings.TrimSpace(status)))
+	if err != nil {
+		return nil, 0, apperror.InvalidInput("status", err.Error())
+	}
+
+	filter := models.NewDeadLetterFilter(
+		utils.StringPtr(strings.TrimSpace(source)),
+		utils.StringPtr(strings.TrimSpace(eventType)),
+		parsedStatus,
+	)
+
+	deadLetters, err := s.repo.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deadLetters, total, nil
+}
+
+/** Возвращает сообщение в outbox. Уже возвращённое повторно не ставится. */
+func (s *deadLetterService) RetryDeadLetter(ctx context.Context, id uuid.UUID) (*models.DeadLetter, error) {
+	if id == uuid.Nil {
+		return nil, apperror.InvalidInput("id", "cannot be empty")
+	}
+
+	deadLetter, err := s.repo.Requeue(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("dead letter requeued",
+		zap.String("dead_letter_id", id.String()),
+		zap.String("event_id", deadLetter.EventID().String()),
+		zap.String("event_type", deadLetter.EventType()),
+		zap.Int("requeue_count", deadLetter.RequeueCount()))
+
+	return deadLetter, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+/*
+discountService — управление промокодами. Применение промокода к подписке
+происходит в subscriptionService при создании; здесь только каталог кодов.
+*/
+type discountService struct {
+	repo repository.DiscountRepository
+	log  *logger.Logger
+}
+
+/** Конструктор сервиса промокодов. */
+func NewDiscountService(repo repository.DiscountRepository, log *logger.Logger) *discountService {
+	return &discountService{
+		repo: repo,
+		log:  log.Named("discount-service"),
+	}
+}
+
+/*
+CreateDiscount — создаёт промокод. validFrom и validTo принимают те же
+форматы, что и даты подписки; месяц без дня означает его начало и конец.
+*/
+func (s *discountService) CreateDiscount(ctx context.Context, code string, kind models.DiscountKind, amount int, serviceName *string, validFrom string, validTo *string) (*models.Discount, error) {
+	from, err := utils.ParseStartDate(validFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	var to *time.Time
+	if validTo != nil && *validTo != "" {
+		end, err := utils.ParseEndDate(*validTo)
+		if err != nil {
+			return nil, err
+		}
+		to = &end
+	}
+
+	discount := models.NewDiscount(code, kind, amount, serviceName, from, to)
+	if err := discount.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("discount", err.Error())
+	}
+
+	if err := s.repo.Create(ctx, discount); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("discount created",
+		zap.String("discount_id", discount.ID().String()),
+		zap.String("code", discount.Code()),
+		zap.String("kind", string(discount.Kind())),
+		zap.Int("amount", discount.Amount()))
+
+	return discount, nil
+}
+
+/** Возвращает промокод по ID. */
+func (s *discountService) GetDiscount(ctx context.Context, id uuid.UUID) (*models.Discount, error) {
+	if id == uuid.Nil {
+		return nil, apperror.InvalidInput("id", "cannot be empty")
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+/** Возвращает все промокоды в порядке создания. */
+func (s *discountService) ListDiscounts(ctx context.Context) ([]*models.Discount, error) {
+	return s.repo.List(ctx)
+}
+
+/** Удаляет промокод; применённый к подпискам удалить нельзя. */
+func (s *discountService) DeleteDiscount(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return apperror.InvalidInput("id", "cannot be empty")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.log.Info("discount deleted", zap.String("discount_id", id.String()))
+	return nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+expiryReminderService — за daysBefore дней до окончания подписки пишет
+событие subscription.expiring в журнал событий и outbox, откуда его
+забирают сервисы уведомлений. Напоминание отмечается в той же транзакции,
+что и событие, поэтому на одну дату окончания уходит ровно одно
+напоминание, даже если задача запущена на нескольких репликах. После
+коммита событие публикуется в шину (publisher может быть nil).
+*/
+type expiryReminderService struct {
+	repo       repository.SubscriptionRepository
+	events     repository.SubscriptionEventRepository
+	tx         repository.Transactor
+	publisher  repository.EventPublisher
+	daysBefore int
+	batchSize  int
+	log        *logger.Logger
+}
+
+/** Конструктор сервиса напоминаний. */
+func NewExpiryReminderService(repo repository.SubscriptionRepository, events repository.SubscriptionEventRepository, tx repository.Transactor, publisher repository.EventPublisher, daysBefore, batchSize int, log *logger.Logger) *expiryReminderService {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return &expiryReminderService{
+		repo:       repo,
+		events:     events,
+		tx:         tx,
+		publisher:  publisher,
+		daysBefore: daysBefore,
+		batchSize:  batchSize,
+		log:        log.Named("expiry-reminders"),
+	}
+}
+
+/*
+SendExpiryReminders отправляет напоминания по всем подпискам, которые
+заканчиваются в ближайшие daysBefore дней, пачками по batchSize.
+Возвращает число отправленных напоминаний.
+*/
+func (s *expiryReminderService) SendExpiryReminders(ctx context.Context) (int, error) {
+	window := models.NewDaysAheadRange(time.Now(), s.daysBefore)
+
+	sent := 0
+	for {
+		due, err := s.repo.GetDueExpiryReminders(ctx, window.From(), window.To(), s.batchSize)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, subscription := range due {
+			ok, err := s.remind(ctx, subscription)
+			if err != nil {
+				return sent, err
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if len(due) < s.batchSize {
+			break
+		}
+	}
+
+	if sent > 0 {
+		s.log.Info("expiry reminders sent",
+			zap.Int("count", sent),
+			zap.Int("days_before", s.daysBefore))
+	}
+	return sent, nil
+}
+
+// remind возвращает false, если напоминание уже отправила другая реплика.
+func (s *expiryReminderService) remind(ctx context.Context, subscription *models.Subscription) (bool, error) {
+	event := models.NewSubscriptionEvent(models.EventSubscriptionExpiring, subscription)
+
+	sent := false
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		marked, err := s.events.MarkExpiryReminded(ctx, subscription.ID(), *subscription.EndDate(), event.ID())
+		if err != nil || !marked {
+			return err
+		}
+		if err := s.events.Record(ctx, event); err != nil {
+			return err
+		}
+		sent = true
+		return nil
+	})
+	if err != nil {
+		s.log.Error("failed to send expiry reminder",
+			zap.String("subscription_id", subscription.ID().String()),
+			zap.Error(err))
+		return false, err
+	}
+
+	if sent && s.publisher != nil {
+		s.publisher.Publish(ctx, models.NewDomainEvent(event))
+	}
+	return sent, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+partitionMaintenanceService держит годовые секции subscriptions на
+yearsAhead лет вперёд. Годы, строки которых уже попали в
+subscriptions_default (подписка с далёкой датой начала), тоже получают
+свою секцию: иначе запросы по этим годам не отсекали бы default.
+*/
+type partitionMaintenanceService struct {
+	repo       repository.PartitionRepository
+	yearsAhead int
+	now        func() time.Time
+	log        *logger.Logger
+}
+
+/** Конструктор сервиса обслуживания секций. */
+func NewPartitionMaintenanceService(repo repository.PartitionRepository, yearsAhead int, log *logger.Logger) *partitionMaintenanceService {
+	if yearsAhead < 0 {
+		yearsAhead = 0
+	}
+	return &partitionMaintenanceService{
+		repo:       repo,
+		yearsAhead: yearsAhead,
+		now:        time.Now,
+		log:        log.Named("partition-maintenance"),
+	}
+}
+
+// EnsurePartitions создаёт недостающие секции и возвращает число созданных.
+func (s *partitionMaintenanceService) EnsurePartitions(ctx context.Context) (int, error) {
+	current := s.now().UTC().Year()
+
+	years := make(map[int]struct{}, s.yearsAhead+1)
+	for year := current; year <= current+s.yearsAhead; year++ {
+		years[year] = struct{}{}
+	}
+
+	stray, err := s.repo.DefaultPartitionYears(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, year := range stray {
+		years[year] = struct{}{}
+	}
+
+	ordered := make([]int, 0, len(years))
+	for year := range years {
+		ordered = append(ordered, year)
+	}
+	sort.Ints(ordered)
+
+	created := 0
+	for _, year := range ordered {
+		ok, err := s.repo.EnsureYearPartition(ctx, year)
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+			s.log.Info("subscriptions partition created", zap.Int("year", year))
+		}
+	}
+
+	return created, nil
+}
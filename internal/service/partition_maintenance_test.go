@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+func TestPartitionMaintenanceService_EnsurePartitions(t *testing.T) {
+	now := time.Date(2025, 12, 31, 23, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		stray    []int
+		existing map[int]bool
+		failOn   int
+		want     []int
+		created  int
+		wantErr  bool
+	}{
+		{"all missing", nil, nil, 0, []int{2025, 2026, 2027}, 3, false},
+		{"existing skipped", nil, map[int]bool{2025: true, 2026: true}, 0, []int{2025, 2026, 2027}, 1, false},
+		{"stray years from default", []int{2019, 2026, 2031}, nil, 0, []int{2019, 2025, 2026, 2027, 2031}, 5, false},
+		{"error stops", nil, nil, 2026, []int{2025, 2026}, 1, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewMockPartitionRepository(gomock.NewController(t))
+			repo.EXPECT().DefaultPartitionYears(gomock.Any()).Return(tc.stray, nil)
+
+			var calls []any
+			for _, year := range tc.want {
+				call := repo.EXPECT().EnsureYearPartition(gomock.Any(), year).Return(!tc.existing[year], nil)
+				if year == tc.failOn {
+					call.Return(false, errDatabase)
+				}
+				calls = append(calls, call)
+			}
+			gomock.InOrder(calls...)
+
+			log, err := logger.NewLogger(logger.Config{Level: "fatal"})
+			if err != nil {
+				t.Fatalf("logger: %v", err)
+			}
+			s := NewPartitionMaintenanceService(repo, 2, log)
+			s.now = func() time.Time { return now }
+
+			created, err := s.EnsurePartitions(context.Background())
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("EnsurePartitions() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if created != tc.created {
+				t.Fatalf("EnsurePartitions() = %d, want %d", created, tc.created)
+			}
+		})
+	}
+}
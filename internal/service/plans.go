@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+/*
+planService — каталог тарифов. Каждое изменение цены или периода оплаты
+записывается в историю цен в той же транзакции, что и сам тариф.
+*/
+type planService struct {
+	repo  repository.PlanRepository
+	tx    repository.Transactor
+	names *ServiceNameRules
+	log   *logger.Logger
+}
+
+/** Конструктор. names может быть nil — тогда названия сервисов не ограничиваются. */
+func NewPlanService(repo repository.PlanRepository, tx repository.Transactor, names *ServiceNameRules, log *logger.Logger) *planService {
+	return &planService{
+		repo:  repo,
+		tx:    tx,
+		names: names,
+		log:   log.Named("plan-service"),
+	}
+}
+
+/** Создаёт тариф и первую запись истории цен. */
+func (s *planService) CreatePlan(ctx context.Context, name, serviceName string, price int, billingCycle models.BillingCycle, features map[string]interface{}) (*models.Plan, error) {
+	plan := models.NewPlan(name, utils.SanitizeText(serviceName), models.Rubles(price), billingCycle, features)
+	if err := plan.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("plan", err.Error())
+	}
+
+	if err := s.names.CheckServiceName(ctx, plan.ServiceName()); err != nil {
+		return nil, err
+	}
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, plan); err != nil {
+			return err
+		}
+		return s.repo.AddPrice(ctx, models.NewPlanPrice(plan.ID(), plan.Price(), plan.BillingCycle(), plan.CreatedAt()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("plan created",
+		zap.String("plan_id", plan.ID().String()),
+		zap.String("name", plan.Name()),
+		zap.Stringer("price", plan.Price()),
+		zap.String("billing_cycle", string(plan.BillingCycle())))
+
+	return plan, nil
+}
+
+/** Возвращает тариф по ID. */
+func (s *planService) GetPlan(ctx context.Context, id uuid.UUID) (*models.Plan, error) {
+	if id == uuid.Nil {
+		return nil, apperror.InvalidInput("id", "cannot be empty")
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+/** Возвращает страницу каталога. */
+func (s *planService) ListPlans(ctx context.Context, limit, offset int) ([]*models.Plan, error) {
+	limit, offset, err := utils.ValidatePagination(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, limit, offset)
+}
+
+/*
+UpdatePlan обновляет переданные поля. features == nil — не менять.
+Подписки, уже созданные по тарифу, сохраняют свою цену.
+*/
+func (s *planService) UpdatePlan(ctx context.Context, id uuid.UUID, name, serviceName *string, price *int, billingCycle *models.BillingCycle, features map[string]interface{}) (*models.Plan, error) {
+	plan, err := s.GetPlan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	hasChanges := false
+	priceChanged := false
+
+	if name != nil && *name != plan.Name() {
+		plan.SetName(*name)
+		hasChanges = true
+	}
+
+	if serviceName != nil {
+		normalized := utils.SanitizeText(*serviceName)
+		if normalized != plan.ServiceName() {
+			if err := s.names.CheckServiceName(ctx, normalized); err != nil {
+				return nil, err
+			}
+			plan.SetServiceName(normalized)
+			hasChanges = true
+		}
+	}
+
+	if price != nil && !models.Rubles(*price).Equal(plan.Price()) {
+		plan.SetPrice(models.Rubles(*price))
+		hasChanges, priceChanged = true, true
+	}
+
+	if billingCycle != nil && *billingCycle != plan.BillingCycle() {
+		plan.SetBillingCycle(*billingCycle)
+		hasChanges, priceChanged = true, true
+	}
+
+	if features != nil {
+		plan.SetFeatures(features)
+		hasChanges = true
+	}
+
+	if !hasChanges {
+		return plan, nil
+	}
+
+	if err := plan.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("plan", err.Error())
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, plan); err != nil {
+			return err
+		}
+		if !priceChanged {
+			return nil
+		}
+		return s.repo.AddPrice(ctx, models.NewPlanPrice(plan.ID(), plan.Price(), plan.BillingCycle(), plan.UpdatedAt()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("plan updated",
+		zap.String("plan_id", plan.ID().String()),
+		zap.Bool("price_changed", priceChanged))
+
+	return plan, nil
+}
+
+/** Удаляет тариф; тариф с подписками удалить нельзя. */
+func (s *planService) DeletePlan(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return apperror.InvalidInput("id", "cannot be empty")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.log.Info("plan deleted", zap.String("plan_id", id.String()))
+	return nil
+}
+
+/** Возвращает историю цен тарифа от первой к последней. */
+func (s *planService) GetPriceHistory(ctx context.Context, id uuid.UUID) ([]*models.PlanPrice, error) {
+	if _, err := s.GetPlan(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListPrices(ctx, id)
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+const (
+	DefaultSuggestLimit = 10
+	MaxSuggestLimit     = 50
+)
+
+/*
+serviceCatalogService — каталог известных сервисов. Названия проходят тот
+же путь, что и у подписок: приводятся к словарю канонических названий и
+проверяются по белому и чёрному спискам.
+*/
+type serviceCatalogService struct {
+	repo      repository.ServiceCatalogRepository
+	names     *ServiceNameRules
+	canonical *CanonicalServiceNames
+	log       *logger.Logger
+}
+
+/** Конструктор. names и canonical могут быть nil — тогда названия не ограничиваются и не приводятся. */
+func NewServiceCatalogService(repo repository.ServiceCatalogRepository, names *ServiceNameRules, canonical *CanonicalServiceNames, log *logger.Logger) *serviceCatalogService {
+	return &serviceCatalogService{
+		repo:      repo,
+		names:     names,
+		canonical: canonical,
+		log:       log.Named("service-catalog"),
+	}
+}
+
+/** Добавляет сервис в каталог; название уникально без учёта регистра. */
+func (s *serviceCatalogService) CreateCatalogService(ctx context.Context, name string, category *string, website, iconURL string, typicalPrice *int) (*models.CatalogService, error) {
+	name, err := s.checkName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsedCategory *models.SubscriptionCategory
+	if category != nil {
+		if parsedCategory, err = parseCategory(*category); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := models.NewCatalogService(name, parsedCategory, website, iconURL, typicalPrice)
+	if err := entry.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("catalog_service", err.Error())
+	}
+
+	if err := s.repo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("catalog service created",
+		zap.String("catalog_id", entry.ID().String()),
+		zap.String("name", entry.Name()))
+
+	return entry, nil
+}
+
+/** Возвращает запись каталога по ID. */
+func (s *serviceCatalogService) GetCatalogService(ctx context.Context, id uuid.UUID) (*models.CatalogService, error) {
+	if id == uuid.Nil {
+		return nil, apperror.InvalidInput("id", "cannot be empty")
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+/** Возвращает страницу каталога по алфавиту. */
+func (s *serviceCatalogService) ListCatalogServices(ctx context.Context, limit, offset int) ([]*models.CatalogService, error) {
+	limit, offset, err := utils.ValidatePagination(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, limit, offset)
+}
+
+/*
+UpdateCatalogService обновляет переданные поля. Пустая строка в category,
+website или icon_url очищает поле. Подписки, привязанные к записи, не
+переименовываются.
+*/
+func (s *serviceCatalogService) UpdateCatalogService(ctx context.Context, id uuid.UUID, name, category, website, iconURL *string, typicalPrice *int) (*models.CatalogService, error) {
+	entry, err := s.GetCatalogService(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	hasChanges := false
+
+	if name != nil && strings.TrimSpace(*name) != entry.Name() {
+		checked, err := s.checkName(ctx, *name)
+		if err != nil {
+			return nil, err
+		}
+		if checked != entry.Name() {
+			entry.SetName(checked)
+			hasChanges = true
+		}
+	}
+
+	if category != nil {
+		parsedCategory, err := parseCategory(*category)
+		if err != nil {
+			return nil, err
+		}
+		current := entry.Category()
+		if (parsedCategory == nil) != (current == nil) || (parsedCategory != nil && *parsedCategory != *current) {
+			entry.SetCategory(parsedCategory)
+			hasChanges = true
+		}
+	}
+
+	if website != nil && strings.TrimSpace(*website) != entry.Website() {
+		entry.SetWebsite(*website)
+		hasChanges = true
+	}
+
+	if iconURL != nil && strings.TrimSpace(*iconURL) != entry.IconURL() {
+		entry.SetIconURL(*iconURL)
+		hasChanges = true
+	}
+
+	if typicalPrice != nil && (entry.TypicalPrice() == nil || *typicalPrice != *entry.TypicalPrice()) {
+		entry.SetTypicalPrice(typicalPrice)
+		hasChanges = true
+	}
+
+	if !hasChanges {
+		return entry, nil
+	}
+
+	if err := entry.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("catalog_service", err.Error())
+	}
+
+	if err := s.repo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("catalog service updated", zap.String("catalog_id", id.String()))
+	return entry, nil
+}
+
+/** Удаляет запись; запись с привязанными подписками удалить нельзя. */
+func (s *serviceCatalogService) DeleteCatalogService(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return apperror.InvalidInput("id", "cannot be empty")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.log.Info("catalog service deleted", zap.String("catalog_id", id.String()))
+	return nil
+}
+
+/*
+SuggestCatalogServices подбирает записи для автодополнения по подстроке
+названия. limit == 0 — DefaultSuggestLimit.
+*/
+func (s *serviceCatalogService) SuggestCatalogServices(ctx context.Context, query string, limit int) ([]*models.CatalogService, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, apperror.InvalidInput("q", "cannot be empty")
+	}
+	if len([]rune(query)) > models.MaxCatalogServiceNameLength {
+		return nil, apperror.InvalidInput("q", "is too long")
+	}
+
+	if limit == 0 {
+		limit = DefaultSuggestLimit
+	}
+	if limit < 0 || limit > MaxSuggestLimit {
+		return nil, apperror.InvalidInput("limit", fmt.Sprintf("must be between 1 and %d", MaxSuggestLimit))
+	}
+
+	return s.repo.Suggest(ctx, query, limit)
+}
+
+// checkName приводит название к каноническому и проверяет его по спискам.
+func (s *serviceCatalogService) checkName(ctx context.Context, name string) (string, error) {
+	name, err := s.canonical.Canonicalize(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", apperror.ValidationFailed("catalog_service", "name cannot be empty")
+	}
+	if err := s.names.CheckServiceName(ctx, name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
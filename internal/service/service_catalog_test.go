@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+func TestServiceCatalogService_CreateCatalogService(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   string
+		website string
+		price   *int
+		create  bool
+		code    string
+	}{
+		{name: "valid entry", entry: " Netflix ", website: "https://netflix.com", price: ptr(599), create: true},
+		{name: "relative website", entry: "Netflix", website: "netflix.com", code: apperror.CodeValidationFailed},
+		{name: "non-positive price", entry: "Netflix", price: ptr(0), code: apperror.CodeValidationFailed},
+		{name: "empty name", entry: "  ", code: apperror.CodeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockServiceCatalogRepository(gomock.NewController(t))
+			if tt.create {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			svc := NewServiceCatalogService(repo, nil, nil, testLogger(t))
+			entry, err := svc.CreateCatalogService(context.Background(), tt.entry, ptr("streaming"), tt.website, "", tt.price)
+			assertErrorCode(t, err, tt.code)
+			if tt.create && entry.Name() != "Netflix" {
+				t.Errorf("name: got %q", entry.Name())
+			}
+		})
+	}
+}
+
+func TestServiceCatalogService_SuggestCatalogServices(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  int
+		code  string
+	}{
+		{name: "default limit", query: " net ", want: DefaultSuggestLimit},
+		{name: "explicit limit", query: "net", limit: 3, want: 3},
+		{name: "empty query", query: " ", code: apperror.CodeInvalidInput},
+		{name: "limit above maximum", query: "net", limit: MaxSuggestLimit + 1, code: apperror.CodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockServiceCatalogRepository(gomock.NewController(t))
+			if tt.code == "" {
+				repo.EXPECT().Suggest(gomock.Any(), "net", tt.want).Return([]*models.CatalogService{}, nil)
+			}
+
+			svc := NewServiceCatalogService(repo, nil, nil, testLogger(t))
+			_, err := svc.SuggestCatalogServices(context.Background(), tt.query, tt.limit)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestSubscriptionService_LinkCatalogService(t *testing.T) {
+	id := uuid.New()
+	catalogID := uuid.New()
+	otherID := uuid.New()
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	stored := func(linked *uuid.UUID) *models.Subscription {
+		sub := models.NewSubscription("Netflix", models.Rubles(599), uuid.New(), start)
+		sub.SetID(id)
+		sub.SetCatalogID(linked)
+		return sub
+	}
+
+	tests := []struct {
+		name    string
+		current *uuid.UUID
+		link    *uuid.UUID
+		setup   func(m *subscriptionServiceMocks)
+		code    string
+	}{
+		{
+			name: "link",
+			link: &catalogID,
+			setup: func(m *subscriptionServiceMocks) {
+				m.catalog.EXPECT().GetByID(gomock.Any(), catalogID).Return(models.NewCatalogService("Netflix", nil, "", "", nil), nil)
+				m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "relink to another entry",
+			current: &otherID,
+			link:    &catalogID,
+			setup: func(m *subscriptionServiceMocks) {
+				m.catalog.EXPECT().GetByID(gomock.Any(), catalogID).Return(models.NewCatalogService("Netflix", nil, "", "", nil), nil)
+				m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "unlink",
+			current: &catalogID,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "same entry is a no-op",
+			current: &catalogID,
+			link:    &catalogID,
+		},
+		{
+			name: "unknown entry",
+			link: &catalogID,
+			setup: func(m *subscriptionServiceMocks) {
+				m.catalog.EXPECT().GetByID(gomock.Any(), catalogID).Return(nil, apperror.NotFound("catalog service"))
+			},
+			code: apperror.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			m.repo.EXPECT().GetByID(gomock.Any(), id).Return(stored(tt.current), nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			sub, err := svc.LinkCatalogService(context.Background(), id, tt.link)
+			assertErrorCode(t, err, tt.code)
+			if tt.code != "" {
+				return
+			}
+			if got := sub.CatalogID(); (got == nil) != (tt.link == nil) || (got != nil && *got != *tt.link) {
+				t.Errorf("catalog id: got %v, want %v", got, tt.link)
+			}
+		})
+	}
+}
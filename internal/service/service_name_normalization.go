@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+serviceNameNormalizationService приводит названия сохранённых подписок к
+словарю канонических названий. Каждая подписка меняется через
+SubscriptionService.UpdateSubscription: он подставляет каноническое
+название, сохраняет прежнее в метаданных original_service_name и пишет
+событие subscription.updated. Подписка, которую нельзя изменить
+(например, каноническое название в чёрном списке), пропускается.
+*/
+type serviceNameNormalizationService struct {
+	dictionary    repository.CanonicalServiceNameRepository
+	subscriptions service.SubscriptionService
+	batchSize     int
+	log           *logger.Logger
+}
+
+/** Конструктор сервиса нормализации названий. */
+func NewServiceNameNormalizationService(dictionary repository.CanonicalServiceNameRepository, subscriptions service.SubscriptionService, batchSize int, log *logger.Logger) *serviceNameNormalizationService {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return &serviceNameNormalizationService{
+		dictionary:    dictionary,
+		subscriptions: subscriptions,
+		batchSize:     batchSize,
+		log:           log.Named("service-name-normalization"),
+	}
+}
+
+// NormalizeExisting проходит по всем записям словаря и возвращает число
+// переименованных подписок.
+func (s *serviceNameNormalizationService) NormalizeExisting(ctx context.Context) (int, error) {
+	entries, err := s.dictionary.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	renamed, skipped := 0, 0
+	for _, entry := range entries {
+		afterID := uuid.Nil
+		for {
+			ids, err := s.dictionary.FindVariants(ctx, entry.Keys(), entry.Name(), afterID, s.batchSize)
+			if err != nil {
+				return renamed, err
+			}
+
+			for _, id := range ids {
+				ok, err := s.normalize(ctx, id, entry.Name())
+				if err != nil {
+					return renamed, err
+				}
+				if ok {
+					renamed++
+				} else {
+					skipped++
+				}
+			}
+
+			if len(ids) < s.batchSize {
+				break
+			}
+			afterID = ids[len(ids)-1]
+		}
+	}
+
+	if renamed > 0 || skipped > 0 {
+		s.log.Info("service names normalized",
+			zap.Int("renamed", renamed),
+			zap.Int("skipped", skipped))
+	}
+	return renamed, nil
+}
+
+// normalize переименовывает одну подписку; false — подписка не изменилась.
+func (s *serviceNameNormalizationService) normalize(ctx context.Context, id uuid.UUID, canonical string) (bool, error) {
+	current, err := s.subscriptions.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return false, s.skip(id, canonical, err)
+	}
+
+	name := current.ServiceName()
+	updated, err := s.subscriptions.UpdateSubscription(ctx, id, &name, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		return false, s.skip(id, canonical, err)
+	}
+	return updated.ServiceName() == canonical, nil
+}
+
+/*
+skip пропускает подписку, которую не удалось изменить по бизнес-правилам
+или которая исчезла. Сбой инфраструктуры возвращается и прерывает
+запуск; следующий начнёт с начала.
+*/
+func (s *serviceNameNormalizationService) skip(id uuid.UUID, canonical string, err error) error {
+	appErr, ok := apperror.IsAppError(err)
+	if !ok || isTransientError(appErr) {
+		return err
+	}
+	s.log.Warn("subscription service name not normalized",
+		zap.String("subscription_id", id.String()),
+		zap.String("canonical", canonical),
+		zap.String("code", appErr.Code()))
+	return nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+func testLogger(t *testing.T) *logger.Logger {
+	t.Helper()
+
+	log, err := logger.NewLogger(logger.Config{Level: "fatal"})
+	if err != nil {
+		t.Fatalf("logger: %v", err)
+	}
+	return log
+}
+
+func TestCanonicalServiceNames_AddEntry(t *testing.T) {
+	netflix := models.NewCanonicalServiceName("Netflix", []string{"Нетфликс"})
+
+	tests := []struct {
+		name    string
+		entry   string
+		aliases []string
+		create  bool
+		code    string
+	}{
+		{name: "new entry", entry: "Yandex Plus", aliases: []string{"Yandex+"}, create: true},
+		{name: "name spelled like an existing entry", entry: " NETFLIX ", code: apperror.CodeConflict},
+		{name: "alias owned by another entry", entry: "Netflix Kids", aliases: []string{"нетфликс"}, code: apperror.CodeConflict},
+		{name: "empty name", entry: " ", code: apperror.CodeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockCanonicalServiceNameRepository(gomock.NewController(t))
+			repo.EXPECT().List(gomock.Any()).Return([]*models.CanonicalServiceName{netflix}, nil).MaxTimes(1)
+			if tt.create {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			names := NewCanonicalServiceNames(repo, time.Minute, testLogger(t))
+			_, err := names.AddEntry(context.Background(), tt.entry, tt.aliases)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestSubscriptionService_CanonicalServiceName(t *testing.T) {
+	id := uuid.New()
+	userID := uuid.New()
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	newService := func(t *testing.T) (*subscriptionService, *subscriptionServiceMocks) {
+		svc, m := newTestSubscriptionService(t)
+		repo := mocks.NewMockCanonicalServiceNameRepository(gomock.NewController(t))
+		repo.EXPECT().List(gomock.Any()).Return([]*models.CanonicalServiceName{
+			models.NewCanonicalServiceName("Netflix", []string{"Нетфликс"}),
+		}, nil).AnyTimes()
+		svc.canonical = NewCanonicalServiceNames(repo, time.Minute, testLogger(t))
+		return svc, m
+	}
+
+	t.Run("create keeps the original spelling", func(t *testing.T) {
+		svc, m := newService(t)
+		m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		sub, err := svc.CreateSubscription(context.Background(), "  NETFLIX ", 599, userID, "01-2025", nil, nil, nil, nil, nil, nil, nil, nil, "",
+			map[string]string{"team": "home"})
+		assertErrorCode(t, err, "")
+		if sub.ServiceName() != "Netflix" {
+			t.Errorf("service name: got %q", sub.ServiceName())
+		}
+		if got := sub.Metadata(); got[models.MetadataOriginalServiceName] != "NETFLIX" || got["team"] != "home" {
+			t.Errorf("metadata: got %v", got)
+		}
+	})
+
+	t.Run("create with canonical name leaves metadata alone", func(t *testing.T) {
+		svc, m := newService(t)
+		m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		sub, err := svc.CreateSubscription(context.Background(), "Netflix", 599, userID, "01-2025", nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+		assertErrorCode(t, err, "")
+		if _, ok := sub.Metadata()[models.MetadataOriginalServiceName]; ok {
+			t.Errorf("metadata: got %v", sub.Metadata())
+		}
+	})
+
+	t.Run("update renames a stored variant", func(t *testing.T) {
+		svc, m := newService(t)
+		stored := models.NewSubscription("нетфликс", models.Rubles(599), userID, start)
+		stored.SetID(id)
+		m.repo.EXPECT().GetByID(gomock.Any(), id).Return(stored, nil)
+		m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		name := "нетфликс"
+		sub, err := svc.UpdateSubscription(context.Background(), id, &name, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		assertErrorCode(t, err, "")
+		if sub.ServiceName() != "Netflix" || sub.Metadata()[models.MetadataOriginalServiceName] != "нетфликс" {
+			t.Errorf("got %q with metadata %v", sub.ServiceName(), sub.Metadata())
+		}
+	})
+
+	t.Run("update with a variant of the current name is a no-op", func(t *testing.T) {
+		svc, m := newService(t)
+		stored := models.NewSubscription("Netflix", models.Rubles(599), userID, start)
+		stored.SetID(id)
+		m.repo.EXPECT().GetByID(gomock.Any(), id).Return(stored, nil)
+
+		_, err := svc.UpdateSubscription(context.Background(), id, ptr("netflix"), nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		assertErrorCode(t, err, "")
+	})
+}
+
+func TestServiceNameNormalizationService_NormalizeExisting(t *testing.T) {
+	entry := models.NewCanonicalServiceName("Netflix", nil)
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	subscription := func(name string) *models.Subscription {
+		return models.NewSubscription(name, models.Rubles(599), uuid.New(), time.Now())
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(subs *mocks.MockSubscriptionService)
+		renamed int
+		wantErr bool
+	}{
+		{
+			name: "renames every variant page by page",
+			setup: func(subs *mocks.MockSubscriptionService) {
+				for _, id := range ids {
+					subs.EXPECT().GetSubscriptionByID(gomock.Any(), id).Return(subscription("netflix"), nil)
+					subs.EXPECT().UpdateSubscription(gomock.Any(), id, ptr("netflix"), nil, nil, nil, nil, nil, nil, nil, nil, nil).
+						Return(subscription("Netflix"), nil)
+				}
+			},
+			renamed: 3,
+		},
+		{
+			name: "rejected and deleted subscriptions are skipped",
+			setup: func(subs *mocks.MockSubscriptionService) {
+				subs.EXPECT().GetSubscriptionByID(gomock.Any(), ids[0]).Return(nil, apperror.SubscriptionNotFound(ids[0].String()))
+				subs.EXPECT().GetSubscriptionByID(gomock.Any(), ids[1]).Return(subscription("NETFLIX"), nil)
+				subs.EXPECT().UpdateSubscription(gomock.Any(), ids[1], gomock.Any(), nil, nil, nil, nil, nil, nil, nil, nil, nil).
+					Return(nil, apperror.ServiceNameNotAllowed("Netflix", "deny", "blocked"))
+				subs.EXPECT().GetSubscriptionByID(gomock.Any(), ids[2]).Return(subscription("netflix"), nil)
+				subs.EXPECT().UpdateSubscription(gomock.Any(), ids[2], gomock.Any(), nil, nil, nil, nil, nil, nil, nil, nil, nil).
+					Return(subscription("Netflix"), nil)
+			},
+			renamed: 1,
+		},
+		{
+			name: "database error stops the run",
+			setup: func(subs *mocks.MockSubscriptionService) {
+				subs.EXPECT().GetSubscriptionByID(gomock.Any(), ids[0]).Return(nil, errDatabase)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dictionary := mocks.NewMockCanonicalServiceNameRepository(ctrl)
+			subs := mocks.NewMockSubscriptionService(ctrl)
+
+			dictionary.EXPECT().List(gomock.Any()).Return([]*models.CanonicalServiceName{entry}, nil)
+			dictionary.EXPECT().FindVariants(gomock.Any(), entry.Keys(), "Netflix", uuid.Nil, 2).Return(ids[:2], nil)
+			dictionary.EXPECT().FindVariants(gomock.Any(), entry.Keys(), "Netflix", ids[1], 2).Return(ids[2:], nil).MaxTimes(1)
+			tt.setup(subs)
+
+			svc := NewServiceNameNormalizationService(dictionary, subs, 2, testLogger(t))
+			renamed, err := svc.NormalizeExisting(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeExisting() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if renamed != tt.renamed {
+				t.Errorf("renamed: got %d, want %d", renamed, tt.renamed)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+ServiceNameRules — белый и чёрный списки названий сервисов, которыми
+управляют администраторы. Правила хранятся в БД, а скомпилированная
+политика кешируется на cacheTTL: изменения на этой реплике видны сразу,
+на остальных — не позже чем через cacheTTL.
+*/
+type ServiceNameRules struct {
+	repo     repository.ServiceNameRuleRepository
+	cacheTTL time.Duration
+	log      *logger.Logger
+
+	mu       sync.Mutex
+	policy   *models.ServiceNamePolicy
+	loadedAt time.Time
+}
+
+/** Конструктор. */
+func NewServiceNameRules(repo repository.ServiceNameRuleRepository, cacheTTL time.Duration, log *logger.Logger) *ServiceNameRules {
+	return &ServiceNameRules{
+		repo:     repo,
+		cacheTTL: cacheTTL,
+		log:      log.Named("service-name-rules"),
+	}
+}
+
+/** Добавляет правило после проверки шаблона. */
+func (s *ServiceNameRules) AddRule(ctx context.Context, list models.ServiceNameList, match models.ServiceNameMatch, pattern, reason string) (*models.ServiceNameRule, error) {
+	rule := models.NewServiceNameRule(list, match, pattern, reason)
+	if err := rule.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("rule", err.Error())
+	}
+
+	if err := s.repo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	s.log.Info("service name rule added",
+		zap.String("rule_id", rule.ID().String()),
+		zap.String("list", string(rule.List())),
+		zap.String("match", string(rule.Match())),
+		zap.String("pattern", rule.Pattern()))
+
+	return rule, nil
+}
+
+/** Возвращает все правила в порядке добавления. */
+func (s *ServiceNameRules) ListRules(ctx context.Context) ([]*models.ServiceNameRule, error) {
+	return s.repo.List(ctx)
+}
+
+/** Удаляет правило. */
+func (s *ServiceNameRules) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return apperror.InvalidInput("id", "cannot be empty")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	s.log.Info("service name rule deleted", zap.String("rule_id", id.String()))
+	return nil
+}
+
+/*
+CheckServiceName возвращает SERVICE_NAME_NOT_ALLOWED, если название
+попало в чёрный список или не попало в непустой белый.
+Безопасен для nil: без правил разрешено всё.
+*/
+func (s *ServiceNameRules) CheckServiceName(ctx context.Context, name string) error {
+	if s == nil {
+		return nil
+	}
+
+	policy, err := s.currentPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	rule, allowed := policy.Check(name)
+	if allowed {
+		return nil
+	}
+
+	if rule == nil {
+		return apperror.ServiceNameNotAllowed(name, string(models.ServiceNameAllowList), "not on the allow list")
+	}
+	return apperror.ServiceNameNotAllowed(name, string(rule.List()), rule.Reason())
+}
+
+/*
+currentPolicy перечитывает правила по истечении cacheTTL. Если БД
+недоступна, но политика уже загружалась, используется прежняя версия.
+*/
+func (s *ServiceNameRules) currentPolicy(ctx context.Context) (*models.ServiceNamePolicy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.policy != nil && time.Since(s.loadedAt) < s.cacheTTL {
+		return s.policy, nil
+	}
+
+	rules, err := s.repo.List(ctx)
+	if err != nil {
+		if s.policy != nil {
+			s.log.Warn("failed to refresh service name rules, using cached policy", zap.Error(err))
+			return s.policy, nil
+		}
+		return nil, err
+	}
+
+	policy, err := models.NewServiceNamePolicy(rules)
+	if err != nil {
+		s.log.Error("skipping invalid service name rules", zap.Error(err))
+	}
+
+	s.policy = policy
+	s.loadedAt = time.Now()
+	return policy, nil
+}
+
+func (s *ServiceNameRules) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
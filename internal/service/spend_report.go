@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+/*
+spendReportService — строит отчёт по тратам всех пользователей.
+Пространство user_id делится на shards диапазонов, которые считаются
+параллельно не более чем parallelism воркерами: один GROUP BY по всей
+таблице на сотнях тысяч пользователей упирается в таймаут запроса.
+*/
+type spendReportService struct {
+	repo        repository.SubscriptionRepository
+	shards      int
+	parallelism int
+	timeout     time.Duration
+	billing     models.BillingMode
+	log         *logger.Logger
+}
+
+/** Конструктор сервиса отчётов. */
+func NewSpendReportService(repo repository.SubscriptionRepository, shards, parallelism int, timeout time.Duration, billing models.BillingMode, log *logger.Logger) *spendReportService {
+	if shards < 1 {
+		shards = 1
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	return &spendReportService{
+		repo:        repo,
+		shards:      shards,
+		parallelism: parallelism,
+		timeout:     timeout,
+		billing:     billing,
+		log:         log.Named("spend-report"),
+	}
+}
+
+/*
+BuildUserSpendReport — считает траты каждого пользователя за период.
+Первая ошибка любого диапазона отменяет остальные.
+*/
+func (s *spendReportService) BuildUserSpendReport(ctx context.Context, startDate, endDate string, billing *models.BillingMode) (*models.UserSpendReport, error) {
+	startTime, endTime, err := utils.ParseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if startTime == nil || endTime == nil {
+		return nil, apperror.InvalidInput("date_range", "both start_date and end_date are required")
+	}
+
+	period := models.NewDateRange(*startTime, *endTime)
+	if err := period.Validate(); err != nil {
+		return nil, apperror.InvalidDateRange(startDate, endDate)
+	}
+
+	mode := billingModeOrDefault(billing, s.billing)
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	started := time.Now()
+	ranges := models.SplitUserIDSpace(s.shards)
+	results := make([][]*models.UserSpend, len(ranges))
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		jobs     = make(chan int)
+	)
+
+	for w := 0; w < s.parallelism && w < len(ranges); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				spends, err := s.repo.GetUserSpendForRange(ctx, period, mode, ranges[i])
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				results[i] = spends
+			}
+		}()
+	}
+
+dispatch:
+	for i := range ranges {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		s.log.Error("user spend report timed out", zap.Duration("timeout", s.timeout))
+		return nil, apperror.ServiceUnavailable("spend-report", ctx.Err()).
+			WithDetail("reason", "report did not finish within "+s.timeout.String())
+	}
+	if firstErr != nil {
+		s.log.Error("user spend report failed", zap.Error(firstErr))
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, part := range results {
+		total += len(part)
+	}
+	users := make([]*models.UserSpend, 0, total)
+	for _, part := range results {
+		users = append(users, part...)
+	}
+
+	report := models.NewUserSpendReport(period, mode, users)
+
+	s.log.Info("user spend report built",
+		zap.Int("users", len(users)),
+		zap.Int("shards", len(ranges)),
+		zap.Int("parallelism", s.parallelism),
+		zap.Duration("duration", time.Since(started)))
+
+	return report, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+subscriptionArchiveService переносит подписки, закончившиеся больше
+afterMonths месяцев назад, в subscriptions_archive. Основная таблица
+остаётся маленькой для списков и расчётов стоимости, архив доступен через
+фильтр archived=true. Каждая пачка переносится отдельным запросом, поэтому
+прерванный запуск продолжается со следующей пачки.
+*/
+type subscriptionArchiveService struct {
+	repo        repository.SubscriptionRepository
+	afterMonths int
+	batchSize   int
+	costs       *CostCache
+	now         func() time.Time
+	log         *logger.Logger
+}
+
+/*
+Конструктор сервиса архивации. costs может быть nil; иначе после переноса
+кеш стоимости сбрасывается — архивные подписки в расчёт не входят.
+*/
+func NewSubscriptionArchiveService(repo repository.SubscriptionRepository, afterMonths, batchSize int, costs *CostCache, log *logger.Logger) *subscriptionArchiveService {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return &subscriptionArchiveService{
+		repo:        repo,
+		afterMonths: afterMonths,
+		batchSize:   batchSize,
+		costs:       costs,
+		now:         time.Now,
+		log:         log.Named("subscription-archive"),
+	}
+}
+
+// ArchiveEnded архивирует все подходящие подписки пачками по batchSize и
+// возвращает их число.
+func (s *subscriptionArchiveService) ArchiveEnded(ctx context.Context) (int, error) {
+	before := s.now().UTC().AddDate(0, -s.afterMonths, 0)
+
+	archived := 0
+	for {
+		moved, err := s.repo.ArchiveEnded(ctx, before, s.batchSize)
+		archived += moved
+		if moved > 0 {
+			s.costs.InvalidateAll()
+		}
+		if err != nil {
+			return archived, err
+		}
+		if moved < s.batchSize {
+			break
+		}
+	}
+
+	if archived > 0 {
+		s.log.Info("subscriptions archived",
+			zap.Int("count", archived),
+			zap.Time("ended_before", before))
+	}
+	return archived, nil
+}
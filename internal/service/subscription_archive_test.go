@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+func TestSubscriptionArchiveService_ArchiveEnded(t *testing.T) {
+	now := time.Date(2025, 7, 15, 10, 30, 0, 0, time.UTC)
+	before := time.Date(2023, 7, 15, 10, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		batches []int
+		err     error
+		want    int
+	}{
+		{"nothing to archive", []int{0}, nil, 0},
+		{"partial batch stops", []int{1}, nil, 1},
+		{"full batches continue", []int{2, 2, 1}, nil, 5},
+		{"error keeps archived count", []int{2, 0}, errDatabase, 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewMockSubscriptionRepository(gomock.NewController(t))
+			for i, moved := range tc.batches {
+				call := repo.EXPECT().ArchiveEnded(gomock.Any(), before, 2).Return(moved, nil)
+				if tc.err != nil && i == len(tc.batches)-1 {
+					call.Return(moved, tc.err)
+				}
+			}
+
+			log, err := logger.NewLogger(logger.Config{Level: "fatal"})
+			if err != nil {
+				t.Fatalf("logger: %v", err)
+			}
+			s := NewSubscriptionArchiveService(repo, 24, 2, nil, log)
+			s.now = func() time.Time { return now }
+
+			archived, err := s.ArchiveEnded(context.Background())
+			if err != tc.err {
+				t.Fatalf("ArchiveEnded() error = %v, want %v", err, tc.err)
+			}
+			if archived != tc.want {
+				t.Fatalf("ArchiveEnded() = %d, want %d", archived, tc.want)
+			}
+		})
+	}
+}
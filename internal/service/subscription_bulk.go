@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// errBulkItemFailed откатывает пакет после отказа одного элемента; сам
+// отказ уже записан в итог элемента.
+var errBulkItemFailed = errors.New("bulk update item failed")
+
+/*
+subscriptionBulkService — пакетные изменения поверх SubscriptionService.
+Каждая подписка меняется через UpdateSubscription с теми же проверками,
+историей цен и событием subscription.updated, но весь пакет коммитится
+одной транзакцией: либо применились все изменения, либо ни одного.
+*/
+type subscriptionBulkService struct {
+	subscriptions service.SubscriptionService
+	tx            repository.Transactor
+	log           *logger.Logger
+}
+
+/** Конструктор сервиса пакетных изменений. */
+func NewSubscriptionBulkService(subscriptions service.SubscriptionService, tx repository.Transactor, log *logger.Logger) *subscriptionBulkService {
+	return &subscriptionBulkService{
+		subscriptions: subscriptions,
+		tx:            tx,
+		log:           log.Named("subscription-bulk"),
+	}
+}
+
+/*
+UpdateSubscriptions применяет изменения по порядку. Первый отказ по
+бизнес-правилам (нет подписки, неверное поле) останавливает пакет и
+откатывает транзакцию: уже применённые элементы получают rolled_back,
+следующие — skipped. Сбой инфраструктуры возвращается ошибкой.
+*/
+func (s *subscriptionBulkService) UpdateSubscriptions(ctx context.Context, patches []*models.SubscriptionPatch) (*models.BulkUpdateResult, error) {
+	if err := validatePatches(patches); err != nil {
+		return nil, err
+	}
+
+	items := make([]*models.BulkUpdateItemResult, len(patches))
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for i, patch := range patches {
+			subscription, err := s.apply(ctx, patch.ID(), patch)
+			if err == nil {
+				items[i] = models.NewBulkUpdateItemResult(patch.ID(), models.BulkItemUpdated, subscription)
+				continue
+			}
+
+			appErr, ok := apperror.IsAppError(err)
+			if !ok || isTransientError(appErr) {
+				return err
+			}
+			items[i] = models.NewBulkUpdateItemFailed(patch.ID(), appErr.Code(), rejectionMessage(appErr))
+			markRolledBack(items[:i], patches[:i])
+			for j := i + 1; j < len(patches); j++ {
+				items[j] = models.NewBulkUpdateItemResult(patches[j].ID(), models.BulkItemSkipped, nil)
+			}
+			return errBulkItemFailed
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkItemFailed) {
+		s.log.Error("bulk update failed", zap.Int("items", len(patches)), zap.Error(err))
+		return nil, err
+	}
+
+	result := models.NewBulkUpdateResult(items)
+	s.log.Info("bulk update finished",
+		zap.Int("items", len(patches)),
+		zap.Bool("applied", result.Applied()))
+	return result, nil
+}
+
+/*
+UpdateByFilter применяет patch ко всем текущим подпискам под фильтром.
+Фильтр обязан сужать выборку хотя бы по одному полю; service_name здесь
+сравнивается с названием целиком без учёта регистра, а не как подстрока.
+При dryRun ничего не меняется, возвращаются подписки, которые изменились бы.
+Любая ошибка откатывает все изменения.
+*/
+func (s *subscriptionBulkService) UpdateByFilter(ctx context.Context, filter *models.SubscriptionFilter, patch *models.SubscriptionPatch, dryRun bool) (*models.BulkFilterUpdateResult, error) {
+	if filter == nil || !(filter.HasUserID() || filter.HasServiceName() || filter.HasTags() || filter.HasCategory() || filter.HasMetadata()) {
+		return nil, apperror.InvalidInput("filter", "must set at least one of user_id, service_name, tags, category, metadata")
+	}
+	if patch == nil || patch.IsEmpty() {
+		return nil, apperror.InvalidInput("set", "must change at least one field")
+	}
+
+	ids, matched, err := s.matchingIDs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return models.NewBulkFilterUpdateResult(true, matched, ids), nil
+	}
+	if matched > models.MaxBulkFilterUpdateRows {
+		return nil, apperror.InvalidInput("filter",
+			fmt.Sprintf("matches %d subscriptions, at most %d can be updated at once", matched, models.MaxBulkFilterUpdateRows))
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, id := range ids {
+			if _, err := s.apply(ctx, id, patch); err != nil {
+				if appErr, ok := apperror.IsAppError(err); ok {
+					return appErr.WithDetail("subscription_id", id.String())
+				}
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("bulk update by filter failed", zap.Int("matched", len(ids)), zap.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("bulk update by filter applied", zap.Int("updated", len(ids)))
+	return models.NewBulkFilterUpdateResult(false, matched, ids), nil
+}
+
+func (s *subscriptionBulkService) apply(ctx context.Context, id uuid.UUID, patch *models.SubscriptionPatch) (*models.Subscription, error) {
+	return s.subscriptions.UpdateSubscription(ctx, id,
+		patch.ServiceName(), patch.Price(), patch.StartDate(), patch.EndDate(),
+		patch.Tags(), patch.Category(), patch.PaymentMethod(), patch.BillingDay(), patch.Notes(), patch.Metadata())
+}
+
+/*
+matchingIDs считает подписки под фильтром и возвращает ID первых
+MaxBulkFilterUpdateRows из них. Фильтр по названию в репозитории —
+подстрока, поэтому здесь остаются только точные совпадения.
+*/
+func (s *subscriptionBulkService) matchingIDs(ctx context.Context, filter *models.SubscriptionFilter) ([]uuid.UUID, int, error) {
+	var name string
+	if filter.HasServiceName() {
+		name = utils.SanitizeText(*filter.ServiceName())
+	}
+
+	ids := make([]uuid.UUID, 0)
+	matched := 0
+	err := s.subscriptions.ExportSubscriptions(ctx, filter, func(subscription *models.Subscription) error {
+		if name != "" && !strings.EqualFold(subscription.ServiceName(), name) {
+			return nil
+		}
+		matched++
+		if len(ids) < models.MaxBulkFilterUpdateRows {
+			ids = append(ids, subscription.ID())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return ids, matched, nil
+}
+
+func validatePatches(patches []*models.SubscriptionPatch) error {
+	if len(patches) == 0 {
+		return apperror.InvalidInput("items", "cannot be empty")
+	}
+	if len(patches) > models.MaxBulkUpdateItems {
+		return apperror.InvalidInput("items", fmt.Sprintf("must contain at most %d items", models.MaxBulkUpdateItems))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(patches))
+	for i, patch := range patches {
+		field := fmt.Sprintf("items[%d]", i)
+		switch {
+		case patch.ID() == uuid.Nil:
+			return apperror.InvalidInput(field+".id", "cannot be empty")
+		case seen[patch.ID()]:
+			return apperror.InvalidInput(field+".id", "duplicate subscription "+patch.ID().String())
+		case patch.IsEmpty():
+			return apperror.InvalidInput(field, "must change at least one field")
+		}
+		seen[patch.ID()] = true
+	}
+	return nil
+}
+
+func markRolledBack(items []*models.BulkUpdateItemResult, patches []*models.SubscriptionPatch) {
+	for i := range items {
+		items[i] = models.NewBulkUpdateItemResult(patches[i].ID(), models.BulkItemRolledBack, nil)
+	}
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+func newTestBulkService(t *testing.T) (*subscriptionBulkService, *mocks.MockSubscriptionService, *[]error) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	subscriptions := mocks.NewMockSubscriptionService(ctrl)
+	tx := mocks.NewMockTransactor(ctrl)
+
+	// Ошибки, с которыми завершились транзакции: nil — коммит.
+	outcomes := &[]error{}
+	tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			*outcomes = append(*outcomes, err)
+			return err
+		}).AnyTimes()
+
+	log, err := logger.NewLogger(logger.Config{Level: "fatal"})
+	if err != nil {
+		t.Fatalf("logger: %v", err)
+	}
+	return NewSubscriptionBulkService(subscriptions, tx, log), subscriptions, outcomes
+}
+
+func pricePatch(id uuid.UUID, price int) *models.SubscriptionPatch {
+	return models.NewSubscriptionPatch(id, nil, &price, nil, nil, nil, nil, nil, nil, nil, nil)
+}
+
+func TestSubscriptionBulkService_UpdateSubscriptions(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	notFound := apperror.SubscriptionNotFound(ids[1].String())
+
+	cases := []struct {
+		name      string
+		errs      []error
+		want      []models.BulkItemStatus
+		wantErr   bool
+		committed bool
+	}{
+		{"all applied", []error{nil, nil, nil},
+			[]models.BulkItemStatus{models.BulkItemUpdated, models.BulkItemUpdated, models.BulkItemUpdated}, false, true},
+		{"rejected item rolls back the batch", []error{nil, notFound},
+			[]models.BulkItemStatus{models.BulkItemRolledBack, models.BulkItemFailed, models.BulkItemSkipped}, false, false},
+		{"database error fails the request", []error{nil, errDatabase}, nil, true, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, subscriptions, outcomes := newTestBulkService(t)
+			for i, err := range tc.errs {
+				sub := models.NewSubscription("Netflix", models.Rubles(100*(i+1)), uuid.New(), time.Now())
+				subscriptions.EXPECT().
+					UpdateSubscription(gomock.Any(), ids[i], nil, ptr(100*(i+1)), nil, nil, nil, nil, nil, nil, nil, nil).
+					Return(sub, err)
+			}
+
+			patches := make([]*models.SubscriptionPatch, len(ids))
+			for i, id := range ids {
+				patches[i] = pricePatch(id, 100*(i+1))
+			}
+
+			result, err := s.UpdateSubscriptions(context.Background(), patches)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("UpdateSubscriptions() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if committed := len(*outcomes) == 1 && (*outcomes)[0] == nil; committed != tc.committed {
+				t.Fatalf("committed = %v, want %v", committed, tc.committed)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			if result.Applied() != tc.committed {
+				t.Errorf("Applied() = %v, want %v", result.Applied(), tc.committed)
+			}
+			for i, item := range result.Items() {
+				if item.ID() != ids[i] || item.Status() != tc.want[i] {
+					t.Errorf("item %d = %s %s, want %s %s", i, item.ID(), item.Status(), ids[i], tc.want[i])
+				}
+			}
+			if failed := result.Items()[1]; failed.Status() == models.BulkItemFailed && failed.ErrorCode() != apperror.CodeSubscriptionNotFound {
+				t.Errorf("failed item code = %s", failed.ErrorCode())
+			}
+		})
+	}
+}
+
+func TestSubscriptionBulkService_UpdateSubscriptionsValidation(t *testing.T) {
+	id := uuid.New()
+	tooMany := make([]*models.SubscriptionPatch, models.MaxBulkUpdateItems+1)
+	for i := range tooMany {
+		tooMany[i] = pricePatch(uuid.New(), 100)
+	}
+
+	cases := []struct {
+		name    string
+		patches []*models.SubscriptionPatch
+	}{
+		{"empty", nil},
+		{"too many", tooMany},
+		{"missing id", []*models.SubscriptionPatch{pricePatch(uuid.Nil, 100)}},
+		{"duplicate id", []*models.SubscriptionPatch{pricePatch(id, 100), pricePatch(id, 200)}},
+		{"no fields", []*models.SubscriptionPatch{models.NewSubscriptionPatch(id, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, _ := newTestBulkService(t)
+			_, err := s.UpdateSubscriptions(context.Background(), tc.patches)
+			assertErrorCode(t, err, apperror.CodeInvalidInput)
+		})
+	}
+}
+
+func TestSubscriptionBulkService_UpdateByFilter(t *testing.T) {
+	exact := models.NewSubscription("Yandex+", models.Rubles(299), uuid.New(), time.Now())
+	lower := models.NewSubscription("yandex+", models.Rubles(299), uuid.New(), time.Now())
+	// Подстрока для репозитория, но другой сервис.
+	other := models.NewSubscription("Yandex+ Music", models.Rubles(199), uuid.New(), time.Now())
+	rename := models.NewSubscriptionPatch(uuid.Nil, ptr("Yandex Plus"), nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	byName := func() *models.SubscriptionFilter {
+		filter := models.NewSubscriptionFilter()
+		filter.SetServiceName(ptr("Yandex+"))
+		return filter
+	}
+
+	t.Run("dry run counts exact matches", func(t *testing.T) {
+		s, subscriptions, outcomes := newTestBulkService(t)
+		subscriptions.EXPECT().ExportSubscriptions(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.SubscriptionFilter, fn func(*models.Subscription) error) error {
+				for _, sub := range []*models.Subscription{exact, other, lower} {
+					if err := fn(sub); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		result, err := s.UpdateByFilter(context.Background(), byName(), rename, true)
+		if err != nil {
+			t.Fatalf("UpdateByFilter() error = %v", err)
+		}
+		if result.Matched() != 2 || result.Updated() != 0 || len(*outcomes) != 0 {
+			t.Fatalf("dry run: matched %d, updated %d, transactions %d", result.Matched(), result.Updated(), len(*outcomes))
+		}
+	})
+
+	t.Run("applies patch to every match", func(t *testing.T) {
+		s, subscriptions, outcomes := newTestBulkService(t)
+		subscriptions.EXPECT().ExportSubscriptions(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.SubscriptionFilter, fn func(*models.Subscription) error) error {
+				_ = fn(exact)
+				return fn(lower)
+			})
+		for _, sub := range []*models.Subscription{exact, lower} {
+			subscriptions.EXPECT().
+				UpdateSubscription(gomock.Any(), sub.ID(), rename.ServiceName(), nil, nil, nil, nil, nil, nil, nil, nil, nil).
+				Return(sub, nil)
+		}
+
+		result, err := s.UpdateByFilter(context.Background(), byName(), rename, false)
+		if err != nil {
+			t.Fatalf("UpdateByFilter() error = %v", err)
+		}
+		if result.Updated() != 2 || len(*outcomes) != 1 || (*outcomes)[0] != nil {
+			t.Fatalf("apply: updated %d, transactions %v", result.Updated(), *outcomes)
+		}
+	})
+
+	t.Run("rejected row rolls back", func(t *testing.T) {
+		s, subscriptions, outcomes := newTestBulkService(t)
+		subscriptions.EXPECT().ExportSubscriptions(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.SubscriptionFilter, fn func(*models.Subscription) error) error {
+				return fn(exact)
+			})
+		subscriptions.EXPECT().
+			UpdateSubscription(gomock.Any(), exact.ID(), gomock.Any(), nil, nil, nil, nil, nil, nil, nil, nil, nil).
+			Return(nil, apperror.InvalidInput("service_name", "denied"))
+
+		_, err := s.UpdateByFilter(context.Background(), byName(), rename, false)
+		assertErrorCode(t, err, apperror.CodeInvalidInput)
+		if len(*outcomes) != 1 || (*outcomes)[0] == nil {
+			t.Fatalf("transaction must roll back, got %v", *outcomes)
+		}
+	})
+
+	t.Run("too many matches", func(t *testing.T) {
+		s, subscriptions, _ := newTestBulkService(t)
+		subscriptions.EXPECT().ExportSubscriptions(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.SubscriptionFilter, fn func(*models.Subscription) error) error {
+				for i := 0; i <= models.MaxBulkFilterUpdateRows; i++ {
+					if err := fn(exact); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		_, err := s.UpdateByFilter(context.Background(), byName(), rename, false)
+		assertErrorCode(t, err, apperror.CodeInvalidInput)
+	})
+
+	t.Run("filter is required", func(t *testing.T) {
+		s, _, _ := newTestBulkService(t)
+		_, err := s.UpdateByFilter(context.Background(), models.NewSubscriptionFilter(), rename, true)
+		assertErrorCode(t, err, apperror.CodeInvalidInput)
+	})
+
+	t.Run("export error", func(t *testing.T) {
+		s, subscriptions, _ := newTestBulkService(t)
+		subscriptions.EXPECT().ExportSubscriptions(gomock.Any(), gomock.Any(), gomock.Any()).Return(errDatabase)
+
+		_, err := s.UpdateByFilter(context.Background(), byName(), rename, true)
+		if !errors.Is(err, errDatabase) {
+			t.Fatalf("UpdateByFilter() error = %v, want %v", err, errDatabase)
+		}
+	})
+}
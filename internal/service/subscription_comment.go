@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+/*
+subscriptionCommentService — заметки к подпискам. Перед любой операцией
+загружает подписку: несуществующая даёт 404, а не пустой список, чужая
+для пользователя из JWT — 403, как и сама подписка.
+*/
+type subscriptionCommentService struct {
+	comments      repository.SubscriptionCommentRepository
+	subscriptions repository.SubscriptionRepository
+	log           *logger.Logger
+}
+
+/** Конструктор сервиса комментариев. */
+func NewSubscriptionCommentService(comments repository.SubscriptionCommentRepository, subscriptions repository.SubscriptionRepository, log *logger.Logger) *subscriptionCommentService {
+	return &subscriptionCommentService{
+		comments:      comments,
+		subscriptions: subscriptions,
+		log:           log.Named("subscription-comment-service"),
+	}
+}
+
+/** Добавляет комментарий к подписке. */
+func (s *subscriptionCommentService) AddComment(ctx context.Context, subscriptionID uuid.UUID, author, body string) (*models.SubscriptionComment, error) {
+	if _, err := loadOwnedSubscription(ctx, s.subscriptions, subscriptionID, models.PermissionSubscriptionsWrite); err != nil {
+		return nil, err
+	}
+
+	comment := models.NewSubscriptionComment(subscriptionID, author, body)
+	if err := comment.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("comment", err.Error())
+	}
+
+	if err := s.comments.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("subscription comment added",
+		zap.String("subscription_id", subscriptionID.String()),
+		zap.String("comment_id", comment.ID().String()),
+		zap.String("author", comment.Author()))
+
+	return comment, nil
+}
+
+/** Возвращает страницу комментариев в хронологическом порядке и их общее число. */
+func (s *subscriptionCommentService) ListComments(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]*models.SubscriptionComment, int, error) {
+	if _, err := loadOwnedSubscription(ctx, s.subscriptions, subscriptionID, models.PermissionSubscriptionsRead); err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset, err := utils.ValidatePagination(limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	comments, err := s.comments.ListBySubscriptionID(ctx, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.comments.CountBySubscriptionID(ctx, subscriptionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+func TestSubscriptionCommentService_Access(t *testing.T) {
+	subscriptionID := uuid.New()
+	owner := uuid.New()
+
+	tests := []struct {
+		name      string
+		principal *models.Principal
+		found     bool
+		code      string
+	}{
+		{name: "no principal", found: true},
+		{name: "owner's token", principal: models.NewPrincipal(owner.String(), models.RoleViewer, models.PrincipalSourceJWT), found: true},
+		{name: "admin token", principal: models.NewPrincipal(uuid.NewString(), models.RoleAdmin, models.PrincipalSourceJWT), found: true},
+		{name: "another user's token", principal: models.NewPrincipal(uuid.NewString(), models.RoleOperator, models.PrincipalSourceJWT), found: true, code: apperror.CodeForbidden},
+		{name: "unknown subscription", code: apperror.CodeSubscriptionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run("add: "+tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			comments := mocks.NewMockSubscriptionCommentRepository(ctrl)
+			subscriptions := mocks.NewMockSubscriptionRepository(ctrl)
+			s := NewSubscriptionCommentService(comments, subscriptions, testLogger(t))
+
+			expectSubscription(subscriptions, subscriptionID, owner, tt.found)
+			if tt.code == "" {
+				comments.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			_, err := s.AddComment(principalContext(tt.principal), subscriptionID, "support", "Refund requested")
+			assertErrorCode(t, err, tt.code)
+		})
+
+		t.Run("list: "+tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			comments := mocks.NewMockSubscriptionCommentRepository(ctrl)
+			subscriptions := mocks.NewMockSubscriptionRepository(ctrl)
+			s := NewSubscriptionCommentService(comments, subscriptions, testLogger(t))
+
+			expectSubscription(subscriptions, subscriptionID, owner, tt.found)
+			if tt.code == "" {
+				comments.EXPECT().ListBySubscriptionID(gomock.Any(), subscriptionID, gomock.Any(), gomock.Any()).Return(nil, nil)
+				comments.EXPECT().CountBySubscriptionID(gomock.Any(), subscriptionID).Return(0, nil)
+			}
+
+			_, _, err := s.ListComments(principalContext(tt.principal), subscriptionID, 10, 0)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func expectSubscription(subscriptions *mocks.MockSubscriptionRepository, id, owner uuid.UUID, found bool) {
+	if !found {
+		subscriptions.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+		return
+	}
+	subscriptions.EXPECT().GetByID(gomock.Any(), id).Return(ownedSubscription(id, owner), nil)
+}
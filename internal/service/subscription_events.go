@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/** Режимы записи событий по подпискам. */
+const (
+	EventDeliveryBestEffort    = "best_effort"
+	EventDeliveryTransactional = "transactional"
+)
+
+/*
+SubscriptionEventRecorder — записывает событие, аудит и outbox для
+каждого изменения подписки и публикует событие в шину после коммита.
+  - best_effort: изменение коммитится сразу, события пишутся асинхронно;
+    событие, которое не удалось записать, сохраняется в dead_letters.
+  - transactional: изменение и все три записи коммитятся одной транзакцией,
+    ошибка записи откатывает изменение.
+*/
+type SubscriptionEventRecorder struct {
+	events        repository.SubscriptionEventRepository
+	deadLetters   repository.DeadLetterRepository
+	tx            repository.Transactor
+	transactional bool
+	asyncTimeout  time.Duration
+	publisher     repository.EventPublisher
+	log           *logger.Logger
+	wg            sync.WaitGroup
+}
+
+/*
+Конструктор. delivery — одна из констант EventDelivery*. events может быть
+nil — тогда события не пишутся в БД, но публикуются; publisher может быть
+nil — тогда не публикуются.
+*/
+func NewSubscriptionEventRecorder(events repository.SubscriptionEventRepository, deadLetters repository.DeadLetterRepository, tx repository.Transactor, delivery string, asyncTimeout time.Duration, publisher repository.EventPublisher, log *logger.Logger) *SubscriptionEventRecorder {
+	return &SubscriptionEventRecorder{
+		events:        events,
+		deadLetters:   deadLetters,
+		tx:            tx,
+		transactional: delivery == EventDeliveryTransactional,
+		asyncTimeout:  asyncTimeout,
+		publisher:     publisher,
+		log:           log.Named("subscription-events"),
+	}
+}
+
+/*
+apply выполняет изменение op и записывает событие, которое строит event.
+event вызывается только после успешного op, чтобы взять итоговое состояние.
+Если ctx уже несёт транзакцию (команда биллинга), событие пишется в ней
+же при любом режиме: асинхронная запись не увидела бы незакоммиченную
+подписку. Публикация в шину откладывается до коммита.
+*/
+func (r *SubscriptionEventRecorder) apply(ctx context.Context, op func(ctx context.Context) error, event func() *models.SubscriptionEvent) error {
+	if r == nil {
+		return op(ctx)
+	}
+
+	if r.events == nil {
+		if err := op(ctx); err != nil {
+			return err
+		}
+		r.publish(ctx, event())
+		return nil
+	}
+
+	if r.transactional || r.tx.InTransaction(ctx) {
+		var evt *models.SubscriptionEvent
+		err := r.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := op(ctx); err != nil {
+				return err
+			}
+			evt = event()
+			if err := r.events.Record(ctx, evt); err != nil {
+				return err
+			}
+			r.publish(ctx, evt)
+			return nil
+		})
+		return err
+	}
+
+	if err := op(ctx); err != nil {
+		return err
+	}
+
+	evt := event()
+	r.publish(ctx, evt)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.asyncTimeout)
+		defer cancel()
+
+		if err := r.events.Record(ctx, evt); err != nil {
+			r.deadLetter(ctx, evt, err)
+		}
+	}()
+
+	return nil
+}
+
+// publish отправляет событие в шину после коммита транзакции из ctx, чтобы
+// потребители не увидели изменение, которое затем откатится.
+func (r *SubscriptionEventRecorder) publish(ctx context.Context, evt *models.SubscriptionEvent) {
+	if r.publisher == nil {
+		return
+	}
+	r.tx.AfterCommit(ctx, func(ctx context.Context) {
+		r.publisher.Publish(ctx, models.NewDomainEvent(evt))
+	})
+}
+
+// deadLetter сохраняет событие для ручной повторной отправки; если и это не
+// удалось (обычно БД недоступна), событие теряется.
+func (r *SubscriptionEventRecorder) deadLetter(ctx context.Context, evt *models.SubscriptionEvent, cause error) {
+	err := r.deadLetters.CreateForEvent(ctx, evt, models.DeadLetterSourceEventRecording, cause.Error())
+	if err == nil {
+		r.log.Warn("subscription event moved to dead letters",
+			zap.String("event_type", evt.Type()),
+			zap.String("subscription_id", evt.SubscriptionID().String()),
+			zap.Error(cause))
+		return
+	}
+
+	r.log.Warn("subscription event dropped",
+		zap.String("event_type", evt.Type()),
+		zap.String("subscription_id", evt.SubscriptionID().String()),
+		zap.Error(cause),
+		zap.NamedError("dead_letter_error", err))
+}
+
+/** Дожидается завершения асинхронных записей. Вызывается при остановке. */
+func (r *SubscriptionEventRecorder) Close() {
+	if r == nil {
+		return
+	}
+	r.wg.Wait()
+}
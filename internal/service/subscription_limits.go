@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+SubscriptionLimits ограничивает число текущих (не архивных) подписок одного
+пользователя — защита от импорта, который зациклился. Лимит мягкий: число
+считается до вставки без блокировок, и параллельные создания могут
+превысить его на несколько подписок. Лимит по умолчанию берётся из
+limits.max_subscriptions_per_user, администратор может задать пользователю
+свой.
+*/
+type SubscriptionLimits struct {
+	repo          repository.SubscriptionLimitRepository
+	subscriptions repository.SubscriptionRepository
+	defaultLimit  int
+	log           *logger.Logger
+}
+
+/** Конструктор. defaultLimit = 0 — без ограничения. */
+func NewSubscriptionLimits(repo repository.SubscriptionLimitRepository, subscriptions repository.SubscriptionRepository, defaultLimit int, log *logger.Logger) *SubscriptionLimits {
+	return &SubscriptionLimits{
+		repo:          repo,
+		subscriptions: subscriptions,
+		defaultLimit:  defaultLimit,
+		log:           log.Named("subscription-limits"),
+	}
+}
+
+/** Возвращает действующий лимит пользователя и число его подписок. */
+func (s *SubscriptionLimits) GetSubscriptionLimit(ctx context.Context, userID uuid.UUID) (*models.SubscriptionLimitUsage, error) {
+	if userID == uuid.Nil {
+		return nil, apperror.InvalidInput("user_id", "cannot be empty")
+	}
+
+	limit, override, err := s.effectiveLimit(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.countSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewSubscriptionLimitUsage(userID, limit, override, used), nil
+}
+
+/*
+Задаёт пользователю свой лимит после проверки. Уже существующие подписки
+сверх нового лимита не трогаются: запрещается только создание новых.
+*/
+func (s *SubscriptionLimits) SetSubscriptionLimit(ctx context.Context, userID uuid.UUID, maxSubscriptions int, reason string) (*models.SubscriptionLimitUsage, error) {
+	limit := models.NewSubscriptionLimit(userID, maxSubscriptions, reason)
+	if err := limit.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("subscription_limit", err.Error())
+	}
+
+	if err := s.repo.Save(ctx, limit); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("subscription limit set",
+		zap.String("user_id", userID.String()),
+		zap.Int("max_subscriptions", maxSubscriptions))
+
+	used, err := s.countSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewSubscriptionLimitUsage(userID, maxSubscriptions, limit, used), nil
+}
+
+/** Удаляет лимит пользователя; дальше действует лимит по умолчанию. */
+func (s *SubscriptionLimits) ResetSubscriptionLimit(ctx context.Context, userID uuid.UUID) error {
+	if userID == uuid.Nil {
+		return apperror.InvalidInput("user_id", "cannot be empty")
+	}
+
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.log.Info("subscription limit reset", zap.String("user_id", userID.String()))
+	return nil
+}
+
+/*
+CheckSubscriptionLimit возвращает LIMIT_EXCEEDED, если у пользователя уже
+столько подписок, сколько разрешено. Безопасен для nil: без лимитов
+разрешено всё.
+*/
+func (s *SubscriptionLimits) CheckSubscriptionLimit(ctx context.Context, userID uuid.UUID) error {
+	if s == nil {
+		return nil
+	}
+
+	limit, _, err := s.effectiveLimit(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if limit == 0 {
+		return nil
+	}
+
+	used, err := s.countSubscriptions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if used < limit {
+		return nil
+	}
+
+	s.log.Warn("subscription limit exceeded",
+		zap.String("user_id", userID.String()),
+		zap.Int("limit", limit),
+		zap.Int("used", used))
+	return apperror.LimitExceeded("subscriptions", limit).
+		WithDetail("user_id", userID.String())
+}
+
+// effectiveLimit возвращает лимит администратора, если он задан, иначе
+// лимит по умолчанию.
+func (s *SubscriptionLimits) effectiveLimit(ctx context.Context, userID uuid.UUID) (int, *models.SubscriptionLimit, error) {
+	override, err := s.repo.GetByUser(ctx, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if override != nil {
+		return override.MaxSubscriptions(), override, nil
+	}
+	return s.defaultLimit, nil, nil
+}
+
+func (s *SubscriptionLimits) countSubscriptions(ctx context.Context, userID uuid.UUID) (int, error) {
+	filter := models.NewSubscriptionFilter()
+	filter.SetUserID(&userID)
+	return s.subscriptions.Count(ctx, filter)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+func newTestSubscriptionLimits(t *testing.T, defaultLimit int) (*SubscriptionLimits, *mocks.MockSubscriptionLimitRepository, *mocks.MockSubscriptionRepository) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockSubscriptionLimitRepository(ctrl)
+	subscriptions := mocks.NewMockSubscriptionRepository(ctrl)
+
+	return NewSubscriptionLimits(repo, subscriptions, defaultLimit, testLogger(t)), repo, subscriptions
+}
+
+// userFilter проверяет, что подписки считаются по одному пользователю.
+func userFilter(userID uuid.UUID) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		filter, ok := x.(*models.SubscriptionFilter)
+		return ok && filter.UserID() != nil && *filter.UserID() == userID && !filter.Archived()
+	})
+}
+
+func TestSubscriptionLimits_CheckSubscriptionLimit(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name         string
+		defaultLimit int
+		override     *models.SubscriptionLimit
+		overrideErr  error
+		used         int
+		counted      bool
+		code         string
+	}{
+		{name: "unlimited by default", defaultLimit: 0},
+		{name: "below default limit", defaultLimit: 3, used: 2, counted: true},
+		{name: "at default limit", defaultLimit: 3, used: 3, counted: true, code: apperror.CodeLimitExceeded},
+		{name: "override lifts limit", defaultLimit: 3, override: models.NewSubscriptionLimit(userID, 0, "import")},
+		{name: "override raises limit", defaultLimit: 3, override: models.NewSubscriptionLimit(userID, 10, "import"), used: 5, counted: true},
+		{name: "override lowers limit", defaultLimit: 0, override: models.NewSubscriptionLimit(userID, 1, "abuse"), used: 1, counted: true, code: apperror.CodeLimitExceeded},
+		{name: "override lookup fails", defaultLimit: 3, overrideErr: errDatabase, code: apperror.CodeDatabaseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, repo, subscriptions := newTestSubscriptionLimits(t, tt.defaultLimit)
+			repo.EXPECT().GetByUser(gomock.Any(), userID).Return(tt.override, tt.overrideErr)
+			if tt.counted {
+				subscriptions.EXPECT().Count(gomock.Any(), userFilter(userID)).Return(tt.used, nil)
+			}
+
+			err := limits.CheckSubscriptionLimit(context.Background(), userID)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestSubscriptionLimits_NilAllowsEverything(t *testing.T) {
+	var limits *SubscriptionLimits
+	if err := limits.CheckSubscriptionLimit(context.Background(), uuid.New()); err != nil {
+		t.Errorf("CheckSubscriptionLimit() on nil = %v, want nil", err)
+	}
+}
+
+func TestSubscriptionLimits_GetSubscriptionLimit(t *testing.T) {
+	userID := uuid.New()
+	override := models.RestoreSubscriptionLimit(userID, 50, "import", time.Now())
+
+	limits, repo, subscriptions := newTestSubscriptionLimits(t, 10)
+	repo.EXPECT().GetByUser(gomock.Any(), userID).Return(override, nil)
+	subscriptions.EXPECT().Count(gomock.Any(), userFilter(userID)).Return(12, nil)
+
+	usage, err := limits.GetSubscriptionLimit(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("GetSubscriptionLimit() error = %v", err)
+	}
+	if usage.Limit() != 50 || usage.Override() != override || usage.Used() != 12 {
+		t.Errorf("usage = limit %d, override %v, used %d; want 50, override, 12", usage.Limit(), usage.Override(), usage.Used())
+	}
+}
+
+func TestSubscriptionLimits_SetSubscriptionLimit(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("saves override", func(t *testing.T) {
+		limits, repo, subscriptions := newTestSubscriptionLimits(t, 10)
+		repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, limit *models.SubscriptionLimit) error {
+			if limit.UserID() != userID || limit.MaxSubscriptions() != 0 || limit.Reason() != "trusted import" {
+				t.Errorf("saved limit = %s/%d/%q", limit.UserID(), limit.MaxSubscriptions(), limit.Reason())
+			}
+			return nil
+		})
+		subscriptions.EXPECT().Count(gomock.Any(), userFilter(userID)).Return(7, nil)
+
+		usage, err := limits.SetSubscriptionLimit(context.Background(), userID, 0, "trusted import")
+		if err != nil {
+			t.Fatalf("SetSubscriptionLimit() error = %v", err)
+		}
+		if usage.Limit() != 0 || usage.Override() == nil || usage.Used() != 7 {
+			t.Errorf("usage = limit %d, override %v, used %d; want 0, override, 7", usage.Limit(), usage.Override(), usage.Used())
+		}
+	})
+
+	t.Run("rejects negative limit", func(t *testing.T) {
+		limits, _, _ := newTestSubscriptionLimits(t, 10)
+
+		_, err := limits.SetSubscriptionLimit(context.Background(), userID, -1, "")
+		assertErrorCode(t, err, apperror.CodeValidationFailed)
+	})
+}
+
+func TestSubscriptionService_CreateSubscriptionLimit(t *testing.T) {
+	userID := uuid.New()
+
+	svc, m := newTestSubscriptionService(t)
+	limits, repo, _ := newTestSubscriptionLimits(t, 2)
+	limits.subscriptions = m.repo
+	svc.limits = limits
+
+	repo.EXPECT().GetByUser(gomock.Any(), userID).Return(nil, nil)
+	m.repo.EXPECT().Count(gomock.Any(), userFilter(userID)).Return(2, nil)
+
+	_, err := svc.CreateSubscription(context.Background(), "Netflix", 799, userID, "07-2025", nil, nil, nil, nil, nil, nil, nil, nil, "", nil)
+	assertErrorCode(t, err, apperror.CodeLimitExceeded)
+
+	appErr, _ := apperror.IsAppError(err)
+	if appErr.Details()["limit"] != "2" {
+		t.Errorf("details = %v, want limit 2", appErr.Details())
+	}
+}
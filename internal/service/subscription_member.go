@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+/*
+subscriptionMemberService — участники общих подписок. Доли проверяет
+репозиторий под блокировкой подписки; сервис валидирует ввод, отдаёт 404
+для несуществующей подписки и 403 пользователю из JWT для чужой.
+*/
+type subscriptionMemberService struct {
+	members       repository.SubscriptionMemberRepository
+	subscriptions repository.SubscriptionRepository
+	costs         *CostCache
+	log           *logger.Logger
+}
+
+/*
+Конструктор сервиса участников. costs может быть nil; иначе изменение
+участников сбрасывает кеш стоимости целиком — доли меняются и у
+участника, и у владельца.
+*/
+func NewSubscriptionMemberService(members repository.SubscriptionMemberRepository, subscriptions repository.SubscriptionRepository, costs *CostCache, log *logger.Logger) *subscriptionMemberService {
+	return &subscriptionMemberService{
+		members:       members,
+		subscriptions: subscriptions,
+		costs:         costs,
+		log:           log.Named("subscription-member-service"),
+	}
+}
+
+/** Добавляет участника с долей sharePercent; владелец платит остаток. */
+func (s *subscriptionMemberService) AddMember(ctx context.Context, subscriptionID, userID uuid.UUID, sharePercent int) (*models.SubscriptionMember, error) {
+	if _, err := loadOwnedSubscription(ctx, s.subscriptions, subscriptionID, models.PermissionSubscriptionsWrite); err != nil {
+		return nil, err
+	}
+
+	member := models.NewSubscriptionMember(subscriptionID, userID, sharePercent)
+	if err := member.Validate(); err != nil {
+		return nil, apperror.ValidationFailed("member", err.Error())
+	}
+
+	if err := s.members.Add(ctx, member); err != nil {
+		return nil, err
+	}
+	s.costs.InvalidateAll()
+
+	s.log.Info("subscription member added",
+		zap.String("subscription_id", subscriptionID.String()),
+		zap.String("user_id", userID.String()),
+		zap.Int("share_percent", sharePercent))
+
+	return member, nil
+}
+
+/** Участники подписки в порядке добавления. */
+func (s *subscriptionMemberService) ListMembers(ctx context.Context, subscriptionID uuid.UUID) ([]*models.SubscriptionMember, error) {
+	if _, err := loadOwnedSubscription(ctx, s.subscriptions, subscriptionID, models.PermissionSubscriptionsRead); err != nil {
+		return nil, err
+	}
+
+	return s.members.ListBySubscriptionID(ctx, subscriptionID)
+}
+
+/** Убирает участника; его доля возвращается владельцу. */
+func (s *subscriptionMemberService) RemoveMember(ctx context.Context, subscriptionID, userID uuid.UUID) error {
+	if _, err := loadOwnedSubscription(ctx, s.subscriptions, subscriptionID, models.PermissionSubscriptionsWrite); err != nil {
+		return err
+	}
+
+	if err := s.members.Remove(ctx, subscriptionID, userID); err != nil {
+		return err
+	}
+	s.costs.InvalidateAll()
+
+	s.log.Info("subscription member removed",
+		zap.String("subscription_id", subscriptionID.String()),
+		zap.String("user_id", userID.String()))
+
+	return nil
+}
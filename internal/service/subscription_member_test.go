@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+// ownedSubscription — подписка владельца owner с идентификатором id.
+func ownedSubscription(id, owner uuid.UUID) *models.Subscription {
+	sub := models.NewSubscription("Spotify Family", models.Rubles(269), owner, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	sub.SetID(id)
+	return sub
+}
+
+// principalContext — ctx с клиентом principal; nil — без клиента.
+func principalContext(principal *models.Principal) context.Context {
+	if principal == nil {
+		return context.Background()
+	}
+	return models.ContextWithPrincipal(context.Background(), principal)
+}
+
+func TestSubscriptionMemberService_AddMember(t *testing.T) {
+	subscriptionID := uuid.New()
+	owner := uuid.New()
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		share  int
+		repo   error
+		code   string
+	}{
+		{name: "valid member", userID: uuid.New(), share: 50},
+		{name: "whole price", userID: uuid.New(), share: 100},
+		{name: "zero share", userID: uuid.New(), share: 0, code: apperror.CodeValidationFailed},
+		{name: "share above 100", userID: uuid.New(), share: 101, code: apperror.CodeValidationFailed},
+		{name: "empty user", userID: uuid.Nil, share: 50, code: apperror.CodeValidationFailed},
+		{name: "shares exceed 100", userID: uuid.New(), share: 60,
+			repo: apperror.Conflict("subscription member", "member shares would exceed 100%"), code: apperror.CodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			members := mocks.NewMockSubscriptionMemberRepository(ctrl)
+			subscriptions := mocks.NewMockSubscriptionRepository(ctrl)
+			s := NewSubscriptionMemberService(members, subscriptions, nil, testLogger(t))
+
+			subscriptions.EXPECT().GetByID(gomock.Any(), subscriptionID).Return(ownedSubscription(subscriptionID, owner), nil)
+			if tt.code == "" || tt.repo != nil {
+				members.EXPECT().Add(gomock.Any(), gomock.Any()).Return(tt.repo)
+			}
+
+			member, err := s.AddMember(context.Background(), subscriptionID, tt.userID, tt.share)
+			assertErrorCode(t, err, tt.code)
+			if tt.code == "" && (member.UserID() != tt.userID || member.SharePercent() != tt.share) {
+				t.Errorf("member: got %s %d", member.UserID(), member.SharePercent())
+			}
+		})
+	}
+}
+
+func TestSubscriptionMemberService_ListMembers(t *testing.T) {
+	subscriptionID := uuid.New()
+	owner := uuid.New()
+
+	t.Run("unknown subscription", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		subscriptions := mocks.NewMockSubscriptionRepository(ctrl)
+		s := NewSubscriptionMemberService(mocks.NewMockSubscriptionMemberRepository(ctrl), subscriptions, nil, testLogger(t))
+		subscriptions.EXPECT().GetByID(gomock.Any(), subscriptionID).Return(nil, nil)
+
+		_, err := s.ListMembers(context.Background(), subscriptionID)
+		assertErrorCode(t, err, apperror.CodeSubscriptionNotFound)
+	})
+
+	t.Run("owner keeps the rest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		subscriptions := mocks.NewMockSubscriptionRepository(ctrl)
+		members := mocks.NewMockSubscriptionMemberRepository(ctrl)
+		s := NewSubscriptionMemberService(members, subscriptions, nil, testLogger(t))
+		subscriptions.EXPECT().GetByID(gomock.Any(), subscriptionID).Return(ownedSubscription(subscriptionID, owner), nil)
+		members.EXPECT().ListBySubscriptionID(gomock.Any(), subscriptionID).Return([]*models.SubscriptionMember{
+			models.NewSubscriptionMember(subscriptionID, uuid.New(), 25),
+			models.NewSubscriptionMember(subscriptionID, uuid.New(), 30),
+		}, nil)
+
+		list, err := s.ListMembers(context.Background(), subscriptionID)
+		if err != nil {
+			t.Fatalf("ListMembers() error = %v", err)
+		}
+		if share := models.OwnerSharePercent(list); share != 45 {
+			t.Errorf("owner share: got %d, want 45", share)
+		}
+	})
+}
+
+func TestSubscriptionMemberService_OwnerAccess(t *testing.T) {
+	subscriptionID := uuid.New()
+	owner := uuid.New()
+	stranger := models.NewPrincipal(uuid.NewString(), models.RoleOperator, models.PrincipalSourceJWT)
+
+	tests := []struct {
+		name    string
+		call    func(s *subscriptionMemberService, ctx context.Context) error
+		allowed func(members *mocks.MockSubscriptionMemberRepository)
+	}{
+		{
+			name: "list",
+			call: func(s *subscriptionMemberService, ctx context.Context) error {
+				_, err := s.ListMembers(ctx, subscriptionID)
+				return err
+			},
+			allowed: func(members *mocks.MockSubscriptionMemberRepository) {
+				members.EXPECT().ListBySubscriptionID(gomock.Any(), subscriptionID).Return(nil, nil)
+			},
+		},
+		{
+			name: "add",
+			call: func(s *subscriptionMemberService, ctx context.Context) error {
+				_, err := s.AddMember(ctx, subscriptionID, uuid.New(), 50)
+				return err
+			},
+			allowed: func(members *mocks.MockSubscriptionMemberRepository) {
+				members.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "remove",
+			call: func(s *subscriptionMemberService, ctx context.Context) error {
+				return s.RemoveMember(ctx, subscriptionID, uuid.New())
+			},
+			allowed: func(members *mocks.MockSubscriptionMemberRepository) {
+				members.EXPECT().Remove(gomock.Any(), subscriptionID, gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" by another user is forbidden", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			subscriptions := mocks.NewMockSubscriptionRepository(ctrl)
+			s := NewSubscriptionMemberService(mocks.NewMockSubscriptionMemberRepository(ctrl), subscriptions, nil, testLogger(t))
+			subscriptions.EXPECT().GetByID(gomock.Any(), subscriptionID).Return(ownedSubscription(subscriptionID, owner), nil)
+
+			assertErrorCode(t, tt.call(s, principalContext(stranger)), apperror.CodeForbidden)
+		})
+
+		t.Run(tt.name+" by the owner", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			subscriptions := mocks.NewMockSubscriptionRepository(ctrl)
+			members := mocks.NewMockSubscriptionMemberRepository(ctrl)
+			s := NewSubscriptionMemberService(members, subscriptions, nil, testLogger(t))
+			subscriptions.EXPECT().GetByID(gomock.Any(), subscriptionID).Return(ownedSubscription(subscriptionID, owner), nil)
+			tt.allowed(members)
+
+			principal := models.NewPrincipal(owner.String(), models.RoleViewer, models.PrincipalSourceJWT)
+			assertErrorCode(t, tt.call(s, principalContext(principal)), "")
+		})
+	}
+}
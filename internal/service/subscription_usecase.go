@@ -2,6 +2,11 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -9,6 +14,7 @@ import (
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/featureflags"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
 )
@@ -19,54 +25,171 @@ subscriptionService — слой бизнес-логики для работы 
 и запись логов.
 */
 type subscriptionService struct {
-	repo repository.SubscriptionRepository
-	log  *logger.Logger
+	repo      repository.SubscriptionRepository
+	discounts repository.DiscountRepository
+	plans     repository.PlanRepository
+	catalog   repository.ServiceCatalogRepository
+	tx        repository.Transactor
+	events    *SubscriptionEventRecorder
+	names     *ServiceNameRules
+	canonical *CanonicalServiceNames
+	costs     *CostCache
+	limits    *SubscriptionLimits
+	billing   models.BillingMode
+	flags     *featureflags.Flags
+	log       *logger.Logger
 }
 
-/** Конструктор сервиса, принимает репозиторий и логгер. */
-func NewSubscriptionService(repo repository.SubscriptionRepository, log *logger.Logger) *subscriptionService {
+/*
+Конструктор сервиса. events может быть nil — тогда события не пишутся;
+names может быть nil — тогда названия сервисов не ограничиваются;
+canonical может быть nil — тогда названия не приводятся к словарю;
+costs может быть nil — тогда стоимость не кешируется;
+limits может быть nil — тогда число подписок не ограничивается.
+billing — режим расчёта стоимости, если запрос не задал свой; flags может
+быть nil — тогда все флаги выключены.
+*/
+func NewSubscriptionService(repo repository.SubscriptionRepository, discounts repository.DiscountRepository, plans repository.PlanRepository, catalog repository.ServiceCatalogRepository, tx repository.Transactor, events *SubscriptionEventRecorder, names *ServiceNameRules, canonical *CanonicalServiceNames, costs *CostCache, limits *SubscriptionLimits, billing models.BillingMode, flags *featureflags.Flags, log *logger.Logger) *subscriptionService {
 	return &subscriptionService{
-		repo: repo,
-		log:  log.Named("subscription-service"),
+		repo:      repo,
+		discounts: discounts,
+		plans:     plans,
+		catalog:   catalog,
+		tx:        tx,
+		events:    events,
+		names:     names,
+		canonical: canonical,
+		costs:     costs,
+		limits:    limits,
+		billing:   billing,
+		flags:     flags,
+		log:       log.Named("subscription-service"),
 	}
 }
 
 /*
 CreateSubscription — создаёт новую подписку.
-- Валидирует входные данные.
-- Парсит даты начала/окончания.
-- Проверяет корректность диапазона.
-- Сохраняет подписку через репозиторий.
+  - Если задан planID, берёт название сервиса и месячную цену из тарифа.
+  - Если задан catalogID, берёт из записи каталога название и категорию, если они не заданы.
+  - Валидирует входные данные.
+  - Приводит название к словарю канонических названий.
+  - Проверяет название по белому и чёрному спискам.
+  - Парсит даты начала/окончания.
+  - Проверяет корректность диапазона.
+  - Нормализует теги, проверяет категорию, способ оплаты, день списания,
+    заметку и метаданные.
+  - Проверяет лимит числа подписок пользователя.
+  - Применяет промокод, если он передан.
+  - Сохраняет подписку через репозиторий.
 */
-func (s *subscriptionService) CreateSubscription(ctx context.Context, serviceName string, price int, userID uuid.UUID, startDate string, endDate *string) (*models.Subscription, error) {
+func (s *subscriptionService) CreateSubscription(ctx context.Context, serviceName string, price int, userID uuid.UUID, startDate string, endDate *string, promoCode *string, planID *uuid.UUID, catalogID *uuid.UUID, tags []string, category *string, paymentMethod *string, billingDay *int, notes string, metadata map[string]string) (*models.Subscription, error) {
 	s.log.Debug("creating subscription",
 		zap.String("service_name", serviceName),
 		zap.Int("price", price),
 		zap.String("user_id", userID.String()))
 
+	if planID != nil {
+		if serviceName != "" || price != 0 {
+			return nil, apperror.InvalidInput("plan_id", "service_name and price must be omitted when plan_id is set")
+		}
+
+		plan, err := s.plans.GetByID(ctx, *planID)
+		if err != nil {
+			return nil, err
+		}
+		serviceName = plan.ServiceName()
+		if price, err = plan.MonthlyPrice().WholeUnits(); err != nil {
+			return nil, apperror.InternalError("failed to read plan price", err)
+		}
+	}
+
+	if catalogID != nil {
+		entry, err := s.catalog.GetByID(ctx, *catalogID)
+		if err != nil {
+			return nil, err
+		}
+		if serviceName == "" {
+			serviceName = entry.Name()
+		}
+		if category == nil && entry.Category() != nil {
+			value := string(*entry.Category())
+			category = &value
+		}
+	}
+
 	if err := s.validateCreateInput(serviceName, price, userID); err != nil {
 		return nil, err
 	}
 
-	startTime, err := utils.ParseMonthYear(startDate)
+	serviceName, original, err := s.canonicalServiceName(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.names.CheckServiceName(ctx, serviceName); err != nil {
+		return nil, err
+	}
+
+	startTime, err := utils.ParseStartDate(startDate)
 	if err != nil {
 		return nil, err
 	}
-	startTime = utils.StartOfMonth(startTime)
 
 	subscription := models.NewSubscription(
-		utils.NormalizeString(serviceName),
-		price,
+		serviceName,
+		models.Rubles(price),
 		userID,
 		startTime,
 	)
+	subscription.SetPlanID(planID)
+	subscription.SetCatalogID(catalogID)
+
+	normalizedTags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	subscription.SetTags(normalizedTags)
+
+	if category != nil {
+		parsedCategory, err := parseCategory(*category)
+		if err != nil {
+			return nil, err
+		}
+		subscription.SetCategory(parsedCategory)
+	}
+
+	if paymentMethod != nil {
+		parsedPaymentMethod, err := parsePaymentMethod(*paymentMethod)
+		if err != nil {
+			return nil, err
+		}
+		subscription.SetPaymentMethod(parsedPaymentMethod)
+	}
+
+	if billingDay != nil {
+		if err := models.ValidateBillingDay(*billingDay); err != nil {
+			return nil, apperror.InvalidInput("billing_day", err.Error())
+		}
+		subscription.SetBillingDay(billingDay)
+	}
+
+	normalizedNotes, err := validateNotes(notes)
+	if err != nil {
+		return nil, err
+	}
+	subscription.SetNotes(normalizedNotes)
+
+	metadata = withOriginalServiceName(metadata, original)
+	if err := validateMetadata(metadata); err != nil {
+		return nil, err
+	}
+	subscription.SetMetadata(metadata)
 
 	if endDate != nil && *endDate != "" {
-		endTime, err := utils.ParseMonthYear(*endDate)
+		endTime, err := utils.ParseEndDate(*endDate)
 		if err != nil {
 			return nil, err
 		}
-		endTime = utils.EndOfMonth(endTime)
 
 		if err := utils.ValidateDateRange(&startTime, &endTime); err != nil {
 			return nil, err
@@ -79,7 +202,25 @@ func (s *subscriptionService) CreateSubscription(ctx context.Context, serviceNam
 		return nil, apperror.InvalidSubscriptionData("subscription", err.Error())
 	}
 
-	if err := s.repo.Create(ctx, subscription); err != nil {
+	if err := s.limits.CheckSubscriptionLimit(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if promoCode != nil && *promoCode != "" {
+		discount, err := s.redeemPromoCode(ctx, *promoCode, subscription)
+		if err != nil {
+			return nil, err
+		}
+		discountID := discount.ID()
+		subscription.SetDiscountID(&discountID)
+	}
+
+	err = s.events.apply(ctx, func(ctx context.Context) error {
+		return s.repo.Create(ctx, subscription)
+	}, func() *models.SubscriptionEvent {
+		return models.NewSubscriptionEvent(models.EventSubscriptionCreated, subscription)
+	})
+	if err != nil {
 		s.log.Error("failed to create subscription", zap.Error(err))
 		return nil, err
 	}
@@ -91,15 +232,51 @@ func (s *subscriptionService) CreateSubscription(ctx context.Context, serviceNam
 	return subscription, nil
 }
 
+/*
+redeemPromoCode находит промокод и проверяет, что он применим к подписке
+сейчас. Неизвестный или неподходящий код — PROMO_CODE_INVALID.
+*/
+func (s *subscriptionService) redeemPromoCode(ctx context.Context, code string, subscription *models.Subscription) (*models.Discount, error) {
+	discount, err := s.discounts.GetByCode(ctx, strings.TrimSpace(code))
+	if err != nil {
+		if appErr, ok := apperror.IsAppError(err); ok && appErr.Code() == apperror.CodeNotFound {
+			return nil, apperror.PromoCodeInvalid(code, "promo code does not exist")
+		}
+		return nil, err
+	}
+
+	if err := discount.CheckRedeemable(subscription.ServiceName(), time.Now()); err != nil {
+		return nil, apperror.PromoCodeInvalid(code, err.Error())
+	}
+
+	return discount, nil
+}
+
 /** Получает подписку по ID, возвращает ошибку если не найдена. */
 func (s *subscriptionService) GetSubscriptionByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
 	s.log.Debug("getting subscription by id", zap.String("subscription_id", id.String()))
 
+	return s.getOwnedSubscription(ctx, id, models.PermissionSubscriptionsRead)
+}
+
+/*
+getOwnedSubscription загружает подписку и проверяет, что клиент из
+контекста может с ней работать (см. authorizeOwner). permission — для
+текста ошибки: что именно клиенту не разрешено.
+*/
+func (s *subscriptionService) getOwnedSubscription(ctx context.Context, id uuid.UUID, permission models.Permission) (*models.Subscription, error) {
+	return loadOwnedSubscription(ctx, s.repo, id, permission)
+}
+
+// loadOwnedSubscription — getOwnedSubscription для сервисов вложенных
+// ресурсов подписки (комментарии, участники): доступ к ним — доступ к
+// самой подписке.
+func loadOwnedSubscription(ctx context.Context, repo repository.SubscriptionRepository, id uuid.UUID, permission models.Permission) (*models.Subscription, error) {
 	if id == uuid.Nil {
 		return nil, apperror.InvalidInput("id", "cannot be empty")
 	}
 
-	subscription, err := s.repo.GetByID(ctx, id)
+	subscription, err := repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -108,9 +285,29 @@ func (s *subscriptionService) GetSubscriptionByID(ctx context.Context, id uuid.U
 		return nil, apperror.SubscriptionNotFound(id.String())
 	}
 
+	if err := authorizeOwner(ctx, subscription, permission); err != nil {
+		return nil, err
+	}
+
 	return subscription, nil
 }
 
+/*
+authorizeOwner — проверка владельца на уровне сервиса, а не HTTP-обработчика,
+чтобы её получил любой транспорт. Пользователь из JWT работает только со
+своими подписками, администратор и сервисные клиенты — с любыми. Без
+клиента в контексте (аутентификация выключена, фоновые задачи, команды
+биллинга) проверки нет.
+*/
+func authorizeOwner(ctx context.Context, subscription *models.Subscription, permission models.Permission) error {
+	principal := models.PrincipalFromContext(ctx)
+	if principal == nil || principal.CanAccessUser(subscription.UserID()) {
+		return nil
+	}
+	return apperror.Forbidden(string(principal.Role()), string(permission)).
+		WithDetail("reason", "subscription belongs to another user")
+}
+
 /** Получает подписки по ID пользователя с пагинацией. */
 func (s *subscriptionService) GetSubscriptionsByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Subscription, error) {
 	s.log.Debug("getting subscriptions by user",
@@ -139,8 +336,8 @@ func (s *subscriptionService) GetSubscriptionsByUser(ctx context.Context, userID
 	return subscriptions, nil
 }
 
-/** Получает все подписки с фильтром и пагинацией. */
-func (s *subscriptionService) GetAllSubscriptions(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) ([]*models.Subscription, error) {
+/** Получает страницу подписок с фильтром и общее число подходящих под фильтр. */
+func (s *subscriptionService) GetAllSubscriptions(ctx context.Context, filter *models.SubscriptionFilter, limit, offset int) ([]*models.Subscription, int, error) {
 	s.log.Debug("getting filtered subscriptions",
 		zap.Int("limit", limit),
 		zap.Int("offset", offset))
@@ -150,33 +347,96 @@ func (s *subscriptionService) GetAllSubscriptions(ctx context.Context, filter *m
 	}
 
 	if err := filter.Validate(); err != nil {
-		return nil, apperror.InvalidFilterParams("filter", err.Error())
+		return nil, 0, apperror.InvalidFilterParams("filter", err.Error())
 	}
 
 	limit, offset, err := utils.ValidatePagination(limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	subscriptions, total, err := s.repo.GetAllWithTotal(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.log.Debug("retrieved filtered subscriptions",
+		zap.Int("count", len(subscriptions)),
+		zap.Int("total", total))
+
+	return subscriptions, total, nil
+}
+
+/*
+ExportSubscriptions передаёт fn все подписки под фильтром, от новых к
+старым, без пагинации. Память не растёт с размером выборки: подписки
+читаются из БД порциями.
+*/
+func (s *subscriptionService) ExportSubscriptions(ctx context.Context, filter *models.SubscriptionFilter, fn func(*models.Subscription) error) error {
+	if filter == nil {
+		filter = models.NewSubscriptionFilter()
+	}
+
+	if err := filter.Validate(); err != nil {
+		return apperror.InvalidFilterParams("filter", err.Error())
+	}
+
+	exported := 0
+	err := s.repo.IterateAll(ctx, filter, func(subscription *models.Subscription) error {
+		exported++
+		return fn(subscription)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.log.Debug("exported subscriptions", zap.Int("count", exported))
+	return nil
+}
+
+/*
+SearchSubscriptions — поиск по названию, тегам и заметкам с учётом
+опечаток. Результаты отсортированы по релевантности, совпадения подсвечены.
+*/
+func (s *subscriptionService) SearchSubscriptions(ctx context.Context, query string, userID *uuid.UUID, limit, offset int) ([]*models.SubscriptionSearchHit, error) {
+	s.log.Debug("searching subscriptions",
+		zap.String("query", query),
+		zap.Int("limit", limit),
+		zap.Int("offset", offset))
+
+	parsed, err := models.ParseSearchQuery(query)
+	if err != nil {
+		return nil, apperror.InvalidInput("q", err.Error())
+	}
+
+	limit, offset, err = utils.ValidatePagination(limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
-	subscriptions, err := s.repo.GetAll(ctx, filter, limit, offset)
+	hits, err := s.repo.Search(ctx, parsed, userID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
-	s.log.Debug("retrieved filtered subscriptions",
-		zap.Int("count", len(subscriptions)))
+	for _, hit := range hits {
+		hit.Highlight(parsed)
+	}
 
-	return subscriptions, nil
+	return hits, nil
 }
 
 /*
 UpdateSubscription — обновляет существующую подписку.
 Обновляет только те поля, которые переданы и изменились.
+tags и metadata заменяются целиком; пустая строка в category снимает категорию,
+в payment_method — способ оплаты, в notes — удаляет заметку; billing_day = 0
+возвращает списание в день даты начала.
 */
-func (s *subscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, serviceName *string, price *int, startDate *string, endDate *string) (*models.Subscription, error) {
+func (s *subscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, serviceName *string, price *int, startDate *string, endDate *string, tags *[]string, category *string, paymentMethod *string, billingDay *int, notes *string, metadata *map[string]string) (*models.Subscription, error) {
 	s.log.Debug("updating subscription", zap.String("subscription_id", id.String()))
 
-	subscription, err := s.GetSubscriptionByID(ctx, id)
+	subscription, err := s.getOwnedSubscription(ctx, id, models.PermissionSubscriptionsWrite)
 	if err != nil {
 		return nil, err
 	}
@@ -186,26 +446,39 @@ func (s *subscriptionService) UpdateSubscription(ctx context.Context, id uuid.UU
 	}
 
 	hasChanges := false
+	var priceChange *models.PriceChange
+	var originalName string
 
 	if serviceName != nil && *serviceName != "" {
-		normalized := utils.NormalizeString(*serviceName)
-		if normalized != subscription.ServiceName() {
-			subscription.SetServiceName(normalized)
+		canonical, original, err := s.canonicalServiceName(ctx, *serviceName)
+		if err != nil {
+			return nil, err
+		}
+		if canonical != subscription.ServiceName() {
+			if err := s.names.CheckServiceName(ctx, canonical); err != nil {
+				return nil, err
+			}
+			subscription.SetServiceName(canonical)
+			originalName = original
 			hasChanges = true
 		}
 	}
 
-	if price != nil && *price != subscription.Price() {
-		subscription.SetPrice(*price)
+	if price != nil && !models.Rubles(*price).Equal(subscription.Price()) {
+		oldPrice, err := subscription.Price().WholeUnits()
+		if err != nil {
+			return nil, apperror.InternalError("failed to read subscription price", err)
+		}
+		priceChange = models.NewPriceChange(subscription.ID(), oldPrice, *price, time.Now())
+		subscription.SetPrice(models.Rubles(*price))
 		hasChanges = true
 	}
 
 	if startDate != nil && *startDate != "" {
-		newStartDate, err := utils.ParseMonthYear(*startDate)
+		newStartDate, err := utils.ParseStartDate(*startDate)
 		if err != nil {
 			return nil, err
 		}
-		newStartDate = utils.StartOfMonth(newStartDate)
 		subscription.SetStartDate(newStartDate)
 		hasChanges = true
 	}
@@ -215,16 +488,88 @@ func (s *subscriptionService) UpdateSubscription(ctx context.Context, id uuid.UU
 			subscription.SetEndDate(nil)
 			hasChanges = true
 		} else {
-			newEndDate, err := utils.ParseMonthYear(*endDate)
+			newEndDate, err := utils.ParseEndDate(*endDate)
 			if err != nil {
 				return nil, err
 			}
-			newEndDate = utils.EndOfMonth(newEndDate)
 			subscription.SetEndDate(&newEndDate)
 			hasChanges = true
 		}
 	}
 
+	if tags != nil {
+		normalizedTags, err := normalizeTags(*tags)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Equal(normalizedTags, subscription.Tags()) {
+			subscription.SetTags(normalizedTags)
+			hasChanges = true
+		}
+	}
+
+	if category != nil {
+		parsedCategory, err := parseCategory(*category)
+		if err != nil {
+			return nil, err
+		}
+		current := subscription.Category()
+		if (parsedCategory == nil) != (current == nil) || (parsedCategory != nil && *parsedCategory != *current) {
+			subscription.SetCategory(parsedCategory)
+			hasChanges = true
+		}
+	}
+
+	if paymentMethod != nil {
+		parsedPaymentMethod, err := parsePaymentMethod(*paymentMethod)
+		if err != nil {
+			return nil, err
+		}
+		current := subscription.PaymentMethod()
+		if (parsedPaymentMethod == nil) != (current == nil) || (parsedPaymentMethod != nil && *parsedPaymentMethod != *current) {
+			subscription.SetPaymentMethod(parsedPaymentMethod)
+			hasChanges = true
+		}
+	}
+
+	if billingDay != nil {
+		parsedBillingDay, err := parseBillingDay(*billingDay)
+		if err != nil {
+			return nil, err
+		}
+		current := subscription.BillingDay()
+		if (parsedBillingDay == nil) != (current == nil) || (parsedBillingDay != nil && *parsedBillingDay != *current) {
+			subscription.SetBillingDay(parsedBillingDay)
+			hasChanges = true
+		}
+	}
+
+	if notes != nil {
+		normalizedNotes, err := validateNotes(*notes)
+		if err != nil {
+			return nil, err
+		}
+		if normalizedNotes != subscription.Notes() {
+			subscription.SetNotes(normalizedNotes)
+			hasChanges = true
+		}
+	}
+
+	if metadata != nil || originalName != "" {
+		updated := subscription.Metadata()
+		if metadata != nil {
+			updated = *metadata
+		}
+		updated = withOriginalServiceName(updated, originalName)
+		if err := validateMetadata(updated); err != nil {
+			return nil, err
+		}
+		if !maps.Equal(updated, subscription.Metadata()) {
+			subscription.SetMetadata(updated)
+			hasChanges = true
+		}
+	}
+
 	if !hasChanges {
 		return subscription, nil
 	}
@@ -233,7 +578,20 @@ func (s *subscriptionService) UpdateSubscription(ctx context.Context, id uuid.UU
 		return nil, apperror.InvalidSubscriptionData("subscription", err.Error())
 	}
 
-	if err := s.repo.Update(ctx, subscription); err != nil {
+	err = s.events.apply(ctx, func(ctx context.Context) error {
+		if priceChange == nil {
+			return s.repo.Update(ctx, subscription)
+		}
+		return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.repo.Update(ctx, subscription); err != nil {
+				return err
+			}
+			return s.repo.RecordPriceChange(ctx, priceChange)
+		})
+	}, func() *models.SubscriptionEvent {
+		return models.NewSubscriptionEvent(models.EventSubscriptionUpdated, subscription)
+	})
+	if err != nil {
 		s.log.Error("failed to update subscription", zap.Error(err))
 		return nil, err
 	}
@@ -244,24 +602,60 @@ func (s *subscriptionService) UpdateSubscription(ctx context.Context, id uuid.UU
 	return subscription, nil
 }
 
-/** Удаляет подписку по ID, проверяя её существование. */
-func (s *subscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
-	s.log.Debug("deleting subscription", zap.String("subscription_id", id.String()))
+/*
+LinkCatalogService привязывает подписку к записи каталога; nil снимает
+привязку. Название и цена подписки не меняются.
+*/
+func (s *subscriptionService) LinkCatalogService(ctx context.Context, id uuid.UUID, catalogID *uuid.UUID) (*models.Subscription, error) {
+	subscription, err := s.getOwnedSubscription(ctx, id, models.PermissionSubscriptionsWrite)
+	if err != nil {
+		return nil, err
+	}
 
-	if id == uuid.Nil {
-		return apperror.InvalidInput("id", "cannot be empty")
+	current := subscription.CatalogID()
+	if (current == nil && catalogID == nil) || (current != nil && catalogID != nil && *current == *catalogID) {
+		return subscription, nil
 	}
 
-	exists, err := s.repo.Exists(ctx, id)
+	if catalogID != nil {
+		if _, err := s.catalog.GetByID(ctx, *catalogID); err != nil {
+			return nil, err
+		}
+	}
+	subscription.SetCatalogID(catalogID)
+
+	err = s.events.apply(ctx, func(ctx context.Context) error {
+		return s.repo.Update(ctx, subscription)
+	}, func() *models.SubscriptionEvent {
+		return models.NewSubscriptionEvent(models.EventSubscriptionUpdated, subscription)
+	})
 	if err != nil {
-		return err
+		s.log.Error("failed to link catalog service", zap.Error(err))
+		return nil, err
 	}
 
-	if !exists {
-		return apperror.SubscriptionNotFound(id.String())
+	s.log.Info("subscription catalog link updated",
+		zap.String("subscription_id", id.String()),
+		zap.Bool("linked", catalogID != nil))
+
+	return subscription, nil
+}
+
+/** Удаляет подписку по ID, проверяя её существование. */
+func (s *subscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	s.log.Debug("deleting subscription", zap.String("subscription_id", id.String()))
+
+	subscription, err := s.getOwnedSubscription(ctx, id, models.PermissionSubscriptionsWrite)
+	if err != nil {
+		return err
 	}
 
-	if err := s.repo.Delete(ctx, id); err != nil {
+	err = s.events.apply(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	}, func() *models.SubscriptionEvent {
+		return models.NewSubscriptionEvent(models.EventSubscriptionDeleted, subscription)
+	})
+	if err != nil {
 		s.log.Error("failed to delete subscription", zap.Error(err))
 		return err
 	}
@@ -272,11 +666,82 @@ func (s *subscriptionService) DeleteSubscription(ctx context.Context, id uuid.UU
 	return nil
 }
 
+/*
+DeleteUserSubscriptions — удаляет все подписки пользователя, включая
+архивные, одной транзакцией (для удаления аккаунта) и пишет одно
+агрегированное событие subscription.bulk_deleted вместо события на каждую
+подписку. Возвращает число удалённых подписок.
+*/
+func (s *subscriptionService) DeleteUserSubscriptions(ctx context.Context, userID uuid.UUID) (int, error) {
+	s.log.Debug("deleting user subscriptions", zap.String("user_id", userID.String()))
+
+	if userID == uuid.Nil {
+		return 0, apperror.InvalidUserID(userID.String())
+	}
+
+	// Архивные подписки удаляются вместе с текущими, поэтому и считаются обе таблицы.
+	count := 0
+	for _, archived := range []bool{false, true} {
+		filter := models.NewSubscriptionFilter()
+		filter.SetUserID(&userID)
+		filter.SetArchived(archived)
+		n, err := s.repo.Count(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+	if count == 0 {
+		return 0, nil
+	}
+
+	var deleted []uuid.UUID
+	err := s.events.apply(ctx, func(ctx context.Context) error {
+		return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			deleted, err = s.repo.DeleteByUserID(ctx, userID)
+			return err
+		})
+	}, func() *models.SubscriptionEvent {
+		return models.NewSubscriptionsBulkDeletedEvent(userID, deleted)
+	})
+	if err != nil {
+		s.log.Error("failed to delete user subscriptions",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+		return 0, err
+	}
+
+	s.log.Info("user subscriptions deleted",
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(deleted)))
+
+	return len(deleted), nil
+}
+
+/** Возвращает изменения цены подписки в порядке вступления в силу. */
+func (s *subscriptionService) GetPriceHistory(ctx context.Context, id uuid.UUID) ([]*models.PriceChange, error) {
+	if _, err := s.GetSubscriptionByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.GetPriceHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		history = make([]*models.PriceChange, 0)
+	}
+	return history, nil
+}
+
 /*
 CalculateTotalCost — считает общую стоимость подписок за период.
-Можно фильтровать по userID и имени сервиса.
+Можно фильтровать по userID и имени сервиса; billing == nil — режим по умолчанию.
+pricing выбирает текущие цены или цены по истории изменений. Результат
+кешируется в costs (см. CostCache).
 */
-func (s *subscriptionService) CalculateTotalCost(ctx context.Context, userID *uuid.UUID, serviceName *string, startDate, endDate string) (*models.CostSummary, error) {
+func (s *subscriptionService) CalculateTotalCost(ctx context.Context, userID *uuid.UUID, serviceName *string, startDate, endDate string, billing *models.BillingMode, pricing models.PricingMode) (*models.CostSummary, error) {
 	s.log.Debug("calculating total cost",
 		zap.String("start_date", startDate),
 		zap.String("end_date", endDate))
@@ -290,7 +755,7 @@ func (s *subscriptionService) CalculateTotalCost(ctx context.Context, userID *uu
 		return nil, apperror.InvalidInput("date_range", "both start_date and end_date are required")
 	}
 
-	period := models.NewDatePeriod(*startTime, *endTime)
+	period := models.NewDateRange(*startTime, *endTime)
 	if err := period.Validate(); err != nil {
 		return nil, apperror.InvalidDateRange(startDate, endDate)
 	}
@@ -300,40 +765,290 @@ func (s *subscriptionService) CalculateTotalCost(ctx context.Context, userID *uu
 		filter.SetUserID(userID)
 	}
 	if serviceName != nil && *serviceName != "" {
-		normalized := utils.NormalizeString(*serviceName)
+		normalized := utils.SanitizeText(*serviceName)
 		filter.SetServiceName(&normalized)
 	}
 
-	totalCost, err := s.repo.GetTotalCostForPeriod(ctx, filter, period)
+	mode := s.billingMode(ctx, billing, userID)
+	return s.costs.Get(filter, period, mode, pricing, func() (*models.CostSummary, error) {
+		breakdown, err := s.repo.GetTotalCostForPeriod(ctx, filter, period, mode, pricing)
+		if err != nil {
+			return nil, err
+		}
+
+		summary := models.NewCostSummary(period, mode, pricing)
+		summary.SetBreakdown(breakdown)
+
+		s.log.Info("calculated total cost",
+			zap.Int("gross_cost", breakdown.Gross()),
+			zap.Int("discount", breakdown.Discount()),
+			zap.Int("total_cost", breakdown.Net()),
+			zap.String("period", startDate+" to "+endDate))
+
+		return summary, nil
+	})
+}
+
+/*
+CalculateCostByCategory — траты за период в разрезе категорий.
+Подписки без категории собираются в uncategorized.
+*/
+func (s *subscriptionService) CalculateCostByCategory(ctx context.Context, userID *uuid.UUID, startDate, endDate string, billing *models.BillingMode, pricing models.PricingMode) (*models.CategoryCostReport, error) {
+	s.log.Debug("calculating cost by category",
+		zap.String("start_date", startDate),
+		zap.String("end_date", endDate))
+
+	startTime, endTime, err := utils.ParseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if startTime == nil || endTime == nil {
+		return nil, apperror.InvalidInput("date_range", "both start_date and end_date are required")
+	}
+
+	period := models.NewDateRange(*startTime, *endTime)
+	if err := period.Validate(); err != nil {
+		return nil, apperror.InvalidDateRange(startDate, endDate)
+	}
+
+	filter := models.NewSubscriptionFilter()
+	if userID != nil {
+		filter.SetUserID(userID)
+	}
+
+	mode := s.billingMode(ctx, billing, userID)
+	costs, err := s.repo.GetCostByCategory(ctx, filter, period, mode, pricing)
 	if err != nil {
 		return nil, err
 	}
 
-	summary := models.NewCostSummary(*period)
-	summary.SetTotalCost(totalCost)
+	report := models.NewCategoryCostReport(period, mode, pricing, costs)
 
-	s.log.Info("calculated total cost",
-		zap.Int("total_cost", totalCost),
+	s.log.Info("calculated cost by category",
+		zap.Int("categories", len(report.Categories())),
+		zap.Int("total_cost", report.TotalCost()),
 		zap.String("period", startDate+" to "+endDate))
 
-	return summary, nil
+	return report, nil
 }
 
-/** Возвращает количество подписок (с фильтром по userID, если задан). */
-func (s *subscriptionService) GetSubscriptionStats(ctx context.Context, userID *uuid.UUID) (int, error) {
-	s.log.Debug("getting subscription stats")
+/*
+CalculateCostByPaymentMethod — траты за период в разрезе способов оплаты:
+какая карта за что платит. Подписки без способа оплаты собираются в одну
+строку с payment_method = nil.
+*/
+func (s *subscriptionService) CalculateCostByPaymentMethod(ctx context.Context, userID *uuid.UUID, startDate, endDate string, billing *models.BillingMode, pricing models.PricingMode) (*models.PaymentMethodCostReport, error) {
+	s.log.Debug("calculating cost by payment method",
+		zap.String("start_date", startDate),
+		zap.String("end_date", endDate))
+
+	startTime, endTime, err := utils.ParseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if startTime == nil || endTime == nil {
+		return nil, apperror.InvalidInput("date_range", "both start_date and end_date are required")
+	}
+
+	period := models.NewDateRange(*startTime, *endTime)
+	if err := period.Validate(); err != nil {
+		return nil, apperror.InvalidDateRange(startDate, endDate)
+	}
 
 	filter := models.NewSubscriptionFilter()
 	if userID != nil {
 		filter.SetUserID(userID)
 	}
 
-	count, err := s.repo.Count(ctx, filter)
+	mode := s.billingMode(ctx, billing, userID)
+	costs, err := s.repo.GetCostByPaymentMethod(ctx, filter, period, mode, pricing)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	report := models.NewPaymentMethodCostReport(period, mode, pricing, costs)
+
+	s.log.Info("calculated cost by payment method",
+		zap.Int("payment_methods", len(report.PaymentMethods())),
+		zap.Int("total_cost", report.TotalCost()),
+		zap.String("period", startDate+" to "+endDate))
+
+	return report, nil
+}
+
+/*
+GetUserSubscriptionStats — сводка по подпискам пользователя на текущий
+момент: количество по статусам, траты в месяц, средняя и максимальная
+цена, ближайшее окончание.
+*/
+func (s *subscriptionService) GetUserSubscriptionStats(ctx context.Context, userID uuid.UUID) (*models.UserSubscriptionStats, error) {
+	s.log.Debug("getting subscription stats", zap.String("user_id", userID.String()))
+
+	if userID == uuid.Nil {
+		return nil, apperror.InvalidUserID(userID.String())
+	}
+
+	return s.repo.GetUserStats(ctx, userID, time.Now().UTC())
+}
+
+/*
+GetExpiringSubscriptions — подписки пользователя, которые заканчиваются
+сегодня или в ближайшие withinDays дней, по возрастанию даты окончания.
+*/
+func (s *subscriptionService) GetExpiringSubscriptions(ctx context.Context, userID uuid.UUID, withinDays int) ([]*models.Subscription, error) {
+	s.log.Debug("getting expiring subscriptions",
+		zap.String("user_id", userID.String()),
+		zap.Int("within_days", withinDays))
+
+	if userID == uuid.Nil {
+		return nil, apperror.InvalidUserID(userID.String())
 	}
 
-	return count, nil
+	if withinDays < 0 || withinDays > models.MaxExpiringWithinDays {
+		return nil, apperror.InvalidInput("within_days", fmt.Sprintf("must be between 0 and %d", models.MaxExpiringWithinDays))
+	}
+
+	window := models.NewDaysAheadRange(time.Now(), withinDays)
+	return s.repo.GetExpiring(ctx, userID, window.From(), window.To())
+}
+
+/*
+GetSubscriptionCalendar — возвращает годовой календарь подписок пользователя:
+для каждого месяца список активных подписок и их суммарную стоимость.
+*/
+func (s *subscriptionService) GetSubscriptionCalendar(ctx context.Context, userID uuid.UUID, year int, billing *models.BillingMode, pricing models.PricingMode) (*models.SubscriptionCalendar, error) {
+	s.log.Debug("getting subscription calendar",
+		zap.String("user_id", userID.String()),
+		zap.Int("year", year))
+
+	if userID == uuid.Nil {
+		return nil, apperror.InvalidUserID(userID.String())
+	}
+
+	if err := utils.ValidateYear(year); err != nil {
+		return nil, err
+	}
+
+	calendar, err := s.repo.GetCalendar(ctx, userID, year, s.billingMode(ctx, billing, &userID), pricing)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Debug("retrieved subscription calendar",
+		zap.String("user_id", userID.String()),
+		zap.Int("year", year),
+		zap.Int("total_cost", calendar.TotalCost()))
+
+	return calendar, nil
+}
+
+/*
+GetCostForecast — прогноз трат пользователя на months месяцев, начиная с
+текущего, по текущим ценам и с учётом дат окончания и скидок.
+*/
+func (s *subscriptionService) GetCostForecast(ctx context.Context, userID uuid.UUID, months int, billing *models.BillingMode) (*models.CostForecast, error) {
+	s.log.Debug("getting cost forecast",
+		zap.String("user_id", userID.String()),
+		zap.Int("months", months))
+
+	if userID == uuid.Nil {
+		return nil, apperror.InvalidUserID(userID.String())
+	}
+
+	if months < 1 || months > models.MaxForecastMonths {
+		return nil, apperror.InvalidInput("months", fmt.Sprintf("must be between 1 and %d", models.MaxForecastMonths))
+	}
+
+	from := utils.StartOfMonth(time.Now().UTC())
+	forecast, err := s.repo.GetForecast(ctx, userID, from, months, s.billingMode(ctx, billing, &userID))
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Debug("cost forecast calculated",
+		zap.String("user_id", userID.String()),
+		zap.Int("months", months),
+		zap.Int("total_cost", forecast.TotalCost()))
+
+	return forecast, nil
+}
+
+/*
+GetBillingCalendar — дни списаний пользователя за месяц (MM-YYYY) по
+billing_day подписок. Подписки месяца берутся тем же запросом, что и
+прогноз, поэтому месяц может быть и прошедшим.
+*/
+func (s *subscriptionService) GetBillingCalendar(ctx context.Context, userID uuid.UUID, month string) (*models.BillingCalendar, error) {
+	s.log.Debug("getting billing calendar",
+		zap.String("user_id", userID.String()),
+		zap.String("month", month))
+
+	if userID == uuid.Nil {
+		return nil, apperror.InvalidUserID(userID.String())
+	}
+
+	from, err := utils.ParseMonthYear(month)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := s.repo.GetForecast(ctx, userID, from, 1, models.BillingMonthly)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar, err := models.NewBillingCalendar(from, forecast.MonthOf(from).Subscriptions())
+	if err != nil {
+		return nil, apperror.InternalError("failed to build billing calendar", err)
+	}
+
+	s.log.Debug("billing calendar built",
+		zap.String("user_id", userID.String()),
+		zap.Int("days", len(calendar.Days())))
+
+	return calendar, nil
+}
+
+/*
+FindDuplicateSubscriptions — вероятные дубли среди подписок пользователя:
+пары, которые действуют одновременно и называются одинаково или похоже
+(сходство не ниже DuplicateNameSimilarity).
+*/
+func (s *subscriptionService) FindDuplicateSubscriptions(ctx context.Context, userID uuid.UUID) ([]*models.SubscriptionDuplicate, error) {
+	if userID == uuid.Nil {
+		return nil, apperror.InvalidUserID(userID.String())
+	}
+
+	duplicates, err := s.repo.FindDuplicates(ctx, userID, models.DuplicateNameSimilarity)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Debug("duplicate subscriptions found",
+		zap.String("user_id", userID.String()),
+		zap.Int("pairs", len(duplicates)))
+
+	return duplicates, nil
+}
+
+/** Считает бизнес-показатели (траты, активные пользователи и подписки) за текущий месяц. */
+func (s *subscriptionService) GetBusinessKPIs(ctx context.Context) (*models.BusinessKPIs, error) {
+	now := time.Now().UTC()
+	period := models.NewDateRange(utils.StartOfMonth(now), utils.EndOfMonth(now))
+
+	kpis, err := s.repo.GetBusinessKPIs(ctx, period, s.billing)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Debug("business kpis calculated",
+		zap.Int("monthly_spend", kpis.MonthlySpend()),
+		zap.Int("active_users", kpis.ActiveUsers()),
+		zap.Int("active_subscriptions", kpis.ActiveSubscriptions()))
+
+	return kpis, nil
 }
 
 /** Валидация входных данных для создания подписки. */
@@ -369,3 +1084,120 @@ func (s *subscriptionService) validateUpdateInput(serviceName *string, price *in
 
 	return nil
 }
+
+/*
+canonicalServiceName очищает название (utils.SanitizeText) и заменяет
+его каноническим по словарю. original — название в присланном виде, если словарь его
+изменил, иначе пустая строка.
+*/
+func (s *subscriptionService) canonicalServiceName(ctx context.Context, serviceName string) (string, string, error) {
+	normalized := utils.SanitizeText(serviceName)
+	canonical, err := s.canonical.Canonicalize(ctx, normalized)
+	if err != nil {
+		return "", "", err
+	}
+	if canonical == normalized {
+		return normalized, "", nil
+	}
+	return canonical, normalized, nil
+}
+
+// withOriginalServiceName возвращает копию метаданных с исходным
+// названием сервиса; пустое original оставляет метаданные как есть.
+func withOriginalServiceName(metadata map[string]string, original string) map[string]string {
+	if original == "" {
+		return metadata
+	}
+	updated := make(map[string]string, len(metadata)+1)
+	maps.Copy(updated, metadata)
+	updated[models.MetadataOriginalServiceName] = original
+	return updated
+}
+
+/** Нормализует теги; ошибка — INVALID_INPUT по полю tags. */
+func normalizeTags(tags []string) ([]string, error) {
+	normalized, err := models.NormalizeTags(tags)
+	if err != nil {
+		return nil, apperror.InvalidInput("tags", err.Error())
+	}
+	return normalized, nil
+}
+
+/** Разбирает категорию; пустая строка — без категории. */
+func parseCategory(value string) (*models.SubscriptionCategory, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	category, err := models.ParseSubscriptionCategory(value)
+	if err != nil {
+		return nil, apperror.InvalidInput("category", err.Error())
+	}
+	return &category, nil
+}
+
+/** Разбирает способ оплаты; пустая строка — не указан. */
+func parsePaymentMethod(value string) (*string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if err := models.ValidatePaymentMethod(value); err != nil {
+		return nil, apperror.InvalidInput("payment_method", err.Error())
+	}
+	return &value, nil
+}
+
+/** Разбирает день списания при обновлении; 0 — день даты начала. */
+func parseBillingDay(day int) (*int, error) {
+	if day == 0 {
+		return nil, nil
+	}
+	if err := models.ValidateBillingDay(day); err != nil {
+		return nil, apperror.InvalidInput("billing_day", err.Error())
+	}
+	return &day, nil
+}
+
+/** Нормализует и проверяет заметку; ошибка — INVALID_INPUT по полю notes. */
+func validateNotes(notes string) (string, error) {
+	notes = models.NormalizeNotes(notes)
+	if err := models.ValidateNotes(notes); err != nil {
+		return "", apperror.InvalidInput("notes", err.Error())
+	}
+	return notes, nil
+}
+
+/** Проверяет метаданные; ошибка — INVALID_INPUT по полю metadata. */
+func validateMetadata(metadata map[string]string) error {
+	if err := models.ValidateMetadata(metadata); err != nil {
+		return apperror.InvalidInput("metadata", err.Error())
+	}
+	return nil
+}
+
+/*
+Режим из запроса, если задан; иначе prorated при включённом для
+пользователя флаге daily_proration, иначе режим сервиса.
+*/
+func (s *subscriptionService) billingMode(ctx context.Context, requested *models.BillingMode, userID *uuid.UUID) models.BillingMode {
+	if requested != nil {
+		return *requested
+	}
+
+	var tenant string
+	if userID != nil {
+		tenant = userID.String()
+	}
+	if s.flags.Enabled(ctx, featureflags.DailyProration, tenant) {
+		return models.BillingProrated
+	}
+	return s.billing
+}
+
+/** Режим из запроса, если задан, иначе режим сервиса. */
+func billingModeOrDefault(requested *models.BillingMode, fallback models.BillingMode) models.BillingMode {
+	if requested != nil {
+		return *requested
+	}
+	return fallback
+}
@@ -0,0 +1,1252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/mocks"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/featureflags"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+var errDatabase = apperror.DatabaseError("test", errors.New("connection refused"))
+
+type subscriptionServiceMocks struct {
+	repo      *mocks.MockSubscriptionRepository
+	discounts *mocks.MockDiscountRepository
+	plans     *mocks.MockPlanRepository
+	catalog   *mocks.MockServiceCatalogRepository
+	tx        *mocks.MockTransactor
+}
+
+/*
+newTestSubscriptionService собирает сервис на моках без записи событий и
+без правил названий. Transactor просто выполняет fn: атомарность
+проверяется интеграционными тестами репозиториев.
+*/
+func newTestSubscriptionService(t *testing.T) (*subscriptionService, *subscriptionServiceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	m := &subscriptionServiceMocks{
+		repo:      mocks.NewMockSubscriptionRepository(ctrl),
+		discounts: mocks.NewMockDiscountRepository(ctrl),
+		plans:     mocks.NewMockPlanRepository(ctrl),
+		catalog:   mocks.NewMockServiceCatalogRepository(ctrl),
+		tx:        mocks.NewMockTransactor(ctrl),
+	}
+	m.tx.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	log, err := logger.NewLogger(logger.Config{Level: "fatal"})
+	if err != nil {
+		t.Fatalf("logger: %v", err)
+	}
+
+	return NewSubscriptionService(m.repo, m.discounts, m.plans, m.catalog, m.tx, nil, nil, nil, nil, nil, models.BillingMonthly, nil, log), m
+}
+
+// assertErrorCode проверяет код AppError; пустой code означает успех.
+func assertErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	if code == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+
+	appErr, ok := apperror.IsAppError(err)
+	if !ok {
+		t.Fatalf("expected %s, got %v", code, err)
+	}
+	if appErr.Code() != code {
+		t.Fatalf("expected %s, got %s (%v)", code, appErr.Code(), err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestSubscriptionService_CreateSubscription(t *testing.T) {
+	userID := uuid.New()
+	planID := uuid.New()
+	catalogID := uuid.New()
+	streaming := models.CategoryStreaming
+
+	type input struct {
+		serviceName string
+		price       int
+		userID      uuid.UUID
+		startDate   string
+		endDate     *string
+		promoCode   *string
+		planID      *uuid.UUID
+		catalogID   *uuid.UUID
+		tags        []string
+		category    *string
+		payment     *string
+		billingDay  *int
+		notes       string
+		metadata    map[string]string
+	}
+	valid := func(modify func(in *input)) input {
+		in := input{serviceName: "Yandex Plus", price: 399, userID: userID, startDate: "07-2025"}
+		if modify != nil {
+			modify(&in)
+		}
+		return in
+	}
+
+	tests := []struct {
+		name  string
+		input input
+		setup func(m *subscriptionServiceMocks)
+		code  string
+		check func(t *testing.T, sub *models.Subscription)
+	}{
+		{
+			name:  "minimal subscription",
+			input: valid(nil),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.ServiceName() != "Yandex Plus" || !sub.Price().Equal(models.Rubles(399)) || sub.UserID() != userID {
+					t.Errorf("got %s/%s/%s", sub.ServiceName(), sub.Price(), sub.UserID())
+				}
+				if want := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC); !sub.StartDate().Equal(want) {
+					t.Errorf("start date: got %s, want %s", sub.StartDate(), want)
+				}
+				if sub.EndDate() != nil {
+					t.Errorf("end date: got %s, want none", sub.EndDate())
+				}
+			},
+		},
+		{
+			name: "service name is trimmed",
+			input: valid(func(in *input) {
+				in.serviceName = "  Yandex Plus  "
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.ServiceName() != "Yandex Plus" {
+					t.Errorf("service name: got %q", sub.ServiceName())
+				}
+			},
+		},
+		{
+			name: "service name is sanitized",
+			input: valid(func(in *input) {
+				in.serviceName = "\u200BYandex\u00A0\t Plus\uFEFF"
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.ServiceName() != "Yandex Plus" {
+					t.Errorf("service name: got %q", sub.ServiceName())
+				}
+			},
+		},
+		{
+			name: "service name of invisible characters only",
+			input: valid(func(in *input) {
+				in.serviceName = "\u200B\u200E\u2060"
+			}),
+			code: apperror.CodeInvalidServiceName,
+		},
+		{
+			name: "all optional fields",
+			input: valid(func(in *input) {
+				in.endDate = ptr("12-2025")
+				in.tags = []string{"Family", "family", "work"}
+				in.category = ptr("streaming")
+				in.payment = ptr(" Tinkoff *1234 ")
+				in.billingDay = ptr(31)
+				in.notes = "  shared  "
+				in.metadata = map[string]string{"team": "platform"}
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if want := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond); sub.EndDate() == nil || !sub.EndDate().Equal(want) {
+					t.Errorf("end date: got %v, want %s", sub.EndDate(), want)
+				}
+				if len(sub.Tags()) != 2 {
+					t.Errorf("tags must be deduplicated: got %v", sub.Tags())
+				}
+				if sub.Category() == nil || *sub.Category() != models.CategoryStreaming {
+					t.Errorf("category: got %v", sub.Category())
+				}
+				if sub.PaymentMethod() == nil || *sub.PaymentMethod() != "Tinkoff *1234" {
+					t.Errorf("payment method: got %v", sub.PaymentMethod())
+				}
+				if sub.BillingDay() == nil || *sub.BillingDay() != 31 {
+					t.Errorf("billing day: got %v", sub.BillingDay())
+				}
+				if sub.Notes() != "shared" {
+					t.Errorf("notes: got %q", sub.Notes())
+				}
+			},
+		},
+		{
+			name: "end date in the start month",
+			input: valid(func(in *input) {
+				in.endDate = ptr("07-2025")
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "ISO dates with days",
+			input: valid(func(in *input) {
+				in.startDate = "2025-07-15"
+				in.endDate = ptr("2025-07-15")
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.StartDate().Day() != 15 || sub.EndDate().Sub(sub.StartDate()) >= 24*time.Hour {
+					t.Errorf("got %s .. %s", sub.StartDate(), sub.EndDate())
+				}
+			},
+		},
+		{
+			name: "empty end date means open-ended",
+			input: valid(func(in *input) {
+				in.endDate = ptr("")
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.EndDate() != nil {
+					t.Errorf("end date: got %s", sub.EndDate())
+				}
+			},
+		},
+		{
+			name:  "empty service name",
+			input: valid(func(in *input) { in.serviceName = "   " }),
+			code:  apperror.CodeInvalidServiceName,
+		},
+		{
+			name:  "zero price",
+			input: valid(func(in *input) { in.price = 0 }),
+			code:  apperror.CodeInvalidPrice,
+		},
+		{
+			name:  "negative price",
+			input: valid(func(in *input) { in.price = -1 }),
+			code:  apperror.CodeInvalidPrice,
+		},
+		{
+			name:  "price above limit",
+			input: valid(func(in *input) { in.price = 1000001 }),
+			code:  apperror.CodeInvalidInput,
+		},
+		{
+			name:  "nil user id",
+			input: valid(func(in *input) { in.userID = uuid.Nil }),
+			code:  apperror.CodeInvalidUserID,
+		},
+		{
+			name:  "month out of range",
+			input: valid(func(in *input) { in.startDate = "13-2025" }),
+			code:  apperror.CodeInvalidDateFormat,
+		},
+		{
+			name:  "year before supported range",
+			input: valid(func(in *input) { in.startDate = "12-1999" }),
+			code:  apperror.CodeInvalidDateFormat,
+		},
+		{
+			name:  "invalid end date",
+			input: valid(func(in *input) { in.endDate = ptr("2025/12") }),
+			code:  apperror.CodeInvalidDateFormat,
+		},
+		{
+			name:  "end date before start date",
+			input: valid(func(in *input) { in.endDate = ptr("06-2025") }),
+			code:  apperror.CodeInvalidDateRange,
+		},
+		{
+			name:  "unknown category",
+			input: valid(func(in *input) { in.category = ptr("groceries") }),
+			code:  apperror.CodeInvalidInput,
+		},
+		{
+			name:  "billing day out of range",
+			input: valid(func(in *input) { in.billingDay = ptr(32) }),
+			code:  apperror.CodeInvalidInput,
+		},
+		{
+			name:  "payment method too long",
+			input: valid(func(in *input) { in.payment = ptr(strings.Repeat("x", models.MaxPaymentMethodLength+1)) }),
+			code:  apperror.CodeInvalidInput,
+		},
+		{
+			name: "too many metadata keys",
+			input: valid(func(in *input) {
+				in.metadata = map[string]string{}
+				for i := 0; i <= models.MaxMetadataKeys; i++ {
+					in.metadata[uuid.NewString()] = "v"
+				}
+			}),
+			code: apperror.CodeInvalidInput,
+		},
+		{
+			name: "plan fills service name and price",
+			input: valid(func(in *input) {
+				in.serviceName, in.price = "", 0
+				in.planID = &planID
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				plan := models.NewPlan("Netflix Standard", "Netflix", models.Rubles(899), models.BillingCycleMonthly, nil)
+				m.plans.EXPECT().GetByID(gomock.Any(), planID).Return(plan, nil)
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.ServiceName() != "Netflix" || !sub.Price().Equal(models.Rubles(899)) {
+					t.Errorf("got %s/%s", sub.ServiceName(), sub.Price())
+				}
+				if sub.PlanID() == nil || *sub.PlanID() != planID {
+					t.Errorf("plan id: got %v", sub.PlanID())
+				}
+			},
+		},
+		{
+			name:  "plan with explicit price",
+			input: valid(func(in *input) { in.planID = &planID }),
+			code:  apperror.CodeInvalidInput,
+		},
+		{
+			name: "unknown plan",
+			input: valid(func(in *input) {
+				in.serviceName, in.price = "", 0
+				in.planID = &planID
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				m.plans.EXPECT().GetByID(gomock.Any(), planID).Return(nil, apperror.NotFound("plan"))
+			},
+			code: apperror.CodeNotFound,
+		},
+		{
+			name: "catalog entry fills name and category",
+			input: valid(func(in *input) {
+				in.serviceName = ""
+				in.catalogID = &catalogID
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				entry := models.NewCatalogService("Kinopoisk", &streaming, "", "", nil)
+				m.catalog.EXPECT().GetByID(gomock.Any(), catalogID).Return(entry, nil)
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.ServiceName() != "Kinopoisk" || sub.Category() == nil || *sub.Category() != streaming {
+					t.Errorf("got %s/%v", sub.ServiceName(), sub.Category())
+				}
+				if sub.CatalogID() == nil || *sub.CatalogID() != catalogID {
+					t.Errorf("catalog id: got %v, want %s", sub.CatalogID(), catalogID)
+				}
+			},
+		},
+		{
+			name: "explicit name is kept with catalog entry",
+			input: valid(func(in *input) {
+				in.catalogID = &catalogID
+				in.category = ptr("music")
+			}),
+			setup: func(m *subscriptionServiceMocks) {
+				entry := models.NewCatalogService("Kinopoisk", &streaming, "", "", nil)
+				m.catalog.EXPECT().GetByID(gomock.Any(), catalogID).Return(entry, nil)
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.ServiceName() != "Yandex Plus" || *sub.Category() != models.CategoryMusic {
+					t.Errorf("got %s/%s", sub.ServiceName(), *sub.Category())
+				}
+			},
+		},
+		{
+			name:  "unknown catalog entry",
+			input: valid(func(in *input) { in.catalogID = &catalogID }),
+			setup: func(m *subscriptionServiceMocks) {
+				m.catalog.EXPECT().GetByID(gomock.Any(), catalogID).Return(nil, apperror.NotFound("catalog service"))
+			},
+			code: apperror.CodeNotFound,
+		},
+		{
+			name:  "valid promo code",
+			input: valid(func(in *input) { in.promoCode = ptr(" SPRING ") }),
+			setup: func(m *subscriptionServiceMocks) {
+				discount := models.NewDiscount("SPRING", models.DiscountPercentage, 10, nil, time.Now().AddDate(0, -1, 0), nil)
+				m.discounts.EXPECT().GetByCode(gomock.Any(), "SPRING").Return(discount, nil)
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.DiscountID() == nil {
+					t.Error("discount is not attached")
+				}
+			},
+		},
+		{
+			name:  "unknown promo code",
+			input: valid(func(in *input) { in.promoCode = ptr("NOPE") }),
+			setup: func(m *subscriptionServiceMocks) {
+				m.discounts.EXPECT().GetByCode(gomock.Any(), "NOPE").Return(nil, apperror.NotFound("promo code"))
+			},
+			code: apperror.CodePromoCodeInvalid,
+		},
+		{
+			name:  "promo code for another service",
+			input: valid(func(in *input) { in.promoCode = ptr("NETFLIX") }),
+			setup: func(m *subscriptionServiceMocks) {
+				discount := models.NewDiscount("NETFLIX", models.DiscountFixed, 100, ptr("Netflix"), time.Now().AddDate(0, -1, 0), nil)
+				m.discounts.EXPECT().GetByCode(gomock.Any(), "NETFLIX").Return(discount, nil)
+			},
+			code: apperror.CodePromoCodeInvalid,
+		},
+		{
+			name:  "promo code lookup fails",
+			input: valid(func(in *input) { in.promoCode = ptr("SPRING") }),
+			setup: func(m *subscriptionServiceMocks) {
+				m.discounts.EXPECT().GetByCode(gomock.Any(), "SPRING").Return(nil, errDatabase)
+			},
+			code: apperror.CodeDatabaseError,
+		},
+		{
+			name:  "repository error is returned as is",
+			input: valid(nil),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errDatabase)
+			},
+			code: apperror.CodeDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			in := tt.input
+			sub, err := svc.CreateSubscription(context.Background(), in.serviceName, in.price, in.userID, in.startDate,
+				in.endDate, in.promoCode, in.planID, in.catalogID, in.tags, in.category, in.payment, in.billingDay, in.notes, in.metadata)
+			assertErrorCode(t, err, tt.code)
+			if tt.check != nil {
+				tt.check(t, sub)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_GetSubscriptionByID(t *testing.T) {
+	id := uuid.New()
+	owner := uuid.New()
+	existing := models.NewSubscription("Netflix", models.Rubles(599), owner, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name      string
+		id        uuid.UUID
+		principal *models.Principal
+		setup     func(m *subscriptionServiceMocks)
+		code      string
+	}{
+		{
+			name: "found",
+			id:   id,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+			},
+		},
+		{
+			name:      "owner's token",
+			id:        id,
+			principal: models.NewPrincipal(owner.String(), models.RoleViewer, models.PrincipalSourceJWT),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+			},
+		},
+		{
+			name:      "another user's token",
+			id:        id,
+			principal: models.NewPrincipal(uuid.NewString(), models.RoleOperator, models.PrincipalSourceJWT),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+			},
+			code: apperror.CodeForbidden,
+		},
+		{
+			name:      "admin token sees any user",
+			id:        id,
+			principal: models.NewPrincipal(uuid.NewString(), models.RoleAdmin, models.PrincipalSourceJWT),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+			},
+		},
+		{
+			name:      "api key is not tied to a user",
+			id:        id,
+			principal: models.NewPrincipal(uuid.NewString(), models.RoleViewer, models.PrincipalSourceAPIKey),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+			},
+		},
+		{
+			name: "nil id",
+			id:   uuid.Nil,
+			code: apperror.CodeInvalidInput,
+		},
+		{
+			name: "not found",
+			id:   id,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+			},
+			code: apperror.CodeSubscriptionNotFound,
+		},
+		{
+			name: "repository error",
+			id:   id,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(nil, errDatabase)
+			},
+			code: apperror.CodeDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = models.ContextWithPrincipal(ctx, tt.principal)
+			}
+			_, err := svc.GetSubscriptionByID(ctx, tt.id)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestSubscriptionService_UpdateSubscription(t *testing.T) {
+	id := uuid.New()
+	existing := func() *models.Subscription {
+		sub := models.NewSubscription("Netflix", models.Rubles(599), uuid.New(), time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+		sub.SetID(id)
+		return sub
+	}
+
+	type input struct {
+		serviceName *string
+		price       *int
+		startDate   *string
+		endDate     *string
+		tags        *[]string
+		category    *string
+		payment     *string
+		billingDay  *int
+		notes       *string
+		metadata    *map[string]string
+	}
+
+	tests := []struct {
+		name  string
+		input input
+		setup func(m *subscriptionServiceMocks)
+		code  string
+		check func(t *testing.T, sub *models.Subscription)
+	}{
+		{
+			name:  "no changes skip the write",
+			input: input{serviceName: ptr("Netflix"), price: ptr(599)},
+		},
+		{
+			name:  "price change records history in a transaction",
+			input: input{price: ptr(799)},
+			setup: func(m *subscriptionServiceMocks) {
+				gomock.InOrder(
+					m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil),
+					m.repo.EXPECT().RecordPriceChange(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, change *models.PriceChange) error {
+							if change.OldPrice() != 599 || change.NewPrice() != 799 {
+								t.Errorf("price change: got %d -> %d", change.OldPrice(), change.NewPrice())
+							}
+							return nil
+						}),
+				)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if !sub.Price().Equal(models.Rubles(799)) {
+					t.Errorf("price: got %s", sub.Price())
+				}
+			},
+		},
+		{
+			name:  "rename without price change",
+			input: input{serviceName: ptr(" Netflix Premium ")},
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.ServiceName() != "Netflix Premium" {
+					t.Errorf("service name: got %q", sub.ServiceName())
+				}
+			},
+		},
+		{
+			name:  "empty end date clears it",
+			input: input{endDate: ptr("")},
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.EndDate() != nil {
+					t.Errorf("end date: got %s", sub.EndDate())
+				}
+			},
+		},
+		{
+			name:  "empty category on an uncategorized subscription",
+			input: input{category: ptr("")},
+		},
+		{
+			name:  "payment method",
+			input: input{payment: ptr("Family Sharing")},
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			check: func(t *testing.T, sub *models.Subscription) {
+				if sub.PaymentMethod() == nil || *sub.PaymentMethod() != "Family Sharing" {
+					t.Errorf("payment method: got %v", sub.PaymentMethod())
+				}
+			},
+		},
+		{
+			name:  "zero billing day on a subscription without one",
+			input: input{billingDay: ptr(0)},
+		},
+		{
+			name:  "billing day out of range",
+			input: input{billingDay: ptr(32)},
+			code:  apperror.CodeInvalidInput,
+		},
+		{
+			name:  "end date before start date",
+			input: input{endDate: ptr("12-2024")},
+			code:  apperror.CodeInvalidSubscriptionData,
+		},
+		{
+			name:  "invalid start date",
+			input: input{startDate: ptr("00-2025")},
+			code:  apperror.CodeInvalidDateFormat,
+		},
+		{
+			name:  "invalid price",
+			input: input{price: ptr(0)},
+			code:  apperror.CodeInvalidPrice,
+		},
+		{
+			name:  "unknown category",
+			input: input{category: ptr("groceries")},
+			code:  apperror.CodeInvalidInput,
+		},
+		{
+			name:  "repository error",
+			input: input{notes: ptr("cancel in spring")},
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errDatabase)
+			},
+			code: apperror.CodeDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			m.repo.EXPECT().GetByID(gomock.Any(), id).Return(existing(), nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			in := tt.input
+			sub, err := svc.UpdateSubscription(context.Background(), id, in.serviceName, in.price, in.startDate,
+				in.endDate, in.tags, in.category, in.payment, in.billingDay, in.notes, in.metadata)
+			assertErrorCode(t, err, tt.code)
+			if tt.check != nil {
+				tt.check(t, sub)
+			}
+		})
+	}
+
+	t.Run("missing subscription", func(t *testing.T) {
+		svc, m := newTestSubscriptionService(t)
+		m.repo.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+
+		_, err := svc.UpdateSubscription(context.Background(), id, nil, ptr(100), nil, nil, nil, nil, nil, nil, nil, nil)
+		assertErrorCode(t, err, apperror.CodeSubscriptionNotFound)
+	})
+}
+
+func TestSubscriptionService_DeleteSubscription(t *testing.T) {
+	id := uuid.New()
+	existing := models.NewSubscription("Netflix", models.Rubles(599), uuid.New(), time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name      string
+		id        uuid.UUID
+		principal *models.Principal
+		setup     func(m *subscriptionServiceMocks)
+		code      string
+	}{
+		{
+			name: "deleted",
+			id:   id,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+				m.repo.EXPECT().Delete(gomock.Any(), id).Return(nil)
+			},
+		},
+		{
+			name: "nil id",
+			id:   uuid.Nil,
+			code: apperror.CodeInvalidInput,
+		},
+		{
+			name: "not found",
+			id:   id,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(nil, nil)
+			},
+			code: apperror.CodeSubscriptionNotFound,
+		},
+		{
+			name:      "another user's token",
+			id:        id,
+			principal: models.NewPrincipal(uuid.NewString(), models.RoleOperator, models.PrincipalSourceJWT),
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+			},
+			code: apperror.CodeForbidden,
+		},
+		{
+			name: "delete fails",
+			id:   id,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetByID(gomock.Any(), id).Return(existing, nil)
+				m.repo.EXPECT().Delete(gomock.Any(), id).Return(errDatabase)
+			},
+			code: apperror.CodeDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = models.ContextWithPrincipal(ctx, tt.principal)
+			}
+			assertErrorCode(t, svc.DeleteSubscription(ctx, tt.id), tt.code)
+		})
+	}
+}
+
+// archivedUserFilter — userFilter для архива подписок.
+func archivedUserFilter(userID uuid.UUID) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		filter, ok := x.(*models.SubscriptionFilter)
+		return ok && filter.UserID() != nil && *filter.UserID() == userID && filter.Archived()
+	})
+}
+
+func TestSubscriptionService_DeleteUserSubscriptions(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		setup  func(m *subscriptionServiceMocks)
+		want   int
+		code   string
+	}{
+		{
+			name:   "deletes all in one transaction",
+			userID: userID,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Count(gomock.Any(), userFilter(userID)).Return(2, nil)
+				m.repo.EXPECT().Count(gomock.Any(), archivedUserFilter(userID)).Return(1, nil)
+				m.repo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return([]uuid.UUID{uuid.New(), uuid.New(), uuid.New()}, nil)
+			},
+			want: 3,
+		},
+		{
+			name:   "only archived subscriptions",
+			userID: userID,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Count(gomock.Any(), userFilter(userID)).Return(0, nil)
+				m.repo.EXPECT().Count(gomock.Any(), archivedUserFilter(userID)).Return(1, nil)
+				m.repo.EXPECT().DeleteByUserID(gomock.Any(), userID).Return([]uuid.UUID{uuid.New()}, nil)
+			},
+			want: 1,
+		},
+		{
+			name:   "nothing to delete",
+			userID: userID,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Count(gomock.Any(), gomock.Any()).Return(0, nil).Times(2)
+			},
+		},
+		{
+			name:   "nil user id",
+			userID: uuid.Nil,
+			code:   apperror.CodeInvalidUserID,
+		},
+		{
+			name:   "count fails",
+			userID: userID,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().Count(gomock.Any(), gomock.Any()).Return(0, errDatabase)
+			},
+			code: apperror.CodeDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			deleted, err := svc.DeleteUserSubscriptions(context.Background(), tt.userID)
+			assertErrorCode(t, err, tt.code)
+			if deleted != tt.want {
+				t.Errorf("deleted: got %d, want %d", deleted, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_ListAndSearch(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("default page size", func(t *testing.T) {
+		svc, m := newTestSubscriptionService(t)
+		m.repo.EXPECT().GetByUserID(gomock.Any(), userID, 20, 0).Return(nil, nil)
+
+		_, err := svc.GetSubscriptionsByUser(context.Background(), userID, 0, 0)
+		assertErrorCode(t, err, "")
+	})
+
+	t.Run("page size is capped", func(t *testing.T) {
+		svc, m := newTestSubscriptionService(t)
+		m.repo.EXPECT().GetAllWithTotal(gomock.Any(), gomock.Any(), 100, 40).Return(nil, 0, nil)
+
+		_, _, err := svc.GetAllSubscriptions(context.Background(), nil, 500, 40)
+		assertErrorCode(t, err, "")
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		svc, _ := newTestSubscriptionService(t)
+
+		_, err := svc.GetSubscriptionsByUser(context.Background(), userID, 10, -1)
+		assertErrorCode(t, err, apperror.CodeInvalidPaginationParams)
+	})
+
+	t.Run("nil user id", func(t *testing.T) {
+		svc, _ := newTestSubscriptionService(t)
+
+		_, err := svc.GetSubscriptionsByUser(context.Background(), uuid.Nil, 10, 0)
+		assertErrorCode(t, err, apperror.CodeInvalidUserID)
+	})
+
+	t.Run("empty search query", func(t *testing.T) {
+		svc, _ := newTestSubscriptionService(t)
+
+		_, err := svc.SearchSubscriptions(context.Background(), "   ", nil, 10, 0)
+		assertErrorCode(t, err, apperror.CodeInvalidInput)
+	})
+}
+
+func TestSubscriptionService_CalculateTotalCost(t *testing.T) {
+	userID := uuid.New()
+	prorated := models.BillingProrated
+	monthly := models.BillingMonthly
+
+	log, err := logger.NewLogger(logger.Config{Level: "fatal"})
+	if err != nil {
+		t.Fatalf("logger: %v", err)
+	}
+	prorateUser := featureflags.New(featureflags.NewStatic(map[featureflags.Flag]featureflags.Rule{
+		featureflags.DailyProration: {Tenants: map[string]bool{userID.String(): true}},
+	}), nil, log)
+
+	tests := []struct {
+		name      string
+		startDate string
+		endDate   string
+		billing   *models.BillingMode
+		flags     *featureflags.Flags
+		setup     func(m *subscriptionServiceMocks)
+		code      string
+		wantNet   int
+	}{
+		{
+			name:      "default billing mode",
+			startDate: "01-2025",
+			endDate:   "03-2025",
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetTotalCostForPeriod(gomock.Any(), gomock.Any(), gomock.Any(), models.BillingMonthly, models.PricingCurrent).
+					DoAndReturn(func(_ context.Context, filter *models.SubscriptionFilter, period models.DateRange, _ models.BillingMode, _ models.PricingMode) (models.CostBreakdown, error) {
+						if filter.UserID() == nil || *filter.UserID() != userID {
+							t.Errorf("filter user: got %v", filter.UserID())
+						}
+						if period.Months() != 3 {
+							t.Errorf("period: got %d months", period.Months())
+						}
+						return models.NewCostBreakdown(1000, 100), nil
+					})
+			},
+			wantNet: 900,
+		},
+		{
+			name:      "requested billing mode",
+			startDate: "01-2025",
+			endDate:   "01-2025",
+			billing:   &prorated,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetTotalCostForPeriod(gomock.Any(), gomock.Any(), gomock.Any(), models.BillingProrated, models.PricingCurrent).
+					Return(models.NewCostBreakdown(500, 0), nil)
+			},
+			wantNet: 500,
+		},
+		{
+			name:      "daily proration flag for user",
+			startDate: "01-2025",
+			endDate:   "01-2025",
+			flags:     prorateUser,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetTotalCostForPeriod(gomock.Any(), gomock.Any(), gomock.Any(), models.BillingProrated, models.PricingCurrent).
+					Return(models.NewCostBreakdown(300, 0), nil)
+			},
+			wantNet: 300,
+		},
+		{
+			name:      "requested billing mode wins over flag",
+			startDate: "01-2025",
+			endDate:   "01-2025",
+			billing:   &monthly,
+			flags:     prorateUser,
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetTotalCostForPeriod(gomock.Any(), gomock.Any(), gomock.Any(), models.BillingMonthly, models.PricingCurrent).
+					Return(models.NewCostBreakdown(400, 0), nil)
+			},
+			wantNet: 400,
+		},
+		{
+			name:      "missing end date",
+			startDate: "01-2025",
+			code:      apperror.CodeInvalidInput,
+		},
+		{
+			name:      "reversed range",
+			startDate: "03-2025",
+			endDate:   "01-2025",
+			code:      apperror.CodeInvalidDateRange,
+		},
+		{
+			name:      "invalid date",
+			startDate: "2025-13",
+			endDate:   "01-2026",
+			code:      apperror.CodeInvalidDateFormat,
+		},
+		{
+			name:      "repository error",
+			startDate: "01-2025",
+			endDate:   "03-2025",
+			setup: func(m *subscriptionServiceMocks) {
+				m.repo.EXPECT().GetTotalCostForPeriod(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(models.CostBreakdown{}, errDatabase)
+			},
+			code: apperror.CodeDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			svc.flags = tt.flags
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			summary, err := svc.CalculateTotalCost(context.Background(), &userID, nil, tt.startDate, tt.endDate, tt.billing, models.PricingCurrent)
+			assertErrorCode(t, err, tt.code)
+			if tt.code == "" && summary.TotalCost() != tt.wantNet {
+				t.Errorf("net cost: got %d, want %d", summary.TotalCost(), tt.wantNet)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_GetExpiringSubscriptions(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		withinDays int
+		expectRepo bool
+		code       string
+	}{
+		{name: "today only", withinDays: 0, expectRepo: true},
+		{name: "upper bound", withinDays: models.MaxExpiringWithinDays, expectRepo: true},
+		{name: "negative", withinDays: -1, code: apperror.CodeInvalidInput},
+		{name: "above bound", withinDays: models.MaxExpiringWithinDays + 1, code: apperror.CodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			if tt.expectRepo {
+				m.repo.EXPECT().GetExpiring(gomock.Any(), userID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, from, to time.Time) ([]*models.Subscription, error) {
+						if to.Before(from) {
+							t.Errorf("window: %s .. %s", from, to)
+						}
+						return nil, nil
+					})
+			}
+
+			_, err := svc.GetExpiringSubscriptions(context.Background(), userID, tt.withinDays)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestSubscriptionService_GetSubscriptionCalendar(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		year       int
+		expectRepo bool
+		code       string
+	}{
+		{name: "valid year", userID: userID, year: 2025, expectRepo: true},
+		{name: "year too early", userID: userID, year: 1999, code: apperror.CodeInvalidInput},
+		{name: "year too late", userID: userID, year: 2101, code: apperror.CodeInvalidInput},
+		{name: "nil user id", userID: uuid.Nil, year: 2025, code: apperror.CodeInvalidUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			if tt.expectRepo {
+				m.repo.EXPECT().GetCalendar(gomock.Any(), tt.userID, tt.year, models.BillingMonthly, models.PricingCurrent).
+					Return(models.NewSubscriptionCalendar(tt.year, models.BillingMonthly), nil)
+			}
+
+			_, err := svc.GetSubscriptionCalendar(context.Background(), tt.userID, tt.year, nil, models.PricingCurrent)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestSubscriptionService_GetCostForecast(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		months     int
+		expectRepo bool
+		code       string
+	}{
+		{name: "six months", userID: userID, months: 6, expectRepo: true},
+		{name: "upper bound", userID: userID, months: models.MaxForecastMonths, expectRepo: true},
+		{name: "zero months", userID: userID, months: 0, code: apperror.CodeInvalidInput},
+		{name: "above bound", userID: userID, months: models.MaxForecastMonths + 1, code: apperror.CodeInvalidInput},
+		{name: "nil user id", userID: uuid.Nil, months: 6, code: apperror.CodeInvalidUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newTestSubscriptionService(t)
+			if tt.expectRepo {
+				m.repo.EXPECT().GetForecast(gomock.Any(), tt.userID, gomock.Any(), tt.months, models.BillingMonthly).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, from time.Time, months int, billing models.BillingMode) (*models.CostForecast, error) {
+						if from.Day() != 1 || from.Hour() != 0 {
+							t.Errorf("forecast must start on the first of the month, got %s", from)
+						}
+						return models.NewCostForecast(from, months, billing), nil
+					})
+			}
+
+			forecast, err := svc.GetCostForecast(context.Background(), tt.userID, tt.months, nil)
+			assertErrorCode(t, err, tt.code)
+			if tt.code == "" && len(forecast.Months()) != tt.months {
+				t.Errorf("months: got %d, want %d", len(forecast.Months()), tt.months)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_CalculateCostByPaymentMethod(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("unspecified method goes last", func(t *testing.T) {
+		svc, m := newTestSubscriptionService(t)
+		m.repo.EXPECT().GetCostByPaymentMethod(gomock.Any(), gomock.Any(), gomock.Any(), models.BillingMonthly, models.PricingCurrent).
+			Return([]*models.PaymentMethodCost{
+				models.NewPaymentMethodCost(nil, models.NewCostBreakdown(5000, 0), 3),
+				models.NewPaymentMethodCost(ptr("Family Sharing"), models.NewCostBreakdown(400, 0), 1),
+				models.NewPaymentMethodCost(ptr("Tinkoff *1234"), models.NewCostBreakdown(2000, 200), 2),
+			}, nil)
+
+		report, err := svc.CalculateCostByPaymentMethod(context.Background(), &userID, "01-2025", "03-2025", nil, models.PricingCurrent)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		methods := report.PaymentMethods()
+		if len(methods) != 3 || *methods[0].PaymentMethod() != "Tinkoff *1234" || methods[2].PaymentMethod() != nil {
+			t.Errorf("unexpected order: %v", methods)
+		}
+		if report.TotalCost() != 7200 {
+			t.Errorf("total cost: got %d, want 7200", report.TotalCost())
+		}
+	})
+
+	t.Run("missing end date", func(t *testing.T) {
+		svc, _ := newTestSubscriptionService(t)
+
+		_, err := svc.CalculateCostByPaymentMethod(context.Background(), &userID, "01-2025", "", nil, models.PricingCurrent)
+		assertErrorCode(t, err, apperror.CodeInvalidInput)
+	})
+}
+
+func TestSubscriptionService_GetBillingCalendar(t *testing.T) {
+	userID := uuid.New()
+	february := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	withDay := func(sub *models.Subscription, day int) *models.Subscription {
+		sub.SetBillingDay(&day)
+		return sub
+	}
+	// 31-е в феврале — последний день месяца.
+	monthEnd := withDay(models.NewSubscription("Netflix", models.Rubles(799), userID, time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)), 31)
+	// Без billing_day — день даты начала.
+	startDay := models.NewSubscription("Spotify", models.Rubles(299), userID, time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC))
+	// В месяц начала списание не раньше даты начала.
+	startsLater := withDay(models.NewSubscription("Okko", models.Rubles(399), userID, time.Date(2025, time.February, 20, 0, 0, 0, 0, time.UTC)), 5)
+	// Закончилась до дня списания.
+	ended := withDay(models.NewSubscription("Kinopoisk", models.Rubles(269), userID, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)), 25)
+	endDate := time.Date(2025, time.February, 14, 23, 59, 59, 0, time.UTC)
+	ended.SetEndDate(&endDate)
+
+	svc, m := newTestSubscriptionService(t)
+	m.repo.EXPECT().GetForecast(gomock.Any(), userID, february, 1, models.BillingMonthly).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, from time.Time, months int, billing models.BillingMode) (*models.CostForecast, error) {
+			forecast := models.NewCostForecast(from, months, billing)
+			for _, sub := range []*models.Subscription{monthEnd, startDay, startsLater, ended} {
+				price, err := sub.Price().WholeUnits()
+				if err != nil {
+					return nil, err
+				}
+				forecast.MonthOf(from).AddSubscription(sub, nil, models.NewPriceSchedule(price, nil))
+			}
+			return forecast, nil
+		})
+
+	calendar, err := svc.GetBillingCalendar(context.Background(), userID, "02-2025")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := map[string]int{}
+	for _, day := range calendar.Days() {
+		for _, sub := range day.Subscriptions() {
+			got[sub.ServiceName()] = day.Date().Day()
+		}
+	}
+	want := map[string]int{"Netflix": 28, "Spotify": 10, "Okko": 20}
+	if len(got) != len(want) {
+		t.Fatalf("charges: got %v, want %v", got, want)
+	}
+	for name, day := range want {
+		if got[name] != day {
+			t.Errorf("%s: charged on %d, want %d", name, got[name], day)
+		}
+	}
+	if calendar.TotalCost() != 799+299+399 {
+		t.Errorf("total cost: got %d", calendar.TotalCost())
+	}
+
+	t.Run("invalid month", func(t *testing.T) {
+		svc, _ := newTestSubscriptionService(t)
+
+		_, err := svc.GetBillingCalendar(context.Background(), userID, "2025-02")
+		assertErrorCode(t, err, apperror.CodeInvalidDateFormat)
+	})
+}
+
+func TestSubscriptionService_FindDuplicateSubscriptions(t *testing.T) {
+	userID := uuid.New()
+	netflix := models.NewSubscription("Netflix", models.Rubles(799), userID, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	premium := models.NewSubscription("netflix premium", models.Rubles(999), userID, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+	endDate := time.Date(2025, time.June, 30, 23, 59, 59, 0, time.UTC)
+	premium.SetEndDate(&endDate)
+
+	svc, m := newTestSubscriptionService(t)
+	m.repo.EXPECT().FindDuplicates(gomock.Any(), userID, models.DuplicateNameSimilarity).
+		Return([]*models.SubscriptionDuplicate{models.NewSubscriptionDuplicate(netflix, premium, 0.55)}, nil)
+
+	duplicates, err := svc.FindDuplicateSubscriptions(context.Background(), userID)
+	if err != nil || len(duplicates) != 1 {
+		t.Fatalf("got %v, %v", duplicates, err)
+	}
+
+	duplicate := duplicates[0]
+	if duplicate.Reason() != models.DuplicateSimilarName {
+		t.Errorf("reason: got %s", duplicate.Reason())
+	}
+	if from, to := duplicate.Overlap(); !from.Equal(premium.StartDate()) || to == nil || !to.Equal(endDate) {
+		t.Errorf("overlap: got %s – %v", from, to)
+	}
+	if !duplicate.MonthlySavings().Equal(models.Rubles(799)) {
+		t.Errorf("monthly savings: got %s", duplicate.MonthlySavings())
+	}
+
+	_, err = svc.FindDuplicateSubscriptions(context.Background(), uuid.Nil)
+	assertErrorCode(t, err, apperror.CodeInvalidUserID)
+}
@@ -0,0 +1,6 @@
+package request
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"billing-dashboard" minLength:"1" maxLength:"100"`
+	Role string `json:"role" binding:"required,oneof=admin operator viewer" example:"viewer" enums:"admin,operator,viewer"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type CreateCanonicalServiceNameRequest struct {
+	Name    string   `json:"name" binding:"required,max=255" example:"Netflix" minLength:"1" maxLength:"255"`
+	Aliases []string `json:"aliases" binding:"max=32,dive,max=255" example:"Нетфликс,Netflix Inc"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type CreateCommentRequest struct {
+	Author string `json:"author" binding:"required,max=255" example:"support:anna" minLength:"1" maxLength:"255"`
+	Body   string `json:"body" binding:"required,max=4000" example:"Cancelled by phone on the 12th" minLength:"1" maxLength:"4000"`
+}
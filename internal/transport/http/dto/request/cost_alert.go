@@ -0,0 +1,5 @@
+package request
+
+type CreateCostAlertRequest struct {
+	MonthlyLimit int `json:"monthly_limit" binding:"required,min=1,max=100000000" example:"3000"`
+}
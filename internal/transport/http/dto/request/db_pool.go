@@ -0,0 +1,8 @@
+package request
+
+// ResizeDBPoolRequest — новый размер пула; без min_conns он сохраняется,
+// но не больше max_conns.
+type ResizeDBPoolRequest struct {
+	MaxConns *int32 `json:"max_conns" binding:"required,min=1" example:"10" minimum:"1"`
+	MinConns *int32 `json:"min_conns" binding:"omitempty,min=0" example:"2" minimum:"0"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type CreateDiscountRequest struct {
+	Code        string `json:"code" binding:"required,max=64" example:"SUMMER25" minLength:"1" maxLength:"64"`
+	Kind        string `json:"kind" binding:"required,oneof=percentage fixed" example:"percentage" enums:"percentage,fixed"`
+	Amount      int    `json:"amount" binding:"required,min=1" example:"25"`
+	ServiceName string `json:"service_name,omitempty" binding:"max=255" example:"Yandex Plus" maxLength:"255"`
+	ValidFrom   string `json:"valid_from" binding:"required,monthyear" example:"06-2025"`
+	ValidTo     string `json:"valid_to,omitempty" binding:"omitempty,monthyear" example:"08-2025"`
+}
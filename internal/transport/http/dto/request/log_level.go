@@ -0,0 +1,9 @@
+package request
+
+// SetLogLevelRequest — временный уровень логов; duration в секундах, без
+// него — logger.level_override.default_duration.
+type SetLogLevelRequest struct {
+	Level    string `json:"level" binding:"required,oneof=debug info warn error" example:"debug" enums:"debug,info,warn,error"`
+	Duration *int   `json:"duration" binding:"omitempty,min=1" example:"900" minimum:"1"`
+	Reason   string `json:"reason" binding:"max=500" example:"INC-1234: duplicate charges" maxLength:"500"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type SetMaintenanceRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required" example:"true"`
+	Reason  string `json:"reason" binding:"max=500" example:"migration 000031: partition subscriptions" maxLength:"500"`
+}
@@ -0,0 +1,17 @@
+package request
+
+type CreatePlanRequest struct {
+	Name         string                 `json:"name" binding:"required,max=255" example:"Yandex Plus Family" minLength:"1" maxLength:"255"`
+	ServiceName  string                 `json:"service_name" binding:"required,max=255" example:"Yandex Plus" minLength:"1" maxLength:"255"`
+	Price        int                    `json:"price" binding:"required,min=1,max=12000000" example:"399"`
+	BillingCycle string                 `json:"billing_cycle" binding:"required,oneof=monthly yearly" example:"monthly" enums:"monthly,yearly"`
+	Features     map[string]interface{} `json:"features,omitempty" swaggertype:"object"`
+}
+
+type UpdatePlanRequest struct {
+	Name         *string                `json:"name,omitempty" binding:"omitempty,min=1,max=255" example:"Yandex Plus Family" maxLength:"255"`
+	ServiceName  *string                `json:"service_name,omitempty" binding:"omitempty,min=1,max=255" example:"Yandex Plus" maxLength:"255"`
+	Price        *int                   `json:"price,omitempty" binding:"omitempty,min=1,max=12000000" example:"449"`
+	BillingCycle *string                `json:"billing_cycle,omitempty" binding:"omitempty,oneof=monthly yearly" example:"monthly" enums:"monthly,yearly"`
+	Features     map[string]interface{} `json:"features,omitempty" swaggertype:"object"`
+}
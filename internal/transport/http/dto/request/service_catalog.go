@@ -0,0 +1,35 @@
+package request
+
+import "github.com/google/uuid"
+
+type CreateCatalogServiceRequest struct {
+	Name         string  `json:"name" binding:"required,max=255" example:"Netflix" minLength:"1" maxLength:"255"`
+	Category     *string `json:"category,omitempty" example:"streaming" enums:"streaming,music,cloud,software,gaming,fitness,education,news,shopping,other"`
+	Website      string  `json:"website,omitempty" binding:"max=2048" example:"https://www.netflix.com" maxLength:"2048"`
+	IconURL      string  `json:"icon_url,omitempty" binding:"max=2048" example:"https://cdn.example.com/icons/netflix.png" maxLength:"2048"`
+	TypicalPrice *int    `json:"typical_price,omitempty" binding:"omitempty,min=1,max=1000000" example:"599"`
+}
+
+type UpdateCatalogServiceRequest struct {
+	Name         *string `json:"name,omitempty" binding:"omitempty,min=1,max=255" example:"Netflix" maxLength:"255"`
+	Category     *string `json:"category,omitempty" example:"streaming" enums:"streaming,music,cloud,software,gaming,fitness,education,news,shopping,other"`
+	Website      *string `json:"website,omitempty" binding:"omitempty,max=2048" example:"https://www.netflix.com" maxLength:"2048"`
+	IconURL      *string `json:"icon_url,omitempty" binding:"omitempty,max=2048" example:"https://cdn.example.com/icons/netflix.png" maxLength:"2048"`
+	TypicalPrice *int    `json:"typical_price,omitempty" binding:"omitempty,min=1,max=1000000" example:"699"`
+}
+
+// LinkCatalogServiceRequest: catalog_id == null снимает привязку.
+type LinkCatalogServiceRequest struct {
+	CatalogID *string `json:"catalog_id" binding:"omitempty,uuid4" example:"4f1c2a9e-7b3d-4e8a-9c5f-1a2b3c4d5e6f"`
+}
+
+func (r *LinkCatalogServiceRequest) GetCatalogID() (*uuid.UUID, error) {
+	if r.CatalogID == nil {
+		return nil, nil
+	}
+	id, err := uuid.Parse(*r.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -0,0 +1,8 @@
+package request
+
+type CreateServiceNameRuleRequest struct {
+	List    string `json:"list" binding:"required,oneof=allow deny" example:"deny" enums:"allow,deny"`
+	Match   string `json:"match" binding:"required,oneof=exact regex" example:"regex" enums:"exact,regex"`
+	Pattern string `json:"pattern" binding:"required,max=255" example:"(?:casino|viagra)" minLength:"1" maxLength:"255"`
+	Reason  string `json:"reason" binding:"max=1000" example:"spam campaign 2025-01" maxLength:"1000"`
+}
@@ -2,27 +2,48 @@ package request
 
 import (
 	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
 )
 
 type CreateSubscriptionRequest struct {
-	ServiceName string `json:"service_name" binding:"required" example:"Yandex Plus" minLength:"1" maxLength:"255"`
-	Price       int    `json:"price" binding:"required,min=1,max=1000000" example:"400"`
-	UserID      string `json:"user_id" binding:"required,uuid" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
-	StartDate   string `json:"start_date" binding:"required" example:"07-2025" pattern:"^(0[1-9]|1[0-2])-[0-9]{4}$"`
-	EndDate     string `json:"end_date,omitempty" example:"12-2025" pattern:"^(0[1-9]|1[0-2])-[0-9]{4}$"`
+	ServiceName   string            `json:"service_name,omitempty" binding:"required_without_all=PlanID CatalogID" example:"Yandex Plus" minLength:"1" maxLength:"255"`
+	Price         int               `json:"price,omitempty" binding:"required_without=PlanID,omitempty,min=1,max=1000000" example:"400"`
+	PlanID        string            `json:"plan_id,omitempty" binding:"omitempty,uuid4" example:"9b2e4f60-3c1d-4a7e-8f5b-2d6c1e0a9b3f"`
+	CatalogID     string            `json:"catalog_id,omitempty" binding:"omitempty,uuid4" example:"4f1c2a9e-7b3d-4e8a-9c5f-1a2b3c4d5e6f"`
+	UserID        string            `json:"user_id" binding:"required,uuid4" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate     string            `json:"start_date" binding:"required,monthyear" example:"07-2025"`
+	EndDate       string            `json:"end_date,omitempty" binding:"omitempty,monthyear" example:"12-2025"`
+	PromoCode     string            `json:"promo_code,omitempty" binding:"max=64" example:"SUMMER25" maxLength:"64"`
+	Tags          []string          `json:"tags,omitempty" example:"family,entertainment"`
+	Category      string            `json:"category,omitempty" example:"streaming" enums:"streaming,music,cloud,software,gaming,fitness,education,news,shopping,other"`
+	PaymentMethod string            `json:"payment_method,omitempty" example:"Tinkoff *1234" maxLength:"100"`
+	BillingDay    *int              `json:"billing_day,omitempty" binding:"omitempty,min=1,max=31" example:"15" minimum:"1" maximum:"31"`
+	Notes         string            `json:"notes,omitempty" example:"Shared with family" maxLength:"2000"`
+	Metadata      map[string]string `json:"metadata,omitempty" swaggertype:"object,string" example:"team:platform"`
 }
 
 type UpdateSubscriptionRequest struct {
-	ServiceName *string `json:"service_name,omitempty" example:"Netflix Premium" minLength:"1" maxLength:"255"`
-	Price       *int    `json:"price,omitempty" minimum:"1" maximum:"1000000" example:"799"`
-	StartDate   *string `json:"start_date,omitempty" example:"08-2025" pattern:"^(0[1-9]|1[0-2])-[0-9]{4}$"`
-	EndDate     *string `json:"end_date,omitempty" example:"12-2025" pattern:"^(0[1-9]|1[0-2])-[0-9]{4}$"`
+	ServiceName   *string            `json:"service_name,omitempty" example:"Netflix Premium" minLength:"1" maxLength:"255"`
+	Price         *int               `json:"price,omitempty" minimum:"1" maximum:"1000000" example:"799"`
+	StartDate     *string            `json:"start_date,omitempty" binding:"omitempty,monthyear" example:"08-2025"`
+	EndDate       *string            `json:"end_date,omitempty" binding:"omitempty,monthyear" example:"12-2025"`
+	Tags          *[]string          `json:"tags,omitempty" example:"family,entertainment"`
+	Category      *string            `json:"category,omitempty" example:"streaming" enums:"streaming,music,cloud,software,gaming,fitness,education,news,shopping,other"`
+	PaymentMethod *string            `json:"payment_method,omitempty" example:"Family Sharing" maxLength:"100"`
+	BillingDay    *int               `json:"billing_day,omitempty" binding:"omitempty,min=0,max=31" example:"15" minimum:"0" maximum:"31"`
+	Notes         *string            `json:"notes,omitempty" example:"Cancel before renewal" maxLength:"2000"`
+	Metadata      *map[string]string `json:"metadata,omitempty" swaggertype:"object,string" example:"team:platform"`
 }
 
 type GetSubscriptionRequest struct {
 	ID string `json:"id" path:"id"`
 }
 
+type UpdateSubscriptionPathRequest struct {
+	ID string `json:"id" path:"id"`
+}
+
 type DeleteSubscriptionRequest struct {
 	ID string `json:"id" path:"id"`
 }
@@ -38,10 +59,17 @@ type GetSubscriptionsRequest struct {
 	ServiceName *string `json:"service_name" query:"service_name"`
 	StartDate   *string `json:"start_date" query:"start_date"`
 	EndDate     *string `json:"end_date" query:"end_date"`
+	Tags        *string `json:"tags" query:"tags"`
+	Category    *string `json:"category" query:"category"`
 	Limit       int     `json:"limit" query:"limit"`
 	Offset      int     `json:"offset" query:"offset"`
 }
 
+type GetUserCalendarRequest struct {
+	UserID string `json:"user_id" path:"user_id"`
+	Year   int    `json:"year" query:"year"`
+}
+
 type CalculateCostRequest struct {
 	UserID      *string `json:"user_id" query:"user_id"`
 	ServiceName *string `json:"service_name" query:"service_name"`
@@ -53,18 +81,50 @@ func (r *CreateSubscriptionRequest) GetUserID() (uuid.UUID, error) {
 	return uuid.Parse(r.UserID)
 }
 
-func (r *GetSubscriptionRequest) GetID() (uuid.UUID, error) {
-	return uuid.Parse(r.ID)
+// GetPlanID возвращает nil, если подписка создаётся без тарифа.
+func (r *CreateSubscriptionRequest) GetPlanID() (*uuid.UUID, error) {
+	if r.PlanID == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(r.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// GetCatalogID возвращает nil, если подписка не привязана к каталогу.
+func (r *CreateSubscriptionRequest) GetCatalogID() (*uuid.UUID, error) {
+	if r.CatalogID == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(r.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (r *GetSubscriptionRequest) GetID(ids publicid.Codec) (uuid.UUID, error) {
+	return ids.Decode(r.ID)
+}
+
+func (r *UpdateSubscriptionPathRequest) GetID(ids publicid.Codec) (uuid.UUID, error) {
+	return ids.Decode(r.ID)
 }
 
-func (r *DeleteSubscriptionRequest) GetID() (uuid.UUID, error) {
-	return uuid.Parse(r.ID)
+func (r *DeleteSubscriptionRequest) GetID(ids publicid.Codec) (uuid.UUID, error) {
+	return ids.Decode(r.ID)
 }
 
 func (r *GetUserSubscriptionsRequest) GetUserID() (uuid.UUID, error) {
 	return uuid.Parse(r.UserID)
 }
 
+func (r *GetUserCalendarRequest) GetUserID() (uuid.UUID, error) {
+	return uuid.Parse(r.UserID)
+}
+
 func (r *GetSubscriptionsRequest) GetUserID() (*uuid.UUID, error) {
 	if r.UserID == nil || *r.UserID == "" {
 		return nil, nil
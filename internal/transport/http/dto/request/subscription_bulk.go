@@ -0,0 +1,36 @@
+package request
+
+// BulkUpdateSubscriptionsRequest — изменения подписок списком; поля
+// элемента те же, что у UpdateSubscriptionRequest.
+type BulkUpdateSubscriptionsRequest struct {
+	Items []BulkUpdateItemRequest `json:"items" binding:"required,min=1,max=100,dive"`
+}
+
+type BulkUpdateItemRequest struct {
+	ID string `json:"id" binding:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UpdateSubscriptionRequest
+}
+
+// BulkUpdateByFilterRequest — изменение set для всех подписок под filter.
+// Без dry_run=false изменения только подсчитываются.
+type BulkUpdateByFilterRequest struct {
+	Filter BulkUpdateFilter          `json:"filter"`
+	Set    UpdateSubscriptionRequest `json:"set"`
+	DryRun *bool                     `json:"dry_run,omitempty" example:"true"`
+}
+
+// BulkUpdateFilter — условия отбора; service_name совпадает с названием
+// целиком, без учёта регистра.
+type BulkUpdateFilter struct {
+	UserID      *string           `json:"user_id,omitempty" binding:"omitempty,uuid4" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	ServiceName *string           `json:"service_name,omitempty" example:"Yandex+"`
+	Tags        []string          `json:"tags,omitempty" example:"family"`
+	Category    *string           `json:"category,omitempty" example:"streaming" enums:"streaming,music,cloud,software,gaming,fitness,education,news,shopping,other"`
+	Metadata    map[string]string `json:"metadata,omitempty" swaggertype:"object,string" example:"team:platform"`
+}
+
+// IsDryRun — dry_run по умолчанию включён: изменение по фильтру
+// применяется только явным dry_run=false.
+func (r *BulkUpdateByFilterRequest) IsDryRun() bool {
+	return r.DryRun == nil || *r.DryRun
+}
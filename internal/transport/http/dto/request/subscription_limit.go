@@ -0,0 +1,7 @@
+package request
+
+// SetSubscriptionLimitRequest — лимит подписок пользователя; 0 снимает ограничение.
+type SetSubscriptionLimitRequest struct {
+	MaxSubscriptions *int   `json:"max_subscriptions" binding:"required,min=0" example:"500" minimum:"0"`
+	Reason           string `json:"reason" binding:"max=500" example:"nightly import from the billing system" maxLength:"500"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type AddSubscriptionMemberRequest struct {
+	UserID       string `json:"user_id" binding:"required,uuid4" example:"7a1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d"`
+	SharePercent int    `json:"share_percent" binding:"required,min=1,max=100" example:"25"`
+}
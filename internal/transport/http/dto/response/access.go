@@ -0,0 +1,38 @@
+package response
+
+import "time"
+
+type APIKeyResponse struct {
+	ID        string     `json:"id" example:"8c1f2e3d-4b5a-4c6d-9e7f-0a1b2c3d4e5f"`
+	Name      string     `json:"name" example:"billing-dashboard"`
+	Prefix    string     `json:"prefix" example:"sk_Xy3kP9q"`
+	Role      string     `json:"role" example:"viewer" enums:"admin,operator,viewer"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-01-15T10:30:00Z"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" example:"2025-02-01T09:00:00Z"`
+}
+
+// CreatedAPIKeyResponse — единственный ответ, в котором есть сам ключ.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"sk_Xy3kP9qLr2mN8vB4tW6zH1cJ5dF7gK0aS3eU9iO2pQ"`
+}
+
+type APIKeysListResponse struct {
+	Data []APIKeyResponse `json:"data"`
+}
+
+type EndpointPermissionResponse struct {
+	Method     string `json:"method" example:"GET"`
+	Path       string `json:"path" example:"/subscriptions/:id"`
+	Permission string `json:"permission,omitempty" example:"subscriptions:read"`
+	Allowed    bool   `json:"allowed" example:"true"`
+}
+
+type PermissionsResponse struct {
+	AuthEnabled bool                         `json:"auth_enabled" example:"true"`
+	Subject     string                       `json:"subject,omitempty" example:"8c1f2e3d-4b5a-4c6d-9e7f-0a1b2c3d4e5f"`
+	Source      string                       `json:"source,omitempty" example:"api_key" enums:"jwt,api_key,admin_key"`
+	Role        string                       `json:"role" example:"operator" enums:"admin,operator,viewer"`
+	Permissions []string                     `json:"permissions" example:"subscriptions:read,subscriptions:write"`
+	Endpoints   []EndpointPermissionResponse `json:"endpoints"`
+}
@@ -0,0 +1,85 @@
+package response
+
+import "time"
+
+type ConfigConsistencyResponse struct {
+	Consistent bool                     `json:"consistent" example:"false"`
+	InstanceID string                   `json:"instance_id" example:"subscription-service-7d9f-1"`
+	ConfigHash string                   `json:"config_hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Instances  []ConfigInstanceResponse `json:"instances"`
+}
+
+type ConfigInstanceResponse struct {
+	InstanceID string    `json:"instance_id" example:"subscription-service-7d9f-2"`
+	ConfigHash string    `json:"config_hash" example:"60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752"`
+	ReportedAt time.Time `json:"reported_at" example:"2025-01-15T10:30:00Z"`
+	Matches    bool      `json:"matches" example:"false"`
+}
+
+type UserSpendReportResponse struct {
+	Period      PeriodResponse      `json:"period"`
+	BillingMode string              `json:"billing_mode" example:"monthly" enums:"monthly,prorated"`
+	TotalCost   int                 `json:"total_cost" example:"125000000"`
+	TotalUsers  int                 `json:"total_users" example:"250000"`
+	Currency    string              `json:"currency" example:"RUB"`
+	Users       []UserSpendResponse `json:"users"`
+	Pagination  PaginationResponse  `json:"pagination"`
+}
+
+type UserSpendResponse struct {
+	UserID        string `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	TotalCost     int    `json:"total_cost" example:"1200"`
+	Subscriptions int    `json:"subscriptions" example:"3"`
+}
+
+type LiveStatsResponse struct {
+	Timestamp           time.Time `json:"timestamp" example:"2025-01-15T10:30:00Z"`
+	RequestsPerSecond   float64   `json:"requests_per_second" example:"42.7"`
+	CreatesPerMinute    int64     `json:"creates_per_minute" example:"12"`
+	ActiveSubscriptions int64     `json:"active_subscriptions" example:"184203"`
+	Streams             int       `json:"streams" example:"2"`
+}
+
+type TopServicesResponse struct {
+	Period      PeriodResponse         `json:"period"`
+	BillingMode string                 `json:"billing_mode" example:"monthly" enums:"monthly,prorated"`
+	SortBy      string                 `json:"sort_by" example:"subscribers" enums:"subscribers,revenue"`
+	Currency    string                 `json:"currency" example:"RUB"`
+	Services    []ServiceStatsResponse `json:"services"`
+}
+
+type ServiceStatsResponse struct {
+	ServiceName   string `json:"service_name" example:"Yandex Plus"`
+	Subscribers   int    `json:"subscribers" example:"15230"`
+	Subscriptions int    `json:"subscriptions" example:"15810"`
+	Revenue       int    `json:"revenue" example:"6324000"`
+}
+
+type MRRResponse struct {
+	Period   PeriodResponse     `json:"period"`
+	Current  int                `json:"current" example:"1250000"`
+	Change   int                `json:"change" example:"85000"`
+	Currency string             `json:"currency" example:"RUB"`
+	Months   []MRRPointResponse `json:"months"`
+}
+
+type MRRPointResponse struct {
+	Month         string `json:"month" example:"07-2025"`
+	MRR           int    `json:"mrr" example:"1250000"`
+	Subscriptions int    `json:"subscriptions" example:"3120"`
+}
+
+type ChurnResponse struct {
+	Period       PeriodResponse       `json:"period"`
+	TotalChurned int                  `json:"total_churned" example:"412"`
+	AverageRate  float64              `json:"average_rate" example:"0.0213"`
+	Months       []ChurnPointResponse `json:"months"`
+}
+
+type ChurnPointResponse struct {
+	Month         string  `json:"month" example:"07-2025"`
+	ActiveAtStart int     `json:"active_at_start" example:"3050"`
+	Started       int     `json:"started" example:"140"`
+	Churned       int     `json:"churned" example:"70"`
+	Rate          float64 `json:"rate" example:"0.023"`
+}
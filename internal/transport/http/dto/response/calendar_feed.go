@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+// CalendarFeedResponse — единственный ответ, в котором есть токен ленты;
+// url можно сразу добавить в календарь как подписку.
+type CalendarFeedResponse struct {
+	UserID    string    `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	URL       string    `json:"url" example:"https://api.example.com/api/v1/users/60601fee-2bf1-4721-ae6f-7636e79a0cba/billing-calendar.ics?token=cal_Xy3kP9qLr2mN8vB4tW6zH1cJ5dF7gK0aS3eU9iO2pQ"`
+	Token     string    `json:"token" example:"cal_Xy3kP9qLr2mN8vB4tW6zH1cJ5dF7gK0aS3eU9iO2pQ"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-15T10:30:00Z"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+type CanonicalServiceNameResponse struct {
+	ID        string    `json:"id" example:"7a1c9e2d-4b3f-4e8a-9c6d-2f1e0b3a4c5d"`
+	Name      string    `json:"name" example:"Netflix"`
+	Aliases   []string  `json:"aliases" example:"Нетфликс,Netflix Inc"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-15T10:30:00Z"`
+}
+
+type CanonicalServiceNamesListResponse struct {
+	Data []CanonicalServiceNameResponse `json:"data"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type CommentResponse struct {
+	ID             string    `json:"id" example:"0b1f9c0e-4a53-4f7e-9b1b-2f7a3f3c9a10"`
+	SubscriptionID string    `json:"subscription_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Author         string    `json:"author" example:"support:anna"`
+	Body           string    `json:"body" example:"Cancelled by phone on the 12th"`
+	CreatedAt      time.Time `json:"created_at" example:"2025-01-15T10:30:00Z"`
+}
+
+type CommentsListResponse struct {
+	Data       []CommentResponse  `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
@@ -0,0 +1,26 @@
+package response
+
+import "time"
+
+type CostAlertResponse struct {
+	ID           string    `json:"id" example:"8d2e4f6a-1b3c-4d5e-9f7a-2b4c6d8e0f1a"`
+	UserID       string    `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	MonthlyLimit int       `json:"monthly_limit" example:"3000"`
+	Currency     string    `json:"currency" example:"RUB"`
+	CreatedAt    time.Time `json:"created_at" example:"2025-01-15T10:30:00Z"`
+}
+
+type CostAlertsListResponse struct {
+	Data []CostAlertResponse `json:"data"`
+}
+
+// CostAlertStatusResponse — порог в сводке пользователя: траты текущего
+// месяца и уведомление, если оно уже ушло.
+type CostAlertStatusResponse struct {
+	ID           string     `json:"id" example:"8d2e4f6a-1b3c-4d5e-9f7a-2b4c6d8e0f1a"`
+	MonthlyLimit int        `json:"monthly_limit" example:"3000"`
+	Month        string     `json:"month" example:"07-2025"`
+	Spent        int        `json:"spent" example:"3497"`
+	Exceeded     bool       `json:"exceeded" example:"true"`
+	NotifiedAt   *time.Time `json:"notified_at" example:"2025-07-03T09:00:00Z"`
+}
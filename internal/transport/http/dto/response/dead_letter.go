@@ -0,0 +1,25 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type DeadLetterResponse struct {
+	ID           string          `json:"id" example:"0b6f1f9e-5d4a-4c3b-9e2f-7a8b9c0d1e2f"`
+	Source       string          `json:"source" example:"event_recording" enums:"event_recording,outbox_delivery"`
+	Topic        string          `json:"topic" example:"subscriptions"`
+	EventID      string          `json:"event_id" example:"3f2b8c1d-6e4a-4b7c-9d0e-1f2a3b4c5d6e"`
+	EventType    string          `json:"event_type" example:"subscription.created"`
+	Status       string          `json:"status" example:"pending" enums:"pending,requeued"`
+	Error        string          `json:"error" example:"context deadline exceeded"`
+	Payload      json.RawMessage `json:"payload" swaggertype:"object"`
+	FailedAt     time.Time       `json:"failed_at" example:"2025-01-15T10:30:00Z"`
+	RequeuedAt   *time.Time      `json:"requeued_at,omitempty" example:"2025-01-15T11:00:00Z"`
+	RequeueCount int             `json:"requeue_count" example:"0"`
+}
+
+type DeadLettersListResponse struct {
+	Data       []DeadLetterResponse `json:"data"`
+	Pagination PaginationResponse   `json:"pagination"`
+}
@@ -0,0 +1,26 @@
+package response
+
+// DBPoolStatsResponse — снимок pgxpool.Stat. Счётчики *_count и длительности
+// накапливаются с запуска процесса или с последнего изменения размера пула.
+type DBPoolStatsResponse struct {
+	MaxConns                int32   `json:"max_conns" example:"25"`
+	TotalConns              int32   `json:"total_conns" example:"8"`
+	AcquiredConns           int32   `json:"acquired_conns" example:"3"`
+	IdleConns               int32   `json:"idle_conns" example:"5"`
+	ConstructingConns       int32   `json:"constructing_conns" example:"0"`
+	AcquireCount            int64   `json:"acquire_count" example:"120394"`
+	AcquireDurationMs       float64 `json:"acquire_duration_ms" example:"842.5"`
+	EmptyAcquireCount       int64   `json:"empty_acquire_count" example:"17"`
+	CanceledAcquireCount    int64   `json:"canceled_acquire_count" example:"0"`
+	NewConnsCount           int64   `json:"new_conns_count" example:"31"`
+	MaxLifetimeDestroyCount int64   `json:"max_lifetime_destroy_count" example:"20"`
+	MaxIdleDestroyCount     int64   `json:"max_idle_destroy_count" example:"3"`
+}
+
+// DBPoolResponse — размер пула и его текущее состояние.
+type DBPoolResponse struct {
+	MinConns      int32               `json:"min_conns" example:"5"`
+	MaxConns      int32               `json:"max_conns" example:"25"`
+	MaxConnsLimit int32               `json:"max_conns_limit" example:"100"`
+	Stats         DBPoolStatsResponse `json:"stats"`
+}
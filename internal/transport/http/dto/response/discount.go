@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+type DiscountResponse struct {
+	ID          string    `json:"id" example:"5d3c2a1b-8f4e-4c6d-9a7b-1e2f3a4b5c6d"`
+	Code        string    `json:"code" example:"SUMMER25"`
+	Kind        string    `json:"kind" example:"percentage" enums:"percentage,fixed"`
+	Amount      int       `json:"amount" example:"25"`
+	ServiceName *string   `json:"service_name,omitempty" example:"Yandex Plus"`
+	ValidFrom   string    `json:"valid_from" example:"06-2025"`
+	ValidTo     *string   `json:"valid_to,omitempty" example:"08-2025"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-01-15T10:30:00Z"`
+}
+
+type DiscountsListResponse struct {
+	Data []DiscountResponse `json:"data"`
+}
@@ -16,11 +16,12 @@ type ErrorDetail struct {
 
 type ValidationErrorResponse struct {
 	Error            ErrorDetail       `json:"error"`
-	ValidationErrors []ValidationError `json:"validation_errors"`
+	ValidationErrors []ValidationError `json:"validation_errors,omitempty"`
 }
 
 type ValidationError struct {
 	Field   string `json:"field" example:"price"`
+	Rule    string `json:"rule" example:"min"`
 	Message string `json:"message" example:"must be greater than 0"`
 	Value   string `json:"value,omitempty" example:"-100"`
 }
@@ -37,11 +38,12 @@ func NewErrorResponse(code, message string, details map[string]string, requestID
 	}
 }
 
-func NewValidationErrorResponse(code, message string, validationErrors []ValidationError, requestID string) ValidationErrorResponse {
+func NewValidationErrorResponse(code, message string, details map[string]string, validationErrors []ValidationError, requestID string) ValidationErrorResponse {
 	return ValidationErrorResponse{
 		Error: ErrorDetail{
 			Code:      code,
 			Message:   message,
+			Details:   details,
 			Timestamp: time.Now(),
 			RequestID: requestID,
 		},
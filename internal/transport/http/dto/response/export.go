@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+// ExportFileResponse — выгрузка, выложенная в объектное хранилище: url
+// скачивается без авторизации до expires_at.
+type ExportFileResponse struct {
+	Key       string    `json:"key" example:"exports/2025/07/15/0b7f6a7e-3c1d-4f2a-9e5b-8d4c2a1f6e3b/subscriptions.csv"`
+	URL       string    `json:"url" example:"https://storage.example.com/subscription-service/exports/2025/07/15/0b7f6a7e-3c1d-4f2a-9e5b-8d4c2a1f6e3b/subscriptions.csv?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Signature=..."`
+	Size      int64     `json:"size" example:"48213"`
+	Rows      int       `json:"rows" example:"512"`
+	ExpiresAt time.Time `json:"expires_at" example:"2025-07-15T11:30:00Z"`
+}
@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+// LogLevelResponse — уровень логов этой реплики; until — когда он вернётся
+// к default, уровню из конфигурации.
+type LogLevelResponse struct {
+	Level   string     `json:"level" example:"debug" enums:"debug,info,warn,error,dpanic,panic,fatal"`
+	Default string     `json:"default" example:"info"`
+	Until   *time.Time `json:"until,omitempty" example:"2025-07-15T10:45:00Z"`
+	Reason  string     `json:"reason,omitempty" example:"INC-1234: duplicate charges"`
+}
@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+// MaintenanceResponse — режим обслуживания этой реплики; reads — что
+// происходит с чтениями: allow, cache или deny.
+type MaintenanceResponse struct {
+	Enabled bool       `json:"enabled" example:"true"`
+	Reason  string     `json:"reason,omitempty" example:"migration 000031: partition subscriptions"`
+	Since   *time.Time `json:"since,omitempty" example:"2025-07-15T10:30:00Z"`
+	Reads   string     `json:"reads" example:"cache" enums:"allow,cache,deny"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+type PlanResponse struct {
+	ID           string                 `json:"id" example:"9b2e4f60-3c1d-4a7e-8f5b-2d6c1e0a9b3f"`
+	Name         string                 `json:"name" example:"Yandex Plus Family"`
+	ServiceName  string                 `json:"service_name" example:"Yandex Plus"`
+	Price        int                    `json:"price" example:"399"`
+	Currency     string                 `json:"currency" example:"RUB"`
+	BillingCycle string                 `json:"billing_cycle" example:"monthly" enums:"monthly,yearly"`
+	MonthlyPrice int                    `json:"monthly_price" example:"399"`
+	Features     map[string]interface{} `json:"features" swaggertype:"object"`
+	CreatedAt    time.Time              `json:"created_at" example:"2025-01-15T10:30:00Z"`
+	UpdatedAt    time.Time              `json:"updated_at" example:"2025-01-15T10:30:00Z"`
+}
+
+type PlansListResponse struct {
+	Data       []PlanResponse     `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type PlanPriceResponse struct {
+	Price         int       `json:"price" example:"399"`
+	BillingCycle  string    `json:"billing_cycle" example:"monthly" enums:"monthly,yearly"`
+	EffectiveFrom time.Time `json:"effective_from" example:"2025-01-15T10:30:00Z"`
+}
+
+type PlanPriceHistoryResponse struct {
+	PlanID string              `json:"plan_id" example:"9b2e4f60-3c1d-4a7e-8f5b-2d6c1e0a9b3f"`
+	Data   []PlanPriceResponse `json:"data"`
+}
@@ -0,0 +1,22 @@
+package response
+
+import "time"
+
+// ProblemContentType — тип содержимого ошибок по RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails — ошибка в формате RFC 7807 для клиентов с
+// Accept: application/problem+json. Поля после instance — расширения с
+// теми же данными, что и в ErrorResponse.
+type ProblemDetails struct {
+	Type             string            `json:"type" example:"urn:problem-type:subscription-service:subscription-not-found"`
+	Title            string            `json:"title" example:"Subscription not found"`
+	Status           int               `json:"status" example:"404"`
+	Detail           string            `json:"detail,omitempty" example:"Subscription not found"`
+	Instance         string            `json:"instance,omitempty" example:"/api/v1/subscriptions/123e4567-e89b-12d3-a456-426614174000"`
+	Code             string            `json:"code" example:"SUBSCRIPTION_NOT_FOUND"`
+	Details          map[string]string `json:"details,omitempty"`
+	ValidationErrors []ValidationError `json:"validation_errors,omitempty"`
+	Timestamp        time.Time         `json:"timestamp" example:"2025-01-15T10:30:00Z"`
+	RequestID        string            `json:"request_id,omitempty" example:"20250115103000-abc123"`
+}
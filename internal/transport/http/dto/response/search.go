@@ -0,0 +1,18 @@
+package response
+
+type SearchHighlightResponse struct {
+	Field    string `json:"field" example:"service_name" enums:"service_name,tags,notes"`
+	Fragment string `json:"fragment" example:"<mark>Netflix</mark> Premium"`
+}
+
+type SubscriptionSearchHitResponse struct {
+	Subscription SubscriptionResponse      `json:"subscription"`
+	Rank         float64                   `json:"rank" example:"0.71"`
+	Highlights   []SearchHighlightResponse `json:"highlights"`
+}
+
+type SubscriptionSearchResponse struct {
+	Query      string                          `json:"query" example:"netflx"`
+	Data       []SubscriptionSearchHitResponse `json:"data"`
+	Pagination PaginationResponse              `json:"pagination"`
+}
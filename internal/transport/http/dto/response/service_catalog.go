@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+type CatalogServiceResponse struct {
+	ID           string    `json:"id" example:"4f1c2a9e-7b3d-4e8a-9c5f-1a2b3c4d5e6f"`
+	Name         string    `json:"name" example:"Netflix"`
+	Category     *string   `json:"category,omitempty" example:"streaming"`
+	Website      string    `json:"website,omitempty" example:"https://www.netflix.com"`
+	IconURL      string    `json:"icon_url,omitempty" example:"https://cdn.example.com/icons/netflix.png"`
+	TypicalPrice *int      `json:"typical_price,omitempty" example:"599"`
+	CreatedAt    time.Time `json:"created_at" example:"2025-01-15T10:30:00Z"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2025-01-15T10:30:00Z"`
+}
+
+type CatalogServicesListResponse struct {
+	Data       []CatalogServiceResponse `json:"data"`
+	Pagination PaginationResponse       `json:"pagination"`
+}
+
+type CatalogSuggestionsResponse struct {
+	Query string                   `json:"query" example:"net"`
+	Data  []CatalogServiceResponse `json:"data"`
+}
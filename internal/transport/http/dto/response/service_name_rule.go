@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type ServiceNameRuleResponse struct {
+	ID        string    `json:"id" example:"5d3c2a1b-8f4e-4c6d-9a7b-1e2f3a4b5c6d"`
+	List      string    `json:"list" example:"deny"`
+	Match     string    `json:"match" example:"regex"`
+	Pattern   string    `json:"pattern" example:"(?:casino|viagra)"`
+	Reason    string    `json:"reason" example:"spam campaign 2025-01"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-15T10:30:00Z"`
+}
+
+type ServiceNameRulesListResponse struct {
+	Data []ServiceNameRuleResponse `json:"data"`
+}
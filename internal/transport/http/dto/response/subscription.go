@@ -3,14 +3,24 @@ package response
 import "time"
 
 type SubscriptionResponse struct {
-	ID          string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	ServiceName string    `json:"service_name" example:"Yandex Plus"`
-	Price       int       `json:"price" example:"400"`
-	UserID      string    `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
-	StartDate   string    `json:"start_date" example:"07-2025"`
-	EndDate     *string   `json:"end_date,omitempty" example:"12-2025"`
-	CreatedAt   time.Time `json:"created_at" example:"2025-01-15T10:30:00Z"`
-	UpdatedAt   time.Time `json:"updated_at" example:"2025-01-15T10:30:00Z"`
+	ID            string            `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ServiceName   string            `json:"service_name" example:"Yandex Plus"`
+	Price         int               `json:"price" example:"400"`
+	UserID        string            `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate     string            `json:"start_date" example:"07-2025"`
+	EndDate       *string           `json:"end_date,omitempty" example:"12-2025"`
+	DiscountID    *string           `json:"discount_id,omitempty" example:"5d3c2a1b-8f4e-4c6d-9a7b-1e2f3a4b5c6d"`
+	PlanID        *string           `json:"plan_id,omitempty" example:"9b2e4f60-3c1d-4a7e-8f5b-2d6c1e0a9b3f"`
+	CatalogID     *string           `json:"catalog_id,omitempty" example:"4f1c2a9e-7b3d-4e8a-9c5f-1a2b3c4d5e6f"`
+	Tags          []string          `json:"tags,omitempty" example:"entertainment,family"`
+	Category      *string           `json:"category,omitempty" example:"streaming"`
+	PaymentMethod *string           `json:"payment_method,omitempty" example:"Tinkoff *1234"`
+	BillingDay    *int              `json:"billing_day,omitempty" example:"15"`
+	Notes         string            `json:"notes,omitempty" example:"Shared with family"`
+	Metadata      map[string]string `json:"metadata,omitempty" example:"team:platform"`
+	CreatedAt     time.Time         `json:"created_at" example:"2025-01-15T10:30:00Z"`
+	UpdatedAt     time.Time         `json:"updated_at" example:"2025-01-15T10:30:00Z"`
+	Comments      []CommentResponse `json:"comments,omitempty"`
 }
 
 type SubscriptionsListResponse struct {
@@ -19,9 +29,63 @@ type SubscriptionsListResponse struct {
 }
 
 type CostSummaryResponse struct {
-	TotalCost int            `json:"total_cost" example:"2400"`
-	Period    PeriodResponse `json:"period"`
-	Currency  string         `json:"currency" example:"RUB"`
+	TotalCost   int            `json:"total_cost" example:"2400"`
+	GrossCost   int            `json:"gross_cost" example:"2700"`
+	Discount    int            `json:"discount" example:"300"`
+	Period      PeriodResponse `json:"period"`
+	BillingMode string         `json:"billing_mode" example:"monthly" enums:"monthly,prorated"`
+	Pricing     string         `json:"pricing" example:"current" enums:"current,historical"`
+	Currency    string         `json:"currency" example:"RUB"`
+	// Display — те же суммы и период для показа; только с ?locale=.
+	Display *CostDisplayResponse `json:"display,omitempty"`
+}
+
+// CostDisplayResponse — суммы и период, отформатированные по ?locale=.
+// Строки предназначены для показа, а не для разбора.
+type CostDisplayResponse struct {
+	Locale    string `json:"locale,omitempty" example:"ru"`
+	TotalCost string `json:"total_cost" example:"2 400 ₽"`
+	GrossCost string `json:"gross_cost,omitempty" example:"2 700 ₽"`
+	Discount  string `json:"discount,omitempty" example:"300 ₽"`
+	Period    string `json:"period,omitempty" example:"январь – июнь 2025"`
+}
+
+type CategoryCostResponse struct {
+	Category      string               `json:"category" example:"streaming"`
+	TotalCost     int                  `json:"total_cost" example:"1800"`
+	GrossCost     int                  `json:"gross_cost" example:"2000"`
+	Discount      int                  `json:"discount" example:"200"`
+	Subscriptions int                  `json:"subscriptions" example:"3"`
+	Display       *CostDisplayResponse `json:"display,omitempty"`
+}
+
+type CategoryCostReportResponse struct {
+	TotalCost   int                    `json:"total_cost" example:"5400"`
+	Period      PeriodResponse         `json:"period"`
+	BillingMode string                 `json:"billing_mode" example:"monthly" enums:"monthly,prorated"`
+	Pricing     string                 `json:"pricing" example:"current" enums:"current,historical"`
+	Currency    string                 `json:"currency" example:"RUB"`
+	Display     *CostDisplayResponse   `json:"display,omitempty"`
+	Categories  []CategoryCostResponse `json:"categories"`
+}
+
+type PaymentMethodCostResponse struct {
+	PaymentMethod *string              `json:"payment_method" example:"Tinkoff *1234"`
+	TotalCost     int                  `json:"total_cost" example:"1800"`
+	GrossCost     int                  `json:"gross_cost" example:"2000"`
+	Discount      int                  `json:"discount" example:"200"`
+	Subscriptions int                  `json:"subscriptions" example:"3"`
+	Display       *CostDisplayResponse `json:"display,omitempty"`
+}
+
+type PaymentMethodCostReportResponse struct {
+	TotalCost      int                         `json:"total_cost" example:"5400"`
+	Period         PeriodResponse              `json:"period"`
+	BillingMode    string                      `json:"billing_mode" example:"monthly" enums:"monthly,prorated"`
+	Pricing        string                      `json:"pricing" example:"current" enums:"current,historical"`
+	Currency       string                      `json:"currency" example:"RUB"`
+	Display        *CostDisplayResponse        `json:"display,omitempty"`
+	PaymentMethods []PaymentMethodCostResponse `json:"payment_methods"`
 }
 
 type PeriodResponse struct {
@@ -29,16 +93,138 @@ type PeriodResponse struct {
 	EndDate   string `json:"end_date" example:"06-2025"`
 }
 
+type CalendarResponse struct {
+	Year      int                     `json:"year" example:"2025"`
+	TotalCost int                     `json:"total_cost" example:"7188"`
+	Currency  string                  `json:"currency" example:"RUB"`
+	Months    []CalendarMonthResponse `json:"months"`
+}
+
+type CalendarMonthResponse struct {
+	Month         string                 `json:"month" example:"07-2025"`
+	TotalCost     int                    `json:"total_cost" example:"599"`
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+}
+
+type CostForecastResponse struct {
+	UserID    string                  `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	TotalCost int                     `json:"total_cost" example:"4794"`
+	Currency  string                  `json:"currency" example:"RUB"`
+	Months    []ForecastMonthResponse `json:"months"`
+}
+
+type ForecastMonthResponse struct {
+	Month               string                    `json:"month" example:"07-2025"`
+	TotalCost           int                       `json:"total_cost" example:"799"`
+	ActiveSubscriptions int                       `json:"active_subscriptions" example:"2"`
+	Starting            []SubscriptionRefResponse `json:"starting"`
+	Ending              []SubscriptionRefResponse `json:"ending"`
+}
+
+type BillingCalendarResponse struct {
+	UserID    string               `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	Month     string               `json:"month" example:"02-2025"`
+	TotalCost int                  `json:"total_cost" example:"1198"`
+	Currency  string               `json:"currency" example:"RUB"`
+	Days      []BillingDayResponse `json:"days"`
+}
+
+type BillingDayResponse struct {
+	Date          string                  `json:"date" example:"2025-02-28"`
+	TotalCost     int                     `json:"total_cost" example:"799"`
+	Subscriptions []BillingChargeResponse `json:"subscriptions"`
+}
+
+type BillingChargeResponse struct {
+	ID            string  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ServiceName   string  `json:"service_name" example:"Netflix Premium"`
+	Price         int     `json:"price" example:"799"`
+	BillingDay    *int    `json:"billing_day,omitempty" example:"31"`
+	PaymentMethod *string `json:"payment_method,omitempty" example:"Tinkoff *1234"`
+}
+
 type HealthResponse struct {
-	Status    string            `json:"status"`
-	Timestamp time.Time         `json:"timestamp"`
-	Services  map[string]string `json:"services"`
+	Status    string                `json:"status" enums:"healthy,degraded,unhealthy"`
+	Timestamp time.Time             `json:"timestamp"`
+	Services  map[string]string     `json:"services"`
+	Checks    []HealthCheckResponse `json:"checks"`
+	// Cached — отчёт взят из кэша (health.cache_ttl).
+	Cached bool `json:"cached"`
+	// Breakers — состояние автоматов защиты зависимостей (circuit_breaker).
+	Breakers map[string]string `json:"breakers,omitempty" example:"postgres:closed"`
+}
+
+type HealthCheckResponse struct {
+	Name      string  `json:"name" example:"postgres"`
+	Status    string  `json:"status" enums:"up,down"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms" example:"1.8"`
+	Error     string  `json:"error,omitempty"`
 }
 
 type StatsResponse struct {
-	TotalSubscriptions int `json:"total_subscriptions"`
+	TotalSubscriptions    int                       `json:"total_subscriptions" example:"5"`
+	ActiveSubscriptions   int                       `json:"active_subscriptions" example:"3"`
+	ExpiredSubscriptions  int                       `json:"expired_subscriptions" example:"1"`
+	UpcomingSubscriptions int                       `json:"upcoming_subscriptions" example:"1"`
+	MonthlySpend          int                       `json:"monthly_spend" example:"1997"`
+	AveragePrice          float64                   `json:"average_price" example:"519.8"`
+	Currency              string                    `json:"currency" example:"RUB"`
+	MostExpensive         *SubscriptionRefResponse  `json:"most_expensive"`
+	NextEnding            *SubscriptionRefResponse  `json:"next_ending"`
+	Alerts                []CostAlertStatusResponse `json:"alerts"`
+}
+
+type SubscriptionRefResponse struct {
+	ID          string  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ServiceName string  `json:"service_name" example:"Netflix Premium"`
+	Price       int     `json:"price" example:"799"`
+	EndDate     *string `json:"end_date,omitempty" example:"12-2025"`
 }
 
 type MessageResponse struct {
 	Message string `json:"message"`
 }
+
+type DeleteUserSubscriptionsResponse struct {
+	Message string `json:"message" example:"User subscriptions deleted successfully"`
+	Deleted int    `json:"deleted" example:"4"`
+}
+
+type PriceChangeResponse struct {
+	OldPrice      int       `json:"old_price" example:"400"`
+	NewPrice      int       `json:"new_price" example:"450"`
+	EffectiveFrom time.Time `json:"effective_from" example:"2025-03-10T00:00:00Z"`
+	ChangedAt     time.Time `json:"changed_at" example:"2025-03-10T14:25:00Z"`
+}
+
+type PriceHistoryResponse struct {
+	SubscriptionID string                `json:"subscription_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Data           []PriceChangeResponse `json:"data"`
+}
+
+// DuplicateSubscriptionsResponse — вероятные дубли среди подписок пользователя.
+type DuplicateSubscriptionsResponse struct {
+	UserID     string                          `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	Currency   string                          `json:"currency" example:"RUB"`
+	Duplicates []SubscriptionDuplicateResponse `json:"duplicates"`
+}
+
+type SubscriptionDuplicateResponse struct {
+	Reason         string                 `json:"reason" example:"same_service" enums:"same_service,similar_name"`
+	Similarity     float64                `json:"similarity" example:"0.67"`
+	OverlapStart   string                 `json:"overlap_start" example:"07-2025"`
+	OverlapEnd     *string                `json:"overlap_end" example:"12-2025"`
+	MonthlySavings int                    `json:"monthly_savings" example:"799"`
+	Subscriptions  []SubscriptionResponse `json:"subscriptions"`
+}
+
+type ExpiringSubscriptionsResponse struct {
+	WithinDays    int                            `json:"within_days" example:"30"`
+	Subscriptions []ExpiringSubscriptionResponse `json:"subscriptions"`
+}
+
+type ExpiringSubscriptionResponse struct {
+	SubscriptionResponse
+	DaysLeft int `json:"days_left" example:"12"`
+}
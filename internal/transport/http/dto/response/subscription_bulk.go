@@ -0,0 +1,31 @@
+package response
+
+// BulkUpdateResponse — итог изменения списком. applied=false — транзакция
+// откачена, ни одно изменение не сохранено.
+type BulkUpdateResponse struct {
+	Applied bool                     `json:"applied" example:"false"`
+	Results []BulkUpdateItemResponse `json:"results"`
+}
+
+// BulkUpdateItemResponse — итог элемента: updated, failed, rolled_back
+// (применился, но откачен из-за другого элемента) или skipped.
+type BulkUpdateItemResponse struct {
+	ID           string                `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status       string                `json:"status" example:"rolled_back" enums:"updated,failed,rolled_back,skipped"`
+	Subscription *SubscriptionResponse `json:"subscription,omitempty"`
+	Error        *BulkItemError        `json:"error,omitempty"`
+}
+
+type BulkItemError struct {
+	Code    string `json:"code" example:"SUBSCRIPTION_NOT_FOUND"`
+	Message string `json:"message" example:"Subscription not found"`
+}
+
+// BulkUpdateByFilterResponse — итог изменения по фильтру; при dry_run
+// updated=0, а subscription_ids — подписки, которые изменились бы.
+type BulkUpdateByFilterResponse struct {
+	DryRun          bool     `json:"dry_run" example:"true"`
+	Matched         int      `json:"matched" example:"42"`
+	Updated         int      `json:"updated" example:"0"`
+	SubscriptionIDs []string `json:"subscription_ids" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+// SubscriptionLimitResponse — действующий лимит пользователя. Source —
+// default (limits.max_subscriptions_per_user) или override (задан
+// администратором); reason и updated_at есть только у override.
+type SubscriptionLimitResponse struct {
+	UserID           string     `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	MaxSubscriptions int        `json:"max_subscriptions" example:"500"`
+	Unlimited        bool       `json:"unlimited" example:"false"`
+	Source           string     `json:"source" example:"override" enums:"default,override"`
+	Subscriptions    int        `json:"subscriptions" example:"42"`
+	Reason           string     `json:"reason,omitempty" example:"nightly import from the billing system"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty" example:"2025-01-15T10:30:00Z"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type SubscriptionMemberResponse struct {
+	SubscriptionID string    `json:"subscription_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID         string    `json:"user_id" example:"7a1c2d3e-4f50-4a6b-8c7d-9e0f1a2b3c4d"`
+	SharePercent   int       `json:"share_percent" example:"25"`
+	CreatedAt      time.Time `json:"created_at" example:"2025-01-15T10:30:00Z"`
+}
+
+type SubscriptionMembersResponse struct {
+	Data []SubscriptionMemberResponse `json:"data"`
+	// OwnerSharePercent — доля владельца: всё, что не разобрали участники.
+	OwnerSharePercent int `json:"owner_share_percent" example:"50"`
+}
@@ -0,0 +1,9 @@
+package response
+
+// VersionResponse — версия запущенной сборки (pkg/buildinfo).
+type VersionResponse struct {
+	Version   string `json:"version" example:"v1.4.0"`
+	Commit    string `json:"commit" example:"3f9c2a1d8e7b6c5a4f3e2d1c0b9a8f7e6d5c4b3a"`
+	BuildDate string `json:"build_date" example:"2025-07-15T10:30:00Z"`
+	GoVersion string `json:"go_version" example:"go1.23.4"`
+}
@@ -0,0 +1,33 @@
+// Package request содержит входные DTO API v2. Даты принимаются как YYYY-MM,
+// YYYY-MM-DD или MM-YYYY.
+package request
+
+type CreateSubscriptionRequest struct {
+	ServiceName   string            `json:"service_name,omitempty" binding:"required_without_all=PlanID CatalogID" example:"Yandex Plus" minLength:"1" maxLength:"255"`
+	Price         int               `json:"price,omitempty" binding:"required_without=PlanID,omitempty,min=1,max=1000000" example:"400"`
+	PlanID        string            `json:"plan_id,omitempty" binding:"omitempty,uuid4" example:"9b2e4f60-3c1d-4a7e-8f5b-2d6c1e0a9b3f"`
+	CatalogID     string            `json:"catalog_id,omitempty" binding:"omitempty,uuid4" example:"4f1c2a9e-7b3d-4e8a-9c5f-1a2b3c4d5e6f"`
+	UserID        string            `json:"user_id" binding:"required,uuid4" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate     string            `json:"start_date" binding:"required,monthyear" example:"2025-07"`
+	EndDate       string            `json:"end_date,omitempty" binding:"omitempty,monthyear" example:"2025-12"`
+	PromoCode     string            `json:"promo_code,omitempty" binding:"max=64" example:"SUMMER25" maxLength:"64"`
+	Tags          []string          `json:"tags,omitempty" example:"family,entertainment"`
+	Category      string            `json:"category,omitempty" example:"streaming" enums:"streaming,music,cloud,software,gaming,fitness,education,news,shopping,other"`
+	PaymentMethod string            `json:"payment_method,omitempty" example:"Tinkoff *1234" maxLength:"100"`
+	BillingDay    *int              `json:"billing_day,omitempty" binding:"omitempty,min=1,max=31" example:"15" minimum:"1" maximum:"31"`
+	Notes         string            `json:"notes,omitempty" example:"Shared with family" maxLength:"2000"`
+	Metadata      map[string]string `json:"metadata,omitempty" swaggertype:"object,string" example:"team:platform"`
+}
+
+type UpdateSubscriptionRequest struct {
+	ServiceName   *string            `json:"service_name,omitempty" example:"Netflix Premium" minLength:"1" maxLength:"255"`
+	Price         *int               `json:"price,omitempty" minimum:"1" maximum:"1000000" example:"799"`
+	StartDate     *string            `json:"start_date,omitempty" binding:"omitempty,monthyear" example:"2025-08"`
+	EndDate       *string            `json:"end_date,omitempty" binding:"omitempty,monthyear" example:"2025-12"`
+	Tags          *[]string          `json:"tags,omitempty" example:"family,entertainment"`
+	Category      *string            `json:"category,omitempty" example:"streaming" enums:"streaming,music,cloud,software,gaming,fitness,education,news,shopping,other"`
+	PaymentMethod *string            `json:"payment_method,omitempty" example:"Family Sharing" maxLength:"100"`
+	BillingDay    *int               `json:"billing_day,omitempty" binding:"omitempty,min=0,max=31" example:"15" minimum:"0" maximum:"31"`
+	Notes         *string            `json:"notes,omitempty" example:"Cancel before renewal" maxLength:"2000"`
+	Metadata      *map[string]string `json:"metadata,omitempty" swaggertype:"object,string" example:"team:platform"`
+}
@@ -0,0 +1,42 @@
+// Package response содержит выходные DTO API v2. Даты по умолчанию отдаются в ISO 8601
+// (YYYY-MM), заголовок Accept-Date-Format может это изменить.
+package response
+
+import (
+	"time"
+
+	v1 "github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+// Типы, не изменившиеся между версиями. v1 заморожена, поэтому псевдонимы безопасны.
+type (
+	PaginationResponse = v1.PaginationResponse
+	ErrorResponse      = v1.ErrorResponse
+	MessageResponse    = v1.MessageResponse
+)
+
+type SubscriptionResponse struct {
+	ID            string            `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ServiceName   string            `json:"service_name" example:"Yandex Plus"`
+	Price         int               `json:"price" example:"400"`
+	Currency      string            `json:"currency" example:"RUB"`
+	UserID        string            `json:"user_id" example:"60601fee-2bf1-4721-ae6f-7636e79a0cba"`
+	StartDate     string            `json:"start_date" example:"2025-07"`
+	EndDate       *string           `json:"end_date" example:"2025-12"`
+	DiscountID    *string           `json:"discount_id" example:"5d3c2a1b-8f4e-4c6d-9a7b-1e2f3a4b5c6d"`
+	PlanID        *string           `json:"plan_id" example:"9b2e4f60-3c1d-4a7e-8f5b-2d6c1e0a9b3f"`
+	CatalogID     *string           `json:"catalog_id" example:"4f1c2a9e-7b3d-4e8a-9c5f-1a2b3c4d5e6f"`
+	Tags          []string          `json:"tags" example:"entertainment,family"`
+	Category      *string           `json:"category" example:"streaming"`
+	PaymentMethod *string           `json:"payment_method" example:"Tinkoff *1234"`
+	BillingDay    *int              `json:"billing_day" example:"15"`
+	Notes         string            `json:"notes" example:"Shared with family"`
+	Metadata      map[string]string `json:"metadata" example:"team:platform"`
+	CreatedAt     time.Time         `json:"created_at" example:"2025-01-15T10:30:00Z"`
+	UpdatedAt     time.Time         `json:"updated_at" example:"2025-01-15T10:30:00Z"`
+}
+
+type SubscriptionsListResponse struct {
+	Data       []SubscriptionResponse `json:"data"`
+	Pagination PaginationResponse     `json:"pagination"`
+}
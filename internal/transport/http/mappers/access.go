@@ -0,0 +1,40 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+func APIKeyToResponse(key *models.APIKey) response.APIKeyResponse {
+	return response.APIKeyResponse{
+		ID:        key.ID().String(),
+		Name:      key.Name(),
+		Prefix:    key.Prefix(),
+		Role:      string(key.Role()),
+		CreatedAt: key.CreatedAt(),
+		RevokedAt: key.RevokedAt(),
+	}
+}
+
+func CreatedAPIKeyToResponse(key *models.APIKey, secret string) response.CreatedAPIKeyResponse {
+	return response.CreatedAPIKeyResponse{
+		APIKeyResponse: APIKeyToResponse(key),
+		Key:            secret,
+	}
+}
+
+func APIKeysToResponse(keys []*models.APIKey) response.APIKeysListResponse {
+	data := make([]response.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		data[i] = APIKeyToResponse(key)
+	}
+	return response.APIKeysListResponse{Data: data}
+}
+
+func PermissionsToStrings(permissions []models.Permission) []string {
+	result := make([]string, len(permissions))
+	for i, permission := range permissions {
+		result[i] = string(permission)
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/livestats"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+func ConfigConsistencyToResponse(report *models.ConfigConsistencyReport) response.ConfigConsistencyResponse {
+	local := report.Local()
+
+	instances := make([]response.ConfigInstanceResponse, len(report.Instances()))
+	for i, instance := range report.Instances() {
+		instances[i] = response.ConfigInstanceResponse{
+			InstanceID: instance.InstanceID(),
+			ConfigHash: instance.Hash(),
+			ReportedAt: instance.ReportedAt(),
+			Matches:    instance.Hash() == local.Hash(),
+		}
+	}
+
+	return response.ConfigConsistencyResponse{
+		Consistent: report.IsConsistent(),
+		InstanceID: local.InstanceID(),
+		ConfigHash: local.Hash(),
+		Instances:  instances,
+	}
+}
+
+func UserSpendReportToResponse(report *models.UserSpendReport, limit, offset int, format utils.DateFormat) response.UserSpendReportResponse {
+	period := report.Period()
+	page := report.Page(limit, offset)
+	total := len(report.Users())
+
+	users := make([]response.UserSpendResponse, len(page))
+	for i, spend := range page {
+		users[i] = response.UserSpendResponse{
+			UserID:        spend.UserID().String(),
+			TotalCost:     spend.TotalCost(),
+			Subscriptions: spend.Subscriptions(),
+		}
+	}
+
+	return response.UserSpendReportResponse{
+		Period: response.PeriodResponse{
+			StartDate: format.FormatStart(period.From()),
+			EndDate:   format.FormatEnd(period.To()),
+		},
+		BillingMode: string(report.BillingMode()),
+		TotalCost:   report.TotalCost(),
+		TotalUsers:  total,
+		Currency:    string(models.DefaultCurrency),
+		Users:       users,
+		Pagination:  response.NewPaginationResponse(limit, offset, &total),
+	}
+}
+
+func LiveStatsToResponse(snap livestats.Snapshot) response.LiveStatsResponse {
+	return response.LiveStatsResponse{
+		Timestamp:           snap.Timestamp.UTC(),
+		RequestsPerSecond:   snap.RequestsPerSecond,
+		CreatesPerMinute:    snap.CreatesPerMinute,
+		ActiveSubscriptions: snap.ActiveSubscriptions,
+		Streams:             snap.Streams,
+	}
+}
+
+func TopServicesToResponse(report *models.TopServicesReport, format utils.DateFormat) response.TopServicesResponse {
+	services := make([]response.ServiceStatsResponse, len(report.Services()))
+	for i, stats := range report.Services() {
+		services[i] = response.ServiceStatsResponse{
+			ServiceName:   stats.ServiceName(),
+			Subscribers:   stats.Subscribers(),
+			Subscriptions: stats.Subscriptions(),
+			Revenue:       stats.Revenue(),
+		}
+	}
+
+	return response.TopServicesResponse{
+		Period:      analyticsPeriodToResponse(report.Period(), format),
+		BillingMode: string(report.BillingMode()),
+		SortBy:      string(report.SortBy()),
+		Currency:    string(models.DefaultCurrency),
+		Services:    services,
+	}
+}
+
+func MRRToResponse(report *models.MRRReport, format utils.DateFormat) response.MRRResponse {
+	months := make([]response.MRRPointResponse, len(report.Points()))
+	for i, point := range report.Points() {
+		months[i] = response.MRRPointResponse{
+			Month:         format.Format(point.Month()),
+			MRR:           point.MRR(),
+			Subscriptions: point.Subscriptions(),
+		}
+	}
+
+	return response.MRRResponse{
+		Period:   analyticsPeriodToResponse(report.Period(), format),
+		Current:  report.Current(),
+		Change:   report.Change(),
+		Currency: string(models.DefaultCurrency),
+		Months:   months,
+	}
+}
+
+func ChurnToResponse(report *models.ChurnReport, format utils.DateFormat) response.ChurnResponse {
+	months := make([]response.ChurnPointResponse, len(report.Points()))
+	for i, point := range report.Points() {
+		months[i] = response.ChurnPointResponse{
+			Month:         format.Format(point.Month()),
+			ActiveAtStart: point.ActiveAtStart(),
+			Started:       point.Started(),
+			Churned:       point.Churned(),
+			Rate:          point.Rate(),
+		}
+	}
+
+	return response.ChurnResponse{
+		Period:       analyticsPeriodToResponse(report.Period(), format),
+		TotalChurned: report.TotalChurned(),
+		AverageRate:  report.AverageRate(),
+		Months:       months,
+	}
+}
+
+func analyticsPeriodToResponse(period models.DateRange, format utils.DateFormat) response.PeriodResponse {
+	return response.PeriodResponse{
+		StartDate: format.FormatStart(period.From()),
+		EndDate:   format.FormatEnd(period.To()),
+	}
+}
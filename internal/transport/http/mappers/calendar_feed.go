@@ -0,0 +1,60 @@
+package mappers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/ical"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+const calendarFeedProdID = "-//effective-mobile//subscription-service//RU"
+
+func CalendarFeedTokenToResponse(token *models.CalendarFeedToken, secret, url string) response.CalendarFeedResponse {
+	return response.CalendarFeedResponse{
+		UserID:    token.UserID().String(),
+		URL:       url,
+		Token:     secret,
+		CreatedAt: token.CreatedAt(),
+	}
+}
+
+// CalendarFeedToICal — лента в виде iCalendar: списание — «Netflix — 799 RUB»,
+// окончание — «Netflix ends». stamp — время формирования ленты.
+func CalendarFeedToICal(feed *models.CalendarFeed, stamp time.Time, ids publicid.Codec) (ical.Calendar, error) {
+	events := make([]ical.Event, len(feed.Events()))
+	for i, event := range feed.Events() {
+		subscription := event.Subscription()
+
+		summary := fmt.Sprintf("%s ends", subscription.ServiceName())
+		if event.Kind() == models.CalendarFeedCharge {
+			price, err := priceUnits(subscription.Price())
+			if err != nil {
+				return ical.Calendar{}, err
+			}
+			summary = fmt.Sprintf("%s — %d %s", subscription.ServiceName(), price, subscription.Price().Currency())
+		}
+
+		description := []string{"Subscription " + ids.Encode(subscription.ID())}
+		if method := subscription.PaymentMethod(); method != nil {
+			description = append(description, "Payment method: "+*method)
+		}
+
+		events[i] = ical.Event{
+			UID:         event.UID() + "@subscription-service",
+			Date:        event.Date(),
+			Summary:     summary,
+			Description: strings.Join(description, "\n"),
+			Stamp:       stamp,
+		}
+	}
+
+	return ical.Calendar{
+		ProdID: calendarFeedProdID,
+		Name:   "Subscriptions",
+		Events: events,
+	}, nil
+}
@@ -0,0 +1,27 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+func CanonicalServiceNameToResponse(entry *models.CanonicalServiceName) response.CanonicalServiceNameResponse {
+	aliases := entry.Aliases()
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return response.CanonicalServiceNameResponse{
+		ID:        entry.ID().String(),
+		Name:      entry.Name(),
+		Aliases:   aliases,
+		CreatedAt: entry.CreatedAt(),
+	}
+}
+
+func CanonicalServiceNamesToResponse(entries []*models.CanonicalServiceName) response.CanonicalServiceNamesListResponse {
+	data := make([]response.CanonicalServiceNameResponse, len(entries))
+	for i, entry := range entries {
+		data[i] = CanonicalServiceNameToResponse(entry)
+	}
+	return response.CanonicalServiceNamesListResponse{Data: data}
+}
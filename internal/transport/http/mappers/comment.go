@@ -0,0 +1,25 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+func CommentToResponse(comment *models.SubscriptionComment, ids publicid.Codec) response.CommentResponse {
+	return response.CommentResponse{
+		ID:             comment.ID().String(),
+		SubscriptionID: ids.Encode(comment.SubscriptionID()),
+		Author:         comment.Author(),
+		Body:           comment.Body(),
+		CreatedAt:      comment.CreatedAt(),
+	}
+}
+
+func CommentsToResponse(comments []*models.SubscriptionComment, ids publicid.Codec) []response.CommentResponse {
+	result := make([]response.CommentResponse, len(comments))
+	for i, comment := range comments {
+		result[i] = CommentToResponse(comment, ids)
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+func CostAlertToResponse(alert *models.CostAlert) response.CostAlertResponse {
+	return response.CostAlertResponse{
+		ID:           alert.ID().String(),
+		UserID:       alert.UserID().String(),
+		MonthlyLimit: alert.MonthlyLimit(),
+		Currency:     string(models.DefaultCurrency),
+		CreatedAt:    alert.CreatedAt(),
+	}
+}
+
+func CostAlertsToListResponse(alerts []*models.CostAlert) response.CostAlertsListResponse {
+	data := make([]response.CostAlertResponse, len(alerts))
+	for i, alert := range alerts {
+		data[i] = CostAlertToResponse(alert)
+	}
+	return response.CostAlertsListResponse{Data: data}
+}
+
+func costAlertStatusesToResponse(statuses []*models.CostAlertStatus, format utils.DateFormat) []response.CostAlertStatusResponse {
+	data := make([]response.CostAlertStatusResponse, len(statuses))
+	for i, status := range statuses {
+		data[i] = response.CostAlertStatusResponse{
+			ID:           status.Alert().ID().String(),
+			MonthlyLimit: status.Alert().MonthlyLimit(),
+			Month:        format.Format(status.Month()),
+			Spent:        status.Spent(),
+			Exceeded:     status.Exceeded(),
+			NotifiedAt:   status.NotifiedAt(),
+		}
+	}
+	return data
+}
@@ -0,0 +1,36 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+func DeadLetterToResponse(deadLetter *models.DeadLetter) response.DeadLetterResponse {
+	return response.DeadLetterResponse{
+		ID:           deadLetter.ID().String(),
+		Source:       deadLetter.Source(),
+		Topic:        deadLetter.Topic(),
+		EventID:      deadLetter.EventID().String(),
+		EventType:    deadLetter.EventType(),
+		Status:       string(deadLetter.Status()),
+		Error:        deadLetter.Error(),
+		Payload:      json.RawMessage(deadLetter.Payload()),
+		FailedAt:     deadLetter.FailedAt(),
+		RequeuedAt:   deadLetter.RequeuedAt(),
+		RequeueCount: deadLetter.RequeueCount(),
+	}
+}
+
+func DeadLettersToListResponse(deadLetters []*models.DeadLetter, limit, offset, total int) response.DeadLettersListResponse {
+	data := make([]response.DeadLetterResponse, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		data[i] = DeadLetterToResponse(deadLetter)
+	}
+
+	return response.DeadLettersListResponse{
+		Data:       data,
+		Pagination: response.NewPaginationResponse(limit, offset, &total),
+	}
+}
@@ -0,0 +1,34 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+func DiscountToResponse(discount *models.Discount, format utils.DateFormat) response.DiscountResponse {
+	resp := response.DiscountResponse{
+		ID:          discount.ID().String(),
+		Code:        discount.Code(),
+		Kind:        string(discount.Kind()),
+		Amount:      discount.Amount(),
+		ServiceName: discount.ServiceName(),
+		ValidFrom:   format.FormatStart(discount.ValidFrom()),
+		CreatedAt:   discount.CreatedAt(),
+	}
+
+	if discount.ValidTo() != nil {
+		validTo := format.FormatEnd(*discount.ValidTo())
+		resp.ValidTo = &validTo
+	}
+
+	return resp
+}
+
+func DiscountsToResponse(discounts []*models.Discount, format utils.DateFormat) response.DiscountsListResponse {
+	data := make([]response.DiscountResponse, len(discounts))
+	for i, discount := range discounts {
+		data[i] = DiscountToResponse(discount, format)
+	}
+	return response.DiscountsListResponse{Data: data}
+}
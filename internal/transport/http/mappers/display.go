@@ -0,0 +1,178 @@
+package mappers
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// displayLayout — то, чего нет в golang.org/x/text: названия месяцев,
+// порядок частей даты и место знака валюты.
+type displayLayout struct {
+	// months — месяц сам по себе, как в «январь – июнь 2025».
+	months [12]string
+	// monthsInDate — месяц в дате с днём: в русском родительный падеж.
+	monthsInDate [12]string
+	// dateFormat — дата с днём; аргументы: день, месяц, год.
+	dateFormat string
+	// currencyAfter — знак валюты после суммы: «2 400 ₽».
+	currencyAfter bool
+}
+
+var englishMonths = [12]string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+var displayLayouts = map[language.Tag]displayLayout{
+	language.English: {
+		months:       englishMonths,
+		monthsInDate: englishMonths,
+		dateFormat:   "%[2]s %[1]d, %[3]d",
+	},
+	language.Russian: {
+		months: [12]string{
+			"январь", "февраль", "март", "апрель", "май", "июнь",
+			"июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь",
+		},
+		monthsInDate: [12]string{
+			"января", "февраля", "марта", "апреля", "мая", "июня",
+			"июля", "августа", "сентября", "октября", "ноября", "декабря",
+		},
+		dateFormat:    "%[1]d %[2]s %[3]d",
+		currencyAfter: true,
+	},
+}
+
+// displayLocales — поддерживаемые локали; первая — для языков, которых нет.
+var displayLocales = []language.Tag{language.English, language.Russian}
+
+var displayMatcher = language.NewMatcher(displayLocales)
+
+const (
+	displayRangeSeparator = " – "
+	nonBreakingSpace      = "\u00a0"
+)
+
+/*
+DisplayLocale форматирует суммы и периоды для ответов, которые клиент
+показывает как есть (?locale=). Разделители разрядов и дробной части и
+обозначение валюты берутся из CLDR через golang.org/x/text, названия
+месяцев — из displayLayouts. Поддерживаются en и ru с региональными
+вариантами, остальные языки получают en.
+*/
+type DisplayLocale struct {
+	tag     language.Tag
+	printer *message.Printer
+	layout  displayLayout
+}
+
+/** Разбирает BCP 47 тег (ru, ru-RU, en-GB); некорректный тег — ошибка. */
+func ParseDisplayLocale(value string) (*DisplayLocale, error) {
+	requested, err := language.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid locale %q", value)
+	}
+
+	_, index, _ := displayMatcher.Match(requested)
+	tag := displayLocales[index]
+	return &DisplayLocale{
+		tag:     tag,
+		printer: message.NewPrinter(tag),
+		layout:  displayLayouts[tag],
+	}, nil
+}
+
+/** Выбранная локаль, например ru. */
+func (l *DisplayLocale) Tag() language.Tag {
+	return l.tag
+}
+
+/** Сумма в целых единицах валюты: «2 400 ₽», «RUB 2,400». */
+func (l *DisplayLocale) Money(units int, code models.Currency) string {
+	amount := l.printer.Sprint(number.Decimal(units))
+
+	symbol := string(code)
+	if unit, err := currency.ParseISO(string(code)); err == nil {
+		symbol = l.printer.Sprint(currency.Symbol(unit))
+	}
+
+	if l.layout.currencyAfter {
+		return amount + nonBreakingSpace + symbol
+	}
+	return symbol + nonBreakingSpace + amount
+}
+
+/*
+Period выводит период так же, как FormatStart и FormatEnd: целыми
+месяцами, если он начинается первого числа и заканчивается последним,
+иначе датами: «январь – июнь 2025», «June 2025», «15 января 2025 –
+20 июня 2025». Год у месяцев одного года пишется один раз.
+*/
+func (l *DisplayLocale) Period(period models.DateRange) string {
+	from := period.From()
+	if period.IsOpenEnded() {
+		return l.start(from) + " –"
+	}
+
+	to := period.To()
+	if !from.Equal(utils.StartOfMonth(from)) || !to.Equal(utils.EndOfMonth(to)) {
+		return l.date(from) + displayRangeSeparator + l.date(to)
+	}
+
+	switch {
+	case from.Year() == to.Year() && from.Month() == to.Month():
+		return l.month(to)
+	case from.Year() == to.Year():
+		return l.layout.months[from.Month()-1] + displayRangeSeparator + l.month(to)
+	default:
+		return l.month(from) + displayRangeSeparator + l.month(to)
+	}
+}
+
+func (l *DisplayLocale) start(t time.Time) string {
+	if t.Equal(utils.StartOfMonth(t)) {
+		return l.month(t)
+	}
+	return l.date(t)
+}
+
+func (l *DisplayLocale) month(t time.Time) string {
+	return fmt.Sprintf("%s %d", l.layout.months[t.Month()-1], t.Year())
+}
+
+func (l *DisplayLocale) date(t time.Time) string {
+	return fmt.Sprintf(l.layout.dateFormat, t.Day(), l.layout.monthsInDate[t.Month()-1], t.Year())
+}
+
+// costDisplay — суммы строки отчёта; nil без ?locale=.
+func costDisplay(locale *DisplayLocale, breakdown models.CostBreakdown) *response.CostDisplayResponse {
+	if locale == nil {
+		return nil
+	}
+	return &response.CostDisplayResponse{
+		TotalCost: locale.Money(breakdown.Net(), models.DefaultCurrency),
+		GrossCost: locale.Money(breakdown.Gross(), models.DefaultCurrency),
+		Discount:  locale.Money(breakdown.Discount(), models.DefaultCurrency),
+	}
+}
+
+// reportDisplay — итог и период отчёта; nil без ?locale=.
+func reportDisplay(locale *DisplayLocale, totalCost int, period models.DateRange) *response.CostDisplayResponse {
+	if locale == nil {
+		return nil
+	}
+	return &response.CostDisplayResponse{
+		Locale:    locale.Tag().String(),
+		TotalCost: locale.Money(totalCost, models.DefaultCurrency),
+		Period:    locale.Period(period),
+	}
+}
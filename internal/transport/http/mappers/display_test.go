@@ -0,0 +1,99 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func day(year int, m time.Month, d int) time.Time {
+	return time.Date(year, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDisplayLocale_Money(t *testing.T) {
+	tests := []struct {
+		locale string
+		units  int
+		want   string
+	}{
+		{"ru", 2400, "2\u00a0400\u00a0₽"},
+		{"ru-RU", 1234567, "1\u00a0234\u00a0567\u00a0₽"},
+		{"ru", 0, "0\u00a0₽"},
+		{"en", 2400, "RUB\u00a02,400"},
+		{"en-GB", 1234567, "RUB\u00a01,234,567"},
+		{"fr", 2400, "RUB\u00a02,400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			locale, err := ParseDisplayLocale(tt.locale)
+			if err != nil {
+				t.Fatalf("ParseDisplayLocale(%q) error = %v", tt.locale, err)
+			}
+			if got := locale.Money(tt.units, models.CurrencyRUB); got != tt.want {
+				t.Errorf("Money(%d) = %+q, want %+q", tt.units, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisplayLocale_Period(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		period models.DateRange
+		want   string
+	}{
+		{"months of one year", "ru", models.NewDateRange(month(2025, time.January), utils.EndOfMonth(month(2025, time.June))), "январь – июнь 2025"},
+		{"single month", "ru", models.NewDateRange(month(2025, time.June), utils.EndOfMonth(month(2025, time.June))), "июнь 2025"},
+		{"across years", "ru", models.NewDateRange(month(2024, time.December), utils.EndOfMonth(month(2025, time.February))), "декабрь 2024 – февраль 2025"},
+		{"days", "ru", models.NewDateRange(day(2025, time.January, 15), day(2025, time.June, 20)), "15 января 2025 – 20 июня 2025"},
+		{"english months", "en-US", models.NewDateRange(month(2025, time.January), utils.EndOfMonth(month(2025, time.June))), "January – June 2025"},
+		{"english days", "en", models.NewDateRange(day(2025, time.January, 15), day(2025, time.June, 20)), "January 15, 2025 – June 20, 2025"},
+		{"open-ended", "en", models.NewOpenDateRange(month(2025, time.March)), "March 2025 –"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, err := ParseDisplayLocale(tt.locale)
+			if err != nil {
+				t.Fatalf("ParseDisplayLocale(%q) error = %v", tt.locale, err)
+			}
+			if got := locale.Period(tt.period); got != tt.want {
+				t.Errorf("Period() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDisplayLocale(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "ru", want: "ru"},
+		{value: "ru-RU", want: "ru"},
+		{value: "en-GB", want: "en"},
+		{value: "de", want: "en"},
+		{value: "12345", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			locale, err := ParseDisplayLocale(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDisplayLocale(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if err == nil && locale.Tag().String() != tt.want {
+				t.Errorf("Tag() = %s, want %s", locale.Tag(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package mappers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+// listDataField — поле ответа-списка, к элементам которого применяется
+// выборка; остальные поля списка (pagination и т.п.) остаются как есть.
+const listDataField = "data"
+
+// FieldSet — поля ресурса, запрошенные через ?fields=. Пустой набор
+// означает «все поля».
+type FieldSet map[string]struct{}
+
+// ParseFieldSet разбирает "id,price,service_name" и проверяет имена по
+// json-тегам resource — нулевого значения DTO ресурса.
+func ParseFieldSet(raw string, resource interface{}) (FieldSet, error) {
+	fields := make(FieldSet)
+	if strings.TrimSpace(raw) == "" {
+		return fields, nil
+	}
+
+	known := jsonFieldsOf(reflect.TypeOf(resource))
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := known.index[name]; !ok {
+			return nil, apperror.InvalidInput("fields",
+				fmt.Sprintf("unknown field %q, allowed: %s", name, strings.Join(known.names, ",")))
+		}
+		fields[name] = struct{}{}
+	}
+	return fields, nil
+}
+
+func (f FieldSet) Add(name string) {
+	f[name] = struct{}{}
+}
+
+// String возвращает поля в каноническом порядке — для ключей кеша и ETag.
+func (f FieldSet) String() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+/*
+WithFields оставляет в ответе только запрошенные поля. Для ответов-списков
+выборка применяется к каждому элементу поля "data", для одиночных ресурсов —
+к полям верхнего уровня. При пустом наборе ответ возвращается без изменений.
+*/
+func WithFields(resp interface{}, fields FieldSet) interface{} {
+	if len(fields) == 0 {
+		return resp
+	}
+
+	value := reflect.ValueOf(resp)
+	meta := jsonFieldsOf(value.Type())
+	if i, ok := meta.index[listDataField]; ok && value.Field(i).Kind() == reflect.Slice {
+		out := selectFields(value, meta, nil)
+		items := value.Field(i)
+		data := make([]map[string]interface{}, items.Len())
+		for j := range data {
+			item := items.Index(j)
+			data[j] = selectFields(item, jsonFieldsOf(item.Type()), fields)
+		}
+		out[listDataField] = data
+		return out
+	}
+
+	return selectFields(value, meta, fields)
+}
+
+// selectFields переносит поля структуры в map с учётом omitempty;
+// fields == nil означает все поля.
+func selectFields(value reflect.Value, meta *jsonFields, fields FieldSet) map[string]interface{} {
+	out := make(map[string]interface{}, len(meta.names))
+	for _, name := range meta.names {
+		if fields != nil {
+			if _, ok := fields[name]; !ok {
+				continue
+			}
+		}
+		i := meta.index[name]
+		field := value.Field(i)
+		if meta.omitEmpty[i] && field.IsZero() {
+			continue
+		}
+		out[name] = field.Interface()
+	}
+	return out
+}
+
+type jsonFields struct {
+	names     []string
+	index     map[string]int
+	omitEmpty map[int]bool
+}
+
+var jsonFieldsCache sync.Map
+
+func jsonFieldsOf(t reflect.Type) *jsonFields {
+	if cached, ok := jsonFieldsCache.Load(t); ok {
+		return cached.(*jsonFields)
+	}
+
+	meta := &jsonFields{
+		index:     make(map[string]int),
+		omitEmpty: make(map[int]bool),
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		meta.names = append(meta.names, name)
+		meta.index[name] = i
+		meta.omitEmpty[i] = strings.Contains(options, "omitempty")
+	}
+
+	jsonFieldsCache.Store(t, meta)
+	return meta
+}
@@ -0,0 +1,66 @@
+package mappers
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+func PlanToResponse(plan *models.Plan) (response.PlanResponse, error) {
+	price, err := priceUnits(plan.Price())
+	if err != nil {
+		return response.PlanResponse{}, err
+	}
+	monthlyPrice, err := priceUnits(plan.MonthlyPrice())
+	if err != nil {
+		return response.PlanResponse{}, err
+	}
+
+	return response.PlanResponse{
+		ID:           plan.ID().String(),
+		Name:         plan.Name(),
+		ServiceName:  plan.ServiceName(),
+		Price:        price,
+		Currency:     string(plan.Price().Currency()),
+		BillingCycle: string(plan.BillingCycle()),
+		MonthlyPrice: monthlyPrice,
+		Features:     plan.Features(),
+		CreatedAt:    plan.CreatedAt(),
+		UpdatedAt:    plan.UpdatedAt(),
+	}, nil
+}
+
+func PlansToListResponse(plans []*models.Plan, pagination response.PaginationResponse) (response.PlansListResponse, error) {
+	data := make([]response.PlanResponse, len(plans))
+	for i, plan := range plans {
+		resp, err := PlanToResponse(plan)
+		if err != nil {
+			return response.PlansListResponse{}, err
+		}
+		data[i] = resp
+	}
+	return response.PlansListResponse{
+		Data:       data,
+		Pagination: pagination,
+	}, nil
+}
+
+func PlanPricesToResponse(planID uuid.UUID, prices []*models.PlanPrice) (response.PlanPriceHistoryResponse, error) {
+	data := make([]response.PlanPriceResponse, len(prices))
+	for i, price := range prices {
+		amount, err := priceUnits(price.Price())
+		if err != nil {
+			return response.PlanPriceHistoryResponse{}, err
+		}
+		data[i] = response.PlanPriceResponse{
+			Price:         amount,
+			BillingCycle:  string(price.BillingCycle()),
+			EffectiveFrom: price.EffectiveFrom(),
+		}
+	}
+	return response.PlanPriceHistoryResponse{
+		PlanID: planID.String(),
+		Data:   data,
+	}, nil
+}
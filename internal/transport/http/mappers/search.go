@@ -0,0 +1,38 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+func SearchHitsToResponse(query string, hits []*models.SubscriptionSearchHit, pagination response.PaginationResponse, format utils.DateFormat, ids publicid.Codec) (response.SubscriptionSearchResponse, error) {
+	data := make([]response.SubscriptionSearchHitResponse, len(hits))
+	for i, hit := range hits {
+		subscription, err := SubscriptionToResponse(hit.Subscription(), format, ids)
+		if err != nil {
+			return response.SubscriptionSearchResponse{}, err
+		}
+
+		highlights := make([]response.SearchHighlightResponse, len(hit.Highlights()))
+		for j, highlight := range hit.Highlights() {
+			highlights[j] = response.SearchHighlightResponse{
+				Field:    highlight.Field(),
+				Fragment: highlight.Fragment(),
+			}
+		}
+
+		data[i] = response.SubscriptionSearchHitResponse{
+			Subscription: subscription,
+			Rank:         hit.Rank(),
+			Highlights:   highlights,
+		}
+	}
+
+	return response.SubscriptionSearchResponse{
+		Query:      query,
+		Data:       data,
+		Pagination: pagination,
+	}, nil
+}
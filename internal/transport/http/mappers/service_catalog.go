@@ -0,0 +1,45 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+func CatalogServiceToResponse(service *models.CatalogService) response.CatalogServiceResponse {
+	resp := response.CatalogServiceResponse{
+		ID:           service.ID().String(),
+		Name:         service.Name(),
+		Website:      service.Website(),
+		IconURL:      service.IconURL(),
+		TypicalPrice: service.TypicalPrice(),
+		CreatedAt:    service.CreatedAt(),
+		UpdatedAt:    service.UpdatedAt(),
+	}
+	if service.Category() != nil {
+		category := string(*service.Category())
+		resp.Category = &category
+	}
+	return resp
+}
+
+func catalogServicesToResponse(services []*models.CatalogService) []response.CatalogServiceResponse {
+	data := make([]response.CatalogServiceResponse, len(services))
+	for i, service := range services {
+		data[i] = CatalogServiceToResponse(service)
+	}
+	return data
+}
+
+func CatalogServicesToListResponse(services []*models.CatalogService, pagination response.PaginationResponse) response.CatalogServicesListResponse {
+	return response.CatalogServicesListResponse{
+		Data:       catalogServicesToResponse(services),
+		Pagination: pagination,
+	}
+}
+
+func CatalogSuggestionsToResponse(query string, services []*models.CatalogService) response.CatalogSuggestionsResponse {
+	return response.CatalogSuggestionsResponse{
+		Query: query,
+		Data:  catalogServicesToResponse(services),
+	}
+}
@@ -0,0 +1,25 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+func ServiceNameRuleToResponse(rule *models.ServiceNameRule) response.ServiceNameRuleResponse {
+	return response.ServiceNameRuleResponse{
+		ID:        rule.ID().String(),
+		List:      string(rule.List()),
+		Match:     string(rule.Match()),
+		Pattern:   rule.Pattern(),
+		Reason:    rule.Reason(),
+		CreatedAt: rule.CreatedAt(),
+	}
+}
+
+func ServiceNameRulesToResponse(rules []*models.ServiceNameRule) response.ServiceNameRulesListResponse {
+	data := make([]response.ServiceNameRuleResponse, len(rules))
+	for i, rule := range rules {
+		data[i] = ServiceNameRuleToResponse(rule)
+	}
+	return response.ServiceNameRulesListResponse{Data: data}
+}
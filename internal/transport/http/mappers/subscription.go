@@ -1,55 +1,389 @@
 package mappers
 
 import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
 	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
 	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
 )
 
-func SubscriptionToResponse(subscription *models.Subscription) response.SubscriptionResponse {
+// priceUnits — цена для поля price в целых рублях. Цены подписок и тарифов
+// копеек не содержат, поэтому ошибка здесь — внутренняя, а не ошибка ввода.
+func priceUnits(price models.Money) (int, error) {
+	units, err := price.WholeUnits()
+	if err != nil {
+		return 0, apperror.InternalError("failed to map price", err)
+	}
+	return units, nil
+}
+
+func SubscriptionToResponse(subscription *models.Subscription, format utils.DateFormat, ids publicid.Codec) (response.SubscriptionResponse, error) {
+	price, err := priceUnits(subscription.Price())
+	if err != nil {
+		return response.SubscriptionResponse{}, err
+	}
+
 	resp := response.SubscriptionResponse{
-		ID:          subscription.ID().String(),
-		ServiceName: subscription.ServiceName(),
-		Price:       subscription.Price(),
-		UserID:      subscription.UserID().String(),
-		StartDate:   utils.FormatMonthYear(subscription.StartDate()),
-		CreatedAt:   subscription.CreatedAt(),
-		UpdatedAt:   subscription.UpdatedAt(),
+		ID:            ids.Encode(subscription.ID()),
+		ServiceName:   subscription.ServiceName(),
+		Price:         price,
+		UserID:        subscription.UserID().String(),
+		StartDate:     format.FormatStart(subscription.StartDate()),
+		Tags:          subscription.Tags(),
+		PaymentMethod: subscription.PaymentMethod(),
+		BillingDay:    subscription.BillingDay(),
+		Notes:         subscription.Notes(),
+		Metadata:      subscription.Metadata(),
+		CreatedAt:     subscription.CreatedAt(),
+		UpdatedAt:     subscription.UpdatedAt(),
 	}
 
 	if subscription.EndDate() != nil {
-		endDate := utils.FormatMonthYear(*subscription.EndDate())
+		endDate := format.FormatEnd(*subscription.EndDate())
 		resp.EndDate = &endDate
 	}
 
-	return resp
+	if subscription.DiscountID() != nil {
+		discountID := subscription.DiscountID().String()
+		resp.DiscountID = &discountID
+	}
+
+	if subscription.PlanID() != nil {
+		planID := subscription.PlanID().String()
+		resp.PlanID = &planID
+	}
+
+	if subscription.CatalogID() != nil {
+		catalogID := subscription.CatalogID().String()
+		resp.CatalogID = &catalogID
+	}
+
+	if subscription.Category() != nil {
+		category := string(*subscription.Category())
+		resp.Category = &category
+	}
+
+	return resp, nil
 }
 
-func SubscriptionsToListResponse(subscriptions []*models.Subscription, pagination response.PaginationResponse) response.SubscriptionsListResponse {
-	data := make([]response.SubscriptionResponse, len(subscriptions))
-	for i, subscription := range subscriptions {
-		data[i] = SubscriptionToResponse(subscription)
+func SubscriptionsToListResponse(subscriptions []*models.Subscription, pagination response.PaginationResponse, format utils.DateFormat, ids publicid.Codec) (response.SubscriptionsListResponse, error) {
+	data, err := subscriptionsToResponse(subscriptions, format, ids)
+	if err != nil {
+		return response.SubscriptionsListResponse{}, err
 	}
 
 	return response.SubscriptionsListResponse{
 		Data:       data,
 		Pagination: pagination,
+	}, nil
+}
+
+func subscriptionsToResponse(subscriptions []*models.Subscription, format utils.DateFormat, ids publicid.Codec) ([]response.SubscriptionResponse, error) {
+	data := make([]response.SubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		resp, err := SubscriptionToResponse(subscription, format, ids)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = resp
 	}
+	return data, nil
 }
 
-func CostSummaryToResponse(summary *models.CostSummary) response.CostSummaryResponse {
+func ExpiringSubscriptionsToResponse(subscriptions []*models.Subscription, withinDays int, at time.Time, format utils.DateFormat, ids publicid.Codec) (response.ExpiringSubscriptionsResponse, error) {
+	data := make([]response.ExpiringSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		resp, err := SubscriptionToResponse(subscription, format, ids)
+		if err != nil {
+			return response.ExpiringSubscriptionsResponse{}, err
+		}
+		daysLeft, _ := subscription.DaysUntilEnd(at)
+		data[i] = response.ExpiringSubscriptionResponse{
+			SubscriptionResponse: resp,
+			DaysLeft:             daysLeft,
+		}
+	}
+
+	return response.ExpiringSubscriptionsResponse{
+		WithinDays:    withinDays,
+		Subscriptions: data,
+	}, nil
+}
+
+func DuplicateSubscriptionsToResponse(userID uuid.UUID, duplicates []*models.SubscriptionDuplicate, format utils.DateFormat, ids publicid.Codec) (response.DuplicateSubscriptionsResponse, error) {
+	data := make([]response.SubscriptionDuplicateResponse, len(duplicates))
+	for i, duplicate := range duplicates {
+		savings, err := priceUnits(duplicate.MonthlySavings())
+		if err != nil {
+			return response.DuplicateSubscriptionsResponse{}, err
+		}
+		pair, err := subscriptionsToResponse([]*models.Subscription{duplicate.First(), duplicate.Second()}, format, ids)
+		if err != nil {
+			return response.DuplicateSubscriptionsResponse{}, err
+		}
+
+		from, to := duplicate.Overlap()
+		var overlapEnd *string
+		if to != nil {
+			formatted := format.FormatEnd(*to)
+			overlapEnd = &formatted
+		}
+		data[i] = response.SubscriptionDuplicateResponse{
+			Reason:         string(duplicate.Reason()),
+			Similarity:     math.Round(duplicate.Similarity()*100) / 100,
+			OverlapStart:   format.FormatStart(from),
+			OverlapEnd:     overlapEnd,
+			MonthlySavings: savings,
+			Subscriptions:  pair,
+		}
+	}
+
+	return response.DuplicateSubscriptionsResponse{
+		UserID:     userID.String(),
+		Currency:   string(models.DefaultCurrency),
+		Duplicates: data,
+	}, nil
+}
+
+// CostSummaryToResponse — locale задаёт display; nil — без него.
+func CostSummaryToResponse(summary *models.CostSummary, format utils.DateFormat, locale *DisplayLocale) response.CostSummaryResponse {
 	period := summary.Period()
-	return response.CostSummaryResponse{
+	resp := response.CostSummaryResponse{
 		TotalCost: summary.TotalCost(),
+		GrossCost: summary.GrossCost(),
+		Discount:  summary.Discount(),
+		Period: response.PeriodResponse{
+			StartDate: format.FormatStart(period.From()),
+			EndDate:   format.FormatEnd(period.To()),
+		},
+		BillingMode: string(summary.BillingMode()),
+		Pricing:     string(summary.PricingMode()),
+		Currency:    string(models.DefaultCurrency),
+	}
+
+	if locale != nil {
+		resp.Display = &response.CostDisplayResponse{
+			Locale:    locale.Tag().String(),
+			TotalCost: locale.Money(summary.TotalCost(), models.DefaultCurrency),
+			GrossCost: locale.Money(summary.GrossCost(), models.DefaultCurrency),
+			Discount:  locale.Money(summary.Discount(), models.DefaultCurrency),
+			Period:    locale.Period(period),
+		}
+	}
+
+	return resp
+}
+
+func CategoryCostReportToResponse(report *models.CategoryCostReport, format utils.DateFormat, locale *DisplayLocale) response.CategoryCostReportResponse {
+	categories := make([]response.CategoryCostResponse, len(report.Categories()))
+	for i, category := range report.Categories() {
+		breakdown := category.Breakdown()
+		categories[i] = response.CategoryCostResponse{
+			Category:      string(category.Category()),
+			TotalCost:     breakdown.Net(),
+			GrossCost:     breakdown.Gross(),
+			Discount:      breakdown.Discount(),
+			Subscriptions: category.Subscriptions(),
+			Display:       costDisplay(locale, breakdown),
+		}
+	}
+
+	period := report.Period()
+	return response.CategoryCostReportResponse{
+		TotalCost: report.TotalCost(),
+		Period: response.PeriodResponse{
+			StartDate: format.FormatStart(period.From()),
+			EndDate:   format.FormatEnd(period.To()),
+		},
+		BillingMode: string(report.BillingMode()),
+		Pricing:     string(report.PricingMode()),
+		Currency:    string(models.DefaultCurrency),
+		Display:     reportDisplay(locale, report.TotalCost(), period),
+		Categories:  categories,
+	}
+}
+
+func PaymentMethodCostReportToResponse(report *models.PaymentMethodCostReport, format utils.DateFormat, locale *DisplayLocale) response.PaymentMethodCostReportResponse {
+	methods := make([]response.PaymentMethodCostResponse, len(report.PaymentMethods()))
+	for i, method := range report.PaymentMethods() {
+		breakdown := method.Breakdown()
+		methods[i] = response.PaymentMethodCostResponse{
+			PaymentMethod: method.PaymentMethod(),
+			TotalCost:     breakdown.Net(),
+			GrossCost:     breakdown.Gross(),
+			Discount:      breakdown.Discount(),
+			Subscriptions: method.Subscriptions(),
+			Display:       costDisplay(locale, breakdown),
+		}
+	}
+
+	period := report.Period()
+	return response.PaymentMethodCostReportResponse{
+		TotalCost: report.TotalCost(),
 		Period: response.PeriodResponse{
-			StartDate: utils.FormatMonthYear(period.From()),
-			EndDate:   utils.FormatMonthYear(period.To()),
+			StartDate: format.FormatStart(period.From()),
+			EndDate:   format.FormatEnd(period.To()),
 		},
-		Currency: "RUB",
+		BillingMode:    string(report.BillingMode()),
+		Pricing:        string(report.PricingMode()),
+		Currency:       string(models.DefaultCurrency),
+		Display:        reportDisplay(locale, report.TotalCost(), period),
+		PaymentMethods: methods,
+	}
+}
+
+func UserStatsToResponse(stats *models.UserSubscriptionStats, alerts []*models.CostAlertStatus, format utils.DateFormat, ids publicid.Codec) (response.StatsResponse, error) {
+	mostExpensive, err := subscriptionRefToResponse(stats.MostExpensive(), format, ids)
+	if err != nil {
+		return response.StatsResponse{}, err
+	}
+	nextEnding, err := subscriptionRefToResponse(stats.NextEnding(), format, ids)
+	if err != nil {
+		return response.StatsResponse{}, err
+	}
+
+	return response.StatsResponse{
+		TotalSubscriptions:    stats.Total(),
+		ActiveSubscriptions:   stats.Active(),
+		ExpiredSubscriptions:  stats.Expired(),
+		UpcomingSubscriptions: stats.Upcoming(),
+		MonthlySpend:          stats.MonthlySpend(),
+		AveragePrice:          stats.AveragePrice(),
+		Currency:              string(models.DefaultCurrency),
+		MostExpensive:         mostExpensive,
+		NextEnding:            nextEnding,
+		Alerts:                costAlertStatusesToResponse(alerts, format),
+	}, nil
+}
+
+func subscriptionRefToResponse(ref *models.SubscriptionRef, format utils.DateFormat, ids publicid.Codec) (*response.SubscriptionRefResponse, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	price, err := priceUnits(ref.Price())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.SubscriptionRefResponse{
+		ID:          ids.Encode(ref.ID()),
+		ServiceName: ref.ServiceName(),
+		Price:       price,
+	}
+	if ref.EndDate() != nil {
+		endDate := format.FormatEnd(*ref.EndDate())
+		resp.EndDate = &endDate
 	}
+	return resp, nil
 }
 
-func SubscriptionFilterFromRequest(userID *string, serviceName *string, startDate *string, endDate *string) (*models.SubscriptionFilter, error) {
+func CalendarToResponse(calendar *models.SubscriptionCalendar, format utils.DateFormat, ids publicid.Codec) (response.CalendarResponse, error) {
+	months := make([]response.CalendarMonthResponse, len(calendar.Months()))
+	for i, month := range calendar.Months() {
+		subscriptions, err := subscriptionsToResponse(month.Subscriptions(), format, ids)
+		if err != nil {
+			return response.CalendarResponse{}, err
+		}
+
+		months[i] = response.CalendarMonthResponse{
+			Month:         format.Format(month.Month()),
+			TotalCost:     month.TotalCost(),
+			Subscriptions: subscriptions,
+		}
+	}
+
+	return response.CalendarResponse{
+		Year:      calendar.Year(),
+		TotalCost: calendar.TotalCost(),
+		Currency:  string(models.DefaultCurrency),
+		Months:    months,
+	}, nil
+}
+
+func CostForecastToResponse(userID uuid.UUID, forecast *models.CostForecast, format utils.DateFormat, ids publicid.Codec) (response.CostForecastResponse, error) {
+	months := make([]response.ForecastMonthResponse, len(forecast.Months()))
+	for i, month := range forecast.Months() {
+		starting, err := subscriptionRefsToResponse(month.Starting(), format, ids)
+		if err != nil {
+			return response.CostForecastResponse{}, err
+		}
+		ending, err := subscriptionRefsToResponse(month.Ending(), format, ids)
+		if err != nil {
+			return response.CostForecastResponse{}, err
+		}
+
+		months[i] = response.ForecastMonthResponse{
+			Month:               format.Format(month.Month()),
+			TotalCost:           month.TotalCost(),
+			ActiveSubscriptions: len(month.Subscriptions()),
+			Starting:            starting,
+			Ending:              ending,
+		}
+	}
+
+	return response.CostForecastResponse{
+		UserID:    userID.String(),
+		TotalCost: forecast.TotalCost(),
+		Currency:  string(models.DefaultCurrency),
+		Months:    months,
+	}, nil
+}
+
+func BillingCalendarToResponse(userID uuid.UUID, calendar *models.BillingCalendar, format utils.DateFormat, ids publicid.Codec) (response.BillingCalendarResponse, error) {
+	days := make([]response.BillingDayResponse, len(calendar.Days()))
+	for i, day := range calendar.Days() {
+		charges := make([]response.BillingChargeResponse, len(day.Subscriptions()))
+		for j, subscription := range day.Subscriptions() {
+			price, err := priceUnits(subscription.Price())
+			if err != nil {
+				return response.BillingCalendarResponse{}, err
+			}
+			charges[j] = response.BillingChargeResponse{
+				ID:            ids.Encode(subscription.ID()),
+				ServiceName:   subscription.ServiceName(),
+				Price:         price,
+				BillingDay:    subscription.BillingDay(),
+				PaymentMethod: subscription.PaymentMethod(),
+			}
+		}
+		days[i] = response.BillingDayResponse{
+			Date:          day.Date().Format(utils.ISODateLayout),
+			TotalCost:     day.TotalCost(),
+			Subscriptions: charges,
+		}
+	}
+
+	return response.BillingCalendarResponse{
+		UserID:    userID.String(),
+		Month:     format.Format(calendar.Month()),
+		TotalCost: calendar.TotalCost(),
+		Currency:  string(models.DefaultCurrency),
+		Days:      days,
+	}, nil
+}
+
+func subscriptionRefsToResponse(subscriptions []*models.Subscription, format utils.DateFormat, ids publicid.Codec) ([]response.SubscriptionRefResponse, error) {
+	refs := make([]response.SubscriptionRefResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		ref := models.NewSubscriptionRef(subscription.ID(), subscription.ServiceName(), subscription.Price(), subscription.EndDate())
+		resp, err := subscriptionRefToResponse(ref, format, ids)
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = *resp
+	}
+	return refs, nil
+}
+
+func SubscriptionFilterFromRequest(userID *string, serviceName *string, startDate *string, endDate *string, tags *string, category *string, metadata map[string]string) (*models.SubscriptionFilter, error) {
 	filter := models.NewSubscriptionFilter()
 
 	if userID != nil && *userID != "" {
@@ -61,27 +395,64 @@ func SubscriptionFilterFromRequest(userID *string, serviceName *string, startDat
 	}
 
 	if serviceName != nil && *serviceName != "" {
-		normalized := utils.NormalizeString(*serviceName)
+		normalized := utils.SanitizeText(*serviceName)
 		filter.SetServiceName(&normalized)
 	}
 
 	if startDate != nil && *startDate != "" {
-		start, err := utils.ParseMonthYear(*startDate)
+		start, err := utils.ParseStartDate(*startDate)
 		if err != nil {
 			return nil, err
 		}
-		start = utils.StartOfMonth(start)
 		filter.SetStartDate(&start)
 	}
 
 	if endDate != nil && *endDate != "" {
-		end, err := utils.ParseMonthYear(*endDate)
+		end, err := utils.ParseEndDate(*endDate)
 		if err != nil {
 			return nil, err
 		}
-		end = utils.EndOfMonth(end)
 		filter.SetEndDate(&end)
 	}
 
+	if tags != nil && *tags != "" {
+		normalized, err := models.NormalizeTags(strings.Split(*tags, ","))
+		if err != nil {
+			return nil, apperror.InvalidInput("tags", err.Error())
+		}
+		filter.SetTags(normalized)
+	}
+
+	if category != nil && *category != "" {
+		parsed, err := models.ParseSubscriptionCategory(*category)
+		if err != nil {
+			return nil, apperror.InvalidInput("category", err.Error())
+		}
+		filter.SetCategory(&parsed)
+	}
+
+	if len(metadata) > 0 {
+		if err := models.ValidateMetadata(metadata); err != nil {
+			return nil, apperror.InvalidInput("metadata", err.Error())
+		}
+		filter.SetMetadata(metadata)
+	}
+
 	return filter, nil
 }
+
+func PriceHistoryToResponse(subscriptionID uuid.UUID, history []*models.PriceChange, ids publicid.Codec) response.PriceHistoryResponse {
+	data := make([]response.PriceChangeResponse, len(history))
+	for i, change := range history {
+		data[i] = response.PriceChangeResponse{
+			OldPrice:      change.OldPrice(),
+			NewPrice:      change.NewPrice(),
+			EffectiveFrom: change.EffectiveFrom(),
+			ChangedAt:     change.ChangedAt(),
+		}
+	}
+	return response.PriceHistoryResponse{
+		SubscriptionID: ids.Encode(subscriptionID),
+		Data:           data,
+	}
+}
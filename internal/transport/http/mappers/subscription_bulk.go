@@ -0,0 +1,81 @@
+package mappers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/request"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+func SubscriptionPatchesFromRequest(req request.BulkUpdateSubscriptionsRequest, ids publicid.Codec) ([]*models.SubscriptionPatch, error) {
+	patches := make([]*models.SubscriptionPatch, len(req.Items))
+	for i, item := range req.Items {
+		id, err := ids.Decode(item.ID)
+		if err != nil {
+			return nil, apperror.InvalidInput(fmt.Sprintf("items[%d].id", i), err.Error())
+		}
+		patches[i] = SubscriptionPatchFromRequest(id, item.UpdateSubscriptionRequest)
+	}
+	return patches, nil
+}
+
+func SubscriptionPatchFromRequest(id uuid.UUID, req request.UpdateSubscriptionRequest) *models.SubscriptionPatch {
+	return models.NewSubscriptionPatch(id, req.ServiceName, req.Price, req.StartDate, req.EndDate,
+		req.Tags, req.Category, req.PaymentMethod, req.BillingDay, req.Notes, req.Metadata)
+}
+
+// BulkFilterFromRequest строит фильтр теми же правилами, что и у списка
+// подписок; теги передаются массивом, а не через запятую.
+func BulkFilterFromRequest(filter request.BulkUpdateFilter) (*models.SubscriptionFilter, error) {
+	var tags *string
+	if len(filter.Tags) > 0 {
+		joined := strings.Join(filter.Tags, ",")
+		tags = &joined
+	}
+	return SubscriptionFilterFromRequest(filter.UserID, filter.ServiceName, nil, nil, tags, filter.Category, filter.Metadata)
+}
+
+func BulkUpdateResultToResponse(result *models.BulkUpdateResult, format utils.DateFormat, ids publicid.Codec) (response.BulkUpdateResponse, error) {
+	results := make([]response.BulkUpdateItemResponse, len(result.Items()))
+	for i, item := range result.Items() {
+		resp := response.BulkUpdateItemResponse{
+			ID:     ids.Encode(item.ID()),
+			Status: string(item.Status()),
+		}
+		if subscription := item.Subscription(); subscription != nil {
+			sub, err := SubscriptionToResponse(subscription, format, ids)
+			if err != nil {
+				return response.BulkUpdateResponse{}, err
+			}
+			resp.Subscription = &sub
+		}
+		if item.Status() == models.BulkItemFailed {
+			resp.Error = &response.BulkItemError{Code: item.ErrorCode(), Message: item.ErrorMessage()}
+		}
+		results[i] = resp
+	}
+	return response.BulkUpdateResponse{
+		Applied: result.Applied(),
+		Results: results,
+	}, nil
+}
+
+func BulkFilterUpdateResultToResponse(result *models.BulkFilterUpdateResult, ids publicid.Codec) response.BulkUpdateByFilterResponse {
+	encoded := make([]string, len(result.SubscriptionIDs()))
+	for i, id := range result.SubscriptionIDs() {
+		encoded[i] = ids.Encode(id)
+	}
+	return response.BulkUpdateByFilterResponse{
+		DryRun:          result.DryRun(),
+		Matched:         result.Matched(),
+		Updated:         result.Updated(),
+		SubscriptionIDs: encoded,
+	}
+}
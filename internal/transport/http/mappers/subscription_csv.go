@@ -0,0 +1,68 @@
+package mappers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// SubscriptionCSVHeader — колонки выгрузки подписок в CSV.
+var SubscriptionCSVHeader = []string{
+	"id", "service_name", "price", "user_id", "start_date", "end_date",
+	"category", "tags", "plan_id", "discount_id", "created_at", "updated_at",
+}
+
+// SubscriptionToCSVRecord — строка выгрузки в порядке SubscriptionCSVHeader.
+// Теги перечисляются через ";", пустое поле — значение не задано.
+func SubscriptionToCSVRecord(subscription *models.Subscription, format utils.DateFormat, ids publicid.Codec) ([]string, error) {
+	price, err := priceUnits(subscription.Price())
+	if err != nil {
+		return nil, err
+	}
+
+	record := []string{
+		ids.Encode(subscription.ID()),
+		subscription.ServiceName(),
+		strconv.Itoa(price),
+		subscription.UserID().String(),
+		format.FormatStart(subscription.StartDate()),
+		"",
+		"",
+		strings.Join(subscription.Tags(), ";"),
+		"",
+		"",
+		subscription.CreatedAt().UTC().Format(time.RFC3339),
+		subscription.UpdatedAt().UTC().Format(time.RFC3339),
+	}
+
+	if subscription.EndDate() != nil {
+		record[5] = format.FormatEnd(*subscription.EndDate())
+	}
+	if subscription.Category() != nil {
+		record[6] = string(*subscription.Category())
+	}
+	if subscription.PlanID() != nil {
+		record[8] = subscription.PlanID().String()
+	}
+	if subscription.DiscountID() != nil {
+		record[9] = subscription.DiscountID().String()
+	}
+
+	return record, nil
+}
+
+// ExportArtifactToResponse — выгрузка в хранилище; rows — строк без заголовка.
+func ExportArtifactToResponse(artifact *models.Artifact, rows int) response.ExportFileResponse {
+	return response.ExportFileResponse{
+		Key:       artifact.Key(),
+		URL:       artifact.URL(),
+		Size:      artifact.Size(),
+		Rows:      rows,
+		ExpiresAt: artifact.ExpiresAt(),
+	}
+}
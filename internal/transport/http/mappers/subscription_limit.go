@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+func SubscriptionLimitToResponse(usage *models.SubscriptionLimitUsage) response.SubscriptionLimitResponse {
+	resp := response.SubscriptionLimitResponse{
+		UserID:           usage.UserID().String(),
+		MaxSubscriptions: usage.Limit(),
+		Unlimited:        usage.Limit() == 0,
+		Source:           "default",
+		Subscriptions:    usage.Used(),
+	}
+	if override := usage.Override(); override != nil {
+		updatedAt := override.UpdatedAt()
+		resp.Source = "override"
+		resp.Reason = override.Reason()
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
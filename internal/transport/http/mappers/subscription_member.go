@@ -0,0 +1,27 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+)
+
+func SubscriptionMemberToResponse(member *models.SubscriptionMember, ids publicid.Codec) response.SubscriptionMemberResponse {
+	return response.SubscriptionMemberResponse{
+		SubscriptionID: ids.Encode(member.SubscriptionID()),
+		UserID:         member.UserID().String(),
+		SharePercent:   member.SharePercent(),
+		CreatedAt:      member.CreatedAt(),
+	}
+}
+
+func SubscriptionMembersToResponse(members []*models.SubscriptionMember, ids publicid.Codec) response.SubscriptionMembersResponse {
+	data := make([]response.SubscriptionMemberResponse, len(members))
+	for i, member := range members {
+		data[i] = SubscriptionMemberToResponse(member, ids)
+	}
+	return response.SubscriptionMembersResponse{
+		Data:              data,
+		OwnerSharePercent: models.OwnerSharePercent(members),
+	}
+}
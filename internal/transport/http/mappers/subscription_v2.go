@@ -0,0 +1,74 @@
+package mappers
+
+import (
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	v2response "github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/v2/response"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/publicid"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+func SubscriptionToV2Response(subscription *models.Subscription, format utils.DateFormat, ids publicid.Codec) (v2response.SubscriptionResponse, error) {
+	price, err := priceUnits(subscription.Price())
+	if err != nil {
+		return v2response.SubscriptionResponse{}, err
+	}
+
+	resp := v2response.SubscriptionResponse{
+		ID:            ids.Encode(subscription.ID()),
+		ServiceName:   subscription.ServiceName(),
+		Price:         price,
+		Currency:      string(subscription.Price().Currency()),
+		UserID:        subscription.UserID().String(),
+		StartDate:     format.FormatStart(subscription.StartDate()),
+		Tags:          subscription.Tags(),
+		PaymentMethod: subscription.PaymentMethod(),
+		BillingDay:    subscription.BillingDay(),
+		Notes:         subscription.Notes(),
+		Metadata:      subscription.Metadata(),
+		CreatedAt:     subscription.CreatedAt(),
+		UpdatedAt:     subscription.UpdatedAt(),
+	}
+
+	if subscription.EndDate() != nil {
+		endDate := format.FormatEnd(*subscription.EndDate())
+		resp.EndDate = &endDate
+	}
+
+	if subscription.DiscountID() != nil {
+		discountID := subscription.DiscountID().String()
+		resp.DiscountID = &discountID
+	}
+
+	if subscription.PlanID() != nil {
+		planID := subscription.PlanID().String()
+		resp.PlanID = &planID
+	}
+
+	if subscription.CatalogID() != nil {
+		catalogID := subscription.CatalogID().String()
+		resp.CatalogID = &catalogID
+	}
+
+	if subscription.Category() != nil {
+		category := string(*subscription.Category())
+		resp.Category = &category
+	}
+
+	return resp, nil
+}
+
+func SubscriptionsToV2ListResponse(subscriptions []*models.Subscription, pagination v2response.PaginationResponse, format utils.DateFormat, ids publicid.Codec) (v2response.SubscriptionsListResponse, error) {
+	data := make([]v2response.SubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		resp, err := SubscriptionToV2Response(subscription, format, ids)
+		if err != nil {
+			return v2response.SubscriptionsListResponse{}, err
+		}
+		data[i] = resp
+	}
+
+	return v2response.SubscriptionsListResponse{
+		Data:       data,
+		Pagination: pagination,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+)
+
+const ArtifactCleanupJobName = "artifact-cleanup"
+
+// NewArtifactCleanupJob удаляет из объектного хранилища файлы старше срока хранения.
+func NewArtifactCleanupJob(artifacts service.ArtifactService, interval time.Duration) Job {
+	return Job{
+		Name:      ArtifactCleanupJobName,
+		Interval:  interval,
+		Timeout:   interval,
+		Exclusive: true,
+		Run: func(ctx context.Context) error {
+			_, err := artifacts.CleanupExpired(ctx)
+			return err
+		},
+	}
+}
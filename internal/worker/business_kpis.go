@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/livestats"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/infrastructure/metrics"
+)
+
+const BusinessKPIsJobName = "business-kpis"
+
+// NewBusinessKPIsJob обновляет бизнес-метрики Prometheus и живую панель;
+// любой из приёмников может быть nil.
+func NewBusinessKPIsJob(subscriptions service.SubscriptionService, m *metrics.Metrics, live *livestats.Stats, interval time.Duration) Job {
+	return Job{
+		Name:     BusinessKPIsJobName,
+		Interval: interval,
+		Timeout:  interval,
+		Run: func(ctx context.Context) error {
+			kpis, err := subscriptions.GetBusinessKPIs(ctx)
+			if err != nil {
+				return err
+			}
+
+			if m != nil {
+				m.MonthlySpend.Set(float64(kpis.MonthlySpend()))
+				m.ActiveUsers.Set(float64(kpis.ActiveUsers()))
+				m.ActiveSubscriptions.Set(float64(kpis.ActiveSubscriptions()))
+				m.KPIRefreshedAt.SetToCurrentTime()
+			}
+			if live != nil {
+				live.SetActiveSubscriptions(int64(kpis.ActiveSubscriptions()))
+			}
+			return nil
+		},
+	}
+}
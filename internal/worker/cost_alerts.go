@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+)
+
+const CostAlertsJobName = "cost-alerts"
+
+// NewCostAlertsJob рассылает события cost_alert.exceeded по порогам, которые
+// траты текущего месяца уже превысили.
+func NewCostAlertsJob(alerts service.CostAlertService, interval time.Duration) Job {
+	return Job{
+		Name:      CostAlertsJobName,
+		Interval:  interval,
+		Timeout:   interval,
+		Exclusive: true,
+		Run: func(ctx context.Context) error {
+			_, err := alerts.EvaluateAlerts(ctx)
+			return err
+		},
+	}
+}
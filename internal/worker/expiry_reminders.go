@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+)
+
+const ExpiryRemindersJobName = "expiry-reminders"
+
+// NewExpiryRemindersJob рассылает события subscription.expiring по
+// подпискам, которые скоро закончатся.
+func NewExpiryRemindersJob(reminders service.ExpiryReminderService, interval time.Duration) Job {
+	return Job{
+		Name:      ExpiryRemindersJobName,
+		Interval:  interval,
+		Timeout:   interval,
+		Exclusive: true,
+		Run: func(ctx context.Context) error {
+			_, err := reminders.SendExpiryReminders(ctx)
+			return err
+		},
+	}
+}
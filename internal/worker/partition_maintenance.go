@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+)
+
+const PartitionMaintenanceJobName = "partition-maintenance"
+
+// NewPartitionMaintenanceJob создаёт будущие годовые секции subscriptions.
+func NewPartitionMaintenanceJob(partitions service.PartitionMaintenanceService, interval time.Duration) Job {
+	return Job{
+		Name:      PartitionMaintenanceJobName,
+		Interval:  interval,
+		Timeout:   interval,
+		Exclusive: true,
+		Run: func(ctx context.Context) error {
+			_, err := partitions.EnsurePartitions(ctx)
+			return err
+		},
+	}
+}
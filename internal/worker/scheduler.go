@@ -0,0 +1,167 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/repository"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Timeout  time.Duration
+	// Exclusive — задача работает с общими данными и в каждый момент должна
+	// выполняться только на одной реплике. Задачи, обновляющие состояние
+	// процесса (метрики), остаются неэксклюзивными.
+	Exclusive bool
+	Run       func(ctx context.Context) error
+}
+
+/*
+Scheduler запускает задачи по интервалу. Эксклюзивные задачи перед
+запуском захватывают блокировку с именем задачи через locks: если её
+держит другая реплика, запуск пропускается; если блокировка потеряна во
+время работы, контекст задачи отменяется. Без locks (одна реплика)
+эксклюзивные задачи выполняются как обычные.
+*/
+type Scheduler struct {
+	jobs  []Job
+	locks repository.LockProvider
+	log   *logger.Logger
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(locks repository.LockProvider, log *logger.Logger) *Scheduler {
+	return &Scheduler{
+		locks: locks,
+		log:   log.Named("scheduler"),
+	}
+}
+
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+func (s *Scheduler) Jobs() []Job {
+	return s.jobs
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+
+	s.log.Info("scheduler started", zap.Int("jobs", len(s.jobs)))
+}
+
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	s.wg.Wait()
+	s.log.Info("scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.execute(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, job Job) {
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	if job.Exclusive && s.locks != nil {
+		lock, err := s.locks.TryLock(ctx, job.Name)
+		if err != nil {
+			s.log.Error("failed to acquire job lock",
+				zap.String("job", job.Name),
+				zap.Error(err))
+			return
+		}
+		if lock == nil {
+			s.log.Debug("job skipped: lock is held by another instance",
+				zap.String("job", job.Name))
+			return
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-lock.Lost():
+				s.log.Warn("job lock lost, cancelling run",
+					zap.String("job", job.Name))
+				cancel()
+			case <-done:
+			}
+		}()
+
+		defer func() {
+			releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			defer releaseCancel()
+			if err := lock.Release(releaseCtx); err != nil {
+				s.log.Warn("failed to release job lock",
+					zap.String("job", job.Name),
+					zap.Error(err))
+			}
+		}()
+	}
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		s.log.Error("job failed",
+			zap.String("job", job.Name),
+			zap.Duration("duration", time.Since(start)),
+			zap.Error(err))
+		return
+	}
+
+	s.log.Debug("job completed",
+		zap.String("job", job.Name),
+		zap.Duration("duration", time.Since(start)))
+}
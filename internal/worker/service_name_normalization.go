@@ -0,0 +1,25 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+)
+
+const ServiceNameNormalizationJobName = "service-name-normalization"
+
+// NewServiceNameNormalizationJob приводит названия сохранённых подписок к
+// словарю канонических названий.
+func NewServiceNameNormalizationJob(normalization service.ServiceNameNormalizationService, interval time.Duration) Job {
+	return Job{
+		Name:      ServiceNameNormalizationJobName,
+		Interval:  interval,
+		Timeout:   interval,
+		Exclusive: true,
+		Run: func(ctx context.Context) error {
+			_, err := normalization.NormalizeExisting(ctx)
+			return err
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+)
+
+const SubscriptionArchiveJobName = "subscription-archive"
+
+// NewSubscriptionArchiveJob переносит давно закончившиеся подписки в архив.
+func NewSubscriptionArchiveJob(archive service.SubscriptionArchiveService, interval time.Duration) Job {
+	return Job{
+		Name:      SubscriptionArchiveJobName,
+		Interval:  interval,
+		Timeout:   interval,
+		Exclusive: true,
+		Run: func(ctx context.Context) error {
+			_, err := archive.ArchiveEnded(ctx)
+			return err
+		},
+	}
+}
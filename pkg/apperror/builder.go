@@ -1,6 +1,9 @@
 package apperror
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 func NotFound(resource string) *AppError {
 	message := ErrorMessages[CodeNotFound]
@@ -22,6 +25,12 @@ func ValidationFailed(field, reason string) *AppError {
 		WithDetail("reason", reason)
 }
 
+// InvalidFields — VALIDATION_FAILED со списком всех нарушений по полям.
+func InvalidFields(violations ...FieldViolation) *AppError {
+	return New(CodeValidationFailed, ErrorMessages[CodeValidationFailed]).
+		WithViolations(violations...)
+}
+
 func DatabaseError(operation string, cause error) *AppError {
 	return Wrap(cause, CodeDatabaseError, ErrorMessages[CodeDatabaseError]).
 		WithDetail("operation", operation)
@@ -51,6 +60,12 @@ func InvalidDateFormat(value string) *AppError {
 		WithDetail("expected_format", "MM-YYYY")
 }
 
+func InvalidDateFormatExpecting(value, expectedFormat string) *AppError {
+	return New(CodeInvalidDateFormat, fmt.Sprintf("Invalid date format, expected %s", expectedFormat)).
+		WithDetail("value", value).
+		WithDetail("expected_format", expectedFormat)
+}
+
 func InvalidDateRange(startDate, endDate string) *AppError {
 	return New(CodeInvalidDateRange, ErrorMessages[CodeInvalidDateRange]).
 		WithDetail("start_date", startDate).
@@ -71,6 +86,19 @@ func InvalidServiceName() *AppError {
 	return New(CodeInvalidServiceName, ErrorMessages[CodeInvalidServiceName])
 }
 
+func ServiceNameNotAllowed(serviceName, list, reason string) *AppError {
+	return New(CodeServiceNameNotAllowed, ErrorMessages[CodeServiceNameNotAllowed]).
+		WithDetail("service_name", serviceName).
+		WithDetail("list", list).
+		WithDetail("reason", reason)
+}
+
+func PromoCodeInvalid(code, reason string) *AppError {
+	return New(CodePromoCodeInvalid, ErrorMessages[CodePromoCodeInvalid]).
+		WithDetail("promo_code", code).
+		WithDetail("reason", reason)
+}
+
 func InvalidPaginationParams(limit, offset int) *AppError {
 	return New(CodeInvalidPaginationParams, ErrorMessages[CodeInvalidPaginationParams]).
 		WithDetail("limit", fmt.Sprintf("%d", limit)).
@@ -82,6 +110,11 @@ func ServiceUnavailable(service string, cause error) *AppError {
 		WithDetail("service", service)
 }
 
+func PayloadTooLarge(limit int64) *AppError {
+	return New(CodePayloadTooLarge, ErrorMessages[CodePayloadTooLarge]).
+		WithDetail("max_bytes", fmt.Sprintf("%d", limit))
+}
+
 func Conflict(resource, reason string) *AppError {
 	return New(CodeConflict, ErrorMessages[CodeConflict]).
 		WithDetail("resource", resource).
@@ -93,3 +126,38 @@ func InvalidFilterParams(field, reason string) *AppError {
 		WithDetail("field", field).
 		WithDetail("reason", reason)
 }
+
+func Unauthorized(reason string) *AppError {
+	return New(CodeUnauthorized, ErrorMessages[CodeUnauthorized]).
+		WithDetail("reason", reason)
+}
+
+func Forbidden(role, permission string) *AppError {
+	return New(CodeForbidden, ErrorMessages[CodeForbidden]).
+		WithDetail("role", role).
+		WithDetail("required_permission", permission)
+}
+
+func TooManyRequests(reason string) *AppError {
+	return New(CodeTooManyRequests, ErrorMessages[CodeTooManyRequests]).
+		WithDetail("reason", reason)
+}
+
+func LimitExceeded(resource string, limit int) *AppError {
+	return New(CodeLimitExceeded, ErrorMessages[CodeLimitExceeded]).
+		WithDetail("resource", resource).
+		WithDetail("limit", fmt.Sprintf("%d", limit))
+}
+
+func RequestTimeout(timeout time.Duration) *AppError {
+	return New(CodeRequestTimeout, ErrorMessages[CodeRequestTimeout]).
+		WithDetail("timeout", timeout.String())
+}
+
+func Maintenance(reason string) *AppError {
+	err := New(CodeMaintenance, ErrorMessages[CodeMaintenance])
+	if reason != "" {
+		err = err.WithDetail("reason", reason)
+	}
+	return err
+}
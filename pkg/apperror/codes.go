@@ -8,10 +8,14 @@ const (
 	CodeForbidden            = "FORBIDDEN"
 	CodeConflict             = "CONFLICT"
 	CodeTooManyRequests      = "TOO_MANY_REQUESTS"
+	CodeLimitExceeded        = "LIMIT_EXCEEDED"
+	CodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
 	CodeInternalError        = "INTERNAL_ERROR"
 	CodeDatabaseError        = "DATABASE_ERROR"
 	CodeExternalServiceError = "EXTERNAL_SERVICE_ERROR"
 	CodeServiceUnavailable   = "SERVICE_UNAVAILABLE"
+	CodeRequestTimeout       = "REQUEST_TIMEOUT"
+	CodeMaintenance          = "MAINTENANCE"
 )
 
 const (
@@ -23,6 +27,8 @@ const (
 	CodeInvalidUserID           = "INVALID_USER_ID"
 	CodeInvalidPrice            = "INVALID_PRICE"
 	CodeInvalidServiceName      = "INVALID_SERVICE_NAME"
+	CodeServiceNameNotAllowed   = "SERVICE_NAME_NOT_ALLOWED"
+	CodePromoCodeInvalid        = "PROMO_CODE_INVALID"
 	CodeInvalidPaginationParams = "INVALID_PAGINATION_PARAMS"
 	CodeInvalidFilterParams     = "INVALID_FILTER_PARAMS"
 )
@@ -35,10 +41,14 @@ var ErrorMessages = map[string]string{
 	CodeForbidden:            "Access forbidden",
 	CodeConflict:             "Resource conflict",
 	CodeTooManyRequests:      "Too many requests",
+	CodeLimitExceeded:        "Limit exceeded",
+	CodePayloadTooLarge:      "Request body is too large",
 	CodeInternalError:        "Internal server error",
 	CodeDatabaseError:        "Database operation failed",
 	CodeExternalServiceError: "External service error",
 	CodeServiceUnavailable:   "Service temporarily unavailable",
+	CodeRequestTimeout:       "Request processing timed out",
+	CodeMaintenance:          "Service is in maintenance mode",
 
 	CodeSubscriptionNotFound:    "Subscription not found",
 	CodeSubscriptionExists:      "Subscription already exists",
@@ -48,6 +58,8 @@ var ErrorMessages = map[string]string{
 	CodeInvalidUserID:           "Invalid user ID format",
 	CodeInvalidPrice:            "Price must be a positive integer",
 	CodeInvalidServiceName:      "Service name cannot be empty",
+	CodeServiceNameNotAllowed:   "Service name is not allowed",
+	CodePromoCodeInvalid:        "Promo code cannot be applied",
 	CodeInvalidPaginationParams: "Invalid pagination parameters",
 	CodeInvalidFilterParams:     "Invalid filter parameters",
 }
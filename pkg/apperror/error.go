@@ -12,6 +12,15 @@ type AppError struct {
 	details    map[string]string
 	cause      error
 	httpStatus int
+	violations []FieldViolation
+}
+
+// FieldViolation — нарушенное правило валидации одного поля запроса.
+type FieldViolation struct {
+	Field   string
+	Rule    string
+	Message string
+	Value   string
 }
 
 func New(code, message string) *AppError {
@@ -72,6 +81,16 @@ func (e *AppError) WithDetails(details map[string]string) *AppError {
 	return e
 }
 
+// Violations — ошибки валидации по полям; пусто для остальных ошибок.
+func (e *AppError) Violations() []FieldViolation {
+	return e.violations
+}
+
+func (e *AppError) WithViolations(violations ...FieldViolation) *AppError {
+	e.violations = append(e.violations, violations...)
+	return e
+}
+
 func (e *AppError) WithHTTPStatus(status int) *AppError {
 	e.httpStatus = status
 	return e
@@ -121,6 +140,7 @@ func (e *AppError) Clone() *AppError {
 		details:    details,
 		cause:      e.cause,
 		httpStatus: e.httpStatus,
+		violations: append([]FieldViolation(nil), e.violations...),
 	}
 }
 
@@ -133,22 +153,30 @@ func IsAppError(err error) (*AppError, bool) {
 
 func getDefaultHTTPStatus(code string) int {
 	switch code {
-	case CodeNotFound:
+	case CodeNotFound, CodeSubscriptionNotFound:
 		return http.StatusNotFound
-	case CodeInvalidInput, CodeValidationFailed:
+	case CodeInvalidInput, CodeValidationFailed,
+		CodeInvalidSubscriptionData, CodeInvalidDateFormat, CodeInvalidDateRange, CodeInvalidUserID,
+		CodeInvalidPrice, CodeInvalidServiceName, CodeInvalidPaginationParams, CodeInvalidFilterParams:
 		return http.StatusBadRequest
 	case CodeUnauthorized:
 		return http.StatusUnauthorized
 	case CodeForbidden:
 		return http.StatusForbidden
-	case CodeConflict:
+	case CodeConflict, CodeSubscriptionExists:
 		return http.StatusConflict
 	case CodeTooManyRequests:
 		return http.StatusTooManyRequests
+	case CodePayloadTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case CodeLimitExceeded, CodeServiceNameNotAllowed, CodePromoCodeInvalid:
+		return http.StatusUnprocessableEntity
 	case CodeInternalError, CodeDatabaseError, CodeExternalServiceError:
 		return http.StatusInternalServerError
-	case CodeServiceUnavailable:
+	case CodeServiceUnavailable, CodeMaintenance:
 		return http.StatusServiceUnavailable
+	case CodeRequestTimeout:
+		return http.StatusGatewayTimeout
 	default:
 		return http.StatusInternalServerError
 	}
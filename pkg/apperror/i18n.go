@@ -0,0 +1,125 @@
+package apperror
+
+import (
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// Messages — переводы сообщений об ошибках на один язык.
+type Messages struct {
+	// Codes — сообщение по умолчанию для кода ошибки.
+	Codes map[string]string
+	// Texts — переводы конкретных сообщений по их английскому тексту, для
+	// ошибок, чьё сообщение точнее сообщения по умолчанию своего кода
+	// (например, CONFLICT "Plan already exists").
+	Texts map[string]string
+}
+
+/*
+Catalog выбирает язык сообщения об ошибке по заголовку Accept-Language.
+Сообщения в коде пишутся на базовом языке (английском); для остальных
+языков каталог ищет перевод сначала по тексту сообщения, затем по коду.
+Без перевода остаётся исходное сообщение. Код, детали и нарушения
+валидации не переводятся: клиенты разбирают ошибки по ним.
+
+Каталог расширяется через Register — из init своего пакета или при сборке
+приложения: новые языки и коды добавляются, существующие переводы
+перезаписываются.
+*/
+type Catalog struct {
+	mu        sync.RWMutex
+	base      language.Tag
+	languages []language.Tag
+	messages  map[language.Tag]Messages
+	matcher   language.Matcher
+}
+
+/** Конструктор. base — язык, на котором написаны сообщения в коде. */
+func NewCatalog(base language.Tag) *Catalog {
+	c := &Catalog{
+		base:     base,
+		messages: make(map[language.Tag]Messages),
+	}
+	c.Register(base, Messages{})
+	return c
+}
+
+/** Добавляет переводы на язык tag поверх уже зарегистрированных. */
+func (c *Catalog) Register(tag language.Tag, messages Messages) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current, ok := c.messages[tag]
+	if !ok {
+		current = Messages{Codes: make(map[string]string), Texts: make(map[string]string)}
+		c.languages = append(c.languages, tag)
+		c.matcher = language.NewMatcher(c.languages)
+	}
+	for code, message := range messages.Codes {
+		current.Codes[code] = message
+	}
+	for text, message := range messages.Texts {
+		current.Texts[text] = message
+	}
+	c.messages[tag] = current
+}
+
+/** Языки каталога; первый — базовый. */
+func (c *Catalog) Languages() []language.Tag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]language.Tag(nil), c.languages...)
+}
+
+/*
+Match выбирает язык каталога по значению Accept-Language с учётом q-весов
+и региональных вариантов (ru-RU → ru). Пустой или непонятный заголовок
+даёт базовый язык.
+*/
+func (c *Catalog) Match(acceptLanguage string) language.Tag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, index := language.MatchStrings(c.matcher, acceptLanguage)
+	return c.languages[index]
+}
+
+/*
+Localize переводит сообщение ошибки с кодом code на язык из
+Accept-Language и возвращает его вместе с языком ответа — для заголовка
+Content-Language. Если перевода нет, возвращается исходное сообщение и
+базовый язык.
+*/
+func (c *Catalog) Localize(code, message, acceptLanguage string) (string, language.Tag) {
+	tag := c.Match(acceptLanguage)
+	if tag == c.base {
+		return message, c.base
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	messages := c.messages[tag]
+	if translated, ok := messages.Texts[message]; ok {
+		return translated, tag
+	}
+	if translated, ok := messages.Codes[code]; ok {
+		return translated, tag
+	}
+	return message, c.base
+}
+
+// DefaultCatalog — каталог, которым пользуется HTTP-слой. Английские
+// сообщения — ErrorMessages, переводы на русский — в messages_ru.go.
+var DefaultCatalog = NewCatalog(language.English)
+
+/** Добавляет переводы в DefaultCatalog. */
+func RegisterMessages(tag language.Tag, messages Messages) {
+	DefaultCatalog.Register(tag, messages)
+}
+
+/** Переводит сообщение через DefaultCatalog. */
+func Localize(code, message, acceptLanguage string) (string, language.Tag) {
+	return DefaultCatalog.Localize(code, message, acceptLanguage)
+}
@@ -0,0 +1,68 @@
+package apperror
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestCatalog_Localize(t *testing.T) {
+	catalog := NewCatalog(language.English)
+	catalog.Register(language.Russian, Messages{
+		Codes: map[string]string{CodeConflict: "Конфликт"},
+		Texts: map[string]string{"Plan already exists": "Тариф уже существует"},
+	})
+
+	tests := []struct {
+		name           string
+		code           string
+		message        string
+		acceptLanguage string
+		want           string
+		wantTag        language.Tag
+	}{
+		{name: "no header", code: CodeConflict, message: "Resource conflict", want: "Resource conflict", wantTag: language.English},
+		{name: "base language", code: CodeConflict, message: "Resource conflict", acceptLanguage: "en-US", want: "Resource conflict", wantTag: language.English},
+		{name: "by code", code: CodeConflict, message: "Resource conflict", acceptLanguage: "ru", want: "Конфликт", wantTag: language.Russian},
+		{name: "by text", code: CodeConflict, message: "Plan already exists", acceptLanguage: "ru-RU", want: "Тариф уже существует", wantTag: language.Russian},
+		{name: "quality weights", code: CodeConflict, message: "Resource conflict", acceptLanguage: "de;q=0.9, ru;q=0.8, en;q=0.5", want: "Конфликт", wantTag: language.Russian},
+		{name: "english preferred", code: CodeConflict, message: "Resource conflict", acceptLanguage: "en, ru;q=0.5", want: "Resource conflict", wantTag: language.English},
+		{name: "unsupported language", code: CodeConflict, message: "Resource conflict", acceptLanguage: "fr", want: "Resource conflict", wantTag: language.English},
+		{name: "malformed header", code: CodeConflict, message: "Resource conflict", acceptLanguage: ";;q=x", want: "Resource conflict", wantTag: language.English},
+		{name: "no translation", code: CodeNotFound, message: "Plan not found", acceptLanguage: "ru", want: "Plan not found", wantTag: language.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, tag := catalog.Localize(tt.code, tt.message, tt.acceptLanguage)
+			if got != tt.want || tag != tt.wantTag {
+				t.Errorf("Localize() = %q, %s; want %q, %s", got, tag, tt.want, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestCatalog_RegisterExtends(t *testing.T) {
+	catalog := NewCatalog(language.English)
+	catalog.Register(language.Russian, Messages{Codes: map[string]string{CodeNotFound: "Не найдено"}})
+	catalog.Register(language.Russian, Messages{Codes: map[string]string{"QUOTA_EXHAUSTED": "Квота исчерпана"}})
+	catalog.Register(language.Kazakh, Messages{Codes: map[string]string{CodeNotFound: "Табылмады"}})
+
+	if got, _ := catalog.Localize(CodeNotFound, "Resource not found", "ru"); got != "Не найдено" {
+		t.Errorf("existing russian message = %q", got)
+	}
+	if got, _ := catalog.Localize("QUOTA_EXHAUSTED", "Quota exhausted", "ru"); got != "Квота исчерпана" {
+		t.Errorf("added russian message = %q", got)
+	}
+	if got, _ := catalog.Localize(CodeNotFound, "Resource not found", "kk-KZ"); got != "Табылмады" {
+		t.Errorf("kazakh message = %q", got)
+	}
+}
+
+func TestDefaultCatalog_CoversCodes(t *testing.T) {
+	for code := range ErrorMessages {
+		if got, tag := Localize(code, ErrorMessages[code], "ru"); tag != language.Russian || got == ErrorMessages[code] {
+			t.Errorf("code %s has no russian message", code)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package apperror
+
+import "golang.org/x/text/language"
+
+func init() {
+	RegisterMessages(language.Russian, Messages{
+		Codes: map[string]string{
+			CodeNotFound:             "Ресурс не найден",
+			CodeInvalidInput:         "Некорректные входные данные",
+			CodeValidationFailed:     "Ошибка валидации",
+			CodeUnauthorized:         "Требуется авторизация",
+			CodeForbidden:            "Доступ запрещён",
+			CodeConflict:             "Конфликт с существующими данными",
+			CodeTooManyRequests:      "Слишком много запросов",
+			CodeLimitExceeded:        "Превышен лимит",
+			CodePayloadTooLarge:      "Слишком большое тело запроса",
+			CodeInternalError:        "Внутренняя ошибка сервера",
+			CodeDatabaseError:        "Ошибка при работе с базой данных",
+			CodeExternalServiceError: "Ошибка внешнего сервиса",
+			CodeServiceUnavailable:   "Сервис временно недоступен",
+			CodeRequestTimeout:       "Превышено время обработки запроса",
+			CodeMaintenance:          "Сервис на техническом обслуживании",
+
+			CodeSubscriptionNotFound:    "Подписка не найдена",
+			CodeSubscriptionExists:      "Подписка уже существует",
+			CodeInvalidSubscriptionData: "Некорректные данные подписки",
+			CodeInvalidDateFormat:       "Неверный формат даты",
+			CodeInvalidDateRange:        "Некорректный диапазон дат",
+			CodeInvalidUserID:           "Некорректный идентификатор пользователя",
+			CodeInvalidPrice:            "Цена должна быть положительным целым числом",
+			CodeInvalidServiceName:      "Название сервиса не может быть пустым",
+			CodeServiceNameNotAllowed:   "Название сервиса запрещено",
+			CodePromoCodeInvalid:        "Промокод нельзя применить",
+			CodeInvalidPaginationParams: "Некорректные параметры пагинации",
+			CodeInvalidFilterParams:     "Некорректные параметры фильтрации",
+		},
+		Texts: map[string]string{
+			"Canonical service name already exists":                  "Каноническое название сервиса уже существует",
+			"Catalog service already exists":                         "Сервис уже есть в каталоге",
+			"Catalog service has subscriptions":                      "У сервиса из каталога есть подписки",
+			"Cost alert already exists":                              "Оповещение о расходах уже существует",
+			"Plan already exists":                                    "Тариф уже существует",
+			"Plan has subscriptions":                                 "У тарифа есть подписки",
+			"Promo code already exists":                              "Промокод уже существует",
+			"Promo code is attached to subscriptions":                "Промокод привязан к подпискам",
+			"Service name rule already exists":                       "Правило для названия сервиса уже существует",
+			"Service name spelling already belongs to another entry": "Это написание уже относится к другому названию сервиса",
+		},
+	})
+}
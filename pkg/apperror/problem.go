@@ -0,0 +1,20 @@
+package apperror
+
+import "strings"
+
+// DefaultProblemTypeBase — префикс type в ответах application/problem+json,
+// если api.problem_type_base_uri не задан.
+const DefaultProblemTypeBase = "urn:problem-type:subscription-service:"
+
+/*
+ProblemType — URI типа ошибки для RFC 7807: base и код в kebab-case,
+SUBSCRIPTION_NOT_FOUND → <base>subscription-not-found. Тип однозначно
+соответствует коду, поэтому шлюзы могут разбирать ошибки по type так же,
+как клиенты — по code.
+*/
+func ProblemType(base, code string) string {
+	if base == "" {
+		base = DefaultProblemTypeBase
+	}
+	return base + strings.ToLower(strings.ReplaceAll(code, "_", "-"))
+}
@@ -0,0 +1,217 @@
+// Package breaker — автомат защиты (circuit breaker) для вызовов внешних
+// зависимостей: после серии сбоев вызовы отклоняются сразу, не дожидаясь
+// таймаутов, а зависимость получает время восстановиться.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrOpen — вызов отклонён без обращения к зависимости.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+/*
+Settings: после FailureThreshold ошибок подряд автомат размыкается на
+OpenTimeout, затем пропускает до HalfOpenMaxCalls пробных вызовов. Все
+пробные прошли — автомат замыкается, любой упал — снова размыкается.
+OnStateChange (может быть nil) вызывается при каждом переходе под
+блокировкой автомата: обращаться из него к самому автомату нельзя.
+*/
+type Settings struct {
+	FailureThreshold int
+	OpenTimeout      time.Duration
+	HalfOpenMaxCalls int
+	OnStateChange    func(name string, from, to State)
+}
+
+func (s Settings) withDefaults() Settings {
+	if s.FailureThreshold <= 0 {
+		s.FailureThreshold = 5
+	}
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = 30 * time.Second
+	}
+	if s.HalfOpenMaxCalls <= 0 {
+		s.HalfOpenMaxCalls = 1
+	}
+	return s
+}
+
+type Breaker struct {
+	name     string
+	settings Settings
+	now      func() time.Time
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	openedAt  time.Time
+	inFlight  int
+	successes int
+}
+
+func New(name string, settings Settings) *Breaker {
+	return &Breaker{
+		name:     name,
+		settings: settings.withDefaults(),
+		now:      time.Now,
+	}
+}
+
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State — текущее состояние; истёкший OpenTimeout уже считается half-open.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.expire()
+	return b.state
+}
+
+/*
+Execute вызывает fn, если автомат её пропускает, иначе сразу возвращает
+ErrOpen с именем автомата. Ошибкой зависимости считается любая ошибка fn,
+кроме context.Canceled: вызывающий передумал, а зависимость ни при чём.
+*/
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.acquire(); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.release(err == nil || errors.Is(err, context.Canceled))
+	return err
+}
+
+func (b *Breaker) acquire() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.expire()
+	switch b.state {
+	case StateOpen:
+		return fmt.Errorf("%s: %w", b.name, ErrOpen)
+	case StateHalfOpen:
+		if b.inFlight >= b.settings.HalfOpenMaxCalls {
+			return fmt.Errorf("%s: %w", b.name, ErrOpen)
+		}
+	}
+	b.inFlight++
+	return nil
+}
+
+func (b *Breaker) release(ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.inFlight--
+	switch b.state {
+	case StateClosed:
+		if ok {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.settings.FailureThreshold {
+			b.setState(StateOpen)
+		}
+	case StateHalfOpen:
+		if !ok {
+			b.setState(StateOpen)
+			return
+		}
+		b.successes++
+		if b.successes >= b.settings.HalfOpenMaxCalls {
+			b.setState(StateClosed)
+		}
+	}
+	// StateOpen: ответ вызова, начатого до размыкания, ничего не меняет.
+}
+
+// expire переводит разомкнутый автомат в half-open по истечении OpenTimeout.
+func (b *Breaker) expire() {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.setState(StateHalfOpen)
+	}
+}
+
+func (b *Breaker) setState(to State) {
+	from := b.state
+	b.state = to
+	b.failures = 0
+	b.successes = 0
+	if to == StateOpen {
+		b.openedAt = b.now()
+	}
+	if b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(b.name, from, to)
+	}
+}
+
+// Group — автоматы с общими настройками по именам зависимостей; через него
+// health показывает состояние всех автоматов сразу.
+type Group struct {
+	settings Settings
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+func NewGroup(settings Settings) *Group {
+	return &Group{settings: settings, breakers: make(map[string]*Breaker)}
+}
+
+// Get возвращает автомат name, создавая его при первом обращении.
+func (g *Group) Get(name string) *Breaker {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.breakers[name]
+	if !ok {
+		b = New(name, g.settings)
+		g.breakers[name] = b
+	}
+	return b
+}
+
+// States — состояние каждого автомата группы.
+func (g *Group) States() map[string]State {
+	g.mu.Lock()
+	breakers := make([]*Breaker, 0, len(g.breakers))
+	for _, b := range g.breakers {
+		breakers = append(breakers, b)
+	}
+	g.mu.Unlock()
+
+	states := make(map[string]State, len(breakers))
+	for _, b := range breakers {
+		states[b.Name()] = b.State()
+	}
+	return states
+}
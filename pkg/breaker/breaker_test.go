@@ -0,0 +1,90 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errDown = errors.New("dependency down")
+
+func TestBreakerTransitions(t *testing.T) {
+	now := time.Unix(0, 0)
+	var transitions []string
+
+	b := New("postgres", Settings{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Minute,
+		HalfOpenMaxCalls: 1,
+		OnStateChange: func(_ string, from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	b.now = func() time.Time { return now }
+
+	fail := func() error { return errDown }
+	ok := func() error { return nil }
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		fn      func() error
+		wantErr error
+		want    State
+	}{
+		{"first failure keeps closed", 0, fail, errDown, StateClosed},
+		{"success resets counter", 0, ok, nil, StateClosed},
+		{"failure after reset", 0, fail, errDown, StateClosed},
+		{"threshold opens", 0, fail, errDown, StateOpen},
+		{"open rejects", 0, ok, ErrOpen, StateOpen},
+		{"timeout lets trial fail", time.Minute, fail, errDown, StateOpen},
+		{"reopened rejects", 0, ok, ErrOpen, StateOpen},
+		{"trial succeeds and closes", time.Minute, ok, nil, StateClosed},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		err := b.Execute(step.fn)
+		if !errors.Is(err, step.wantErr) || (step.wantErr == nil && err != nil) {
+			t.Fatalf("%s: err = %v, want %v", step.name, err, step.wantErr)
+		}
+		if got := b.State(); got != step.want {
+			t.Fatalf("%s: state = %s, want %s", step.name, got, step.want)
+		}
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("transitions = %v, want %v", transitions, want)
+		}
+	}
+}
+
+func TestBreakerIgnoresCanceled(t *testing.T) {
+	b := New("webhook", Settings{FailureThreshold: 1})
+
+	if err := b.Execute(func() error { return context.Canceled }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("state = %s, want closed", got)
+	}
+}
+
+func TestGroupStates(t *testing.T) {
+	g := NewGroup(Settings{FailureThreshold: 1})
+	if g.Get("postgres") != g.Get("postgres") {
+		t.Fatal("Get returned different breakers for the same name")
+	}
+	_ = g.Get("webhook").Execute(func() error { return errDown })
+
+	states := g.States()
+	if states["postgres"] != StateClosed || states["webhook"] != StateOpen || len(states) != 2 {
+		t.Fatalf("States() = %v", states)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Package buildinfo — версия сборки, которую подставляет компоновщик:
+
+	go build -ldflags "\
+	  -X github.com/vagonaizer/effective-mobile/subscription-service/pkg/buildinfo.Version=v1.4.0 \
+	  -X github.com/vagonaizer/effective-mobile/subscription-service/pkg/buildinfo.Commit=$(git rev-parse HEAD) \
+	  -X github.com/vagonaizer/effective-mobile/subscription-service/pkg/buildinfo.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+
+Без ldflags коммит и время берутся из VCS-меток go build, если они есть.
+*/
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+const unknown = "unknown"
+
+var (
+	Version   = "dev"
+	Commit    = unknown
+	BuildDate = unknown
+)
+
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	if info.Commit != unknown && info.BuildDate != unknown {
+		return info
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range bi.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.Commit == unknown:
+			info.Commit = setting.Value
+		case setting.Key == "vcs.time" && info.BuildDate == unknown:
+			info.BuildDate = setting.Value
+		}
+	}
+	return info
+}
@@ -0,0 +1,89 @@
+/*
+Package correlation переносит идентификаторы HTTP-запроса через context в
+нижние слои, чтобы строки логов репозиториев и SQL можно было связать с
+записью access-лога: request_id — X-Request-ID запроса, trace_id — trace-id
+из заголовка W3C traceparent, если клиент или прокси его прислали.
+*/
+package correlation
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// TraceparentHeader — заголовок W3C Trace Context.
+const TraceparentHeader = "traceparent"
+
+// IDs — идентификаторы, общие для всех логов одного запроса.
+type IDs struct {
+	RequestID string
+	TraceID   string
+}
+
+type contextKey struct{}
+
+/** Кладёт идентификаторы в контекст. */
+func WithIDs(ctx context.Context, ids IDs) context.Context {
+	return context.WithValue(ctx, contextKey{}, ids)
+}
+
+/** Идентификаторы из контекста; пустые вне HTTP-запроса. */
+func FromContext(ctx context.Context) IDs {
+	ids, _ := ctx.Value(contextKey{}).(IDs)
+	return ids
+}
+
+/** Поля лога request_id и trace_id; пустые идентификаторы пропускаются. */
+func Fields(ctx context.Context) []zap.Field {
+	ids := FromContext(ctx)
+
+	var fields []zap.Field
+	if ids.RequestID != "" {
+		fields = append(fields, zap.String("request_id", ids.RequestID))
+	}
+	if ids.TraceID != "" {
+		fields = append(fields, zap.String("trace_id", ids.TraceID))
+	}
+	return fields
+}
+
+/*
+TraceIDFromTraceparent достаёт trace-id из traceparent вида
+00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01. Некорректный
+заголовок и нулевой trace-id дают пустую строку: по спецификации такой
+заголовок игнорируется.
+*/
+func TraceIDFromTraceparent(header string) string {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return ""
+	}
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || version == "ff" || !isLowerHex(version) {
+		return ""
+	}
+	// Версия 00 состоит ровно из четырёх частей; будущие версии могут
+	// добавить поля в конец.
+	if version == "00" && len(parts) != 4 {
+		return ""
+	}
+	if len(traceID) != 32 || !isLowerHex(traceID) || strings.Trim(traceID, "0") == "" {
+		return ""
+	}
+	if len(parentID) != 16 || !isLowerHex(parentID) || len(flags) != 2 || !isLowerHex(flags) {
+		return ""
+	}
+	return traceID
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
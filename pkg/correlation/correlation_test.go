@@ -0,0 +1,50 @@
+package correlation
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTraceIDFromTraceparent(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "valid", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", want: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{name: "surrounding spaces", header: " 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00 ", want: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{name: "future version with extra field", header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", want: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{name: "empty", header: ""},
+		{name: "zero trace id", header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "uppercase hex", header: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{name: "short trace id", header: "00-4bf92f3577b34da6-00f067aa0ba902b7-01"},
+		{name: "invalid version", header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{name: "version 00 with extra field", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"},
+		{name: "missing flags", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TraceIDFromTraceparent(tt.header); got != tt.want {
+				t.Errorf("TraceIDFromTraceparent(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	if fields := Fields(context.Background()); len(fields) != 0 {
+		t.Errorf("Fields() without ids = %v, want none", fields)
+	}
+
+	ctx := WithIDs(context.Background(), IDs{RequestID: "req-1"})
+	fields := Fields(ctx)
+	if len(fields) != 1 || fields[0].Key != "request_id" || fields[0].String != "req-1" {
+		t.Errorf("Fields() = %v, want request_id only", fields)
+	}
+
+	ctx = WithIDs(context.Background(), IDs{RequestID: "req-1", TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"})
+	if fields := Fields(ctx); len(fields) != 2 || fields[1].Key != "trace_id" {
+		t.Errorf("Fields() = %v, want request_id and trace_id", fields)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Package featureflags — переключатели функций без повторного деплоя.
+
+Значение флага ищется сначала у удалённого провайдера (если он подключён),
+затем в правилах из конфигурации. Правило задаёт значение по умолчанию для
+окружения и переопределения для отдельных тенантов; тенант в сервисе — это
+пользователь (user_id), других тенантов модель пока не знает.
+*/
+package featureflags
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type Flag string
+
+// Известные флаги. Неизвестный флаг всегда выключен.
+const (
+	// DailyProration — считать стоимость пропорционально дням (prorated),
+	// если запрос не задал billing явно.
+	DailyProration Flag = "daily_proration"
+)
+
+// Known — все флаги, которые читает код; конфигурация проверяется по нему.
+var Known = []Flag{DailyProration}
+
+/*
+Provider — порт удалённого источника флагов (LaunchDarkly, Unleash,
+собственный сервис). ok == false — провайдер о флаге не знает, и решение
+принимают правила конфигурации; ошибка тоже ведёт к ним.
+*/
+type Provider interface {
+	Lookup(ctx context.Context, flag Flag, tenant string) (enabled bool, ok bool, err error)
+}
+
+// Rule — значение флага в окружении и переопределения по тенантам.
+type Rule struct {
+	Enabled bool
+	Tenants map[string]bool
+}
+
+// Static — флаги из файла конфигурации; реализует Provider.
+type Static struct {
+	rules map[Flag]Rule
+}
+
+func NewStatic(rules map[Flag]Rule) *Static {
+	return &Static{rules: rules}
+}
+
+func (s *Static) Lookup(_ context.Context, flag Flag, tenant string) (bool, bool, error) {
+	rule, ok := s.rules[flag]
+	if !ok {
+		return false, false, nil
+	}
+	if tenant != "" {
+		if enabled, ok := rule.Tenants[tenant]; ok {
+			return enabled, true, nil
+		}
+	}
+	return rule.Enabled, true, nil
+}
+
+// Flags вычисляет флаги. Нулевой *Flags допустим: все флаги выключены.
+type Flags struct {
+	static *Static
+	remote Provider
+	log    *logger.Logger
+}
+
+// New: remote может быть nil — тогда работают только правила конфигурации.
+func New(static *Static, remote Provider, log *logger.Logger) *Flags {
+	if static == nil {
+		static = NewStatic(nil)
+	}
+	return &Flags{
+		static: static,
+		remote: remote,
+		log:    log.Named("feature-flags"),
+	}
+}
+
+// Enabled сообщает, включён ли flag для tenant; пустой tenant — значение
+// окружения. Сбой удалённого провайдера не ломает запрос.
+func (f *Flags) Enabled(ctx context.Context, flag Flag, tenant string) bool {
+	if f == nil {
+		return false
+	}
+
+	if f.remote != nil {
+		enabled, ok, err := f.remote.Lookup(ctx, flag, tenant)
+		if err != nil {
+			f.log.Warn("remote feature flag lookup failed, using config",
+				zap.String("flag", string(flag)),
+				zap.Error(err))
+		} else if ok {
+			return enabled
+		}
+	}
+
+	enabled, _, _ := f.static.Lookup(ctx, flag, tenant)
+	return enabled
+}
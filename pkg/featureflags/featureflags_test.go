@@ -0,0 +1,52 @@
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+type remoteStub struct {
+	enabled, ok bool
+	err         error
+}
+
+func (r remoteStub) Lookup(context.Context, Flag, string) (bool, bool, error) {
+	return r.enabled, r.ok, r.err
+}
+
+func TestFlagsEnabled(t *testing.T) {
+	log, err := logger.NewLogger(logger.Config{Level: "fatal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	static := NewStatic(map[Flag]Rule{
+		DailyProration: {Enabled: false, Tenants: map[string]bool{"beta-user": true}},
+	})
+
+	cases := []struct {
+		name   string
+		flags  *Flags
+		flag   Flag
+		tenant string
+		want   bool
+	}{
+		{"nil flags", nil, DailyProration, "beta-user", false},
+		{"environment default", New(static, nil, log), DailyProration, "", false},
+		{"tenant override", New(static, nil, log), DailyProration, "beta-user", true},
+		{"other tenant", New(static, nil, log), DailyProration, "someone", false},
+		{"unknown flag", New(static, nil, log), Flag("unknown"), "beta-user", false},
+		{"remote wins", New(static, remoteStub{enabled: true, ok: true}, log), DailyProration, "someone", true},
+		{"remote unknown flag", New(static, remoteStub{}, log), DailyProration, "beta-user", true},
+		{"remote failure", New(static, remoteStub{enabled: true, ok: true, err: errors.New("timeout")}, log), DailyProration, "someone", false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.flags.Enabled(context.Background(), tc.flag, tc.tenant); got != tc.want {
+			t.Errorf("%s: Enabled() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
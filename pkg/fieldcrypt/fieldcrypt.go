@@ -0,0 +1,235 @@
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// Prefix помечает зашифрованное значение: enc:v1:<key id>:<base64(nonce|ciphertext)>.
+	Prefix  = "enc:"
+	version = "v1"
+
+	KeySize = 32
+)
+
+var (
+	ErrUnknownKey = errors.New("unknown encryption key")
+	ErrMalformed  = errors.New("malformed encrypted value")
+)
+
+type Config struct {
+	// ActiveKey — ID ключа, которым шифруются новые значения.
+	ActiveKey string
+	// Keys — ключи в формате "id:base64,id:base64"; старые ключи нужны,
+	// чтобы читать значения до ротации.
+	Keys string
+	// IndexKey — base64-ключ HMAC для слепого индекса (поиск по точному
+	// значению без расшифровки). Не меняется при ротации.
+	IndexKey string
+}
+
+/*
+Cipher шифрует строковые поля AES-256-GCM. Контекст (имя поля) идёт в
+associated data, поэтому значение одного поля нельзя подставить в другое.
+*/
+type Cipher struct {
+	active   string
+	aeads    map[string]cipher.AEAD
+	indexKey []byte
+}
+
+func New(cfg Config) (*Cipher, error) {
+	keys, err := ParseKeys(cfg.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("at least one encryption key is required")
+	}
+	if _, ok := keys[cfg.ActiveKey]; !ok {
+		return nil, fmt.Errorf("active key %q is not among the configured keys", cfg.ActiveKey)
+	}
+
+	indexKey, err := decodeKey(cfg.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("index key: %w", err)
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for id, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		aeads[id] = aead
+	}
+
+	return &Cipher{
+		active:   cfg.ActiveKey,
+		aeads:    aeads,
+		indexKey: indexKey,
+	}, nil
+}
+
+// ParseKeys разбирает список "id:base64,id:base64"; каждый ключ — 32 байта.
+func ParseKeys(spec string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(item, ":")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("key entry must look like id:base64, got %q", maskKey(item))
+		}
+		if _, dup := keys[id]; dup {
+			return nil, fmt.Errorf("key %q is listed twice", id)
+		}
+
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.New("must be base64")
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+func maskKey(item string) string {
+	if id, _, ok := strings.Cut(item, ":"); ok {
+		return id + ":***"
+	}
+	return "***"
+}
+
+// ActiveKey возвращает ID ключа для новых значений.
+func (c *Cipher) ActiveKey() string {
+	return c.active
+}
+
+// KeyIDs возвращает ID всех ключей по алфавиту.
+func (c *Cipher) KeyIDs() []string {
+	ids := make([]string, 0, len(c.aeads))
+	for id := range c.aeads {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Encrypt шифрует значение активным ключом. Пустая строка не шифруется.
+func (c *Cipher) Encrypt(plaintext, context string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	aead := c.aeads[c.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(context))
+	return Prefix + version + ":" + c.active + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+/*
+Decrypt расшифровывает значение любым из известных ключей. Значение без
+префикса enc: считается открытым текстом (записано до включения
+шифрования) и возвращается как есть.
+*/
+func (c *Cipher) Decrypt(value, context string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	keyID, sealed, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	aead, ok := c.aeads[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(context))
+	if err != nil {
+		return "", fmt.Errorf("decrypt with key %q: %w", keyID, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation сообщает, что значение открыто или зашифровано не активным ключом.
+func (c *Cipher) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	keyID, _, err := parse(value)
+	return err != nil || keyID != c.active
+}
+
+/*
+Digest — слепой индекс значения: HMAC-SHA256 по ключу индекса. Одинаковые
+значения дают одинаковый digest, поэтому по нему работает поиск на
+равенство без расшифровки.
+*/
+func (c *Cipher) Digest(value, context string) string {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(context))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsEncrypted сообщает, похоже ли значение на зашифрованное.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix+version+":")
+}
+
+func parse(value string) (string, []byte, error) {
+	rest := strings.TrimPrefix(value, Prefix+version+":")
+	keyID, encoded, ok := strings.Cut(rest, ":")
+	if !ok || keyID == "" {
+		return "", nil, ErrMalformed
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, ErrMalformed
+	}
+	return keyID, sealed, nil
+}
@@ -0,0 +1,129 @@
+package fieldcrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testKey — детерминированный 32-байтовый ключ в base64.
+func testKey(fill byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, KeySize))
+}
+
+func newCipher(t *testing.T, active, keys string) *Cipher {
+	t.Helper()
+	c, err := New(Config{ActiveKey: active, Keys: keys, IndexKey: testKey('i')})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func TestCipher_RoundTrip(t *testing.T) {
+	c := newCipher(t, "k1", "k1:"+testKey('a'))
+
+	sealed, err := c.Encrypt("call before renewal", "notes")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !IsEncrypted(sealed) || !strings.HasPrefix(sealed, "enc:v1:k1:") {
+		t.Errorf("Encrypt() = %q, want enc:v1:k1:...", sealed)
+	}
+	if strings.Contains(sealed, "renewal") {
+		t.Errorf("Encrypt() leaks the plaintext: %q", sealed)
+	}
+
+	again, _ := c.Encrypt("call before renewal", "notes")
+	if again == sealed {
+		t.Error("two encryptions of the same value are equal, want a fresh nonce each time")
+	}
+
+	opened, err := c.Decrypt(sealed, "notes")
+	if err != nil || opened != "call before renewal" {
+		t.Errorf("Decrypt() = %q, %v; want the plaintext", opened, err)
+	}
+
+	if sealed, _ := c.Encrypt("", "notes"); sealed != "" {
+		t.Errorf("Encrypt(\"\") = %q, want empty", sealed)
+	}
+	if opened, err := c.Decrypt("written before encryption", "notes"); err != nil || opened != "written before encryption" {
+		t.Errorf("Decrypt(plaintext) = %q, %v; want it unchanged", opened, err)
+	}
+}
+
+func TestCipher_WrongContext(t *testing.T) {
+	c := newCipher(t, "k1", "k1:"+testKey('a'))
+
+	sealed, err := c.Encrypt("secret", "subscription:a:notes")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if _, err := c.Decrypt(sealed, "subscription:b:notes"); err == nil {
+		t.Error("Decrypt() with another context succeeded, want an error")
+	}
+}
+
+func TestCipher_UnknownKey(t *testing.T) {
+	old := newCipher(t, "2024-01", "2024-01:"+testKey('a'))
+	sealed, err := old.Encrypt("secret", "notes")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	withoutOld := newCipher(t, "2024-06", "2024-06:"+testKey('b'))
+	if _, err := withoutOld.Decrypt(sealed, "notes"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Decrypt() error = %v, want ErrUnknownKey", err)
+	}
+
+	rotated := newCipher(t, "2024-06", "2024-01:"+testKey('a')+",2024-06:"+testKey('b'))
+	if opened, err := rotated.Decrypt(sealed, "notes"); err != nil || opened != "secret" {
+		t.Errorf("Decrypt() after rotation = %q, %v; want the plaintext", opened, err)
+	}
+
+	if _, err := rotated.Decrypt("enc:v1:2024-06:not base64!", "notes"); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Decrypt(malformed) error = %v, want ErrMalformed", err)
+	}
+}
+
+func TestCipher_NeedsRotation(t *testing.T) {
+	old := newCipher(t, "2024-01", "2024-01:"+testKey('a'))
+	c := newCipher(t, "2024-06", "2024-01:"+testKey('a')+",2024-06:"+testKey('b'))
+
+	sealedOld, _ := old.Encrypt("secret", "notes")
+	sealedActive, _ := c.Encrypt("secret", "notes")
+
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"empty", "", false},
+		{"plaintext", "written before encryption", true},
+		{"old key", sealedOld, true},
+		{"active key", sealedActive, false},
+		{"malformed", "enc:v1:", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.NeedsRotation(tt.value); got != tt.want {
+				t.Errorf("NeedsRotation(%q) = %t, want %t", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCipher_Digest(t *testing.T) {
+	c := newCipher(t, "k1", "k1:"+testKey('a'))
+	other := newCipher(t, "k2", "k2:"+testKey('b'))
+
+	if c.Digest("team", "metadata:owner") != other.Digest("team", "metadata:owner") {
+		t.Error("Digest() depends on the encryption key, want only the index key")
+	}
+	if c.Digest("team", "metadata:owner") == c.Digest("team", "metadata:project") {
+		t.Error("Digest() is the same for different contexts")
+	}
+}
@@ -0,0 +1,155 @@
+// Package health — реестр проверок зависимостей с кэшированием результата.
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name     string
+	critical bool
+	fn       CheckFunc
+}
+
+type Result struct {
+	Name     string
+	Critical bool
+	Status   string
+	Latency  time.Duration
+	Error    string
+}
+
+type Report struct {
+	Status    string
+	CheckedAt time.Time
+	// Cached — отчёт взят из кэша, проверки не запускались.
+	Cached  bool
+	Results []Result
+}
+
+// Ready — все критичные проверки прошли; некритичные на готовность не влияют.
+func (r Report) Ready() bool {
+	return r.Status != StatusUnhealthy
+}
+
+// FailedCritical — имена упавших критичных проверок.
+func (r Report) FailedCritical() []string {
+	var names []string
+	for _, res := range r.Results {
+		if res.Critical && res.Status == StatusDown {
+			names = append(names, res.Name)
+		}
+	}
+	return names
+}
+
+/*
+Registry запускает проверки параллельно, каждую со своим таймаутом, и
+держит отчёт ttl: частые опросы балансировщика и Kubernetes не доходят до
+зависимостей. Одновременные запросы при устаревшем кэше ждут один прогон.
+*/
+type Registry struct {
+	ttl     time.Duration
+	timeout time.Duration
+
+	mu     sync.Mutex
+	checks []check
+	last   *Report
+}
+
+// NewRegistry: ttl == 0 отключает кэш, timeout ограничивает каждую проверку.
+func NewRegistry(ttl, timeout time.Duration) *Registry {
+	return &Registry{ttl: ttl, timeout: timeout}
+}
+
+// Register добавляет проверку. Упавшая критичная проверка делает сервис
+// неготовым, некритичная — только помечает его degraded.
+func (r *Registry) Register(name string, critical bool, fn CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, check{name: name, critical: critical, fn: fn})
+	r.last = nil
+}
+
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.last != nil && time.Since(r.last.CheckedAt) < r.ttl {
+		cached := *r.last
+		cached.Cached = true
+		return cached
+	}
+
+	// Отчёт достанется и другим запросам, поэтому отмена запроса, который
+	// его запустил, не должна превращаться в упавшие проверки.
+	report := r.run(context.WithoutCancel(ctx))
+	r.last = &report
+	return report
+}
+
+func (r *Registry) run(ctx context.Context) Report {
+	results := make([]Result, len(r.checks))
+
+	var wg sync.WaitGroup
+	for i, c := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.runOne(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	status := StatusHealthy
+	for _, res := range results {
+		if res.Status == StatusUp {
+			continue
+		}
+		if res.Critical {
+			status = StatusUnhealthy
+			break
+		}
+		status = StatusDegraded
+	}
+
+	return Report{Status: status, CheckedAt: time.Now(), Results: results}
+}
+
+func (r *Registry) runOne(ctx context.Context, c check) Result {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := c.fn(ctx)
+	res := Result{
+		Name:     c.name,
+		Critical: c.critical,
+		Status:   StatusUp,
+		Latency:  time.Since(start),
+	}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
@@ -0,0 +1,101 @@
+/*
+Package ical пишет календари в формате iCalendar (RFC 5545) — ровно
+столько, сколько нужно для подписки на ленту в Google и Apple Calendar:
+события на весь день со сводкой и описанием. Строки завершаются CRLF,
+текст экранируется, длинные строки переносятся по 75 байт.
+*/
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	// maxLineLength — предел длины строки в байтах без CRLF (RFC 5545, 3.1).
+	maxLineLength = 75
+)
+
+// Calendar — VCALENDAR с событиями.
+type Calendar struct {
+	// ProdID — идентификатор продукта, обязательное свойство.
+	ProdID string
+	// Name — название календаря, которое показывают клиенты (X-WR-CALNAME).
+	Name   string
+	Events []Event
+}
+
+// Event — VEVENT на весь день Date.
+type Event struct {
+	// UID должен быть стабильным: по нему клиент обновляет событие.
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	// Stamp — время формирования события (DTSTAMP).
+	Stamp time.Time
+}
+
+// Write выводит календарь в w.
+func (c Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", escape(c.ProdID))
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	for _, event := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(event.UID))
+		line("DTSTAMP", event.Stamp.UTC().Format(dateTimeLayout))
+		line("DTSTART;VALUE=DATE", event.Date.Format(dateLayout))
+		line("DTEND;VALUE=DATE", event.Date.AddDate(0, 0, 1).Format(dateLayout))
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escape экранирует TEXT-значение (RFC 5545, 3.3.11).
+func escape(value string) string {
+	return escaper.Replace(value)
+}
+
+// writeFolded пишет строку, перенося её по maxLineLength байт без разрыва
+// UTF-8 символов; строка продолжения начинается с пробела.
+func writeFolded(w *bufio.Writer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// Пробел в начале строки продолжения входит в её длину.
+		limit = maxLineLength - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
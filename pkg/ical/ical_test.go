@@ -0,0 +1,75 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarWrite(t *testing.T) {
+	stamp := time.Date(2025, time.July, 1, 9, 30, 0, 0, time.UTC)
+	calendar := Calendar{
+		ProdID: "-//test//EN",
+		Name:   "Подписки",
+		Events: []Event{{
+			UID:         "sub-1",
+			Date:        time.Date(2025, time.July, 31, 0, 0, 0, 0, time.UTC),
+			Summary:     "Netflix, Premium; 799 RUB",
+			Description: "line one\nline two",
+			Stamp:       stamp,
+		}},
+	}
+
+	var b strings.Builder
+	if err := calendar.Write(&b); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Подписки\r\n",
+		"DTSTAMP:20250701T093000Z\r\n",
+		"DTSTART;VALUE=DATE:20250731\r\n",
+		"DTEND;VALUE=DATE:20250801\r\n",
+		`SUMMARY:Netflix\, Premium\; 799 RUB` + "\r\n",
+		`DESCRIPTION:line one\nline two` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output has no %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCalendarWriteFoldsLongLines(t *testing.T) {
+	calendar := Calendar{
+		ProdID: "-//test//EN",
+		Events: []Event{{UID: "sub-1", Summary: strings.Repeat("ж", 100)}},
+	}
+
+	var b strings.Builder
+	if err := calendar.Write(&b); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var summary strings.Builder
+	inSummary := false
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("line is %d bytes: %q", len(line), line)
+		}
+		switch {
+		case strings.HasPrefix(line, "SUMMARY:"):
+			inSummary = true
+			summary.WriteString(strings.TrimPrefix(line, "SUMMARY:"))
+		case inSummary && strings.HasPrefix(line, " "):
+			summary.WriteString(line[1:])
+		default:
+			inSummary = false
+		}
+	}
+	if summary.String() != strings.Repeat("ж", 100) {
+		t.Errorf("unfolded summary = %q", summary.String())
+	}
+}
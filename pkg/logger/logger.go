@@ -1,7 +1,9 @@
 package logger
 
 import (
+	"io"
 	"os"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -10,57 +12,88 @@ import (
 type Logger struct {
 	logger *zap.Logger
 	sugar  *zap.SugaredLogger
+	// closers — файлы выходов; общие для логгера и всех его Named/With.
+	closers []io.Closer
+	// level — уровень всех выходов; общий для логгера и всех его Named/With.
+	level zap.AtomicLevel
 }
 
 type Config struct {
 	Level       string
 	Development bool
-	Encoding    string
+	// Encoding — формат выходов, у которых он не задан: json или console.
+	Encoding string
+	// Outputs — куда писать; пусто — только stdout.
+	Outputs []OutputConfig
 }
 
 func NewLogger(cfg Config) (*Logger, error) {
-	var config zap.Config
-
-	if cfg.Development {
-		config = zap.NewDevelopmentConfig()
-		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-	} else {
-		config = zap.NewProductionConfig()
-	}
-
 	level, err := zapcore.ParseLevel(cfg.Level)
 	if err != nil {
 		level = zapcore.InfoLevel
 	}
+	atomicLevel := zap.NewAtomicLevelAt(level)
 
-	config.Level = zap.NewAtomicLevelAt(level)
-
-	if cfg.Encoding != "" {
-		config.Encoding = cfg.Encoding
+	cores, closers, err := buildCores(cfg, atomicLevel)
+	if err != nil {
+		return nil, err
 	}
 
-	config.OutputPaths = []string{"stdout"}
-	config.ErrorOutputPaths = []string{"stderr"}
-
-	zapLogger, err := config.Build(
+	core := zapcore.NewTee(cores...)
+	options := []zap.Option{
 		zap.AddCallerSkip(1),
 		zap.AddCaller(),
 		zap.AddStacktrace(zapcore.ErrorLevel),
-	)
-	if err != nil {
-		return nil, err
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+	}
+	if cfg.Development {
+		options = append(options, zap.Development())
+	} else {
+		// Как в zap.NewProductionConfig: после 100 одинаковых записей за
+		// секунду пишется каждая сотая.
+		core = zapcore.NewSamplerWithOptions(core, time.Second, 100, 100)
 	}
 
+	zapLogger := zap.New(core, options...)
+	return &Logger{
+		logger:  zapLogger,
+		sugar:   zapLogger.Sugar(),
+		closers: closers,
+		level:   atomicLevel,
+	}, nil
+}
+
+// FromZap оборачивает готовый zap.Logger, например с ядром zaptest/observer в
+// тестах. Уровень такого логгера задан его ядром: SetLevel его не меняет.
+func FromZap(zapLogger *zap.Logger) *Logger {
 	return &Logger{
 		logger: zapLogger,
 		sugar:  zapLogger.Sugar(),
-	}, nil
+		level:  zap.NewAtomicLevelAt(zapcore.LevelOf(zapLogger.Core())),
+	}
+}
+
+// Level — текущий минимальный уровень записей.
+func (l *Logger) Level() zapcore.Level {
+	return l.level.Level()
+}
+
+// SetLevel меняет уровень на ходу: сразу для всех выходов, а также для
+// всех логгеров, полученных через Named и With.
+func (l *Logger) SetLevel(level zapcore.Level) {
+	l.level.SetLevel(level)
 }
 
 func (l *Logger) GetZapLogger() *zap.Logger {
 	return l.logger
 }
 
+// Enabled сообщает, попадёт ли в лог запись уровня level: так можно не
+// собирать поля для записи, которая всё равно будет отброшена.
+func (l *Logger) Enabled(level zapcore.Level) bool {
+	return l.logger.Core().Enabled(level)
+}
+
 func (l *Logger) Debug(msg string, fields ...zap.Field) {
 	l.logger.Debug(msg, fields...)
 }
@@ -135,15 +168,19 @@ func (l *Logger) Panicw(msg string, keysAndValues ...interface{}) {
 
 func (l *Logger) With(fields ...zap.Field) *Logger {
 	return &Logger{
-		logger: l.logger.With(fields...),
-		sugar:  l.logger.With(fields...).Sugar(),
+		logger:  l.logger.With(fields...),
+		sugar:   l.logger.With(fields...).Sugar(),
+		closers: l.closers,
+		level:   l.level,
 	}
 }
 
 func (l *Logger) WithOptions(opts ...zap.Option) *Logger {
 	return &Logger{
-		logger: l.logger.WithOptions(opts...),
-		sugar:  l.logger.WithOptions(opts...).Sugar(),
+		logger:  l.logger.WithOptions(opts...),
+		sugar:   l.logger.WithOptions(opts...).Sugar(),
+		closers: l.closers,
+		level:   l.level,
 	}
 }
 
@@ -156,10 +193,20 @@ func (l *Logger) Sync() error {
 	return err2
 }
 
+// Close сбрасывает буферы и закрывает файлы выходов; вызывается последним
+// шагом остановки.
+func (l *Logger) Close() error {
+	// Sync для stdout/stderr на части платформ возвращает EINVAL — не ошибка остановки.
+	_ = l.Sync()
+	return closeAll(l.closers)
+}
+
 func (l *Logger) Named(name string) *Logger {
 	return &Logger{
-		logger: l.logger.Named(name),
-		sugar:  l.sugar.Named(name),
+		logger:  l.logger.Named(name),
+		sugar:   l.sugar.Named(name),
+		closers: l.closers,
+		level:   l.level,
 	}
 }
 
@@ -170,9 +217,3 @@ func NewDefaultLogger() (*Logger, error) {
 		Encoding:    "json",
 	})
 }
-
-func init() {
-	if os.Getenv("LOG_LEVEL") == "" {
-		os.Setenv("LOG_LEVEL", "info")
-	}
-}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewLogger_FileOutput(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "logs", "app.json")
+	consolePath := filepath.Join(dir, "app.log")
+
+	log, err := NewLogger(Config{
+		Level:       "info",
+		Development: true,
+		Encoding:    "console",
+		Outputs: []OutputConfig{
+			{Type: OutputFile, Encoding: "json", Path: jsonPath, MaxSizeMB: 1, MaxBackups: 2, Compress: true},
+			{Type: OutputFile, Path: consolePath},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	log.Named("orders").Info("order created", zap.String("order_id", "42"))
+	log.Debug("below level")
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("json output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("json output has %d lines, want 1:\n%s", len(lines), data)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("json output is not JSON: %v\n%s", err, lines[0])
+	}
+	if entry["order_id"] != "42" || entry["logger"] != "orders" || entry["level"] != "info" {
+		t.Errorf("json entry = %v", entry)
+	}
+
+	data, err = os.ReadFile(consolePath)
+	if err != nil {
+		t.Fatalf("console output: %v", err)
+	}
+	text := string(data)
+	if !strings.Contains(text, "order created") || !strings.Contains(text, "INFO") {
+		t.Errorf("console output = %q", text)
+	}
+	// Цвет уровня — только для терминала.
+	if strings.Contains(text, "\x1b[") {
+		t.Errorf("console file output contains color codes: %q", text)
+	}
+}
+
+func TestNewLogger_InvalidOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output OutputConfig
+	}{
+		{name: "unknown type", output: OutputConfig{Type: "syslog"}},
+		{name: "file without path", output: OutputConfig{Type: OutputFile}},
+		{name: "unknown encoding", output: OutputConfig{Type: OutputStdout, Encoding: "xml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLogger(Config{Outputs: []OutputConfig{tt.output}}); err == nil {
+				t.Error("NewLogger() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := NewLogger(Config{Level: "info", Outputs: []OutputConfig{{Type: OutputFile, Path: path}}})
+	if err != nil {
+		t.Fatalf("NewLogger() error = %v", err)
+	}
+	named := log.Named("postgres")
+
+	named.Debug("hidden")
+	log.SetLevel(zapcore.DebugLevel)
+	if log.Level() != zapcore.DebugLevel || !named.Enabled(zapcore.DebugLevel) {
+		t.Fatalf("level = %s, named logger did not follow", log.Level())
+	}
+	named.Debug("visible")
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hidden") || !strings.Contains(string(data), "visible") {
+		t.Errorf("output = %s, want only the entry after SetLevel", data)
+	}
+}
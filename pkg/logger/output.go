@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Типы выходов OutputConfig.Type.
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
+
+/*
+OutputConfig — один выход логгера. Записи пишутся во все выходы сразу,
+каждый со своим форматом: например, console в stdout для человека и json в
+файл для сборщика логов. Файл ротируется по размеру, как в lumberjack:
+при MaxSizeMB текущий файл переименовывается с отметкой времени, старые
+копии сжимаются и удаляются сверх MaxBackups и старше MaxAgeDays.
+*/
+type OutputConfig struct {
+	// Type — stdout, stderr или file.
+	Type string
+	// Encoding — json или console; пусто — Config.Encoding.
+	Encoding string
+	// Path — путь к файлу для Type file; каталог создаётся при первой записи.
+	Path string
+	// MaxSizeMB — размер файла, после которого он ротируется; 0 — 100 МБ.
+	MaxSizeMB int
+	// MaxBackups — сколько старых файлов хранить; 0 — все.
+	MaxBackups int
+	// MaxAgeDays — через сколько дней удалять старые файлы; 0 — не удалять.
+	MaxAgeDays int
+	// Compress сжимает старые файлы gzip.
+	Compress bool
+}
+
+// buildCores собирает по ядру на каждый выход. Закрывать при остановке
+// нужно только файлы: их возвращает closers.
+func buildCores(cfg Config, level zap.AtomicLevel) (cores []zapcore.Core, closers []io.Closer, err error) {
+	outputs := cfg.Outputs
+	if len(outputs) == 0 {
+		outputs = []OutputConfig{{Type: OutputStdout}}
+	}
+
+	for i, output := range outputs {
+		encoding := output.Encoding
+		if encoding == "" {
+			encoding = cfg.Encoding
+		}
+		if encoding == "" {
+			encoding = defaultEncoding(cfg.Development)
+		}
+
+		var sink zapcore.WriteSyncer
+		switch output.Type {
+		case OutputStdout:
+			sink = zapcore.Lock(os.Stdout)
+		case OutputStderr:
+			sink = zapcore.Lock(os.Stderr)
+		case OutputFile:
+			if output.Path == "" {
+				return nil, nil, fmt.Errorf("logger output %d: file path is required", i)
+			}
+			file := &lumberjack.Logger{
+				Filename:   output.Path,
+				MaxSize:    output.MaxSizeMB,
+				MaxBackups: output.MaxBackups,
+				MaxAge:     output.MaxAgeDays,
+				Compress:   output.Compress,
+			}
+			closers = append(closers, file)
+			sink = zapcore.AddSync(file)
+		default:
+			return nil, nil, fmt.Errorf("logger output %d: unknown type %q", i, output.Type)
+		}
+
+		// Цвет уровня нужен только в терминале: в файле escape-коды мешают
+		// grep и сборщикам логов.
+		encoder, err := newEncoder(encoding, cfg.Development, cfg.Development && output.Type != OutputFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("logger output %d: %w", i, err)
+		}
+		cores = append(cores, zapcore.NewCore(encoder, sink, level))
+	}
+
+	return cores, closers, nil
+}
+
+func newEncoder(encoding string, development, color bool) (zapcore.Encoder, error) {
+	// JSON читают программы, поэтому ключи у него всегда производственные
+	// (level, ts, msg); в режиме разработки меняется только вид console.
+	encoderConfig := zap.NewProductionEncoderConfig()
+	if development && encoding == "console" {
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+	}
+	if color {
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+
+	switch encoding {
+	case "json":
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	case "console":
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %q", encoding)
+	}
+}
+
+func defaultEncoding(development bool) string {
+	if development {
+		return "console"
+	}
+	return "json"
+}
+
+// closeAll закрывает файлы выходов; ошибки собираются вместе.
+func closeAll(closers []io.Closer) error {
+	var errs []error
+	for _, closer := range closers {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,240 @@
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const jsonContentType = "application/json"
+
+// Route описывает один маршрут рядом с его регистрацией в gin.
+type Route struct {
+	Method string
+	// Path — шаблон gin относительно группы версии, например /subscriptions/:id.
+	Path string
+	// ID — имя операции без версии, например CreateSubscription.
+	ID          string
+	Summary     string
+	Description string
+	Tags        []string
+	Params      []Parameter
+	// Body — значение DTO тела запроса; nil — запрос без тела.
+	Body      any
+	Responses []Reply
+	// Errors — коды ошибок, которые отдаются в общем формате ошибки.
+	Errors []int
+	// ContentType ответа; пусто — application/json.
+	ContentType string
+}
+
+// Reply — успешный ответ маршрута. Body nil — ответ без тела.
+type Reply struct {
+	Status      int
+	Description string
+	Body        any
+}
+
+// Builder собирает Document из маршрутов нескольких групп.
+type Builder struct {
+	doc       *Document
+	generator *Generator
+	errorBody any
+	// errorBodies — DTO ошибки для отдельных кодов вместо errorBody.
+	errorBodies map[int]any
+	// errorContents — другие представления ошибок по типу содержимого.
+	errorContents map[string]any
+}
+
+// NewBuilder создаёт построитель; errorBody — DTO, в котором API отдаёт ошибки.
+func NewBuilder(info Info, errorBody any) *Builder {
+	return &Builder{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   make(map[string]*PathItem),
+		},
+		generator:     NewGenerator(),
+		errorBody:     errorBody,
+		errorBodies:   make(map[int]any),
+		errorContents: make(map[string]any),
+	}
+}
+
+// SetErrorBody задаёт отдельный DTO ошибки для status, например расширенный
+// ответ с ошибками валидации для 400.
+func (b *Builder) SetErrorBody(status int, body any) {
+	b.errorBodies[status] = body
+}
+
+// AddErrorContent описывает у всех ошибок ещё одно представление, которое
+// клиент выбирает заголовком Accept, например application/problem+json.
+func (b *Builder) AddErrorContent(contentType string, body any) {
+	b.errorContents[contentType] = body
+}
+
+func (b *Builder) AddServer(url, description string) {
+	b.doc.Servers = append(b.doc.Servers, Server{URL: url, Description: description})
+}
+
+func (b *Builder) AddTag(name, description string) {
+	b.doc.Tags = append(b.doc.Tags, Tag{Name: name, Description: description})
+}
+
+func (b *Builder) AddSecurityScheme(name string, scheme *SecurityScheme) {
+	if b.doc.Components.SecuritySchemes == nil {
+		b.doc.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+	b.doc.Components.SecuritySchemes[name] = scheme
+}
+
+// SetSecurity задаёт требования безопасности по умолчанию для всех операций.
+func (b *Builder) SetSecurity(requirements ...SecurityRequirement) {
+	b.doc.Security = requirements
+}
+
+// Add добавляет маршрут под basePath (например /api/v1) и возвращает
+// операцию, чтобы вызывающий мог дополнить её (безопасность, deprecated).
+// operationId — последний сегмент basePath и ID: v1CreateSubscription.
+func (b *Builder) Add(basePath string, route Route) *Operation {
+	op := &Operation{
+		OperationID: operationID(basePath, route.ID),
+		Summary:     route.Summary,
+		Description: route.Description,
+		Tags:        route.Tags,
+		Parameters:  route.Params,
+		Responses:   make(map[string]*Response),
+	}
+
+	if route.Body != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{jsonContentType: {Schema: b.generator.Schema(route.Body)}},
+		}
+	}
+
+	contentType := route.ContentType
+	if contentType == "" {
+		contentType = jsonContentType
+	}
+	for _, reply := range route.Responses {
+		op.Responses[strconv.Itoa(reply.Status)] = b.response(reply.Status, reply.Description, reply.Body, contentType)
+	}
+	for _, status := range route.Errors {
+		body, ok := b.errorBodies[status]
+		if !ok {
+			body = b.errorBody
+		}
+		resp := b.response(status, "", body, jsonContentType)
+		for contentType, alternative := range b.errorContents {
+			if schema := b.generator.Schema(alternative); schema != nil {
+				if resp.Content == nil {
+					resp.Content = make(map[string]MediaType)
+				}
+				resp.Content[contentType] = MediaType{Schema: schema}
+			}
+		}
+		op.Responses[strconv.Itoa(status)] = resp
+	}
+
+	path := basePath + GinPath(route.Path)
+	item, ok := b.doc.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		b.doc.Paths[path] = item
+	}
+	(*item)[strings.ToLower(route.Method)] = op
+
+	return op
+}
+
+func (b *Builder) response(status int, description string, body any, contentType string) *Response {
+	if description == "" {
+		description = http.StatusText(status)
+	}
+
+	resp := &Response{Description: description}
+	if schema := b.generator.Schema(body); schema != nil {
+		resp.Content = map[string]MediaType{contentType: {Schema: schema}}
+	}
+	return resp
+}
+
+// Document возвращает собранный документ.
+func (b *Builder) Document() *Document {
+	b.doc.Components.Schemas = b.generator.Schemas()
+	sort.Slice(b.doc.Tags, func(i, j int) bool { return b.doc.Tags[i].Name < b.doc.Tags[j].Name })
+	return b.doc
+}
+
+func operationID(basePath, id string) string {
+	segments := strings.Split(strings.Trim(basePath, "/"), "/")
+	return segments[len(segments)-1] + id
+}
+
+// GinPath переводит шаблон gin (/users/:user_id) в шаблон OpenAPI (/users/{user_id}).
+func GinPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// PathParam — обязательный параметр пути.
+func PathParam(name, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: schema}
+}
+
+func QueryParam(name, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func HeaderParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "header", Description: description, Schema: String()}
+}
+
+// Require делает параметр обязательным.
+func (p Parameter) Require() Parameter {
+	p.Required = true
+	return p
+}
+
+func String() *Schema {
+	return &Schema{Type: "string"}
+}
+
+func UUID() *Schema {
+	return &Schema{Type: "string", Format: "uuid"}
+}
+
+func Integer() *Schema {
+	return &Schema{Type: "integer"}
+}
+
+func Boolean() *Schema {
+	return &Schema{Type: "boolean"}
+}
+
+func Enum(values ...string) *Schema {
+	enum := make([]any, len(values))
+	for i, value := range values {
+		enum[i] = value
+	}
+	return &Schema{Type: "string", Enum: enum}
+}
+
+// WithDefault задаёт значение по умолчанию.
+func (s *Schema) WithDefault(value any) *Schema {
+	s.Default = value
+	return s
+}
+
+// WithMaximum задаёт верхнюю границу числа.
+func (s *Schema) WithMaximum(value float64) *Schema {
+	s.Maximum = &value
+	return s
+}
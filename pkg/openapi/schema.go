@@ -0,0 +1,357 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	rawJSONType   = reflect.TypeOf(json.RawMessage{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+	versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+// Generator строит схемы из Go-типов по тегам json, binding и тегам
+// документации (example, enums, format, minLength, maxLength, minimum,
+// maximum, swaggertype). Именованные структуры попадают в components и
+// подставляются ссылкой.
+type Generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// Schemas возвращает накопленные схемы для components.schemas.
+func (g *Generator) Schemas() map[string]*Schema {
+	return g.schemas
+}
+
+// Schema возвращает схему значения v; nil — тело без содержимого.
+func (g *Generator) Schema(v any) *Schema {
+	if v == nil {
+		return nil
+	}
+	return g.typeSchema(reflect.TypeOf(v))
+}
+
+func (g *Generator) typeSchema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawJSONType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.typeSchema(t.Elem()))
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+			return &Schema{}
+		}
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return g.ref(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// ref регистрирует именованную структуру в components и возвращает ссылку.
+func (g *Generator) ref(t reflect.Type) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		name = schemaName(t)
+		g.names[t] = name
+		// Заглушка до построения схемы, чтобы рекурсивные типы не зацикливались.
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.structSchema(t)
+	}
+	return &Schema{Ref: RefPrefix + name}
+}
+
+// schemaName — имя пакета и типа, например response.PlanResponse; для
+// пакетов внутри каталога версии (…/v2/response) версия добавляется
+// спереди: v2.response.SubscriptionResponse.
+func schemaName(t reflect.Type) string {
+	segments := strings.Split(t.PkgPath(), "/")
+	name := segments[len(segments)-1] + "." + t.Name()
+	if len(segments) > 1 && versionSegment.MatchString(segments[len(segments)-2]) {
+		name = segments[len(segments)-2] + "." + name
+	}
+	return name
+}
+
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitempty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		// Встроенная структура без json-имени раскрывается в поля родителя.
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := g.structSchema(embedded)
+				for prop, propSchema := range inner.Properties {
+					schema.Properties[prop] = propSchema
+				}
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+
+		prop := g.fieldSchema(field)
+		schema.Properties[name] = prop
+
+		if isRequired(field, omitempty) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	if len(schema.Properties) == 0 {
+		schema.Properties = nil
+	}
+	return schema
+}
+
+func jsonName(field reflect.StructField) (name string, omitempty, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false
+}
+
+// isRequired: поле обязательно, если этого требует binding, а для полей без
+// binding — если оно всегда присутствует в JSON (без omitempty, возможно null).
+func isRequired(field reflect.StructField, omitempty bool) bool {
+	binding, hasBinding := field.Tag.Lookup("binding")
+	if hasBinding {
+		for _, rule := range strings.Split(binding, ",") {
+			if rule == "required" {
+				return true
+			}
+		}
+		return false
+	}
+	return !omitempty
+}
+
+func (g *Generator) fieldSchema(field reflect.StructField) *Schema {
+	schema := g.typeSchema(field.Type)
+	if swaggerType := field.Tag.Get("swaggertype"); swaggerType != "" {
+		schema = swaggerTypeSchema(swaggerType)
+		if field.Type.Kind() == reflect.Pointer {
+			schema = nullable(schema)
+		}
+	}
+
+	if schema.Ref != "" || len(schema.AllOf) > 0 {
+		return schema
+	}
+
+	// Ограничения относятся к элементам, если поле — массив строк.
+	target := schema
+	if schema.Type == "array" && schema.Items != nil && schema.Items.Ref == "" {
+		target = schema.Items
+	}
+
+	applyBinding(target, field.Tag.Get("binding"))
+	applyDocTags(schema, target, field)
+	return schema
+}
+
+// swaggerTypeSchema разбирает swaggertype: "object", "object,string", "string".
+func swaggerTypeSchema(value string) *Schema {
+	kind, elem, _ := strings.Cut(value, ",")
+	switch kind {
+	case "object":
+		schema := &Schema{Type: "object", AdditionalProperties: &Schema{}}
+		if elem != "" {
+			schema.AdditionalProperties = &Schema{Type: elem}
+		}
+		return schema
+	case "array":
+		return &Schema{Type: "array", Items: &Schema{Type: elem}}
+	default:
+		return &Schema{Type: kind}
+	}
+}
+
+func applyBinding(schema *Schema, binding string) {
+	for _, rule := range strings.Split(binding, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "uuid", "uuid4":
+			schema.Format = "uuid"
+		case "oneof":
+			schema.Enum = parseEnum(schema.Type, strings.Fields(value))
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			setBound(schema, key == "min", n)
+		}
+	}
+}
+
+func setBound(schema *Schema, lower bool, n float64) {
+	switch schema.Type {
+	case "string":
+		length := int(n)
+		if lower {
+			schema.MinLength = &length
+		} else {
+			schema.MaxLength = &length
+		}
+	case "integer", "number":
+		if lower {
+			schema.Minimum = &n
+		} else {
+			schema.Maximum = &n
+		}
+	}
+}
+
+func applyDocTags(schema, target *Schema, field reflect.StructField) {
+	tag := field.Tag
+
+	if format := tag.Get("format"); format != "" {
+		target.Format = format
+	}
+	if enums := tag.Get("enums"); enums != "" {
+		target.Enum = parseEnum(target.Type, strings.Split(enums, ","))
+	}
+	for key, lower := range map[string]bool{"minLength": true, "maxLength": false} {
+		if value := tag.Get(key); value != "" {
+			if n, err := strconv.Atoi(value); err == nil {
+				setBound(target, lower, float64(n))
+			}
+		}
+	}
+	for key, lower := range map[string]bool{"minimum": true, "maximum": false} {
+		if value := tag.Get(key); value != "" {
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				setBound(target, lower, n)
+			}
+		}
+	}
+	if example, ok := tag.Lookup("example"); ok {
+		schema.Example = parseExample(schema, example)
+	}
+}
+
+func parseEnum(kind string, values []string) []any {
+	enum := make([]any, 0, len(values))
+	for _, value := range values {
+		enum = append(enum, parseScalar(kind, strings.TrimSpace(value)))
+	}
+	return enum
+}
+
+func parseExample(schema *Schema, example string) any {
+	switch schema.Type {
+	case "array":
+		items := strings.Split(example, ",")
+		values := make([]any, 0, len(items))
+		kind := ""
+		if schema.Items != nil {
+			kind = schema.Items.Type
+		}
+		for _, item := range items {
+			values = append(values, parseScalar(kind, strings.TrimSpace(item)))
+		}
+		return values
+	case "object":
+		values := make(map[string]any)
+		for _, pair := range strings.Split(example, ",") {
+			key, value, _ := strings.Cut(pair, ":")
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		return values
+	default:
+		return parseScalar(schema.Type, example)
+	}
+}
+
+func parseScalar(kind, value string) any {
+	switch kind {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// nullable помечает схему как допускающую null; ссылку оборачивает в allOf,
+// потому что в OpenAPI 3.0 соседние с $ref ключи игнорируются.
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+	}
+	schema.Nullable = true
+	return schema
+}
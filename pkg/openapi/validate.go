@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Operation возвращает операцию по методу и пути OpenAPI; nil — нет такой.
+func (d *Document) Operation(method, path string) *Operation {
+	item, ok := d.Paths[path]
+	if !ok {
+		return nil
+	}
+	return (*item)[strings.ToLower(method)]
+}
+
+// ValidateResponse проверяет, что ответ операции соответствует документу:
+// код описан, а тело подходит под схему этого кода.
+func (d *Document) ValidateResponse(method, path string, status int, body []byte) error {
+	op := d.Operation(method, path)
+	if op == nil {
+		return fmt.Errorf("%s %s is not documented", method, path)
+	}
+
+	resp, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		return fmt.Errorf("%s %s: status %d is not documented", method, path, status)
+	}
+
+	if len(resp.Content) == 0 {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return fmt.Errorf("%s %s: status %d is documented without a body", method, path, status)
+		}
+		return nil
+	}
+
+	media, ok := resp.Content[jsonContentType]
+	if !ok {
+		// Не-JSON содержимое (например, text/event-stream) схемой не проверяется.
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("%s %s: status %d: body is not JSON: %w", method, path, status, err)
+	}
+	if err := d.Validate(media.Schema, value); err != nil {
+		return fmt.Errorf("%s %s: status %d: %w", method, path, status, err)
+	}
+	return nil
+}
+
+// Validate проверяет значение, разобранное из JSON, по схеме и возвращает
+// все найденные расхождения.
+func (d *Document) Validate(schema *Schema, value any) error {
+	var problems []string
+	d.validate(schema, value, "$", &problems)
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(problems, "; "))
+}
+
+func (d *Document) validate(schema *Schema, value any, path string, problems *[]string) {
+	if schema == nil {
+		return
+	}
+	fail := func(format string, args ...any) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if schema.Ref != "" {
+		resolved, ok := d.Components.Schemas[strings.TrimPrefix(schema.Ref, RefPrefix)]
+		if !ok {
+			fail("unresolved reference %s", schema.Ref)
+			return
+		}
+		d.validate(resolved, value, path, problems)
+		return
+	}
+
+	if value == nil {
+		if !schema.Nullable && (schema.Type != "" || len(schema.AllOf) > 0) {
+			fail("null is not allowed")
+		}
+		return
+	}
+
+	for _, part := range schema.AllOf {
+		d.validate(part, value, path, problems)
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		fail("%v is not one of %v", value, schema.Enum)
+	}
+
+	switch schema.Type {
+	case "":
+		return
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			fail("expected object, got %T", value)
+			return
+		}
+		d.validateObject(schema, object, path, problems)
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			fail("expected array, got %T", value)
+			return
+		}
+		for i, item := range items {
+			d.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			fail("expected string, got %T", value)
+			return
+		}
+		if problem := checkString(schema, s); problem != "" {
+			fail("%s", problem)
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			fail("expected %s, got %T", schema.Type, value)
+			return
+		}
+		if schema.Type == "integer" && n != math.Trunc(n) {
+			fail("expected integer, got %v", n)
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			fail("%v is less than minimum %v", n, *schema.Minimum)
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			fail("%v is greater than maximum %v", n, *schema.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("expected boolean, got %T", value)
+		}
+	default:
+		fail("unknown schema type %q", schema.Type)
+	}
+}
+
+func (d *Document) validateObject(schema *Schema, object map[string]any, path string, problems *[]string) {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			*problems = append(*problems, path+": missing required property "+strconv.Quote(name))
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propPath := path + "." + name
+		if prop, ok := schema.Properties[name]; ok {
+			d.validate(prop, object[name], propPath, problems)
+			continue
+		}
+		switch {
+		case schema.AdditionalProperties != nil:
+			d.validate(schema.AdditionalProperties, object[name], propPath, problems)
+		case schema.Properties != nil:
+			// Поле, которого нет в схеме, — признак расхождения DTO и документа.
+			*problems = append(*problems, propPath+": property is not documented")
+		}
+	}
+}
+
+func checkString(schema *Schema, s string) string {
+	length := len([]rune(s))
+	if schema.MinLength != nil && length < *schema.MinLength {
+		return fmt.Sprintf("length %d is less than %d", length, *schema.MinLength)
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		return fmt.Sprintf("length %d is greater than %d", length, *schema.MaxLength)
+	}
+
+	switch schema.Format {
+	case "uuid":
+		if _, err := uuid.Parse(s); err != nil {
+			return fmt.Sprintf("%q is not a uuid", s)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return fmt.Sprintf("%q is not an RFC 3339 date-time", s)
+		}
+	}
+	return ""
+}
+
+func inEnum(enum []any, value any) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
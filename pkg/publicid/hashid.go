@@ -0,0 +1,102 @@
+package publicid
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
+	// 62^22 > 2^128: 22 символа — минимум, в который без потерь помещается
+	// любой UUID, так что идентификатор не короче UUID, а только непрозрачнее.
+	encodedLength = 22
+)
+
+type HashIDCodec struct {
+	alphabet string
+	index    map[rune]int64
+	prefix   string
+}
+
+func NewHashIDCodec(salt, prefix string) (*HashIDCodec, error) {
+	if salt == "" {
+		return nil, errors.New("hashid salt must not be empty")
+	}
+
+	alphabet := shuffle(defaultAlphabet, salt)
+	index := make(map[rune]int64, len(alphabet))
+	for i, ch := range alphabet {
+		index[ch] = int64(i)
+	}
+
+	return &HashIDCodec{
+		alphabet: alphabet,
+		index:    index,
+		prefix:   prefix,
+	}, nil
+}
+
+func (c *HashIDCodec) Encode(id uuid.UUID) string {
+	base := big.NewInt(int64(len(c.alphabet)))
+	value := new(big.Int).SetBytes(id[:])
+	mod := new(big.Int)
+
+	encoded := make([]byte, encodedLength)
+	for i := encodedLength - 1; i >= 0; i-- {
+		value.DivMod(value, base, mod)
+		encoded[i] = c.alphabet[mod.Int64()]
+	}
+
+	return c.prefix + string(encoded)
+}
+
+func (c *HashIDCodec) Decode(publicID string) (uuid.UUID, error) {
+	if !strings.HasPrefix(publicID, c.prefix) {
+		return uuid.Nil, ErrInvalidID
+	}
+	publicID = strings.TrimPrefix(publicID, c.prefix)
+	if len(publicID) != encodedLength {
+		return uuid.Nil, ErrInvalidID
+	}
+
+	base := big.NewInt(int64(len(c.alphabet)))
+	value := new(big.Int)
+	for _, ch := range publicID {
+		digit, ok := c.index[ch]
+		if !ok {
+			return uuid.Nil, ErrInvalidID
+		}
+		value.Mul(value, base)
+		value.Add(value, big.NewInt(digit))
+	}
+
+	raw := value.Bytes()
+	if len(raw) > len(uuid.UUID{}) {
+		return uuid.Nil, ErrInvalidID
+	}
+
+	var id uuid.UUID
+	copy(id[len(id)-len(raw):], raw)
+	return id, nil
+}
+
+// shuffle — детерминированная перестановка алфавита по соли (как в hashids),
+// чтобы разные инсталляции выдавали разные публичные идентификаторы.
+func shuffle(alphabet, salt string) string {
+	result := []byte(alphabet)
+	saltBytes := []byte(salt)
+
+	for i, v, p := len(result)-1, 0, 0; i > 0; i-- {
+		v %= len(saltBytes)
+		integer := int(saltBytes[v])
+		p += integer
+		j := (integer + v + p) % i
+		result[i], result[j] = result[j], result[i]
+		v++
+	}
+
+	return string(result)
+}
@@ -0,0 +1,73 @@
+package publicid
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ModeUUID   = "uuid"
+	ModeHashID = "hashid"
+)
+
+var ErrInvalidID = errors.New("invalid public id")
+
+type Codec interface {
+	Encode(id uuid.UUID) string
+	Decode(publicID string) (uuid.UUID, error)
+}
+
+type Config struct {
+	Mode   string
+	Salt   string
+	Prefix string
+	// AcceptUUID — на время перехода на hashid Decode принимает и исходный
+	// UUID, чтобы клиенты со старыми ссылками продолжали работать.
+	AcceptUUID bool
+}
+
+func NewCodec(cfg Config) (Codec, error) {
+	switch strings.ToLower(cfg.Mode) {
+	case "", ModeUUID:
+		return UUIDCodec{}, nil
+	case ModeHashID:
+		codec, err := NewHashIDCodec(cfg.Salt, cfg.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.AcceptUUID {
+			return transitionCodec{codec}, nil
+		}
+		return codec, nil
+	default:
+		return nil, errors.New("unknown public id mode: " + cfg.Mode)
+	}
+}
+
+// transitionCodec выдаёт публичные идентификаторы, но принимает и исходные UUID.
+type transitionCodec struct {
+	Codec
+}
+
+func (c transitionCodec) Decode(publicID string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(publicID); err == nil {
+		return id, nil
+	}
+	return c.Codec.Decode(publicID)
+}
+
+type UUIDCodec struct{}
+
+func (UUIDCodec) Encode(id uuid.UUID) string {
+	return id.String()
+}
+
+func (UUIDCodec) Decode(publicID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(publicID)
+	if err != nil {
+		return uuid.Nil, ErrInvalidID
+	}
+	return id, nil
+}
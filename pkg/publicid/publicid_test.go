@@ -0,0 +1,125 @@
+package publicid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewCodec_RawUUIDIsOptIn(t *testing.T) {
+	id := uuid.MustParse("6f1c2a4e-8b3d-4c5a-9e7f-0a1b2c3d4e5f")
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		rawOK   bool
+		encoded string
+	}{
+		{name: "uuid mode", cfg: Config{Mode: ModeUUID}, rawOK: true, encoded: id.String()},
+		{name: "hashid", cfg: Config{Mode: ModeHashID, Salt: "pepper", Prefix: "sub_"}},
+		{name: "hashid during transition", cfg: Config{Mode: ModeHashID, Salt: "pepper", Prefix: "sub_", AcceptUUID: true}, rawOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec, err := NewCodec(tt.cfg)
+			if err != nil {
+				t.Fatalf("NewCodec() error = %v", err)
+			}
+
+			encoded := codec.Encode(id)
+			if tt.encoded != "" && encoded != tt.encoded {
+				t.Errorf("Encode() = %q, want %q", encoded, tt.encoded)
+			}
+			if !strings.HasPrefix(encoded, tt.cfg.Prefix) {
+				t.Errorf("Encode() = %q, want prefix %q", encoded, tt.cfg.Prefix)
+			}
+			if decoded, err := codec.Decode(encoded); err != nil || decoded != id {
+				t.Errorf("Decode(Encode()) = %v, %v; want %v", decoded, err, id)
+			}
+
+			decoded, err := codec.Decode(id.String())
+			if tt.rawOK {
+				if err != nil || decoded != id {
+					t.Errorf("Decode(raw UUID) = %v, %v; want %v", decoded, err, id)
+				}
+			} else if !errors.Is(err, ErrInvalidID) {
+				t.Errorf("Decode(raw UUID) error = %v, want ErrInvalidID", err)
+			}
+		})
+	}
+}
+
+func TestHashIDCodec_DependsOnSalt(t *testing.T) {
+	id := uuid.New()
+	first, _ := NewHashIDCodec("pepper", "sub_")
+	second, _ := NewHashIDCodec("salt", "sub_")
+
+	if first.Encode(id) == second.Encode(id) {
+		t.Error("different salts produced the same public id")
+	}
+	if decoded, _ := second.Decode(first.Encode(id)); decoded == id {
+		t.Error("a public id decoded to the same UUID under another salt")
+	}
+}
+
+func TestRegistry_PerTenantCodecs(t *testing.T) {
+	id := uuid.New()
+	shared := Config{Mode: ModeHashID, Salt: "pepper", Prefix: "sub_"}
+	registry, err := NewRegistry(shared, map[string]Config{
+		"acme":    {Mode: ModeHashID, Salt: "acme-salt", Prefix: "acme_"},
+		"initech": {Mode: ModeUUID},
+	})
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	acme := registry.For("acme").Encode(id)
+	if !strings.HasPrefix(acme, "acme_") {
+		t.Errorf("tenant id = %q, want prefix acme_", acme)
+	}
+	if got := registry.For("initech").Encode(id); got != id.String() {
+		t.Errorf("uuid tenant id = %q, want %q", got, id)
+	}
+	common := registry.For("unknown").Encode(id)
+	if !strings.HasPrefix(common, "sub_") || common != registry.Shared().Encode(id) {
+		t.Errorf("unknown tenant id = %q, want the shared one", common)
+	}
+
+	// Ссылку арендатора открывает любой кодек реестра, и наоборот.
+	for _, tenant := range []string{"acme", "initech", ""} {
+		codec := registry.For(tenant)
+		for _, publicID := range []string{acme, common} {
+			if decoded, err := codec.Decode(publicID); err != nil || decoded != id {
+				t.Errorf("For(%q).Decode(%q) = %v, %v; want %v", tenant, publicID, decoded, err, id)
+			}
+		}
+	}
+}
+
+func TestNewRegistry_RejectsAmbiguousPrefixes(t *testing.T) {
+	shared := Config{Mode: ModeHashID, Salt: "pepper", Prefix: "sub_"}
+
+	tests := []struct {
+		name    string
+		tenants map[string]Config
+	}{
+		{name: "no prefix", tenants: map[string]Config{"acme": {Mode: ModeHashID, Salt: "s"}}},
+		{name: "same as shared", tenants: map[string]Config{"acme": {Mode: ModeHashID, Salt: "s", Prefix: "sub_"}}},
+		{name: "extends shared", tenants: map[string]Config{"acme": {Mode: ModeHashID, Salt: "s", Prefix: "sub_acme_"}}},
+		{name: "two tenants", tenants: map[string]Config{
+			"acme":   {Mode: ModeHashID, Salt: "s", Prefix: "ac_"},
+			"acme-2": {Mode: ModeHashID, Salt: "t", Prefix: "ac_"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRegistry(shared, tt.tenants); err == nil {
+				t.Error("NewRegistry() error = nil, want a prefix conflict")
+			}
+		})
+	}
+}
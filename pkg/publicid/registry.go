@@ -0,0 +1,121 @@
+package publicid
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Registry — кодеки по арендаторам. Арендатор со своими настройками получает
+// свой кодек, остальные — общий. Decode любого кодека реестра узнаёт
+// идентификаторы арендаторов по префиксу, поэтому ссылку, выданную одному
+// клиенту, откроет и другой, если у него есть доступ к подписке.
+type Registry struct {
+	shared  Codec
+	tenants map[string]Codec
+}
+
+// route — префикс hashid-идентификаторов арендатора и его кодек.
+type route struct {
+	prefix string
+	codec  Codec
+}
+
+func NewRegistry(shared Config, tenants map[string]Config) (*Registry, error) {
+	base, err := NewCodec(shared)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(tenants))
+	for tenant := range tenants {
+		names = append(names, tenant)
+	}
+	sort.Strings(names)
+
+	codecs := make(map[string]Codec, len(tenants))
+	routes := make([]route, 0, len(tenants))
+	for _, tenant := range names {
+		cfg := tenants[tenant]
+		codec, err := NewCodec(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("public ids of tenant %s: %w", tenant, err)
+		}
+		codecs[tenant] = codec
+		if strings.EqualFold(cfg.Mode, ModeHashID) {
+			routes = append(routes, route{prefix: cfg.Prefix, codec: codec})
+		}
+	}
+
+	if err := checkPrefixes(shared, routes); err != nil {
+		return nil, err
+	}
+
+	registry := &Registry{
+		shared:  &routedCodec{Codec: base, routes: routes},
+		tenants: make(map[string]Codec, len(codecs)),
+	}
+	for tenant, codec := range codecs {
+		registry.tenants[tenant] = &routedCodec{Codec: codec, routes: routes, shared: base}
+	}
+	return registry, nil
+}
+
+// checkPrefixes требует у каждого арендатора с hashid свой префикс: по
+// нему Decode находит кодек, так что ни один префикс не может начинаться
+// с другого.
+func checkPrefixes(shared Config, routes []route) error {
+	prefixes := make([]string, 0, len(routes)+1)
+	if strings.EqualFold(shared.Mode, ModeHashID) && shared.Prefix != "" {
+		prefixes = append(prefixes, shared.Prefix)
+	}
+	for _, r := range routes {
+		if r.prefix == "" {
+			return fmt.Errorf("tenant public ids in %s mode need their own prefix", ModeHashID)
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(r.prefix, prefix) || strings.HasPrefix(prefix, r.prefix) {
+				return fmt.Errorf("tenant public id prefix %q overlaps %q", r.prefix, prefix)
+			}
+		}
+		prefixes = append(prefixes, r.prefix)
+	}
+	return nil
+}
+
+// For — кодек арендатора; пустой или неизвестный арендатор получает общий.
+func (r *Registry) For(tenant string) Codec {
+	if codec, ok := r.tenants[tenant]; ok {
+		return codec
+	}
+	return r.shared
+}
+
+// Shared — общий кодек для вызовов без арендатора (команды биллинга).
+func (r *Registry) Shared() Codec {
+	return r.shared
+}
+
+// routedCodec кодирует своим кодеком, а разбирает идентификатор с
+// префиксом арендатора кодеком этого арендатора. Остальное разбирает свой
+// кодек, а у арендатора, если свой не подошёл, — общий.
+type routedCodec struct {
+	Codec
+	routes []route
+	shared Codec
+}
+
+func (c *routedCodec) Decode(publicID string) (uuid.UUID, error) {
+	for _, r := range c.routes {
+		if strings.HasPrefix(publicID, r.prefix) {
+			return r.codec.Decode(publicID)
+		}
+	}
+	id, err := c.Codec.Decode(publicID)
+	if err != nil && c.shared != nil {
+		return c.shared.Decode(publicID)
+	}
+	return id, err
+}
@@ -0,0 +1,137 @@
+// Package shutdown — упорядоченный реестр хуков остановки приложения.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+)
+
+// Phase задаёт место хука в последовательности остановки. Фазы идут по
+// возрастанию: пока сервер не дослал ответы, воркеры и база ещё нужны.
+type Phase int
+
+const (
+	// PhaseServer — прекращение приёма запросов и ожидание текущих.
+	PhaseServer Phase = iota
+	// PhaseWorkers — фоновые задачи, потребители очередей, вотчдог.
+	PhaseWorkers
+	// PhaseOutbox — досылка асинхронных событий и шины.
+	PhaseOutbox
+	// PhaseDatabase — закрытие пула соединений.
+	PhaseDatabase
+	// PhaseLogger — сброс буферов логгера, всегда последним.
+	PhaseLogger
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseServer:
+		return "server"
+	case PhaseWorkers:
+		return "workers"
+	case PhaseOutbox:
+		return "outbox"
+	case PhaseDatabase:
+		return "database"
+	case PhaseLogger:
+		return "logger"
+	default:
+		return fmt.Sprintf("phase(%d)", int(p))
+	}
+}
+
+type Hook func(ctx context.Context) error
+
+type hook struct {
+	phase Phase
+	name  string
+	fn    Hook
+}
+
+/*
+Registry выполняет хуки по фазам, внутри фазы — в порядке регистрации.
+Ошибка хука не прерывает остановку: остальные ресурсы всё равно нужно
+освободить, ошибки возвращаются вместе. Повторный Run ничего не делает,
+поэтому Close можно звать и из обработчика сигнала, и из defer.
+*/
+type Registry struct {
+	mu    sync.Mutex
+	hooks []hook
+	ran   bool
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register добавляет хук. Хуки, зарегистрированные после Run, не вызываются.
+func (r *Registry) Register(phase Phase, name string, fn Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, hook{phase: phase, name: name, fn: fn})
+}
+
+// Names — имена хуков в порядке выполнения, для логов и тестов.
+func (r *Registry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ordered := r.ordered()
+	names := make([]string, len(ordered))
+	for i, h := range ordered {
+		names[i] = h.name
+	}
+	return names
+}
+
+// Run выполняет хуки. Лог пишется до фазы логгера, после неё им
+// пользоваться уже нельзя.
+func (r *Registry) Run(ctx context.Context, log *logger.Logger) error {
+	r.mu.Lock()
+	if r.ran {
+		r.mu.Unlock()
+		return nil
+	}
+	r.ran = true
+	ordered := r.ordered()
+	r.mu.Unlock()
+
+	var errs []error
+	for _, h := range ordered {
+		start := time.Now()
+		err := h.fn(ctx)
+		if log != nil && h.phase < PhaseLogger {
+			fields := []zap.Field{
+				zap.String("phase", h.phase.String()),
+				zap.String("hook", h.name),
+				zap.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				log.Error("shutdown hook failed", append(fields, zap.Error(err))...)
+			} else {
+				log.Debug("shutdown hook completed", fields...)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Registry) ordered() []hook {
+	ordered := make([]hook, len(r.hooks))
+	copy(ordered, r.hooks)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].phase < ordered[j].phase
+	})
+	return ordered
+}
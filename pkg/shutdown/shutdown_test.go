@@ -0,0 +1,77 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegistryRunsPhasesInOrder(t *testing.T) {
+	r := NewRegistry()
+	var got []string
+	record := func(name string) Hook {
+		return func(context.Context) error {
+			got = append(got, name)
+			return nil
+		}
+	}
+
+	r.Register(PhaseLogger, "logger", record("logger"))
+	r.Register(PhaseDatabase, "postgres", record("postgres"))
+	r.Register(PhaseWorkers, "scheduler", record("scheduler"))
+	r.Register(PhaseServer, "http_server", record("http_server"))
+	r.Register(PhaseWorkers, "watchdog", record("watchdog"))
+	r.Register(PhaseOutbox, "event_bus", record("event_bus"))
+	r.Register(PhaseServer, "in_flight", record("in_flight"))
+
+	want := []string{"http_server", "in_flight", "scheduler", "watchdog", "event_bus", "postgres", "logger"}
+	if names := r.Names(); !reflect.DeepEqual(names, want) {
+		t.Fatalf("Names() = %v, want %v", names, want)
+	}
+
+	if err := r.Run(context.Background(), nil); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("run order = %v, want %v", got, want)
+	}
+}
+
+func TestRegistryContinuesAfterFailure(t *testing.T) {
+	r := NewRegistry()
+	errServer := errors.New("drain timeout")
+	closed := false
+
+	r.Register(PhaseServer, "http_server", func(context.Context) error { return errServer })
+	r.Register(PhaseDatabase, "postgres", func(context.Context) error {
+		closed = true
+		return nil
+	})
+
+	err := r.Run(context.Background(), nil)
+	if !errors.Is(err, errServer) {
+		t.Fatalf("Run() error = %v, want %v", err, errServer)
+	}
+	if !closed {
+		t.Fatal("database hook was skipped after server hook failure")
+	}
+}
+
+func TestRegistryRunsOnce(t *testing.T) {
+	r := NewRegistry()
+	calls := 0
+	r.Register(PhaseDatabase, "postgres", func(context.Context) error {
+		calls++
+		return nil
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := r.Run(context.Background(), nil); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("hook called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,48 @@
+package testutil
+
+import (
+	"context"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/models"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+)
+
+// Ключи StaticAuth: по одному на роль.
+const (
+	AdminKey    = "test-admin-key"
+	OperatorKey = "test-operator-key"
+	ViewerKey   = "test-viewer-key"
+)
+
+/*
+StaticAuth — AuthService для тестов: принимает ключи и токены из Keys и
+отдаёт клиента с соответствующей ролью. Управление ключами не реализовано,
+его вызов — паника встроенного nil-интерфейса.
+*/
+type StaticAuth struct {
+	service.AuthService
+	Keys map[string]models.Role
+}
+
+// NewStaticAuth знает AdminKey, OperatorKey и ViewerKey.
+func NewStaticAuth() *StaticAuth {
+	return &StaticAuth{Keys: map[string]models.Role{
+		AdminKey:    models.RoleAdmin,
+		OperatorKey: models.RoleOperator,
+		ViewerKey:   models.RoleViewer,
+	}}
+}
+
+func (a *StaticAuth) Authenticate(_ context.Context, apiKey, bearerToken string) (*models.Principal, error) {
+	if role, ok := a.Keys[apiKey]; ok && apiKey != "" {
+		return models.NewPrincipal(apiKey, role, models.PrincipalSourceAPIKey), nil
+	}
+	if role, ok := a.Keys[bearerToken]; ok && bearerToken != "" {
+		return models.NewPrincipal(bearerToken, role, models.PrincipalSourceJWT), nil
+	}
+	if apiKey == "" && bearerToken == "" {
+		return nil, apperror.Unauthorized("missing credentials")
+	}
+	return nil, apperror.Unauthorized("invalid credentials")
+}
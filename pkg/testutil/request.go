@@ -0,0 +1,79 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+)
+
+// RequestOption дополняет запрос перед отправкой.
+type RequestOption func(*http.Request)
+
+// WithAPIKey передаёт ключ в X-API-Key.
+func WithAPIKey(key string) RequestOption {
+	return WithHeader(middleware.APIKeyHeader, key)
+}
+
+// WithBearer передаёт токен в Authorization: Bearer.
+func WithBearer(token string) RequestOption {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithHeader задаёт произвольный заголовок.
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+/*
+NewRequest строит запрос к target. body: nil — без тела, string и []byte
+уходят как есть (удобно для заведомо битого JSON), остальное кодируется в
+JSON. Для непустого тела выставляется Content-Type: application/json.
+*/
+func NewRequest(t testing.TB, method, target string, body any, opts ...RequestOption) *http.Request {
+	t.Helper()
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(b)
+	case []byte:
+		reader = bytes.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("encode request body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+// Serve прогоняет запрос через handler и возвращает записанный ответ.
+func Serve(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// Do — NewRequest и Serve одним вызовом.
+func Do(t testing.TB, handler http.Handler, method, target string, body any, opts ...RequestOption) *httptest.ResponseRecorder {
+	t.Helper()
+	return Serve(handler, NewRequest(t, method, target, body, opts...))
+}
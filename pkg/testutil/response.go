@@ -0,0 +1,40 @@
+package testutil
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/transport/http/dto/response"
+)
+
+// AssertStatus валит тест, если код ответа не want; тело попадает в сообщение.
+func AssertStatus(t testing.TB, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, want, rec.Body.String())
+	}
+}
+
+// DecodeJSON разбирает тело ответа в T, например response.SubscriptionResponse.
+func DecodeJSON[T any](t testing.TB, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode %T: %v; body: %s", v, err, rec.Body.String())
+	}
+	return v
+}
+
+// DecodeError разбирает тело ответа с ошибкой и проверяет её код.
+func DecodeError(t testing.TB, rec *httptest.ResponseRecorder, wantCode string) response.ErrorResponse {
+	t.Helper()
+
+	body := DecodeJSON[response.ErrorResponse](t, rec)
+	if body.Error.Code != wantCode {
+		t.Fatalf("error code = %q, want %q; body: %s", body.Error.Code, wantCode, rec.Body.String())
+	}
+	return body
+}
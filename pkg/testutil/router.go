@@ -0,0 +1,112 @@
+/*
+Package testutil — общие заготовки для тестов HTTP-слоя: gin-роутер с той же
+цепочкой middleware, что и в приложении, запросы с учётными данными и
+декодирование DTO из ответа. Пакет импортируют только _test.go файлы.
+*/
+package testutil
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/middleware"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/domain/ports/service"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/logger"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/utils"
+)
+
+// RouterOptions — что подключить к тестовому роутеру.
+type RouterOptions struct {
+	// Versions — группы /api/<Name>; удобно собирать через V1 и V2.
+	Versions []router.APIVersion
+	// Health — хендлер проб на корне (/health/...), как в приложении.
+	Health router.RouteHandler
+	// Auth включает middleware.Authorize в каждой версии; nil — без аутентификации.
+	Auth service.AuthService
+	// MaxBodySize > 0 подключает middleware.BodyLimit.
+	MaxBodySize int64
+	// Middlewares выполняются первыми, до логгера и обработки ошибок.
+	Middlewares []gin.HandlerFunc
+	// Logger по умолчанию — NewLogger(t).
+	Logger *logger.Logger
+	// BodyLog — тела в access-логе; по умолчанию не пишутся.
+	BodyLog middleware.BodyLogOptions
+}
+
+// NewLogger возвращает логгер, который молчит в выводе тестов.
+func NewLogger(t testing.TB) *logger.Logger {
+	t.Helper()
+
+	log, err := logger.NewLogger(logger.Config{Level: "fatal"})
+	if err != nil {
+		t.Fatalf("logger: %v", err)
+	}
+	return log
+}
+
+// V1 — группа v1 с форматом дат по умолчанию (MM-YYYY).
+func V1(handlers ...router.RouteHandler) router.APIVersion {
+	return Version("v1", utils.DateFormatMonthYear, handlers...)
+}
+
+// V2 — группа v2 с датами в ISO (YYYY-MM).
+func V2(handlers ...router.RouteHandler) router.APIVersion {
+	return Version("v2", utils.DateFormatISO, handlers...)
+}
+
+// Version — группа /api/<name> с middleware.DateFormat(dateFormat).
+func Version(name string, dateFormat utils.DateFormat, handlers ...router.RouteHandler) router.APIVersion {
+	return router.APIVersion{
+		Name:        name,
+		Middlewares: []gin.HandlerFunc{middleware.DateFormat(dateFormat)},
+		Handlers:    handlers,
+	}
+}
+
+/*
+NewRouter собирает движок в порядке app.initRouter: StructuredLogger,
+Recovery, ErrorHandler и BodyLimit, затем версии API. Необязательные
+middleware приложения (сжатие, тайминги, снапшоты) тесты подключают сами
+через Middlewares или собственную цепочку.
+*/
+func NewRouter(t testing.TB, opts RouterOptions) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	log := opts.Logger
+	if log == nil {
+		log = NewLogger(t)
+	}
+
+	r := router.New(router.RouterConfig{Debug: true, Logger: log})
+
+	middlewares := append([]gin.HandlerFunc{}, opts.Middlewares...)
+	middlewares = append(middlewares,
+		middleware.StructuredLogger(log, opts.BodyLog),
+		middleware.Recovery(log, apperror.DefaultProblemTypeBase),
+		middleware.ErrorHandler(log, apperror.DefaultProblemTypeBase),
+	)
+	if opts.MaxBodySize > 0 {
+		middlewares = append(middlewares, middleware.BodyLimit(opts.MaxBodySize))
+	}
+	r.SetupMiddleware(middlewares...)
+
+	if opts.Health != nil {
+		r.RegisterHealthRoutes(opts.Health)
+	}
+
+	versions := make([]router.APIVersion, 0, len(opts.Versions))
+	for _, version := range opts.Versions {
+		version.Middlewares = append([]gin.HandlerFunc{}, version.Middlewares...)
+		if opts.Auth != nil {
+			version.Middlewares = append(version.Middlewares, middleware.Authorize(opts.Auth, "/api/"+version.Name, log))
+		}
+		versions = append(versions, version)
+	}
+	r.RegisterAPIRoutes(versions...)
+
+	return r.Engine()
+}
@@ -0,0 +1,72 @@
+package testutil_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/vagonaizer/effective-mobile/subscription-service/internal/delivery/http/router"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/apperror"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/openapi"
+	"github.com/vagonaizer/effective-mobile/subscription-service/pkg/testutil"
+)
+
+type echoHandler struct{}
+
+func (echoHandler) Routes() []openapi.Route { return nil }
+
+func (echoHandler) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/subscriptions/", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"service_name": "Netflix"})
+	})
+	group.POST("/subscriptions/", func(c *gin.Context) {
+		var body struct {
+			ServiceName string `json:"service_name"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(apperror.InvalidInput("body", err.Error()))
+			return
+		}
+		c.JSON(http.StatusCreated, body)
+	})
+}
+
+func TestRouterWithAuth(t *testing.T) {
+	engine := testutil.NewRouter(t, testutil.RouterOptions{
+		Versions: []router.APIVersion{testutil.V1(echoHandler{})},
+		Auth:     testutil.NewStaticAuth(),
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		body   any
+		opts   []testutil.RequestOption
+		status int
+		code   string
+	}{
+		{"no credentials", http.MethodGet, nil, nil, http.StatusUnauthorized, apperror.CodeUnauthorized},
+		{"unknown key", http.MethodGet, nil, []testutil.RequestOption{testutil.WithAPIKey("nope")}, http.StatusUnauthorized, apperror.CodeUnauthorized},
+		{"viewer reads", http.MethodGet, nil, []testutil.RequestOption{testutil.WithAPIKey(testutil.ViewerKey)}, http.StatusOK, ""},
+		{"viewer cannot write", http.MethodPost, map[string]string{"service_name": "Netflix"}, []testutil.RequestOption{testutil.WithAPIKey(testutil.ViewerKey)}, http.StatusForbidden, apperror.CodeForbidden},
+		{"operator writes with bearer", http.MethodPost, map[string]string{"service_name": "Netflix"}, []testutil.RequestOption{testutil.WithBearer(testutil.OperatorKey)}, http.StatusCreated, ""},
+		{"malformed body", http.MethodPost, `{"service_name":`, []testutil.RequestOption{testutil.WithAPIKey(testutil.AdminKey)}, http.StatusBadRequest, apperror.CodeInvalidInput},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := testutil.Do(t, engine, tc.method, "/api/v1/subscriptions/", tc.body, tc.opts...)
+			testutil.AssertStatus(t, rec, tc.status)
+
+			if tc.code != "" {
+				testutil.DecodeError(t, rec, tc.code)
+				return
+			}
+			got := testutil.DecodeJSON[map[string]string](t, rec)
+			if got["service_name"] != "Netflix" {
+				t.Errorf("service_name = %q, want Netflix", got["service_name"])
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package timing
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type contextKey struct{}
+
+type Recorder struct {
+	start     time.Time
+	dbNanos   atomic.Int64
+	dbQueries atomic.Int64
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{start: time.Now()}
+}
+
+func WithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, contextKey{}, recorder)
+}
+
+func FromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(contextKey{}).(*Recorder)
+	return recorder
+}
+
+func (r *Recorder) ObserveDB(duration time.Duration) {
+	r.dbNanos.Add(int64(duration))
+	r.dbQueries.Add(1)
+}
+
+func (r *Recorder) Total() time.Duration {
+	return time.Since(r.start)
+}
+
+func (r *Recorder) DB() time.Duration {
+	return time.Duration(r.dbNanos.Load())
+}
+
+func (r *Recorder) DBQueries() int {
+	return int(r.dbQueries.Load())
+}
+
+func Milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	zeroWidthNonJoiner = '\u200C'
+	zeroWidthJoiner    = '\u200D'
+	emojiPresentation  = '\uFE0F'
+	blackFlag          = '\U0001F3F4'
+	tagFirst           = '\U000E0020'
+	tagLast            = '\U000E007F'
+)
+
+/*
+SanitizeText приводит текст от клиента (названия сервисов и фильтры по
+ним) к виду, в котором его можно хранить и сравнивать:
+  - невалидные UTF-8 последовательности удаляются;
+  - любые пробельные символы, включая переводы строк, табуляцию и
+    неразрывный пробел, схлопываются в один пробел, края обрезаются;
+  - управляющие (Cc) и невидимые форматирующие (Cf) символы удаляются:
+    пробелы нулевой ширины, BOM, мягкий перенос, метки и переопределения
+    направления текста. Остаются только те, без которых текст выглядит
+    иначе: ZWJ внутри эмодзи-последовательностей (семья, флаг-радуга),
+    ZWNJ между арабскими буквами (персидский) и теги флагов регионов
+    (Шотландия, Уэльс);
+  - результат приводится к NFC, так что e с комбинируемым U+0301
+    совпадает с готовой é.
+
+Направление арабского и еврейского текста определяется самими буквами,
+поэтому удаление меток направления его не портит.
+*/
+func SanitizeText(s string) string {
+	runes := []rune(strings.ToValidUTF8(s, ""))
+
+	var b strings.Builder
+	b.Grow(len(s))
+
+	var last rune
+	pendingSpace := false
+	for i, r := range runes {
+		if unicode.IsSpace(r) {
+			pendingSpace = b.Len() > 0
+			last = ' '
+			continue
+		}
+		if isInvisible(r) && !keepsInvisible(r, last, next(runes, i)) {
+			continue
+		}
+
+		if pendingSpace {
+			b.WriteByte(' ')
+			pendingSpace = false
+		}
+		b.WriteRune(r)
+		last = r
+	}
+
+	return norm.NFC.String(b.String())
+}
+
+func isInvisible(r rune) bool {
+	return unicode.In(r, unicode.Cc, unicode.Cf)
+}
+
+// keepsInvisible решает, меняет ли невидимый символ r отображение текста
+// между last (последний оставленный символ) и next.
+func keepsInvisible(r, last, next rune) bool {
+	switch {
+	case r == zeroWidthJoiner:
+		return isEmoji(last) && isEmoji(next)
+	case r == zeroWidthNonJoiner:
+		return unicode.Is(unicode.Arabic, last) && unicode.Is(unicode.Arabic, next)
+	case r >= tagFirst && r <= tagLast:
+		return last == blackFlag || (last >= tagFirst && last <= tagLast)
+	default:
+		return false
+	}
+}
+
+// isEmoji — символ, который может стоять по краям ZWJ в эмодзи: сам
+// значок, селектор эмодзи-представления или модификатор цвета кожи.
+func isEmoji(r rune) bool {
+	return unicode.In(r, unicode.So, unicode.Sk) || r == emojiPresentation
+}
+
+func next(runes []rune, i int) rune {
+	if i+1 < len(runes) {
+		return runes[i+1]
+	}
+	return 0
+}
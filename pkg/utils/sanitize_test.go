@@ -0,0 +1,81 @@
+package utils
+
+import "testing"
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "plain text", input: "Yandex Plus", want: "Yandex Plus"},
+		{name: "empty", input: "", want: ""},
+
+		{name: "whitespace is trimmed and collapsed", input: "  Yandex \t\n  Plus  ", want: "Yandex Plus"},
+		{name: "no-break and ideographic spaces", input: "Yandex\u00A0Plus\u3000Family", want: "Yandex Plus Family"},
+		{name: "whitespace only", input: " \t\r\n\u00A0", want: ""},
+
+		{name: "control characters", input: "Net\x00fl\x07ix\x7f", want: "Netflix"},
+		{name: "invalid UTF-8", input: "Net\xfflix", want: "Netlix"},
+
+		{name: "zero-width space inside a word", input: "Net\u200Bflix", want: "Netflix"},
+		{name: "byte order mark", input: "\uFEFFNetflix", want: "Netflix"},
+		{name: "word joiner and soft hyphen", input: "Spo\u2060ti\u00ADfy", want: "Spotify"},
+		{name: "zero-width joiner between letters", input: "Net\u200Dflix", want: "Netflix"},
+		{name: "zero-width non-joiner between latin letters", input: "Net\u200Cflix", want: "Netflix"},
+		{name: "invisible characters only", input: "\u200B\u200C\u200D\uFEFF", want: ""},
+		{name: "zero-width space between words", input: "Yandex \u200B Plus", want: "Yandex Plus"},
+
+		{name: "emoji", input: "Netflix 🎬", want: "Netflix 🎬"},
+		{name: "emoji ZWJ sequence", input: "Family \U0001F468\u200D\U0001F469\u200D\U0001F467", want: "Family \U0001F468\u200D\U0001F469\u200D\U0001F467"},
+		{name: "emoji with skin tone in ZWJ sequence", input: "\U0001F469\U0001F3FD\u200D\U0001F4BB Dev", want: "\U0001F469\U0001F3FD\u200D\U0001F4BB Dev"},
+		{name: "rainbow flag", input: "Pride \U0001F3F3\uFE0F\u200D\U0001F308", want: "Pride \U0001F3F3\uFE0F\u200D\U0001F308"},
+		{name: "subdivision flag tags", input: "\U0001F3F4\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F TV", want: "\U0001F3F4\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F TV"},
+		{name: "dangling joiner after emoji", input: "Music 🎵\u200D", want: "Music 🎵"},
+		{name: "stray tag characters", input: "Net\U000E0067flix", want: "Netflix"},
+
+		{name: "hebrew", input: "שירות מוזיקה", want: "שירות מוזיקה"},
+		{name: "arabic with right-to-left mark", input: "\u200Fخدمة موسيقى\u200F", want: "خدمة موسيقى"},
+		{name: "bidi override spoofing", input: "Netflix \u202Excod.exe\u202C", want: "Netflix xcod.exe"},
+		{name: "bidi isolates", input: "\u2067قناة\u2069 Plus", want: "قناة Plus"},
+		{name: "persian zero-width non-joiner is kept", input: "می\u200Cخواهم", want: "می\u200Cخواهم"},
+
+		{name: "decomposed accent is composed", input: "Cafe\u0301", want: "Café"},
+		{name: "cyrillic with decomposed short i", input: "Кинои\u0306", want: "Киной"},
+		{name: "accent after removed zero-width space", input: "Cafe\u200B\u0301", want: "Café"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeText(tt.input)
+			if got != tt.want {
+				t.Errorf("SanitizeText(%+q) = %+q, want %+q", tt.input, got, tt.want)
+			}
+			if again := SanitizeText(got); again != got {
+				t.Errorf("SanitizeText is not idempotent: %+q -> %+q", got, again)
+			}
+		})
+	}
+}
+
+func TestValidateServiceName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "valid", input: "Netflix"},
+		{name: "blank", input: "   ", wantErr: true},
+		{name: "zero-width characters only", input: "\u200B\u2060\uFEFF", wantErr: true},
+		{name: "emoji only", input: "🎬"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateServiceName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateServiceName(%+q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
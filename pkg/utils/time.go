@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -10,6 +11,69 @@ import (
 
 const DateLayout = "01-2006"
 
+// ISOMonthLayout — формат месяца ISO 8601 (YYYY-MM), используемый в API v2.
+const ISOMonthLayout = "2006-01"
+
+// ISODateLayout — полная дата ISO 8601 (YYYY-MM-DD).
+const ISODateLayout = "2006-01-02"
+
+// AcceptedDateFormats перечисляет форматы ParseStartDate и ParseEndDate —
+// для сообщений об ошибке.
+const AcceptedDateFormats = "MM-YYYY, YYYY-MM or YYYY-MM-DD"
+
+// DateFormat — формат дат в ответах API.
+type DateFormat string
+
+const (
+	DateFormatMonthYear DateFormat = "MM-YYYY"
+	DateFormatISO       DateFormat = "YYYY-MM"
+)
+
+// ParseDateFormat разбирает имя формата без учёта регистра; "iso" —
+// синоним YYYY-MM.
+func ParseDateFormat(value string) (DateFormat, bool) {
+	switch strings.ToUpper(strings.TrimSpace(value)) {
+	case string(DateFormatMonthYear):
+		return DateFormatMonthYear, true
+	case string(DateFormatISO), "ISO", "ISO8601":
+		return DateFormatISO, true
+	default:
+		return "", false
+	}
+}
+
+// Format выводит месяц в выбранном формате; неизвестный формат
+// считается MM-YYYY.
+func (f DateFormat) Format(t time.Time) string {
+	if f == DateFormatISO {
+		return FormatISOMonth(t)
+	}
+	return FormatMonthYear(t)
+}
+
+// FormatStart выводит дату начала месяцем, если она совпадает с началом
+// месяца, иначе полной датой YYYY-MM-DD: у MM-YYYY нет варианта с днём.
+func (f DateFormat) FormatStart(t time.Time) string {
+	if t.Equal(StartOfMonth(t)) {
+		return f.Format(t)
+	}
+	return t.Format(ISODateLayout)
+}
+
+// FormatEnd — то же для даты окончания: месяцем, если она совпадает
+// с концом месяца.
+func (f DateFormat) FormatEnd(t time.Time) string {
+	if t.Equal(EndOfMonth(t)) {
+		return f.Format(t)
+	}
+	return t.Format(ISODateLayout)
+}
+
+const (
+	MinYear = 2000
+	MaxYear = 2100
+)
+
 func ParseMonthYear(dateStr string) (time.Time, error) {
 	if dateStr == "" {
 		return time.Time{}, apperror.InvalidDateFormat(dateStr)
@@ -26,17 +90,85 @@ func ParseMonthYear(dateStr string) (time.Time, error) {
 	}
 
 	year, err := strconv.Atoi(parts[1])
-	if err != nil || year < 2000 || year > 2100 {
+	if err != nil || year < MinYear || year > MaxYear {
 		return time.Time{}, apperror.InvalidDateFormat(dateStr)
 	}
 
 	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), nil
 }
 
+/*
+ParseStartDate принимает "07-2025", "2025-07" или "2025-07-15". Месяц
+означает его первый день, полная дата — начало этого дня (UTC).
+*/
+func ParseStartDate(dateStr string) (time.Time, error) {
+	t, hasDay, err := parseDate(dateStr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if hasDay {
+		return StartOfDay(t), nil
+	}
+	return StartOfMonth(t), nil
+}
+
+/*
+ParseEndDate принимает те же форматы, что ParseStartDate. Месяц означает
+его последний момент, полная дата — конец этого дня: граница включается.
+*/
+func ParseEndDate(dateStr string) (time.Time, error) {
+	t, hasDay, err := parseDate(dateStr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if hasDay {
+		return EndOfDay(t), nil
+	}
+	return EndOfMonth(t), nil
+}
+
+// parseDate разбирает любой поддерживаемый формат; hasDay сообщает,
+// был ли указан день.
+func parseDate(dateStr string) (t time.Time, hasDay bool, err error) {
+	switch {
+	case len(dateStr) == len(ISODateLayout):
+		t, err = time.Parse(ISODateLayout, dateStr)
+		hasDay = true
+	case len(dateStr) == len(ISOMonthLayout) && dateStr[4] == '-':
+		t, err = time.Parse(ISOMonthLayout, dateStr)
+	default:
+		t, err = ParseMonthYear(dateStr)
+	}
+
+	if err != nil || t.Year() < MinYear || t.Year() > MaxYear {
+		return time.Time{}, false, apperror.InvalidDateFormatExpecting(dateStr, AcceptedDateFormats)
+	}
+	return t, hasDay, nil
+}
+
+func FormatISOMonth(t time.Time) string {
+	return t.Format(ISOMonthLayout)
+}
+
+func ValidateYear(year int) error {
+	if year < MinYear || year > MaxYear {
+		return apperror.InvalidInput("year", fmt.Sprintf("must be between %d and %d", MinYear, MaxYear))
+	}
+	return nil
+}
+
 func FormatMonthYear(t time.Time) string {
 	return t.Format(DateLayout)
 }
 
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func EndOfDay(t time.Time) time.Time {
+	return StartOfDay(t).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
 func StartOfMonth(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
 }
@@ -48,8 +180,8 @@ func EndOfMonth(t time.Time) time.Time {
 func ValidateDateRange(startDate, endDate *time.Time) error {
 	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
 		return apperror.InvalidDateRange(
-			FormatMonthYear(*startDate),
-			FormatMonthYear(*endDate),
+			DateFormatMonthYear.FormatStart(*startDate),
+			DateFormatMonthYear.FormatEnd(*endDate),
 		)
 	}
 	return nil
@@ -59,20 +191,18 @@ func ParseDateRange(startDateStr, endDateStr string) (*time.Time, *time.Time, er
 	var startDate, endDate *time.Time
 
 	if startDateStr != "" {
-		start, err := ParseMonthYear(startDateStr)
+		start, err := ParseStartDate(startDateStr)
 		if err != nil {
 			return nil, nil, err
 		}
-		start = StartOfMonth(start)
 		startDate = &start
 	}
 
 	if endDateStr != "" {
-		end, err := ParseMonthYear(endDateStr)
+		end, err := ParseEndDate(endDateStr)
 		if err != nil {
 			return nil, nil, err
 		}
-		end = EndOfMonth(end)
 		endDate = &end
 	}
 
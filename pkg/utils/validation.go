@@ -20,8 +20,11 @@ func ValidateUUID(id string, fieldName string) (uuid.UUID, error) {
 	return parsedUUID, nil
 }
 
+// ValidateServiceName проверяет название после SanitizeText: строка из
+// одних пробелов и невидимых символов считается пустой.
 func ValidateServiceName(serviceName string) error {
-	if strings.TrimSpace(serviceName) == "" {
+	serviceName = SanitizeText(serviceName)
+	if serviceName == "" {
 		return apperror.InvalidServiceName()
 	}
 	if len(serviceName) > 255 {
@@ -60,10 +63,6 @@ func ValidatePagination(limit, offset int) (int, int, error) {
 	return limit, offset, nil
 }
 
-func NormalizeString(s string) string {
-	return strings.TrimSpace(s)
-}
-
 func IsEmpty(s *string) bool {
 	return s == nil || strings.TrimSpace(*s) == ""
 }